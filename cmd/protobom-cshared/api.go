@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// The functions in this file implement the operations exported over the C
+// ABI. Documents cross the boundary encoded as protobuf.
+
+// parseDocument reads an SBOM in any supported format and returns the
+// protobom document encoded as protobuf.
+func parseDocument(input []byte) ([]byte, error) {
+	doc, err := reader.New().ParseStream(bytes.NewReader(input))
+	if err != nil {
+		return nil, fmt.Errorf("parsing document: %w", err)
+	}
+	data, err := proto.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling document: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalDocument decodes a protobuf-encoded document. The metadata and
+// node list are initialized if the document does not have them, as the
+// serializers expect them.
+func unmarshalDocument(data []byte) (*sbom.Document, error) {
+	if len(data) == 0 {
+		return nil, errors.New("empty document buffer")
+	}
+	doc := &sbom.Document{}
+	if err := proto.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling document: %w", err)
+	}
+	if doc.Metadata == nil {
+		doc.Metadata = &sbom.Metadata{}
+	}
+	if doc.NodeList == nil {
+		doc.NodeList = sbom.NewNodeList()
+	}
+	return doc, nil
+}
+
+// convertDocument renders a protobuf-encoded document in the specified
+// format.
+func convertDocument(data []byte, format string, indent int) ([]byte, error) {
+	doc, err := unmarshalDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{
+		Format:           formats.Format(format),
+		RenderOptions:    &native.RenderOptions{Indent: indent},
+		SerializeOptions: &native.SerializeOptions{},
+	}); err != nil {
+		return nil, fmt.Errorf("writing document: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// validationIssue is the JSON representation of a sbom.ValidationIssue
+type validationIssue struct {
+	NodeID  string `json:"nodeId,omitempty"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// validateDocument validates a protobuf-encoded document and returns the
+// issues found as a JSON array.
+func validateDocument(data []byte) ([]byte, error) {
+	doc, err := unmarshalDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	issues := []validationIssue{}
+	for _, i := range doc.Validate(nil) {
+		issues = append(issues, validationIssue{NodeID: i.NodeID, Field: i.Field, Message: i.Message})
+	}
+	out, err := json.Marshal(issues)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling issues: %w", err)
+	}
+	return out, nil
+}
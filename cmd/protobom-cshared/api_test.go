@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestParseDocument(t *testing.T) {
+	input, err := os.ReadFile("../../test/conformance/testdata/spdx/2.3/json/curl.spdx.json")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		input   []byte
+		mustErr bool
+	}{
+		{name: "spdx", input: input},
+		{name: "invalid input", input: []byte("not an sbom"), mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := parseDocument(tc.input)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			doc := &sbom.Document{}
+			require.NoError(t, proto.Unmarshal(data, doc))
+			require.NotEmpty(t, doc.GetNodeList().GetNodes())
+		})
+	}
+}
+
+func TestConvertDocument(t *testing.T) {
+	input, err := os.ReadFile("../../test/conformance/testdata/spdx/2.3/json/curl.spdx.json")
+	require.NoError(t, err)
+	parsed, err := parseDocument(input)
+	require.NoError(t, err)
+	// Documents without metadata can be converted
+	noMetadata, err := proto.Marshal(&sbom.Document{
+		NodeList: &sbom.NodeList{Nodes: []*sbom.Node{{Id: "app", Name: "app"}}, RootElements: []string{"app"}},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		format   string
+		contains string
+		mustErr  bool
+	}{
+		{name: "parsed document", data: parsed, format: string(formats.CDX15JSON), contains: `"bomFormat": "CycloneDX"`},
+		{name: "document without metadata", data: noMetadata, format: string(formats.CDX15JSON), contains: `"name": "app"`},
+		{name: "no data", format: string(formats.CDX15JSON), mustErr: true},
+		{name: "unknown format", data: parsed, format: "application/unknown", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := convertDocument(tc.data, tc.format, 2)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, string(out), tc.contains)
+		})
+	}
+}
+
+func TestValidateDocument(t *testing.T) {
+	input, err := os.ReadFile("../../test/conformance/testdata/spdx/2.3/json/curl.spdx.json")
+	require.NoError(t, err)
+	parsed, err := parseDocument(input)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		data    []byte
+		mustErr bool
+	}{
+		{name: "parsed document", data: parsed},
+		{name: "invalid protobuf", data: []byte{0xff}, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := validateDocument(tc.data)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			issues := []validationIssue{}
+			require.NoError(t, json.Unmarshal(out, &issues))
+		})
+	}
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build cgo
+
+// protobom-cshared exports the protobom conversion API as a C shared
+// library for bindings in other languages. Build it with:
+//
+//	go build -buildmode=c-shared -o libprotobom.so ./cmd/protobom-cshared
+//
+// which also writes the libprotobom.h header. Documents are exchanged
+// encoded as protobuf using the messages in api/sbom.proto:
+//
+//	int protobom_parse(const char *data, int len, char **out, int *out_len);
+//	int protobom_convert(const char *doc, int len, const char *format, int indent, char **out, int *out_len);
+//	int protobom_validate(const char *doc, int len, char **out, int *out_len);
+//	void protobom_free(void *ptr);
+//
+// protobom_parse reads an SBOM in any supported format and returns the
+// protobom document. protobom_convert renders a protobom document in the
+// specified format and protobom_validate returns a JSON array of the
+// issues found in a document.
+//
+// The functions return 0 on success with the result in out. On error they
+// return -1 and out holds the error message. In both cases out is
+// allocated by the library and must be released with protobom_free.
+package main
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+func main() {}
+
+//export protobom_parse
+func protobom_parse(data *C.char, length C.int, out **C.char, outLen *C.int) (ret C.int) {
+	defer recoverResult(&ret, out, outLen)
+	res, err := parseDocument(C.GoBytes(unsafe.Pointer(data), length))
+	return setResult(res, err, out, outLen)
+}
+
+//export protobom_convert
+func protobom_convert(doc *C.char, length C.int, format *C.char, indent C.int, out **C.char, outLen *C.int) (ret C.int) {
+	defer recoverResult(&ret, out, outLen)
+	res, err := convertDocument(C.GoBytes(unsafe.Pointer(doc), length), C.GoString(format), int(indent))
+	return setResult(res, err, out, outLen)
+}
+
+//export protobom_validate
+func protobom_validate(doc *C.char, length C.int, out **C.char, outLen *C.int) (ret C.int) {
+	defer recoverResult(&ret, out, outLen)
+	res, err := validateDocument(C.GoBytes(unsafe.Pointer(doc), length))
+	return setResult(res, err, out, outLen)
+}
+
+//export protobom_free
+func protobom_free(ptr unsafe.Pointer) {
+	C.free(ptr)
+}
+
+// recoverResult is deferred by the exported functions to return a panic as
+// an error. A panic reaching the C caller aborts the host process.
+func recoverResult(ret *C.int, out **C.char, outLen *C.int) {
+	if r := recover(); r != nil {
+		*ret = setResult(nil, fmt.Errorf("internal error: %v", r), out, outLen)
+	}
+}
+
+// setResult copies the result, or the error message, to a buffer allocated
+// in the C heap and returns the status code.
+func setResult(data []byte, err error, out **C.char, outLen *C.int) C.int {
+	ret := C.int(0)
+	if err != nil {
+		data = []byte(err.Error())
+		ret = -1
+	}
+	// Allocate an extra byte to NUL-terminate error messages
+	buf := C.malloc(C.size_t(len(data) + 1))
+	copy(unsafe.Slice((*byte)(buf), len(data)+1), append(data, 0))
+	*out = (*C.char)(buf)
+	*outLen = C.int(len(data))
+	return ret
+}
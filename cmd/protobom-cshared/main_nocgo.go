@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !cgo
+
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	fmt.Fprintln(os.Stderr, "protobom-cshared must be built with cgo enabled and -buildmode=c-shared")
+	os.Exit(1)
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// The functions in this file implement the operations exposed to
+// JavaScript. They are kept free of syscall/js so they can be tested on
+// any platform.
+
+// detectFormat returns the format of an SBOM
+func detectFormat(input []byte) (string, error) {
+	format, err := (&formats.Sniffer{}).SniffReader(bytes.NewReader(input))
+	if err != nil {
+		return "", fmt.Errorf("detecting format: %w", err)
+	}
+	return string(format), nil
+}
+
+// parse reads an SBOM in any supported format
+func parse(input []byte) (*sbom.Document, error) {
+	doc, err := reader.New().ParseStream(bytes.NewReader(input))
+	if err != nil {
+		return nil, fmt.Errorf("parsing document: %w", err)
+	}
+	return doc, nil
+}
+
+// toProtobomJSON returns the protobom representation of an SBOM as JSON,
+// for viewers to browse the graph without knowing the native formats.
+func toProtobomJSON(input []byte) (string, error) {
+	doc, err := parse(input)
+	if err != nil {
+		return "", err
+	}
+	data, err := protojson.Marshal(doc)
+	if err != nil {
+		return "", fmt.Errorf("marshaling document: %w", err)
+	}
+	return string(data), nil
+}
+
+// convert translates an SBOM to the specified format
+func convert(input []byte, format string, indent int) (string, error) {
+	doc, err := parse(input)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{
+		Format:           formats.Format(format),
+		RenderOptions:    &native.RenderOptions{Indent: indent},
+		SerializeOptions: &native.SerializeOptions{},
+	}); err != nil {
+		return "", fmt.Errorf("writing document: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// listFormats returns the formats that documents can be converted to
+func listFormats() []string {
+	ret := []string{}
+	for _, f := range writer.ListSerializers() {
+		ret = append(ret, string(f))
+	}
+	return ret
+}
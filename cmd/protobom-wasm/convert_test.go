@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+)
+
+func TestConvert(t *testing.T) {
+	input, err := os.ReadFile("../../test/conformance/testdata/spdx/2.3/json/curl.spdx.json")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		input    []byte
+		format   string
+		contains string
+		mustErr  bool
+	}{
+		{name: "spdx to cyclonedx", input: input, format: string(formats.CDX15JSON), contains: `"bomFormat": "CycloneDX"`},
+		{name: "spdx to spdx", input: input, format: string(formats.SPDX23JSON), contains: `"spdxVersion": "SPDX-2.3"`},
+		{name: "unknown format", input: input, format: "application/unknown", mustErr: true},
+		{name: "invalid input", input: []byte("not an sbom"), format: string(formats.CDX15JSON), mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := convert(tc.input, tc.format, 2)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, out, tc.contains)
+		})
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		path     string
+		expected formats.Format
+	}{
+		{name: "spdx", path: "../../test/conformance/testdata/spdx/2.3/json/curl.spdx.json", expected: formats.SPDX23JSON},
+		{name: "cyclonedx", path: "../../test/conformance/testdata/cyclonedx/1.5/json/bom-1.5.json", expected: formats.CDX15JSON},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			input, err := os.ReadFile(tc.path)
+			require.NoError(t, err)
+			format, err := detectFormat(input)
+			require.NoError(t, err)
+			require.Equal(t, string(tc.expected), format)
+
+			js, err := toProtobomJSON(input)
+			require.NoError(t, err)
+			require.Contains(t, js, `"nodeList"`)
+		})
+	}
+}
+
+func TestListFormats(t *testing.T) {
+	for _, f := range []formats.Format{formats.SPDX23JSON, formats.CDX15JSON} {
+		t.Run(string(f), func(t *testing.T) {
+			require.Contains(t, listFormats(), string(f))
+		})
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build js && wasm
+
+// protobom-wasm exposes the protobom reader and writer to JavaScript. Build
+// it with:
+//
+//	GOOS=js GOARCH=wasm go build -o protobom.wasm ./cmd/protobom-wasm
+//
+// and load it with the wasm_exec.js shim shipped with Go. The module
+// registers a global protobom object:
+//
+//	protobom.detectFormat(sbom)          the format of the document
+//	protobom.convert(sbom, format, indent) the document in another format
+//	protobom.toJSON(sbom)                the protobom document as JSON
+//	protobom.formats()                   the formats available to convert
+//
+// Each function returns an object with the result in its value property,
+// or the error message in its error property.
+package main
+
+import (
+	"errors"
+	"syscall/js"
+)
+
+func main() {
+	js.Global().Set("protobom", js.ValueOf(map[string]any{
+		"detectFormat": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			if len(args) < 1 {
+				return result(nil, errMissingArgs)
+			}
+			return result(detectFormat([]byte(args[0].String())))
+		}),
+		"convert": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			if len(args) < 2 {
+				return result(nil, errMissingArgs)
+			}
+			indent := 0
+			if len(args) > 2 && args[2].Type() == js.TypeNumber {
+				indent = args[2].Int()
+			}
+			return result(convert([]byte(args[0].String()), args[1].String(), indent))
+		}),
+		"toJSON": js.FuncOf(func(_ js.Value, args []js.Value) any {
+			if len(args) < 1 {
+				return result(nil, errMissingArgs)
+			}
+			return result(toProtobomJSON([]byte(args[0].String())))
+		}),
+		"formats": js.FuncOf(func(js.Value, []js.Value) any {
+			list := []any{}
+			for _, f := range listFormats() {
+				list = append(list, f)
+			}
+			return result(list, nil)
+		}),
+	}))
+
+	// Keep the module alive to serve calls from JavaScript
+	select {}
+}
+
+var errMissingArgs = errors.New("missing arguments")
+
+// result returns the value or error to JavaScript
+func result(value any, err error) any {
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{"value": value}
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/testkit"
+)
+
+const usage = `usage: protobom <command> [flags]
+
+Commands:
+  conformance   Report which format conversions are lossless for a document
+`
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches the subcommand in args
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	switch args[0] {
+	case "conformance":
+		return runConformance(args[1:], out)
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
+
+// formatList is a flag accepting a comma separated list of formats
+type formatList []formats.Format
+
+func (fl *formatList) String() string {
+	return fmt.Sprint(*fl)
+}
+
+func (fl *formatList) Set(v string) error {
+	for _, f := range strings.Split(v, ",") {
+		*fl = append(*fl, formats.Format(strings.TrimSpace(f)))
+	}
+	return nil
+}
+
+// runConformance runs a document through all the round-trippable formats
+// and prints the conversion matrix.
+func runConformance(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("conformance", flag.ContinueOnError)
+	jsonOutput := fs.Bool("json", false, "output the report as JSON")
+	verbose := fs.Bool("v", false, "list the differences of lossy conversions")
+	targets := formatList{}
+	fs.Var(&targets, "format", "comma separated list of formats to check (default all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: protobom conformance [-json] [-v] [-format f1,f2] FILE")
+	}
+
+	doc, err := reader.New().ParseFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("reading document: %w", err)
+	}
+
+	report := testkit.CheckConformance(doc, &testkit.ConformanceOptions{Formats: targets})
+
+	if *jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FORMAT\tSTATUS\tDETAILS")
+	for _, r := range report.Results {
+		details := r.Error
+		if r.Status == testkit.StatusLossy {
+			details = fmt.Sprintf("%d differences", len(r.Differences))
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", r.Format, r.Status, details)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if *verbose {
+		for _, r := range report.Results {
+			for _, d := range r.Differences {
+				fmt.Fprintf(out, "%s: %s\n", r.Format, d)
+			}
+		}
+	}
+	return nil
+}
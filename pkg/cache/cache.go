@@ -0,0 +1,80 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package cache provides the caches used to avoid repeating lookups to
+// external services, such as fetching the SBOMs of container images or the
+// SPDX license list.
+//
+// Entries expire after the TTL set when they are stored. A cache primed in
+// a previous run can be used to work fully offline: see GetOrLoad and
+// ErrOffline.
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrOffline is returned by GetOrLoad when an entry is not cached and
+// loading it is disabled with a nil loader.
+var ErrOffline = errors.New("entry not cached and remote lookups are disabled")
+
+// Cache stores byte values by key. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the value stored under key. The boolean is false if the
+	// key is not cached or its entry expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores value under key. The entry expires after ttl, a zero ttl
+	// keeps it until it is deleted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the entry of key, if any
+	Delete(ctx context.Context, key string) error
+}
+
+// LoadFunc loads the value of a missing cache entry
+type LoadFunc func(ctx context.Context) ([]byte, error)
+
+// GetOrLoad returns the value cached under key. On a miss, it calls load and
+// caches the result for ttl. If load is nil, a miss returns ErrOffline.
+func GetOrLoad(ctx context.Context, c Cache, key string, ttl time.Duration, load LoadFunc) ([]byte, error) {
+	data, ok, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("reading cache entry: %w", err)
+	}
+	if ok {
+		return data, nil
+	}
+	if load == nil {
+		return nil, fmt.Errorf("%s: %w", key, ErrOffline)
+	}
+	data, err = load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Set(ctx, key, data, ttl); err != nil {
+		return nil, fmt.Errorf("writing cache entry: %w", err)
+	}
+	return data, nil
+}
+
+// expiry returns the expiration time of an entry stored at now with ttl.
+// Entries without ttl have a zero expiration time.
+func expiry(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// expired returns true if an entry expiring at exp is stale at now
+func expired(exp, now time.Time) bool {
+	return !exp.IsZero() && !now.Before(exp)
+}
@@ -0,0 +1,188 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaches(t *testing.T) {
+	ctx := context.Background()
+	for name, newCache := range map[string]func(t *testing.T, now func() time.Time) Cache{
+		"memory": func(_ *testing.T, now func() time.Time) Cache {
+			m := NewMemory()
+			m.now = now
+			return m
+		},
+		"disk": func(t *testing.T, now func() time.Time) Cache {
+			d := NewDisk(t.TempDir())
+			d.now = now
+			return d
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			c := newCache(t, func() time.Time { return clock })
+
+			_, ok, err := c.Get(ctx, "missing")
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			require.NoError(t, c.Set(ctx, "forever", []byte("a"), 0))
+			require.NoError(t, c.Set(ctx, "hour", []byte("b"), time.Hour))
+			require.NoError(t, c.Set(ctx, "empty", []byte{}, 0))
+
+			data, ok, err := c.Get(ctx, "hour")
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, []byte("b"), data)
+
+			_, ok, err = c.Get(ctx, "empty")
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			clock = clock.Add(time.Hour)
+			_, ok, err = c.Get(ctx, "hour")
+			require.NoError(t, err)
+			require.False(t, ok)
+
+			data, ok, err = c.Get(ctx, "forever")
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, []byte("a"), data)
+
+			require.NoError(t, c.Delete(ctx, "forever"))
+			require.NoError(t, c.Delete(ctx, "forever"))
+			_, ok, err = c.Get(ctx, "forever")
+			require.NoError(t, err)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestDiskPersists(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		ttl time.Duration
+		// elapsed is the time between storing and reading the key
+		elapsed time.Duration
+		found   bool
+	}{
+		"within ttl":    {ttl: time.Hour, elapsed: time.Minute, found: true},
+		"no expiration": {elapsed: 24 * time.Hour, found: true},
+		"expired":       {ttl: time.Hour, elapsed: time.Hour},
+	} {
+		t.Run(name, func(t *testing.T) {
+			clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+			dir := t.TempDir()
+			d := NewDisk(dir)
+			d.now = func() time.Time { return clock }
+			require.NoError(t, d.Set(ctx, "key", []byte("value"), tc.ttl))
+
+			d = NewDisk(dir)
+			d.now = func() time.Time { return clock.Add(tc.elapsed) }
+			data, ok, err := d.Get(ctx, "key")
+			require.NoError(t, err)
+			require.Equal(t, tc.found, ok)
+			if tc.found {
+				require.Equal(t, []byte("value"), data)
+			}
+		})
+	}
+}
+
+func TestGetOrLoad(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		cached map[string]string
+		key    string
+		// load is nil for offline lookups
+		load    LoadFunc
+		lookups int
+		data    string
+		calls   int
+		errIs   error
+		mustErr bool
+		stored  bool
+	}{
+		"loaded once": {
+			key:     "key",
+			load:    func(context.Context) ([]byte, error) { return []byte("loaded"), nil },
+			lookups: 2,
+			data:    "loaded",
+			calls:   1,
+			stored:  true,
+		},
+		"cached": {
+			cached:  map[string]string{"key": "cached"},
+			key:     "key",
+			load:    func(context.Context) ([]byte, error) { return []byte("loaded"), nil },
+			lookups: 1,
+			data:    "cached",
+			stored:  true,
+		},
+		"offline cached": {
+			cached:  map[string]string{"key": "cached"},
+			key:     "key",
+			lookups: 1,
+			data:    "cached",
+			stored:  true,
+		},
+		"offline miss": {
+			cached:  map[string]string{"key": "cached"},
+			key:     "other",
+			lookups: 1,
+			errIs:   ErrOffline,
+		},
+		"failed loads are not cached": {
+			key:     "failing",
+			load:    func(context.Context) ([]byte, error) { return nil, errors.New("service down") },
+			lookups: 2,
+			calls:   2,
+			mustErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := NewMemory()
+			for k, v := range tc.cached {
+				require.NoError(t, c.Set(ctx, k, []byte(v), 0))
+			}
+			calls := 0
+			var load LoadFunc
+			if tc.load != nil {
+				load = func(ctx context.Context) ([]byte, error) {
+					calls++
+					return tc.load(ctx)
+				}
+			}
+
+			for range tc.lookups {
+				data, err := GetOrLoad(ctx, c, tc.key, 0, load)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+					continue
+				}
+				if tc.mustErr {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, []byte(tc.data), data)
+			}
+			require.Equal(t, tc.calls, calls)
+
+			_, ok, err := c.Get(ctx, tc.key)
+			require.NoError(t, err)
+			require.Equal(t, tc.stored, ok)
+		})
+	}
+}
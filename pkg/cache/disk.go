@@ -0,0 +1,112 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+var _ Cache = (*Disk)(nil)
+
+// diskHeaderSize is the size of the expiration time prefixed to the entries
+const diskHeaderSize = 8
+
+// Disk is a Cache that stores its entries as files in a directory, so they
+// persist across runs. The directory can be primed in a connected
+// environment and copied to run offline.
+type Disk struct {
+	// Path is the directory holding the cache entries. It is created when
+	// the first entry is stored.
+	Path string
+
+	now func() time.Time
+}
+
+// NewDisk returns a cache storing its entries in the directory path
+func NewDisk(path string) *Disk {
+	return &Disk{
+		Path: path,
+		now:  time.Now,
+	}
+}
+
+// entryPath returns the path of the file storing the entry of key
+func (d *Disk) entryPath(key string) string {
+	return filepath.Join(d.Path, fmt.Sprintf("%x", sha256.Sum256([]byte(key))))
+}
+
+// Get implements Cache
+func (d *Disk) Get(_ context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(d.entryPath(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("reading cache file: %w", err)
+	}
+	if len(data) < diskHeaderSize {
+		return nil, false, fmt.Errorf("cache file of %q is truncated", key)
+	}
+
+	var expires time.Time
+	if nanos := int64(binary.BigEndian.Uint64(data)); nanos != 0 { //nolint:gosec
+		expires = time.Unix(0, nanos)
+	}
+	if expired(expires, d.now()) {
+		return nil, false, d.Delete(context.Background(), key)
+	}
+	return data[diskHeaderSize:], true, nil
+}
+
+// Set implements Cache. Entries are written to a temporary file first and
+// renamed, so readers never see a partial entry.
+func (d *Disk) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := os.MkdirAll(d.Path, 0o755); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	var nanos uint64
+	if exp := expiry(d.now(), ttl); !exp.IsZero() {
+		nanos = uint64(exp.UnixNano()) //nolint:gosec
+	}
+	data := binary.BigEndian.AppendUint64(make([]byte, 0, diskHeaderSize+len(value)), nanos)
+	data = append(data, value...)
+
+	f, err := os.CreateTemp(d.Path, ".entry-*")
+	if err != nil {
+		return fmt.Errorf("creating cache file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()           //nolint:errcheck,gosec
+		os.Remove(f.Name()) //nolint:errcheck,gosec
+		return fmt.Errorf("writing cache file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck,gosec
+		return fmt.Errorf("closing cache file: %w", err)
+	}
+	if err := os.Rename(f.Name(), d.entryPath(key)); err != nil {
+		os.Remove(f.Name()) //nolint:errcheck,gosec
+		return fmt.Errorf("storing cache file: %w", err)
+	}
+	return nil
+}
+
+// Delete implements Cache
+func (d *Disk) Delete(_ context.Context, key string) error {
+	if err := os.Remove(d.entryPath(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing cache file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package cache
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+var _ Cache = (*Memory)(nil)
+
+type memoryEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+// Memory is a Cache that keeps its entries in memory. Its contents are lost
+// when the process exits.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+// NewMemory returns an empty in-memory cache
+func NewMemory() *Memory {
+	return &Memory{
+		entries: map[string]memoryEntry{},
+		now:     time.Now,
+	}
+}
+
+// Get implements Cache
+func (m *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	if expired(e.expires, m.now()) {
+		delete(m.entries, key)
+		return nil, false, nil
+	}
+	return slices.Clone(e.data), true, nil
+}
+
+// Set implements Cache
+func (m *Memory) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[key] = memoryEntry{
+		data:    slices.Clone(value),
+		expires: expiry(m.now(), ttl),
+	}
+	return nil
+}
+
+// Delete implements Cache
+func (m *Memory) Delete(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
@@ -8,6 +8,43 @@ import (
 	"github.com/protobom/protobom/pkg/formats"
 )
 
+// Names of the CycloneDX metadata properties used to record document data
+// that has no native field in CycloneDX.
+const (
+	PropertyRevisionReason         = "protobom:revision:reason"
+	PropertyDocumentComment        = "protobom:document:comment"
+	PropertyDocumentCreatorComment = "protobom:document:creator_comment"
+)
+
+// Names of the CycloneDX component properties used to record node text
+// fields that have no native field in CycloneDX.
+const (
+	PropertyNodeSummary         = "protobom:node:summary"
+	PropertyNodeComment         = "protobom:node:comment"
+	PropertyNodeSourceInfo      = "protobom:node:source_info"
+	PropertyNodeLicenseComments = "protobom:node:license_comments"
+)
+
+// PropertyLicenseID is the property of the CycloneDX named licenses that
+// records the LicenseRef-* identifier of a custom license when it can't be
+// derived from the license name. License properties are only encoded in
+// CycloneDX 1.5 and later.
+const PropertyLicenseID = "protobom:license:id"
+
+// PropertyHashPrefix prefixes the names of the component properties that
+// record hashes computed with algorithms not supported by CycloneDX. The
+// prefix is followed by the protobom algorithm name, eg protobom:hash:SSDEEP
+const PropertyHashPrefix = "protobom:hash:"
+
+// Names of the properties of the CycloneDX tool components used to record
+// the signature of the tool binaries. Tool hashes are native to CycloneDX
+// but components can't carry JSF signatures.
+const (
+	PropertyToolSignatureAlgorithm = "protobom:tool:signature:algorithm"
+	PropertyToolSignatureKeyID     = "protobom:tool:signature:key_id"
+	PropertyToolSignatureValue     = "protobom:tool:signature:value"
+)
+
 func ParseVersion(version string) (cyclonedx.SpecVersion, error) {
 	var specVersion cyclonedx.SpecVersion
 	switch version {
@@ -17,6 +17,7 @@ const (
 	SPDX23JSON = Format("text/spdx+json;version=2.3")
 	SPDX22TV   = Format("text/spdx+text;version=2.2")
 	SPDX22JSON = Format("text/spdx+json;version=2.2")
+	SPDX30JSON = Format("text/spdx+json;version=3.0")
 	CDX10JSON  = Format("application/vnd.cyclonedx+json;version=1.0")
 	CDX11JSON  = Format("application/vnd.cyclonedx+json;version=1.1")
 	CDX12JSON  = Format("application/vnd.cyclonedx+json;version=1.2")
@@ -24,8 +25,23 @@ const (
 	CDX14JSON  = Format("application/vnd.cyclonedx+json;version=1.4")
 	CDX15JSON  = Format("application/vnd.cyclonedx+json;version=1.5")
 	CDX16JSON  = Format("application/vnd.cyclonedx+json;version=1.6")
+	CDX14XML   = Format("application/vnd.cyclonedx+xml;version=1.4")
+	CDX15XML   = Format("application/vnd.cyclonedx+xml;version=1.5")
+	CDX16XML   = Format("application/vnd.cyclonedx+xml;version=1.6")
 	CDXFORMAT  = "cyclonedx"
 	SPDXFORMAT = "spdx"
+
+	// HTMLReport is a write-only format that renders a human-readable
+	// report of the document.
+	HTMLReport = Format("text/html;profile=protobom-report")
+
+	// MarkdownReport is a write-only format that renders a compact summary
+	// of the document, suitable for pull request comments.
+	MarkdownReport = Format("text/markdown;profile=protobom-report")
+
+	// GitHubSnapshotJSON is a write-only format for the snapshots submitted
+	// to the GitHub dependency submission API.
+	GitHubSnapshotJSON = Format("application/vnd.github.dependency-snapshot+json;version=0")
 )
 
 type Document interface{}
@@ -78,6 +94,8 @@ func (f *Format) Encoding() string {
 	switch {
 	case strings.Contains(string(*f), JSON):
 		return JSON
+	case strings.Contains(string(*f), XML):
+		return XML
 	case strings.Contains(string(*f), TEXT):
 		return TEXT
 	default:
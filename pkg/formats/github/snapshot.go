@@ -0,0 +1,66 @@
+// Package github defines the documents exchanged with the GitHub
+// dependency graph APIs.
+package github
+
+// Relationships of the resolved dependencies in a manifest
+const (
+	RelationshipDirect   = "direct"
+	RelationshipIndirect = "indirect"
+)
+
+// Scopes of the resolved dependencies in a manifest
+const (
+	ScopeRuntime     = "runtime"
+	ScopeDevelopment = "development"
+)
+
+// Snapshot is the document submitted to the GitHub dependency submission
+// API. It lists the dependencies of a repository at a commit, grouped by
+// the manifest declaring them.
+type Snapshot struct {
+	Version   int                  `json:"version"`
+	Sha       string               `json:"sha"`
+	Ref       string               `json:"ref"`
+	Job       *Job                 `json:"job"`
+	Detector  *Detector            `json:"detector"`
+	Scanned   string               `json:"scanned"`
+	Metadata  map[string]any       `json:"metadata,omitempty"`
+	Manifests map[string]*Manifest `json:"manifests"`
+}
+
+// Job identifies the workflow run that produced the snapshot. Snapshots
+// with the same correlator replace each other.
+type Job struct {
+	Correlator string `json:"correlator"`
+	ID         string `json:"id"`
+	HTMLURL    string `json:"html_url,omitempty"`
+}
+
+// Detector describes the tool that built the snapshot
+type Detector struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	URL     string `json:"url"`
+}
+
+// Manifest is a collection of dependencies, usually a lock file
+type Manifest struct {
+	Name     string                  `json:"name"`
+	File     *ManifestFile           `json:"file,omitempty"`
+	Metadata map[string]any          `json:"metadata,omitempty"`
+	Resolved map[string]*ResolvedDep `json:"resolved"`
+}
+
+// ManifestFile points to the manifest file in the repository
+type ManifestFile struct {
+	SourceLocation string `json:"source_location"`
+}
+
+// ResolvedDep is a dependency resolved in a manifest
+type ResolvedDep struct {
+	PackageURL   string         `json:"package_url"`
+	Metadata     map[string]any `json:"metadata,omitempty"`
+	Relationship string         `json:"relationship,omitempty"`
+	Scope        string         `json:"scope,omitempty"`
+	Dependencies []string       `json:"dependencies"`
+}
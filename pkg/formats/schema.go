@@ -0,0 +1,111 @@
+package formats
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+)
+
+// schemaDir is the directory of the embedded schemas
+const schemaDir = "schemas"
+
+// schemaFS holds the JSON schemas of the formats. The CycloneDX schemas
+// reference the SPDX license list and JSF schemas stored next to them.
+//
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// ErrNoSchema is returned when there is no embedded schema for a format.
+// Only the CycloneDX JSON formats have one, the SPDX schemas are not
+// embedded yet.
+var ErrNoSchema = errors.New("no schema available")
+
+// schemaFiles maps the formats to their schema file. The SPDX formats are
+// not covered.
+var schemaFiles = map[Format]string{
+	CDX12JSON: "bom-1.2.schema.json",
+	CDX13JSON: "bom-1.3.schema.json",
+	CDX14JSON: "bom-1.4.schema.json",
+	CDX15JSON: "bom-1.5.schema.json",
+	CDX16JSON: "bom-1.6.schema.json",
+}
+
+// SchemaInfo describes the schema of a format
+type SchemaInfo struct {
+	// Format is the format validated by the schema
+	Format Format `json:"format"`
+
+	// Type and Version identify the specification, eg "cyclonedx" "1.6"
+	Type    string `json:"type"`
+	Version string `json:"version"`
+
+	// File is the path of the schema in SchemaFS
+	File string `json:"file"`
+
+	// ID is the schema $id and Draft the JSON schema version it uses
+	ID    string `json:"id"`
+	Draft string `json:"draft"`
+}
+
+// Schema returns the JSON schema of a format. Formats without an embedded
+// schema, including all the SPDX formats, return an error wrapping
+// ErrNoSchema.
+func Schema(f Format) ([]byte, error) {
+	file, ok := schemaFiles[f]
+	if !ok {
+		if f.Type() == SPDXFORMAT {
+			return nil, fmt.Errorf("%w for format %q: SPDX schemas are not embedded, only CycloneDX JSON is covered", ErrNoSchema, f)
+		}
+		return nil, fmt.Errorf("%w for format %q", ErrNoSchema, f)
+	}
+	data, err := schemaFS.ReadFile(path.Join(schemaDir, file))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s schema: %w", f, err)
+	}
+	return data, nil
+}
+
+// SchemaFS returns a filesystem with the embedded schemas, including the
+// schemas they reference, to resolve the references when validating.
+func SchemaFS() fs.FS {
+	sub, err := fs.Sub(schemaFS, schemaDir)
+	if err != nil {
+		// The directory is embedded, this can't happen
+		panic(err)
+	}
+	return sub
+}
+
+// SchemaFormats returns the formats with an embedded schema, currently only
+// the CycloneDX JSON formats
+func SchemaFormats() []Format {
+	return slices.Sorted(maps.Keys(schemaFiles))
+}
+
+// GetSchemaInfo returns the description of the schema of a format
+func GetSchemaInfo(f Format) (*SchemaInfo, error) {
+	data, err := Schema(f)
+	if err != nil {
+		return nil, err
+	}
+	header := struct {
+		ID     string `json:"$id"`
+		Schema string `json:"$schema"`
+	}{}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("parsing %s schema: %w", f, err)
+	}
+	return &SchemaInfo{
+		Format:  f,
+		Type:    f.Type(),
+		Version: f.Version(),
+		File:    schemaFiles[f],
+		ID:      header.ID,
+		Draft:   header.Schema,
+	}, nil
+}
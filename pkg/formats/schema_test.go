@@ -0,0 +1,55 @@
+package formats
+
+import (
+	"encoding/json"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchema(t *testing.T) {
+	require.Equal(t, []Format{CDX12JSON, CDX13JSON, CDX14JSON, CDX15JSON, CDX16JSON}, SchemaFormats())
+
+	for _, f := range SchemaFormats() {
+		data, err := Schema(f)
+		require.NoError(t, err)
+		require.True(t, json.Valid(data))
+
+		info, err := GetSchemaInfo(f)
+		require.NoError(t, err)
+		require.Equal(t, CDXFORMAT, info.Type)
+		require.Equal(t, f.Version(), info.Version)
+		require.Contains(t, info.ID, "http://cyclonedx.org/schema/bom-"+f.Version())
+		require.Equal(t, "http://json-schema.org/draft-07/schema#", info.Draft)
+
+		// The schema file is also available in the filesystem
+		_, err = fs.Stat(SchemaFS(), info.File)
+		require.NoError(t, err)
+	}
+
+	// Schemas referenced by the CycloneDX schemas
+	for _, file := range []string{"spdx.schema.json", "jsf-0.82.schema.json"} {
+		_, err := fs.Stat(SchemaFS(), file)
+		require.NoError(t, err)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		format   Format
+		contains string
+	}{
+		{"spdx json", SPDX23JSON, "SPDX schemas are not embedded"},
+		{"spdx tag-value", SPDX23TV, "SPDX schemas are not embedded"},
+		{"cyclonedx xml", CDX16XML, "no schema available"},
+		{"unknown", Format("unknown"), "no schema available"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Schema(tc.format)
+			require.ErrorIs(t, err, ErrNoSchema)
+			require.ErrorContains(t, err, tc.contains)
+			_, err = GetSchemaInfo(tc.format)
+			require.ErrorIs(t, err, ErrNoSchema)
+		})
+	}
+}
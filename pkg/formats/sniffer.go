@@ -2,17 +2,47 @@ package formats
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
+	"unicode"
 )
 
 const (
 	EmptyFormat = Format("")
 )
 
+var (
+	// ErrUnknownFormat is returned when the format of the data can't be
+	// determined.
+	ErrUnknownFormat = errors.New("unknown SBOM format")
+
+	// ErrUnsupportedFormat is returned when the data is in a known format
+	// protobom can't read, such as the syft JSON format.
+	ErrUnsupportedFormat = errors.New("unsupported SBOM format")
+
+	// errNotJSON signals the sniffer to fall back to the text formats
+	errNotJSON = errors.New("data is not JSON")
+
+	// errNotXML signals the sniffer to fall back to the text formats
+	errNotXML = errors.New("data is not XML")
+)
+
+// utf8BOM is the byte order mark some tools write at the start of files
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// cdxSchemaRe matches the URLs of the CycloneDX JSON schemas
+var cdxSchemaRe = regexp.MustCompile(`cyclonedx\.org/schema/bom-(\d+\.\d+)\.schema\.json`)
+
+// cdxNamespaceRe matches the XML namespaces of the CycloneDX documents
+var cdxNamespaceRe = regexp.MustCompile(`^https?://cyclonedx\.org/schema/bom/(\d+\.\d+)$`)
+
 var sniffFormats = []sniffFormat{
 	cdxSniff{},
 	spdxSniff{},
@@ -53,53 +83,37 @@ func (fs *Sniffer) SniffReader(f io.ReadSeeker) (Format, error) {
 		}
 	}()
 
-	type SpecVersionStruct struct {
-		BomFormat       string `json:"bomFormat"`
-		CDXSpecVersion  string `json:"specVersion"`
-		SPDXSpecVersion string `json:"spdxVersion"`
-	}
-
-	decoder := json.NewDecoder(f)
-
-	var specversionjson SpecVersionStruct
-	err := decoder.Decode(&specversionjson)
-	if err == nil {
-		if strings.EqualFold(specversionjson.BomFormat, CDXFORMAT) {
-			switch specversionjson.CDXSpecVersion {
-			case "1.3":
-				return CDX13JSON, nil
-			case "1.4":
-				return CDX14JSON, nil
-			case "1.5":
-				return CDX15JSON, nil
-			case "1.6":
-				return CDX16JSON, nil
-			default:
-				// JSON + BomFormat CycloneDX but specVersion not 1.3, 1.4, 1.5, or 1.6
-				return "", fmt.Errorf("unknown SBOM format")
-			}
-		} else {
-			// JSON but not CycloneDX so assuming SPDX
-			switch specversionjson.SPDXSpecVersion {
-			case "SPDX-2.2":
-				return SPDX22JSON, nil
-			case "SPDX-2.3":
-				return SPDX23JSON, nil
-			default:
-				// JSON + not CycloneDX but spdxVersion not SPDX-2.2 or SPDX-2.3
-				return "", fmt.Errorf("unknown SBOM format")
-			}
-		}
+	r, first, err := openSniffReader(f)
+	if err != nil {
+		return "", err
 	}
 
-	// not JSON.  Parse line-by-line with string hacks
+	// JSON documents are identified by their top level keys
+	if first == '{' {
+		format, err := sniffJSON(r)
+		if err == nil || !errors.Is(err, errNotJSON) {
+			return format, err
+		}
+		r, _, err = openSniffReader(f)
+		if err != nil {
+			return "", err
+		}
+	}
 
-	_, err = f.Seek(0, 0)
-	if err != nil {
-		return "", fmt.Errorf("seeking to the beginning of SBOM file: %w", err)
+	// XML documents are identified by their root element
+	if first == '<' {
+		format, err := sniffXML(r)
+		if err == nil || !errors.Is(err, errNotXML) {
+			return format, err
+		}
+		r, _, err = openSniffReader(f)
+		if err != nil {
+			return "", err
+		}
 	}
 
-	fileScanner := bufio.NewScanner(f)
+	// not JSON or XML.  Parse line-by-line with string hacks
+	fileScanner := bufio.NewScanner(r)
 	fileScanner.Split(bufio.ScanLines)
 
 	var format Format
@@ -117,8 +131,186 @@ func (fs *Sniffer) SniffReader(f io.ReadSeeker) (Format, error) {
 		return format, nil
 	}
 
-	// TODO(puerco): Implement a light parser in case the string hacks don't work
-	return "", fmt.Errorf("unknown SBOM format")
+	return "", ErrUnknownFormat
+}
+
+// openSniffReader rewinds f and returns a reader positioned after the UTF-8
+// byte order mark, if any, and the first non blank character of the data.
+func openSniffReader(f io.ReadSeeker) (*bufio.Reader, byte, error) {
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, 0, fmt.Errorf("seeking to the beginning of SBOM file: %w", err)
+	}
+	r := bufio.NewReader(f)
+	if bom, err := r.Peek(len(utf8BOM)); err == nil && bytes.Equal(bom, utf8BOM) {
+		r.Discard(len(utf8BOM)) //nolint:errcheck,gosec // The bytes were peeked
+	}
+	for i := 1; ; i++ {
+		data, err := r.Peek(i)
+		if len(data) < i {
+			if err != nil && !errors.Is(err, io.EOF) {
+				return nil, 0, fmt.Errorf("reading SBOM data: %w", err)
+			}
+			return r, 0, nil
+		}
+		if c := data[i-1]; !unicode.IsSpace(rune(c)) {
+			return r, c, nil
+		}
+	}
+}
+
+// jsonSniffKeys are the top level keys that identify the JSON formats
+type jsonSniffKeys struct {
+	BomFormat       string
+	CDXSpecVersion  string
+	SPDXSpecVersion string
+	Schema          string
+	Artifacts       bool
+	Descriptor      bool
+}
+
+// format returns the format identified by the keys found so far. It
+// returns an empty format while the keys are not conclusive.
+func (k *jsonSniffKeys) format() (Format, error) {
+	switch {
+	case strings.EqualFold(k.BomFormat, CDXFORMAT) && k.CDXSpecVersion != "":
+		return cdxJSONFormat(k.CDXSpecVersion)
+	case k.SPDXSpecVersion != "":
+		switch k.SPDXSpecVersion {
+		case "SPDX-2.2":
+			return SPDX22JSON, nil
+		case "SPDX-2.3":
+			return SPDX23JSON, nil
+		default:
+			// JSON + spdxVersion but not SPDX-2.2 or SPDX-2.3
+			return "", fmt.Errorf("%w: unsupported SPDX version %q", ErrUnknownFormat, k.SPDXSpecVersion)
+		}
+	case k.Artifacts && k.Descriptor:
+		return "", fmt.Errorf("%w: syft JSON", ErrUnsupportedFormat)
+	}
+	return EmptyFormat, nil
+}
+
+// sniffJSON scans the keys of the top level object of a JSON document, in
+// any order, until they identify the format. Only the values of the keys
+// used to identify the formats are decoded, the rest are skipped. It
+// returns errNotJSON if the data can't be parsed as JSON.
+func sniffJSON(r io.Reader) (Format, error) {
+	dec := json.NewDecoder(r)
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return "", errNotJSON
+	}
+
+	keys := &jsonSniffKeys{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return "", errNotJSON
+		}
+		key, ok := t.(string)
+		if !ok {
+			return "", errNotJSON
+		}
+
+		var dest any
+		switch key {
+		case "bomFormat":
+			dest = &keys.BomFormat
+		case "specVersion":
+			dest = &keys.CDXSpecVersion
+		case "spdxVersion":
+			dest = &keys.SPDXSpecVersion
+		case "$schema":
+			dest = &keys.Schema
+		case "artifacts":
+			keys.Artifacts = true
+		case "descriptor":
+			keys.Descriptor = true
+		}
+		if dest == nil {
+			dest = &json.RawMessage{}
+		}
+		if err := dec.Decode(dest); err != nil {
+			// A key with a value of an unexpected type doesn't identify
+			// the format but the document is still JSON.
+			var typeErr *json.UnmarshalTypeError
+			if !errors.As(err, &typeErr) {
+				return "", errNotJSON
+			}
+		}
+
+		format, err := keys.format()
+		if err != nil || format != EmptyFormat {
+			return format, err
+		}
+	}
+
+	// Documents missing the specVersion can still be identified by the
+	// CycloneDX schema they reference
+	if m := cdxSchemaRe.FindStringSubmatch(keys.Schema); m != nil {
+		return cdxJSONFormat(m[1])
+	}
+	if keys.BomFormat != "" && !strings.EqualFold(keys.BomFormat, CDXFORMAT) {
+		return "", fmt.Errorf("%w: bomFormat %q", ErrUnknownFormat, keys.BomFormat)
+	}
+	return "", ErrUnknownFormat
+}
+
+// cdxJSONFormat returns the CycloneDX JSON format of a spec version
+func cdxJSONFormat(version string) (Format, error) {
+	switch version {
+	case "1.3":
+		return CDX13JSON, nil
+	case "1.4":
+		return CDX14JSON, nil
+	case "1.5":
+		return CDX15JSON, nil
+	case "1.6":
+		return CDX16JSON, nil
+	default:
+		// JSON + BomFormat CycloneDX but specVersion not 1.3, 1.4, 1.5, or 1.6
+		return "", fmt.Errorf("%w: unsupported CycloneDX version %q", ErrUnknownFormat, version)
+	}
+}
+
+// sniffXML reads the root element of an XML document to identify the
+// format. CycloneDX documents have a bom root element in the namespace of
+// their spec version. It returns errNotXML if the data can't be parsed as
+// XML.
+func sniffXML(r io.Reader) (Format, error) {
+	dec := xml.NewDecoder(r)
+	for {
+		t, err := dec.Token()
+		if err != nil {
+			return "", errNotXML
+		}
+		root, ok := t.(xml.StartElement)
+		if !ok {
+			// Skip the XML declaration, comments and doctype
+			continue
+		}
+		if root.Name.Local != "bom" {
+			return "", fmt.Errorf("%w: XML root element %q", ErrUnknownFormat, root.Name.Local)
+		}
+		m := cdxNamespaceRe.FindStringSubmatch(root.Name.Space)
+		if m == nil {
+			return "", fmt.Errorf("%w: XML namespace %q", ErrUnknownFormat, root.Name.Space)
+		}
+		return cdxXMLFormat(m[1])
+	}
+}
+
+// cdxXMLFormat returns the CycloneDX XML format of a spec version
+func cdxXMLFormat(version string) (Format, error) {
+	switch version {
+	case "1.4":
+		return CDX14XML, nil
+	case "1.5":
+		return CDX15XML, nil
+	case "1.6":
+		return CDX16XML, nil
+	default:
+		return "", fmt.Errorf("%w: unsupported CycloneDX XML version %q", ErrUnknownFormat, version)
+	}
 }
 
 func (fs *Sniffer) sniff(data []byte) Format {
@@ -148,11 +340,10 @@ func (st *sniffState) Format() Format {
 type cdxSniff struct{}
 
 func (c cdxSniff) sniff(data []byte) Format {
-	// protobom only supports CDX formats as JSON
-	//  we are parsing the JSON in SniffReader by decoding to the SpecVersionStruct
-	//   removing all the previous JSON-related string matching from this function
-	//   if we want to support CDX XML formats in the future, could sniff for that in this func
-	//   until then, return EmptyFormat because we wont get here with a supported scenario
+	// The CycloneDX JSON and XML documents are identified in SniffReader
+	//  by decoding their top level keys or root element, there is no CDX
+	//  line based format so return EmptyFormat because we wont get here
+	//  with a supported scenario
 
 	return EmptyFormat
 }
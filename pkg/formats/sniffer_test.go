@@ -2,6 +2,7 @@ package formats
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -110,3 +111,69 @@ func TestSniffFile(t *testing.T) {
 		}
 	}
 }
+
+func TestSniffReaderJSON(t *testing.T) {
+	fs := Sniffer{}
+	bom := "\xEF\xBB\xBF"
+	for _, tc := range []struct {
+		name   string
+		data   string
+		format Format
+		err    error
+	}{
+		{"keys in any order", `{"specVersion":"1.5","components":[],"bomFormat":"CycloneDX"}`, CDX15JSON, nil},
+		{
+			"discriminator after a large array",
+			`{"components":[` + strings.Repeat(`{"name":"x","type":"library"},`, 5000) + `{"name":"y"}],"specVersion":"1.6","bomFormat":"CycloneDX"}`,
+			CDX16JSON, nil,
+		},
+		{"byte order mark cdx", bom + `{"bomFormat":"CycloneDX","specVersion":"1.4"}`, CDX14JSON, nil},
+		{"byte order mark spdx", bom + "\n  " + `{"SPDXID":"SPDXRef-DOCUMENT","spdxVersion":"SPDX-2.3"}`, SPDX23JSON, nil},
+		{"schema without spec version", `{"$schema":"http://cyclonedx.org/schema/bom-1.5.schema.json","bomFormat":"CycloneDX"}`, CDX15JSON, nil},
+		{"unexpected value types", `{"version":"1","bomFormat":5,"spdxVersion":"SPDX-2.2"}`, SPDX22JSON, nil},
+		{"syft", `{"artifacts":[],"source":{},"descriptor":{"name":"syft"}}`, "", ErrUnsupportedFormat},
+		{"unsupported cdx version", `{"bomFormat":"CycloneDX","specVersion":"0.9"}`, "", ErrUnknownFormat},
+		{"unknown json", `{"hello":"world"}`, "", ErrUnknownFormat},
+		{"byte order mark tag value", bom + "SPDXVersion: SPDX-2.3\nDataLicense: CC0-1.0\n", SPDX23TV, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := fs.SniffReader(strings.NewReader(tc.data))
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.format, format)
+		})
+	}
+}
+
+func TestSniffReaderXML(t *testing.T) {
+	fs := Sniffer{}
+	for _, tc := range []struct {
+		name   string
+		data   string
+		format Format
+		err    error
+	}{
+		{"cdx 1.4", `<?xml version="1.0" encoding="UTF-8"?>` + "\n" + `<bom xmlns="http://cyclonedx.org/schema/bom/1.4" version="1"></bom>`, CDX14XML, nil},
+		{"cdx 1.5", `<bom xmlns="http://cyclonedx.org/schema/bom/1.5" serialNumber="urn:uuid:1" version="1"><components/></bom>`, CDX15XML, nil},
+		{"comment before root", "\xEF\xBB\xBF<?xml version=\"1.0\"?>\n<!-- generated -->\n" + `<bom xmlns="http://cyclonedx.org/schema/bom/1.6"/>`, CDX16XML, nil},
+		{"unsupported cdx version", `<bom xmlns="http://cyclonedx.org/schema/bom/1.1"/>`, "", ErrUnknownFormat},
+		{"no namespace", `<bom version="1"/>`, "", ErrUnknownFormat},
+		{"unknown xml", `<project xmlns="http://maven.apache.org/POM/4.0.0"/>`, "", ErrUnknownFormat},
+		{"not xml", `<<<`, "", ErrUnknownFormat},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			format, err := fs.SniffReader(strings.NewReader(tc.data))
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.format, format)
+			require.Equal(t, XML, format.Encoding())
+			require.Equal(t, CDXFORMAT, format.Type())
+		})
+	}
+}
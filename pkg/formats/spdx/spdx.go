@@ -23,6 +23,7 @@ const (
 	ExtRefTypeCPE22  = "cpe22Type"
 	ExtRefTypeCPE23  = "cpe23Type"
 	ExtRefTypeGitoid = "gitoid"
+	ExtRefTypeSWHID  = "swh"
 )
 
 // ParseActorString parses an SPDX "actor string", it is a specially formatted
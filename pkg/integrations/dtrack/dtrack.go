@@ -0,0 +1,270 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package dtrack integrates protobom pipelines with OWASP Dependency-Track.
+// Documents are uploaded as CycloneDX, and once Dependency-Track has
+// analyzed them, its findings are recorded back in the source document as
+// vulnerabilities affecting the nodes.
+package dtrack
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// DefaultPollInterval is the time between checks of the processing status
+const DefaultPollInterval = 2 * time.Second
+
+// UploadFormat is the format of the documents uploaded. CycloneDX 1.5 is
+// supported by all the maintained Dependency-Track releases.
+var UploadFormat = formats.CDX15JSON
+
+// Client talks to the Dependency-Track API server
+type Client struct {
+	// BaseURL is the URL of the API server, without the /api path
+	BaseURL *url.URL
+
+	// APIKey authenticates the requests. The team of the key needs the
+	// BOM_UPLOAD and VIEW_VULNERABILITY permissions, and PROJECT_CREATION_UPLOAD
+	// to create projects on upload.
+	APIKey string
+
+	// HTTPClient performs the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// PollInterval is the time between checks of the processing status
+	PollInterval time.Duration
+}
+
+// ClientOption is a functional option to configure the client
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used to talk to the server
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithPollInterval sets the time between checks of the processing status
+func WithPollInterval(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.PollInterval = d
+	}
+}
+
+// NewClient returns a client of the Dependency-Track server at baseURL
+func NewClient(baseURL, apiKey string, opts ...ClientOption) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing Dependency-Track URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("server URL must be http or https, got %q", baseURL)
+	}
+	c := &Client{
+		BaseURL:      u,
+		APIKey:       apiKey,
+		HTTPClient:   http.DefaultClient,
+		PollInterval: DefaultPollInterval,
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	return c, nil
+}
+
+// do sends a request to the API and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method string, u *url.URL, body any, out any) error {
+	var rd io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request: %w", err)
+		}
+		rd = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), rd)
+	if err != nil {
+		return fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-Api-Key", c.APIKey)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("calling Dependency-Track: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("calling Dependency-Track %s: HTTP %s", u.Path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding Dependency-Track response: %w", err)
+	}
+	return nil
+}
+
+// Project identifies a Dependency-Track project
+type Project struct {
+	UUID    string `json:"uuid"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// UploadOptions selects the project receiving a document, by UUID or by
+// name and version
+type UploadOptions struct {
+	ProjectUUID    string
+	ProjectName    string
+	ProjectVersion string
+
+	// AutoCreate creates the project if it does not exist
+	AutoCreate bool
+}
+
+// Upload sends a CycloneDX document to a project and returns the token to
+// track its processing
+func (c *Client) Upload(ctx context.Context, bom []byte, opts *UploadOptions) (string, error) {
+	if opts == nil || (opts.ProjectUUID == "" && opts.ProjectName == "") {
+		return "", errors.New("upload requires a project UUID or name")
+	}
+	req := struct {
+		Project        string `json:"project,omitempty"`
+		ProjectName    string `json:"projectName,omitempty"`
+		ProjectVersion string `json:"projectVersion,omitempty"`
+		AutoCreate     bool   `json:"autoCreate,omitempty"`
+		BOM            string `json:"bom"`
+	}{
+		Project:        opts.ProjectUUID,
+		ProjectName:    opts.ProjectName,
+		ProjectVersion: opts.ProjectVersion,
+		AutoCreate:     opts.AutoCreate,
+		BOM:            base64.StdEncoding.EncodeToString(bom),
+	}
+	resp := struct {
+		Token string `json:"token"`
+	}{}
+	if err := c.do(ctx, http.MethodPut, c.BaseURL.JoinPath("api", "v1", "bom"), req, &resp); err != nil {
+		return "", fmt.Errorf("uploading BOM: %w", err)
+	}
+	if resp.Token == "" {
+		return "", errors.New("uploading BOM: server returned no token")
+	}
+	return resp.Token, nil
+}
+
+// UploadDocument serializes a document in the UploadFormat and uploads it
+func (c *Client) UploadDocument(ctx context.Context, doc *sbom.Document, opts *UploadOptions) (string, error) {
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{Format: UploadFormat}); err != nil {
+		return "", fmt.Errorf("serializing document: %w", err)
+	}
+	return c.Upload(ctx, buf.Bytes(), opts)
+}
+
+// Processing returns true while the server is processing the upload with
+// the token
+func (c *Client) Processing(ctx context.Context, token string) (bool, error) {
+	resp := struct {
+		Processing bool `json:"processing"`
+	}{}
+	if err := c.do(ctx, http.MethodGet, c.BaseURL.JoinPath("api", "v1", "event", "token", token), nil, &resp); err != nil {
+		return false, fmt.Errorf("checking processing status: %w", err)
+	}
+	return resp.Processing, nil
+}
+
+// WaitForProcessing polls the server until the upload with the token is
+// processed or ctx is done
+func (c *Client) WaitForProcessing(ctx context.Context, token string) error {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	for {
+		processing, err := c.Processing(ctx, token)
+		if err != nil {
+			return err
+		}
+		if !processing {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for BOM processing: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+	}
+}
+
+// LookupProject returns the project with the name and version
+func (c *Client) LookupProject(ctx context.Context, name, version string) (*Project, error) {
+	u := c.BaseURL.JoinPath("api", "v1", "project", "lookup")
+	u.RawQuery = url.Values{"name": {name}, "version": {version}}.Encode()
+	p := &Project{}
+	if err := c.do(ctx, http.MethodGet, u, nil, p); err != nil {
+		return nil, fmt.Errorf("looking up project %s@%s: %w", name, version, err)
+	}
+	return p, nil
+}
+
+// Findings returns the vulnerabilities found in the components of a project
+func (c *Client) Findings(ctx context.Context, projectUUID string) ([]*Finding, error) {
+	findings := []*Finding{}
+	u := c.BaseURL.JoinPath("api", "v1", "finding", "project", projectUUID)
+	if err := c.do(ctx, http.MethodGet, u, nil, &findings); err != nil {
+		return nil, fmt.Errorf("getting project findings: %w", err)
+	}
+	return findings, nil
+}
+
+// Sync uploads a document, waits for Dependency-Track to analyze it and
+// records the findings in the document
+func (c *Client) Sync(ctx context.Context, doc *sbom.Document, opts *UploadOptions) (*FindingsReport, error) {
+	token, err := c.UploadDocument(ctx, doc, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.WaitForProcessing(ctx, token); err != nil {
+		return nil, err
+	}
+
+	projectUUID := opts.ProjectUUID
+	if projectUUID == "" {
+		p, err := c.LookupProject(ctx, opts.ProjectName, opts.ProjectVersion)
+		if err != nil {
+			return nil, err
+		}
+		projectUUID = p.UUID
+	}
+
+	findings, err := c.Findings(ctx, projectUUID)
+	if err != nil {
+		return nil, err
+	}
+	return ApplyFindings(doc, findings), nil
+}
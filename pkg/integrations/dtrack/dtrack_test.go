@@ -0,0 +1,279 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package dtrack
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const testFindings = `[
+  {
+    "component": {"uuid": "c1", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+    "vulnerability": {
+      "vulnId": "CVE-2021-23337", "source": "NVD", "severity": "HIGH",
+      "cvssV3BaseScore": 7.2, "cvssV3Vector": "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H",
+      "aliases": [{"cveId": "CVE-2021-23337", "ghsaId": "GHSA-35jh-r3h4-6jhm"}],
+      "cwes": [{"cweId": 94}]
+    },
+    "analysis": {"state": "NOT_AFFECTED", "isSuppressed": true}
+  },
+  {
+    "component": {"uuid": "c2", "name": "zlib", "version": "1.2.11"},
+    "vulnerability": {"vulnId": "CVE-2018-25032", "source": "NVD", "severity": "HIGH"},
+    "analysis": {"state": "NOT_SET"}
+  },
+  {
+    "component": {"uuid": "c3", "name": "left-pad", "version": "1.0.0", "purl": "pkg:npm/left-pad@1.0.0"},
+    "vulnerability": {"vulnId": "CVE-0000-0000", "source": "NVD", "severity": "LOW"}
+  }
+]`
+
+func TestApplyFindings(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		findings        string
+		vulnerabilities int
+		unmatched       []string
+		// expected is the vulnerability applied from the first finding
+		expected *sbom.Vulnerability
+		method   string
+		analysis *sbom.Vulnerability_Analysis_State
+	}{
+		{
+			name: "suppressed finding",
+			findings: `[{
+  "component": {"uuid": "c1", "name": "lodash", "version": "4.17.20", "purl": "pkg:npm/lodash@4.17.20"},
+  "vulnerability": {
+    "vulnId": "CVE-2021-23337", "source": "NVD", "severity": "HIGH",
+    "cvssV3BaseScore": 7.2, "cvssV3Vector": "CVSS:3.1/AV:N/AC:L/PR:H/UI:N/S:U/C:H/I:H/A:H",
+    "aliases": [{"cveId": "CVE-2021-23337", "ghsaId": "GHSA-35jh-r3h4-6jhm"}],
+    "cwes": [{"cweId": 94}]
+  },
+  "analysis": {"state": "NOT_AFFECTED", "isSuppressed": true}
+}]`,
+			vulnerabilities: 1,
+			unmatched:       []string{},
+			expected: &sbom.Vulnerability{
+				Id:       "CVE-2021-23337",
+				Affects:  []string{"lodash"},
+				Aliases:  []string{"GHSA-35jh-r3h4-6jhm"},
+				Cwes:     []int32{94},
+				Severity: sbom.Vulnerability_HIGH,
+			},
+			method:   sbom.ScoringMethodCVSSv31,
+			analysis: sbom.Vulnerability_Analysis_NOT_AFFECTED.Enum(),
+		},
+		{
+			name: "matched by name and version",
+			findings: `[{
+  "component": {"uuid": "c2", "name": "zlib", "version": "1.2.11"},
+  "vulnerability": {"vulnId": "CVE-2018-25032", "source": "NVD", "severity": "HIGH"},
+  "analysis": {"state": "NOT_SET"}
+}]`,
+			vulnerabilities: 1,
+			unmatched:       []string{},
+			expected: &sbom.Vulnerability{
+				Id:       "CVE-2018-25032",
+				Affects:  []string{"zlib"},
+				Aliases:  []string{},
+				Cwes:     []int32{},
+				Severity: sbom.Vulnerability_HIGH,
+			},
+		},
+		{
+			name:      "unmatched component",
+			findings:  `[{"component": {"uuid": "c3", "name": "left-pad", "version": "1.0.0", "purl": "pkg:npm/left-pad@1.0.0"}, "vulnerability": {"vulnId": "CVE-0000-0000", "source": "NVD", "severity": "LOW"}}]`,
+			unmatched: []string{"pkg:npm/left-pad@1.0.0"},
+		},
+		{
+			name:            "all findings",
+			findings:        testFindings,
+			vulnerabilities: 2,
+			unmatched:       []string{"pkg:npm/left-pad@1.0.0"},
+			expected: &sbom.Vulnerability{
+				Id:       "CVE-2021-23337",
+				Affects:  []string{"lodash"},
+				Aliases:  []string{"GHSA-35jh-r3h4-6jhm"},
+				Cwes:     []int32{94},
+				Severity: sbom.Vulnerability_HIGH,
+			},
+			method:   sbom.ScoringMethodCVSSv31,
+			analysis: sbom.Vulnerability_Analysis_NOT_AFFECTED.Enum(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			findings := []*Finding{}
+			require.NoError(t, json.Unmarshal([]byte(tc.findings), &findings))
+
+			doc := sbom.NewDocument()
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0.0"})
+			doc.NodeList.AddNode(&sbom.Node{
+				Id: "lodash", Name: "lodash", Version: "4.17.20",
+				Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:npm/lodash@4.17.20"},
+			})
+			doc.NodeList.AddNode(&sbom.Node{Id: "zlib", Name: "zlib", Version: "1.2.11"})
+
+			report := ApplyFindings(doc, findings)
+			require.Equal(t, tc.vulnerabilities, report.Vulnerabilities)
+			require.Equal(t, tc.unmatched, report.Unmatched)
+			require.Len(t, doc.Vulnerabilities, tc.vulnerabilities)
+			if tc.expected == nil {
+				return
+			}
+
+			v := doc.GetVulnerability(tc.expected.Id)
+			require.NotNil(t, v)
+			require.Equal(t, tc.expected.Affects, v.Affects)
+			require.Equal(t, tc.expected.Aliases, v.Aliases)
+			require.Equal(t, tc.expected.Cwes, v.Cwes)
+			require.Equal(t, tc.expected.Severity, v.Severity)
+			if tc.method != "" {
+				require.Equal(t, tc.method, v.Ratings[0].Method)
+			}
+			analysis := v.AnalysisFor(v.Affects[0])
+			if tc.analysis == nil {
+				require.Nil(t, analysis)
+				return
+			}
+			require.Equal(t, *tc.analysis, analysis.GetState())
+		})
+	}
+}
+
+func TestSync(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		apiKey          string
+		opts            *UploadOptions
+		polls           int
+		vulnerabilities int
+		errContains     string
+	}{
+		{
+			name:            "upload and apply findings",
+			apiKey:          "key",
+			opts:            &UploadOptions{ProjectName: "app", ProjectVersion: "1.0.0", AutoCreate: true},
+			polls:           3,
+			vulnerabilities: 2,
+		},
+		{
+			name:        "no project",
+			apiKey:      "key",
+			opts:        &UploadOptions{},
+			errContains: "project UUID or name",
+		},
+		{
+			name:        "unauthorized",
+			apiKey:      "wrong",
+			opts:        &UploadOptions{ProjectUUID: "p1"},
+			errContains: "401",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			polls := 0
+			var uploaded map[string]any
+			mux := http.NewServeMux()
+			mux.HandleFunc("PUT /api/v1/bom", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get("X-Api-Key") != "key" {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				data, _ := io.ReadAll(r.Body) //nolint:errcheck
+				require.NoError(t, json.Unmarshal(data, &uploaded))
+				io.WriteString(w, `{"token": "tok"}`) //nolint:errcheck
+			})
+			mux.HandleFunc("GET /api/v1/event/token/tok", func(w http.ResponseWriter, _ *http.Request) {
+				polls++
+				json.NewEncoder(w).Encode(map[string]bool{"processing": polls < 3}) //nolint:errcheck,errchkjson
+			})
+			mux.HandleFunc("GET /api/v1/project/lookup", func(w http.ResponseWriter, r *http.Request) {
+				require.Equal(t, "app", r.URL.Query().Get("name"))
+				io.WriteString(w, `{"uuid": "p1", "name": "app", "version": "1.0.0"}`) //nolint:errcheck
+			})
+			mux.HandleFunc("GET /api/v1/finding/project/p1", func(w http.ResponseWriter, _ *http.Request) {
+				io.WriteString(w, testFindings) //nolint:errcheck
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, tc.apiKey, WithPollInterval(time.Millisecond))
+			require.NoError(t, err)
+
+			doc := sbom.NewDocument()
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0.0"})
+			doc.NodeList.AddNode(&sbom.Node{
+				Id: "lodash", Name: "lodash", Version: "4.17.20",
+				Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:npm/lodash@4.17.20"},
+			})
+			doc.NodeList.AddNode(&sbom.Node{Id: "zlib", Name: "zlib", Version: "1.2.11"})
+
+			report, err := c.Sync(context.Background(), doc, tc.opts)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				require.Nil(t, uploaded)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.polls, polls)
+			require.Equal(t, tc.vulnerabilities, report.Vulnerabilities)
+			require.Len(t, doc.Vulnerabilities, tc.vulnerabilities)
+
+			require.Equal(t, tc.opts.ProjectName, uploaded["projectName"])
+			require.Equal(t, tc.opts.AutoCreate, uploaded["autoCreate"])
+			bom, err := base64.StdEncoding.DecodeString(uploaded["bom"].(string)) //nolint:forcetypeassert
+			require.NoError(t, err)
+			require.Contains(t, string(bom), `"specVersion": "1.5"`)
+		})
+	}
+}
+
+func TestWaitForProcessingCanceled(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		processing bool
+		// cancel cancels the context before waiting
+		cancel bool
+		errIs  error
+	}{
+		{name: "processed", processing: false},
+		{name: "timed out", processing: true, errIs: context.DeadlineExceeded},
+		{name: "canceled", processing: true, cancel: true, errIs: context.Canceled},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				json.NewEncoder(w).Encode(map[string]bool{"processing": tc.processing}) //nolint:errcheck,errchkjson
+			}))
+			defer srv.Close()
+
+			c, err := NewClient(srv.URL, "key", WithPollInterval(time.Millisecond))
+			require.NoError(t, err)
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+
+			err = c.WaitForProcessing(ctx, "tok")
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package dtrack
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Finding is a vulnerability found by Dependency-Track in a component of a
+// project, as returned by the findings API
+type Finding struct {
+	Component struct {
+		UUID    string `json:"uuid"`
+		Group   string `json:"group"`
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Purl    string `json:"purl"`
+	} `json:"component"`
+
+	Vulnerability struct {
+		UUID            string              `json:"uuid"`
+		VulnID          string              `json:"vulnId"`
+		Source          string              `json:"source"`
+		Severity        string              `json:"severity"`
+		Description     string              `json:"description"`
+		Recommendation  string              `json:"recommendation"`
+		CVSSV2BaseScore *float64            `json:"cvssV2BaseScore"`
+		CVSSV2Vector    string              `json:"cvssV2Vector"`
+		CVSSV3BaseScore *float64            `json:"cvssV3BaseScore"`
+		CVSSV3Vector    string              `json:"cvssV3Vector"`
+		Aliases         []map[string]string `json:"aliases"`
+		CWEs            []struct {
+			CweID int32 `json:"cweId"`
+		} `json:"cwes"`
+	} `json:"vulnerability"`
+
+	Analysis struct {
+		State        string `json:"state"`
+		IsSuppressed bool   `json:"isSuppressed"`
+	} `json:"analysis"`
+}
+
+// analysisStates maps the Dependency-Track analysis states to the protobom
+// ones. NOT_SET findings have no analysis.
+var analysisStates = map[string]sbom.Vulnerability_Analysis_State{
+	"EXPLOITABLE":    sbom.Vulnerability_Analysis_EXPLOITABLE,
+	"IN_TRIAGE":      sbom.Vulnerability_Analysis_IN_TRIAGE,
+	"FALSE_POSITIVE": sbom.Vulnerability_Analysis_FALSE_POSITIVE,
+	"NOT_AFFECTED":   sbom.Vulnerability_Analysis_NOT_AFFECTED,
+	"RESOLVED":       sbom.Vulnerability_Analysis_RESOLVED,
+}
+
+// FindingsReport summarizes the findings recorded in a document
+type FindingsReport struct {
+	// Vulnerabilities is the number of findings recorded
+	Vulnerabilities int
+
+	// Unmatched lists the components of the findings not found in the
+	// document, by package URL or name and version
+	Unmatched []string
+}
+
+// ApplyFindings records the findings in the document as vulnerabilities
+// affecting the nodes of their components, matched by package URL or, if
+// the component has none, by name and version. The Dependency-Track
+// analysis of each finding is recorded as the analysis of its node.
+func ApplyFindings(doc *sbom.Document, findings []*Finding) *FindingsReport {
+	report := &FindingsReport{Unmatched: []string{}}
+	for _, f := range findings {
+		nodes := findingNodes(doc.GetNodeList(), f)
+		if len(nodes) == 0 {
+			c := f.Component.Purl
+			if c == "" {
+				c = f.Component.Name + "@" + f.Component.Version
+			}
+			if !slices.Contains(report.Unmatched, c) {
+				report.Unmatched = append(report.Unmatched, c)
+			}
+			continue
+		}
+
+		v := findingVulnerability(f)
+		for _, n := range nodes {
+			v.Affects = append(v.Affects, n.Id)
+		}
+		doc.AddVulnerability(v)
+		report.Vulnerabilities++
+
+		state, ok := analysisStates[f.Analysis.State]
+		if !ok {
+			continue
+		}
+		recorded := doc.GetVulnerability(v.Id)
+		if recorded.NodeAnalyses == nil {
+			recorded.NodeAnalyses = map[string]*sbom.Vulnerability_Analysis{}
+		}
+		for _, n := range nodes {
+			recorded.NodeAnalyses[n.Id] = &sbom.Vulnerability_Analysis{State: state}
+		}
+	}
+	return report
+}
+
+// findingNodes returns the nodes of the finding component
+func findingNodes(nl *sbom.NodeList, f *Finding) []*sbom.Node {
+	if nl == nil {
+		return nil
+	}
+	if f.Component.Purl != "" {
+		return nl.GetNodesByIdentifier("purl", f.Component.Purl)
+	}
+	return slices.DeleteFunc(nl.GetNodesByName(f.Component.Name), func(n *sbom.Node) bool {
+		return n.Version != f.Component.Version
+	})
+}
+
+// findingVulnerability converts the vulnerability of a finding
+func findingVulnerability(f *Finding) *sbom.Vulnerability {
+	fv := f.Vulnerability
+	v := &sbom.Vulnerability{
+		Id:             fv.VulnID,
+		SourceName:     fv.Source,
+		Description:    fv.Description,
+		Recommendation: fv.Recommendation,
+		Ratings:        []*sbom.Vulnerability_Rating{},
+		Affects:        []string{},
+		Aliases:        []string{},
+		Cwes:           []int32{},
+	}
+
+	severity := strings.ToLower(fv.Severity)
+	if fv.CVSSV3BaseScore != nil {
+		method := sbom.ScoringMethodFromVector(fv.CVSSV3Vector)
+		if method == "" {
+			method = sbom.ScoringMethodCVSSv3
+		}
+		v.Ratings = append(v.Ratings, &sbom.Vulnerability_Rating{
+			Source: fv.Source, Score: fv.CVSSV3BaseScore, Method: method, Vector: fv.CVSSV3Vector, Severity: severity,
+		})
+	}
+	if fv.CVSSV2BaseScore != nil {
+		v.Ratings = append(v.Ratings, &sbom.Vulnerability_Rating{
+			Source: fv.Source, Score: fv.CVSSV2BaseScore, Method: sbom.ScoringMethodCVSSv2, Vector: fv.CVSSV2Vector,
+		})
+	}
+	if len(v.Ratings) == 0 && severity != "" {
+		v.Ratings = append(v.Ratings, &sbom.Vulnerability_Rating{Source: fv.Source, Severity: severity})
+	}
+
+	for _, aliases := range fv.Aliases {
+		for _, id := range aliases {
+			if id != "" && id != v.Id && !slices.Contains(v.Aliases, id) {
+				v.Aliases = append(v.Aliases, id)
+			}
+		}
+	}
+	slices.Sort(v.Aliases)
+	for _, cwe := range fv.CWEs {
+		if !slices.Contains(v.Cwes, cwe.CweID) {
+			v.Cwes = append(v.Cwes, cwe.CweID)
+		}
+	}
+	v.NormalizeSeverity()
+	return v
+}
@@ -0,0 +1,416 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package guac exports protobom documents as ingestion predicates for
+// GUAC, the Graph for Understanding Artifact Composition. The predicates
+// mirror the JSON representation of the GUAC assembler input, so protobom
+// can act as the normalization front-end of any SBOM format GUAC ingests.
+//
+// The protobom edges are translated to the closest GUAC evidence, keeping
+// the original edge type in the justification of each predicate:
+// dependency edges become IsDependency predicates, packages containing
+// hashed files become IsOccurrence predicates, the root nodes get a
+// HasSBOM predicate and the vulnerabilities affecting packages become
+// CertifyVuln predicates.
+package guac
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Dependency types of the GUAC IsDependency predicates
+const (
+	DependencyTypeDirect   = "DIRECT"
+	DependencyTypeIndirect = "INDIRECT"
+	DependencyTypeUnknown  = "UNKNOWN"
+)
+
+// DefaultCollector is the collector recorded in the predicates when none is
+// set in the options
+const DefaultCollector = "protobom"
+
+// dependencyEdgeTypes are the edges exported as IsDependency predicates,
+// mapped to the GUAC dependency type. Reverse edges, eg A DEV_DEPENDENCY_OF
+// B, are exported from their destination to their source.
+var dependencyEdgeTypes = map[sbom.Edge_Type]string{
+	sbom.Edge_dependsOn:           DependencyTypeDirect,
+	sbom.Edge_buildDependency:     DependencyTypeDirect,
+	sbom.Edge_devDependency:       DependencyTypeDirect,
+	sbom.Edge_optionalDependency:  DependencyTypeDirect,
+	sbom.Edge_providedDependency:  DependencyTypeDirect,
+	sbom.Edge_runtimeDependency:   DependencyTypeDirect,
+	sbom.Edge_testDependency:      DependencyTypeDirect,
+	sbom.Edge_buildTool:           DependencyTypeDirect,
+	sbom.Edge_devTool:             DependencyTypeDirect,
+	sbom.Edge_testTool:            DependencyTypeDirect,
+	sbom.Edge_staticLink:          DependencyTypeDirect,
+	sbom.Edge_dynamicLink:         DependencyTypeDirect,
+	sbom.Edge_contains:            DependencyTypeUnknown,
+	sbom.Edge_optionalComponent:   DependencyTypeUnknown,
+	sbom.Edge_prerequisite:        DependencyTypeUnknown,
+	sbom.Edge_expandedFromArchive: DependencyTypeUnknown,
+}
+
+// notAffectedStates are the analysis states of the nodes not exported as
+// vulnerable
+var notAffectedStates = []sbom.Vulnerability_Analysis_State{
+	sbom.Vulnerability_Analysis_NOT_AFFECTED,
+	sbom.Vulnerability_Analysis_FALSE_POSITIVE,
+	sbom.Vulnerability_Analysis_RESOLVED,
+}
+
+// PackageQualifier is a qualifier of a package URL
+type PackageQualifier struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Package identifies a package in GUAC by the components of its purl
+type Package struct {
+	Type       string             `json:"type"`
+	Namespace  string             `json:"namespace,omitempty"`
+	Name       string             `json:"name"`
+	Version    string             `json:"version,omitempty"`
+	Qualifiers []PackageQualifier `json:"qualifiers,omitempty"`
+	Subpath    string             `json:"subpath,omitempty"`
+}
+
+// Artifact identifies a file in GUAC by its digest
+type Artifact struct {
+	Algorithm string `json:"algorithm"`
+	Digest    string `json:"digest"`
+}
+
+// Evidence are the fields GUAC records about the origin of every predicate
+type Evidence struct {
+	Justification string `json:"justification,omitempty"`
+	Origin        string `json:"origin"`
+	Collector     string `json:"collector"`
+	DocumentRef   string `json:"documentRef,omitempty"`
+}
+
+// IsDependency records that a package depends on another one
+type IsDependency struct {
+	Pkg          *Package `json:"pkg"`
+	DepPkg       *Package `json:"depPkg"`
+	IsDependency struct {
+		DependencyType string `json:"dependencyType"`
+		VersionRange   string `json:"versionRange"`
+		Evidence
+	} `json:"isDependency"`
+}
+
+// IsOccurrence records that a file is an occurrence of a package
+type IsOccurrence struct {
+	Pkg          *Package  `json:"pkg"`
+	Artifact     *Artifact `json:"artifact"`
+	IsOccurrence Evidence  `json:"isOccurrence"`
+}
+
+// HasSBOM records the SBOM describing a package
+type HasSBOM struct {
+	Pkg     *Package `json:"pkg"`
+	HasSBOM struct {
+		URI              string    `json:"uri"`
+		Algorithm        string    `json:"algorithm"`
+		Digest           string    `json:"digest"`
+		DownloadLocation string    `json:"downloadLocation"`
+		KnownSince       time.Time `json:"knownSince"`
+		Evidence
+	} `json:"hasSBOM"`
+}
+
+// Vulnerability identifies a vulnerability in GUAC
+type Vulnerability struct {
+	Type            string `json:"type"`
+	VulnerabilityID string `json:"vulnerabilityID"`
+}
+
+// CertifyVuln records that a package is affected by a vulnerability
+type CertifyVuln struct {
+	Pkg           *Package       `json:"pkg"`
+	Vulnerability *Vulnerability `json:"vulnerability"`
+	VulnData      struct {
+		TimeScanned time.Time `json:"timeScanned"`
+		Origin      string    `json:"origin"`
+		Collector   string    `json:"collector"`
+	} `json:"vulnData"`
+}
+
+// Predicates is a GUAC ingestion document
+type Predicates struct {
+	IsDependency []*IsDependency `json:"isDependency,omitempty"`
+	IsOccurrence []*IsOccurrence `json:"isOccurrence,omitempty"`
+	HasSBOM      []*HasSBOM      `json:"hasSBOM,omitempty"`
+	CertifyVuln  []*CertifyVuln  `json:"certifyVuln,omitempty"`
+}
+
+// Write encodes the predicates as JSON
+func (p *Predicates) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(p); err != nil {
+		return fmt.Errorf("encoding GUAC predicates: %w", err)
+	}
+	return nil
+}
+
+// ExportOptions controls the export of documents
+type ExportOptions struct {
+	// Origin is the location the document was collected from. Defaults to
+	// the document ID.
+	Origin string
+
+	// Collector names the tool collecting the document
+	Collector string
+
+	// URI, Algorithm and Digest identify the SBOM in the HasSBOM
+	// predicates. When the digest is not set, the SHA-256 of the document
+	// protobuf is used.
+	URI       string
+	Algorithm string
+	Digest    string
+
+	// DownloadLocation is where the SBOM can be fetched from
+	DownloadLocation string
+}
+
+// DefaultExportOptions is the set of options used when none are specified
+var DefaultExportOptions = &ExportOptions{
+	Collector: DefaultCollector,
+}
+
+// ExportReport lists the parts of the document without a GUAC equivalent
+type ExportReport struct {
+	// Unidentified lists the nodes skipped because they have no purl
+	Unidentified []string
+
+	// SkippedEdges counts the edge destinations without a GUAC equivalent
+	// or pointing to unidentified nodes, by edge type
+	SkippedEdges map[string]int
+}
+
+// exporter holds the state of an export
+type exporter struct {
+	doc      *sbom.Document
+	opts     *ExportOptions
+	evidence Evidence
+	pkgs     map[string]*Package
+	report   *ExportReport
+	preds    *Predicates
+}
+
+// Export translates a document to GUAC ingestion predicates
+func Export(doc *sbom.Document, opts *ExportOptions) (*Predicates, *ExportReport, error) {
+	if opts == nil {
+		opts = DefaultExportOptions
+	}
+	e := &exporter{
+		doc:  doc,
+		opts: opts,
+		evidence: Evidence{
+			Origin:      cmp.Or(opts.Origin, doc.GetMetadata().GetId()),
+			Collector:   cmp.Or(opts.Collector, DefaultCollector),
+			DocumentRef: doc.GetMetadata().GetId(),
+		},
+		pkgs:   map[string]*Package{},
+		report: &ExportReport{Unidentified: []string{}, SkippedEdges: map[string]int{}},
+		preds:  &Predicates{},
+	}
+
+	for _, n := range doc.GetNodeList().GetNodes() {
+		if n.GetType() == sbom.Node_FILE {
+			continue
+		}
+		p, err := packageFromPurl(string(n.Purl()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("node %s: %w", n.GetId(), err)
+		}
+		if p == nil {
+			e.report.Unidentified = append(e.report.Unidentified, n.GetId())
+			continue
+		}
+		e.pkgs[n.GetId()] = p
+	}
+
+	if err := e.exportHasSBOM(); err != nil {
+		return nil, nil, err
+	}
+	for _, edge := range doc.GetNodeList().GetEdges() {
+		e.exportEdge(edge)
+	}
+	e.exportVulnerabilities()
+	return e.preds, e.report, nil
+}
+
+// exportHasSBOM records the document as the SBOM of the root nodes
+func (e *exporter) exportHasSBOM() error {
+	algorithm, digest := e.opts.Algorithm, e.opts.Digest
+	if digest == "" {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(e.doc)
+		if err != nil {
+			return fmt.Errorf("computing document digest: %w", err)
+		}
+		sum := sha256.Sum256(data)
+		algorithm, digest = "sha256", hex.EncodeToString(sum[:])
+	}
+
+	for _, id := range e.doc.GetNodeList().GetRootElements() {
+		p, ok := e.pkgs[id]
+		if !ok {
+			continue
+		}
+		hs := &HasSBOM{Pkg: p}
+		hs.HasSBOM.URI = cmp.Or(e.opts.URI, e.doc.GetMetadata().GetId())
+		hs.HasSBOM.Algorithm = algorithm
+		hs.HasSBOM.Digest = digest
+		hs.HasSBOM.DownloadLocation = e.opts.DownloadLocation
+		if e.doc.GetMetadata().GetDate() != nil {
+			hs.HasSBOM.KnownSince = e.doc.GetMetadata().GetDate().AsTime()
+		}
+		hs.HasSBOM.Evidence = e.evidence
+		e.preds.HasSBOM = append(e.preds.HasSBOM, hs)
+	}
+	return nil
+}
+
+// exportEdge translates the destinations of an edge to IsDependency or,
+// for files contained in packages, IsOccurrence predicates
+func (e *exporter) exportEdge(edge *sbom.Edge) {
+	edgeType := edge.GetType()
+	for _, to := range edge.GetTo() {
+		from := edge.GetFrom()
+		t := edgeType
+		if t.IsReverse() {
+			from, to = to, from
+			// Inverse types are exported as their canonical type
+			if inverse := t.Inverse(); inverse != sbom.Edge_UNKNOWN {
+				t = inverse
+			}
+		}
+		depType, ok := dependencyEdgeTypes[t]
+		if !ok {
+			e.report.SkippedEdges[edgeType.String()]++
+			continue
+		}
+		evidence := e.evidence
+		evidence.Justification = fmt.Sprintf("protobom %s edge", edgeType)
+
+		pkg, ok := e.pkgs[from]
+		if !ok {
+			e.report.SkippedEdges[edgeType.String()]++
+			continue
+		}
+		if dep, ok := e.pkgs[to]; ok {
+			d := &IsDependency{Pkg: pkg, DepPkg: dep}
+			d.IsDependency.DependencyType = depType
+			d.IsDependency.VersionRange = dep.Version
+			d.IsDependency.Evidence = evidence
+			e.preds.IsDependency = append(e.preds.IsDependency, d)
+			continue
+		}
+
+		artifacts := fileArtifacts(e.doc.GetNodeList().GetNodeByID(to))
+		if t != sbom.Edge_contains || len(artifacts) == 0 {
+			e.report.SkippedEdges[edgeType.String()]++
+			continue
+		}
+		for _, a := range artifacts {
+			e.preds.IsOccurrence = append(e.preds.IsOccurrence, &IsOccurrence{
+				Pkg: pkg, Artifact: a, IsOccurrence: evidence,
+			})
+		}
+	}
+}
+
+// exportVulnerabilities records the packages affected by the document
+// vulnerabilities, leaving out those analyzed as not affected
+func (e *exporter) exportVulnerabilities() {
+	scanned := time.Time{}
+	if e.doc.GetMetadata().GetDate() != nil {
+		scanned = e.doc.GetMetadata().GetDate().AsTime()
+	}
+	for _, v := range e.doc.GetVulnerabilities() {
+		vt, _, _ := strings.Cut(v.GetId(), "-")
+		vuln := &Vulnerability{Type: strings.ToLower(vt), VulnerabilityID: strings.ToLower(v.GetId())}
+		for _, id := range v.GetAffects() {
+			p, ok := e.pkgs[id]
+			if !ok || slices.Contains(notAffectedStates, v.AnalysisFor(id).GetState()) {
+				continue
+			}
+			cv := &CertifyVuln{Pkg: p, Vulnerability: vuln}
+			cv.VulnData.TimeScanned = scanned
+			cv.VulnData.Origin = e.evidence.Origin
+			cv.VulnData.Collector = e.evidence.Collector
+			e.preds.CertifyVuln = append(e.preds.CertifyVuln, cv)
+		}
+	}
+}
+
+// fileArtifacts returns the artifacts identifying a file node, one per
+// hash, sorted by algorithm
+func fileArtifacts(n *sbom.Node) []*Artifact {
+	if n.GetType() != sbom.Node_FILE {
+		return nil
+	}
+	ret := []*Artifact{}
+	for _, algo := range slices.Sorted(maps.Keys(n.GetHashes())) {
+		name := strings.ReplaceAll(strings.ToLower(sbom.HashAlgorithm(algo).String()), "_", "-")
+		ret = append(ret, &Artifact{Algorithm: name, Digest: strings.ToLower(n.GetHashes()[algo])})
+	}
+	return ret
+}
+
+// packageFromPurl splits a package URL in its components. It returns nil
+// if purl is empty.
+func packageFromPurl(purl string) (*Package, error) {
+	if purl == "" {
+		return nil, nil
+	}
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return nil, fmt.Errorf("invalid package URL %q", purl)
+	}
+	p := &Package{}
+	rest, p.Subpath, _ = strings.Cut(rest, "#")
+	rest, _, _ = strings.Cut(rest, "?")
+	if i := strings.LastIndex(rest, "@"); i > strings.LastIndex(rest, "/") {
+		rest, p.Version = rest[:i], rest[i+1:]
+	}
+
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[len(parts)-1] == "" {
+		return nil, fmt.Errorf("invalid package URL %q", purl)
+	}
+	p.Type = strings.ToLower(parts[0])
+	p.Name = parts[len(parts)-1]
+	p.Namespace = strings.Join(parts[1:len(parts)-1], "/")
+
+	for _, s := range []*string{&p.Namespace, &p.Name, &p.Version, &p.Subpath} {
+		if d, err := url.PathUnescape(*s); err == nil {
+			*s = d
+		}
+	}
+	qualifiers := sbom.PackageURL(purl).Qualifiers()
+	for _, k := range slices.Sorted(maps.Keys(qualifiers)) {
+		p.Qualifiers = append(p.Qualifiers, PackageQualifier{Key: k, Value: qualifiers[k]})
+	}
+	return p, nil
+}
@@ -0,0 +1,276 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package guac
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func purlNode(id, purl string) *sbom.Node {
+	return &sbom.Node{
+		Id: id, Name: id,
+		Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): purl},
+	}
+}
+
+func TestPackageFromPurl(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		purl     string
+		expected *Package
+		mustErr  bool
+	}{
+		{name: "empty", purl: ""},
+		{
+			name:     "encoded namespace",
+			purl:     "pkg:npm/%40angular/core@16.0.0",
+			expected: &Package{Type: "npm", Namespace: "@angular", Name: "core", Version: "16.0.0"},
+		},
+		{
+			name: "subpath",
+			purl: "pkg:golang/github.com/acme/lib@v1.2.0#sub/dir",
+			expected: &Package{
+				Type: "golang", Namespace: "github.com/acme", Name: "lib", Version: "v1.2.0", Subpath: "sub/dir",
+			},
+		},
+		{
+			name: "sorted qualifiers",
+			purl: "pkg:deb/debian/curl@8.0?distro=debian-12&arch=amd64",
+			expected: &Package{
+				Type: "deb", Namespace: "debian", Name: "curl", Version: "8.0",
+				Qualifiers: []PackageQualifier{{Key: "arch", Value: "amd64"}, {Key: "distro", Value: "debian-12"}},
+			},
+		},
+		{
+			name:     "no namespace or version",
+			purl:     "pkg:generic/openssl",
+			expected: &Package{Type: "generic", Name: "openssl"},
+		},
+		{name: "no scheme", purl: "npm/lodash@1.0.0", mustErr: true},
+		{name: "no name", purl: "pkg:npm", mustErr: true},
+		{name: "no type", purl: "pkg:/lodash", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			p, err := packageFromPurl(tc.purl)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, p)
+		})
+	}
+}
+
+func TestExport(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		edges   []*sbom.Edge
+		vulns   []*sbom.Vulnerability
+		opts    *ExportOptions
+		invalid bool
+		// dependencies are rendered as "pkg -> dep (type): justification"
+		dependencies []string
+		occurrences  []*Artifact
+		// vulnerable are rendered as "vulnerability pkg"
+		vulnerable []string
+		skipped    map[string]int
+		sbomURI    string
+		sbomDigest string
+	}{
+		{
+			name: "dependencies",
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib", "noid"}},
+				// contained_by is exported in the canonical direction
+				{Type: sbom.Edge_contained_by, From: "base", To: []string{"app"}},
+			},
+			opts: &ExportOptions{Collector: "test", Origin: "file:///sbom.json"},
+			dependencies: []string{
+				"app -> lib (DIRECT): protobom dependsOn edge",
+				"app -> base (UNKNOWN): protobom contained_by edge",
+			},
+			skipped: map[string]int{"dependsOn": 1},
+			sbomURI: "urn:uuid:doc",
+		},
+		{
+			name:    "edges without equivalent",
+			edges:   []*sbom.Edge{{Type: sbom.Edge_documentation, From: "lib", To: []string{"base"}}},
+			skipped: map[string]int{"documentation": 1},
+			sbomURI: "urn:uuid:doc",
+		},
+		{
+			name:  "file occurrences",
+			edges: []*sbom.Edge{{Type: sbom.Edge_contains, From: "app", To: []string{"file"}}},
+			occurrences: []*Artifact{
+				{Algorithm: "sha1", Digest: "1234"},
+				{Algorithm: "sha256", Digest: "abcd"},
+			},
+			skipped: map[string]int{},
+			sbomURI: "urn:uuid:doc",
+		},
+		{
+			name: "vulnerabilities",
+			vulns: []*sbom.Vulnerability{
+				{Id: "CVE-2024-0001", Affects: []string{"lib", "base"}},
+				{
+					Id: "GHSA-aaaa-bbbb-cccc", Affects: []string{"app"},
+					NodeAnalyses: map[string]*sbom.Vulnerability_Analysis{
+						"app": {State: sbom.Vulnerability_Analysis_NOT_AFFECTED},
+					},
+				},
+			},
+			vulnerable: []string{"cve-2024-0001 lib", "cve-2024-0001 base"},
+			skipped:    map[string]int{},
+			sbomURI:    "urn:uuid:doc",
+		},
+		{
+			name:       "sbom location",
+			opts:       &ExportOptions{URI: "https://example.com/sbom.json", Algorithm: "sha512", Digest: "ffff"},
+			skipped:    map[string]int{},
+			sbomURI:    "https://example.com/sbom.json",
+			sbomDigest: "ffff",
+		},
+		{
+			name:    "invalid purl",
+			invalid: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "urn:uuid:doc"
+			doc.NodeList.AddRootNode(purlNode("app", "pkg:oci/app@sha256%3Aabc?repository_url=ghcr.io%2Facme%2Fapp"))
+			doc.NodeList.AddNode(purlNode("lib", "pkg:golang/github.com/acme/lib@v1.2.0#sub/dir"))
+			doc.NodeList.AddNode(purlNode("base", "pkg:deb/debian/base@12"))
+			doc.NodeList.AddNode(&sbom.Node{Id: "noid", Name: "noid"})
+			doc.NodeList.AddNode(&sbom.Node{
+				Id: "file", Type: sbom.Node_FILE, Name: "/bin/app",
+				Hashes: map[int32]string{
+					int32(sbom.HashAlgorithm_SHA256): "ABCD",
+					int32(sbom.HashAlgorithm_SHA1):   "1234",
+				},
+			})
+			if tc.invalid {
+				doc.NodeList.AddNode(purlNode("bad", "npm/lodash"))
+			}
+			for _, e := range tc.edges {
+				doc.NodeList.AddEdge(e)
+			}
+			doc.Vulnerabilities = tc.vulns
+
+			preds, report, err := Export(doc, tc.opts)
+			if tc.invalid {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, []string{"noid"}, report.Unidentified)
+			require.Equal(t, tc.skipped, report.SkippedEdges)
+
+			var dependencies []string
+			for _, d := range preds.IsDependency {
+				dependencies = append(dependencies, fmt.Sprintf(
+					"%s -> %s (%s): %s", d.Pkg.Name, d.DepPkg.Name, d.IsDependency.DependencyType, d.IsDependency.Justification,
+				))
+				require.Equal(t, tc.opts.Origin, d.IsDependency.Origin)
+				require.Equal(t, tc.opts.Collector, d.IsDependency.Collector)
+				require.Equal(t, "urn:uuid:doc", d.IsDependency.DocumentRef)
+			}
+			require.Equal(t, tc.dependencies, dependencies)
+
+			var occurrences []*Artifact
+			for _, o := range preds.IsOccurrence {
+				occurrences = append(occurrences, o.Artifact)
+			}
+			require.Equal(t, tc.occurrences, occurrences)
+
+			var vulnerable []string
+			for _, cv := range preds.CertifyVuln {
+				vulnerable = append(vulnerable, cv.Vulnerability.VulnerabilityID+" "+cv.Pkg.Name)
+			}
+			require.Equal(t, tc.vulnerable, vulnerable)
+
+			require.Len(t, preds.HasSBOM, 1)
+			require.Equal(t, "oci", preds.HasSBOM[0].Pkg.Type)
+			require.Equal(t, "sha256:abc", preds.HasSBOM[0].Pkg.Version)
+			require.Equal(t, tc.sbomURI, preds.HasSBOM[0].HasSBOM.URI)
+			if tc.sbomDigest == "" {
+				require.Equal(t, "sha256", preds.HasSBOM[0].HasSBOM.Algorithm)
+				require.Len(t, preds.HasSBOM[0].HasSBOM.Digest, 64)
+			} else {
+				require.Equal(t, tc.opts.Algorithm, preds.HasSBOM[0].HasSBOM.Algorithm)
+				require.Equal(t, tc.sbomDigest, preds.HasSBOM[0].HasSBOM.Digest)
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, preds.Write(&buf))
+			decoded := map[string][]map[string]any{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+			require.Len(t, decoded["isDependency"], len(tc.dependencies))
+			for _, d := range decoded["isDependency"] {
+				require.Contains(t, d["isDependency"], "justification")
+			}
+		})
+	}
+}
+
+func TestExportEdgeDirection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		edge    *sbom.Edge
+		depType string
+	}{
+		"depends on": {
+			edge:    &sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}},
+			depType: DependencyTypeDirect,
+		},
+		"dependency of": {
+			edge:    &sbom.Edge{Type: sbom.Edge_dependencyOf, From: "lib", To: []string{"app"}},
+			depType: DependencyTypeDirect,
+		},
+		"dev dependency of": {
+			edge:    &sbom.Edge{Type: sbom.Edge_devDependency, From: "lib", To: []string{"app"}},
+			depType: DependencyTypeDirect,
+		},
+		"runtime dependency of": {
+			edge:    &sbom.Edge{Type: sbom.Edge_runtimeDependency, From: "lib", To: []string{"app"}},
+			depType: DependencyTypeDirect,
+		},
+		"build tool of": {
+			edge:    &sbom.Edge{Type: sbom.Edge_buildTool, From: "lib", To: []string{"app"}},
+			depType: DependencyTypeDirect,
+		},
+		"optional component of": {
+			edge:    &sbom.Edge{Type: sbom.Edge_optionalComponent, From: "lib", To: []string{"app"}},
+			depType: DependencyTypeUnknown,
+		},
+		"static link": {
+			edge:    &sbom.Edge{Type: sbom.Edge_staticLink, From: "app", To: []string{"lib"}},
+			depType: DependencyTypeDirect,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddRootNode(purlNode("app", "pkg:npm/app@1.0.0"))
+			doc.NodeList.AddNode(purlNode("lib", "pkg:npm/lib@2.0.0"))
+			doc.NodeList.AddEdge(tc.edge)
+
+			preds, _, err := Export(doc, &ExportOptions{})
+			require.NoError(t, err)
+			require.Len(t, preds.IsDependency, 1)
+			require.Equal(t, "app", preds.IsDependency[0].Pkg.Name)
+			require.Equal(t, "lib", preds.IsDependency[0].DepPkg.Name)
+			require.Equal(t, tc.depType, preds.IsDependency[0].IsDependency.DependencyType)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	"github.com/protobom/protobom/pkg/cache"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// cacheKeyPrefix namespaces the fetcher entries in shared caches
+const cacheKeyPrefix = "k8s.fetch:"
+
+// CachingFetcher wraps a Fetcher to cache the SBOMs fetched for each image,
+// so repeated reviews don't query the registry again. With a nil Fetcher,
+// only cached images can be fetched.
+type CachingFetcher struct {
+	Fetcher Fetcher
+	Cache   cache.Cache
+
+	// TTL is the time the SBOMs of an image are kept in the cache. Zero
+	// keeps them until they are removed from the cache.
+	TTL time.Duration
+}
+
+// NewCachingFetcher returns a CachingFetcher caching the SBOMs fetched by
+// fetcher in c for ttl.
+func NewCachingFetcher(fetcher Fetcher, c cache.Cache, ttl time.Duration) *CachingFetcher {
+	return &CachingFetcher{
+		Fetcher: fetcher,
+		Cache:   c,
+		TTL:     ttl,
+	}
+}
+
+// Fetch implements Fetcher
+func (cf *CachingFetcher) Fetch(ctx context.Context, image string) ([]*sbom.Document, error) {
+	var load cache.LoadFunc
+	if cf.Fetcher != nil {
+		load = func(ctx context.Context) ([]byte, error) {
+			docs, err := cf.Fetcher.Fetch(ctx, image)
+			if err != nil {
+				return nil, err
+			}
+			return marshalDocuments(docs)
+		}
+	}
+
+	data, err := cache.GetOrLoad(ctx, cf.Cache, cacheKeyPrefix+image, cf.TTL, load)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", image, err)
+	}
+	docs, err := unmarshalDocuments(data)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cached SBOMs of %s: %w", image, err)
+	}
+	return docs, nil
+}
+
+// marshalDocuments encodes documents as size-delimited protobuf messages
+func marshalDocuments(docs []*sbom.Document) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		if _, err := protodelim.MarshalTo(&buf, doc); err != nil {
+			return nil, fmt.Errorf("marshaling document: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalDocuments decodes the documents encoded by marshalDocuments
+func unmarshalDocuments(data []byte) ([]*sbom.Document, error) {
+	r := bufio.NewReader(bytes.NewReader(data))
+	docs := []*sbom.Document{}
+	for {
+		doc := &sbom.Document{}
+		if err := protodelim.UnmarshalFrom(r, doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
@@ -0,0 +1,79 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/cache"
+)
+
+func TestCachingFetcher(t *testing.T) {
+	ctx := context.Background()
+	for name, tc := range map[string]struct {
+		// primed fetches the image through an online fetcher first
+		primed  bool
+		offline bool
+		image   string
+		lookups int
+		calls   int
+		errIs   error
+	}{
+		"fetched once": {
+			image:   "nginx",
+			lookups: 2,
+			calls:   1,
+		},
+		"cached": {
+			primed:  true,
+			image:   "nginx",
+			lookups: 1,
+		},
+		// Without a fetcher only the cached images are available
+		"offline cached": {
+			primed:  true,
+			offline: true,
+			image:   "nginx",
+			lookups: 1,
+		},
+		"offline miss": {
+			primed:  true,
+			offline: true,
+			image:   "busybox",
+			lookups: 1,
+			errIs:   cache.ErrOffline,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := cache.NewDisk(t.TempDir())
+			if tc.primed {
+				_, err := NewCachingFetcher(&flakyFetcher{}, c, 0).Fetch(ctx, "nginx")
+				require.NoError(t, err)
+			}
+
+			f := &flakyFetcher{}
+			cf := NewCachingFetcher(f, c, 0)
+			if tc.offline {
+				cf = NewCachingFetcher(nil, c, 0)
+			}
+			for range tc.lookups {
+				docs, err := cf.Fetch(ctx, tc.image)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+					continue
+				}
+				require.NoError(t, err)
+				require.Len(t, docs, 1)
+				require.Equal(t, tc.image, docs[0].GetNodeList().GetNodes()[0].GetId())
+			}
+			require.Equal(t, tc.calls, f.calls)
+		})
+	}
+}
@@ -0,0 +1,117 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package k8s
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Container captures the fields of a Kubernetes container spec needed
+// to locate its SBOM.
+type Container struct {
+	Name  string `json:"name" yaml:"name"`
+	Image string `json:"image" yaml:"image"`
+}
+
+// PodSpec is the subset of a Kubernetes pod spec listing the containers
+// of the pod.
+type PodSpec struct {
+	Containers          []Container `json:"containers,omitempty" yaml:"containers,omitempty"`
+	InitContainers      []Container `json:"initContainers,omitempty" yaml:"initContainers,omitempty"`
+	EphemeralContainers []Container `json:"ephemeralContainers,omitempty" yaml:"ephemeralContainers,omitempty"`
+}
+
+// podTemplate is the template of pods in the workload controllers
+type podTemplate struct {
+	Spec *PodSpec `yaml:"spec"`
+}
+
+// object is the subset of the Kubernetes objects carrying pod specs. The
+// spec field is decoded lazily as its shape depends on the kind.
+type object struct {
+	Kind string    `yaml:"kind"`
+	Spec yaml.Node `yaml:"spec"`
+}
+
+// Images returns the image references of all the containers in the pod
+// spec. The list preserves the order in the spec and has no duplicates.
+func (ps *PodSpec) Images() []string {
+	images := []string{}
+	if ps == nil {
+		return images
+	}
+
+	seen := map[string]struct{}{}
+	for _, list := range [][]Container{ps.InitContainers, ps.Containers, ps.EphemeralContainers} {
+		for _, c := range list {
+			if c.Image == "" {
+				continue
+			}
+			if _, ok := seen[c.Image]; ok {
+				continue
+			}
+			seen[c.Image] = struct{}{}
+			images = append(images, c.Image)
+		}
+	}
+	return images
+}
+
+// PodSpecFromObject extracts the pod spec from a Kubernetes object encoded
+// in YAML or JSON. It supports pods and the built-in workload controllers
+// (Deployment, StatefulSet, DaemonSet, ReplicaSet, ReplicationController,
+// Job and CronJob).
+func PodSpecFromObject(data []byte) (*PodSpec, error) {
+	obj := &object{}
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return nil, fmt.Errorf("decoding object: %w", err)
+	}
+
+	if obj.Spec.IsZero() {
+		return nil, errors.New("object has no spec")
+	}
+
+	switch obj.Kind {
+	case "Pod":
+		spec := &PodSpec{}
+		if err := obj.Spec.Decode(spec); err != nil {
+			return nil, fmt.Errorf("decoding pod spec: %w", err)
+		}
+		return spec, nil
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController", "Job":
+		s := &struct {
+			Template podTemplate `yaml:"template"`
+		}{}
+		if err := obj.Spec.Decode(s); err != nil {
+			return nil, fmt.Errorf("decoding %s spec: %w", obj.Kind, err)
+		}
+		if s.Template.Spec == nil {
+			return nil, fmt.Errorf("%s has no pod template", obj.Kind)
+		}
+		return s.Template.Spec, nil
+	case "CronJob":
+		s := &struct {
+			JobTemplate struct {
+				Spec struct {
+					Template podTemplate `yaml:"template"`
+				} `yaml:"spec"`
+			} `yaml:"jobTemplate"`
+		}{}
+		if err := obj.Spec.Decode(s); err != nil {
+			return nil, fmt.Errorf("decoding CronJob spec: %w", err)
+		}
+		if s.JobTemplate.Spec.Template.Spec == nil {
+			return nil, errors.New("CronJob has no pod template")
+		}
+		return s.JobTemplate.Spec.Template.Spec, nil
+	default:
+		return nil, fmt.Errorf("unsupported object kind %q", obj.Kind)
+	}
+}
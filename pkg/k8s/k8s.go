@@ -0,0 +1,154 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package k8s provides building blocks to write SBOM-aware Kubernetes
+// admission controllers. The Reviewer extracts the images from a pod spec,
+// fetches their SBOMs, merges them into a single document and evaluates it
+// against a set of policies.
+//
+// The package does not depend on the Kubernetes client libraries. SBOMs are
+// retrieved through the Fetcher interface, which can be implemented on top
+// of any OCI registry client.
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/protobom/protobom/pkg/policy"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Fetcher retrieves the SBOMs attached to a container image.
+type Fetcher interface {
+	// Fetch returns the SBOMs found for the image reference. An empty
+	// list and no error means the image has no SBOMs attached.
+	Fetch(ctx context.Context, image string) ([]*sbom.Document, error)
+}
+
+// Reviewer evaluates pods using the SBOMs of their images.
+type Reviewer struct {
+	Fetcher   Fetcher
+	Evaluator *policy.Evaluator
+	Options   Options
+}
+
+// Options controls the behavior of the reviewer
+type Options struct {
+	// RequireSBOM denies pods running images without SBOMs
+	RequireSBOM bool
+}
+
+// ReviewerOption is a functional option to configure the reviewer
+type ReviewerOption func(*Reviewer)
+
+// WithRequireSBOM sets the reviewer to deny pods running images without SBOMs
+func WithRequireSBOM(require bool) ReviewerOption {
+	return func(r *Reviewer) {
+		r.Options.RequireSBOM = require
+	}
+}
+
+// WithEvaluator sets the policy evaluator used to review the pods
+func WithEvaluator(e *policy.Evaluator) ReviewerOption {
+	return func(r *Reviewer) {
+		r.Evaluator = e
+	}
+}
+
+// Result captures the outcome of a pod review
+type Result struct {
+	// Allowed is true when the pod complies with all the policies
+	Allowed bool
+
+	// Images lists the image references found in the pod spec
+	Images []string
+
+	// Missing lists the images without SBOMs
+	Missing []string
+
+	// Document is the merged SBOM of all the pod images
+	Document *sbom.Document
+
+	// Violations contains the failed policy evaluations
+	Violations []*policy.Violation
+}
+
+// NewReviewer returns a new reviewer fetching SBOMs using fetcher
+func NewReviewer(fetcher Fetcher, opts ...ReviewerOption) *Reviewer {
+	r := &Reviewer{
+		Fetcher: fetcher,
+		Options: Options{},
+	}
+	for _, o := range opts {
+		o(r)
+	}
+	return r
+}
+
+// Review fetches the SBOMs of the images in the pod spec, merges them and
+// evaluates the result against the configured policies.
+func (r *Reviewer) Review(ctx context.Context, spec *PodSpec) (*Result, error) {
+	if r.Fetcher == nil {
+		return nil, errors.New("reviewer has no SBOM fetcher")
+	}
+
+	res := &Result{
+		Images:     spec.Images(),
+		Missing:    []string{},
+		Violations: []*policy.Violation{},
+	}
+
+	docs := []*sbom.Document{}
+	for _, image := range res.Images {
+		fetched, err := r.Fetcher.Fetch(ctx, image)
+		if err != nil {
+			return nil, fmt.Errorf("fetching SBOMs of %s: %w", image, err)
+		}
+		if len(fetched) == 0 {
+			res.Missing = append(res.Missing, image)
+			continue
+		}
+		docs = append(docs, fetched...)
+	}
+
+	res.Document = MergeDocuments(docs...)
+
+	if r.Evaluator != nil {
+		violations, err := r.Evaluator.Evaluate(res.Document)
+		if err != nil {
+			return nil, fmt.Errorf("evaluating policies: %w", err)
+		}
+		res.Violations = violations
+	}
+
+	res.Allowed = len(res.Violations) == 0 && (!r.Options.RequireSBOM || len(res.Missing) == 0)
+	return res, nil
+}
+
+// ReviewObject extracts the pod spec from a Kubernetes object in YAML or
+// JSON and reviews it.
+func (r *Reviewer) ReviewObject(ctx context.Context, data []byte) (*Result, error) {
+	spec, err := PodSpecFromObject(data)
+	if err != nil {
+		return nil, fmt.Errorf("extracting pod spec: %w", err)
+	}
+	return r.Review(ctx, spec)
+}
+
+// MergeDocuments combines the node lists of the documents into a new
+// document. The root elements of all documents are preserved as roots.
+func MergeDocuments(docs ...*sbom.Document) *sbom.Document {
+	merged := sbom.NewDocument()
+	for _, d := range docs {
+		if d == nil || d.NodeList == nil {
+			continue
+		}
+		merged.NodeList = merged.NodeList.Union(d.NodeList)
+	}
+	return merged
+}
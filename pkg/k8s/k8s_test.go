@@ -0,0 +1,144 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/policy"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+type fakeFetcher map[string][]*sbom.Document
+
+func (f fakeFetcher) Fetch(_ context.Context, image string) ([]*sbom.Document, error) {
+	if image == "broken" {
+		return nil, errors.New("registry error")
+	}
+	return f[image], nil
+}
+
+func imageDocument(id string, hashed bool) *sbom.Document {
+	doc := sbom.NewDocument()
+	n := &sbom.Node{
+		Id: id, Name: id,
+		Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:oci/" + id},
+		Hashes:      map[int32]string{},
+	}
+	if hashed {
+		n.Hashes[int32(sbom.HashAlgorithm_SHA256)] = "abc"
+	}
+	doc.NodeList.AddRootNode(n)
+	return doc
+}
+
+func TestPodSpecFromObject(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data     string
+		expected []string
+		mustErr  bool
+	}{
+		"pod yaml": {
+			data: `
+kind: Pod
+spec:
+  initContainers:
+    - name: init
+      image: busybox
+  containers:
+    - name: app
+      image: nginx:1.25
+    - name: sidecar
+      image: busybox
+`,
+			expected: []string{"busybox", "nginx:1.25"},
+		},
+		"deployment json": {
+			data:     `{"kind":"Deployment","spec":{"template":{"spec":{"containers":[{"name":"app","image":"app:v1"}]}}}}`,
+			expected: []string{"app:v1"},
+		},
+		"cronjob": {
+			data: `
+kind: CronJob
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - image: job:v1
+`,
+			expected: []string{"job:v1"},
+		},
+		"unsupported kind": {data: "kind: Service\nspec:\n  ports: []\n", mustErr: true},
+		"no spec":          {data: "kind: Pod\n", mustErr: true},
+		"no template":      {data: "kind: Deployment\nspec:\n  replicas: 1\n", mustErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec, err := PodSpecFromObject([]byte(tc.data))
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, spec.Images())
+		})
+	}
+}
+
+func TestReview(t *testing.T) {
+	evaluator, err := policy.NewEvaluator(&policy.Policy{
+		Name:      "oci-sha256",
+		Match:     `purlType(node) == "oci"`,
+		Condition: `hasHash(node, "SHA256")`,
+	})
+	require.NoError(t, err)
+
+	fetcher := fakeFetcher{
+		"good":     {imageDocument("good", true)},
+		"unhashed": {imageDocument("unhashed", false)},
+	}
+
+	for name, tc := range map[string]struct {
+		images     []string
+		opts       []ReviewerOption
+		allowed    bool
+		missing    []string
+		violations int
+		nodes      int
+		mustErr    bool
+	}{
+		"compliant":        {images: []string{"good"}, allowed: true, missing: []string{}, nodes: 1},
+		"violation":        {images: []string{"good", "unhashed"}, allowed: false, missing: []string{}, violations: 1, nodes: 2},
+		"missing allowed":  {images: []string{"good", "nosbom"}, allowed: true, missing: []string{"nosbom"}, nodes: 1},
+		"missing required": {images: []string{"nosbom"}, opts: []ReviewerOption{WithRequireSBOM(true)}, allowed: false, missing: []string{"nosbom"}},
+		"fetch error":      {images: []string{"broken"}, mustErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			spec := &PodSpec{}
+			for _, i := range tc.images {
+				spec.Containers = append(spec.Containers, Container{Image: i})
+			}
+			r := NewReviewer(fetcher, append([]ReviewerOption{WithEvaluator(evaluator)}, tc.opts...)...)
+			res, err := r.Review(context.Background(), spec)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.allowed, res.Allowed)
+			require.Equal(t, tc.missing, res.Missing)
+			require.Len(t, res.Violations, tc.violations)
+			require.Len(t, res.Document.NodeList.Nodes, tc.nodes)
+			require.Len(t, res.Document.NodeList.RootElements, tc.nodes)
+		})
+	}
+}
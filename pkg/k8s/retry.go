@@ -0,0 +1,207 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Default settings of the RetryingFetcher
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 500 * time.Millisecond
+	DefaultMaxBackoff     = 10 * time.Second
+)
+
+// permanentError marks an error that must not be retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err to signal the RetryingFetcher that the fetch must not
+// be retried, for example when the image does not exist or access is
+// denied. Fetchers return it from Fetch.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// RetryOptions configures the RetryingFetcher
+type RetryOptions struct {
+	// MaxAttempts is the number of times a fetch is tried before giving
+	// up, including the first one.
+	MaxAttempts int
+
+	// InitialBackoff is the wait after the first failed attempt. The wait
+	// doubles after each attempt up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// Timeout limits the duration of each attempt. Zero means no limit
+	// other than the deadline of the context.
+	Timeout time.Duration
+
+	// RateLimit is the maximum number of fetches started per second,
+	// counting the retries. Zero disables rate limiting.
+	RateLimit float64
+}
+
+// RetryOption is a functional option to configure the RetryingFetcher
+type RetryOption func(*RetryOptions)
+
+// WithMaxAttempts sets the number of times a fetch is tried
+func WithMaxAttempts(n int) RetryOption {
+	return func(o *RetryOptions) {
+		o.MaxAttempts = n
+	}
+}
+
+// WithBackoff sets the initial and maximum waits between attempts
+func WithBackoff(initial, maxBackoff time.Duration) RetryOption {
+	return func(o *RetryOptions) {
+		o.InitialBackoff = initial
+		o.MaxBackoff = maxBackoff
+	}
+}
+
+// WithTimeout limits the duration of each fetch attempt
+func WithTimeout(d time.Duration) RetryOption {
+	return func(o *RetryOptions) {
+		o.Timeout = d
+	}
+}
+
+// WithRateLimit caps the fetches started per second
+func WithRateLimit(perSecond float64) RetryOption {
+	return func(o *RetryOptions) {
+		o.RateLimit = perSecond
+	}
+}
+
+// RetryingFetcher wraps a Fetcher to retry failed fetches with exponential
+// backoff, limit the rate of requests sent to the registry and bound the
+// duration of each attempt. It is safe for concurrent use if the wrapped
+// fetcher is.
+type RetryingFetcher struct {
+	Fetcher Fetcher
+	Options RetryOptions
+
+	mu   sync.Mutex
+	next time.Time
+
+	// sleep waits for d or until ctx is done. Replaced in tests.
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryingFetcher returns a RetryingFetcher wrapping fetcher
+func NewRetryingFetcher(fetcher Fetcher, opts ...RetryOption) *RetryingFetcher {
+	rf := &RetryingFetcher{
+		Fetcher: fetcher,
+		Options: RetryOptions{
+			MaxAttempts:    DefaultMaxAttempts,
+			InitialBackoff: DefaultInitialBackoff,
+			MaxBackoff:     DefaultMaxBackoff,
+		},
+		sleep: sleepContext,
+	}
+	for _, o := range opts {
+		o(&rf.Options)
+	}
+	return rf
+}
+
+// Fetch implements Fetcher. Errors wrapped with Permanent and the
+// cancellation of ctx stop the retries.
+func (rf *RetryingFetcher) Fetch(ctx context.Context, image string) ([]*sbom.Document, error) {
+	if rf.Fetcher == nil {
+		return nil, errors.New("retrying fetcher has no fetcher to wrap")
+	}
+
+	attempts := max(rf.Options.MaxAttempts, 1)
+	backoff := rf.Options.InitialBackoff
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", image, err)
+		}
+		if err := rf.wait(ctx); err != nil {
+			return nil, fmt.Errorf("fetching %s: %w", image, err)
+		}
+
+		var docs []*sbom.Document
+		docs, err = rf.attempt(ctx, image)
+		if err == nil {
+			return docs, nil
+		}
+
+		var perr *permanentError
+		if errors.As(err, &perr) || ctx.Err() != nil || attempt == attempts {
+			break
+		}
+
+		if err := rf.sleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		backoff = min(backoff*2, rf.Options.MaxBackoff)
+	}
+	return nil, fmt.Errorf("fetching %s: %w", image, err)
+}
+
+// attempt runs a single fetch bounded by the attempt timeout
+func (rf *RetryingFetcher) attempt(ctx context.Context, image string) ([]*sbom.Document, error) {
+	if rf.Options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, rf.Options.Timeout)
+		defer cancel()
+	}
+	return rf.Fetcher.Fetch(ctx, image)
+}
+
+// wait blocks until the rate limit allows a new fetch
+func (rf *RetryingFetcher) wait(ctx context.Context) error {
+	if rf.Options.RateLimit <= 0 {
+		return nil
+	}
+	interval := time.Duration(float64(time.Second) / rf.Options.RateLimit)
+
+	rf.mu.Lock()
+	now := time.Now()
+	slot := rf.next
+	if slot.Before(now) {
+		slot = now
+	}
+	rf.next = slot.Add(interval)
+	rf.mu.Unlock()
+
+	return rf.sleep(ctx, time.Until(slot))
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
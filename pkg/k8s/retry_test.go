@@ -0,0 +1,169 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// flakyFetcher fails the first failures fetches with err
+type flakyFetcher struct {
+	failures int
+	err      error
+	calls    int
+	timeouts int
+}
+
+func (f *flakyFetcher) Fetch(ctx context.Context, image string) ([]*sbom.Document, error) {
+	f.calls++
+	if _, ok := ctx.Deadline(); ok {
+		f.timeouts++
+	}
+	if f.calls <= f.failures {
+		return nil, f.err
+	}
+	return []*sbom.Document{imageDocument(image, true)}, nil
+}
+
+func TestRetryingFetcher(t *testing.T) {
+	for name, tc := range map[string]struct {
+		fetcher *flakyFetcher
+		opts    []RetryOption
+		calls   int
+		sleeps  []time.Duration
+		mustErr bool
+	}{
+		"first attempt": {
+			fetcher: &flakyFetcher{},
+			calls:   1,
+		},
+		"recovers": {
+			fetcher: &flakyFetcher{failures: 2, err: errors.New("503")},
+			opts:    []RetryOption{WithBackoff(time.Second, 10*time.Second)},
+			calls:   3,
+			sleeps:  []time.Duration{time.Second, 2 * time.Second},
+		},
+		"backoff capped": {
+			fetcher: &flakyFetcher{failures: 3, err: errors.New("503")},
+			opts:    []RetryOption{WithMaxAttempts(5), WithBackoff(time.Second, 3*time.Second)},
+			calls:   4,
+			sleeps:  []time.Duration{time.Second, 2 * time.Second, 3 * time.Second},
+		},
+		"gives up": {
+			fetcher: &flakyFetcher{failures: 5, err: errors.New("503")},
+			opts:    []RetryOption{WithMaxAttempts(2), WithBackoff(time.Second, time.Second)},
+			calls:   2,
+			sleeps:  []time.Duration{time.Second},
+			mustErr: true,
+		},
+		"permanent": {
+			fetcher: &flakyFetcher{failures: 5, err: Permanent(errors.New("not found"))},
+			calls:   1,
+			mustErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rf := NewRetryingFetcher(tc.fetcher, tc.opts...)
+			var sleeps []time.Duration
+			rf.sleep = func(_ context.Context, d time.Duration) error {
+				sleeps = append(sleeps, d)
+				return nil
+			}
+			docs, err := rf.Fetch(context.Background(), "nginx")
+			require.Equal(t, tc.calls, tc.fetcher.calls)
+			require.Equal(t, tc.sleeps, sleeps)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.ErrorIs(t, err, tc.fetcher.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, docs, 1)
+		})
+	}
+}
+
+func TestRetryingFetcherTimeout(t *testing.T) {
+	for name, tc := range map[string]struct {
+		opts     []RetryOption
+		timeouts int
+	}{
+		"with timeout":    {opts: []RetryOption{WithTimeout(time.Minute)}, timeouts: 1},
+		"without timeout": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := &flakyFetcher{}
+			_, err := NewRetryingFetcher(f, tc.opts...).Fetch(context.Background(), "nginx")
+			require.NoError(t, err)
+			require.Equal(t, tc.timeouts, f.timeouts)
+		})
+	}
+}
+
+func TestRetryingFetcherCancel(t *testing.T) {
+	for name, tc := range map[string]struct {
+		ctx   func() (context.Context, context.CancelFunc)
+		errIs error
+	}{
+		"canceled": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			errIs: context.Canceled,
+		},
+		"deadline exceeded": {
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+			},
+			errIs: context.DeadlineExceeded,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := &flakyFetcher{failures: 5, err: errors.New("503")}
+			ctx, cancel := tc.ctx()
+			defer cancel()
+			_, err := NewRetryingFetcher(f).Fetch(ctx, "nginx")
+			require.ErrorIs(t, err, tc.errIs)
+			require.Zero(t, f.calls)
+		})
+	}
+}
+
+func TestRetryingFetcherRateLimit(t *testing.T) {
+	for name, tc := range map[string]struct {
+		rate    float64
+		fetches int
+		// elapsed is the minimum time the fetches take
+		elapsed time.Duration
+	}{
+		// The third fetch waits for two 10ms slots
+		"limited":      {rate: 100, fetches: 3, elapsed: 15 * time.Millisecond},
+		"single fetch": {rate: 100, fetches: 1},
+		"unlimited":    {fetches: 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			f := &flakyFetcher{}
+			rf := NewRetryingFetcher(f, WithRateLimit(tc.rate))
+			start := time.Now()
+			for range tc.fetches {
+				_, err := rf.Fetch(context.Background(), "nginx")
+				require.NoError(t, err)
+			}
+			require.GreaterOrEqual(t, time.Since(start), tc.elapsed)
+			require.Equal(t, tc.fetches, f.calls)
+		})
+	}
+}
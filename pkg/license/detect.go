@@ -0,0 +1,268 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// PropertyDetectionConfidence records in the nodes the confidence of the
+// licenses detected from their texts, from 0 to 1
+const PropertyDetectionConfidence = "protobom:license:detection_confidence"
+
+// DefaultMinConfidence is the lowest score accepted as a detection
+const DefaultMinConfidence = 0.9
+
+// embeddedCorpusFS has the texts of the most common licenses
+//
+//go:embed corpus/*.txt
+var embeddedCorpusFS embed.FS
+
+var (
+	// copyrightLineRe matches the copyright statements, which change from
+	// one copy of a license to the next
+	copyrightLineRe = regexp.MustCompile(`(?im)^\W*(copyright\s+(\(c\)|©|\d|<)|(\(c\)|©)\s*\d).*$`)
+
+	// nonWordRe matches the punctuation and spacing between words
+	nonWordRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+	embeddedCorpus     *Corpus
+	embeddedCorpusOnce sync.Once
+)
+
+// Match is a license detected in a text
+type Match struct {
+	// ID is the SPDX identifier of the license
+	ID string
+
+	// Confidence is the similarity of the text with the license, from 0
+	// to 1
+	Confidence float64
+}
+
+// Corpus is a set of reference license texts to detect licenses from.
+// Texts are compared by their word bigrams, ignoring case, punctuation and
+// copyright statements, so reformatted copies still match.
+type Corpus struct {
+	texts map[string]map[string]struct{}
+}
+
+// NewCorpus returns an empty corpus
+func NewCorpus() *Corpus {
+	return &Corpus{texts: map[string]map[string]struct{}{}}
+}
+
+// EmbeddedCorpus returns a corpus of the most common licenses built into
+// protobom. Load the full SPDX corpus with LoadCorpus to detect others.
+func EmbeddedCorpus() *Corpus {
+	embeddedCorpusOnce.Do(func() {
+		c, err := loadCorpusFS(embeddedCorpusFS, "corpus")
+		if err != nil {
+			panic(fmt.Sprintf("loading embedded license corpus: %v", err))
+		}
+		embeddedCorpus = c
+	})
+	return embeddedCorpus
+}
+
+// LoadCorpus reads the license texts in dir, one per file named after
+// the license identifier with a .txt extension. This is the layout of the
+// text directory in the SPDX license-list-data repository. Deprecated
+// licenses are skipped.
+func LoadCorpus(dir string) (*Corpus, error) {
+	return loadCorpusFS(os.DirFS(dir), ".")
+}
+
+// loadCorpusFS reads the license texts in a directory of fsys
+func loadCorpusFS(fsys fs.FS, dir string) (*Corpus, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading corpus directory: %w", err)
+	}
+	c := NewCorpus()
+	for _, e := range entries {
+		id, ok := strings.CutSuffix(e.Name(), ".txt")
+		if e.IsDir() || !ok || strings.HasPrefix(id, "deprecated_") {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading license text: %w", err)
+		}
+		c.Add(id, string(data))
+	}
+	return c, nil
+}
+
+// Add registers the text of a license in the corpus
+func (c *Corpus) Add(id, text string) {
+	c.texts[id] = bigrams(text)
+}
+
+// Len returns the number of licenses in the corpus
+func (c *Corpus) Len() int {
+	return len(c.texts)
+}
+
+// Match returns the license of the corpus most similar to text. It returns
+// nil if the corpus is empty or the text has no words.
+func (c *Corpus) Match(text string) *Match {
+	candidate := bigrams(text)
+	if len(candidate) == 0 {
+		return nil
+	}
+	var best *Match
+	for _, id := range slices.Sorted(maps.Keys(c.texts)) {
+		score := dice(candidate, c.texts[id])
+		if best == nil || score > best.Confidence {
+			best = &Match{ID: id, Confidence: score}
+		}
+	}
+	return best
+}
+
+// bigrams returns the set of consecutive word pairs of the normalized text
+func bigrams(text string) map[string]struct{} {
+	text = copyrightLineRe.ReplaceAllString(text, "")
+	words := strings.Fields(nonWordRe.ReplaceAllString(strings.ToLower(text), " "))
+	set := make(map[string]struct{}, len(words))
+	for i := 1; i < len(words); i++ {
+		set[words[i-1]+" "+words[i]] = struct{}{}
+	}
+	return set
+}
+
+// dice returns the Sørensen-Dice coefficient of two sets
+func dice(a, b map[string]struct{}) float64 {
+	if len(a)+len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for k := range a {
+		if _, ok := b[k]; ok {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
+
+// DetectOptions controls the detection of licenses in documents
+type DetectOptions struct {
+	// Corpus has the reference texts. Defaults to the EmbeddedCorpus.
+	Corpus *Corpus
+
+	// MinConfidence is the lowest score accepted as a detection
+	MinConfidence float64
+}
+
+// DefaultDetectOptions is the set of options used when none are specified
+var DefaultDetectOptions = &DetectOptions{
+	MinConfidence: DefaultMinConfidence,
+}
+
+// DetectionReport lists the licenses detected in a document
+type DetectionReport struct {
+	// Detected has the licenses detected in each node, keyed by node ID
+	Detected map[string][]*Match
+
+	// Undetected lists the nodes with license texts that did not match
+	// the corpus with enough confidence
+	Undetected []string
+}
+
+// DetectLicenses fills in the concluded license of the nodes that only
+// declare custom licenses (LicenseRef-*) with their full text in the
+// document. Each text is matched against the corpus and, if all of them
+// are detected with enough confidence, the concluded license is set to the
+// detected identifiers and the lowest confidence is recorded in the node
+// PropertyDetectionConfidence property. Nodes with a concluded license are
+// left untouched.
+func DetectLicenses(doc *sbom.Document, opts *DetectOptions) *DetectionReport {
+	if opts == nil {
+		opts = DefaultDetectOptions
+	}
+	corpus := opts.Corpus
+	if corpus == nil {
+		corpus = EmbeddedCorpus()
+	}
+
+	report := &DetectionReport{Detected: map[string][]*Match{}, Undetected: []string{}}
+	for _, n := range doc.GetNodeList().GetNodes() {
+		if lc := strings.TrimSpace(n.LicenseConcluded); lc != "" && lc != spdx.NOASSERTION {
+			continue
+		}
+		texts := nodeLicenseTexts(doc.GetMetadata(), n)
+		if len(texts) == 0 {
+			continue
+		}
+
+		matches := []*Match{}
+		for _, text := range texts {
+			m := corpus.Match(text)
+			if m == nil || m.Confidence < opts.MinConfidence {
+				matches = nil
+				break
+			}
+			matches = append(matches, m)
+		}
+		if matches == nil {
+			report.Undetected = append(report.Undetected, n.Id)
+			continue
+		}
+
+		ids := []string{}
+		confidence := 1.0
+		for _, m := range matches {
+			if !slices.Contains(ids, m.ID) {
+				ids = append(ids, m.ID)
+			}
+			confidence = min(confidence, m.Confidence)
+		}
+		n.LicenseConcluded = strings.Join(ids, " AND ")
+		setNodeProperty(n, PropertyDetectionConfidence, strconv.FormatFloat(confidence, 'f', 2, 64))
+		report.Detected[n.Id] = matches
+	}
+	return report
+}
+
+// nodeLicenseTexts returns the texts of the custom licenses declared by the
+// node. It returns nil if the node declares a license without text.
+func nodeLicenseTexts(md *sbom.Metadata, n *sbom.Node) []string {
+	texts := []string{}
+	for _, id := range n.GetLicenses() {
+		if !sbom.IsLicenseRef(id) {
+			return nil
+		}
+		l := md.GetCustomLicense(id)
+		if strings.TrimSpace(l.GetText()) == "" {
+			return nil
+		}
+		texts = append(texts, l.GetText())
+	}
+	return texts
+}
+
+// setNodeProperty replaces the values of a node property
+func setNodeProperty(n *sbom.Node, name, data string) {
+	n.Properties = slices.DeleteFunc(n.Properties, func(p *sbom.Property) bool {
+		return p.GetName() == name
+	})
+	n.Properties = append(n.Properties, &sbom.Property{Name: name, Data: data})
+}
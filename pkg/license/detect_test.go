@@ -0,0 +1,198 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const testMITText = `Copyright (c) 2019 Jane Doe <jane@example.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+associated documentation files (the "Software"), to deal in the Software without restriction,
+including without limitation the rights to use, copy, modify, merge, publish, distribute,
+sublicense, and/or sell copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all copies or
+substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING BUT
+NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES
+OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.`
+
+func TestCorpusMatch(t *testing.T) {
+	corpus := EmbeddedCorpus()
+	require.Equal(t, 7, corpus.Len())
+
+	for name, id := range map[string]string{
+		"MIT": "MIT", "ISC": "ISC", "0BSD": "0BSD", "BSD-2-Clause": "BSD-2-Clause",
+		"BSD-3-Clause": "BSD-3-Clause", "Unlicense": "Unlicense", "Apache-2.0": "Apache-2.0",
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := embeddedCorpusFS.ReadFile("corpus/" + name + ".txt")
+			require.NoError(t, err)
+			m := corpus.Match(strings.ToUpper(string(data)))
+			require.Equal(t, id, m.ID)
+			require.InDelta(t, 1.0, m.Confidence, 0.001)
+		})
+	}
+
+	m := corpus.Match(testMITText)
+	require.Equal(t, "MIT", m.ID)
+	require.Greater(t, m.Confidence, 0.95)
+
+	m = corpus.Match("All rights reserved, do not redistribute this software")
+	require.Less(t, m.Confidence, DefaultMinConfidence)
+
+	require.Nil(t, corpus.Match("  "))
+	require.Nil(t, NewCorpus().Match(testMITText))
+}
+
+func TestLoadCorpus(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		files   map[string]string
+		missing bool
+		count   int
+		mustErr bool
+	}{
+		{
+			name: "license texts",
+			files: map[string]string{
+				"MIT.txt":                testMITText,
+				"deprecated_GPL-2.0.txt": "GPL",
+				"README.md":              "readme",
+			},
+			count: 1,
+		},
+		{
+			name:  "multiple licenses",
+			files: map[string]string{"MIT.txt": testMITText, "X11.txt": testMITText},
+			count: 2,
+		},
+		{
+			name: "empty directory",
+		},
+		{
+			name:    "missing directory",
+			missing: true,
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tc.files {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600))
+			}
+			if tc.missing {
+				dir = filepath.Join(dir, "missing")
+			}
+
+			corpus, err := LoadCorpus(dir)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.count, corpus.Len())
+		})
+	}
+}
+
+func TestDetectLicenses(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		licenses  []string
+		concluded string
+		opts      *DetectOptions
+		expected  string
+		// detected has the licenses detected in the node texts
+		detected   []string
+		undetected bool
+	}{
+		{
+			name:     "detected",
+			licenses: []string{"LicenseRef-mit"},
+			expected: "MIT",
+			detected: []string{"MIT"},
+		},
+		{
+			name:      "noassertion is replaced",
+			licenses:  []string{"LicenseRef-mit"},
+			concluded: "NOASSERTION",
+			expected:  "MIT",
+			detected:  []string{"MIT"},
+		},
+		{
+			name:      "concluded license is kept",
+			licenses:  []string{"LicenseRef-mit"},
+			concluded: "Apache-2.0",
+			expected:  "Apache-2.0",
+		},
+		{
+			name:       "custom text",
+			licenses:   []string{"LicenseRef-mit", "LicenseRef-custom"},
+			undetected: true,
+		},
+		{
+			// With a lower threshold, the custom license is matched too
+			name:     "custom text with lower threshold",
+			licenses: []string{"LicenseRef-mit", "LicenseRef-custom"},
+			opts:     &DetectOptions{MinConfidence: 0},
+			expected: "MIT AND BSD-3-Clause",
+			detected: []string{"MIT", "BSD-3-Clause"},
+		},
+		{
+			name:     "license without text",
+			licenses: []string{"LicenseRef-notext"},
+		},
+		{
+			name:     "identified license",
+			licenses: []string{"MIT", "LicenseRef-mit"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.CustomLicenses = []*sbom.License{
+				{Id: "LicenseRef-mit", Text: testMITText},
+				{Id: "LicenseRef-custom", Text: "This software may only be used on Tuesdays."},
+				{Id: "LicenseRef-notext"},
+			}
+			doc.NodeList.AddNode(&sbom.Node{Id: "node", Licenses: tc.licenses, LicenseConcluded: tc.concluded})
+
+			report := DetectLicenses(doc, tc.opts)
+			node := doc.NodeList.GetNodeByID("node")
+			require.Equal(t, tc.expected, node.LicenseConcluded)
+			if tc.undetected {
+				require.Equal(t, []string{"node"}, report.Undetected)
+			} else {
+				require.Empty(t, report.Undetected)
+			}
+			if tc.detected == nil {
+				require.Empty(t, report.Detected)
+				require.Empty(t, node.Properties)
+				return
+			}
+			detected := []string{}
+			for _, m := range report.Detected["node"] {
+				detected = append(detected, m.ID)
+			}
+			require.Equal(t, tc.detected, detected)
+			require.Equal(t, PropertyDetectionConfidence, node.Properties[0].Name)
+		})
+	}
+}
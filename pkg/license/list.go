@@ -0,0 +1,367 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package license validates and normalizes license identifiers against the
+// SPDX license list.
+//
+// A version of the list is embedded so validation works offline. A newer
+// list can be loaded at runtime with UpdateList. Each Verdict records the
+// version of the list it was made against, so results can be reproduced.
+package license
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/protobom/protobom/pkg/cache"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// DefaultListURL is the location of the SPDX license list published by SPDX
+const DefaultListURL = "https://spdx.org/licenses/licenses.json"
+
+// embeddedListData is the SPDX license list built into protobom
+//
+//go:embed spdx-licenses.json
+var embeddedListData []byte
+
+// Entry is a license or exception in the SPDX license list
+type Entry struct {
+	ID         string
+	Name       string
+	Deprecated bool
+}
+
+// List is a version of the SPDX license list. Identifiers are looked up
+// ignoring case, as required by the SPDX specification.
+type List struct {
+	Version    string
+	licenses   map[string]*Entry
+	exceptions map[string]*Entry
+}
+
+// listFile is the JSON format of the list published by SPDX. The exceptions
+// are published in a separate file but can be included in the same one.
+type listFile struct {
+	Version  string `json:"licenseListVersion"`
+	Licenses []struct {
+		ID         string `json:"licenseId"`
+		Name       string `json:"name"`
+		Deprecated bool   `json:"isDeprecatedLicenseId"`
+	} `json:"licenses"`
+	Exceptions []struct {
+		ID         string `json:"licenseExceptionId"`
+		Name       string `json:"name"`
+		Deprecated bool   `json:"isDeprecatedLicenseId"`
+	} `json:"exceptions"`
+}
+
+var (
+	embeddedList *List
+	currentList  atomic.Pointer[List]
+)
+
+func init() {
+	l, err := ParseList(bytes.NewReader(embeddedListData))
+	if err != nil {
+		panic(fmt.Sprintf("parsing embedded license list: %v", err))
+	}
+	embeddedList = l
+	currentList.Store(l)
+}
+
+// ParseList reads a license list in the JSON format published by SPDX
+func ParseList(r io.Reader) (*List, error) {
+	f := &listFile{}
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return nil, fmt.Errorf("decoding license list: %w", err)
+	}
+	if f.Version == "" {
+		return nil, errors.New("license list has no version")
+	}
+	if len(f.Licenses) == 0 {
+		return nil, errors.New("license list has no licenses")
+	}
+
+	l := &List{
+		Version:    f.Version,
+		licenses:   make(map[string]*Entry, len(f.Licenses)),
+		exceptions: make(map[string]*Entry, len(f.Exceptions)),
+	}
+	for _, e := range f.Licenses {
+		l.licenses[strings.ToLower(e.ID)] = &Entry{ID: e.ID, Name: e.Name, Deprecated: e.Deprecated}
+	}
+	for _, e := range f.Exceptions {
+		l.exceptions[strings.ToLower(e.ID)] = &Entry{ID: e.ID, Name: e.Name, Deprecated: e.Deprecated}
+	}
+	return l, nil
+}
+
+// Embedded returns the license list built into protobom
+func Embedded() *List {
+	return embeddedList
+}
+
+// Current returns the license list in use, the embedded one unless it was
+// replaced with UpdateList or SetList.
+func Current() *List {
+	return currentList.Load()
+}
+
+// SetList replaces the license list in use. A nil list restores the
+// embedded one.
+func SetList(l *List) {
+	if l == nil {
+		l = embeddedList
+	}
+	currentList.Store(l)
+}
+
+// listCacheKeyPrefix namespaces the license list entries in shared caches
+const listCacheKeyPrefix = "license.list:"
+
+// UpdateOptions configures how UpdateList fetches remote license lists
+type UpdateOptions struct {
+	// HTTPClient performs the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Cache stores the lists fetched, keyed by their URL, so batch runs
+	// don't download them again.
+	Cache cache.Cache
+
+	// TTL is the time the lists are kept in the cache. Zero keeps them
+	// until they are removed from the cache.
+	TTL time.Duration
+
+	// Offline only reads the lists from the cache, lists not cached
+	// return an error wrapping cache.ErrOffline.
+	Offline bool
+}
+
+// UpdateOption configures the UpdateOptions of UpdateList
+type UpdateOption func(*UpdateOptions)
+
+// WithHTTPClient sets the HTTP client used to fetch the license list
+func WithHTTPClient(hc *http.Client) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.HTTPClient = hc
+	}
+}
+
+// WithCache caches the license lists fetched in c for ttl
+func WithCache(c cache.Cache, ttl time.Duration) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.Cache = c
+		o.TTL = ttl
+	}
+}
+
+// WithOffline disables fetching the license lists not in the cache
+func WithOffline(offline bool) UpdateOption {
+	return func(o *UpdateOptions) {
+		o.Offline = offline
+	}
+}
+
+// UpdateList loads the license list from source and makes it the list in
+// use. The source is an http(s) URL, such as DefaultListURL, or the path
+// of a file. If the new list has no exceptions, the exceptions of the
+// current list are kept. Remote lists are fetched with the HTTP client
+// and cache set in the options.
+func UpdateList(ctx context.Context, source string, opts ...UpdateOption) (*List, error) {
+	o := &UpdateOptions{HTTPClient: http.DefaultClient}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		data, err = o.fetch(ctx, source)
+	} else {
+		data, err = os.ReadFile(source)
+		if err != nil {
+			err = fmt.Errorf("opening license list: %w", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := ParseList(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if len(l.exceptions) == 0 {
+		l.exceptions = Current().exceptions
+	}
+	SetList(l)
+	return l, nil
+}
+
+// fetch returns the license list at url, from the cache if set
+func (o *UpdateOptions) fetch(ctx context.Context, url string) ([]byte, error) {
+	if o.Cache == nil {
+		if o.Offline {
+			return nil, fmt.Errorf("fetching license list %s: %w", url, cache.ErrOffline)
+		}
+		return download(ctx, o.HTTPClient, url)
+	}
+
+	var load cache.LoadFunc
+	if !o.Offline {
+		load = func(ctx context.Context) ([]byte, error) {
+			data, err := download(ctx, o.HTTPClient, url)
+			if err != nil {
+				return nil, err
+			}
+			// Don't cache data that is not a license list
+			if _, err := ParseList(bytes.NewReader(data)); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+	}
+	data, err := cache.GetOrLoad(ctx, o.Cache, listCacheKeyPrefix+url, o.TTL, load)
+	if err != nil {
+		return nil, fmt.Errorf("fetching license list: %w", err)
+	}
+	return data, nil
+}
+
+// download fetches the data at url
+func download(ctx context.Context, hc *http.Client, url string) ([]byte, error) {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building license list request: %w", err)
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetching license list: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching license list: HTTP %s", resp.Status)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading license list: %w", err)
+	}
+	return data, nil
+}
+
+// License returns the license with the identifier id
+func (l *List) License(id string) (*Entry, bool) {
+	e, ok := l.licenses[strings.ToLower(strings.TrimSpace(id))]
+	return e, ok
+}
+
+// Exception returns the license exception with the identifier id
+func (l *List) Exception(id string) (*Entry, bool) {
+	e, ok := l.exceptions[strings.ToLower(strings.TrimSpace(id))]
+	return e, ok
+}
+
+// Normalize returns the identifier of a license with the case used in the
+// list. Identifiers not in the list are returned unchanged.
+func (l *List) Normalize(id string) string {
+	if e, ok := l.License(id); ok {
+		return e.ID
+	}
+	return id
+}
+
+// Verdict is the result of validating a license expression
+type Verdict struct {
+	// Expression is the expression validated and Normalized the expression
+	// with the identifiers in their canonical case. Normalized is empty if
+	// the expression can't be parsed.
+	Expression string
+	Normalized string
+
+	// ListVersion is the version of the license list used to validate
+	ListVersion string
+
+	// Unknown and Deprecated list the identifiers not in the list and the
+	// deprecated ones. Custom LicenseRef-* licenses are never unknown.
+	Unknown    []string
+	Deprecated []string
+
+	// Error is set when the expression can't be parsed
+	Error string
+}
+
+// Valid returns true if the expression parsed and all its identifiers are
+// in the license list
+func (v *Verdict) Valid() bool {
+	return v.Error == "" && len(v.Unknown) == 0
+}
+
+// Validate checks the identifiers of a license expression against the list
+func (l *List) Validate(expression string) *Verdict {
+	v := &Verdict{
+		Expression:  expression,
+		ListVersion: l.Version,
+	}
+	expr, err := sbom.ParseLicenseExpression(expression)
+	if err != nil {
+		v.Error = err.Error()
+		return v
+	}
+	l.checkExpression(expr, v)
+	v.Normalized = expr.String()
+	return v
+}
+
+// checkExpression validates the identifiers of expr, normalizing their case
+func (l *List) checkExpression(expr *sbom.LicenseExpression, v *Verdict) {
+	for _, a := range expr.Args {
+		l.checkExpression(a, v)
+	}
+	if expr.Operator != "" {
+		return
+	}
+
+	switch e, ok := l.License(expr.License); {
+	case ok:
+		expr.License = e.ID
+		if e.Deprecated {
+			v.Deprecated = append(v.Deprecated, e.ID)
+		}
+	case !sbom.IsLicenseRef(expr.License) && !strings.HasPrefix(expr.License, "DocumentRef-"):
+		v.Unknown = append(v.Unknown, expr.License)
+	}
+
+	if expr.Exception == "" {
+		return
+	}
+	if e, ok := l.Exception(expr.Exception); ok {
+		expr.Exception = e.ID
+		if e.Deprecated {
+			v.Deprecated = append(v.Deprecated, e.ID)
+		}
+		return
+	}
+	v.Unknown = append(v.Unknown, expr.Exception)
+}
+
+// Validate checks a license expression against the license list in use
+func Validate(expression string) *Verdict {
+	return Current().Validate(expression)
+}
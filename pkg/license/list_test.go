@@ -0,0 +1,296 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package license
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/cache"
+)
+
+const testList = `{
+  "licenseListVersion": "3.99",
+  "licenses": [
+    {"licenseId": "MIT", "name": "MIT License", "isDeprecatedLicenseId": false},
+    {"licenseId": "New-License-1.0", "name": "A New License", "isDeprecatedLicenseId": false}
+  ]
+}`
+
+func TestEmbeddedList(t *testing.T) {
+	l := Embedded()
+	require.NotEmpty(t, l.Version)
+
+	for _, tc := range []struct {
+		name       string
+		id         string
+		exception  bool
+		found      bool
+		canonical  string
+		deprecated bool
+	}{
+		{name: "license ignores case", id: "apache-2.0", found: true, canonical: "Apache-2.0"},
+		{name: "deprecated license", id: "GPL-2.0+", found: true, canonical: "GPL-2.0+", deprecated: true},
+		{name: "exception", id: "classpath-exception-2.0", exception: true, found: true, canonical: "Classpath-exception-2.0"},
+		{name: "exception is not a license", id: "Classpath-exception-2.0"},
+		{name: "unknown license", id: "Not-A-License"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var e *Entry
+			var ok bool
+			if tc.exception {
+				e, ok = l.Exception(tc.id)
+			} else {
+				e, ok = l.License(tc.id)
+			}
+			require.Equal(t, tc.found, ok)
+			if !tc.found {
+				return
+			}
+			require.Equal(t, tc.canonical, e.ID)
+			require.Equal(t, tc.deprecated, e.Deprecated)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	l := Embedded()
+	for name, tc := range map[string]struct {
+		expression string
+		normalized string
+		unknown    []string
+		deprecated []string
+		valid      bool
+		mustErr    bool
+	}{
+		"single":    {expression: "MIT", normalized: "MIT", valid: true},
+		"case":      {expression: "mit or apache-2.0", normalized: "MIT OR Apache-2.0", valid: true},
+		"exception": {expression: "GPL-2.0-only WITH classpath-exception-2.0", normalized: "GPL-2.0-only WITH Classpath-exception-2.0", valid: true},
+		"licenseref": {
+			expression: "MIT AND LicenseRef-custom", normalized: "MIT AND LicenseRef-custom", valid: true,
+		},
+		"unknown": {
+			expression: "MIT AND Made-Up-1.0", normalized: "MIT AND Made-Up-1.0", unknown: []string{"Made-Up-1.0"},
+		},
+		"unknown exception": {
+			expression: "GPL-2.0-only WITH Made-Up-exception", normalized: "GPL-2.0-only WITH Made-Up-exception",
+			unknown: []string{"Made-Up-exception"},
+		},
+		"deprecated": {
+			expression: "GPL-2.0+", normalized: "GPL-2.0+", deprecated: []string{"GPL-2.0+"}, valid: true,
+		},
+		"invalid": {expression: "MIT AND", mustErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			v := l.Validate(tc.expression)
+			require.Equal(t, l.Version, v.ListVersion)
+			require.Equal(t, tc.valid, v.Valid())
+			if tc.mustErr {
+				require.NotEmpty(t, v.Error)
+				return
+			}
+			require.Empty(t, v.Error)
+			require.Equal(t, tc.normalized, v.Normalized)
+			require.Equal(t, tc.unknown, v.Unknown)
+			require.Equal(t, tc.deprecated, v.Deprecated)
+		})
+	}
+}
+
+func TestUpdateList(t *testing.T) {
+	t.Cleanup(func() { SetList(nil) })
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/licenses.json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testList)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "licenses.json")
+	require.NoError(t, os.WriteFile(path, []byte(testList), 0o600))
+
+	for _, tc := range []struct {
+		name    string
+		source  string
+		mustErr bool
+	}{
+		{name: "url", source: srv.URL + "/licenses.json"},
+		{name: "file", source: path},
+		{name: "missing url", source: srv.URL + "/missing.json", mustErr: true},
+		{name: "missing file", source: filepath.Join(t.TempDir(), "missing.json"), mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			SetList(nil)
+			require.False(t, Validate("New-License-1.0").Valid())
+
+			l, err := UpdateList(ctx, tc.source)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Same(t, Embedded(), Current())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "3.99", l.Version)
+			require.Same(t, l, Current())
+
+			// The exceptions of the current list are kept
+			v := Validate("New-License-1.0 WITH Classpath-exception-2.0")
+			require.True(t, v.Valid())
+			require.Equal(t, "3.99", v.ListVersion)
+
+			SetList(nil)
+			require.Same(t, Embedded(), Current())
+		})
+	}
+}
+
+// redirectTransport sends all the requests to host
+type redirectTransport struct {
+	host string
+}
+
+func (rt *redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Host = rt.host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestUpdateListCache(t *testing.T) {
+	t.Cleanup(func() { SetList(nil) })
+	ctx := context.Background()
+
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/invalid.json" {
+			w.Write([]byte("not a list")) //nolint:errcheck
+			return
+		}
+		w.Write([]byte(testList)) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	// The injected client sends the requests to the test server
+	client := srv.Client()
+	client.Transport = &redirectTransport{host: strings.TrimPrefix(srv.URL, "http://")}
+
+	for _, tc := range []struct {
+		name     string
+		url      string
+		opts     func(c cache.Cache) []UpdateOption
+		requests int32
+		errIs    error
+		mustErr  bool
+	}{
+		{
+			name:     "no cache",
+			url:      srv.URL + "/licenses.json",
+			opts:     func(cache.Cache) []UpdateOption { return nil },
+			requests: 2,
+		},
+		{
+			name:     "injected client",
+			url:      "http://licenses.example/licenses.json",
+			opts:     func(cache.Cache) []UpdateOption { return []UpdateOption{WithHTTPClient(client)} },
+			requests: 2,
+		},
+		{
+			name: "cached",
+			url:  srv.URL + "/licenses.json",
+			opts: func(c cache.Cache) []UpdateOption {
+				return []UpdateOption{WithCache(c, time.Hour)}
+			},
+			requests: 1,
+		},
+		{
+			name: "offline without cache",
+			url:  srv.URL + "/licenses.json",
+			opts: func(cache.Cache) []UpdateOption {
+				return []UpdateOption{WithOffline(true)}
+			},
+			errIs: cache.ErrOffline,
+		},
+		{
+			name: "offline with an empty cache",
+			url:  srv.URL + "/licenses.json",
+			opts: func(c cache.Cache) []UpdateOption {
+				return []UpdateOption{WithCache(c, 0), WithOffline(true)}
+			},
+			errIs: cache.ErrOffline,
+		},
+		{
+			name: "invalid lists are not cached",
+			url:  srv.URL + "/invalid.json",
+			opts: func(c cache.Cache) []UpdateOption {
+				return []UpdateOption{WithCache(c, time.Hour)}
+			},
+			requests: 2,
+			mustErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			requests.Store(0)
+			opts := tc.opts(cache.NewMemory())
+			for range 2 {
+				l, err := UpdateList(ctx, tc.url, opts...)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+					continue
+				}
+				if tc.mustErr {
+					require.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+				require.Equal(t, "3.99", l.Version)
+			}
+			require.Equal(t, tc.requests, requests.Load())
+		})
+	}
+}
+
+func TestUpdateListPrimedCache(t *testing.T) {
+	t.Cleanup(func() { SetList(nil) })
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name    string
+		cached  string
+		offline bool
+		mustErr bool
+	}{
+		{name: "offline", cached: testList, offline: true},
+		{name: "online", cached: testList},
+		{name: "invalid cached list", cached: "not a list", offline: true, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := cache.NewMemory()
+			require.NoError(t, c.Set(ctx, listCacheKeyPrefix+DefaultListURL, []byte(tc.cached), 0))
+
+			l, err := UpdateList(ctx, DefaultListURL, WithCache(c, 0), WithOffline(tc.offline))
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "3.99", l.Version)
+		})
+	}
+}
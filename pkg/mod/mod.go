@@ -25,3 +25,16 @@ const SPDX_RENDER_PROPERTIES_IN_ANNOTATIONS = Mod("SPDX_RENDER_PROPERTIES")
 // annotations by "protobom - v1.0.0" into an sbom.Property and store it in the
 // node properties.
 const SPDX_READ_ANNOTATIONS_TO_PROPERTIES = Mod("SPDX_READ_ANNOTATIONS_TO_PROPERTIES")
+
+// CDX_READ_DEPENDENCIES_AS_DEPENDS_ON is a mod that causes the CycloneDX
+// unserializer to read the BOM dependency graph as dependsOn edges. By
+// default dependencies are read as contains edges, the same type used for
+// nested components, which makes both relationships indistinguishable when
+// the document is converted to SPDX.
+const CDX_READ_DEPENDENCIES_AS_DEPENDS_ON = Mod("CDX_READ_DEPENDENCIES_AS_DEPENDS_ON")
+
+// CDX_RENDER_CONTAINS_AS_COMPONENTS_ONLY is a mod that causes the CycloneDX
+// serializer to leave the contains edges out of the dependency graph. The
+// containment is still expressed by nesting the components, so the
+// dependency graph only records the dependency relationships.
+const CDX_RENDER_CONTAINS_AS_COMPONENTS_ONLY = Mod("CDX_RENDER_CONTAINS_AS_COMPONENTS_ONLY")
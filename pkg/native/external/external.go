@@ -0,0 +1,178 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package external implements serializer and unserializer drivers backed by
+// external programs. It lets converters written in any language add formats
+// to protobom without linking into the Go binary.
+//
+// The driver runs the program once per document, with the operation name
+// appended to the configured arguments:
+//
+//	unserialize  reads the native document from stdin and writes the
+//	             protobom document, encoded as protobuf, to stdout.
+//	serialize    reads the protobom document, encoded as protobuf, from
+//	             stdin and writes the native document to stdout.
+//
+// The options are passed in the environment: PROTOBOM_MODS holds the
+// comma-separated list of enabled mods and PROTOBOM_INDENT the number of
+// spaces to indent the rendered document (zero when minified). A non-zero
+// exit status fails the operation, the program's stderr is included in the
+// returned error.
+package external
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/mod"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Operations requested to the external program
+const (
+	OperationSerialize   = "serialize"
+	OperationUnserialize = "unserialize"
+)
+
+// Environment variables passed to the external program
+const (
+	EnvMods   = "PROTOBOM_MODS"
+	EnvIndent = "PROTOBOM_INDENT"
+)
+
+var (
+	_ native.Serializer   = &Driver{}
+	_ native.Unserializer = &Driver{}
+)
+
+// Driver is a format driver that delegates the conversion to an external
+// program. It can be registered both as a reader unserializer and as a
+// writer serializer.
+type Driver struct {
+	// Command is the path or name of the program to run
+	Command string
+
+	// Args are passed to the program before the operation name
+	Args []string
+
+	// Env lists additional environment variables in "KEY=value" form
+	Env []string
+}
+
+// New returns a driver that runs command with args
+func New(command string, args ...string) *Driver {
+	return &Driver{
+		Command: command,
+		Args:    args,
+	}
+}
+
+// Unserialize sends the native document to the external program and parses
+// the protobom document it returns.
+func (d *Driver) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
+	var mods map[mod.Mod]struct{}
+	if opts != nil {
+		mods = opts.Mods
+	}
+
+	out, err := d.run(OperationUnserialize, r, modsEnv(mods))
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &sbom.Document{}
+	if err := proto.Unmarshal(out, doc); err != nil {
+		return nil, fmt.Errorf("parsing document from %s: %w", d.Command, err)
+	}
+	return doc, nil
+}
+
+// Serialize returns the document unchanged, the external program is run
+// when the document is rendered.
+func (d *Driver) Serialize(bom *sbom.Document, opts *native.SerializeOptions, _ interface{}) (interface{}, error) {
+	if bom == nil {
+		return nil, errors.New("unable to serialize nil document")
+	}
+	var mods map[mod.Mod]struct{}
+	if opts != nil {
+		mods = opts.Mods
+	}
+	return &serializedDocument{doc: bom, mods: mods}, nil
+}
+
+// Render sends the document to the external program and writes the native
+// document it returns to w.
+func (d *Driver) Render(doc interface{}, w io.Writer, ro *native.RenderOptions, _ interface{}) error {
+	sdoc, ok := doc.(*serializedDocument)
+	if !ok {
+		return fmt.Errorf("unable to render %T, expected document from the external driver", doc)
+	}
+
+	data, err := proto.Marshal(sdoc.doc)
+	if err != nil {
+		return fmt.Errorf("marshaling document: %w", err)
+	}
+
+	env := append(modsEnv(sdoc.mods), fmt.Sprintf("%s=%d", EnvIndent, len(ro.IndentString())))
+	out, err := d.run(OperationSerialize, bytes.NewReader(data), env)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(out); err != nil {
+		return fmt.Errorf("writing rendered document: %w", err)
+	}
+	return nil
+}
+
+// serializedDocument carries the document and the serializer options
+// from Serialize to Render.
+type serializedDocument struct {
+	doc  *sbom.Document
+	mods map[mod.Mod]struct{}
+}
+
+// run executes the program with the operation, feeding it stdin. It returns
+// the program's output.
+func (d *Driver) run(operation string, stdin io.Reader, env []string) ([]byte, error) {
+	if d.Command == "" {
+		return nil, errors.New("external driver has no command configured")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(d.Command, append(slices.Clone(d.Args), operation)...) //nolint:gosec // The command is set by the driver owner
+	cmd.Stdin = stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = slices.Concat(os.Environ(), d.Env, env)
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("running %s %s: %w: %s", d.Command, operation, err, msg)
+		}
+		return nil, fmt.Errorf("running %s %s: %w", d.Command, operation, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// modsEnv returns the environment variable listing the enabled mods
+func modsEnv(mods map[mod.Mod]struct{}) []string {
+	names := make([]string, 0, len(mods))
+	for m := range mods {
+		names = append(names, string(m))
+	}
+	slices.Sort(names)
+	return []string{EnvMods + "=" + strings.Join(names, ",")}
+}
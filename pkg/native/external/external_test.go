@@ -0,0 +1,178 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/mod"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const helperEnv = "PROTOBOM_TEST_EXTERNAL_DRIVER"
+
+// TestMain runs the test binary as a fake external driver when the helper
+// environment variable is set. The fake format is a list of node names, one
+// per line.
+func TestMain(m *testing.M) {
+	if os.Getenv(helperEnv) == "" {
+		os.Exit(m.Run())
+	}
+
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		os.Exit(2)
+	}
+	switch os.Args[len(os.Args)-1] {
+	case OperationUnserialize:
+		if len(input) == 0 {
+			fmt.Fprintln(os.Stderr, "empty document")
+			os.Exit(1)
+		}
+		doc := sbom.NewDocument()
+		doc.Metadata.Name = os.Getenv(EnvMods)
+		for _, name := range strings.Fields(string(input)) {
+			doc.NodeList.AddNode(&sbom.Node{Id: name, Name: name})
+		}
+		out, err := proto.Marshal(doc)
+		if err != nil {
+			os.Exit(2)
+		}
+		os.Stdout.Write(out) //nolint:errcheck
+	case OperationSerialize:
+		doc := &sbom.Document{}
+		if err := proto.Unmarshal(input, doc); err != nil {
+			os.Exit(2)
+		}
+		fmt.Printf("indent=%s\n", os.Getenv(EnvIndent))
+		for _, n := range doc.GetNodeList().GetNodes() {
+			fmt.Println(n.Name)
+		}
+	default:
+		os.Exit(3)
+	}
+	os.Exit(0)
+}
+
+func newTestDriver(t *testing.T) *Driver {
+	t.Helper()
+	exe, err := os.Executable()
+	require.NoError(t, err)
+	d := New(exe, "-test.run=^$")
+	d.Env = []string{helperEnv + "=1"}
+	return d
+}
+
+func TestUnserialize(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		driver func(*testing.T) *Driver
+		input  string
+		mods   map[mod.Mod]struct{}
+		nodes  []string
+		// metadataName is the mods list the fake driver stores in the name
+		metadataName string
+		errContains  string
+		mustErr      bool
+	}{
+		{
+			name:         "nodes and mods",
+			driver:       newTestDriver,
+			input:        "a\nb\n",
+			mods:         map[mod.Mod]struct{}{"B": {}, "A": {}},
+			nodes:        []string{"a", "b"},
+			metadataName: "A,B",
+		},
+		{
+			name:   "no mods",
+			driver: newTestDriver,
+			input:  "a\n",
+			nodes:  []string{"a"},
+		},
+		{
+			// The driver error output is included in the error
+			name:        "driver error",
+			driver:      newTestDriver,
+			input:       "",
+			mustErr:     true,
+			errContains: "empty document",
+		},
+		{
+			name:    "no command",
+			driver:  func(*testing.T) *Driver { return New("") },
+			input:   "a",
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := tc.driver(t).Unserialize(strings.NewReader(tc.input), &native.UnserializeOptions{Mods: tc.mods}, nil)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			names := []string{}
+			for _, n := range doc.NodeList.Nodes {
+				names = append(names, n.Name)
+			}
+			require.Equal(t, tc.nodes, names)
+			require.Equal(t, tc.metadataName, doc.Metadata.Name)
+		})
+	}
+}
+
+func TestSerializeRender(t *testing.T) {
+	d := newTestDriver(t)
+	doc := sbom.NewDocument()
+	doc.NodeList.AddNode(&sbom.Node{Id: "a", Name: "a"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "b", Name: "b"})
+
+	for _, tc := range []struct {
+		name     string
+		doc      *sbom.Document
+		opts     *native.RenderOptions
+		expected string
+		mustErr  bool
+	}{
+		{name: "indented", doc: doc, opts: &native.RenderOptions{Indent: 2}, expected: "indent=2\na\nb\n"},
+		{name: "minified", doc: doc, opts: &native.RenderOptions{Indent: 2, Minify: true}, expected: "indent=0\na\nb\n"},
+		{name: "empty document", doc: sbom.NewDocument(), opts: &native.RenderOptions{}, expected: "indent=0\n"},
+		{name: "nil document", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			serialized, err := d.Serialize(tc.doc, &native.SerializeOptions{}, nil)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, d.Render(serialized, &buf, tc.opts, nil))
+			require.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestRenderInvalidDocument(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		serialized any
+	}{
+		{name: "string", serialized: "not a document"},
+		{name: "nil", serialized: nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.Error(t, newTestDriver(t).Render(tc.serialized, &buf, nil, nil))
+		})
+	}
+}
@@ -4,6 +4,9 @@ package native
 
 import (
 	"io"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/sbom"
@@ -15,16 +18,71 @@ type Serializer interface {
 	Render(interface{}, io.Writer, *RenderOptions, interface{}) error
 }
 
+// Line endings supported in RenderOptions.LineEnding
+const (
+	LineEndingLF   = "\n"
+	LineEndingCRLF = "\r\n"
+)
+
 type RenderOptions struct {
+	// Indent is the number of spaces used to indent the JSON documents of
+	// the SPDX and GitHub serializers. Zero renders them without whitespace.
+	// The CycloneDX encoders use their own fixed indentation.
 	Indent int
+
+	// Minify renders the documents without whitespace, regardless of the
+	// value of Indent. CycloneDX documents are only minified when set.
+	Minify bool
+
+	// LineEnding is the line terminator of the rendered documents, either
+	// LineEndingLF (the default) or LineEndingCRLF. It is applied by the
+	// writer to the output of all serializers.
+	LineEnding string
+}
+
+// IndentString returns the string used to indent each level of the rendered
+// documents. It is empty when the output is minified.
+func (ro *RenderOptions) IndentString() string {
+	if ro == nil || ro.Minify || ro.Indent <= 0 {
+		return ""
+	}
+	return strings.Repeat(" ", ro.Indent)
 }
 
 type SerializeOptions struct {
 	Mods map[mod.Mod]struct{}
+
+	// FieldMask limits the document fields passed to the serializer. When
+	// set, only the fields listed in the mask are emitted (protobuf FieldMask
+	// semantics). Paths are relative to the document and can traverse
+	// repeated fields, eg "node_list.nodes.name".
+	FieldMask *fieldmaskpb.FieldMask
+
+	// ExcludeFieldMask lists document fields that will not be emitted,
+	// for example "node_list.nodes.hashes" or "metadata.authors.email".
+	ExcludeFieldMask *fieldmaskpb.FieldMask
+
+	// CustomPurposes maps protobom purposes to the SPDX primary package
+	// purpose or CycloneDX component type written by the serializers. Its
+	// entries take precedence over the built-in mapping.
+	CustomPurposes map[sbom.Purpose]string
+}
+
+// CustomPurpose returns the format label configured for a purpose in
+// CustomPurposes.
+func (so *SerializeOptions) CustomPurpose(p sbom.Purpose) (string, bool) {
+	if so == nil {
+		return "", false
+	}
+	label, ok := so.CustomPurposes[p]
+	return label, ok
 }
 
 // IsModEnabled returns true when the passed mod is enabled in the options set.
 func (so *SerializeOptions) IsModEnabled(m mod.Mod) bool {
+	if so == nil {
+		return false
+	}
 	_, ok := so.Mods[m]
 	return ok
 }
@@ -0,0 +1,210 @@
+package serializers
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Identifiers of the SPDX 3.0 profiles the serializer can check
+const (
+	ProfileCore      = "core"
+	ProfileSoftware  = "software"
+	ProfileSecurity  = "security"
+	ProfileLicensing = "simpleLicensing"
+)
+
+// profileCheckers maps the profiles to the functions checking a document
+// conforms to them
+var profileCheckers = map[string]func(*sbom.Document) []*ProfileViolation{
+	ProfileCore:      checkCoreProfile,
+	ProfileSoftware:  checkSoftwareProfile,
+	ProfileSecurity:  checkSecurityProfile,
+	ProfileLicensing: checkLicensingProfile,
+}
+
+// ProfileViolation is an element that prevents a document from claiming
+// conformance to an SPDX 3.0 profile
+type ProfileViolation struct {
+	Profile string
+
+	// ElementID is the ID of the offending node or vulnerability, empty when
+	// the violation is in the document itself
+	ElementID string
+
+	Message string
+}
+
+func (v *ProfileViolation) String() string {
+	if v.ElementID == "" {
+		return fmt.Sprintf("%s: %s", v.Profile, v.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", v.Profile, v.ElementID, v.Message)
+}
+
+// ProfileConformanceError is returned by the serializer when the document
+// does not conform to the profiles selected in the options
+type ProfileConformanceError struct {
+	Violations []*ProfileViolation
+}
+
+func (e *ProfileConformanceError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.String())
+	}
+	return fmt.Sprintf("document does not conform to the SPDX 3.0 profiles: %s", strings.Join(msgs, "; "))
+}
+
+// ValidateProfiles checks a document against the conformance requirements
+// of SPDX 3.0 profiles and returns the elements preventing it from claiming
+// them. The core profile is always checked as every other profile builds on
+// it. Unknown profiles are reported as violations.
+func ValidateProfiles(bom *sbom.Document, profiles ...string) []*ProfileViolation {
+	violations := []*ProfileViolation{}
+	if !slices.Contains(profiles, ProfileCore) {
+		profiles = append([]string{ProfileCore}, profiles...)
+	}
+	for _, p := range profiles {
+		check, ok := profileCheckers[p]
+		if !ok {
+			violations = append(violations, &ProfileViolation{Profile: p, Message: "unsupported profile"})
+			continue
+		}
+		violations = append(violations, check(bom)...)
+	}
+	return violations
+}
+
+// checkCoreProfile requires the creation info and the element identifiers,
+// and relationships between known elements
+func checkCoreProfile(bom *sbom.Document) []*ProfileViolation {
+	violations := []*ProfileViolation{}
+	add := func(id, format string, args ...any) {
+		violations = append(violations, &ProfileViolation{
+			Profile: ProfileCore, ElementID: id, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if len(bom.GetMetadata().GetAuthors()) == 0 && len(bom.GetMetadata().GetTools()) == 0 {
+		add("", "creation info has no agent or tool that created it")
+	}
+	ids := map[string]struct{}{}
+	for _, n := range bom.GetNodeList().GetNodes() {
+		if n.GetId() == "" {
+			add("", "node %q has no identifier", n.GetName())
+			continue
+		}
+		ids[n.GetId()] = struct{}{}
+	}
+	if len(bom.GetNodeList().GetRootElements()) == 0 {
+		add("", "document has no root elements")
+	}
+	for _, id := range bom.GetNodeList().GetRootElements() {
+		if _, ok := ids[id]; !ok {
+			add(id, "root element not found in the document")
+		}
+	}
+	for _, e := range bom.GetNodeList().GetEdges() {
+		if e.GetType() == sbom.Edge_UNKNOWN {
+			add(e.GetFrom(), "relationship has no type")
+		}
+		if _, ok := ids[e.GetFrom()]; !ok {
+			add(e.GetFrom(), "%s relationship from an element not in the document", e.GetType())
+		}
+		if len(e.GetTo()) == 0 {
+			add(e.GetFrom(), "%s relationship has no targets", e.GetType())
+		}
+		for _, to := range e.GetTo() {
+			if _, ok := ids[to]; !ok {
+				add(e.GetFrom(), "%s relationship to %s, not in the document", e.GetType(), to)
+			}
+		}
+	}
+	return violations
+}
+
+// checkSoftwareProfile requires the names of the software artifacts and
+// the versions or package URLs of the packages
+func checkSoftwareProfile(bom *sbom.Document) []*ProfileViolation {
+	violations := []*ProfileViolation{}
+	for _, n := range bom.GetNodeList().GetNodes() {
+		if n.GetName() == "" {
+			violations = append(violations, &ProfileViolation{
+				Profile: ProfileSoftware, ElementID: n.GetId(), Message: "software artifact has no name",
+			})
+		}
+		if n.GetType() == sbom.Node_PACKAGE && n.GetVersion() == "" && n.Purl() == "" {
+			violations = append(violations, &ProfileViolation{
+				Profile: ProfileSoftware, ElementID: n.GetId(), Message: "package has no version or package URL",
+			})
+		}
+	}
+	return violations
+}
+
+// checkSecurityProfile requires vulnerabilities with identifiers affecting
+// elements of the document, and the justification of the not affected VEX
+// assessments
+func checkSecurityProfile(bom *sbom.Document) []*ProfileViolation {
+	violations := []*ProfileViolation{}
+	add := func(id, format string, args ...any) {
+		violations = append(violations, &ProfileViolation{
+			Profile: ProfileSecurity, ElementID: id, Message: fmt.Sprintf(format, args...),
+		})
+	}
+	for i, v := range bom.GetVulnerabilities() {
+		if v.GetId() == "" {
+			add("", "vulnerability #%d has no identifier", i+1)
+			continue
+		}
+		for _, nodeID := range v.GetAffects() {
+			if bom.GetNodeList().GetNodeByID(nodeID) == nil {
+				add(v.GetId(), "affects %s, not in the document", nodeID)
+				continue
+			}
+			a := v.AnalysisFor(nodeID)
+			if a.GetState() == sbom.Vulnerability_Analysis_NOT_AFFECTED &&
+				a.GetJustification() == sbom.Vulnerability_Analysis_UNKNOWN_JUSTIFICATION && a.GetDetail() == "" {
+				add(v.GetId(), "not affected assessment of %s has no justification or impact statement", nodeID)
+			}
+		}
+	}
+	return violations
+}
+
+// checkLicensingProfile requires valid license expressions and the text of
+// the custom licenses they reference
+func checkLicensingProfile(bom *sbom.Document) []*ProfileViolation {
+	violations := []*ProfileViolation{}
+	add := func(id, format string, args ...any) {
+		violations = append(violations, &ProfileViolation{
+			Profile: ProfileLicensing, ElementID: id, Message: fmt.Sprintf(format, args...),
+		})
+	}
+	for _, n := range bom.GetNodeList().GetNodes() {
+		expressions := slices.Clone(n.GetLicenses())
+		if n.GetLicenseConcluded() != "" {
+			expressions = append(expressions, n.GetLicenseConcluded())
+		}
+		for _, s := range expressions {
+			if s == spdx.NOASSERTION || s == spdx.NONE {
+				continue
+			}
+			expr, err := sbom.ParseLicenseExpression(s)
+			if err != nil {
+				add(n.GetId(), "invalid license expression %q: %v", s, err)
+				continue
+			}
+			for _, id := range expr.Licenses() {
+				if sbom.IsLicenseRef(id) && bom.GetMetadata().GetCustomLicense(id).GetText() == "" {
+					add(n.GetId(), "custom license %s has no text", id)
+				}
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,132 @@
+package serializers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func profileTestDocument() *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Authors = []*sbom.Person{{Name: "ACME"}}
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0", Licenses: []string{"MIT AND LicenseRef-acme"}})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Version: "2.0", LicenseConcluded: "NOASSERTION"})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+	doc.Metadata.AddCustomLicense(&sbom.License{Id: "LicenseRef-acme", Text: "All rights reserved"})
+	doc.Vulnerabilities = []*sbom.Vulnerability{{
+		Id: "CVE-2024-0001", Affects: []string{"lib"},
+		Analysis: &sbom.Vulnerability_Analysis{
+			State:         sbom.Vulnerability_Analysis_NOT_AFFECTED,
+			Justification: sbom.Vulnerability_Analysis_CODE_NOT_REACHABLE,
+		},
+	}}
+	return doc
+}
+
+func TestValidateProfiles(t *testing.T) {
+	all := []string{ProfileSoftware, ProfileSecurity, ProfileLicensing}
+	for name, tc := range map[string]struct {
+		prepare  func(*sbom.Document)
+		profiles []string
+		expected []string
+	}{
+		"conformant": {profiles: all, expected: []string{}},
+		"core is always checked": {
+			prepare:  func(d *sbom.Document) { d.Metadata.Authors = nil },
+			profiles: []string{ProfileSoftware},
+			expected: []string{"core: creation info has no agent or tool that created it"},
+		},
+		"dangling relationship": {
+			prepare: func(d *sbom.Document) {
+				d.NodeList.Edges = append(d.NodeList.Edges, &sbom.Edge{Type: sbom.Edge_contains, From: "app", To: []string{"nope"}})
+			},
+			expected: []string{"core: app: contains relationship to nope, not in the document"},
+		},
+		"unversioned package": {
+			prepare:  func(d *sbom.Document) { d.NodeList.GetNodeByID("lib").Version = "" },
+			profiles: all,
+			expected: []string{"software: lib: package has no version or package URL"},
+		},
+		"unversioned package not checked without software profile": {
+			prepare:  func(d *sbom.Document) { d.NodeList.GetNodeByID("lib").Version = "" },
+			profiles: []string{ProfileSecurity},
+			expected: []string{},
+		},
+		"unjustified not affected": {
+			prepare: func(d *sbom.Document) {
+				d.Vulnerabilities[0].Analysis.Justification = sbom.Vulnerability_Analysis_UNKNOWN_JUSTIFICATION
+			},
+			profiles: all,
+			expected: []string{"security: CVE-2024-0001: not affected assessment of lib has no justification or impact statement"},
+		},
+		"custom license without text": {
+			prepare:  func(d *sbom.Document) { d.Metadata.CustomLicenses = nil },
+			profiles: all,
+			expected: []string{"simpleLicensing: app: custom license LicenseRef-acme has no text"},
+		},
+		"invalid expression": {
+			prepare:  func(d *sbom.Document) { d.NodeList.GetNodeByID("lib").LicenseConcluded = "MIT AND" },
+			profiles: []string{ProfileLicensing},
+			expected: []string{`simpleLicensing: lib: invalid license expression "MIT AND"`},
+		},
+		"unsupported profile": {
+			profiles: []string{"build"},
+			expected: []string{"build: unsupported profile"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := profileTestDocument()
+			if tc.prepare != nil {
+				tc.prepare(doc)
+			}
+			violations := ValidateProfiles(doc, tc.profiles...)
+			require.Len(t, violations, len(tc.expected))
+			for i, v := range violations {
+				require.Contains(t, v.String(), tc.expected[i])
+			}
+		})
+	}
+}
+
+func TestSerializeProfiles(t *testing.T) {
+	for name, tc := range map[string]struct {
+		prepare     func(*sbom.Document)
+		profiles    []string
+		conformance []string
+		violations  int
+	}{
+		"conformant profiles": {
+			profiles:    []string{ProfileSoftware, ProfileLicensing},
+			conformance: []string{ProfileCore, ProfileSoftware, ProfileLicensing},
+		},
+		"profile violations": {
+			prepare:    func(d *sbom.Document) { d.Metadata.CustomLicenses = nil },
+			profiles:   []string{ProfileLicensing},
+			violations: 1,
+		},
+		"profiles not checked": {
+			prepare: func(d *sbom.Document) { d.Metadata.CustomLicenses = nil },
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := profileTestDocument()
+			if tc.prepare != nil {
+				tc.prepare(doc)
+			}
+			raw, err := NewSPDX3().Serialize(doc, nil, SPDX3Options{Profiles: tc.profiles})
+			if tc.violations > 0 {
+				var perr *ProfileConformanceError
+				require.True(t, errors.As(err, &perr))
+				require.Len(t, perr.Violations, tc.violations)
+				return
+			}
+			require.NoError(t, err)
+			if tc.conformance != nil {
+				require.Equal(t, tc.conformance, raw.(*spdx3Graph).Graph[1].(*spdx3Collection).ProfileConformance) //nolint:forcetypeassert
+			}
+		})
+	}
+}
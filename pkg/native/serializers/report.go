@@ -0,0 +1,196 @@
+package serializers
+
+import (
+	"sort"
+
+	"github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// report is the data model passed to the human-readable report templates. It
+// is built from a protobom document by the report serializers.
+type report struct {
+	Metadata   *sbom.Metadata
+	Components []*reportComponent
+	Tree       []*reportTreeNode
+	Licenses   []*reportLicenseCount
+	Stats      reportStats
+}
+
+// reportComponent is a row in the component table of a report
+type reportComponent struct {
+	ID       string
+	Name     string
+	Version  string
+	Type     string
+	Purl     string
+	Licenses []string
+	Supplier string
+}
+
+// reportTreeNode is an entry in the dependency tree of a report. Nodes
+// reached more than once are expanded the first time only, the other
+// entries are references to it.
+type reportTreeNode struct {
+	ID       string
+	Name     string
+	Version  string
+	EdgeType string
+	Ref      bool
+	Children []*reportTreeNode
+}
+
+// reportLicenseCount is an entry in the license breakdown of a report
+type reportLicenseCount struct {
+	License string
+	Count   int
+}
+
+// reportStats groups the summary numbers of a report
+type reportStats struct {
+	Nodes         int
+	Packages      int
+	Files         int
+	Edges         int
+	RootNodes     int
+	NoLicense     int
+	NoVersion     int
+	LicenseKinds  int
+	CustomLicense int
+}
+
+// buildReport computes the report model from a protobom document
+func buildReport(bom *sbom.Document) *report {
+	r := &report{
+		Metadata:   bom.GetMetadata(),
+		Components: []*reportComponent{},
+		Tree:       []*reportTreeNode{},
+		Licenses:   []*reportLicenseCount{},
+	}
+
+	nl := bom.GetNodeList()
+	licenseCounts := map[string]int{}
+	for _, n := range nl.GetNodes() {
+		c := &reportComponent{
+			ID:       n.Id,
+			Name:     n.Name,
+			Version:  n.Version,
+			Type:     n.Type.String(),
+			Purl:     string(n.Purl()),
+			Licenses: nodeLicenses(n),
+		}
+		if len(n.Suppliers) > 0 {
+			c.Supplier = n.Suppliers[0].Name
+		}
+		r.Components = append(r.Components, c)
+
+		if n.Type == sbom.Node_FILE {
+			r.Stats.Files++
+		} else {
+			r.Stats.Packages++
+		}
+		if n.Version == "" {
+			r.Stats.NoVersion++
+		}
+		if len(c.Licenses) == 0 {
+			r.Stats.NoLicense++
+		}
+		for _, l := range c.Licenses {
+			licenseCounts[l]++
+		}
+	}
+
+	sort.SliceStable(r.Components, func(i, j int) bool {
+		if r.Components[i].Name == r.Components[j].Name {
+			return r.Components[i].Version < r.Components[j].Version
+		}
+		return r.Components[i].Name < r.Components[j].Name
+	})
+
+	for l, c := range licenseCounts {
+		r.Licenses = append(r.Licenses, &reportLicenseCount{License: l, Count: c})
+	}
+	sort.Slice(r.Licenses, func(i, j int) bool {
+		if r.Licenses[i].Count == r.Licenses[j].Count {
+			return r.Licenses[i].License < r.Licenses[j].License
+		}
+		return r.Licenses[i].Count > r.Licenses[j].Count
+	})
+
+	r.Stats.Nodes = len(nl.GetNodes())
+	r.Stats.Edges = len(nl.GetEdges())
+	r.Stats.RootNodes = len(nl.GetRootElements())
+	r.Stats.LicenseKinds = len(r.Licenses)
+	r.Stats.CustomLicense = len(bom.GetMetadata().GetCustomLicenses())
+
+	if nl != nil {
+		edges := indexEdgesByFrom(nl)
+		expanded := map[string]struct{}{}
+		for _, id := range nl.RootElements {
+			if n := buildReportTree(nl, edges, id, "", expanded); n != nil {
+				r.Tree = append(r.Tree, n)
+			}
+		}
+	}
+
+	return r
+}
+
+// nodeLicenses returns the licenses of the node, falling back to the
+// concluded license if no declared licenses are set.
+func nodeLicenses(n *sbom.Node) []string {
+	ret := []string{}
+	for _, l := range n.Licenses {
+		if l != "" && l != spdx.NOASSERTION && l != spdx.NONE {
+			ret = append(ret, l)
+		}
+	}
+	if len(ret) == 0 && n.LicenseConcluded != "" &&
+		n.LicenseConcluded != spdx.NOASSERTION && n.LicenseConcluded != spdx.NONE {
+		ret = append(ret, n.LicenseConcluded)
+	}
+	return ret
+}
+
+// indexEdgesByFrom returns the edges of a nodelist indexed by source node
+func indexEdgesByFrom(nl *sbom.NodeList) map[string][]*sbom.Edge {
+	ret := map[string][]*sbom.Edge{}
+	for _, e := range nl.Edges {
+		ret[e.From] = append(ret[e.From], e)
+	}
+	return ret
+}
+
+// buildReportTree recursively builds the dependency tree under the node
+// with the specified ID. Each node is expanded once in the whole document,
+// nodes already in expanded are returned as references. This cuts cycles
+// and keeps the tree linear in the size of the graph.
+func buildReportTree(
+	nl *sbom.NodeList, edges map[string][]*sbom.Edge, id, edgeType string, expanded map[string]struct{},
+) *reportTreeNode {
+	n := nl.GetNodeByID(id)
+	if n == nil {
+		return nil
+	}
+	tn := &reportTreeNode{
+		ID:       n.Id,
+		Name:     n.Name,
+		Version:  n.Version,
+		EdgeType: edgeType,
+		Children: []*reportTreeNode{},
+	}
+	if _, ok := expanded[id]; ok {
+		tn.Ref = true
+		return tn
+	}
+	expanded[id] = struct{}{}
+
+	for _, e := range edges[id] {
+		for _, to := range e.To {
+			if child := buildReportTree(nl, edges, to, e.Type.String(), expanded); child != nil {
+				tn.Children = append(tn.Children, child)
+			}
+		}
+	}
+	return tn
+}
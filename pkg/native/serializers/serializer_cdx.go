@@ -1,10 +1,13 @@
 package serializers
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,6 +16,8 @@ import (
 	"github.com/google/uuid"
 
 	cdxformats "github.com/protobom/protobom/pkg/formats/cyclonedx"
+	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
 )
@@ -59,7 +64,7 @@ func NewCDX(version, encoding string) *CDX {
 	}
 }
 
-func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts interface{}) (interface{}, error) {
+func (s *CDX) Serialize(bom *sbom.Document, serializeopts *native.SerializeOptions, rawopts interface{}) (interface{}, error) {
 	// Load the context with the CDX value. We initialize a context here
 	// but we should get it as part of the method to capture cancelations
 	// from the CLI or REST API.
@@ -125,18 +130,30 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts
 	// Convert all nodes to cdx cmponents
 	components := map[string]*cdx.Component{}
 	for _, node := range bom.NodeList.Nodes {
-		components[node.Id] = s.nodeToComponent(node)
+		components[node.Id] = s.nodeToComponent(serializeopts, node)
 	}
 
 	// CLear the protobom generated bomrefs
 	clearAutoRefs(components)
 
+	// Render custom licenses as CycloneDX named licenses
+	for _, c := range components {
+		applyCustomLicenses(c, bom.Metadata)
+	}
+
+	// Reference the datasets of the AI models in their model cards
+	for id, c := range components {
+		applyModelCardDatasets(c, bom.NodeList, id, components)
+	}
+
 	rootNode := bom.NodeList.GetNodeByID(bom.NodeList.RootElements[0])
 	if rootNode == nil {
 		return nil, fmt.Errorf("integrity error: root node %q not found", bom.NodeList.RootElements[0])
 	}
 
-	doc.Metadata.Component = s.nodeToComponent(rootNode)
+	doc.Metadata.Component = s.nodeToComponent(serializeopts, rootNode)
+	applyCustomLicenses(doc.Metadata.Component, bom.Metadata)
+	applyModelCardDatasets(doc.Metadata.Component, bom.NodeList, rootNode.Id, components)
 
 	// Extract the component tree
 	componentTree, err := recurseComponentComponents(
@@ -148,7 +165,9 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts
 	doc.Components = componentTree
 
 	// Build the dependency graph:
-	deps, err := buildDependencies(bom.NodeList, components)
+	deps, err := buildDependencies(
+		bom.NodeList, components, serializeopts.IsModEnabled(mod.CDX_RENDER_CONTAINS_AS_COMPONENTS_ONLY),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("building dependency tree: %w", err)
 	}
@@ -158,12 +177,160 @@ func (s *CDX) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts
 		doc.Metadata.Component.Name = bom.GetMetadata().GetName()
 	}
 
+	if vulns := buildVulnerabilities(bom.Vulnerabilities, components); len(vulns) > 0 {
+		doc.Vulnerabilities = &vulns
+	}
+
 	return doc, nil
 }
 
-func buildDependencies(nl *sbom.NodeList, components map[string]*cdx.Component) ([]cdx.Dependency, error) {
+// vulnerabilitySource returns the name of the database publishing a
+// vulnerability identifier, when it can be inferred from its prefix.
+func vulnerabilitySource(id string) string {
+	switch {
+	case strings.HasPrefix(id, "CVE-"):
+		return "NVD"
+	case strings.HasPrefix(id, "GHSA-"):
+		return "GitHub"
+	case strings.HasPrefix(id, "GO-"):
+		return "Go Vulnerability Database"
+	default:
+		return ""
+	}
+}
+
+// buildVulnerabilities returns the CycloneDX vulnerabilities of the document.
+// Nodes without a bom-ref can't be referenced and are left out of the
+// affected components. Vulnerabilities are only encoded in CycloneDX 1.4 and
+// later, the encoder drops them from older versions.
+//
+// CycloneDX records a single analysis per vulnerability, so the nodes with
+// their own analysis are listed in a copy of the vulnerability with it.
+func buildVulnerabilities(vulns []*sbom.Vulnerability, components map[string]*cdx.Component) []cdx.Vulnerability {
+	ret := []cdx.Vulnerability{}
+	for _, v := range vulns {
+		vuln := cdx.Vulnerability{
+			ID:             v.Id,
+			Description:    v.Description,
+			Detail:         v.Detail,
+			Recommendation: v.Recommendation,
+		}
+		if v.SourceName != "" || v.SourceUrl != "" {
+			vuln.Source = &cdx.Source{Name: v.SourceName, URL: v.SourceUrl}
+		}
+		if v.Published != nil {
+			vuln.Published = v.Published.AsTime().Format(time.RFC3339)
+		}
+		if v.Updated != nil {
+			vuln.Updated = v.Updated.AsTime().Format(time.RFC3339)
+		}
+		if len(v.Ratings) > 0 {
+			ratings := []cdx.VulnerabilityRating{}
+			for _, r := range v.Ratings {
+				rating := cdx.VulnerabilityRating{
+					Score:    r.Score,
+					Severity: cdx.Severity(r.Severity),
+					Method:   cdx.ScoringMethod(r.Method),
+					Vector:   r.Vector,
+				}
+				if r.Source != "" {
+					rating.Source = &cdx.Source{Name: r.Source}
+				}
+				ratings = append(ratings, rating)
+			}
+			vuln.Ratings = &ratings
+		}
+		if len(v.Cwes) > 0 {
+			cwes := []int{}
+			for _, c := range v.Cwes {
+				cwes = append(cwes, int(c))
+			}
+			vuln.CWEs = &cwes
+		}
+		if len(v.Advisories) > 0 {
+			advisories := []cdx.Advisory{}
+			for _, url := range v.Advisories {
+				advisories = append(advisories, cdx.Advisory{URL: url})
+			}
+			vuln.Advisories = &advisories
+		}
+		if len(v.Aliases) > 0 {
+			refs := []cdx.VulnerabilityReference{}
+			for _, id := range v.Aliases {
+				// The schema requires a source in the references
+				refs = append(refs, cdx.VulnerabilityReference{ID: id, Source: &cdx.Source{Name: vulnerabilitySource(id)}})
+			}
+			vuln.References = &refs
+		}
+		affects := []cdx.Affects{}
+		nodeVulns := []cdx.Vulnerability{}
+		for _, id := range v.Affects {
+			c, ok := components[id]
+			if !ok || c.BOMRef == "" {
+				continue
+			}
+			a, ok := v.GetNodeAnalyses()[id]
+			if !ok {
+				affects = append(affects, cdx.Affects{Ref: c.BOMRef})
+				continue
+			}
+			nodeVuln := vuln
+			nodeVuln.Affects = &[]cdx.Affects{{Ref: c.BOMRef}}
+			nodeVuln.Analysis = buildAnalysis(a)
+			nodeVulns = append(nodeVulns, nodeVuln)
+		}
+		if len(affects) > 0 {
+			vuln.Affects = &affects
+		}
+		vuln.Analysis = buildAnalysis(v.GetAnalysis())
+		ret = append(ret, vuln)
+		ret = append(ret, nodeVulns...)
+	}
+	return ret
+}
+
+// buildAnalysis returns the CycloneDX impact analysis of a vulnerability,
+// nil if it has not been analyzed.
+func buildAnalysis(a *sbom.Vulnerability_Analysis) *cdx.VulnerabilityAnalysis {
+	if a == nil || (a.State == sbom.Vulnerability_Analysis_UNKNOWN_STATE &&
+		a.Justification == sbom.Vulnerability_Analysis_UNKNOWN_JUSTIFICATION && a.Detail == "") {
+		return nil
+	}
+	analysis := &cdx.VulnerabilityAnalysis{
+		State:         a.State.ToCycloneDX(),
+		Justification: a.Justification.ToCycloneDX(),
+		Detail:        a.Detail,
+	}
+	if len(a.Responses) > 0 {
+		responses := []cdx.ImpactAnalysisResponse{}
+		for _, r := range a.Responses {
+			if r != sbom.Vulnerability_Analysis_UNKNOWN_RESPONSE {
+				responses = append(responses, r.ToCycloneDX())
+			}
+		}
+		analysis.Response = &responses
+	}
+	if a.FirstIssued != nil {
+		analysis.FirstIssued = a.FirstIssued.AsTime().Format(time.RFC3339)
+	}
+	if a.LastUpdated != nil {
+		analysis.LastUpdated = a.LastUpdated.AsTime().Format(time.RFC3339)
+	}
+	return analysis
+}
+
+// buildDependencies returns the CycloneDX dependency graph of the NodeList
+// edges. When skipContains is set, contains edges are not rendered.
+func buildDependencies(nl *sbom.NodeList, components map[string]*cdx.Component, skipContains bool) ([]cdx.Dependency, error) {
 	ret := []cdx.Dependency{}
 	for _, e := range nl.Edges {
+		if skipContains && e.Type == sbom.Edge_contains {
+			continue
+		}
+		// Datasets are referenced from the model cards
+		if e.Type == sbom.Edge_trainedOn || e.Type == sbom.Edge_testedOn {
+			continue
+		}
 		if _, ok := components[e.From]; !ok {
 			return nil, fmt.Errorf("node %q not found in components list", e.From)
 		}
@@ -214,6 +381,8 @@ func recurseComponentComponents(
 	}
 
 	protoIDToComp := map[string]*cdx.Component{}
+	// Keep the edge order so the output is deterministic
+	ids := []string{}
 
 	// First add the nodes to the top
 	for _, id := range descendants.Edges[0].To {
@@ -224,11 +393,12 @@ func recurseComponentComponents(
 			return nil, fmt.Errorf("unable to find component for node %q", id)
 		}
 		protoIDToComp[id] = components[id]
+		ids = append(ids, id)
 		(*seen)[id] = struct{}{}
 	}
 
 	// Now cycle them again and recurse
-	for id := range protoIDToComp {
+	for _, id := range ids {
 		comps, err := recurseComponentComponents(id, nl, components, seen)
 		if err != nil {
 			return nil, err
@@ -237,8 +407,8 @@ func recurseComponentComponents(
 	}
 
 	// Assemble the return slice
-	for _, comp := range protoIDToComp {
-		ret = append(ret, *comp)
+	for _, id := range ids {
+		ret = append(ret, *protoIDToComp[id])
 	}
 	return &ret, nil
 }
@@ -286,7 +456,9 @@ func buildMetadata(doc *sbom.Document) (*cdx.Metadata, error) {
 		metadata.Lifecycles = &lifecycles
 	}
 
-	if len(doc.GetMetadata().GetTools()) > 0 {
+	if len(doc.GetMetadata().ToolVerifications()) > 0 {
+		metadata.Tools = buildToolComponents(doc.GetMetadata())
+	} else if len(doc.GetMetadata().GetTools()) > 0 {
 		var tools []cdx.Tool //nolint:staticcheck
 		for _, bomtool := range doc.GetMetadata().GetTools() {
 			tools = append(tools, cdx.Tool{ //nolint:staticcheck // Tool is needed for older cdx versions
@@ -304,9 +476,119 @@ func buildMetadata(doc *sbom.Document) (*cdx.Metadata, error) {
 		metadata.Timestamp = t.Format(time.RFC3339)
 	}
 
+	if properties := buildMetadataProperties(doc.GetMetadata()); len(properties) > 0 {
+		metadata.Properties = &properties
+	}
+
 	return &metadata, nil
 }
 
+// buildToolComponents returns the metadata tools as CycloneDX 1.5+ tool
+// components, including the hashes of their binaries. Signatures are
+// recorded as component properties. The encoder converts the components to
+// legacy tools when rendering older versions, those keep only the hashes.
+func buildToolComponents(md *sbom.Metadata) *cdx.ToolsChoice {
+	components := []cdx.Component{}
+	for _, t := range md.GetTools() {
+		c := cdx.Component{
+			Type:    cdx.ComponentTypeApplication,
+			Name:    t.GetName(),
+			Version: t.GetVersion(),
+		}
+		if t.GetVendor() != "" {
+			c.Supplier = &cdx.OrganizationalEntity{Name: t.GetVendor()}
+		}
+
+		tv := md.GetToolVerification(t.GetName(), t.GetVersion())
+		if tv == nil {
+			components = append(components, c)
+			continue
+		}
+		hashes := []cdx.Hash{}
+		properties := []cdx.Property{}
+		for _, algo := range slices.Sorted(maps.Keys(tv.Hashes)) {
+			cdxAlgo, err := (&CDX{}).protoHashAlgoToCdxAlgo(sbom.HashAlgorithm(algo))
+			if err != nil {
+				// Algorithms not supported in CDX are recorded as properties
+				properties = append(properties, cdx.Property{
+					Name:  cdxformats.PropertyHashPrefix + sbom.HashAlgorithm(algo).String(),
+					Value: tv.Hashes[algo],
+				})
+				continue
+			}
+			hashes = append(hashes, cdx.Hash{Algorithm: cdxAlgo, Value: tv.Hashes[algo]})
+		}
+		if sig := tv.Signature; sig != nil {
+			for _, p := range []cdx.Property{
+				{Name: cdxformats.PropertyToolSignatureAlgorithm, Value: sig.Algorithm},
+				{Name: cdxformats.PropertyToolSignatureKeyID, Value: sig.KeyID},
+				{Name: cdxformats.PropertyToolSignatureValue, Value: sig.Value},
+			} {
+				if p.Value != "" {
+					properties = append(properties, p)
+				}
+			}
+		}
+		if len(hashes) > 0 {
+			c.Hashes = &hashes
+		}
+		if len(properties) > 0 {
+			c.Properties = &properties
+		}
+		components = append(components, c)
+	}
+	return &cdx.ToolsChoice{Components: &components}
+}
+
+// buildMetadataProperties returns the CycloneDX metadata properties from the
+// document properties, adding the document comments and revision reason. The
+// tool verification properties rendered in the tool components are skipped.
+func buildMetadataProperties(md *sbom.Metadata) []cdx.Property {
+	properties := []cdx.Property{}
+	for _, p := range md.GetProperties() {
+		if p.Name == sbom.PropertyToolVerification && toolVerificationRendered(md, p) {
+			continue
+		}
+		properties = append(properties, cdx.Property{Name: p.Name, Value: p.Data})
+	}
+
+	// CycloneDX has no fields for these, so record them as properties
+	extra := []cdx.Property{
+		{Name: cdxformats.PropertyDocumentComment, Value: md.GetComment()},
+		{Name: cdxformats.PropertyDocumentCreatorComment, Value: md.GetCreatorComment()},
+	}
+	if rev := md.LatestRevision(); rev != nil {
+		extra = append(extra, cdx.Property{Name: cdxformats.PropertyRevisionReason, Value: rev.Reason})
+	}
+
+	for _, p := range extra {
+		if p.Value == "" {
+			continue
+		}
+		i := slices.IndexFunc(properties, func(existing cdx.Property) bool {
+			return existing.Name == p.Name
+		})
+		if i == -1 {
+			properties = append(properties, p)
+		} else {
+			properties[i].Value = p.Value
+		}
+	}
+	return properties
+}
+
+// toolVerificationRendered returns true if the tool verification property
+// is for one of the metadata tools, those are rendered by buildToolComponents
+func toolVerificationRendered(md *sbom.Metadata, p *sbom.Property) bool {
+	tv := &sbom.ToolVerification{}
+	if err := json.Unmarshal([]byte(p.Data), tv); err != nil {
+		return false
+	}
+	return slices.ContainsFunc(md.GetTools(), func(t *sbom.Tool) bool {
+		return t.GetName() == tv.Name && t.GetVersion() == tv.Version
+	})
+}
+
 // sbomTypeToPhase converts a SBOM document type to a CDX lifecycle phase
 func sbomTypeToPhase(dt *sbom.DocumentType) (cdx.LifecyclePhase, error) {
 	switch *dt.Type {
@@ -348,8 +630,52 @@ func clearAutoRefs(comps map[string]*cdx.Component) {
 	}
 }
 
+// applyCustomLicenses replaces the license IDs in the component that point
+// to custom licenses (LicenseRef-*) defined in the document metadata with
+// CycloneDX named licenses including the full license text.
+func applyCustomLicenses(c *cdx.Component, md *sbom.Metadata) {
+	if c == nil || c.Licenses == nil {
+		return
+	}
+	for i := range *c.Licenses {
+		lc := &(*c.Licenses)[i]
+		if lc.License == nil || !sbom.IsLicenseRef(lc.License.ID) {
+			continue
+		}
+		custom := md.GetCustomLicense(lc.License.ID)
+		if custom == nil {
+			continue
+		}
+
+		// CycloneDX only allows SPDX license list IDs in the id field, so
+		// custom licenses are rendered as named licenses. The LicenseRef ID
+		// is recorded in a property when the name does not produce it.
+		lc.License = &cdx.License{
+			Name: custom.Id,
+		}
+		if custom.Name != "" {
+			lc.License.Name = custom.Name
+			if sbom.NewLicenseRef(custom.Name) != custom.Id {
+				lc.License.Properties = &[]cdx.Property{
+					{Name: cdxformats.PropertyLicenseID, Value: custom.Id},
+				}
+			}
+		}
+		if custom.Text != "" {
+			lc.License.Text = &cdx.AttachedText{
+				ContentType: "text/plain",
+				Content:     custom.Text,
+			}
+		}
+		// TODO(degradation): Only the first URL is kept
+		if len(custom.Urls) > 0 {
+			lc.License.URL = custom.Urls[0]
+		}
+	}
+}
+
 // nodeToComponent converts a node in protobuf to a CycloneDX component
-func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
+func (s *CDX) nodeToComponent(serializeopts *native.SerializeOptions, n *sbom.Node) *cdx.Component {
 	if n == nil {
 		return nil
 	}
@@ -363,11 +689,14 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 		ExternalReferences: &[]cdx.ExternalReference{},
 	}
 
+	*c.ExternalReferences = append(*c.ExternalReferences, nodeURLReferences(n)...)
+
 	if n.Type == sbom.Node_FILE {
 		c.Type = cdx.ComponentTypeFile
 	} else if len(n.PrimaryPurpose) > 0 {
-		componentType, err := s.purposeToComponentType(n.PrimaryPurpose[0])
-		if err == nil {
+		if custom, ok := serializeopts.CustomPurpose(n.PrimaryPurpose[0]); ok {
+			c.Type = cdx.ComponentType(custom)
+		} else if componentType, err := s.purposeToComponentType(n.PrimaryPurpose[0]); err == nil {
 			c.Type = componentType
 		}
 		// TODO(degradation): Multiple PrimaryPurpose in protobom.Node, but
@@ -393,7 +722,7 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 		for algo, hash := range n.Hashes {
 			cdxAlgo, err := s.protoHashAlgoToCdxAlgo(sbom.HashAlgorithm(algo))
 			if err != nil {
-				// TODO(degradation): Algorithm not supported in CDX
+				// Algorithms not supported in CDX are recorded as properties
 				continue
 			}
 			*c.Hashes = append(*c.Hashes, cdx.Hash{
@@ -441,6 +770,12 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 				if c.CPE == "" {
 					c.CPE = n.Identifiers[idType]
 				}
+			case int32(sbom.SoftwareIdentifierType_GITOID):
+				// The encoder drops OmniBOR IDs and SWHIDs from documents older
+				// than CDX 1.6
+				c.OmniborID = &[]string{n.Identifiers[idType]}
+			case int32(sbom.SoftwareIdentifierType_SWHID):
+				c.SWHID = &[]string{n.Identifiers[idType]}
 			}
 		}
 	}
@@ -476,11 +811,100 @@ func (s *CDX) nodeToComponent(n *sbom.Node) *cdx.Component {
 			Value: p.Data,
 		})
 	}
+	properties = append(properties, nodeTextProperties(n)...)
+	properties = append(properties, s.unsupportedHashProperties(n.Hashes)...)
 	c.Properties = &properties
 
+	if n.AiModel != nil {
+		c.ModelCard = aiModelToModelCard(n.AiModel)
+	}
+	if n.Dataset != nil {
+		c.Data = datasetToComponentData(n.Name, n.Dataset)
+	}
+
 	return c
 }
 
+// aiModelToModelCard converts the AI model data of a node to a CycloneDX
+// model card. The first type of model is rendered as the architecture family.
+//
+// TODO(degradation): Model cards have no fields for the domain, training
+// information, hyperparameters, autonomy, risk assessment and personal
+// information use of the model.
+func aiModelToModelCard(m *sbom.AIModel) *cdx.MLModelCard {
+	mc := &cdx.MLModelCard{
+		ModelParameters: &cdx.MLModelParameters{
+			Task:              m.Task,
+			ModelArchitecture: m.ModelArchitecture,
+		},
+	}
+	if len(m.TypeOfModel) > 0 {
+		mc.ModelParameters.ArchitectureFamily = m.TypeOfModel[0]
+	}
+
+	if len(m.Metrics) > 0 {
+		metrics := []cdx.MLPerformanceMetric{}
+		for _, k := range slices.Sorted(maps.Keys(m.Metrics)) {
+			metrics = append(metrics, cdx.MLPerformanceMetric{Type: k, Value: m.Metrics[k]})
+		}
+		mc.QuantitativeAnalysis = &cdx.MLQuantitativeAnalysis{PerformanceMetrics: &metrics}
+	}
+
+	if m.InformationAboutApplication != "" || m.Limitation != "" {
+		mc.Considerations = &cdx.MLModelCardConsiderations{}
+		if m.InformationAboutApplication != "" {
+			mc.Considerations.UseCases = &[]string{m.InformationAboutApplication}
+		}
+		if m.Limitation != "" {
+			mc.Considerations.TechnicalLimitations = &[]string{m.Limitation}
+		}
+	}
+	return mc
+}
+
+// datasetToComponentData converts the dataset data of a node to CycloneDX
+// component data. The confidentiality level is rendered as the data
+// classification.
+//
+// TODO(degradation): Component data has no fields for the size, collection
+// process, biases, availability and anonymization of the dataset.
+func datasetToComponentData(name string, d *sbom.Dataset) *cdx.ComponentData {
+	return &cdx.ComponentData{
+		Type:           cdx.ComponentDataTypeDataset,
+		Name:           name,
+		Classification: d.ConfidentialityLevel,
+		Description:    d.IntendedUse,
+	}
+}
+
+// modelCardDatasets returns references to the datasets a model was trained
+// or tested on, skipping the datasets without a bom-ref.
+func modelCardDatasets(nl *sbom.NodeList, id string, components map[string]*cdx.Component) []cdx.MLDatasetChoice {
+	ret := []cdx.MLDatasetChoice{}
+	for _, e := range nl.Edges {
+		if e.From != id || (e.Type != sbom.Edge_trainedOn && e.Type != sbom.Edge_testedOn) {
+			continue
+		}
+		for _, to := range e.To {
+			if c, ok := components[to]; ok && c.BOMRef != "" {
+				ret = append(ret, cdx.MLDatasetChoice{Ref: c.BOMRef})
+			}
+		}
+	}
+	return ret
+}
+
+// applyModelCardDatasets adds the references to the training and testing
+// datasets to the component model card.
+func applyModelCardDatasets(c *cdx.Component, nl *sbom.NodeList, id string, components map[string]*cdx.Component) {
+	if c == nil || c.ModelCard == nil {
+		return
+	}
+	if datasets := modelCardDatasets(nl, id, components); len(datasets) > 0 {
+		c.ModelCard.ModelParameters.Datasets = &datasets
+	}
+}
+
 // Render calls the official CDX serializer to render the BOM into a specific version
 func (s *CDX) Render(doc interface{}, wr io.Writer, o *native.RenderOptions, _ interface{}) error {
 	if doc == nil {
@@ -497,14 +921,16 @@ func (s *CDX) Render(doc interface{}, wr io.Writer, o *native.RenderOptions, _ i
 		return fmt.Errorf("getting CDX encoding: %w", err)
 	}
 
-	encoder := cdx.NewBOMEncoder(wr, encoding)
-	encoder.SetPretty(true)
-
 	cdxdoc, ok := doc.(*cdx.BOM)
 	if !ok {
 		return errors.New("document is not a cyclonedx bom")
 	}
 
+	// The CycloneDX encoders have a fixed indentation, so we only switch
+	// their pretty printing off when the output is minified.
+	encoder := cdx.NewBOMEncoder(wr, encoding)
+	encoder.SetPretty(o == nil || !o.Minify)
+	encoder.SetEscapeHTML(false)
 	if err := encoder.EncodeVersion(cdxdoc, version); err != nil {
 		return fmt.Errorf("encoding sbom to stream: %w", err)
 	}
@@ -599,11 +1025,74 @@ func (s *CDX) protobomExtRefTypeToCdxType(protoExtRefType sbom.ExternalReference
 	}
 }
 
+// nodeURLReferences returns the node homepage and download location as
+// CycloneDX website and distribution external references. SPDX download
+// locations that are not URLs (NONE and NOASSERTION) are not rendered.
+func nodeURLReferences(n *sbom.Node) []cdx.ExternalReference {
+	ret := []cdx.ExternalReference{}
+	for _, u := range []struct {
+		url     string
+		refType cdx.ExternalReferenceType
+	}{
+		{n.UrlHome, cdx.ERTypeWebsite},
+		{n.UrlDownload, cdx.ERTypeDistribution},
+	} {
+		if u.url == "" || u.url == protospdx.NONE || u.url == protospdx.NOASSERTION {
+			continue
+		}
+		ret = append(ret, cdx.ExternalReference{URL: u.url, Type: u.refType})
+	}
+	return ret
+}
+
+// nodeTextProperties returns the node text fields with no CycloneDX
+// equivalent as component properties. The description is rendered in the
+// component description field.
+//
+//	Node field       | CycloneDX property
+//	-----------------|-------------------------------
+//	summary          | protobom:node:summary
+//	comment          | protobom:node:comment
+//	source_info      | protobom:node:source_info
+//	license_comments | protobom:node:license_comments
+func nodeTextProperties(n *sbom.Node) []cdx.Property {
+	ret := []cdx.Property{}
+	for _, p := range []cdx.Property{
+		{Name: cdxformats.PropertyNodeSummary, Value: n.Summary},
+		{Name: cdxformats.PropertyNodeComment, Value: n.Comment},
+		{Name: cdxformats.PropertyNodeSourceInfo, Value: n.SourceInfo},
+		{Name: cdxformats.PropertyNodeLicenseComments, Value: n.LicenseComments},
+	} {
+		if p.Value != "" {
+			ret = append(ret, p)
+		}
+	}
+	return ret
+}
+
+// unsupportedHashProperties returns the hashes computed with algorithms
+// not supported by CycloneDX as component properties, sorted by algorithm.
+func (s *CDX) unsupportedHashProperties(hashes map[int32]string) []cdx.Property {
+	ret := []cdx.Property{}
+	for _, algo := range slices.Sorted(maps.Keys(hashes)) {
+		name, ok := sbom.HashAlgorithm_name[algo]
+		if !ok || algo == int32(sbom.HashAlgorithm_UNKNOWN) {
+			continue
+		}
+		if _, err := s.protoHashAlgoToCdxAlgo(sbom.HashAlgorithm(algo)); err == nil {
+			continue
+		}
+		ret = append(ret, cdx.Property{Name: cdxformats.PropertyHashPrefix + name, Value: hashes[algo]})
+	}
+	return ret
+}
+
 // protoHashAlgoToCdxAlgo converts the protobom algorithm to the CDX
 // algorithm string.
-// TODO(degradation): The use of the following algorithms will result in
-// data loss when rendering to CycloneDX 1.4: ADLER32 MD4 MD6 SHA224
-// Also, HashAlgorithm_UNKNOWN also means data loss.
+// The following algorithms have no CycloneDX equivalent: MD2 ADLER32 MD4
+// MD6 SHA224 SSDEEP. Component hashes using them are preserved as properties
+// (see unsupportedHashProperties), external reference hashes are dropped.
+// HashAlgorithm_UNKNOWN also means data loss.
 func (s *CDX) protoHashAlgoToCdxAlgo(protoAlgo sbom.HashAlgorithm) (cdx.HashAlgorithm, error) {
 	switch protoAlgo {
 	case sbom.HashAlgorithm_MD5:
@@ -638,34 +1127,10 @@ func (s *CDX) protoHashAlgoToCdxAlgo(protoAlgo sbom.HashAlgorithm) (cdx.HashAlgo
 }
 
 // purposeToComponentType converts from a protobom enumerated purpose to
-// a CycloneDC component type
+// a CycloneDX component type using the mapping in sbom.Purpose.ToCDX
 func (s *CDX) purposeToComponentType(purpose sbom.Purpose) (cdx.ComponentType, error) {
-	switch purpose {
-	case sbom.Purpose_APPLICATION, sbom.Purpose_EXECUTABLE, sbom.Purpose_INSTALL:
-		return cdx.ComponentTypeApplication, nil
-	case sbom.Purpose_CONTAINER:
-		return cdx.ComponentTypeContainer, nil
-	case sbom.Purpose_DATA, sbom.Purpose_BOM, sbom.Purpose_CONFIGURATION, sbom.Purpose_DOCUMENTATION, sbom.Purpose_EVIDENCE, sbom.Purpose_MANIFEST, sbom.Purpose_REQUIREMENT, sbom.Purpose_SPECIFICATION, sbom.Purpose_TEST, sbom.Purpose_OTHER:
-		return cdx.ComponentTypeData, nil
-	case sbom.Purpose_DEVICE:
-		return cdx.ComponentTypeDevice, nil
-	case sbom.Purpose_DEVICE_DRIVER:
-		return cdx.ComponentTypeDeviceDriver, nil
-	case sbom.Purpose_FILE, sbom.Purpose_PATCH, sbom.Purpose_SOURCE, sbom.Purpose_ARCHIVE:
-		return cdx.ComponentTypeFile, nil
-	case sbom.Purpose_FIRMWARE:
-		return cdx.ComponentTypeFirmware, nil
-	case sbom.Purpose_FRAMEWORK:
-		return cdx.ComponentTypeFramework, nil
-	case sbom.Purpose_LIBRARY, sbom.Purpose_MODULE:
-		return cdx.ComponentTypeLibrary, nil
-	case sbom.Purpose_MACHINE_LEARNING_MODEL, sbom.Purpose_MODEL:
-		return cdx.ComponentTypeMachineLearningModel, nil
-	case sbom.Purpose_OPERATING_SYSTEM:
-		return cdx.ComponentTypeOS, nil
-	case sbom.Purpose_PLATFORM:
-		return cdx.ComponentTypePlatform, nil
+	if t := purpose.ToCDX(); t != "" {
+		return t, nil
 	}
-
 	return "", fmt.Errorf("document purpose %q not supported", purpose)
 }
@@ -1,11 +1,18 @@
 package serializers
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	cdxformats "github.com/protobom/protobom/pkg/formats/cyclonedx"
+	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
 )
 
@@ -75,7 +82,7 @@ func TestComponentType(t *testing.T) {
 		}, cdx.ComponentTypePlatform},
 	} {
 		tc.prepare(node)
-		comp := sut.nodeToComponent(node)
+		comp := sut.nodeToComponent(nil, node)
 		require.Equal(t, comp.Type, tc.compType, s)
 	}
 }
@@ -153,6 +160,149 @@ func TestProtoHashAlgoToCdxAlgo(t *testing.T) {
 	}
 }
 
+func TestUnsupportedHashProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		hashes   map[int32]string
+		expected []cdx.Property
+	}{
+		{
+			name: "unsupported algorithms",
+			hashes: map[int32]string{
+				int32(sbom.HashAlgorithm_SHA256):  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+				int32(sbom.HashAlgorithm_SSDEEP):  "3:AXGBicFlgVNhBGcL6wCrFQEv:AXGHsNhxLsr2C",
+				int32(sbom.HashAlgorithm_ADLER32): "00000001",
+			},
+			expected: []cdx.Property{
+				{Name: cdxformats.PropertyHashPrefix + "ADLER32", Value: "00000001"},
+				{Name: cdxformats.PropertyHashPrefix + "SSDEEP", Value: "3:AXGBicFlgVNhBGcL6wCrFQEv:AXGHsNhxLsr2C"},
+			},
+		},
+		{
+			name: "only supported algorithms",
+			hashes: map[int32]string{
+				int32(sbom.HashAlgorithm_SHA256): "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			},
+		},
+		{
+			name:   "unknown algorithm",
+			hashes: map[int32]string{int32(sbom.HashAlgorithm_UNKNOWN): "abc"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			props := NewCDX("1.5", "json").unsupportedHashProperties(tc.hashes)
+			if tc.expected == nil {
+				require.Empty(t, props)
+				return
+			}
+			require.Equal(t, tc.expected, props)
+		})
+	}
+}
+
+func TestNodeSoftFields(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		sut        *sbom.Node
+		refs       []cdx.ExternalReference
+		properties []cdx.Property
+	}{
+		{
+			name: "text fields",
+			sut: &sbom.Node{
+				Id: "pkg", Name: "pkg", Description: "A package", Summary: "Short summary",
+				Comment: "Reviewed", SourceInfo: "Built from git tag v1.0",
+			},
+			properties: []cdx.Property{
+				{Name: cdxformats.PropertyNodeSummary, Value: "Short summary"},
+				{Name: cdxformats.PropertyNodeComment, Value: "Reviewed"},
+				{Name: cdxformats.PropertyNodeSourceInfo, Value: "Built from git tag v1.0"},
+			},
+		},
+		{
+			name: "home page",
+			sut:  &sbom.Node{Id: "pkg", Name: "pkg", UrlHome: "https://example.com/"},
+			refs: []cdx.ExternalReference{{URL: "https://example.com/", Type: cdx.ERTypeWebsite}},
+		},
+		{
+			// NOASSERTION download locations are not references
+			name: "noassertion download",
+			sut:  &sbom.Node{Id: "pkg", Name: "pkg", UrlHome: "https://example.com/", UrlDownload: protospdx.NOASSERTION},
+			refs: []cdx.ExternalReference{{URL: "https://example.com/", Type: cdx.ERTypeWebsite}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewCDX("1.5", "json").nodeToComponent(nil, tc.sut)
+			require.Equal(t, tc.sut.Description, c.Description)
+			if tc.refs == nil {
+				require.Empty(t, c.ExternalReferences)
+			} else {
+				require.Equal(t, tc.refs, *c.ExternalReferences)
+			}
+			if tc.properties == nil {
+				require.Empty(t, c.Properties)
+			} else {
+				require.Equal(t, tc.properties, *c.Properties)
+			}
+		})
+	}
+}
+
+func TestBuildDependencies(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		edges        []*sbom.Edge
+		skipContains bool
+		expected     map[string][]string
+	}{
+		{
+			name: "all edges",
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"image"}},
+				{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}},
+			},
+			expected: map[string][]string{"app": {"image", "lib"}},
+		},
+		{
+			name: "skip contains",
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"image"}},
+				{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}},
+			},
+			skipContains: true,
+			expected:     map[string][]string{"app": {"lib"}},
+		},
+		{
+			name:         "only contains",
+			edges:        []*sbom.Edge{{Type: sbom.Edge_contains, From: "app", To: []string{"image"}}},
+			skipContains: true,
+			expected:     map[string][]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &sbom.NodeList{
+				Nodes:        []*sbom.Node{{Id: "app"}, {Id: "image"}, {Id: "lib"}},
+				Edges:        tc.edges,
+				RootElements: []string{"app"},
+			}
+			components := map[string]*cdx.Component{}
+			for _, n := range nl.Nodes {
+				components[n.Id] = &cdx.Component{BOMRef: n.Id}
+			}
+
+			deps, err := buildDependencies(nl, components, tc.skipContains)
+			require.NoError(t, err)
+			got := map[string][]string{}
+			for _, d := range deps {
+				if d.Dependencies != nil {
+					got[d.Ref] = append(got[d.Ref], *d.Dependencies...)
+				}
+			}
+			require.Equal(t, tc.expected, got)
+		})
+	}
+}
+
 func TestPurposeToComponentType(t *testing.T) {
 	cdxs := NewCDX("1.5", "json")
 	for protoPupose, cdxType := range map[sbom.Purpose]cdx.ComponentType{
@@ -190,3 +340,531 @@ func TestPurposeToComponentType(t *testing.T) {
 		require.Equal(t, cdxType, res)
 	}
 }
+
+func TestApplyCustomLicenses(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		license  string
+		expected *cdx.License
+	}{
+		{
+			name:     "spdx license",
+			license:  "Apache-2.0",
+			expected: &cdx.License{ID: "Apache-2.0"},
+		},
+		{
+			name:    "custom license",
+			license: "LicenseRef-acme",
+			expected: &cdx.License{
+				Name:       "ACME License",
+				Text:       &cdx.AttachedText{Content: "Do whatever", ContentType: "text/plain"},
+				URL:        "https://example.com/",
+				Properties: &[]cdx.Property{{Name: cdxformats.PropertyLicenseID, Value: "LicenseRef-acme"}},
+			},
+		},
+		{
+			name:    "custom license without name",
+			license: "LicenseRef-noname",
+			expected: &cdx.License{
+				Name: "LicenseRef-noname",
+				Text: &cdx.AttachedText{Content: "Some text", ContentType: "text/plain"},
+			},
+		},
+		{
+			// Undefined custom licenses are left untouched
+			name:     "undefined custom license",
+			license:  "LicenseRef-undefined",
+			expected: &cdx.License{ID: "LicenseRef-undefined"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := CDX{}
+			md := &sbom.Metadata{
+				CustomLicenses: []*sbom.License{
+					{Id: "LicenseRef-acme", Name: "ACME License", Text: "Do whatever", Urls: []string{"https://example.com/"}},
+					{Id: "LicenseRef-noname", Text: "Some text"},
+				},
+			}
+			comp := sut.nodeToComponent(nil, &sbom.Node{Id: "node1", Licenses: []string{tc.license}})
+			applyCustomLicenses(comp, md)
+			require.NotNil(t, comp.Licenses)
+			require.Len(t, *comp.Licenses, 1)
+			require.Equal(t, tc.expected, (*comp.Licenses)[0].License)
+		})
+	}
+}
+
+func TestBuildMetadataRevision(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		reasons    []string
+		properties *[]cdx.Property
+	}{
+		{name: "no revisions"},
+		{
+			name:    "revision",
+			reasons: []string{"removed false positives"},
+			properties: &[]cdx.Property{
+				{Name: cdxformats.PropertyRevisionReason, Value: "removed false positives"},
+			},
+		},
+		{
+			name:    "last revision",
+			reasons: []string{"removed false positives", "updated licenses"},
+			properties: &[]cdx.Property{
+				{Name: cdxformats.PropertyRevisionReason, Value: "updated licenses"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			for _, r := range tc.reasons {
+				require.NoError(t, doc.Bump(r))
+			}
+			md, err := buildMetadata(doc)
+			require.NoError(t, err)
+			require.Equal(t, tc.properties, md.Properties)
+		})
+	}
+}
+
+func TestBuildMetadataProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		comment    string
+		creator    string
+		properties []*sbom.Property
+		bump       string
+		expected   *[]cdx.Property
+	}{
+		{
+			name: "no properties",
+		},
+		{
+			name:       "all fields",
+			comment:    "Generated in CI",
+			creator:    "Reviewed by the release team",
+			properties: []*sbom.Property{{Name: "build", Data: "1234"}},
+			bump:       "rebuilt",
+			expected: &[]cdx.Property{
+				{Name: "build", Value: "1234"},
+				{Name: cdxformats.PropertyDocumentComment, Value: "Generated in CI"},
+				{Name: cdxformats.PropertyDocumentCreatorComment, Value: "Reviewed by the release team"},
+				{Name: cdxformats.PropertyRevisionReason, Value: "rebuilt"},
+			},
+		},
+		{
+			// A revision reason read from a previous document is replaced
+			name: "stale revision reason",
+			properties: []*sbom.Property{
+				{Name: "build", Data: "1234"},
+				{Name: cdxformats.PropertyRevisionReason, Data: "stale"},
+			},
+			bump: "rebuilt",
+			expected: &[]cdx.Property{
+				{Name: "build", Value: "1234"},
+				{Name: cdxformats.PropertyRevisionReason, Value: "rebuilt"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Comment = tc.comment
+			doc.Metadata.CreatorComment = tc.creator
+			if tc.bump != "" {
+				require.NoError(t, doc.Bump(tc.bump))
+			}
+			for _, p := range tc.properties {
+				doc.Metadata.SetProperty(p.Name, p.Data)
+			}
+
+			md, err := buildMetadata(doc)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, md.Properties)
+		})
+	}
+}
+
+func TestBuildMetadataToolVerification(t *testing.T) {
+	digest := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	for _, tc := range []struct {
+		name         string
+		verification *sbom.ToolVerification
+		// legacy is true when the tools are written in the deprecated list
+		legacy     bool
+		hashes     []cdx.Hash
+		properties []cdx.Property
+	}{
+		{
+			name: "verification material",
+			verification: &sbom.ToolVerification{
+				Name: "scanner", Version: "1.0",
+				Hashes: map[int32]string{
+					int32(sbom.HashAlgorithm_SHA256): digest,
+					int32(sbom.HashAlgorithm_MD4):    "0123456789abcdef0123456789abcdef",
+				},
+				Signature: &sbom.ToolSignature{Algorithm: "ES256", KeyID: "release-key", Value: "c2lnbmF0dXJl"},
+			},
+			hashes: []cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: digest}},
+			properties: []cdx.Property{
+				{Name: cdxformats.PropertyHashPrefix + "MD4", Value: "0123456789abcdef0123456789abcdef"},
+				{Name: cdxformats.PropertyToolSignatureAlgorithm, Value: "ES256"},
+				{Name: cdxformats.PropertyToolSignatureKeyID, Value: "release-key"},
+				{Name: cdxformats.PropertyToolSignatureValue, Value: "c2lnbmF0dXJl"},
+			},
+		},
+		{
+			name: "hashes only",
+			verification: &sbom.ToolVerification{
+				Name: "scanner", Version: "1.0",
+				Hashes: map[int32]string{int32(sbom.HashAlgorithm_SHA256): digest},
+			},
+			hashes: []cdx.Hash{{Algorithm: cdx.HashAlgoSHA256, Value: digest}},
+		},
+		{
+			// Documents without verification material keep the legacy tools
+			name:   "no verification material",
+			legacy: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Tools = []*sbom.Tool{{Name: "scanner", Version: "1.0", Vendor: "ACME"}, {Name: "other"}}
+			if tc.verification != nil {
+				require.NoError(t, doc.Metadata.SetToolVerification(tc.verification))
+			}
+
+			md, err := buildMetadata(doc)
+			require.NoError(t, err)
+			require.NotNil(t, md.Tools)
+			if tc.legacy {
+				require.Nil(t, md.Tools.Components)
+				require.Len(t, *md.Tools.Tools, 2) //nolint:staticcheck
+				return
+			}
+			require.Nil(t, md.Tools.Tools) //nolint:staticcheck
+			require.NotNil(t, md.Tools.Components)
+			tools := *md.Tools.Components
+			require.Len(t, tools, 2)
+			require.Equal(t, cdx.ComponentTypeApplication, tools[0].Type)
+			require.Equal(t, "ACME", tools[0].Supplier.Name)
+			require.Equal(t, tc.hashes, *tools[0].Hashes)
+			if tc.properties == nil {
+				require.Nil(t, tools[0].Properties)
+			} else {
+				require.Equal(t, tc.properties, *tools[0].Properties)
+			}
+			require.Equal(t, "other", tools[1].Name)
+			require.Nil(t, tools[1].Hashes)
+
+			// The verification property is not repeated in the metadata properties
+			if md.Properties != nil {
+				for _, p := range *md.Properties {
+					require.NotEqual(t, sbom.PropertyToolVerification, p.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestCDXRenderStrings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		node     *sbom.Node
+		contains []string
+	}{
+		{
+			name:     "non ascii and markup",
+			node:     &sbom.Node{Id: "pkg", Name: "Überprüfung <core>", Version: "1.0"},
+			contains: []string{`"Überprüfung <core>"`},
+		},
+		{
+			name: "purl qualifiers",
+			node: &sbom.Node{
+				Id: "pkg", Name: "core", Version: "1.0",
+				Identifiers: map[int32]string{
+					int32(sbom.SoftwareIdentifierType_PURL): "pkg:deb/debian/core@1.0?arch=amd64&distro=debian-12",
+				},
+			},
+			contains: []string{"arch=amd64&distro=debian-12"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddNode(tc.node)
+			doc.NodeList.RootElements = []string{tc.node.Id}
+
+			s := NewCDX("1.5", "json")
+			bom, err := s.Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+
+			var b strings.Builder
+			require.NoError(t, s.Render(bom, &b, &native.RenderOptions{}, nil))
+			require.True(t, json.Valid([]byte(b.String())))
+			for _, want := range tc.contains {
+				require.Contains(t, b.String(), want)
+			}
+		})
+	}
+}
+
+func TestCDXRenderIndent(t *testing.T) {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "pkg", Name: "pkg", Version: "1.0"})
+
+	for _, tc := range []struct {
+		name     string
+		encoding string
+		options  *native.RenderOptions
+		minified bool
+		contains string
+	}{
+		{"json nil options", "json", nil, false, "\n  \"bomFormat\""},
+		{"json empty options", "json", &native.RenderOptions{}, false, "\n  \"bomFormat\""},
+		{"json indent is fixed", "json", &native.RenderOptions{Indent: 4}, false, "\n  \"bomFormat\""},
+		{"json minified", "json", &native.RenderOptions{Indent: 4, Minify: true}, true, `{"$schema"`},
+		{"xml empty options", "xml", &native.RenderOptions{}, false, "\n  <metadata>"},
+		{"xml minified", "xml", &native.RenderOptions{Minify: true}, true, "><metadata>"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewCDX("1.5", tc.encoding)
+			bom, err := s.Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+
+			var b strings.Builder
+			require.NoError(t, s.Render(bom, &b, tc.options, nil))
+			// The XML output has the header on its own line
+			lines := strings.Count(strings.TrimSpace(b.String()), "\n")
+			if tc.minified {
+				require.LessOrEqual(t, lines, 1)
+			} else {
+				require.Greater(t, lines, 2)
+			}
+			require.Contains(t, b.String(), tc.contains)
+		})
+	}
+}
+
+func TestNodeModelCard(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		edges    []*sbom.Edge
+		datasets []cdx.MLDatasetChoice
+		deps     int
+	}{
+		{
+			// The datasets are referenced from the model card, not the dependencies
+			name:     "trained on dataset",
+			edges:    []*sbom.Edge{{Type: sbom.Edge_trainedOn, From: "model", To: []string{"corpus"}}},
+			datasets: []cdx.MLDatasetChoice{{Ref: "corpus"}},
+		},
+		{
+			name:  "depends on dataset",
+			edges: []*sbom.Edge{{Type: sbom.Edge_dependsOn, From: "model", To: []string{"corpus"}}},
+			deps:  1,
+		},
+		{
+			name: "no edges",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &sbom.NodeList{
+				Nodes: []*sbom.Node{
+					{
+						Id:   "model",
+						Name: "model",
+						AiModel: &sbom.AIModel{
+							TypeOfModel:                 []string{"transformer"},
+							Task:                        "text-generation",
+							ModelArchitecture:           "LLaMA",
+							InformationAboutApplication: "Chat assistants",
+							Limitation:                  "English only",
+							Metrics:                     map[string]string{"perplexity": "5.2", "accuracy": "0.91"},
+						},
+					},
+					{
+						Id:      "corpus",
+						Name:    "corpus",
+						Dataset: &sbom.Dataset{IntendedUse: "Pretraining", ConfidentialityLevel: "green"},
+					},
+				},
+				Edges:        tc.edges,
+				RootElements: []string{"model"},
+			}
+
+			s := NewCDX("1.6", "json")
+			components := map[string]*cdx.Component{}
+			for _, n := range nl.Nodes {
+				components[n.Id] = s.nodeToComponent(nil, n)
+			}
+			applyModelCardDatasets(components["model"], nl, "model", components)
+
+			mc := components["model"].ModelCard
+			require.NotNil(t, mc)
+			require.Equal(t, "text-generation", mc.ModelParameters.Task)
+			require.Equal(t, "transformer", mc.ModelParameters.ArchitectureFamily)
+			require.Equal(t, "LLaMA", mc.ModelParameters.ModelArchitecture)
+			if tc.datasets == nil {
+				require.Nil(t, mc.ModelParameters.Datasets)
+			} else {
+				require.Equal(t, tc.datasets, *mc.ModelParameters.Datasets)
+			}
+			require.Equal(t, []cdx.MLPerformanceMetric{
+				{Type: "accuracy", Value: "0.91"},
+				{Type: "perplexity", Value: "5.2"},
+			}, *mc.QuantitativeAnalysis.PerformanceMetrics)
+			require.Equal(t, []string{"Chat assistants"}, *mc.Considerations.UseCases)
+			require.Equal(t, []string{"English only"}, *mc.Considerations.TechnicalLimitations)
+
+			require.Equal(t, &cdx.ComponentData{
+				Type:           cdx.ComponentDataTypeDataset,
+				Name:           "corpus",
+				Classification: "green",
+				Description:    "Pretraining",
+			}, components["corpus"].Data)
+
+			deps, err := buildDependencies(nl, components, false)
+			require.NoError(t, err)
+			require.Len(t, deps, tc.deps)
+		})
+	}
+}
+
+func TestBuildVulnerabilities(t *testing.T) {
+	score := 9.8
+	for _, tc := range []struct {
+		name      string
+		vuln      *sbom.Vulnerability
+		source    *cdx.Source
+		affects   *[]cdx.Affects
+		published string
+		analysis  *cdx.VulnerabilityAnalysis
+		severity  cdx.Severity
+		reference string
+		advisory  string
+		cwes      *[]int
+	}{
+		{
+			name: "all fields",
+			vuln: &sbom.Vulnerability{
+				Id:         "CVE-2024-0001",
+				SourceName: "NVD",
+				Aliases:    []string{"GHSA-aaaa-bbbb-cccc"},
+				Ratings:    []*sbom.Vulnerability_Rating{{Source: "NVD", Score: &score, Severity: "critical", Method: "CVSSv31"}},
+				Advisories: []string{"https://example.com/advisory"},
+				Cwes:       []int32{79},
+				Published:  timestamppb.New(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				Analysis:   &sbom.Vulnerability_Analysis{State: sbom.Vulnerability_Analysis_NOT_AFFECTED},
+			},
+			source:    &cdx.Source{Name: "NVD"},
+			published: "2024-01-02T00:00:00Z",
+			analysis:  &cdx.VulnerabilityAnalysis{State: cdx.IASNotAffected},
+			severity:  cdx.SeverityCritical,
+			reference: "GitHub",
+			advisory:  "https://example.com/advisory",
+			cwes:      &[]int{79},
+		},
+		{
+			name: "affects only components with a bom-ref",
+			vuln: &sbom.Vulnerability{
+				Id:      "CVE-2024-0001",
+				Affects: []string{"lib", "auto", "missing"},
+			},
+			affects: &[]cdx.Affects{{Ref: "lib"}},
+		},
+		{
+			name: "id only",
+			vuln: &sbom.Vulnerability{Id: "CVE-2024-0002"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			components := map[string]*cdx.Component{
+				"lib":  {BOMRef: "lib"},
+				"auto": {},
+			}
+			res := buildVulnerabilities([]*sbom.Vulnerability{tc.vuln}, components)
+			require.Len(t, res, 1)
+			v := res[0]
+			require.Equal(t, tc.vuln.Id, v.ID)
+			require.Equal(t, tc.source, v.Source)
+			require.Equal(t, tc.affects, v.Affects)
+			require.Equal(t, tc.published, v.Published)
+			if tc.analysis == nil {
+				require.Nil(t, v.Analysis)
+			} else {
+				require.NotNil(t, v.Analysis)
+				require.Equal(t, tc.analysis.State, v.Analysis.State)
+			}
+			if tc.severity != "" {
+				require.Equal(t, tc.severity, (*v.Ratings)[0].Severity)
+			}
+			if tc.reference != "" {
+				require.Equal(t, tc.reference, (*v.References)[0].Source.Name)
+			}
+			if tc.advisory != "" {
+				require.Equal(t, tc.advisory, (*v.Advisories)[0].URL)
+			}
+			require.Equal(t, tc.cwes, v.CWEs)
+		})
+	}
+}
+
+func TestBuildVulnerabilitiesNodeAnalyses(t *testing.T) {
+	type entry struct {
+		affects  []cdx.Affects
+		analysis *cdx.VulnerabilityAnalysis
+	}
+	for _, tc := range []struct {
+		name     string
+		analyses map[string]*sbom.Vulnerability_Analysis
+		expected []entry
+	}{
+		{
+			name: "document analysis only",
+			expected: []entry{
+				{[]cdx.Affects{{Ref: "app"}, {Ref: "lib"}}, &cdx.VulnerabilityAnalysis{State: cdx.IASInTriage}},
+			},
+		},
+		{
+			name: "node analysis",
+			analyses: map[string]*sbom.Vulnerability_Analysis{
+				"lib": {
+					State:         sbom.Vulnerability_Analysis_NOT_AFFECTED,
+					Justification: sbom.Vulnerability_Analysis_CODE_NOT_REACHABLE,
+					Responses:     []sbom.Vulnerability_Analysis_Response{sbom.Vulnerability_Analysis_WILL_NOT_FIX},
+					FirstIssued:   timestamppb.New(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)),
+				},
+			},
+			expected: []entry{
+				{[]cdx.Affects{{Ref: "app"}}, &cdx.VulnerabilityAnalysis{State: cdx.IASInTriage}},
+				{[]cdx.Affects{{Ref: "lib"}}, &cdx.VulnerabilityAnalysis{
+					State:         cdx.IASNotAffected,
+					Justification: cdx.IAJCodeNotReachable,
+					Response:      &[]cdx.ImpactAnalysisResponse{cdx.IARWillNotFix},
+					FirstIssued:   "2024-01-02T00:00:00Z",
+				}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			vulns := []*sbom.Vulnerability{{
+				Id:           "CVE-2024-0001",
+				Affects:      []string{"app", "lib"},
+				Analysis:     &sbom.Vulnerability_Analysis{State: sbom.Vulnerability_Analysis_IN_TRIAGE},
+				NodeAnalyses: tc.analyses,
+			}}
+			components := map[string]*cdx.Component{
+				"app": {BOMRef: "app"},
+				"lib": {BOMRef: "lib"},
+			}
+
+			res := buildVulnerabilities(vulns, components)
+			require.Len(t, res, len(tc.expected))
+			for i, e := range tc.expected {
+				require.Equal(t, "CVE-2024-0001", res[i].ID)
+				require.Equal(t, e.affects, *res[i].Affects)
+				require.Equal(t, e.analysis, res[i].Analysis)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package serializers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+
+	"github.com/protobom/protobom/pkg/formats/github"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ native.Serializer = &GitHubSnapshot{}
+
+// GitHubSnapshot is a write-only serializer that renders the document as a
+// snapshot for the GitHub dependency submission API. Each root element of
+// the document becomes a manifest listing the packages reachable from it.
+// Nodes without a package URL are not submitted but their dependencies are.
+type GitHubSnapshot struct{}
+
+// GitHubSnapshotOptions groups the configuration options for the GitHub
+// snapshot serializer. Sha and Ref are required by the API.
+type GitHubSnapshotOptions struct {
+	// Sha is the commit the snapshot describes
+	Sha string
+
+	// Ref is the git reference of the commit, eg refs/heads/main
+	Ref string
+
+	// Correlator groups the snapshots that replace each other, usually
+	// the workflow and job names.
+	Correlator string
+
+	// JobID is the ID of the workflow run submitting the snapshot
+	JobID string
+
+	// DetectorName, DetectorVersion and DetectorURL describe the tool
+	// that produced the data. They default to protobom.
+	DetectorName    string
+	DetectorVersion string
+	DetectorURL     string
+
+	// Manifests maps root element IDs to the path of the manifest file
+	// they were generated from.
+	Manifests map[string]string
+}
+
+var DefaultGitHubSnapshotOptions = GitHubSnapshotOptions{
+	DetectorName: "protobom",
+	DetectorURL:  "https://github.com/protobom/protobom",
+}
+
+// githubDependencyEdges are the edge types relating packages to their
+// dependencies. The reverse types point from the dependency to the packages
+// using it.
+var githubDependencyEdges = []sbom.Edge_Type{
+	sbom.Edge_contains,
+	sbom.Edge_dependsOn,
+	sbom.Edge_dynamicLink,
+	sbom.Edge_optionalComponent,
+	sbom.Edge_optionalDependency,
+	sbom.Edge_runtimeDependency,
+	sbom.Edge_staticLink,
+	sbom.Edge_dependencyOf,
+	sbom.Edge_buildDependency,
+	sbom.Edge_buildTool,
+	sbom.Edge_devDependency,
+	sbom.Edge_devTool,
+	sbom.Edge_providedDependency,
+	sbom.Edge_testDependency,
+}
+
+// githubDevelopmentEdges are the edge types of dependencies not needed
+// at runtime.
+var githubDevelopmentEdges = []sbom.Edge_Type{
+	sbom.Edge_buildDependency,
+	sbom.Edge_buildTool,
+	sbom.Edge_devDependency,
+	sbom.Edge_devTool,
+	sbom.Edge_testDependency,
+}
+
+// githubDependency is a dependency edge with the scope it implies
+type githubDependency struct {
+	id          string
+	development bool
+}
+
+func NewGitHubSnapshot() *GitHubSnapshot {
+	return &GitHubSnapshot{}
+}
+
+// Serialize builds the GitHub snapshot from the protobom document
+func (s *GitHubSnapshot) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts any) (any, error) {
+	if bom == nil {
+		return nil, errors.New("document is nil, unable to build GitHub snapshot")
+	}
+
+	opts := DefaultGitHubSnapshotOptions
+	if rawopts != nil {
+		var ok bool
+		if opts, ok = rawopts.(GitHubSnapshotOptions); !ok {
+			return nil, fmt.Errorf("error casting GitHub snapshot options")
+		}
+	}
+	if opts.Sha == "" || opts.Ref == "" {
+		return nil, errors.New("the commit sha and ref are required to build a GitHub snapshot")
+	}
+	if opts.DetectorName == "" {
+		opts.DetectorName = DefaultGitHubSnapshotOptions.DetectorName
+		opts.DetectorURL = DefaultGitHubSnapshotOptions.DetectorURL
+	}
+	if opts.DetectorVersion == "" {
+		opts.DetectorVersion = version.GetVersionInfo().GitVersion
+	}
+
+	scanned := time.Now().UTC()
+	if bom.GetMetadata().GetDate() != nil {
+		scanned = bom.GetMetadata().GetDate().AsTime().UTC()
+	}
+
+	snapshot := &github.Snapshot{
+		Version: 0,
+		Sha:     opts.Sha,
+		Ref:     opts.Ref,
+		Job: &github.Job{
+			Correlator: opts.Correlator,
+			ID:         opts.JobID,
+		},
+		Detector: &github.Detector{
+			Name:    opts.DetectorName,
+			Version: opts.DetectorVersion,
+			URL:     opts.DetectorURL,
+		},
+		Scanned:   scanned.Format(time.RFC3339),
+		Manifests: map[string]*github.Manifest{},
+	}
+
+	nl := bom.GetNodeList()
+	if nl == nil {
+		return snapshot, nil
+	}
+
+	deps := githubDependencies(nl)
+	for _, root := range nl.GetRootNodes() {
+		name := root.Name
+		if name == "" {
+			name = root.Id
+		}
+		manifest := &github.Manifest{
+			Name:     name,
+			Resolved: githubResolved(nl, deps, root.Id),
+		}
+		if path, ok := opts.Manifests[root.Id]; ok {
+			manifest.File = &github.ManifestFile{SourceLocation: path}
+		} else if root.Type == sbom.Node_FILE {
+			manifest.File = &github.ManifestFile{SourceLocation: root.Name}
+		}
+		snapshot.Manifests[name] = manifest
+	}
+
+	return snapshot, nil
+}
+
+// githubDependencies indexes the dependencies of each node, following the
+// edges that describe dependency relationships in either direction.
+func githubDependencies(nl *sbom.NodeList) map[string][]githubDependency {
+	deps := map[string][]githubDependency{}
+	for _, e := range nl.Edges {
+		if !slices.Contains(githubDependencyEdges, e.Type) {
+			continue
+		}
+		dev := slices.Contains(githubDevelopmentEdges, e.Type)
+		for _, to := range e.To {
+			if e.Type.IsReverse() {
+				deps[to] = append(deps[to], githubDependency{id: e.From, development: dev})
+			} else {
+				deps[e.From] = append(deps[e.From], githubDependency{id: to, development: dev})
+			}
+		}
+	}
+	return deps
+}
+
+// githubPurlDependencies returns the dependencies of a node that have a
+// package URL. Nodes without a package URL are traversed to reach their
+// own dependencies.
+func githubPurlDependencies(nl *sbom.NodeList, deps map[string][]githubDependency, id string) []githubDependency {
+	ret := []githubDependency{}
+	seen := map[string]struct{}{id: {}}
+	queue := slices.Clone(deps[id])
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
+		if _, ok := seen[dep.id]; ok {
+			continue
+		}
+		seen[dep.id] = struct{}{}
+		n := nl.GetNodeByID(dep.id)
+		if n == nil {
+			continue
+		}
+		if n.Purl() != "" {
+			ret = append(ret, dep)
+			continue
+		}
+		for _, d := range deps[dep.id] {
+			queue = append(queue, githubDependency{id: d.id, development: dep.development || d.development})
+		}
+	}
+	return ret
+}
+
+// githubResolved returns the packages reachable from a root element
+func githubResolved(nl *sbom.NodeList, deps map[string][]githubDependency, rootID string) map[string]*github.ResolvedDep {
+	resolved := map[string]*github.ResolvedDep{}
+
+	// Walk the graph breadth first so the shortest path sets the
+	// relationship and scope of each package.
+	type step struct {
+		githubDependency
+		direct bool
+	}
+	queue := []step{}
+	for _, d := range githubPurlDependencies(nl, deps, rootID) {
+		queue = append(queue, step{githubDependency: d, direct: true})
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current.id == rootID {
+			continue
+		}
+
+		n := nl.GetNodeByID(current.id)
+		purl := string(n.Purl())
+		if _, ok := resolved[purl]; ok {
+			continue
+		}
+
+		rel := github.RelationshipIndirect
+		if current.direct {
+			rel = github.RelationshipDirect
+		}
+		scope := github.ScopeRuntime
+		if current.development {
+			scope = github.ScopeDevelopment
+		}
+		resolved[purl] = &github.ResolvedDep{
+			PackageURL:   purl,
+			Relationship: rel,
+			Scope:        scope,
+			Dependencies: []string{},
+		}
+
+		for _, d := range githubPurlDependencies(nl, deps, n.Id) {
+			dpurl := string(nl.GetNodeByID(d.id).Purl())
+			if !slices.Contains(resolved[purl].Dependencies, dpurl) {
+				resolved[purl].Dependencies = append(resolved[purl].Dependencies, dpurl)
+			}
+			queue = append(queue, step{githubDependency: githubDependency{id: d.id, development: current.development || d.development}})
+		}
+		slices.Sort(resolved[purl].Dependencies)
+	}
+	return resolved
+}
+
+// Render writes the snapshot as JSON to wr
+func (s *GitHubSnapshot) Render(doc any, wr io.Writer, o *native.RenderOptions, _ any) error {
+	snapshot, ok := doc.(*github.Snapshot)
+	if !ok {
+		return errors.New("unable to cast doc as GitHub snapshot")
+	}
+
+	enc := json.NewEncoder(wr)
+	enc.SetIndent("", o.IndentString())
+	if err := enc.Encode(snapshot); err != nil {
+		return fmt.Errorf("encoding GitHub snapshot: %w", err)
+	}
+	return nil
+}
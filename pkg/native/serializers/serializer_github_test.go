@@ -0,0 +1,222 @@
+package serializers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats/github"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/unserializers"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestGitHubSnapshotSerialize(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): p}
+	}
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{},
+		NodeList: &sbom.NodeList{
+			Nodes: []*sbom.Node{
+				{Id: "app", Name: "package-lock.json", Type: sbom.Node_FILE},
+				{Id: "express", Name: "express", Identifiers: purl("pkg:npm/express@4.18.2")},
+				{Id: "group", Name: "bundled"},
+				{Id: "debug", Name: "debug", Identifiers: purl("pkg:npm/debug@2.6.9")},
+				{Id: "ms", Name: "ms", Identifiers: purl("pkg:npm/ms@2.0.0")},
+				{Id: "jest", Name: "jest", Identifiers: purl("pkg:npm/jest@29.0.0")},
+			},
+			Edges: []*sbom.Edge{
+				{Type: sbom.Edge_dependsOn, From: "app", To: []string{"express", "group"}},
+				{Type: sbom.Edge_contains, From: "group", To: []string{"debug"}},
+				{Type: sbom.Edge_dependencyOf, From: "ms", To: []string{"debug"}},
+				{Type: sbom.Edge_dependsOn, From: "express", To: []string{"debug"}},
+				{Type: sbom.Edge_devDependency, From: "jest", To: []string{"app"}},
+			},
+			RootElements: []string{"app"},
+		},
+	}
+	resolved := map[string]*github.ResolvedDep{
+		"pkg:npm/express@4.18.2": {
+			PackageURL: "pkg:npm/express@4.18.2", Relationship: github.RelationshipDirect, Scope: github.ScopeRuntime,
+			Dependencies: []string{"pkg:npm/debug@2.6.9"},
+		},
+		// Reached through a node without purl, still a direct dependency
+		"pkg:npm/debug@2.6.9": {
+			PackageURL: "pkg:npm/debug@2.6.9", Relationship: github.RelationshipDirect, Scope: github.ScopeRuntime,
+			Dependencies: []string{"pkg:npm/ms@2.0.0"},
+		},
+		"pkg:npm/ms@2.0.0": {
+			PackageURL: "pkg:npm/ms@2.0.0", Relationship: github.RelationshipIndirect, Scope: github.ScopeRuntime,
+			Dependencies: []string{},
+		},
+		"pkg:npm/jest@29.0.0": {
+			PackageURL: "pkg:npm/jest@29.0.0", Relationship: github.RelationshipDirect, Scope: github.ScopeDevelopment,
+			Dependencies: []string{},
+		},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		doc      *sbom.Document
+		opts     any
+		mustErr  bool
+		location string
+	}{
+		{name: "sha and ref are required", doc: doc, mustErr: true},
+		{name: "nil document", opts: GitHubSnapshotOptions{Sha: "0123456789abcdef0123456789abcdef01234567", Ref: "refs/heads/main"}, mustErr: true},
+		{
+			name:     "resolved dependencies",
+			doc:      doc,
+			opts:     GitHubSnapshotOptions{Sha: "0123456789abcdef0123456789abcdef01234567", Ref: "refs/heads/main", Correlator: "ci"},
+			location: "package-lock.json",
+		},
+		{
+			// The manifest paths can be set in the options
+			name: "manifest paths",
+			doc:  doc,
+			opts: GitHubSnapshotOptions{
+				Sha: "0123456789abcdef0123456789abcdef01234567", Ref: "refs/heads/main", Correlator: "ci",
+				Manifests: map[string]string{"app": "web/package-lock.json"},
+			},
+			location: "web/package-lock.json",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := NewGitHubSnapshot().Serialize(tc.doc, &native.SerializeOptions{}, tc.opts)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			snapshot, ok := res.(*github.Snapshot)
+			require.True(t, ok)
+			require.Equal(t, "protobom", snapshot.Detector.Name)
+			require.Equal(t, "ci", snapshot.Job.Correlator)
+
+			require.Len(t, snapshot.Manifests, 1)
+			manifest := snapshot.Manifests["package-lock.json"]
+			require.NotNil(t, manifest)
+			require.Equal(t, tc.location, manifest.File.SourceLocation)
+			require.Equal(t, resolved, manifest.Resolved)
+		})
+	}
+}
+
+func TestGitHubSnapshotRender(t *testing.T) {
+	doc := &sbom.Document{
+		Metadata: &sbom.Metadata{},
+		NodeList: &sbom.NodeList{
+			Nodes: []*sbom.Node{
+				{Id: "app", Name: "package-lock.json", Type: sbom.Node_FILE},
+				{Id: "express", Name: "express", Identifiers: map[int32]string{
+					int32(sbom.SoftwareIdentifierType_PURL): "pkg:npm/express@4.18.2",
+				}},
+			},
+			Edges:        []*sbom.Edge{{Type: sbom.Edge_dependsOn, From: "app", To: []string{"express"}}},
+			RootElements: []string{"app"},
+		},
+	}
+	s := NewGitHubSnapshot()
+	snapshot, err := s.Serialize(doc, &native.SerializeOptions{}, GitHubSnapshotOptions{
+		Sha: "0123456789abcdef0123456789abcdef01234567", Ref: "refs/heads/main",
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		snapshot any
+		opts     *native.RenderOptions
+		contains []string
+		mustErr  bool
+	}{
+		{
+			name:     "indented",
+			snapshot: snapshot,
+			opts:     &native.RenderOptions{Indent: 2},
+			contains: []string{`"ref": "refs/heads/main"`, `"package_url": "pkg:npm/express@4.18.2"`},
+		},
+		{
+			name:     "minified",
+			snapshot: snapshot,
+			opts:     &native.RenderOptions{Minify: true},
+			contains: []string{`"ref":"refs/heads/main"`},
+		},
+		{name: "not a snapshot", snapshot: "not a snapshot", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := s.Render(tc.snapshot, &b, tc.opts, nil)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, json.Valid(b.Bytes()))
+			for _, want := range tc.contains {
+				require.Contains(t, b.String(), want)
+			}
+		})
+	}
+}
+
+func TestGitHubSnapshotEdgeDirection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		relationship string
+		scope        string
+	}{
+		"depends on":             {"DEPENDS_ON", github.ScopeRuntime},
+		"dependency of":          {"DEPENDENCY_OF", github.ScopeRuntime},
+		"runtime dependency of":  {"RUNTIME_DEPENDENCY_OF", github.ScopeRuntime},
+		"optional dependency of": {"OPTIONAL_DEPENDENCY_OF", github.ScopeRuntime},
+		"dev dependency of":      {"DEV_DEPENDENCY_OF", github.ScopeDevelopment},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// The relationships run from the app to the library unless
+			// they are one of the *_OF types
+			from, to := "SPDXRef-app", "SPDXRef-lib"
+			if strings.HasSuffix(tc.relationship, "_OF") {
+				from, to = to, from
+			}
+			spdxJSON := fmt.Sprintf(`{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "app",
+  "documentNamespace": "https://example.com/app",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [
+    {"SPDXID": "SPDXRef-app", "name": "app", "downloadLocation": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/app@1.0.0"}]},
+    {"SPDXID": "SPDXRef-lib", "name": "lib", "downloadLocation": "NOASSERTION",
+     "externalRefs": [{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:npm/lib@2.0.0"}]}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relationshipType": "DESCRIBES", "relatedSpdxElement": "SPDXRef-app"},
+    {"spdxElementId": %q, "relationshipType": %q, "relatedSpdxElement": %q}
+  ]
+}`, from, tc.relationship, to)
+			doc, err := unserializers.NewSPDX23().Unserialize(strings.NewReader(spdxJSON), &native.UnserializeOptions{}, nil)
+			require.NoError(t, err)
+
+			opts := GitHubSnapshotOptions{Sha: "0123456789abcdef0123456789abcdef01234567", Ref: "refs/heads/main"}
+			res, err := NewGitHubSnapshot().Serialize(doc, &native.SerializeOptions{}, opts)
+			require.NoError(t, err)
+			snapshot, ok := res.(*github.Snapshot)
+			require.True(t, ok)
+			require.Len(t, snapshot.Manifests, 1)
+			for _, manifest := range snapshot.Manifests {
+				// The library is a direct dependency of the app
+				require.Len(t, manifest.Resolved, 1)
+				lib := manifest.Resolved["pkg:npm/lib@2.0.0"]
+				require.NotNil(t, lib)
+				require.Equal(t, github.RelationshipDirect, lib.Relationship)
+				require.Equal(t, tc.scope, lib.Scope)
+			}
+		})
+	}
+}
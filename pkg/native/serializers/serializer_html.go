@@ -0,0 +1,80 @@
+package serializers
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ native.Serializer = &HTML{}
+
+//go:embed templates/report.html.tmpl
+var htmlReportTemplate string
+
+// HTML is a write-only serializer that renders a human-readable report of
+// the document: its metadata, a component table, the dependency tree and
+// a license breakdown.
+type HTML struct{}
+
+// HTMLOptions groups the configuration options for the HTML report serializer.
+type HTMLOptions struct {
+	// Template is an html/template string used to render the report instead
+	// of the built-in template. The template is executed with the report
+	// data model, the "join" function (strings.Join) is available.
+	Template string
+}
+
+var DefaultHTMLOptions = HTMLOptions{}
+
+func NewHTML() *HTML {
+	return &HTML{}
+}
+
+// Serialize builds the report data model from the protobom document
+func (s *HTML) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ any) (any, error) {
+	if bom == nil {
+		return nil, errors.New("document is nil, unable to build HTML report")
+	}
+	if bom.Metadata == nil {
+		return nil, errors.New("document metadata is nil, unable to build HTML report")
+	}
+	return buildReport(bom), nil
+}
+
+// Render executes the report template and writes the HTML output to wr
+func (s *HTML) Render(doc any, wr io.Writer, _ *native.RenderOptions, rawopts any) error {
+	r, ok := doc.(*report)
+	if !ok {
+		return errors.New("unable to cast doc as report")
+	}
+
+	opts := DefaultHTMLOptions
+	if rawopts != nil {
+		if opts, ok = rawopts.(HTMLOptions); !ok {
+			return fmt.Errorf("error casting HTML options")
+		}
+	}
+
+	tmplData := htmlReportTemplate
+	if opts.Template != "" {
+		tmplData = opts.Template
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"join": strings.Join,
+	}).Parse(tmplData)
+	if err != nil {
+		return fmt.Errorf("parsing HTML report template: %w", err)
+	}
+
+	if err := tmpl.Execute(wr, r); err != nil {
+		return fmt.Errorf("rendering HTML report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package serializers
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func testReportDocument() *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Name = "test <document>"
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "root", Name: "app", Version: "1.0.0", Licenses: []string{"Apache-2.0"}})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib1", Name: "lib-one", Version: "0.1.0", Licenses: []string{"MIT"}})
+	doc.NodeList.AddNode(&sbom.Node{Id: "lib2", Name: "lib-two", LicenseConcluded: "MIT"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "file", Name: "README", Type: sbom.Node_FILE})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib1", "lib2"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "lib1", To: []string{"root"}})
+	return doc
+}
+
+// testLadderDocument returns a ladder where every step depends on both
+// nodes of the next one, it has 2^steps paths.
+func testLadderDocument(steps int) *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.NodeList.AddRootNode(&sbom.Node{Id: "root", Name: "root"})
+	prev := []string{"root"}
+	for i := range steps {
+		step := []string{fmt.Sprintf("a%d", i), fmt.Sprintf("b%d", i)}
+		for _, id := range step {
+			doc.NodeList.AddNode(&sbom.Node{Id: id, Name: id})
+		}
+		for _, from := range prev {
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: from, To: step})
+		}
+		prev = step
+	}
+	return doc
+}
+
+func TestBuildReport(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		sut        *sbom.Document
+		stats      reportStats
+		components []string
+		licenses   []*reportLicenseCount
+	}{
+		{
+			name: "components and licenses",
+			sut:  testReportDocument(),
+			stats: reportStats{
+				Nodes: 4, Packages: 3, Files: 1, Edges: 2, RootNodes: 1,
+				NoLicense: 1, NoVersion: 2, LicenseKinds: 2,
+			},
+			components: []string{"README", "app", "lib-one", "lib-two"},
+			licenses:   []*reportLicenseCount{{License: "MIT", Count: 2}, {License: "Apache-2.0", Count: 1}},
+		},
+		{
+			name:       "empty document",
+			sut:        sbom.NewDocument(),
+			components: []string{},
+			licenses:   []*reportLicenseCount{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := buildReport(tc.sut)
+			require.Equal(t, tc.stats, r.Stats)
+			names := []string{}
+			for _, c := range r.Components {
+				names = append(names, c.Name)
+			}
+			require.Equal(t, tc.components, names)
+			require.Equal(t, tc.licenses, r.Licenses)
+		})
+	}
+}
+
+func TestBuildReportTree(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		sut  *sbom.Document
+		// expanded and refs count the expanded and the reference entries
+		expanded int
+		refs     int
+	}{
+		// The tree is cut at the cycle
+		{name: "cycle", sut: testReportDocument(), expanded: 3, refs: 1},
+		// Each node of a graph with an exponential number of paths is
+		// expanded once
+		{name: "dag", sut: testLadderDocument(45), expanded: 91, refs: 88},
+		{name: "empty document", sut: sbom.NewDocument()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := buildReport(tc.sut)
+			expanded, refs := 0, 0
+			var count func([]*reportTreeNode)
+			count = func(nodes []*reportTreeNode) {
+				for _, n := range nodes {
+					if n.Ref {
+						refs++
+						require.Empty(t, n.Children)
+					} else {
+						expanded++
+					}
+					count(n.Children)
+				}
+			}
+			count(r.Tree)
+			require.Equal(t, tc.expanded, expanded)
+			require.Equal(t, tc.refs, refs)
+		})
+	}
+}
+
+func TestHTMLRender(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *sbom.Document
+		opts     any
+		contains []string
+		expected string
+		mustErr  bool
+	}{
+		{
+			name:     "builtin template",
+			sut:      testReportDocument(),
+			contains: []string{"<h1>test &lt;document&gt;</h1>", "<td>lib-one</td>", "Dependency Tree"},
+		},
+		{
+			name:     "reference to an expanded node",
+			sut:      testLadderDocument(45),
+			contains: []string{`href="#tree-b44"`},
+		},
+		{
+			// Custom templates override the builtin one
+			name:     "custom template",
+			sut:      testReportDocument(),
+			opts:     HTMLOptions{Template: `{{ range .Components }}{{ .Name }};{{ end }}`},
+			expected: "README;app;lib-one;lib-two;",
+		},
+		{
+			name:    "nil document",
+			mustErr: true,
+		},
+		{
+			name:    "broken template",
+			sut:     testReportDocument(),
+			opts:    HTMLOptions{Template: "{{ .Broken"},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewHTML()
+			var b bytes.Buffer
+			doc, err := s.Serialize(tc.sut, &native.SerializeOptions{}, nil)
+			if err == nil {
+				err = s.Render(doc, &b, &native.RenderOptions{}, tc.opts)
+			}
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tc.contains {
+				require.Contains(t, b.String(), s)
+			}
+			if tc.expected != "" {
+				require.Equal(t, tc.expected, b.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package serializers
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ native.Serializer = &Markdown{}
+
+//go:embed templates/report.md.tmpl
+var markdownReportTemplate string
+
+// Markdown is a write-only serializer that renders a compact summary of the
+// document intended to be posted as a pull request comment by CI bots. When
+// a baseline document is configured, the report includes the components
+// added, removed and updated since the baseline.
+type Markdown struct{}
+
+// MarkdownOptions groups the configuration options for the markdown serializer.
+type MarkdownOptions struct {
+	// Baseline is a document to compare against. When set, the report
+	// includes a section with the component changes.
+	Baseline *sbom.Document
+
+	// DeniedLicenses is a list of license identifiers that trigger a license
+	// alert when found in a component license or license expression.
+	DeniedLicenses []string
+
+	// AlertOnMissingLicense adds an alert for components without license data.
+	AlertOnMissingLicense bool
+
+	// Template is a text/template string used instead of the built-in template.
+	Template string
+}
+
+var DefaultMarkdownOptions = MarkdownOptions{}
+
+// markdownReport extends the report model with the change and alert data
+type markdownReport struct {
+	*report
+	Delta  *reportDelta
+	Alerts []*reportAlert
+}
+
+// reportDelta captures the component changes from a baseline
+type reportDelta struct {
+	Added   []*reportComponent
+	Removed []*reportComponent
+	Updated []*reportUpdate
+}
+
+// reportUpdate is a component whose version changed from the baseline
+type reportUpdate struct {
+	Name string
+	From string
+	To   string
+}
+
+// reportAlert is a component flagged by the license checks
+type reportAlert struct {
+	Name    string
+	Version string
+	License string
+}
+
+func NewMarkdown() *Markdown {
+	return &Markdown{}
+}
+
+// Serialize builds the markdown report data from the protobom document
+func (s *Markdown) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts any) (any, error) {
+	if bom == nil {
+		return nil, errors.New("document is nil, unable to build markdown report")
+	}
+	if bom.Metadata == nil {
+		return nil, errors.New("document metadata is nil, unable to build markdown report")
+	}
+
+	opts := DefaultMarkdownOptions
+	if rawopts != nil {
+		var ok bool
+		if opts, ok = rawopts.(MarkdownOptions); !ok {
+			return nil, fmt.Errorf("error casting markdown options")
+		}
+	}
+
+	r := &markdownReport{
+		report: buildReport(bom),
+		Alerts: []*reportAlert{},
+	}
+
+	if opts.Baseline != nil {
+		r.Delta = computeReportDelta(buildReport(opts.Baseline), r.report)
+	}
+
+	for _, c := range r.Components {
+		if len(c.Licenses) == 0 && opts.AlertOnMissingLicense {
+			r.Alerts = append(r.Alerts, &reportAlert{Name: c.Name, Version: c.Version, License: "(none)"})
+			continue
+		}
+		for _, l := range c.Licenses {
+			if licenseMatchesAny(l, opts.DeniedLicenses) {
+				r.Alerts = append(r.Alerts, &reportAlert{Name: c.Name, Version: c.Version, License: l})
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Render executes the markdown template and writes the output to wr
+func (s *Markdown) Render(doc any, wr io.Writer, _ *native.RenderOptions, rawopts any) error {
+	r, ok := doc.(*markdownReport)
+	if !ok {
+		return errors.New("unable to cast doc as markdown report")
+	}
+
+	opts := DefaultMarkdownOptions
+	if rawopts != nil {
+		if opts, ok = rawopts.(MarkdownOptions); !ok {
+			return fmt.Errorf("error casting markdown options")
+		}
+	}
+
+	tmplData := markdownReportTemplate
+	if opts.Template != "" {
+		tmplData = opts.Template
+	}
+
+	tmpl, err := template.New("report").Funcs(template.FuncMap{
+		"join": strings.Join,
+		"md":   escapeMarkdownCell,
+	}).Parse(tmplData)
+	if err != nil {
+		return fmt.Errorf("parsing markdown report template: %w", err)
+	}
+
+	if err := tmpl.Execute(wr, r); err != nil {
+		return fmt.Errorf("rendering markdown report: %w", err)
+	}
+	return nil
+}
+
+// componentKey returns the string used to match components between two
+// reports. Versions are not part of the key so updates can be detected.
+func componentKey(c *reportComponent) string {
+	if c.Purl != "" {
+		// Strip the version from the purl
+		purl, _, _ := strings.Cut(c.Purl, "@")
+		return purl
+	}
+	return c.Type + ":" + c.Name
+}
+
+// computeReportDelta compares the components of two reports
+func computeReportDelta(baseline, current *report) *reportDelta {
+	delta := &reportDelta{
+		Added:   []*reportComponent{},
+		Removed: []*reportComponent{},
+		Updated: []*reportUpdate{},
+	}
+
+	before := map[string][]*reportComponent{}
+	for _, c := range baseline.Components {
+		before[componentKey(c)] = append(before[componentKey(c)], c)
+	}
+	after := map[string][]*reportComponent{}
+	for _, c := range current.Components {
+		after[componentKey(c)] = append(after[componentKey(c)], c)
+	}
+
+	for _, c := range current.Components {
+		prev, ok := before[componentKey(c)]
+		if !ok {
+			delta.Added = append(delta.Added, c)
+			continue
+		}
+		if len(prev) == 1 && len(after[componentKey(c)]) == 1 && prev[0].Version != c.Version {
+			delta.Updated = append(delta.Updated, &reportUpdate{Name: c.Name, From: prev[0].Version, To: c.Version})
+		}
+	}
+
+	for _, c := range baseline.Components {
+		if _, ok := after[componentKey(c)]; !ok {
+			delta.Removed = append(delta.Removed, c)
+		}
+	}
+	return delta
+}
+
+// licenseMatchesAny returns true if any of the license IDs appear in the
+// license string, which may be an SPDX license expression.
+func licenseMatchesAny(license string, ids []string) bool {
+	if len(ids) == 0 {
+		return false
+	}
+	tokens := strings.FieldsFunc(license, func(r rune) bool {
+		return r == ' ' || r == '(' || r == ')'
+	})
+	for _, t := range tokens {
+		if slices.Contains(ids, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeMarkdownCell escapes a string to be used in a markdown table cell
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\n", " ")
+	return s
+}
@@ -0,0 +1,132 @@
+package serializers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestLicenseMatchesAny(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		license  string
+		ids      []string
+		expected bool
+	}{
+		{"same license", "GPL-3.0-only", []string{"GPL-3.0-only"}, true},
+		{"license in expression", "(MIT OR GPL-3.0-only)", []string{"GPL-3.0-only"}, true},
+		{"license name suffix", "LGPL-3.0-only", []string{"GPL-3.0-only"}, false},
+		{"no ids", "MIT", nil, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, licenseMatchesAny(tc.license, tc.ids))
+		})
+	}
+}
+
+func TestComputeReportDelta(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*sbom.Document)
+		added   []string
+		removed []string
+		updated []*reportUpdate
+	}{
+		{
+			name:    "no changes",
+			prepare: func(*sbom.Document) {},
+		},
+		{
+			name: "added",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.AddNode(&sbom.Node{Id: "lib3", Name: "lib-three", Version: "3.0.0"})
+			},
+			added: []string{"lib-three"},
+		},
+		{
+			name: "removed",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.RemoveNodes([]string{"lib2"})
+			},
+			removed: []string{"lib-two"},
+		},
+		{
+			name: "updated",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.GetNodeByID("lib1").Version = "0.2.0"
+			},
+			updated: []*reportUpdate{{Name: "lib-one", From: "0.1.0", To: "0.2.0"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			current := testReportDocument()
+			tc.prepare(current)
+			delta := computeReportDelta(buildReport(testReportDocument()), buildReport(current))
+
+			names := func(components []*reportComponent) []string {
+				var ret []string
+				for _, c := range components {
+					ret = append(ret, c.Name)
+				}
+				return ret
+			}
+			require.Equal(t, tc.added, names(delta.Added))
+			require.Equal(t, tc.removed, names(delta.Removed))
+			require.Len(t, delta.Updated, len(tc.updated))
+			for i, u := range tc.updated {
+				require.Equal(t, u, delta.Updated[i])
+			}
+		})
+	}
+}
+
+func TestMarkdownRender(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		opts        any
+		contains    []string
+		notContains []string
+	}{
+		{
+			name: "baseline and alerts",
+			opts: MarkdownOptions{
+				Baseline:       testReportDocument(),
+				DeniedLicenses: []string{"GPL-3.0-only"},
+			},
+			contains: []string{"1 added, 0 removed, 0 updated.", "License alerts", `| gpl\|lib | 1.0 | GPL-3.0-only |`},
+		},
+		{
+			name:        "baseline only",
+			opts:        MarkdownOptions{Baseline: testReportDocument()},
+			contains:    []string{"Changes from baseline", "1 added, 0 removed, 0 updated."},
+			notContains: []string{"License alerts"},
+		},
+		{
+			// Without baseline or alerts the sections are not rendered
+			name:        "no options",
+			notContains: []string{"Changes from baseline", "License alerts"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			current := testReportDocument()
+			current.NodeList.AddNode(&sbom.Node{Id: "gpl", Name: "gpl|lib", Version: "1.0", Licenses: []string{"GPL-3.0-only"}})
+
+			s := NewMarkdown()
+			doc, err := s.Serialize(current, &native.SerializeOptions{}, tc.opts)
+			require.NoError(t, err)
+
+			var b bytes.Buffer
+			require.NoError(t, s.Render(doc, &b, &native.RenderOptions{}, tc.opts))
+			for _, s := range tc.contains {
+				require.Contains(t, b.String(), s)
+			}
+			for _, s := range tc.notContains {
+				require.NotContains(t, b.String(), s)
+			}
+		})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,8 +15,10 @@ import (
 	"github.com/spdx/tools-golang/spdx"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/spdx/tools-golang/tagvalue"
 	"sigs.k8s.io/release-utils/version"
 
+	"github.com/protobom/protobom/pkg/formats"
 	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
@@ -23,7 +27,11 @@ import (
 
 var _ native.Serializer = &SPDX23{}
 
-type SPDX23 struct{}
+// SPDX23 renders SPDX 2.3 documents, in JSON unless created with
+// NewSPDX23TV
+type SPDX23 struct {
+	encoding string
+}
 
 type SPDX23Options struct {
 	// Deprecated: FailOnInvalidDocIdFragment makes the serializer return an
@@ -68,14 +76,31 @@ func NewSPDX23() *SPDX23 {
 	return &SPDX23{}
 }
 
+// NewSPDX23TV returns a serializer that renders SPDX 2.3 documents in the
+// tag-value encoding
+func NewSPDX23TV() *SPDX23 {
+	return &SPDX23{encoding: formats.TEXT}
+}
+
 func (s *SPDX23) Render(doc any, wr io.Writer, o *native.RenderOptions, _ any) error {
-	// TODO: add support for XML
-	encoder := json.NewEncoder(wr)
-	encoder.SetIndent("", strings.Repeat(" ", o.Indent))
 	spdxDoc, ok := doc.(*spdx.Document)
 	if !ok {
 		return errors.New("unable to cast doc as spdx.Document")
 	}
+
+	// The tag-value format has no indentation
+	if s.encoding == formats.TEXT {
+		if err := tagvalue.Write(spdxDoc, wr); err != nil {
+			return fmt.Errorf("encoding sbom to stream: %w", err)
+		}
+		return nil
+	}
+
+	encoder := json.NewEncoder(wr)
+	encoder.SetIndent("", o.IndentString())
+	// Purl qualifiers and license texts are written verbatim, the output
+	// is not meant to be embedded in HTML.
+	encoder.SetEscapeHTML(false)
 	if err := encoder.Encode(spdxDoc); err != nil {
 		return fmt.Errorf("encoding sbom to stream: %w", err)
 	}
@@ -122,6 +147,16 @@ func spdxNamespaceFromProtobomID(opts SPDX23Options, protoId string) (spdxId str
 	return strings.Replace(protoId, "#SPDXRef-DOCUMENT", "", 1), nil
 }
 
+// spdxRevisionSuffixRe matches the revision suffix added to SPDX namespaces
+var spdxRevisionSuffixRe = regexp.MustCompile(`-r[0-9]+$`)
+
+// spdxRevisionNamespace returns the namespace of a revised document. SPDX
+// requires each version of a document to have a unique namespace so the
+// version is appended to it, replacing the suffix of any previous revision.
+func spdxRevisionNamespace(ns, version string) string {
+	return spdxRevisionSuffixRe.ReplaceAllString(ns, "") + "-r" + version
+}
+
 // Serialize takes a protobom and returns an SPDX 2.3 struct
 func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOptions, rawopts any) (any, error) {
 	if bom == nil {
@@ -149,6 +184,11 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 		return nil, fmt.Errorf("serializing SPDX namespace: %w", err)
 	}
 
+	revision := bom.Metadata.LatestRevision()
+	if revision != nil {
+		ns = spdxRevisionNamespace(ns, revision.Version)
+	}
+
 	doc := &spdx.Document{
 		SPDXVersion:       spdx.Version,
 		DataLicense:       spdx.DataLicense,
@@ -168,11 +208,32 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 			},
 
 			// Interesting, should we keep the original date?
-			Created: time.Now().UTC().Format(time.RFC3339),
-			// CreatorComment: bom.Metadata.... /// TODO(puerco): Missing in the proto
+			Created:        time.Now().UTC().Format(time.RFC3339),
+			CreatorComment: bom.Metadata.CreatorComment,
 		},
 	}
 
+	if revision != nil && revision.Reason != "" {
+		revisionComment := fmt.Sprintf("Revision %s: %s", revision.Version, revision.Reason)
+		if doc.CreationInfo.CreatorComment != "" {
+			revisionComment = doc.CreationInfo.CreatorComment + "\n" + revisionComment
+		}
+		doc.CreationInfo.CreatorComment = revisionComment
+	}
+
+	// Document properties are rendered as annotations on the document
+	if serializeopts.IsModEnabled(mod.SPDX_RENDER_PROPERTIES_IN_ANNOTATIONS) {
+		for i, property := range bom.Metadata.Properties {
+			annotation, err := propertyAnnotation(property, string(protospdx.DOCUMENT))
+			if err != nil {
+				return nil, fmt.Errorf(
+					"unable to serialize document property #%d (%s): %w", i, property.Name, err,
+				)
+			}
+			doc.Annotations = append(doc.Annotations, &annotation)
+		}
+	}
+
 	for _, t := range bom.Metadata.Tools {
 		// TODO(degradation): SPDX is prescriptive on how this field is structured
 		// it is a tool identifier word separated from the version with a dash.
@@ -213,6 +274,8 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 		})
 	}
 
+	doc.OtherLicenses = buildOtherLicenses(bom)
+
 	packages, err := s.buildPackages(serializeopts, opts, bom)
 	if err != nil {
 		return nil, fmt.Errorf("building SPDX packages: %w", err)
@@ -244,9 +307,120 @@ func (s *SPDX23) Serialize(bom *sbom.Document, serializeopts *native.SerializeOp
 	doc.Files = files
 	doc.Relationships = rels
 
+	// The tag-value readers only accept identifiers made of letters,
+	// numbers, dots and dashes
+	if s.encoding == formats.TEXT {
+		rewriteTagValueIDs(doc)
+	}
+
 	return doc, nil
 }
 
+// invalidSPDXIDCharsRe matches the characters not allowed in the idstring of
+// SPDX element identifiers
+var invalidSPDXIDCharsRe = regexp.MustCompile(`[^a-zA-Z0-9.-]+`)
+
+// rewriteTagValueIDs replaces the package and file identifiers that are not
+// valid SPDX idstrings with valid unique ones derived from them, updating
+// the relationships and annotations that refer to them.
+func rewriteTagValueIDs(doc *spdx.Document) {
+	taken := map[common.ElementID]struct{}{}
+	ids := []common.ElementID{}
+	for _, p := range doc.Packages {
+		taken[p.PackageSPDXIdentifier] = struct{}{}
+		ids = append(ids, p.PackageSPDXIdentifier)
+	}
+	for _, f := range doc.Files {
+		taken[f.FileSPDXIdentifier] = struct{}{}
+		ids = append(ids, f.FileSPDXIdentifier)
+	}
+
+	renames := map[common.ElementID]common.ElementID{}
+	for _, id := range ids {
+		idstring := strings.TrimPrefix(string(id), "SPDXRef-")
+		valid := invalidSPDXIDCharsRe.ReplaceAllString(idstring, "-")
+		if valid == idstring && valid != "" {
+			continue
+		}
+		if _, ok := renames[id]; ok {
+			continue
+		}
+		if valid == "" {
+			valid = "element"
+		}
+		newID := common.ElementID(valid)
+		for i := 2; ; i++ {
+			if _, ok := taken[newID]; !ok {
+				break
+			}
+			newID = common.ElementID(fmt.Sprintf("%s-%d", valid, i))
+		}
+		taken[newID] = struct{}{}
+		renames[id] = newID
+	}
+	if len(renames) == 0 {
+		return
+	}
+
+	rename := func(id *common.ElementID) {
+		if newID, ok := renames[*id]; ok {
+			*id = newID
+		}
+	}
+	renameRef := func(ref *common.DocElementID) {
+		if ref.DocumentRefID == "" && ref.SpecialID == "" {
+			rename(&ref.ElementRefID)
+		}
+	}
+	for _, p := range doc.Packages {
+		rename(&p.PackageSPDXIdentifier)
+		for i := range p.Annotations {
+			renameRef(&p.Annotations[i].AnnotationSPDXIdentifier)
+		}
+	}
+	for _, f := range doc.Files {
+		rename(&f.FileSPDXIdentifier)
+		for i := range f.Annotations {
+			renameRef(&f.Annotations[i].AnnotationSPDXIdentifier)
+		}
+	}
+	for _, r := range doc.Relationships {
+		renameRef(&r.RefA)
+		renameRef(&r.RefB)
+	}
+	for _, a := range doc.Annotations {
+		renameRef(&a.AnnotationSPDXIdentifier)
+	}
+}
+
+// buildOtherLicenses returns the SPDX extracted licensing info entries from
+// the custom licenses defined in the protobom.
+func buildOtherLicenses(bom *sbom.Document) []*spdx.OtherLicense {
+	if len(bom.Metadata.CustomLicenses) == 0 {
+		return nil
+	}
+	ret := []*spdx.OtherLicense{}
+	for _, l := range bom.Metadata.CustomLicenses {
+		if l.Id == "" {
+			// TODO(degradation): Custom licenses without an ID cannot be referenced
+			continue
+		}
+		ol := &spdx.OtherLicense{
+			LicenseIdentifier:      l.Id,
+			ExtractedText:          l.Text,
+			LicenseName:            l.Name,
+			LicenseCrossReferences: l.Urls,
+			LicenseComment:         l.Comment,
+		}
+		// The extracted text is mandatory in SPDX
+		if ol.ExtractedText == "" {
+			ol.ExtractedText = protospdx.NOASSERTION
+		}
+		ret = append(ret, ol)
+	}
+	return ret
+}
+
 func buildRelationships(bom *sbom.Document) ([]*spdx.Relationship, error) { //nolint:unparam
 	relationships := []*spdx.Relationship{}
 	for _, e := range bom.NodeList.Edges {
@@ -364,51 +538,14 @@ func (s *SPDX23) buildPackages(
 			// Files:                       []*v2_3.File{},
 		}
 
-		if len(node.PrimaryPurpose) > 0 && (node.PrimaryPurpose[0] != sbom.Purpose_UNKNOWN_PURPOSE) {
-			// Allowed values: APPLICATION, FRAMEWORK, LIBRARY, CONTAINER, OPERATING-SYSTEM, DEVICE, FIRMWARE, SOURCE, ARCHIVE, FILE, INSTALL, OTHER
-
-			if len(node.PrimaryPurpose) > 1 {
-				// TODO(degradation): Multiple PrimaryPurpose in protobom.Node, but spdx.Package only allows single PrimaryPackagePurpose so we are using the first
-				if true { // temp workaround in favor of adding a lint tag
-					break
-				}
-			}
-
-			switch node.PrimaryPurpose[0] {
-			case sbom.Purpose_APPLICATION, sbom.Purpose_EXECUTABLE:
-				p.PrimaryPackagePurpose = "APPLICATION"
-			case sbom.Purpose_FRAMEWORK:
-				p.PrimaryPackagePurpose = "FRAMEWORK"
-			case sbom.Purpose_LIBRARY, sbom.Purpose_MODULE:
-				p.PrimaryPackagePurpose = "LIBRARY"
-			case sbom.Purpose_CONTAINER:
-				p.PrimaryPackagePurpose = "CONTAINER"
-			case sbom.Purpose_OPERATING_SYSTEM:
-				p.PrimaryPackagePurpose = "OPERATING-SYSTEM"
-			case sbom.Purpose_DEVICE, sbom.Purpose_DEVICE_DRIVER:
-				p.PrimaryPackagePurpose = "DEVICE"
-			case sbom.Purpose_FIRMWARE:
-				p.PrimaryPackagePurpose = "FIRMWARE"
-			case sbom.Purpose_SOURCE, sbom.Purpose_PATCH:
-				p.PrimaryPackagePurpose = "SOURCE"
-			case sbom.Purpose_ARCHIVE:
-				p.PrimaryPackagePurpose = "ARCHIVE"
-			case sbom.Purpose_FILE:
-				p.PrimaryPackagePurpose = "FILE"
-			case sbom.Purpose_INSTALL:
-				p.PrimaryPackagePurpose = "INSTALL"
-			case sbom.Purpose_OTHER, sbom.Purpose_DATA, sbom.Purpose_BOM,
-				sbom.Purpose_CONFIGURATION, sbom.Purpose_DOCUMENTATION,
-				sbom.Purpose_EVIDENCE, sbom.Purpose_MANIFEST, sbom.Purpose_REQUIREMENT,
-				sbom.Purpose_SPECIFICATION, sbom.Purpose_TEST,
-				sbom.Purpose_MACHINE_LEARNING_MODEL, sbom.Purpose_MODEL,
-				sbom.Purpose_PLATFORM:
-				p.PrimaryPackagePurpose = "OTHER"
-			default:
-				// TODO(degradation): Non-matching primary purpose to component type mapping
-				if true { // temp workaround in favor of adding a lint tag
-					break
-				}
+		// TODO(degradation): Multiple PrimaryPurpose in protobom.Node, but
+		// spdx.Package only allows single PrimaryPackagePurpose so we are
+		// using the first
+		if len(node.PrimaryPurpose) > 0 {
+			if custom, ok := serializeopts.CustomPurpose(node.PrimaryPurpose[0]); ok {
+				p.PrimaryPackagePurpose = custom
+			} else {
+				p.PrimaryPackagePurpose = node.PrimaryPurpose[0].ToSPDX2()
 			}
 		}
 
@@ -458,6 +595,22 @@ func (s *SPDX23) buildPackages(
 			})
 		}
 
+		// Vulnerabilities are rendered as security advisory references
+		for _, v := range bom.GetNodeVulnerabilities(node.Id) {
+			for _, url := range v.AdvisoryURLs() {
+				if slices.ContainsFunc(node.ExternalReferences, func(e *sbom.ExternalReference) bool {
+					return e.Type == sbom.ExternalReference_SECURITY_ADVISORY && e.Url == url
+				}) {
+					continue
+				}
+				p.PackageExternalReferences = append(p.PackageExternalReferences, &v2_3.PackageExternalReference{
+					Category: spdx.CategorySecurity,
+					RefType:  spdx.SecurityAdvisory,
+					Locator:  url,
+				})
+			}
+		}
+
 		for i := range node.Identifiers {
 			p.PackageExternalReferences = append(p.PackageExternalReferences, &v2_3.PackageExternalReference{
 				Category: sbom.SoftwareIdentifierType(i).ToSPDX2Category(),
@@ -488,26 +641,13 @@ func (s *SPDX23) buildPackages(
 		if len(node.Properties) > 0 &&
 			serializeopts.IsModEnabled(mod.SPDX_RENDER_PROPERTIES_IN_ANNOTATIONS) {
 			for i, property := range node.Properties {
-				jsonProperty, err := json.Marshal(property)
+				annotation, err := propertyAnnotation(property, node.Id)
 				if err != nil {
 					return nil, fmt.Errorf(
 						"unable to serialize property #%d (%s): %w", i, property.Name, err,
 					)
 				}
-				p.Annotations = append(p.Annotations, spdx.Annotation{
-					Annotator: common.Annotator{
-						// We fix the annotator version to v1 as we want to identify
-						// protobom, yet make the string deterministic:
-						Annotator:     "protobom - v1.0.0",
-						AnnotatorType: "Tool",
-					},
-					AnnotationDate: "1970-01-01T00:00:00Z",
-					AnnotationType: "OTHER",
-					AnnotationSPDXIdentifier: common.DocElementID{
-						ElementRefID: common.ElementID(node.Id),
-					},
-					AnnotationComment: string(jsonProperty),
-				})
+				p.Annotations = append(p.Annotations, annotation)
 			}
 		}
 
@@ -517,6 +657,29 @@ func (s *SPDX23) buildPackages(
 	return packages, nil
 }
 
+// propertyAnnotation encodes a protobom property as an SPDX annotation on
+// the element identified by elementID.
+func propertyAnnotation(property *sbom.Property, elementID string) (spdx.Annotation, error) {
+	jsonProperty, err := json.Marshal(property)
+	if err != nil {
+		return spdx.Annotation{}, err
+	}
+	return spdx.Annotation{
+		Annotator: common.Annotator{
+			// We fix the annotator version to v1 as we want to identify
+			// protobom, yet make the string deterministic:
+			Annotator:     "protobom - v1.0.0",
+			AnnotatorType: "Tool",
+		},
+		AnnotationDate: "1970-01-01T00:00:00Z",
+		AnnotationType: "OTHER",
+		AnnotationSPDXIdentifier: common.DocElementID{
+			ElementRefID: common.ElementID(elementID),
+		},
+		AnnotationComment: string(jsonProperty),
+	}, nil
+}
+
 // ExtRefCategoryFromProtobomExtRef reads a protobom external reference struct and returns a
 // string with the corresponding category
 func (s *SPDX23) extRefCategoryFromProtobomExtRef(extref *sbom.ExternalReference) string {
@@ -9,6 +9,7 @@ import (
 	"github.com/spdx/tools-golang/spdx/v2/common"
 	"github.com/stretchr/testify/require"
 
+	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
@@ -237,3 +238,359 @@ func TestPropertiesMod(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildOtherLicenses(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		licenses []*sbom.License
+		expected []*spdx.OtherLicense
+	}{
+		{
+			name: "no custom licenses",
+		},
+		{
+			name: "all fields",
+			licenses: []*sbom.License{
+				{Id: "LicenseRef-acme", Name: "ACME License", Text: "Do whatever", Urls: []string{"https://example.com/"}},
+			},
+			expected: []*spdx.OtherLicense{{
+				LicenseIdentifier:      "LicenseRef-acme",
+				LicenseName:            "ACME License",
+				ExtractedText:          "Do whatever",
+				LicenseCrossReferences: []string{"https://example.com/"},
+			}},
+		},
+		{
+			name:     "without text",
+			licenses: []*sbom.License{{Id: "LicenseRef-notext"}},
+			expected: []*spdx.OtherLicense{{
+				LicenseIdentifier: "LicenseRef-notext",
+				ExtractedText:     protospdx.NOASSERTION,
+			}},
+		},
+		{
+			name:     "without id",
+			licenses: []*sbom.License{{Name: "No ID"}},
+			expected: []*spdx.OtherLicense{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.CustomLicenses = tc.licenses
+			require.Equal(t, tc.expected, buildOtherLicenses(doc))
+		})
+	}
+}
+
+func TestSerializeRevision(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		reasons   []string
+		namespace string
+		comment   string
+	}{
+		{
+			// Documents without revisions keep their namespace
+			name:      "no revisions",
+			namespace: "https://example.com/sbom-r2",
+		},
+		{
+			name:      "revision",
+			reasons:   []string{"updated licenses"},
+			namespace: "https://example.com/sbom-r3",
+			comment:   "Revision 3: updated licenses",
+		},
+		{
+			name:      "two revisions",
+			reasons:   []string{"updated licenses", "added hashes"},
+			namespace: "https://example.com/sbom-r4",
+			comment:   "Revision 4: added hashes",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "https://example.com/sbom-r2"
+			doc.Metadata.Version = "2"
+			for _, r := range tc.reasons {
+				require.NoError(t, doc.Bump(r))
+			}
+
+			res, err := NewSPDX23().Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+			spdxDoc, ok := res.(*spdx.Document)
+			require.True(t, ok)
+			require.Equal(t, tc.namespace, spdxDoc.DocumentNamespace)
+			require.Equal(t, tc.comment, spdxDoc.CreationInfo.CreatorComment)
+		})
+	}
+}
+
+func TestSerializeDocumentComments(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		mods        map[mod.Mod]struct{}
+		bump        string
+		creator     string
+		annotations []string
+	}{
+		{
+			// Properties are only rendered when the mod is enabled
+			name:    "no mods",
+			creator: "Reviewed by the release team",
+		},
+		{
+			name:        "properties in annotations",
+			mods:        map[mod.Mod]struct{}{mod.SPDX_RENDER_PROPERTIES_IN_ANNOTATIONS: {}},
+			creator:     "Reviewed by the release team",
+			annotations: []string{`{"name": "build", "data": "1234"}`},
+		},
+		{
+			// Revision reasons are appended to the creator comment
+			name:    "revision",
+			bump:    "rebuilt",
+			creator: "Reviewed by the release team\nRevision 2: rebuilt",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Comment = "Generated in CI"
+			doc.Metadata.CreatorComment = "Reviewed by the release team"
+			doc.Metadata.SetProperty("build", "1234")
+			if tc.bump != "" {
+				require.NoError(t, doc.Bump(tc.bump))
+			}
+
+			res, err := NewSPDX23().Serialize(doc, &native.SerializeOptions{Mods: tc.mods}, nil)
+			require.NoError(t, err)
+			spdxDoc, ok := res.(*spdx.Document)
+			require.True(t, ok)
+			require.Equal(t, "Generated in CI", spdxDoc.DocumentComment)
+			require.Equal(t, tc.creator, spdxDoc.CreationInfo.CreatorComment)
+			require.Len(t, spdxDoc.Annotations, len(tc.annotations))
+			for i, a := range tc.annotations {
+				require.Equal(t, common.ElementID(protospdx.DOCUMENT), spdxDoc.Annotations[i].AnnotationSPDXIdentifier.ElementRefID)
+				require.JSONEq(t, a, spdxDoc.Annotations[i].AnnotationComment)
+			}
+		})
+	}
+}
+
+func TestSPDXRenderStrings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		node     *sbom.Node
+		contains []string
+	}{
+		{
+			name:     "non ascii and markup",
+			node:     &sbom.Node{Id: "pkg", Name: "Überprüfung <core>", Version: "1.0"},
+			contains: []string{`"Überprüfung <core>"`},
+		},
+		{
+			name: "purl qualifiers",
+			node: &sbom.Node{
+				Id: "pkg", Name: "core", Version: "1.0",
+				Identifiers: map[int32]string{
+					int32(sbom.SoftwareIdentifierType_PURL): "pkg:deb/debian/core@1.0?arch=amd64&distro=debian-12",
+				},
+			},
+			contains: []string{"arch=amd64&distro=debian-12"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddNode(tc.node)
+			doc.NodeList.RootElements = []string{tc.node.Id}
+
+			s := NewSPDX23()
+			spdxDoc, err := s.Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+
+			var b strings.Builder
+			require.NoError(t, s.Render(spdxDoc, &b, &native.RenderOptions{Indent: 2}, nil))
+			require.True(t, json.Valid([]byte(b.String())))
+			for _, want := range tc.contains {
+				require.Contains(t, b.String(), want)
+			}
+		})
+	}
+}
+
+func TestSPDXRenderTagValue(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		prepare     func(*sbom.Document)
+		contains    []string
+		notContains []string
+		// jsonIDs are the package identifiers written by the JSON serializer
+		jsonIDs []common.ElementID
+	}{
+		{
+			name: "tag value",
+			contains: []string{
+				"SPDXVersion: SPDX-2.3\n",
+				"DocumentNamespace: https://example.com/test\n",
+				"PackageName: app\n",
+				"PackageLicenseDeclared: MIT\n",
+				"Relationship: SPDXRef-app DEPENDS_ON SPDXRef-lib\n",
+			},
+			jsonIDs: []common.ElementID{"app", "lib"},
+		},
+		{
+			// Identifiers are rewritten to valid SPDX idstrings, the JSON
+			// encoding keeps the original identifiers
+			name: "identifiers",
+			prepare: func(doc *sbom.Document) {
+				doc.NodeList.AddNode(&sbom.Node{Id: "pkg:npm/lib@2.0", Name: "lib", Version: "2.0"})
+				doc.NodeList.AddNode(&sbom.Node{Id: "pkg-npm-lib-2.0", Name: "other"})
+				doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"pkg:npm/lib@2.0"}})
+			},
+			contains: []string{
+				"SPDXID: SPDXRef-pkg-npm-lib-2.0-2\n",
+				"Relationship: SPDXRef-app DEPENDS_ON SPDXRef-pkg-npm-lib-2.0-2\n",
+			},
+			notContains: []string{"pkg:npm"},
+			jsonIDs:     []common.ElementID{"app", "lib", "pkg:npm/lib@2.0", "pkg-npm-lib-2.0"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "https://example.com/test"
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0", Licenses: []string{"MIT"}})
+			doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Version: "2.0"})
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+			if tc.prepare != nil {
+				tc.prepare(doc)
+			}
+
+			s := NewSPDX23TV()
+			spdxDoc, err := s.Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+			var b strings.Builder
+			require.NoError(t, s.Render(spdxDoc, &b, &native.RenderOptions{Indent: 2}, nil))
+			require.False(t, json.Valid([]byte(b.String())))
+			for _, str := range tc.contains {
+				require.Contains(t, b.String(), str)
+			}
+			for _, str := range tc.notContains {
+				require.NotContains(t, b.String(), str)
+			}
+
+			spdxDoc, err = NewSPDX23().Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+			jsonIDs := []common.ElementID{}
+			for _, p := range spdxDoc.(*spdx.Document).Packages { //nolint:forcetypeassert
+				jsonIDs = append(jsonIDs, p.PackageSPDXIdentifier)
+			}
+			require.Equal(t, tc.jsonIDs, jsonIDs)
+		})
+	}
+}
+
+func TestBuildPackagesPurpose(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		purposes []sbom.Purpose
+		custom   map[sbom.Purpose]string
+		expected string
+	}{
+		// Only the first purpose is written
+		{name: "multiple purposes", purposes: []sbom.Purpose{sbom.Purpose_OPERATING_SYSTEM, sbom.Purpose_CONTAINER}, expected: "OPERATING-SYSTEM"},
+		{name: "container", purposes: []sbom.Purpose{sbom.Purpose_CONTAINER}, expected: "CONTAINER"},
+		{name: "no spdx purpose", purposes: []sbom.Purpose{sbom.Purpose_MODEL}, expected: "OTHER"},
+		{
+			name:     "custom purpose",
+			purposes: []sbom.Purpose{sbom.Purpose_MODEL},
+			custom:   map[sbom.Purpose]string{sbom.Purpose_MODEL: "APPLICATION"},
+			expected: "APPLICATION",
+		},
+		{name: "no purpose", expected: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddNode(&sbom.Node{Id: "pkg", Name: "pkg", PrimaryPurpose: tc.purposes})
+
+			packages, err := NewSPDX23().buildPackages(&native.SerializeOptions{CustomPurposes: tc.custom}, SPDX23Options{}, doc)
+			require.NoError(t, err)
+			require.Len(t, packages, 1)
+			require.Equal(t, tc.expected, packages[0].PrimaryPackagePurpose)
+		})
+	}
+}
+
+func TestSerializeVulnerabilities(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		refs     []*sbom.ExternalReference
+		vulns    []*sbom.Vulnerability
+		locators []string
+	}{
+		{
+			name: "advisory already referenced",
+			refs: []*sbom.ExternalReference{
+				{Type: sbom.ExternalReference_SECURITY_ADVISORY, Url: "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+			},
+			vulns: []*sbom.Vulnerability{{
+				Id:         "CVE-2021-44228",
+				Advisories: []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+				Affects:    []string{"log4j"},
+			}},
+			locators: []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+		},
+		{
+			name: "source url",
+			vulns: []*sbom.Vulnerability{{
+				Id:        "CVE-2021-45046",
+				SourceUrl: "https://nvd.nist.gov/vuln/detail/CVE-2021-45046",
+				Affects:   []string{"log4j"},
+			}},
+			locators: []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-45046"},
+		},
+		{
+			name: "advisory and source url",
+			vulns: []*sbom.Vulnerability{
+				{
+					Id:         "CVE-2021-44228",
+					Advisories: []string{"https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+					Affects:    []string{"log4j"},
+				},
+				{
+					Id:        "CVE-2021-45046",
+					SourceUrl: "https://nvd.nist.gov/vuln/detail/CVE-2021-45046",
+					Affects:   []string{"log4j"},
+				},
+			},
+			locators: []string{
+				"https://nvd.nist.gov/vuln/detail/CVE-2021-44228",
+				"https://nvd.nist.gov/vuln/detail/CVE-2021-45046",
+			},
+		},
+		{
+			name:     "other package",
+			vulns:    []*sbom.Vulnerability{{Id: "CVE-2021-44228", SourceUrl: "https://example.com", Affects: []string{"other"}}},
+			locators: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "log4j", Name: "log4j-core", ExternalReferences: tc.refs})
+			for _, v := range tc.vulns {
+				doc.AddVulnerability(v)
+			}
+
+			res, err := NewSPDX23().Serialize(doc, &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+			spdxDoc, ok := res.(*spdx.Document)
+			require.True(t, ok)
+			require.Len(t, spdxDoc.Packages, 1)
+
+			locators := []string{}
+			for _, er := range spdxDoc.Packages[0].PackageExternalReferences {
+				require.Equal(t, "SECURITY", er.Category)
+				require.Equal(t, "advisory", er.RefType)
+				locators = append(locators, er.Locator)
+			}
+			require.ElementsMatch(t, tc.locators, locators)
+		})
+	}
+}
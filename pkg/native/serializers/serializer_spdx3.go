@@ -0,0 +1,679 @@
+package serializers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/release-utils/version"
+
+	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ native.Serializer = &SPDX3{}
+
+const (
+	// spdx3Context is the JSON-LD context of the SPDX 3.0 documents
+	spdx3Context = "https://spdx.org/rdf/3.0.1/spdx-context.jsonld"
+
+	spdx3SpecVersion = "3.0.1"
+
+	// spdx3CreationInfoID is the blank node of the creation info shared by
+	// all the elements of the document
+	spdx3CreationInfoID = "_:creationinfo"
+
+	spdx3DataLicense = "https://spdx.org/licenses/CC0-1.0"
+)
+
+// SPDX3 serializes protobom documents to SPDX 3.0 JSON-LD
+type SPDX3 struct{}
+
+type SPDX3Options struct {
+	// Deprecated: Indent is not used, the indentation is set in the
+	// render options.
+	Indent int
+
+	// Profiles are the SPDX 3.0 profiles the document claims conformance
+	// to. The document is checked against them before serializing it and,
+	// if it does not conform, the serializer returns a
+	// *ProfileConformanceError listing the offending elements.
+	Profiles []string
+}
+
+var DefaultSPDX3Options = SPDX3Options{}
+
+func NewSPDX3() *SPDX3 {
+	return &SPDX3{}
+}
+
+// spdx3Graph is the JSON-LD document, a flat list of the SPDX elements
+type spdx3Graph struct {
+	Context string `json:"@context"`
+	Graph   []any  `json:"@graph"`
+}
+
+type spdx3CreationInfo struct {
+	ID           string   `json:"@id"`
+	Type         string   `json:"type"` // CreationInfo
+	SpecVersion  string   `json:"specVersion"`
+	Created      string   `json:"created"`
+	CreatedBy    []string `json:"createdBy,omitempty"`
+	CreatedUsing []string `json:"createdUsing,omitempty"`
+	Comment      string   `json:"comment,omitempty"`
+}
+
+// spdx3Element holds the properties common to all the SPDX 3.0 elements
+type spdx3Element struct {
+	Type               string                    `json:"type"`
+	SpdxID             string                    `json:"spdxId"`
+	CreationInfo       string                    `json:"creationInfo"`
+	Name               string                    `json:"name,omitempty"`
+	Summary            string                    `json:"summary,omitempty"`
+	Description        string                    `json:"description,omitempty"`
+	Comment            string                    `json:"comment,omitempty"`
+	VerifiedUsing      []spdx3Hash               `json:"verifiedUsing,omitempty"`
+	ExternalRef        []spdx3ExternalRef        `json:"externalRef,omitempty"`
+	ExternalIdentifier []spdx3ExternalIdentifier `json:"externalIdentifier,omitempty"`
+}
+
+// spdx3Collection is an SpdxDocument or an Sbom
+type spdx3Collection struct {
+	spdx3Element
+	ProfileConformance []string `json:"profileConformance,omitempty"`
+	DataLicense        string   `json:"dataLicense,omitempty"`
+	Element            []string `json:"element"`
+	RootElement        []string `json:"rootElement"`
+}
+
+// spdx3Artifact holds the properties of the packages and files
+type spdx3Artifact struct {
+	spdx3Element
+	SuppliedBy        string   `json:"suppliedBy,omitempty"`
+	OriginatedBy      []string `json:"originatedBy,omitempty"`
+	ReleaseTime       string   `json:"releaseTime,omitempty"`
+	BuiltTime         string   `json:"builtTime,omitempty"`
+	ValidUntilTime    string   `json:"validUntilTime,omitempty"`
+	CopyrightText     string   `json:"software_copyrightText,omitempty"`
+	AttributionText   []string `json:"software_attributionText,omitempty"`
+	PrimaryPurpose    string   `json:"software_primaryPurpose,omitempty"`
+	AdditionalPurpose []string `json:"software_additionalPurpose,omitempty"`
+}
+
+type spdx3Package struct {
+	spdx3Artifact
+	PackageVersion   string `json:"software_packageVersion,omitempty"`
+	DownloadLocation string `json:"software_downloadLocation,omitempty"`
+	PackageURL       string `json:"software_packageUrl,omitempty"`
+	HomePage         string `json:"software_homePage,omitempty"`
+	SourceInfo       string `json:"software_sourceInfo,omitempty"`
+}
+
+type spdx3File struct {
+	spdx3Artifact
+}
+
+// spdx3AIPackage is a package describing an AI model (SPDX 3 AI profile)
+type spdx3AIPackage struct {
+	spdx3Package
+	TypeOfModel                     []string               `json:"ai_typeOfModel,omitempty"`
+	Domain                          []string               `json:"ai_domain,omitempty"`
+	InformationAboutApplication     string                 `json:"ai_informationAboutApplication,omitempty"`
+	InformationAboutTraining        string                 `json:"ai_informationAboutTraining,omitempty"`
+	Limitation                      string                 `json:"ai_limitation,omitempty"`
+	Metric                          []spdx3DictionaryEntry `json:"ai_metric,omitempty"`
+	Hyperparameter                  []spdx3DictionaryEntry `json:"ai_hyperparameter,omitempty"`
+	AutonomyType                    string                 `json:"ai_autonomyType,omitempty"`
+	SafetyRiskAssessment            string                 `json:"ai_safetyRiskAssessment,omitempty"`
+	UseSensitivePersonalInformation string                 `json:"ai_useSensitivePersonalInformation,omitempty"`
+}
+
+// spdx3DatasetPackage is a package describing a dataset (SPDX 3 Dataset
+// profile)
+type spdx3DatasetPackage struct {
+	spdx3Package
+	DatasetType                     []string `json:"dataset_datasetType,omitempty"`
+	IntendedUse                     string   `json:"dataset_intendedUse,omitempty"`
+	DataCollectionProcess           string   `json:"dataset_dataCollectionProcess,omitempty"`
+	DatasetSize                     int64    `json:"dataset_datasetSize,omitempty"`
+	ConfidentialityLevel            string   `json:"dataset_confidentialityLevel,omitempty"`
+	HasSensitivePersonalInformation string   `json:"dataset_hasSensitivePersonalInformation,omitempty"`
+	KnownBias                       []string `json:"dataset_knownBias,omitempty"`
+	DatasetAvailability             string   `json:"dataset_datasetAvailability,omitempty"`
+	AnonymizationMethodUsed         []string `json:"dataset_anonymizationMethodUsed,omitempty"`
+}
+
+type spdx3Relationship struct {
+	spdx3Element
+	From             string   `json:"from"`
+	To               []string `json:"to"`
+	RelationshipType string   `json:"relationshipType"`
+	Scope            string   `json:"scope,omitempty"`
+}
+
+type spdx3LicenseExpression struct {
+	spdx3Element
+	LicenseExpression string `json:"simplelicensing_licenseExpression"`
+}
+
+type spdx3DictionaryEntry struct {
+	Type  string `json:"type"` // DictionaryEntry
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+type spdx3ExternalRef struct {
+	Type            string   `json:"type"` // ExternalRef
+	ExternalRefType string   `json:"externalRefType"`
+	Locator         []string `json:"locator"`
+	Comment         string   `json:"comment,omitempty"`
+}
+
+type spdx3ExternalIdentifier struct {
+	Type                   string `json:"type"` // ExternalIdentifier
+	ExternalIdentifierType string `json:"externalIdentifierType"`
+	Identifier             string `json:"identifier"`
+}
+
+type spdx3Hash struct {
+	Type      string `json:"type"` // Hash
+	Algorithm string `json:"algorithm"`
+	HashValue string `json:"hashValue"`
+}
+
+// spdx3Builder accumulates the elements of the document while it is
+// converted
+type spdx3Builder struct {
+	namespace string
+	graph     []any
+	elements  []string
+	agents    map[string]string
+	profiles  []string
+}
+
+// id returns the SPDX ID of a protobom identifier. Identifiers that are
+// already absolute IRIs are used verbatim, the rest are made relative to
+// the document namespace.
+func (b *spdx3Builder) id(protoID string) string {
+	if u, err := url.Parse(protoID); err == nil && u.Scheme != "" && (u.Host != "" || u.Scheme == "urn") {
+		return protoID
+	}
+	return b.namespace + "#" + (&url.URL{Fragment: protoID}).EscapedFragment()
+}
+
+// add appends an element to the graph
+func (b *spdx3Builder) add(spdxID string, element any) {
+	b.graph = append(b.graph, element)
+	b.elements = append(b.elements, spdxID)
+}
+
+// addProfile records a profile the document conforms to
+func (b *spdx3Builder) addProfile(profile string) {
+	if !slices.Contains(b.profiles, profile) {
+		b.profiles = append(b.profiles, profile)
+	}
+}
+
+// element returns the common properties of a new element
+func (b *spdx3Builder) element(elementType, spdxID string) spdx3Element {
+	return spdx3Element{Type: elementType, SpdxID: spdxID, CreationInfo: spdx3CreationInfoID}
+}
+
+// agent returns the SPDX ID of the Person or Organization element of a
+// protobom person, adding it to the graph the first time it is seen
+func (b *spdx3Builder) agent(p *sbom.Person) string {
+	key := fmt.Sprintf("%v|%s|%s|%s", p.GetIsOrg(), p.GetName(), p.GetEmail(), p.GetUrl())
+	if id, ok := b.agents[key]; ok {
+		return id
+	}
+	sum := sha256.Sum256([]byte(key))
+	id := fmt.Sprintf("%s#SPDXRef-Agent-%x", b.namespace, sum[:8])
+	b.agents[key] = id
+
+	agentType := "Person"
+	if p.GetIsOrg() {
+		agentType = "Organization"
+	}
+	a := b.element(agentType, id)
+	a.Name = p.GetName()
+	if p.GetEmail() != "" {
+		a.ExternalIdentifier = append(a.ExternalIdentifier, spdx3ExternalIdentifier{
+			Type: "ExternalIdentifier", ExternalIdentifierType: "email", Identifier: p.GetEmail(),
+		})
+	}
+	if p.GetUrl() != "" {
+		a.ExternalRef = append(a.ExternalRef, spdx3ExternalRef{
+			Type: "ExternalRef", ExternalRefType: "altWebPage", Locator: []string{p.GetUrl()},
+		})
+	}
+	b.add(id, &a)
+	return id
+}
+
+// Serialize converts the protobom document to the SPDX 3.0 element graph
+func (s *SPDX3) Serialize(bom *sbom.Document, _ *native.SerializeOptions, rawopts any) (any, error) {
+	if bom == nil {
+		return nil, errors.New("document is nil, unable to serialize to SPDX 3.0")
+	}
+	opts := DefaultSPDX3Options
+	if rawopts != nil {
+		var ok bool
+		if opts, ok = rawopts.(SPDX3Options); !ok {
+			return nil, errors.New("error casting SPDX 3.0 options")
+		}
+	}
+	if len(opts.Profiles) > 0 {
+		if violations := ValidateProfiles(bom, opts.Profiles...); len(violations) > 0 {
+			return nil, &ProfileConformanceError{Violations: violations}
+		}
+	}
+
+	ns, err := spdxNamespaceFromProtobomID(SPDX23Options{GenerateDocumentID: true}, bom.GetMetadata().GetId())
+	if err != nil {
+		return nil, fmt.Errorf("building SPDX namespace: %w", err)
+	}
+	b := &spdx3Builder{namespace: ns, agents: map[string]string{}, profiles: []string{}}
+	if len(opts.Profiles) > 0 {
+		for _, p := range append([]string{ProfileCore}, opts.Profiles...) {
+			b.addProfile(p)
+		}
+	}
+
+	created := time.Now().UTC()
+	if bom.GetMetadata().GetDate() != nil {
+		created = bom.GetMetadata().GetDate().AsTime().UTC()
+	}
+	ci := &spdx3CreationInfo{
+		ID:          spdx3CreationInfoID,
+		Type:        "CreationInfo",
+		SpecVersion: spdx3SpecVersion,
+		Created:     created.Format(time.RFC3339),
+		Comment:     bom.GetMetadata().GetComment(),
+	}
+	for _, a := range bom.GetMetadata().GetAuthors() {
+		ci.CreatedBy = append(ci.CreatedBy, b.agent(a))
+	}
+	tools := slices.Clone(bom.GetMetadata().GetTools())
+	tools = append(tools, &sbom.Tool{Name: "protobom", Version: version.GetVersionInfo().GitVersion})
+	for i, t := range tools {
+		id := fmt.Sprintf("%s#SPDXRef-Tool-%d", ns, i+1)
+		tool := b.element("Tool", id)
+		tool.Name = t.GetName()
+		if t.GetVersion() != "" {
+			tool.Name += "-" + t.GetVersion()
+		}
+		b.add(id, &tool)
+		ci.CreatedUsing = append(ci.CreatedUsing, id)
+	}
+
+	for _, n := range bom.GetNodeList().GetNodes() {
+		if err := s.addNode(b, n); err != nil {
+			return nil, fmt.Errorf("converting node %q: %w", n.GetId(), err)
+		}
+	}
+
+	relationships := 0
+	addRelationship := func(from string, to []string, rel sbom.SPDX3Relationship) {
+		relationships++
+		id := fmt.Sprintf("%s#SPDXRef-Relationship-%d", ns, relationships)
+		r := &spdx3Relationship{
+			spdx3Element:     b.element("Relationship", id),
+			From:             from,
+			To:               to,
+			RelationshipType: rel.Type,
+			Scope:            rel.Scope,
+		}
+		if rel.Scope != "" {
+			r.Type = "LifecycleScopedRelationship"
+		}
+		b.add(id, r)
+	}
+	for _, e := range bom.GetNodeList().GetEdges() {
+		rel := e.GetType().ToSPDX3()
+		if rel.Type == "" {
+			return nil, fmt.Errorf("unable to serialize %s relationship without type", e.GetFrom())
+		}
+		if !rel.Reverse {
+			to := make([]string, 0, len(e.GetTo()))
+			for _, id := range e.GetTo() {
+				to = append(to, b.id(id))
+			}
+			addRelationship(b.id(e.GetFrom()), to, rel)
+			continue
+		}
+		for _, id := range e.GetTo() {
+			addRelationship(b.id(id), []string{b.id(e.GetFrom())}, rel)
+		}
+	}
+
+	// TODO(degradation): Vulnerabilities are not yet written as security
+	// profile elements
+
+	s.addLicenses(b, bom, addRelationship)
+
+	roots := make([]string, 0, len(bom.GetNodeList().GetRootElements()))
+	for _, id := range bom.GetNodeList().GetRootElements() {
+		roots = append(roots, b.id(id))
+	}
+	sbomElement := &spdx3Collection{
+		spdx3Element: b.element("software_Sbom", ns+"#SPDXRef-Sbom"),
+		Element:      slices.Clone(b.elements),
+		RootElement:  roots,
+	}
+	doc := &spdx3Collection{
+		spdx3Element:       b.element("SpdxDocument", ns+"#SPDXRef-DOCUMENT"),
+		ProfileConformance: b.profiles,
+		DataLicense:        spdx3DataLicense,
+		Element:            append(slices.Clone(b.elements), sbomElement.SpdxID),
+		RootElement:        []string{sbomElement.SpdxID},
+	}
+	doc.Name = bom.GetMetadata().GetName()
+
+	return &spdx3Graph{
+		Context: spdx3Context,
+		Graph:   slices.Concat([]any{ci, doc, sbomElement}, b.graph),
+	}, nil
+}
+
+// addNode adds the element of a node to the graph
+func (s *SPDX3) addNode(b *spdx3Builder, n *sbom.Node) error {
+	a := spdx3Artifact{
+		spdx3Element:    b.element("", b.id(n.GetId())),
+		CopyrightText:   n.GetCopyright(),
+		AttributionText: n.GetAttribution(),
+	}
+	a.Name = n.GetName()
+	a.Summary = n.GetSummary()
+	a.Description = n.GetDescription()
+	a.Comment = n.GetComment()
+	if n.GetReleaseDate() != nil {
+		a.ReleaseTime = n.GetReleaseDate().AsTime().UTC().Format(time.RFC3339)
+	}
+	if n.GetBuildDate() != nil {
+		a.BuiltTime = n.GetBuildDate().AsTime().UTC().Format(time.RFC3339)
+	}
+	if n.GetValidUntilDate() != nil {
+		a.ValidUntilTime = n.GetValidUntilDate().AsTime().UTC().Format(time.RFC3339)
+	}
+
+	// The first purpose is the primary one, SPDX 3 records the rest as
+	// additional purposes
+	for _, p := range n.GetPrimaryPurpose() {
+		purpose := p.ToSPDX3()
+		switch {
+		case purpose == "":
+			// TODO(degradation): Purpose with no SPDX 3 equivalent
+		case a.PrimaryPurpose == "":
+			a.PrimaryPurpose = purpose
+		case purpose != a.PrimaryPurpose && !slices.Contains(a.AdditionalPurpose, purpose):
+			a.AdditionalPurpose = append(a.AdditionalPurpose, purpose)
+		}
+	}
+
+	if len(n.GetSuppliers()) > 0 {
+		// TODO(degradation): SPDX 3 artifacts have a single supplier
+		a.SuppliedBy = b.agent(n.GetSuppliers()[0])
+	}
+	for _, p := range n.GetOriginators() {
+		a.OriginatedBy = append(a.OriginatedBy, b.agent(p))
+	}
+
+	for _, algo := range slices.Sorted(maps.Keys(n.GetHashes())) {
+		ha := sbom.HashAlgorithm(algo)
+		if ha.ToSPDX3() == "" {
+			// TODO(degradation): Algorithm not supported in SPDX 3
+			continue
+		}
+		a.VerifiedUsing = append(a.VerifiedUsing, spdx3Hash{
+			Type: "Hash", Algorithm: ha.ToSPDX3(), HashValue: n.GetHashes()[algo],
+		})
+	}
+	for _, er := range n.GetExternalReferences() {
+		a.ExternalRef = append(a.ExternalRef, spdx3ExternalRef{
+			Type:            "ExternalRef",
+			ExternalRefType: spdx3ExternalRefType(er.GetType()),
+			Locator:         []string{er.GetUrl()},
+			Comment:         er.GetComment(),
+		})
+	}
+
+	switch n.GetType() {
+	case sbom.Node_FILE:
+		a.Type = "software_File"
+		b.add(a.SpdxID, &spdx3File{spdx3Artifact: a})
+	case sbom.Node_PACKAGE:
+		a.Type = "software_Package"
+		p := spdx3Package{
+			spdx3Artifact:    a,
+			PackageVersion:   n.GetVersion(),
+			DownloadLocation: n.GetUrlDownload(),
+			PackageURL:       string(n.Purl()),
+			HomePage:         n.GetUrlHome(),
+			SourceInfo:       n.GetSourceInfo(),
+		}
+		switch {
+		case n.GetAiModel() != nil:
+			b.add(p.SpdxID, spdx3AI(p, n.GetAiModel()))
+			b.addProfile("ai")
+		case n.GetDataset() != nil:
+			b.add(p.SpdxID, spdx3Dataset(p, n.GetDataset()))
+			b.addProfile("dataset")
+		default:
+			b.add(p.SpdxID, &p)
+		}
+	default:
+		return fmt.Errorf("unknown node type %s", n.GetType())
+	}
+	return nil
+}
+
+// addLicenses adds the license expressions of the nodes and relates them
+// with hasDeclaredLicense and hasConcludedLicense relationships
+func (s *SPDX3) addLicenses(b *spdx3Builder, bom *sbom.Document, relate func(string, []string, sbom.SPDX3Relationship)) {
+	expressions := map[string]string{}
+	expression := func(expr string) string {
+		if id, ok := expressions[expr]; ok {
+			return id
+		}
+		id := fmt.Sprintf("%s#SPDXRef-License-%d", b.namespace, len(expressions)+1)
+		expressions[expr] = id
+		b.add(id, &spdx3LicenseExpression{
+			spdx3Element:      b.element("simplelicensing_LicenseExpression", id),
+			LicenseExpression: expr,
+		})
+		return id
+	}
+
+	for _, n := range bom.GetNodeList().GetNodes() {
+		declared := []string{}
+		for _, l := range n.GetLicenses() {
+			if l != "" && l != protospdx.NOASSERTION && l != protospdx.NONE {
+				declared = append(declared, l)
+			}
+		}
+		if len(declared) > 0 {
+			expr := declared[0]
+			if len(declared) > 1 {
+				expr = "(" + strings.Join(declared, ") AND (") + ")"
+			}
+			relate(b.id(n.GetId()), []string{expression(expr)}, sbom.SPDX3Relationship{Type: "hasDeclaredLicense"})
+		}
+		if l := n.GetLicenseConcluded(); l != "" && l != protospdx.NOASSERTION && l != protospdx.NONE {
+			relate(b.id(n.GetId()), []string{expression(l)}, sbom.SPDX3Relationship{Type: "hasConcludedLicense"})
+		}
+	}
+}
+
+// spdx3AI extends a package with the data of the AI profile
+func spdx3AI(p spdx3Package, m *sbom.AIModel) *spdx3AIPackage {
+	p.Type = "ai_AIPackage"
+	ap := &spdx3AIPackage{
+		spdx3Package:                    p,
+		TypeOfModel:                     m.TypeOfModel,
+		Domain:                          m.Domain,
+		InformationAboutApplication:     m.InformationAboutApplication,
+		InformationAboutTraining:        m.InformationAboutTraining,
+		Limitation:                      m.Limitation,
+		Metric:                          spdx3DictionaryEntries(m.Metrics),
+		Hyperparameter:                  spdx3DictionaryEntries(m.Hyperparameters),
+		AutonomyType:                    m.AutonomyType,
+		SafetyRiskAssessment:            m.SafetyRiskAssessment,
+		UseSensitivePersonalInformation: m.UseSensitivePersonalInformation,
+	}
+	// SPDX 3 has no model architecture or task fields, the closest is the
+	// type of model.
+	for _, s := range []string{m.ModelArchitecture, m.Task} {
+		if s != "" && !slices.Contains(ap.TypeOfModel, s) {
+			ap.TypeOfModel = append(ap.TypeOfModel, s)
+		}
+	}
+	return ap
+}
+
+// spdx3Dataset extends a package with the data of the dataset profile
+func spdx3Dataset(p spdx3Package, d *sbom.Dataset) *spdx3DatasetPackage {
+	p.Type = "dataset_DatasetPackage"
+	return &spdx3DatasetPackage{
+		spdx3Package:                    p,
+		DatasetType:                     d.DatasetType,
+		IntendedUse:                     d.IntendedUse,
+		DataCollectionProcess:           d.DataCollectionProcess,
+		DatasetSize:                     d.DatasetSize,
+		ConfidentialityLevel:            d.ConfidentialityLevel,
+		HasSensitivePersonalInformation: d.HasSensitivePersonalInformation,
+		KnownBias:                       d.KnownBias,
+		DatasetAvailability:             d.DatasetAvailability,
+		AnonymizationMethodUsed:         d.AnonymizationMethodUsed,
+	}
+}
+
+// spdx3DictionaryEntries converts a map to a list of SPDX 3 dictionary
+// entries sorted by key.
+func spdx3DictionaryEntries(m map[string]string) []spdx3DictionaryEntry {
+	keys := slices.Sorted(maps.Keys(m))
+	ret := make([]spdx3DictionaryEntry, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, spdx3DictionaryEntry{Type: "DictionaryEntry", Key: k, Value: m[k]})
+	}
+	return ret
+}
+
+// Render writes the SPDX 3.0 document as JSON-LD
+func (s *SPDX3) Render(rawDoc any, w io.Writer, o *native.RenderOptions, _ any) error {
+	doc, ok := rawDoc.(*spdx3Graph)
+	if !ok {
+		return errors.New("unable to cast SBOM as an SPDX 3.0 SBOM")
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", o.IndentString())
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encoding SBOM: %w", err)
+	}
+	return nil
+}
+
+// spdx3ExternalRefType returns the SPDX 3 external reference type of the
+// protobom reference type
+func spdx3ExternalRefType(t sbom.ExternalReference_ExternalReferenceType) string {
+	switch t {
+	case sbom.ExternalReference_BINARY:
+		return "binaryArtifact"
+	case sbom.ExternalReference_BOWER:
+		return "bower"
+	case sbom.ExternalReference_BUILD_META:
+		return "buildMeta"
+	case sbom.ExternalReference_BUILD_SYSTEM:
+		return "buildSystem"
+	case sbom.ExternalReference_CERTIFICATION_REPORT:
+		return "certificationReport"
+	case sbom.ExternalReference_CHAT:
+		return "chat"
+	case sbom.ExternalReference_COMPONENT_ANALYSIS_REPORT:
+		return "componentAnalysisReport"
+	case sbom.ExternalReference_DOCUMENTATION:
+		return "documentation"
+	case sbom.ExternalReference_DOWNLOAD:
+		return "altDownloadLocation"
+	case sbom.ExternalReference_DYNAMIC_ANALYSIS_REPORT:
+		return "dynamicAnalysisReport"
+	case sbom.ExternalReference_EOL_NOTICE:
+		return "eolNotice"
+	case sbom.ExternalReference_EXPORT_CONTROL_ASSESSMENT:
+		return "exportControlAssessment"
+	case sbom.ExternalReference_FUNDING:
+		return "funding"
+	case sbom.ExternalReference_ISSUE_TRACKER:
+		return "issueTracker"
+	case sbom.ExternalReference_LICENSE:
+		return "license"
+	case sbom.ExternalReference_MAILING_LIST:
+		return "mailingList"
+	case sbom.ExternalReference_MAVEN_CENTRAL:
+		return "mavenCentral"
+	case sbom.ExternalReference_METRICS:
+		return "metrics"
+	case sbom.ExternalReference_NPM:
+		return "npm"
+	case sbom.ExternalReference_NUGET:
+		return "nuget"
+	case sbom.ExternalReference_PRIVACY_ASSESSMENT:
+		return "privacyAssessment"
+	case sbom.ExternalReference_PRODUCT_METADATA:
+		return "productMetadata"
+	case sbom.ExternalReference_PURCHASE_ORDER:
+		return "purchaseOrder"
+	case sbom.ExternalReference_QUALITY_ASSESSMENT_REPORT:
+		return "qualityAssessmentReport"
+	case sbom.ExternalReference_RELEASE_HISTORY:
+		return "releaseHistory"
+	case sbom.ExternalReference_RELEASE_NOTES:
+		return "releaseNotes"
+	case sbom.ExternalReference_RISK_ASSESSMENT:
+		return "riskAssessment"
+	case sbom.ExternalReference_RUNTIME_ANALYSIS_REPORT:
+		return "runtimeAnalysisReport"
+	case sbom.ExternalReference_SECURE_SOFTWARE_ATTESTATION:
+		return "secureSoftwareAttestation"
+	case sbom.ExternalReference_SECURITY_ADVERSARY_MODEL:
+		return "securityAdversaryModel"
+	case sbom.ExternalReference_SECURITY_ADVISORY:
+		return "securityAdvisory"
+	case sbom.ExternalReference_SECURITY_FIX:
+		return "securityFix"
+	case sbom.ExternalReference_SECURITY_OTHER:
+		return "securityOther"
+	case sbom.ExternalReference_SECURITY_PENTEST_REPORT:
+		return "securityPenTestReport"
+	case sbom.ExternalReference_SECURITY_POLICY:
+		return "securityPolicy"
+	case sbom.ExternalReference_SECURITY_THREAT_MODEL:
+		return "securityThreatModel"
+	case sbom.ExternalReference_SOCIAL:
+		return "socialMedia"
+	case sbom.ExternalReference_SOURCE_ARTIFACT:
+		return "sourceArtifact"
+	case sbom.ExternalReference_STATIC_ANALYSIS_REPORT:
+		return "staticAnalysisReport"
+	case sbom.ExternalReference_SUPPORT:
+		return "support"
+	case sbom.ExternalReference_VCS:
+		return "vcs"
+	case sbom.ExternalReference_VULNERABILITY_DISCLOSURE_REPORT:
+		return "vulnerabilityDisclosureReport"
+	case sbom.ExternalReference_VULNERABILITY_EXPLOITABILITY_ASSESSMENT:
+		return "vulnerabilityExploitabilityAssessment"
+	case sbom.ExternalReference_WEBSITE:
+		return "altWebPage"
+	default:
+		return "other"
+	}
+}
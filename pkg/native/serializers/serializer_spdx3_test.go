@@ -0,0 +1,192 @@
+package serializers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func spdx3TestDocument() *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "https://example.com/sboms/app"
+	doc.Metadata.Name = "app SBOM"
+	doc.Metadata.Date = timestamppb.New(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	doc.Metadata.Authors = []*sbom.Person{{Name: "ACME", IsOrg: true, Email: "sbom@acme.example"}}
+	doc.NodeList.AddRootNode(&sbom.Node{
+		Id: "app", Type: sbom.Node_PACKAGE, Name: "app", Version: "1.0",
+		PrimaryPurpose: []sbom.Purpose{sbom.Purpose_APPLICATION, sbom.Purpose_EXECUTABLE, sbom.Purpose_INSTALL},
+		Suppliers:      []*sbom.Person{{Name: "ACME", IsOrg: true, Email: "sbom@acme.example"}},
+		Licenses:       []string{"MIT", "Apache-2.0"},
+		Hashes:         map[int32]string{int32(sbom.HashAlgorithm_SHA256): "abc"},
+		ExternalReferences: []*sbom.ExternalReference{
+			{Url: "git+https://example.com/app", Type: sbom.ExternalReference_VCS},
+		},
+	})
+	doc.NodeList.AddNode(&sbom.Node{
+		Id: "pkg:golang/lib@2.0", Type: sbom.Node_PACKAGE, Name: "lib", Version: "2.0",
+		PrimaryPurpose:   []sbom.Purpose{sbom.Purpose_DEVICE_DRIVER},
+		LicenseConcluded: "NOASSERTION",
+	})
+	doc.NodeList.AddNode(&sbom.Node{Id: "linter", Type: sbom.Node_PACKAGE, Name: "linter", Version: "3"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "main.go", Type: sbom.Node_FILE, Name: "main.go"})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"pkg:golang/lib@2.0"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_devTool, From: "linter", To: []string{"app"}})
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_contained_by, From: "main.go", To: []string{"app"}})
+	return doc
+}
+
+// spdx3Elements indexes the rendered graph elements by type
+func spdx3Elements(t *testing.T, doc *sbom.Document) (map[string]any, map[string][]map[string]any) {
+	t.Helper()
+	s := NewSPDX3()
+	raw, err := s.Serialize(doc, &native.SerializeOptions{}, nil)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, s.Render(raw, &buf, &native.RenderOptions{}, nil))
+
+	out := map[string]any{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &out))
+	elements := map[string][]map[string]any{}
+	graph, ok := out["@graph"].([]any)
+	require.True(t, ok)
+	for _, e := range graph {
+		m, ok := e.(map[string]any)
+		require.True(t, ok)
+		typ, ok := m["type"].(string)
+		require.True(t, ok)
+		elements[typ] = append(elements[typ], m)
+	}
+	return out, elements
+}
+
+func TestSPDX3Serialize(t *testing.T) {
+	out, elements := spdx3Elements(t, spdx3TestDocument())
+	require.Equal(t, spdx3Context, out["@context"])
+	require.Len(t, elements["Organization"], 1)
+	org := elements["Organization"][0]["spdxId"]
+	ns := "https://example.com/sboms/app"
+
+	for _, tc := range []struct {
+		name string
+		typ  string
+		// match selects the elements of the type with these values
+		match  map[string]any
+		count  int
+		fields map[string]any
+	}{
+		{
+			name: "creation info", typ: "CreationInfo", count: 1,
+			fields: map[string]any{"created": "2024-05-01T12:00:00Z", "createdBy": []any{org}},
+		},
+		{
+			name: "document", typ: "SpdxDocument", count: 1,
+			fields: map[string]any{"rootElement": []any{ns + "#SPDXRef-Sbom"}},
+		},
+		{
+			name: "sbom", typ: "software_Sbom", count: 1,
+			fields: map[string]any{"rootElement": []any{ns + "#app"}},
+		},
+		{
+			// Authors and suppliers share the agent element
+			name: "shared agent", typ: "Organization", count: 1,
+		},
+		{
+			name: "package", typ: "software_Package", match: map[string]any{"spdxId": ns + "#app"}, count: 1,
+			fields: map[string]any{
+				"software_primaryPurpose":    "application",
+				"software_additionalPurpose": []any{"executable", "install"},
+				"suppliedBy":                 org,
+				"software_packageVersion":    "1.0",
+				"verifiedUsing":              []any{map[string]any{"type": "Hash", "algorithm": "sha256", "hashValue": "abc"}},
+			},
+		},
+		{
+			name: "purpose", typ: "software_Package", match: map[string]any{"spdxId": ns + "#pkg:golang/lib@2.0"}, count: 1,
+			fields: map[string]any{"software_primaryPurpose": "device"},
+		},
+		{
+			name: "file", typ: "software_File", count: 1,
+		},
+		{
+			name: "relationship", typ: "Relationship", match: map[string]any{"relationshipType": "dependsOn"}, count: 1,
+			fields: map[string]any{"from": ns + "#app", "to": []any{ns + "#pkg:golang/lib@2.0"}},
+		},
+		{
+			// Inverse edges are written in the SPDX 3 direction
+			name: "inverse lifecycle edge", typ: "LifecycleScopedRelationship", match: map[string]any{"relationshipType": "usesTool"}, count: 1,
+			fields: map[string]any{"scope": sbom.SPDX3ScopeDevelopment, "from": ns + "#app", "to": []any{ns + "#linter"}},
+		},
+		{
+			name: "inverse edge", typ: "Relationship", match: map[string]any{"relationshipType": "contains"}, count: 1,
+			fields: map[string]any{"from": ns + "#app", "to": []any{ns + "#main.go"}},
+		},
+		{
+			name: "declared license", typ: "Relationship", match: map[string]any{"relationshipType": "hasDeclaredLicense"}, count: 1,
+		},
+		{
+			// NOASSERTION is not written
+			name: "concluded license", typ: "Relationship", match: map[string]any{"relationshipType": "hasConcludedLicense"},
+		},
+		{
+			name: "license expression", typ: "simplelicensing_LicenseExpression", count: 1,
+			fields: map[string]any{"simplelicensing_licenseExpression": "(MIT) AND (Apache-2.0)"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			found := []map[string]any{}
+		elements:
+			for _, e := range elements[tc.typ] {
+				for k, v := range tc.match {
+					if e[k] != v {
+						continue elements
+					}
+				}
+				found = append(found, e)
+			}
+			require.Len(t, found, tc.count)
+			for k, v := range tc.fields {
+				require.Equal(t, v, found[0][k], k)
+			}
+		})
+	}
+}
+
+func TestSPDX3SerializeErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		prepare func(*sbom.Document) *sbom.Document
+		options any
+	}{
+		{
+			name:    "nil document",
+			prepare: func(*sbom.Document) *sbom.Document { return nil },
+		},
+		{
+			name:    "invalid options",
+			options: "options",
+		},
+		{
+			name: "edge without type",
+			prepare: func(doc *sbom.Document) *sbom.Document {
+				doc.NodeList.AddEdge(&sbom.Edge{From: "app", To: []string{"main.go"}})
+				return doc
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := spdx3TestDocument()
+			if tc.prepare != nil {
+				doc = tc.prepare(doc)
+			}
+			_, err := NewSPDX3().Serialize(doc, nil, tc.options)
+			require.Error(t, err)
+		})
+	}
+}
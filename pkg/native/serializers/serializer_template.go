@@ -0,0 +1,156 @@
+package serializers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ native.Serializer = &Template{}
+
+// Template is a serializer that renders the protobom document through a
+// user-supplied text/template. It is meant to be registered as a custom
+// format in the writer:
+//
+//	tmpl, err := serializers.NewTemplate(`{{ range nodes }}{{ .Name }}{{ "\n" }}{{ end }}`)
+//	writer.RegisterSerializer(formats.Format("text/x-component-list"), tmpl)
+//
+// The template is executed with the *sbom.Document as its data. Besides the
+// standard template functions, the following helpers are available to
+// traverse the graph:
+//
+//	nodes                   all the nodes in the document
+//	rootNodes               the top level nodes of the document
+//	node ID                 the node with the specified ID (nil if not found)
+//	children ID             the nodes related from node ID by any edge
+//	childrenByType ID TYPE  the nodes related from node ID by an edge of TYPE (eg "dependsOn")
+//	descendants ID DEPTH    the nodes reachable from node ID at a maximum of DEPTH levels
+//	edges ID                the edges that originate in node ID
+//	purl NODE               the package URL of a node
+//	join LIST SEP           strings.Join
+type Template struct {
+	tmpl *template.Template
+}
+
+// TemplateOptions groups the configuration options for the template serializer.
+type TemplateOptions struct {
+	// Funcs are additional functions made available to the template. They
+	// must be registered before parsing so they are set when creating the
+	// serializer with NewTemplateWithOptions.
+	Funcs template.FuncMap
+}
+
+// NewTemplate parses the template string and returns a new template serializer
+func NewTemplate(tmpl string) (*Template, error) {
+	return NewTemplateWithOptions(tmpl, TemplateOptions{})
+}
+
+// NewTemplateWithOptions parses the template string with the specified
+// options set and returns a new template serializer.
+func NewTemplateWithOptions(tmpl string, opts TemplateOptions) (*Template, error) {
+	// The helper functions need the document so we register them as
+	// placeholders here and bind them to the document at render time.
+	t, err := template.New("protobom").Funcs(templateFuncs(nil)).Funcs(opts.Funcs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	return &Template{tmpl: t}, nil
+}
+
+// Serialize returns the protobom document, templates are executed directly
+// on the protobom data.
+func (s *Template) Serialize(bom *sbom.Document, _ *native.SerializeOptions, _ any) (any, error) {
+	if bom == nil {
+		return nil, errors.New("document is nil, unable to render template")
+	}
+	return bom, nil
+}
+
+// Render executes the template with the document data and writes the output
+func (s *Template) Render(doc any, wr io.Writer, _ *native.RenderOptions, _ any) error {
+	if s.tmpl == nil {
+		return errors.New("template serializer has no template")
+	}
+	bom, ok := doc.(*sbom.Document)
+	if !ok {
+		return errors.New("unable to cast doc as protobom document")
+	}
+
+	t, err := s.tmpl.Clone()
+	if err != nil {
+		return fmt.Errorf("cloning template: %w", err)
+	}
+
+	if err := t.Funcs(templateFuncs(bom)).Execute(wr, bom); err != nil {
+		return fmt.Errorf("executing template: %w", err)
+	}
+	return nil
+}
+
+// templateFuncs returns the graph helper functions bound to a document
+func templateFuncs(bom *sbom.Document) template.FuncMap {
+	nl := bom.GetNodeList()
+	if nl == nil {
+		nl = sbom.NewNodeList()
+	}
+
+	childrenByType := func(id string, edgeType *sbom.Edge_Type) []*sbom.Node {
+		ret := []*sbom.Node{}
+		seen := map[string]struct{}{}
+		for _, e := range nl.Edges {
+			if e.From != id || (edgeType != nil && e.Type != *edgeType) {
+				continue
+			}
+			for _, to := range e.To {
+				if _, ok := seen[to]; ok {
+					continue
+				}
+				seen[to] = struct{}{}
+				if n := nl.GetNodeByID(to); n != nil {
+					ret = append(ret, n)
+				}
+			}
+		}
+		return ret
+	}
+
+	return template.FuncMap{
+		"nodes":     func() []*sbom.Node { return nl.GetNodes() },
+		"rootNodes": func() []*sbom.Node { return nl.GetRootNodes() },
+		"node":      func(id string) *sbom.Node { return nl.GetNodeByID(id) },
+		"children":  func(id string) []*sbom.Node { return childrenByType(id, nil) },
+		"childrenByType": func(id, edgeType string) ([]*sbom.Node, error) {
+			v, ok := sbom.Edge_Type_value[edgeType]
+			if !ok {
+				return nil, fmt.Errorf("unknown edge type %q", edgeType)
+			}
+			t := sbom.Edge_Type(v)
+			return childrenByType(id, &t), nil
+		},
+		"descendants": func(id string, depth int) []*sbom.Node {
+			ret := []*sbom.Node{}
+			for _, n := range nl.NodeDescendants(id, depth).GetNodes() {
+				if n.Id != id {
+					ret = append(ret, n)
+				}
+			}
+			return ret
+		},
+		"edges": func(id string) []*sbom.Edge {
+			ret := []*sbom.Edge{}
+			for _, e := range nl.Edges {
+				if e.From == id {
+					ret = append(ret, e)
+				}
+			}
+			return ret
+		},
+		"purl": func(n *sbom.Node) string { return string(n.Purl()) },
+		"join": strings.Join,
+	}
+}
@@ -0,0 +1,84 @@
+package serializers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/native"
+)
+
+func TestTemplateRender(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		tmpl     string
+		opts     TemplateOptions
+		expected string
+		parseErr bool
+		execErr  bool
+	}{
+		{
+			name:     "document data",
+			tmpl:     `{{ .Metadata.Name }}`,
+			expected: "test <document>",
+		},
+		{
+			name:     "roots and children",
+			tmpl:     `{{ range rootNodes }}{{ .Name }}:{{ range children .Id }} {{ .Name }}{{ end }}{{ end }}`,
+			expected: "app: lib-one lib-two",
+		},
+		{
+			name:     "children by type",
+			tmpl:     `{{ range childrenByType "root" "contains" }}{{ .Name }}{{ end }}`,
+			expected: "",
+		},
+		{
+			name:    "invalid edge type",
+			tmpl:    `{{ childrenByType "root" "bogus" }}`,
+			execErr: true,
+		},
+		{
+			name:     "descendants",
+			tmpl:     `{{ len (descendants "root" 5) }}`,
+			expected: "2",
+		},
+		{
+			name:     "node lookup",
+			tmpl:     `{{ with node "lib2" }}{{ .LicenseConcluded }}{{ end }}`,
+			expected: "MIT",
+		},
+		{
+			name:     "custom funcs",
+			tmpl:     `{{ upper (node "root").Name }}`,
+			opts:     TemplateOptions{Funcs: template.FuncMap{"upper": strings.ToUpper}},
+			expected: "APP",
+		},
+		{
+			name:     "parse error",
+			tmpl:     `{{ .Metadata.Name `,
+			parseErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := NewTemplateWithOptions(tc.tmpl, tc.opts)
+			if tc.parseErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			doc, err := s.Serialize(testReportDocument(), &native.SerializeOptions{}, nil)
+			require.NoError(t, err)
+			var b bytes.Buffer
+			err = s.Render(doc, &b, &native.RenderOptions{}, nil)
+			if tc.execErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, b.String())
+		})
+	}
+}
@@ -19,10 +19,38 @@ type UnserializeOptions struct {
 	// original SBOM document such as its hashes, size and original location.
 	TrackSource bool
 	Mods        map[mod.Mod]struct{}
+
+	// CustomEdgeTypes maps relationship labels not known to protobom to
+	// edge types. Unserializers use it to type relationships that would
+	// otherwise be read as UNKNOWN.
+	CustomEdgeTypes map[string]sbom.Edge_Type
+
+	// InternStrings makes repeated strings in the parsed document (node
+	// IDs, licenses, suppliers, versions) share memory. It trades some
+	// parsing time for a smaller footprint of large documents.
+	InternStrings bool
+
+	// CustomPurposes maps SPDX primary package purposes or CycloneDX
+	// component types to protobom purposes. Its entries take precedence
+	// over the built-in mapping.
+	CustomPurposes map[string]sbom.Purpose
+}
+
+// CustomPurpose returns the purpose configured for a format label in
+// CustomPurposes.
+func (uo *UnserializeOptions) CustomPurpose(label string) (sbom.Purpose, bool) {
+	if uo == nil {
+		return sbom.Purpose_UNKNOWN_PURPOSE, false
+	}
+	p, ok := uo.CustomPurposes[label]
+	return p, ok
 }
 
 // IsModEnabled returns true when the passed mod is enabled in the options set.
 func (uo *UnserializeOptions) IsModEnabled(m mod.Mod) bool {
+	if uo == nil {
+		return false
+	}
 	_, ok := uo.Mods[m]
 	return ok
 }
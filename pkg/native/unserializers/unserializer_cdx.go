@@ -1,8 +1,10 @@
 package unserializers
 
 import (
+	"encoding/base64"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	cdxformats "github.com/protobom/protobom/pkg/formats/cyclonedx"
+	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
 )
@@ -31,7 +34,7 @@ func NewCDX(version, encoding string) *CDX {
 
 // Unserialize reads datq data from io.Reader r and parses it as a CycloneDX
 // document. If successful returns a protobom Document loaded with the SBOM data.
-func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
+func (u *CDX) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
 	bom := new(cdx.BOM)
 
 	encoding, err := cdxformats.ParseEncoding(u.encoding)
@@ -76,7 +79,7 @@ func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface
 			}
 		}
 		if bom.Metadata.Component != nil {
-			nl, err := u.componentToNodeList(bom.Metadata.Component, &cc)
+			nl, err := u.componentToNodeList(opts, bom.Metadata.Component, &cc)
 			if err != nil {
 				return nil, fmt.Errorf("converting main bom component to node: %w", err)
 			}
@@ -93,12 +96,27 @@ func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface
 				md.Date = timestamppb.New(t)
 			}
 		}
+		if bom.Metadata.Properties != nil {
+			for _, p := range *bom.Metadata.Properties {
+				switch p.Name {
+				case cdxformats.PropertyDocumentComment:
+					md.Comment = p.Value
+				case cdxformats.PropertyDocumentCreatorComment:
+					md.CreatorComment = p.Value
+				default:
+					md.Properties = append(md.Properties, &sbom.Property{Name: p.Name, Data: p.Value})
+				}
+			}
+		}
+		if bom.Metadata.Tools != nil {
+			u.unserializeTools(md, bom.Metadata.Tools)
+		}
 	}
 
 	// Cycle all components and get their graph fragments
 	if bom.Components != nil {
 		for i := range *bom.Components {
-			nl, err := u.componentToNodeList(&(*bom.Components)[i], &cc)
+			nl, err := u.componentToNodeList(opts, &(*bom.Components)[i], &cc)
 			if err != nil {
 				return nil, fmt.Errorf("converting component to node: %w", err)
 			}
@@ -121,19 +139,238 @@ func (u *CDX) Unserialize(r io.Reader, _ *native.UnserializeOptions, _ interface
 		}
 	}
 
+	// Capture the named licenses as custom licenses
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		u.collectComponentLicenses(md, bom.Metadata.Component)
+		u.collectCustomLicenses(md, bom.Metadata.Component.Components)
+	}
+	u.collectCustomLicenses(md, bom.Components)
+
 	// Parse the dependency graph
-	deps := u.parseDependencyGraph(bom)
+	depType := sbom.Edge_contains
+	if opts.IsModEnabled(mod.CDX_READ_DEPENDENCIES_AS_DEPENDS_ON) {
+		depType = sbom.Edge_dependsOn
+	}
+	deps := u.parseDependencyGraph(bom, depType)
 
 	// Now append the dependency data to the document nodelist
 	doc.NodeList.MergeEdges(deps)
 
+	// Relate the AI models to the datasets referenced in their model cards
+	doc.NodeList.MergeEdges(u.modelCardEdges(bom))
+
+	doc.Vulnerabilities = u.unserializeVulnerabilities(bom.Vulnerabilities)
+
 	return doc, nil
 }
 
+// unserializeTools reads the tools that generated the document, both the
+// legacy tools and the components and services used since CycloneDX 1.5.
+// The hashes and signatures of the tool components are recorded as their
+// verification material unless the document already has it.
+func (u *CDX) unserializeTools(md *sbom.Metadata, tools *cdx.ToolsChoice) {
+	if tools.Tools != nil {
+		for _, t := range *tools.Tools {
+			md.Tools = append(md.Tools, &sbom.Tool{Name: t.Name, Version: t.Version, Vendor: t.Vendor})
+			u.unserializeToolVerification(md, t.Name, t.Version, t.Hashes, nil)
+		}
+	}
+	if tools.Components != nil {
+		for _, c := range *tools.Components {
+			vendor := c.Author
+			if c.Supplier != nil && c.Supplier.Name != "" {
+				vendor = c.Supplier.Name
+			}
+			md.Tools = append(md.Tools, &sbom.Tool{Name: c.Name, Version: c.Version, Vendor: vendor})
+			u.unserializeToolVerification(md, c.Name, c.Version, c.Hashes, c.Properties)
+		}
+	}
+	if tools.Services != nil {
+		for _, s := range *tools.Services {
+			t := &sbom.Tool{Name: s.Name, Version: s.Version}
+			if s.Provider != nil {
+				t.Vendor = s.Provider.Name
+			}
+			md.Tools = append(md.Tools, t)
+		}
+	}
+}
+
+// unserializeToolVerification records the verification material of a tool
+// from its hashes and the signature properties of its component
+func (u *CDX) unserializeToolVerification(md *sbom.Metadata, name, version string, hashes *[]cdx.Hash, properties *[]cdx.Property) {
+	if md.GetToolVerification(name, version) != nil {
+		return
+	}
+	tv := &sbom.ToolVerification{Name: name, Version: version, Hashes: map[int32]string{}}
+	if hashes != nil {
+		for _, h := range *hashes {
+			if algo := sbom.HashAlgorithmFromCDX(h.Algorithm); algo != sbom.HashAlgorithm_UNKNOWN {
+				tv.Hashes[int32(algo)] = h.Value
+			}
+		}
+	}
+	sig := &sbom.ToolSignature{}
+	if properties != nil {
+		for _, p := range *properties {
+			switch {
+			case p.Name == cdxformats.PropertyToolSignatureAlgorithm:
+				sig.Algorithm = p.Value
+			case p.Name == cdxformats.PropertyToolSignatureKeyID:
+				sig.KeyID = p.Value
+			case p.Name == cdxformats.PropertyToolSignatureValue:
+				sig.Value = p.Value
+			case strings.HasPrefix(p.Name, cdxformats.PropertyHashPrefix):
+				if algo, ok := sbom.HashAlgorithm_value[strings.TrimPrefix(p.Name, cdxformats.PropertyHashPrefix)]; ok {
+					tv.Hashes[algo] = p.Value
+				}
+			}
+		}
+	}
+	if sig.Value != "" {
+		tv.Signature = sig
+	}
+	if len(tv.Hashes) == 0 && tv.Signature == nil {
+		return
+	}
+	if err := md.SetToolVerification(tv); err != nil {
+		logrus.Warnf("ignoring verification material of tool %q: %v", name, err)
+	}
+}
+
+// unserializeVulnerabilities converts the CycloneDX vulnerabilities. The
+// affected components are referenced by their bom-ref, which is the ID of
+// their nodes.
+//
+// A vulnerability listed more than once records a different analysis for
+// some of the components it affects, the analysis of the repeated entries is
+// kept as the analysis of their affected nodes.
+func (u *CDX) unserializeVulnerabilities(vulns *[]cdx.Vulnerability) []*sbom.Vulnerability {
+	if vulns == nil {
+		return nil
+	}
+	ret := []*sbom.Vulnerability{}
+	seen := map[string]*sbom.Vulnerability{}
+	for i := range *vulns {
+		v := &(*vulns)[i]
+		if prev, ok := seen[v.ID]; ok && v.ID != "" {
+			u.mergeNodeAnalysis(prev, v)
+			continue
+		}
+		vuln := &sbom.Vulnerability{
+			Id:             v.ID,
+			Description:    v.Description,
+			Detail:         v.Detail,
+			Recommendation: v.Recommendation,
+			Published:      u.parseTimestamp(v.Published),
+			Updated:        u.parseTimestamp(v.Updated),
+		}
+		if v.Source != nil {
+			vuln.SourceName = v.Source.Name
+			vuln.SourceUrl = v.Source.URL
+		}
+		if v.Ratings != nil {
+			for _, r := range *v.Ratings {
+				rating := &sbom.Vulnerability_Rating{
+					Score:    r.Score,
+					Severity: string(r.Severity),
+					Method:   string(r.Method),
+					Vector:   r.Vector,
+				}
+				if r.Source != nil {
+					rating.Source = r.Source.Name
+				}
+				vuln.Ratings = append(vuln.Ratings, rating)
+			}
+		}
+		if v.Advisories != nil {
+			for _, a := range *v.Advisories {
+				vuln.Advisories = append(vuln.Advisories, a.URL)
+			}
+		}
+		if v.References != nil {
+			for _, r := range *v.References {
+				if r.ID != "" && r.ID != v.ID {
+					vuln.Aliases = append(vuln.Aliases, r.ID)
+				}
+			}
+		}
+		if v.Affects != nil {
+			for _, a := range *v.Affects {
+				vuln.Affects = append(vuln.Affects, a.Ref)
+			}
+		}
+		if v.CWEs != nil {
+			for _, c := range *v.CWEs {
+				vuln.Cwes = append(vuln.Cwes, int32(c)) //nolint:gosec // CWE ids are small
+			}
+		}
+		vuln.NormalizeSeverity()
+		vuln.Analysis = u.unserializeAnalysis(v.Analysis)
+		seen[v.ID] = vuln
+		ret = append(ret, vuln)
+	}
+	return ret
+}
+
+// mergeNodeAnalysis adds the affected components of a repeated vulnerability
+// entry to vuln, recording the entry analysis as theirs.
+func (u *CDX) mergeNodeAnalysis(vuln *sbom.Vulnerability, v *cdx.Vulnerability) {
+	if v.Affects == nil {
+		return
+	}
+	analysis := u.unserializeAnalysis(v.Analysis)
+	for _, a := range *v.Affects {
+		if !slices.Contains(vuln.Affects, a.Ref) {
+			vuln.Affects = append(vuln.Affects, a.Ref)
+		}
+		if analysis == nil {
+			continue
+		}
+		if vuln.NodeAnalyses == nil {
+			vuln.NodeAnalyses = map[string]*sbom.Vulnerability_Analysis{}
+		}
+		vuln.NodeAnalyses[a.Ref] = analysis.Copy()
+	}
+}
+
+// unserializeAnalysis converts the CycloneDX impact analysis
+func (u *CDX) unserializeAnalysis(a *cdx.VulnerabilityAnalysis) *sbom.Vulnerability_Analysis {
+	if a == nil {
+		return nil
+	}
+	analysis := &sbom.Vulnerability_Analysis{
+		State:         sbom.AnalysisStateFromCDX(a.State),
+		Justification: sbom.JustificationFromCDX(a.Justification),
+		Detail:        a.Detail,
+		FirstIssued:   u.parseTimestamp(a.FirstIssued),
+		LastUpdated:   u.parseTimestamp(a.LastUpdated),
+	}
+	if a.Response != nil {
+		for _, r := range *a.Response {
+			analysis.Responses = append(analysis.Responses, sbom.ResponseFromCDX(r))
+		}
+	}
+	return analysis
+}
+
+// parseTimestamp parses an RFC3339 date, returning nil if empty or invalid
+func (u *CDX) parseTimestamp(date string) *timestamppb.Timestamp {
+	if date == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		logrus.Warnf("unable to parse time %q: %v", date, err)
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
 // componentToNodes takes a CycloneDX component and computes its graph fragment,
 // returning a nodelist
-func (u *CDX) componentToNodeList(component *cdx.Component, cc *int) (*sbom.NodeList, error) {
-	node, err := u.componentToNode(component, cc)
+func (u *CDX) componentToNodeList(opts *native.UnserializeOptions, component *cdx.Component, cc *int) (*sbom.NodeList, error) {
+	node, err := u.componentToNode(opts, component, cc)
 	if err != nil {
 		return nil, fmt.Errorf("converting cdx component to node: %w", err)
 	}
@@ -146,7 +383,7 @@ func (u *CDX) componentToNodeList(component *cdx.Component, cc *int) (*sbom.Node
 
 	if component.Components != nil {
 		for i := range *component.Components {
-			subList, err := u.componentToNodeList(&(*component.Components)[i], cc)
+			subList, err := u.componentToNodeList(opts, &(*component.Components)[i], cc)
 			if err != nil {
 				return nil, fmt.Errorf("converting subcomponent to nodelist: %w", err)
 			}
@@ -159,15 +396,13 @@ func (u *CDX) componentToNodeList(component *cdx.Component, cc *int) (*sbom.Node
 	return nl, nil
 }
 
-func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { //nolint:unparam
+func (u *CDX) componentToNode(opts *native.UnserializeOptions, c *cdx.Component, cc *int) (*sbom.Node, error) { //nolint:unparam
 	(*cc)++
 	node := &sbom.Node{
-		Id:      c.BOMRef,
-		Type:    sbom.Node_PACKAGE,
-		Name:    c.Name,
-		Version: c.Version,
-		// UrlHome:     "", // Perhaps it would make sense to use the supplier URL here
-		UrlDownload:        "",
+		Id:                 c.BOMRef,
+		Type:               sbom.Node_PACKAGE,
+		Name:               c.Name,
+		Version:            c.Version,
 		Licenses:           u.licenseChoicesToLicenseList(c.Licenses),
 		LicenseConcluded:   u.licenseChoicesToLicenseString(c.Licenses),
 		Copyright:          c.Copyright,
@@ -181,15 +416,20 @@ func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { /
 		FileTypes:          []string{},
 	}
 
-	node.PrimaryPurpose = []sbom.Purpose{u.componentTypeToPurpose(c.Type)}
+	purpose := u.componentTypeToPurpose(c.Type)
+	if custom, ok := opts.CustomPurpose(string(c.Type)); ok {
+		purpose = custom
+	}
+	node.PrimaryPurpose = []sbom.Purpose{purpose}
 
 	// Protobom recognizes files in CycloneDX SBOMs when a component is of
 	// type file. In that case we flip the type bit:
-	if u.componentTypeToPurpose(c.Type) == sbom.Purpose_FILE {
+	if purpose == sbom.Purpose_FILE {
 		node.Type = sbom.Node_FILE
 	}
 
 	node.ExternalReferences = u.unserializeExternalReferences(c.ExternalReferences)
+	extractNodeURLs(node)
 
 	// Named external references:
 	if c.CPE != "" {
@@ -204,6 +444,14 @@ func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { /
 		node.Identifiers[int32(sbom.SoftwareIdentifierType_PURL)] = c.PackageURL
 	}
 
+	// TODO(degradation): protobom nodes hold only one gitoid and SWHID
+	if c.OmniborID != nil && len(*c.OmniborID) > 0 {
+		node.Identifiers[int32(sbom.SoftwareIdentifierType_GITOID)] = (*c.OmniborID)[0]
+	}
+	if c.SWHID != nil && len(*c.SWHID) > 0 {
+		node.Identifiers[int32(sbom.SoftwareIdentifierType_SWHID)] = (*c.SWHID)[0]
+	}
+
 	if c.Hashes != nil {
 		for _, h := range *c.Hashes {
 			algo := sbom.HashAlgorithmFromCDX(h.Algorithm)
@@ -223,6 +471,31 @@ func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { /
 	if c.Properties != nil && len(*c.Properties) > 0 {
 		ps := []*sbom.Property{}
 		for _, p := range *c.Properties {
+			// Restore the node fields recorded as properties by protobom
+			switch p.Name {
+			case cdxformats.PropertyNodeSummary:
+				node.Summary = p.Value
+				continue
+			case cdxformats.PropertyNodeComment:
+				node.Comment = p.Value
+				continue
+			case cdxformats.PropertyNodeSourceInfo:
+				node.SourceInfo = p.Value
+				continue
+			case cdxformats.PropertyNodeLicenseComments:
+				node.LicenseComments = p.Value
+				continue
+			}
+
+			// Restore the hashes recorded as properties by protobom
+			if algoName, ok := strings.CutPrefix(p.Name, cdxformats.PropertyHashPrefix); ok {
+				if algo, ok := sbom.HashAlgorithm_value[algoName]; ok {
+					if _, ok := node.Hashes[algo]; !ok {
+						node.Hashes[algo] = p.Value
+					}
+					continue
+				}
+			}
 			protoprop := sbom.NewProperty()
 			protoprop.Name = p.Name
 			protoprop.Data = p.Value
@@ -231,6 +504,13 @@ func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { /
 		node.Properties = ps
 	}
 
+	if c.ModelCard != nil {
+		node.AiModel = u.modelCardToAIModel(c.ModelCard)
+	}
+	if c.Data != nil && c.Data.Type == cdx.ComponentDataTypeDataset {
+		node.Dataset = u.componentDataToDataset(c.Data)
+	}
+
 	// Generate a new ID if none is set
 	if node.Id == "" {
 		node.Id = sbom.NewNodeIdentifier("auto", fmt.Sprintf("%09d", *cc))
@@ -239,9 +519,92 @@ func (u *CDX) componentToNode(c *cdx.Component, cc *int) (*sbom.Node, error) { /
 	return node, nil
 }
 
+// modelCardToAIModel converts a CycloneDX model card to the node AI model data
+func (u *CDX) modelCardToAIModel(mc *cdx.MLModelCard) *sbom.AIModel {
+	m := &sbom.AIModel{}
+	if mc.ModelParameters != nil {
+		m.Task = mc.ModelParameters.Task
+		m.ModelArchitecture = mc.ModelParameters.ModelArchitecture
+		if mc.ModelParameters.ArchitectureFamily != "" {
+			m.TypeOfModel = []string{mc.ModelParameters.ArchitectureFamily}
+		}
+	}
+	if mc.QuantitativeAnalysis != nil && mc.QuantitativeAnalysis.PerformanceMetrics != nil {
+		for _, pm := range *mc.QuantitativeAnalysis.PerformanceMetrics {
+			if pm.Type == "" {
+				continue
+			}
+			if m.Metrics == nil {
+				m.Metrics = map[string]string{}
+			}
+			// TODO(degradation): Metrics computed on slices of the data
+			// overwrite each other
+			m.Metrics[pm.Type] = pm.Value
+		}
+	}
+	if mc.Considerations != nil {
+		if mc.Considerations.UseCases != nil {
+			m.InformationAboutApplication = strings.Join(*mc.Considerations.UseCases, "\n")
+		}
+		if mc.Considerations.TechnicalLimitations != nil {
+			m.Limitation = strings.Join(*mc.Considerations.TechnicalLimitations, "\n")
+		}
+	}
+	return m
+}
+
+// componentDataToDataset converts CycloneDX component data to the node
+// dataset data
+func (u *CDX) componentDataToDataset(cd *cdx.ComponentData) *sbom.Dataset {
+	return &sbom.Dataset{
+		IntendedUse:          cd.Description,
+		ConfidentialityLevel: cd.Classification,
+	}
+}
+
+// modelCardEdges returns trainedOn edges from the components with a model
+// card to the datasets it references.
+//
+// TODO(degradation): Model cards don't tell training and testing datasets
+// apart, all are read as training datasets.
+func (u *CDX) modelCardEdges(bom *cdx.BOM) []*sbom.Edge {
+	ret := []*sbom.Edge{}
+	var walk func(*[]cdx.Component)
+	add := func(c *cdx.Component) {
+		if c.BOMRef == "" || c.ModelCard == nil || c.ModelCard.ModelParameters == nil ||
+			c.ModelCard.ModelParameters.Datasets == nil {
+			return
+		}
+		e := &sbom.Edge{Type: sbom.Edge_trainedOn, From: c.BOMRef, To: []string{}}
+		for _, d := range *c.ModelCard.ModelParameters.Datasets {
+			if d.Ref != "" {
+				e.To = append(e.To, d.Ref)
+			}
+		}
+		if len(e.To) > 0 {
+			ret = append(ret, e)
+		}
+	}
+	walk = func(components *[]cdx.Component) {
+		if components == nil {
+			return
+		}
+		for i := range *components {
+			add(&(*components)[i])
+			walk((*components)[i].Components)
+		}
+	}
+	if bom.Metadata != nil && bom.Metadata.Component != nil {
+		add(bom.Metadata.Component)
+		walk(bom.Metadata.Component.Components)
+	}
+	walk(bom.Components)
+	return ret
+}
+
 // parseDependencyGraph parses the bom dependency graph and returns the
-// protobom Edge set with the data.
-func (u *CDX) parseDependencyGraph(bom *cdx.BOM) []*sbom.Edge {
+// protobom Edge set with the data, typed as edgeType.
+func (u *CDX) parseDependencyGraph(bom *cdx.BOM, edgeType sbom.Edge_Type) []*sbom.Edge {
 	ret := []*sbom.Edge{}
 	mapa := map[string]*sbom.Edge{}
 
@@ -254,7 +617,7 @@ func (u *CDX) parseDependencyGraph(bom *cdx.BOM) []*sbom.Edge {
 		if _, ok := mapa[d.Ref]; !ok {
 			mapa[d.Ref] = sbom.NewEdge()
 			mapa[d.Ref].From = d.Ref
-			mapa[d.Ref].Type = sbom.Edge_contains
+			mapa[d.Ref].Type = edgeType
 		}
 
 		// d.Dependencies is a pointer, so we can panic if nil,
@@ -301,6 +664,28 @@ func (u *CDX) unserializeExternalReferences(cdxReferences *[]cdx.ExternalReferen
 	return ret
 }
 
+// extractNodeURLs moves the first plain website and distribution external
+// references of a node to its homepage and download location fields.
+// References with comments or hashes are kept to avoid losing data.
+func extractNodeURLs(node *sbom.Node) {
+	refs := []*sbom.ExternalReference{}
+	for _, er := range node.ExternalReferences {
+		if er.Comment != "" || len(er.Hashes) > 0 {
+			refs = append(refs, er)
+			continue
+		}
+		switch {
+		case er.Type == sbom.ExternalReference_WEBSITE && node.UrlHome == "":
+			node.UrlHome = er.Url
+		case er.Type == sbom.ExternalReference_DOWNLOAD && node.UrlDownload == "":
+			node.UrlDownload = er.Url
+		default:
+			refs = append(refs, er)
+		}
+	}
+	node.ExternalReferences = refs
+}
+
 // licenseChoicesToLicenseList returns a flat list of license strings combining
 // expressions and IDs in one. This function should be part of a license package.
 func (u *CDX) licenseChoicesToLicenseList(lcs *cdx.Licenses) []string {
@@ -309,18 +694,11 @@ func (u *CDX) licenseChoicesToLicenseList(lcs *cdx.Licenses) []string {
 		return list
 	}
 	for _, lc := range *lcs {
-		// TODO(license): This should handle licenses without an ID and
-		// create custom licenses or another solution that captures the
-		// full cuistom license text.
-		if lc.Expression == "" && lc.License.ID == "" {
+		id := u.licenseChoiceID(&lc)
+		if id == "" {
 			continue
 		}
-
-		if lc.Expression != "" {
-			list = append(list, lc.Expression)
-		} else {
-			list = append(list, lc.License.ID)
-		}
+		list = append(list, id)
 		return list
 	}
 
@@ -336,31 +714,100 @@ func (u *CDX) licenseChoicesToLicenseString(lcs *cdx.Licenses) string {
 	if lcs == nil {
 		return ""
 	}
-	s := ""
+	licenses := []string{}
 	for _, lc := range *lcs {
-		// TODO(license): This should handle licenses without an ID and
-		// create custom licenses or another solution that captures the
-		// full cuistom license text.
-		if lc.Expression == "" && lc.License.ID == "" {
+		newLicense := u.licenseChoiceID(&lc)
+		if newLicense == "" {
 			continue
 		}
-		if s != "" {
-			s += fmt.Sprintf("(%s) OR ", s)
+		licenses = append(licenses, newLicense)
+	}
+
+	// A single license or expression is returned verbatim, multiple
+	// entries are joined in a disjunctive expression.
+	if len(licenses) == 1 {
+		return licenses[0]
+	}
+	for i := range licenses {
+		licenses[i] = fmt.Sprintf("(%s)", licenses[i])
+	}
+	return strings.Join(licenses, " OR ")
+}
+
+// licenseChoiceID returns the string to use as license in a protobom node. It
+// returns the expression or the license ID when set. Named licenses (those without
+// an SPDX ID) are referenced by a LicenseRef-* identifier built from their name.
+func (u *CDX) licenseChoiceID(lc *cdx.LicenseChoice) string {
+	if lc.Expression != "" {
+		return lc.Expression
+	}
+	if lc.License == nil {
+		return ""
+	}
+	if lc.License.ID != "" {
+		return lc.License.ID
+	}
+	return namedLicenseRef(lc.License)
+}
+
+// namedLicenseRef returns the LicenseRef-* identifier of a named license,
+// as recorded by protobom in its properties or built from its name.
+func namedLicenseRef(l *cdx.License) string {
+	if l.Properties != nil {
+		for _, p := range *l.Properties {
+			if p.Name == cdxformats.PropertyLicenseID && sbom.IsLicenseRef(p.Value) {
+				return p.Value
+			}
 		}
+	}
+	return sbom.NewLicenseRef(l.Name)
+}
 
-		var newLicense string
-		if lc.Expression != "" {
-			newLicense = lc.Expression
-		} else {
-			newLicense = lc.License.ID
+// collectCustomLicenses walks the component tree and registers all named
+// licenses found in the document metadata as custom licenses.
+func (u *CDX) collectCustomLicenses(md *sbom.Metadata, components *[]cdx.Component) {
+	if components == nil {
+		return
+	}
+	for i := range *components {
+		u.collectComponentLicenses(md, &(*components)[i])
+		u.collectCustomLicenses(md, (*components)[i].Components)
+	}
+}
+
+// collectComponentLicenses registers the named licenses of a single component
+// as custom licenses in the document metadata.
+func (u *CDX) collectComponentLicenses(md *sbom.Metadata, c *cdx.Component) {
+	if c == nil || c.Licenses == nil {
+		return
+	}
+	for _, lc := range *c.Licenses {
+		if lc.License == nil || lc.License.ID != "" || lc.License.Name == "" {
+			continue
+		}
+		id := namedLicenseRef(lc.License)
+		if id == "" || md.GetCustomLicense(id) != nil {
+			continue
+		}
+		l := &sbom.License{
+			Id: id,
+		}
+		if !sbom.IsLicenseRef(lc.License.Name) {
+			l.Name = lc.License.Name
 		}
-		if s == "" {
-			s = newLicense
-		} else {
-			s += fmt.Sprintf(" (%s)", newLicense)
+		if lc.License.Text != nil {
+			l.Text = lc.License.Text.Content
+			if lc.License.Text.Encoding == "base64" {
+				if decoded, err := base64.StdEncoding.DecodeString(lc.License.Text.Content); err == nil {
+					l.Text = string(decoded)
+				}
+			}
+		}
+		if lc.License.URL != "" {
+			l.Urls = []string{lc.License.URL}
 		}
+		md.CustomLicenses = append(md.CustomLicenses, l)
 	}
-	return s
 }
 
 // phaseToSBOMType converts a CycloneDX lifecycle phase to an SBOM document type
@@ -389,40 +836,10 @@ func (u *CDX) phaseToSBOMType(ph *cdx.LifecyclePhase) *sbom.DocumentType_SBOMTyp
 	}
 }
 
-// componentTypeToPurpose converts the protobom catalog of purposes to the cyclonedx
-// component type.
+// componentTypeToPurpose converts a cyclonedx component type to a protobom
+// purpose using the mapping in sbom.PurposeFromCDX
 func (u *CDX) componentTypeToPurpose(cType cdx.ComponentType) sbom.Purpose {
-	// CycloneDX 1.5 types: "application", "framework", "library", "container",
-	// "platform", "operating-system", "device", "device-driver", "firmware",
-	// "file", "machine-learning-model", "data"
-	switch cType {
-	case cdx.ComponentTypeApplication:
-		return sbom.Purpose_APPLICATION
-	case cdx.ComponentTypeFramework:
-		return sbom.Purpose_FRAMEWORK
-	case cdx.ComponentTypeLibrary:
-		return sbom.Purpose_LIBRARY
-	case cdx.ComponentTypeContainer:
-		return sbom.Purpose_CONTAINER
-	case cdx.ComponentTypePlatform:
-		return sbom.Purpose_PLATFORM
-	case cdx.ComponentTypeOS:
-		return sbom.Purpose_OPERATING_SYSTEM
-	case cdx.ComponentTypeDevice:
-		return sbom.Purpose_DEVICE
-	case cdx.ComponentTypeDeviceDriver:
-		return sbom.Purpose_DEVICE_DRIVER
-	case cdx.ComponentTypeFirmware:
-		return sbom.Purpose_FIRMWARE
-	case cdx.ComponentTypeFile:
-		return sbom.Purpose_FILE
-	case cdx.ComponentTypeMachineLearningModel:
-		return sbom.Purpose_MACHINE_LEARNING_MODEL
-	case cdx.ComponentTypeData:
-		return sbom.Purpose_DATA
-	default:
-		return sbom.Purpose_UNKNOWN_PURPOSE
-	}
+	return sbom.PurposeFromCDX(cType)
 }
 
 // cdxHashAlgoToProtobomAlgo returns a protobom algorithm constant from a
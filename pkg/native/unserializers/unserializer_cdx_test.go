@@ -1,11 +1,14 @@
 package unserializers
 
 import (
+	"strings"
 	"testing"
 
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/stretchr/testify/require"
 
+	"github.com/protobom/protobom/pkg/mod"
+	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/sbom"
 )
 
@@ -161,7 +164,7 @@ func TestDeterministicIds(t *testing.T) {
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			cc := 0
-			nodelist, err := cdxu.componentToNodeList(tc.sut, &cc)
+			nodelist, err := cdxu.componentToNodeList(&native.UnserializeOptions{}, tc.sut, &cc)
 			if tc.mustErr {
 				require.Error(t, err)
 				return
@@ -176,3 +179,825 @@ func TestDeterministicIds(t *testing.T) {
 		})
 	}
 }
+
+func TestCollectCustomLicenses(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		components *[]cdx.Component
+		expected   []*sbom.License
+		// references has the node licenses of the first nested component
+		references []string
+	}{
+		{
+			name: "named license with text",
+			components: &[]cdx.Component{{
+				Name: "a",
+				Licenses: &cdx.Licenses{
+					{License: &cdx.License{ID: "Apache-2.0"}},
+					{License: &cdx.License{Name: "ACME License", Text: &cdx.AttachedText{Content: "Do whatever"}}},
+				},
+			}},
+			expected: []*sbom.License{{Id: "LicenseRef-ACME-License", Name: "ACME License", Text: "Do whatever"}},
+		},
+		{
+			name: "license ref name in a nested component",
+			components: &[]cdx.Component{{
+				Name: "a",
+				Components: &[]cdx.Component{{
+					Name: "b",
+					Licenses: &cdx.Licenses{
+						{License: &cdx.License{Name: "LicenseRef-custom", URL: "https://example.com/license"}},
+					},
+				}},
+			}},
+			expected: []*sbom.License{{Id: "LicenseRef-custom", Urls: []string{"https://example.com/license"}}},
+			// Nodes reference the named license with the generated LicenseRef
+			references: []string{"LicenseRef-custom"},
+		},
+		{
+			name: "license seen twice",
+			components: &[]cdx.Component{{
+				Name: "a",
+				Licenses: &cdx.Licenses{
+					{License: &cdx.License{Name: "ACME License", Text: &cdx.AttachedText{Content: "Do whatever"}}},
+				},
+				Components: &[]cdx.Component{{
+					Name:     "b",
+					Licenses: &cdx.Licenses{{License: &cdx.License{Name: "ACME License"}}},
+				}},
+			}},
+			expected:   []*sbom.License{{Id: "LicenseRef-ACME-License", Name: "ACME License", Text: "Do whatever"}},
+			references: []string{"LicenseRef-ACME-License"},
+		},
+		{
+			name: "spdx licenses only",
+			components: &[]cdx.Component{{
+				Name:     "a",
+				Licenses: &cdx.Licenses{{License: &cdx.License{ID: "Apache-2.0"}}},
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxu := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding)
+			md := &sbom.Metadata{}
+			cdxu.collectCustomLicenses(md, tc.components)
+			require.Len(t, md.CustomLicenses, len(tc.expected))
+			for i, l := range tc.expected {
+				require.Equal(t, l.Id, md.CustomLicenses[i].Id)
+				require.Equal(t, l.Name, md.CustomLicenses[i].Name)
+				require.Equal(t, l.Text, md.CustomLicenses[i].Text)
+				require.Equal(t, l.Urls, md.CustomLicenses[i].Urls)
+			}
+			if tc.references != nil {
+				nested := (*(*tc.components)[0].Components)[0]
+				require.Equal(t, tc.references, cdxu.licenseChoicesToLicenseList(nested.Licenses))
+			}
+		})
+	}
+}
+
+func TestUnserializeMetadataProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		properties     string
+		comment        string
+		creatorComment string
+		expected       map[string]string
+	}{
+		{
+			name: "comments and properties",
+			properties: `[
+      {"name": "build", "value": "1234"},
+      {"name": "protobom:document:comment", "value": "Generated in CI"},
+      {"name": "protobom:document:creator_comment", "value": "Reviewed by the release team"}
+    ]`,
+			comment:        "Generated in CI",
+			creatorComment: "Reviewed by the release team",
+			expected:       map[string]string{"build": "1234"},
+		},
+		{
+			name:       "only comments",
+			properties: `[{"name": "protobom:document:comment", "value": "Generated in CI"}]`,
+			comment:    "Generated in CI",
+			expected:   map[string]string{},
+		},
+		{
+			name:       "no properties",
+			properties: `[]`,
+			expected:   map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "properties": ` + tc.properties + `
+  }
+}`
+			doc, err := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.comment, doc.Metadata.Comment)
+			require.Equal(t, tc.creatorComment, doc.Metadata.CreatorComment)
+			require.Len(t, doc.Metadata.Properties, len(tc.expected))
+			for name, data := range tc.expected {
+				require.Equal(t, data, doc.Metadata.GetProperty(name).Data)
+			}
+		})
+	}
+}
+
+func TestUnserializeHashProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		hashes     string
+		properties string
+		expected   map[int32]string
+		// remaining are the names of the properties kept in the node
+		remaining []string
+	}{
+		{
+			name:       "hash property",
+			hashes:     `[{"alg": "BLAKE3", "content": "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262"}]`,
+			properties: `[{"name": "protobom:hash:SSDEEP", "value": "3:AXGBicFlgVNhBGcL6wCrFQEv:AXGHsNhxLsr2C"}]`,
+			expected: map[int32]string{
+				int32(sbom.HashAlgorithm_BLAKE3): "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262",
+				int32(sbom.HashAlgorithm_SSDEEP): "3:AXGBicFlgVNhBGcL6wCrFQEv:AXGHsNhxLsr2C",
+			},
+			remaining: []string{},
+		},
+		{
+			// Properties of unknown algorithms are kept as properties
+			name:       "unknown algorithm",
+			hashes:     `[]`,
+			properties: `[{"name": "protobom:hash:BOGUS", "value": "1234"}]`,
+			expected:   map[int32]string{},
+			remaining:  []string{"protobom:hash:BOGUS"},
+		},
+		{
+			name:       "other properties",
+			hashes:     `[]`,
+			properties: `[{"name": "build", "value": "1234"}, {"name": "protobom:hash:ADLER32", "value": "00000001"}]`,
+			expected:   map[int32]string{int32(sbom.HashAlgorithm_ADLER32): "00000001"},
+			remaining:  []string{"build"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {
+      "bom-ref": "fw",
+      "type": "firmware",
+      "name": "fw",
+      "hashes": ` + tc.hashes + `,
+      "properties": ` + tc.properties + `
+    }
+  ]
+}`
+			doc, err := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			node := doc.NodeList.GetNodeByID("fw")
+			require.NotNil(t, node)
+			require.Equal(t, tc.expected, node.Hashes)
+			names := []string{}
+			for _, p := range node.Properties {
+				names = append(names, p.Name)
+			}
+			require.Equal(t, tc.remaining, names)
+		})
+	}
+}
+
+func TestUnserializeLabels(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// properties are the CycloneDX metadata and component properties
+		metadataProperties  string
+		componentProperties string
+		labels              map[string]string
+		key                 string
+		value               string
+		nodes               []string
+	}{
+		{
+			name:                "labels",
+			metadataProperties:  `[{"name": "protobom:label:env", "value": "prod"}]`,
+			componentProperties: `[{"name": "protobom:label:team", "value": "core"}]`,
+			labels:              map[string]string{"env": "prod"},
+			key:                 "team",
+			value:               "core",
+			nodes:               []string{"lib"},
+		},
+		{
+			name:                "other properties",
+			metadataProperties:  `[{"name": "env", "value": "prod"}]`,
+			componentProperties: `[{"name": "team", "value": "core"}]`,
+			labels:              map[string]string{},
+			key:                 "team",
+			value:               "core",
+			nodes:               []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {
+    "properties": ` + tc.metadataProperties + `
+  },
+  "components": [
+    {
+      "bom-ref": "lib",
+      "type": "library",
+      "name": "lib",
+      "properties": ` + tc.componentProperties + `
+    }
+  ]
+}`
+			doc, err := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.labels, doc.Metadata.Labels())
+			nodes := []string{}
+			for _, n := range doc.NodeList.GetNodesByLabel(tc.key, tc.value) {
+				nodes = append(nodes, n.Id)
+			}
+			require.Equal(t, tc.nodes, nodes)
+		})
+	}
+}
+
+func TestUnserializeToolVerification(t *testing.T) {
+	digest := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	for _, tc := range []struct {
+		name string
+		// scanner is the CycloneDX tool component under test
+		scanner       string
+		signature     *sbom.ToolSignature
+		digest        string
+		verifications int
+	}{
+		{
+			name: "hashes and signature",
+			scanner: `{
+          "type": "application",
+          "name": "scanner",
+          "version": "1.0",
+          "supplier": {"name": "ACME"},
+          "hashes": [{"alg": "SHA-256", "content": "` + digest + `"}],
+          "properties": [
+            {"name": "protobom:tool:signature:algorithm", "value": "ES256"},
+            {"name": "protobom:tool:signature:value", "value": "c2lnbmF0dXJl"}
+          ]
+        }`,
+			signature:     &sbom.ToolSignature{Algorithm: "ES256", Value: "c2lnbmF0dXJl"},
+			digest:        digest,
+			verifications: 1,
+		},
+		{
+			name: "hashes only",
+			scanner: `{
+          "type": "application",
+          "name": "scanner",
+          "version": "1.0",
+          "supplier": {"name": "ACME"},
+          "hashes": [{"alg": "SHA-256", "content": "` + digest + `"}]
+        }`,
+			digest:        digest,
+			verifications: 1,
+		},
+		{
+			name: "no verification material",
+			scanner: `{
+          "type": "application",
+          "name": "scanner",
+          "version": "1.0",
+          "supplier": {"name": "ACME"}
+        }`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "metadata": {
+    "tools": {
+      "components": [
+        ` + tc.scanner + `,
+        {"type": "application", "name": "other"}
+      ],
+      "services": [{"name": "builder", "provider": {"name": "CI"}}]
+    }
+  }
+}`
+			doc, err := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			require.Len(t, doc.Metadata.Tools, 3)
+			require.Equal(t, "ACME", doc.Metadata.Tools[0].Vendor)
+			require.Equal(t, "CI", doc.Metadata.Tools[2].Vendor)
+			require.Len(t, doc.Metadata.ToolVerifications(), tc.verifications)
+
+			tv := doc.Metadata.GetToolVerification("scanner", "1.0")
+			if tc.verifications == 0 {
+				require.Nil(t, tv)
+				return
+			}
+			require.NotNil(t, tv)
+			require.True(t, tv.HasDigest(sbom.HashAlgorithm_SHA256, tc.digest))
+			require.Equal(t, tc.signature, tv.Signature)
+		})
+	}
+}
+
+func TestUnserializeOmniborID(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		omniborID string
+		gitoid    string
+	}{
+		{
+			name:      "omnibor id",
+			omniborID: `, "omniborId": ["gitoid:blob:sha256:2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4"]`,
+			gitoid:    "gitoid:blob:sha256:2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4",
+		},
+		{
+			name: "no omnibor id",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {
+      "bom-ref": "lib",
+      "type": "library",
+      "name": "lib",
+      "purl": "pkg:generic/lib@1.0"` + tc.omniborID + `
+    }
+  ]
+}`
+			doc, err := NewCDX("1.6", cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			node := doc.NodeList.GetNodeByID("lib")
+			require.NotNil(t, node)
+			require.Equal(t, "pkg:generic/lib@1.0", node.Identifiers[int32(sbom.SoftwareIdentifierType_PURL)])
+			require.Equal(t, tc.gitoid, node.Identifiers[int32(sbom.SoftwareIdentifierType_GITOID)])
+		})
+	}
+}
+
+func TestUnserializeNodeSoftFields(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		refs       string
+		properties string
+		expected   *sbom.Node
+		// extRefs is the number of external references left in the node
+		extRefs int
+	}{
+		{
+			name: "text properties",
+			refs: `[]`,
+			properties: `[
+        {"name": "protobom:node:summary", "value": "Short summary"},
+        {"name": "protobom:node:comment", "value": "Reviewed"},
+        {"name": "protobom:node:source_info", "value": "Built from git tag v1.0"},
+        {"name": "protobom:node:license_comments", "value": "Dual licensed"}
+      ]`,
+			expected: &sbom.Node{
+				Summary: "Short summary", Comment: "Reviewed",
+				SourceInfo: "Built from git tag v1.0", LicenseComments: "Dual licensed",
+			},
+		},
+		{
+			// Only the first website becomes the home page
+			name: "websites",
+			refs: `[
+        {"type": "website", "url": "https://example.com/"},
+        {"type": "website", "url": "https://mirror.example.com/"}
+      ]`,
+			properties: `[]`,
+			expected:   &sbom.Node{UrlHome: "https://example.com/"},
+			extRefs:    1,
+		},
+		{
+			name:       "distribution",
+			refs:       `[{"type": "distribution", "url": "https://example.com/pkg.tgz"}]`,
+			properties: `[]`,
+			expected:   &sbom.Node{UrlDownload: "https://example.com/pkg.tgz"},
+		},
+		{
+			// The distribution reference has a comment so it is not moved
+			name:       "distribution with comment",
+			refs:       `[{"type": "distribution", "url": "https://example.com/pkg.tgz", "comment": "Release tarball"}]`,
+			properties: `[]`,
+			expected:   &sbom.Node{},
+			extRefs:    1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {
+      "bom-ref": "pkg",
+      "type": "library",
+      "name": "pkg",
+      "description": "A package",
+      "externalReferences": ` + tc.refs + `,
+      "properties": ` + tc.properties + `
+    }
+  ]
+}`
+			doc, err := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			node := doc.NodeList.GetNodeByID("pkg")
+			require.NotNil(t, node)
+			require.Equal(t, "A package", node.Description)
+			require.Equal(t, tc.expected.Summary, node.Summary)
+			require.Equal(t, tc.expected.Comment, node.Comment)
+			require.Equal(t, tc.expected.SourceInfo, node.SourceInfo)
+			require.Equal(t, tc.expected.LicenseComments, node.LicenseComments)
+			require.Equal(t, tc.expected.UrlHome, node.UrlHome)
+			require.Equal(t, tc.expected.UrlDownload, node.UrlDownload)
+			require.Len(t, node.ExternalReferences, tc.extRefs)
+			require.Empty(t, node.Properties)
+		})
+	}
+}
+
+func TestUnserializeCustomPurposes(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		componentType string
+		custom        map[string]sbom.Purpose
+		expected      []sbom.Purpose
+	}{
+		{name: "known type", componentType: "operating-system", expected: []sbom.Purpose{sbom.Purpose_OPERATING_SYSTEM}},
+		{
+			name:          "custom purpose",
+			componentType: "cryptographic-asset",
+			custom:        map[string]sbom.Purpose{"cryptographic-asset": sbom.Purpose_DATA},
+			expected:      []sbom.Purpose{sbom.Purpose_DATA},
+		},
+		{
+			// Custom purposes override the built-in mappings
+			name:          "overridden type",
+			componentType: "operating-system",
+			custom:        map[string]sbom.Purpose{"operating-system": sbom.Purpose_PLATFORM},
+			expected:      []sbom.Purpose{sbom.Purpose_PLATFORM},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {"bom-ref": "pkg", "type": "` + tc.componentType + `", "name": "pkg"}
+  ]
+}`
+			doc, err := NewCDX("1.6", cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{CustomPurposes: tc.custom}, nil,
+			)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, doc.NodeList.GetNodeByID("pkg").PrimaryPurpose)
+		})
+	}
+}
+
+func TestUnserializeDependenciesAsDependsOn(t *testing.T) {
+	cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app"}},
+  "components": [
+    {
+      "bom-ref": "image", "type": "container", "name": "image",
+      "components": [{"bom-ref": "base", "type": "operating-system", "name": "base"}]
+    },
+    {"bom-ref": "lib", "type": "library", "name": "lib"}
+  ],
+  "dependencies": [
+    {"ref": "app", "dependsOn": ["lib"]}
+  ]
+}`
+	for _, tc := range []struct {
+		name     string
+		mods     map[mod.Mod]struct{}
+		expected []*sbom.Edge
+	}{
+		{
+			name: "default",
+			mods: map[mod.Mod]struct{}{},
+			expected: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"image", "lib"}},
+				{Type: sbom.Edge_contains, From: "image", To: []string{"base"}},
+			},
+		},
+		{
+			name: "depends-on",
+			mods: map[mod.Mod]struct{}{mod.CDX_READ_DEPENDENCIES_AS_DEPENDS_ON: {}},
+			expected: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"image", "lib"}},
+				{Type: sbom.Edge_contains, From: "image", To: []string{"base"}},
+				{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := NewCDX(cdxUnserializerTestVersion, cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{Mods: tc.mods}, nil,
+			)
+			require.NoError(t, err)
+			doc.NodeList.NormalizeEdges()
+			require.Len(t, doc.NodeList.Edges, len(tc.expected))
+			for i := range tc.expected {
+				require.Equal(t, tc.expected[i].Type, doc.NodeList.Edges[i].Type)
+				require.Equal(t, tc.expected[i].From, doc.NodeList.Edges[i].From)
+				require.Equal(t, tc.expected[i].To, doc.NodeList.Edges[i].To)
+			}
+		})
+	}
+}
+
+func TestUnserializeModelCard(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		modelCard string
+		model     *sbom.AIModel
+		trainedOn []string
+	}{
+		{
+			name: "full model card",
+			modelCard: `{
+        "modelParameters": {
+          "task": "text-generation",
+          "architectureFamily": "transformer",
+          "modelArchitecture": "LLaMA",
+          "datasets": [{"ref": "corpus"}]
+        },
+        "quantitativeAnalysis": {
+          "performanceMetrics": [{"type": "accuracy", "value": "0.91"}]
+        },
+        "considerations": {
+          "useCases": ["Chat assistants"],
+          "technicalLimitations": ["English only"]
+        }
+      }`,
+			model: &sbom.AIModel{
+				TypeOfModel:                 []string{"transformer"},
+				Task:                        "text-generation",
+				ModelArchitecture:           "LLaMA",
+				InformationAboutApplication: "Chat assistants",
+				Limitation:                  "English only",
+				Metrics:                     map[string]string{"accuracy": "0.91"},
+			},
+			trainedOn: []string{"corpus"},
+		},
+		{
+			name:      "parameters only",
+			modelCard: `{"modelParameters": {"task": "text-generation"}}`,
+			model:     &sbom.AIModel{Task: "text-generation"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {
+      "bom-ref": "model",
+      "type": "machine-learning-model",
+      "name": "model",
+      "modelCard": ` + tc.modelCard + `
+    },
+    {
+      "bom-ref": "corpus",
+      "type": "data",
+      "name": "corpus",
+      "data": {"type": "dataset", "name": "corpus", "classification": "green", "description": "Pretraining"}
+    }
+  ]
+}`
+			doc, err := NewCDX("1.6", cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+
+			model := doc.NodeList.GetNodeByID("model")
+			require.NotNil(t, model)
+			require.Equal(t, tc.model, model.AiModel)
+
+			corpus := doc.NodeList.GetNodeByID("corpus")
+			require.NotNil(t, corpus)
+			require.Equal(t, &sbom.Dataset{IntendedUse: "Pretraining", ConfidentialityLevel: "green"}, corpus.Dataset)
+
+			e := doc.NodeList.GetEdgeByType("model", sbom.Edge_trainedOn)
+			if tc.trainedOn == nil {
+				require.Nil(t, e)
+				return
+			}
+			require.NotNil(t, e)
+			require.Equal(t, tc.trainedOn, e.To)
+		})
+	}
+}
+
+func TestUnserializeVulnerabilities(t *testing.T) {
+	score := 10.0
+	for _, tc := range []struct {
+		name      string
+		vuln      string
+		expected  *sbom.Vulnerability
+		published int64
+		ratings   []*sbom.Vulnerability_Rating
+		nodeVulns int
+	}{
+		{
+			name: "all fields",
+			vuln: `{
+      "id": "CVE-2021-44228",
+      "source": {"name": "NVD", "url": "https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+      "references": [{"id": "GHSA-jfh8-c2jp-5v3q", "source": {"name": "GitHub"}}],
+      "ratings": [
+        {"source": {"name": "NVD"}, "score": 10.0, "severity": "critical", "method": "CVSSv31",
+         "vector": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H"}
+      ],
+      "description": "Log4Shell",
+      "cwes": [502, 400],
+      "advisories": [{"url": "https://logging.apache.org/log4j/2.x/security.html"}],
+      "published": "2021-12-10T00:00:00Z",
+      "analysis": {"state": "exploitable", "detail": "JNDI lookups enabled"},
+      "affects": [{"ref": "log4j"}]
+    }`,
+			expected: &sbom.Vulnerability{
+				Id:         "CVE-2021-44228",
+				SourceName: "NVD",
+				Aliases:    []string{"GHSA-jfh8-c2jp-5v3q"},
+				Advisories: []string{"https://logging.apache.org/log4j/2.x/security.html"},
+				Affects:    []string{"log4j"},
+				Severity:   sbom.Vulnerability_CRITICAL,
+				Cwes:       []int32{502, 400},
+				Analysis: &sbom.Vulnerability_Analysis{
+					State:  sbom.Vulnerability_Analysis_EXPLOITABLE,
+					Detail: "JNDI lookups enabled",
+				},
+			},
+			published: 1639094400,
+			ratings:   []*sbom.Vulnerability_Rating{{Source: "NVD", Score: &score, Severity: "critical", Method: "CVSSv31"}},
+			nodeVulns: 1,
+		},
+		{
+			name:     "id only",
+			vuln:     `{"id": "CVE-2021-45046"}`,
+			expected: &sbom.Vulnerability{Id: "CVE-2021-45046"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {"bom-ref": "log4j", "type": "library", "name": "log4j-core", "version": "2.14.1"}
+  ],
+  "vulnerabilities": [` + tc.vuln + `]
+}`
+			doc, err := NewCDX("1.6", cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			require.Len(t, doc.Vulnerabilities, 1)
+
+			v := doc.Vulnerabilities[0]
+			require.Equal(t, tc.expected.Id, v.Id)
+			require.Equal(t, tc.expected.SourceName, v.SourceName)
+			require.ElementsMatch(t, tc.expected.Aliases, v.Aliases)
+			require.ElementsMatch(t, tc.expected.Advisories, v.Advisories)
+			require.ElementsMatch(t, tc.expected.Affects, v.Affects)
+			require.ElementsMatch(t, tc.expected.Cwes, v.Cwes)
+			require.Equal(t, tc.expected.Severity, v.Severity)
+			require.Equal(t, tc.published, v.Published.GetSeconds())
+			require.Nil(t, v.Updated)
+			require.Len(t, v.Ratings, len(tc.ratings))
+			for i, r := range tc.ratings {
+				require.Equal(t, r.Source, v.Ratings[i].Source)
+				require.Equal(t, r.GetScore(), v.Ratings[i].GetScore())
+				require.Equal(t, r.Severity, v.Ratings[i].Severity)
+				require.Equal(t, r.Method, v.Ratings[i].Method)
+			}
+			require.Equal(t, tc.expected.Analysis.GetState(), v.Analysis.GetState())
+			require.Equal(t, tc.expected.Analysis.GetDetail(), v.Analysis.GetDetail())
+			require.Len(t, doc.GetNodeVulnerabilities("log4j"), tc.nodeVulns)
+		})
+	}
+}
+
+func TestUnserializeVulnerabilityNodeAnalyses(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		vulns    string
+		affects  []string
+		analyses map[string]*sbom.Vulnerability_Analysis
+		updated  int64
+	}{
+		{
+			name: "one entry",
+			vulns: `{
+      "id": "CVE-2024-0001",
+      "analysis": {"state": "in_triage"},
+      "affects": [{"ref": "app"}, {"ref": "lib"}]
+    }`,
+			affects: []string{"app", "lib"},
+			analyses: map[string]*sbom.Vulnerability_Analysis{
+				"app": {State: sbom.Vulnerability_Analysis_IN_TRIAGE},
+				"lib": {State: sbom.Vulnerability_Analysis_IN_TRIAGE},
+			},
+		},
+		{
+			name: "entry per analysis",
+			vulns: `{
+      "id": "CVE-2024-0001",
+      "analysis": {"state": "in_triage"},
+      "affects": [{"ref": "app"}]
+    },
+    {
+      "id": "CVE-2024-0001",
+      "analysis": {
+        "state": "not_affected", "justification": "code_not_reachable",
+        "response": ["will_not_fix"], "lastUpdated": "2024-01-02T00:00:00Z"
+      },
+      "affects": [{"ref": "lib"}]
+    }`,
+			affects: []string{"app", "lib"},
+			analyses: map[string]*sbom.Vulnerability_Analysis{
+				"app": {State: sbom.Vulnerability_Analysis_IN_TRIAGE},
+				"lib": {
+					State:         sbom.Vulnerability_Analysis_NOT_AFFECTED,
+					Justification: sbom.Vulnerability_Analysis_CODE_NOT_REACHABLE,
+					Responses:     []sbom.Vulnerability_Analysis_Response{sbom.Vulnerability_Analysis_WILL_NOT_FIX},
+				},
+			},
+			updated: 1704153600,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cdxJSON := `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "version": 1,
+  "metadata": {},
+  "components": [
+    {"bom-ref": "app", "type": "application", "name": "app"},
+    {"bom-ref": "lib", "type": "library", "name": "lib"}
+  ],
+  "vulnerabilities": [` + tc.vulns + `]
+}`
+			doc, err := NewCDX("1.6", cdxUnserializerTestEncoding).Unserialize(
+				strings.NewReader(cdxJSON), &native.UnserializeOptions{}, nil,
+			)
+			require.NoError(t, err)
+			require.Len(t, doc.Vulnerabilities, 1)
+
+			v := doc.Vulnerabilities[0]
+			require.Equal(t, tc.affects, v.Affects)
+			for node, expected := range tc.analyses {
+				a := v.AnalysisFor(node)
+				require.Equal(t, expected.State, a.GetState())
+				require.Equal(t, expected.Justification, a.GetJustification())
+				require.Equal(t, expected.Responses, a.GetResponses())
+			}
+			require.Equal(t, tc.updated, v.AnalysisFor("lib").GetLastUpdated().GetSeconds())
+		})
+	}
+}
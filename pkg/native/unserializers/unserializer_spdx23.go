@@ -13,8 +13,10 @@ import (
 	spdxjson "github.com/spdx/tools-golang/json"
 	"github.com/spdx/tools-golang/spdx"
 	spdx23 "github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"github.com/spdx/tools-golang/tagvalue"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/protobom/protobom/pkg/formats"
 	protospdx "github.com/protobom/protobom/pkg/formats/spdx"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
@@ -23,12 +25,23 @@ import (
 
 var _ native.Unserializer = &SPDX23{}
 
-type SPDX23 struct{}
+// SPDX23 parses SPDX 2.3 documents, in JSON unless created with
+// NewSPDX23TV
+type SPDX23 struct {
+	encoding string
+}
 
 func NewSPDX23() *SPDX23 {
 	return &SPDX23{}
 }
 
+// NewSPDX23TV returns an unserializer that parses SPDX documents in the
+// tag-value encoding. Documents of earlier SPDX 2 versions are converted
+// to SPDX 2.3 when read.
+func NewSPDX23TV() *SPDX23 {
+	return &SPDX23{encoding: formats.TEXT}
+}
+
 // buildDocumentIdentifier builds the protobom identifier from
 // the SPDX information.
 func buildDocumentIdentifier(doc *spdx23.Document) string {
@@ -47,14 +60,15 @@ func buildDocumentIdentifier(doc *spdx23.Document) string {
 
 // ParseStream reads an io.Reader to parse an SPDX 2.3 document from it
 func (u *SPDX23) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ interface{}) (*sbom.Document, error) {
-	spdxDoc, err := spdxjson.Read(r)
+	spdxDoc, err := u.read(r)
 	if err != nil {
-		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+		return nil, err
 	}
 
 	bom := sbom.NewDocument()
 	bom.Metadata.Id = buildDocumentIdentifier(spdxDoc)
 	bom.Metadata.Name = spdxDoc.DocumentName
+	bom.Metadata.Comment = spdxDoc.DocumentComment
 
 	// TODO(degradation): External document references
 
@@ -63,6 +77,7 @@ func (u *SPDX23) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ int
 		if t := u.spdxDateToTime(spdxDoc.CreationInfo.Created); t != nil {
 			bom.Metadata.Date = timestamppb.New(*t)
 		}
+		bom.Metadata.CreatorComment = spdxDoc.CreationInfo.CreatorComment
 		if spdxDoc.CreationInfo.Creators != nil {
 			for _, c := range spdxDoc.CreationInfo.Creators {
 				// TODO: We need to create a parser library in formats/spdx
@@ -80,8 +95,31 @@ func (u *SPDX23) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ int
 
 	// TODO(degradation): SPDX LicenseVersion
 
+	for _, ol := range spdxDoc.OtherLicenses {
+		bom.Metadata.CustomLicenses = append(bom.Metadata.CustomLicenses, u.otherLicenseToLicense(ol))
+	}
+
+	// Document annotations created by protobom carry the document properties.
+	// The JSON encoding does not record the element of top level annotations
+	// so an empty element ID also refers to the document.
+	if opts.IsModEnabled(mod.SPDX_READ_ANNOTATIONS_TO_PROPERTIES) {
+		for _, a := range spdxDoc.Annotations {
+			if a == nil {
+				continue
+			}
+			if id := a.AnnotationSPDXIdentifier.ElementRefID; id != "" && id != protospdx.DOCUMENT {
+				continue
+			}
+			if property := annotationToProperty(a); property != nil {
+				bom.Metadata.Properties = append(bom.Metadata.Properties, property)
+			}
+		}
+	}
+
 	for _, p := range spdxDoc.Packages {
-		bom.NodeList.AddNode(u.packageToNode(opts, p))
+		n := u.packageToNode(opts, p)
+		bom.NodeList.AddNode(n)
+		u.addAdvisoryVulnerabilities(bom, n)
 	}
 
 	for _, f := range spdxDoc.Files {
@@ -93,13 +131,28 @@ func (u *SPDX23) Unserialize(r io.Reader, opts *native.UnserializeOptions, _ int
 		if r.RefA.ElementRefID == "DOCUMENT" && strings.EqualFold(r.Relationship, "DESCRIBES") {
 			bom.NodeList.RootElements = append(bom.NodeList.RootElements, string(r.RefB.ElementRefID))
 		} else {
-			bom.NodeList.AddEdge(u.relationshipToEdge(r))
+			bom.NodeList.AddEdge(u.relationshipToEdge(opts, r))
 		}
 	}
 
 	return bom, nil
 }
 
+// addAdvisoryVulnerabilities records the vulnerabilities of the security
+// advisory references of a node. The references are kept in the node.
+func (u *SPDX23) addAdvisoryVulnerabilities(bom *sbom.Document, n *sbom.Node) {
+	for _, er := range n.ExternalReferences {
+		if er.Type != sbom.ExternalReference_SECURITY_ADVISORY || er.Url == "" {
+			continue
+		}
+		bom.AddVulnerability(&sbom.Vulnerability{
+			Id:         sbom.VulnerabilityIDFromURL(er.Url),
+			Advisories: []string{er.Url},
+			Affects:    []string{n.Id},
+		})
+	}
+}
+
 // packageToNode assigns the data from an SPDX package into a new Node
 func (u *SPDX23) packageToNode(opts *native.UnserializeOptions, p *spdx23.Package) *sbom.Node {
 	n := &sbom.Node{
@@ -120,35 +173,15 @@ func (u *SPDX23) packageToNode(opts *native.UnserializeOptions, p *spdx23.Packag
 		Identifiers:     map[int32]string{},
 	}
 
-	// SPDX 2.3 PrimaryPackagePurpose types: APPLICATION | FRAMEWORK | LIBRARY | CONTAINER | OPERATING-SYSTEM | DEVICE | FIRMWARE | SOURCE | ARCHIVE | FILE | INSTALL | OTHER
-	switch p.PrimaryPackagePurpose {
-	case "APPLICATION":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_APPLICATION}
-	case "FRAMEWORK":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_FRAMEWORK}
-	case "LIBRARY":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_LIBRARY}
-	case "CONTAINER":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_CONTAINER}
-	case "OPERATING-SYSTEM":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_OPERATING_SYSTEM}
-	case "DEVICE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_DEVICE}
-	case "FIRMWARE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_FIRMWARE}
-	case "SOURCE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_SOURCE}
-	case "ARCHIVE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_ARCHIVE}
-	case "FILE":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_FILE}
-	case "INSTALL":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_INSTALL}
-	case "OTHER":
-		n.PrimaryPurpose = []sbom.Purpose{sbom.Purpose_OTHER}
-	case "":
-	default:
+	if p.PrimaryPackagePurpose != "" {
+		purpose := sbom.PurposeFromSPDX2(p.PrimaryPackagePurpose)
+		if custom, ok := opts.CustomPurpose(p.PrimaryPackagePurpose); ok {
+			purpose = custom
+		}
 		// TODO(degradation): unknown PrimaryPackagePurpose not preserved in protobom struct
+		if purpose != sbom.Purpose_UNKNOWN_PURPOSE {
+			n.PrimaryPurpose = []sbom.Purpose{purpose}
+		}
 	}
 
 	// TODO(degradation) NOASSERTION
@@ -232,22 +265,61 @@ func (u *SPDX23) packageToNode(opts *native.UnserializeOptions, p *spdx23.Packag
 	// created by protobom:
 	if opts.IsModEnabled(mod.SPDX_READ_ANNOTATIONS_TO_PROPERTIES) {
 		for i := range p.Annotations {
-			if p.Annotations[i].Annotator.AnnotatorType != "Tool" ||
-				p.Annotations[i].Annotator.Annotator != "protobom - v1.0.0" {
-				continue
+			if property := annotationToProperty(&p.Annotations[i]); property != nil {
+				n.Properties = append(n.Properties, property)
 			}
-
-			property := &sbom.Property{}
-			if err := json.Unmarshal([]byte(p.Annotations[i].AnnotationComment), property); err != nil {
-				continue
-			}
-			n.Properties = append(n.Properties, property)
 		}
 	}
 
 	return n
 }
 
+// read parses the SPDX document in the unserializer encoding
+func (u *SPDX23) read(r io.Reader) (*spdx23.Document, error) {
+	if u.encoding == formats.TEXT {
+		doc, err := tagvalue.Read(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing SPDX tag-value: %w", err)
+		}
+		return doc, nil
+	}
+	doc, err := spdxjson.Read(r)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SPDX json: %w", err)
+	}
+	return doc, nil
+}
+
+// annotationToProperty decodes a property serialized by protobom as an SPDX
+// annotation. It returns nil if the annotation was not created by protobom.
+func annotationToProperty(a *spdx23.Annotation) *sbom.Property {
+	if a.Annotator.AnnotatorType != "Tool" ||
+		a.Annotator.Annotator != "protobom - v1.0.0" {
+		return nil
+	}
+
+	property := &sbom.Property{}
+	if err := json.Unmarshal([]byte(a.AnnotationComment), property); err != nil {
+		return nil
+	}
+	return property
+}
+
+// otherLicenseToLicense converts an SPDX extracted licensing info entry into
+// a protobom custom license.
+func (*SPDX23) otherLicenseToLicense(ol *spdx23.OtherLicense) *sbom.License {
+	l := &sbom.License{
+		Id:      ol.LicenseIdentifier,
+		Text:    ol.ExtractedText,
+		Urls:    ol.LicenseCrossReferences,
+		Comment: ol.LicenseComment,
+	}
+	if ol.LicenseName != protospdx.NOASSERTION {
+		l.Name = ol.LicenseName
+	}
+	return l
+}
+
 // spdxDateToTime is a utility function that turns a date into a go time.Time
 func (*SPDX23) spdxDateToTime(date string) *time.Time {
 	if date == "" {
@@ -309,7 +381,7 @@ func (u *SPDX23) fileToNode(f *spdx23.File) *sbom.Node {
 }
 
 // relationshipToEdge converts the SPDX relationship to a protobom Edge
-func (*SPDX23) relationshipToEdge(r *spdx23.Relationship) *sbom.Edge {
+func (*SPDX23) relationshipToEdge(opts *native.UnserializeOptions, r *spdx23.Relationship) *sbom.Edge {
 	// TODO(degradation) How to handle external documents?
 	// TODO(degradation) How to handle NOASSERTION and NONE targets
 	e := &sbom.Edge{
@@ -317,6 +389,13 @@ func (*SPDX23) relationshipToEdge(r *spdx23.Relationship) *sbom.Edge {
 		From: string(r.RefA.ElementRefID),
 		To:   []string{string(r.RefB.ElementRefID)},
 	}
+
+	// Type custom relationship labels if they are mapped in the options
+	if e.Type == sbom.Edge_UNKNOWN && opts != nil {
+		if t, ok := opts.CustomEdgeTypes[r.Relationship]; ok {
+			e.Type = t
+		}
+	}
 	return e
 }
 
@@ -383,6 +462,8 @@ func (*SPDX23) extRefTypeToIdentifierType(spdxType string) sbom.SoftwareIdentifi
 		return sbom.SoftwareIdentifierType_CPE23
 	case spdx.TypePersistentIdGitoid:
 		return sbom.SoftwareIdentifierType_GITOID
+	case spdx.TypePersistentIdSwh:
+		return sbom.SoftwareIdentifierType_SWHID
 	default:
 		return sbom.SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE
 	}
@@ -2,7 +2,10 @@ package unserializers
 
 import (
 	"fmt"
+	"maps"
 	"regexp"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/spdx/tools-golang/spdx"
@@ -114,6 +117,7 @@ func TestExtRefTypeToIdentifierType(t *testing.T) {
 		{spdx.SecurityCPE23Type, sbom.SoftwareIdentifierType_CPE23},
 		{spdx.SecurityCPE22Type, sbom.SoftwareIdentifierType_CPE22},
 		{spdx.TypePersistentIdGitoid, sbom.SoftwareIdentifierType_GITOID},
+		{spdx.TypePersistentIdSwh, sbom.SoftwareIdentifierType_SWHID},
 		{"", sbom.SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE},
 	} {
 		identifier := s23.extRefTypeToIdentifierType(tc.sut)
@@ -274,3 +278,265 @@ func TestAnnotationsMod(t *testing.T) {
 		})
 	}
 }
+
+func TestOtherLicenseToLicense(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *spdx23.OtherLicense
+		expected *sbom.License
+	}{
+		{
+			name: "all fields",
+			sut: &spdx23.OtherLicense{
+				LicenseIdentifier:      "LicenseRef-acme",
+				ExtractedText:          "ACME license text",
+				LicenseName:            "ACME License",
+				LicenseCrossReferences: []string{"https://example.com/"},
+				LicenseComment:         "A comment",
+			},
+			expected: &sbom.License{
+				Id:      "LicenseRef-acme",
+				Text:    "ACME license text",
+				Name:    "ACME License",
+				Urls:    []string{"https://example.com/"},
+				Comment: "A comment",
+			},
+		},
+		{
+			name: "name not asserted",
+			sut: &spdx23.OtherLicense{
+				LicenseIdentifier: "LicenseRef-acme",
+				ExtractedText:     "ACME license text",
+				LicenseName:       "NOASSERTION",
+			},
+			expected: &sbom.License{Id: "LicenseRef-acme", Text: "ACME license text"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			l := NewSPDX23().otherLicenseToLicense(tc.sut)
+			require.Equal(t, tc.expected.Id, l.Id)
+			require.Equal(t, tc.expected.Text, l.Text)
+			require.Equal(t, tc.expected.Name, l.Name)
+			require.Equal(t, tc.expected.Urls, l.Urls)
+			require.Equal(t, tc.expected.Comment, l.Comment)
+		})
+	}
+}
+
+func TestRelationshipToEdgeCustomTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		custom   map[string]sbom.Edge_Type
+		expected sbom.Edge_Type
+	}{
+		{name: "no custom types", expected: sbom.Edge_UNKNOWN},
+		{
+			name:     "custom type",
+			custom:   map[string]sbom.Edge_Type{"BUILT_WITH": sbom.Edge_buildTool},
+			expected: sbom.Edge_buildTool,
+		},
+		{
+			name:     "other custom type",
+			custom:   map[string]sbom.Edge_Type{"SHIPPED_WITH": sbom.Edge_buildTool},
+			expected: sbom.Edge_UNKNOWN,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rel := &spdx23.Relationship{
+				RefA:         common.DocElementID{ElementRefID: "SPDXRef-A"},
+				RefB:         common.DocElementID{ElementRefID: "SPDXRef-B"},
+				Relationship: "BUILT_WITH",
+			}
+			e := NewSPDX23().relationshipToEdge(&native.UnserializeOptions{CustomEdgeTypes: tc.custom}, rel)
+			require.Equal(t, tc.expected, e.Type)
+			require.Equal(t, "SPDXRef-A", e.From)
+			require.Equal(t, []string{"SPDXRef-B"}, e.To)
+		})
+	}
+}
+
+func TestUnserializeDocumentComments(t *testing.T) {
+	spdxJSON := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "comment": "Generated in CI",
+  "creationInfo": {
+    "creators": ["Tool: protobom"],
+    "created": "2024-01-01T00:00:00Z",
+    "comment": "Reviewed by the release team"
+  },
+  "annotations": [
+    {
+      "annotator": "Tool: protobom - v1.0.0",
+      "annotationDate": "1970-01-01T00:00:00Z",
+      "annotationType": "OTHER",
+      "comment": "{\"name\": \"build\", \"data\": \"1234\"}"
+    },
+    {
+      "annotator": "Person: John Doe",
+      "annotationDate": "1970-01-01T00:00:00Z",
+      "annotationType": "REVIEW",
+      "comment": "Looks good"
+    }
+  ]
+}`
+	for _, tc := range []struct {
+		name     string
+		mods     map[mod.Mod]struct{}
+		expected map[string]string
+	}{
+		{
+			name:     "annotations to properties",
+			mods:     map[mod.Mod]struct{}{mod.SPDX_READ_ANNOTATIONS_TO_PROPERTIES: {}},
+			expected: map[string]string{"build": "1234"},
+		},
+		{
+			// Annotations are only read into properties when the mod is enabled
+			name:     "no mods",
+			expected: map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := NewSPDX23().Unserialize(strings.NewReader(spdxJSON), &native.UnserializeOptions{Mods: tc.mods}, nil)
+			require.NoError(t, err)
+			require.Equal(t, "Generated in CI", doc.Metadata.Comment)
+			require.Equal(t, "Reviewed by the release team", doc.Metadata.CreatorComment)
+			require.Len(t, doc.Metadata.Properties, len(tc.expected))
+			for name, data := range tc.expected {
+				require.Equal(t, data, doc.Metadata.GetProperty(name).Data)
+			}
+		})
+	}
+}
+
+func TestUnserializeSecurityAdvisories(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// refs has the advisory locators of each package
+		refs    map[string][]string
+		vulns   map[string][]string
+		nodeRef map[string]int
+	}{
+		{
+			name: "advisory shared by packages",
+			refs: map[string][]string{
+				"log4j":     {"https://nvd.nist.gov/vuln/detail/CVE-2021-44228", "https://github.com/advisories/GHSA-jfh8-c2jp-5v3q"},
+				"log4j-api": {"https://nvd.nist.gov/vuln/detail/CVE-2021-44228"},
+			},
+			vulns: map[string][]string{
+				"CVE-2021-44228":      {"log4j", "log4j-api"},
+				"GHSA-jfh8-c2jp-5v3q": {"log4j"},
+			},
+			// The references are preserved in the nodes
+			nodeRef: map[string]int{"log4j": 2, "log4j-api": 1},
+		},
+		{
+			name:    "no advisories",
+			refs:    map[string][]string{"log4j": {}},
+			vulns:   map[string][]string{},
+			nodeRef: map[string]int{"log4j": 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			packages := []string{}
+			for _, id := range slices.Sorted(maps.Keys(tc.refs)) {
+				refs := []string{}
+				for _, url := range tc.refs[id] {
+					refs = append(refs, fmt.Sprintf(
+						`{"referenceCategory": "SECURITY", "referenceType": "advisory", "referenceLocator": %q}`, url,
+					))
+				}
+				packages = append(packages, fmt.Sprintf(
+					`{"SPDXID": "SPDXRef-%s", "name": %q, "downloadLocation": "NOASSERTION", "externalRefs": [%s]}`,
+					id, id, strings.Join(refs, ","),
+				))
+			}
+			spdxJSON := `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "test",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"creators": ["Tool: protobom"], "created": "2024-01-01T00:00:00Z"},
+  "packages": [` + strings.Join(packages, ",") + `]
+}`
+			doc, err := NewSPDX23().Unserialize(strings.NewReader(spdxJSON), &native.UnserializeOptions{}, nil)
+			require.NoError(t, err)
+			require.Len(t, doc.Vulnerabilities, len(tc.vulns))
+			for id, affects := range tc.vulns {
+				v := doc.GetVulnerability(id)
+				require.NotNil(t, v)
+				require.Equal(t, affects, v.Affects)
+				require.Len(t, v.Advisories, 1)
+			}
+			for id, n := range tc.nodeRef {
+				require.Len(t, doc.NodeList.GetNodeByID(id).ExternalReferences, n)
+			}
+		})
+	}
+}
+
+func TestUnserializeTagValue(t *testing.T) {
+	spdxTV := `SPDXVersion: SPDX-2.2
+DataLicense: CC0-1.0
+SPDXID: SPDXRef-DOCUMENT
+DocumentName: test
+DocumentNamespace: https://example.com/test
+Creator: Tool: legacy-scanner
+Created: 2021-06-01T00:00:00Z
+
+PackageName: app
+SPDXID: SPDXRef-Package-app
+PackageVersion: 1.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: NOASSERTION
+PackageLicenseDeclared: MIT
+PackageCopyrightText: NOASSERTION
+ExternalRef: PACKAGE-MANAGER purl pkg:golang/example.com/app@1.0
+
+PackageName: lib
+SPDXID: SPDXRef-Package-lib
+PackageVersion: 2.0
+PackageDownloadLocation: NOASSERTION
+FilesAnalyzed: false
+PackageLicenseConcluded: NOASSERTION
+PackageLicenseDeclared: NOASSERTION
+PackageCopyrightText: NOASSERTION
+
+Relationship: SPDXRef-DOCUMENT DESCRIBES SPDXRef-Package-app
+Relationship: SPDXRef-Package-app DEPENDS_ON SPDXRef-Package-lib
+`
+	for _, tc := range []struct {
+		name         string
+		unserializer native.Unserializer
+		mustErr      bool
+	}{
+		{name: "tag value", unserializer: NewSPDX23TV()},
+		// JSON unserializers don't parse tag-value
+		{name: "json", unserializer: NewSPDX23(), mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := tc.unserializer.Unserialize(strings.NewReader(spdxTV), &native.UnserializeOptions{}, nil)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "https://example.com/test#DOCUMENT", doc.Metadata.Id)
+			require.Len(t, doc.NodeList.Nodes, 2)
+			// As in JSON, the SPDXRef- prefix is trimmed from the element IDs
+			require.Equal(t, []string{"Package-app"}, doc.NodeList.RootElements)
+			app := doc.NodeList.GetNodeByID("Package-app")
+			require.NotNil(t, app)
+			require.Equal(t, "1.0", app.Version)
+			require.Equal(t, []string{"MIT"}, app.Licenses)
+			require.Equal(t, "pkg:golang/example.com/app@1.0", app.Identifiers[int32(sbom.SoftwareIdentifierType_PURL)])
+			require.Len(t, doc.NodeList.Edges, 1)
+			require.Equal(t, sbom.Edge_dependsOn, doc.NodeList.Edges[0].Type)
+		})
+	}
+}
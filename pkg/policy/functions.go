@@ -0,0 +1,116 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package policy
+
+import (
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var (
+	documentType = cel.ObjectType("protobom.protobom.Document")
+	nodeType     = cel.ObjectType("protobom.protobom.Node")
+)
+
+// newEnv returns the CEL environment with the protobom types, the document
+// and node variables and the helper functions.
+func newEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Types(&sbom.Document{}, &sbom.Node{}),
+		cel.Variable("document", documentType),
+		cel.Variable("node", nodeType),
+
+		// purl(node) returns the package URL of the node
+		cel.Function("purl",
+			cel.Overload("purl_node", []*cel.Type{nodeType}, cel.StringType,
+				cel.UnaryBinding(func(v ref.Val) ref.Val {
+					n, ok := v.Value().(*sbom.Node)
+					if !ok {
+						return types.NewErr("purl: argument is not a node")
+					}
+					return types.String(n.Purl())
+				}),
+			),
+		),
+
+		// purlType(node) returns the type of the node's package URL
+		cel.Function("purlType",
+			cel.Overload("purlType_node", []*cel.Type{nodeType}, cel.StringType,
+				cel.UnaryBinding(func(v ref.Val) ref.Val {
+					n, ok := v.Value().(*sbom.Node)
+					if !ok {
+						return types.NewErr("purlType: argument is not a node")
+					}
+					return types.String(purlType(string(n.Purl())))
+				}),
+			),
+		),
+
+		// hasHash(node, algorithm) returns true if the node has a hash of
+		// the specified algorithm (eg "SHA256")
+		cel.Function("hasHash",
+			cel.Overload("hasHash_node_string", []*cel.Type{nodeType, cel.StringType}, cel.BoolType,
+				cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+					n, ok := lhs.Value().(*sbom.Node)
+					if !ok {
+						return types.NewErr("hasHash: argument is not a node")
+					}
+					algo, ok := rhs.Value().(string)
+					if !ok {
+						return types.NewErr("hasHash: algorithm is not a string")
+					}
+					return types.Bool(nodeHash(n, algo) != "")
+				}),
+			),
+		),
+
+		// hash(node, algorithm) returns the node's hash value of the specified
+		// algorithm or an empty string if not found
+		cel.Function("hash",
+			cel.Overload("hash_node_string", []*cel.Type{nodeType, cel.StringType}, cel.StringType,
+				cel.BinaryBinding(func(lhs, rhs ref.Val) ref.Val {
+					n, ok := lhs.Value().(*sbom.Node)
+					if !ok {
+						return types.NewErr("hash: argument is not a node")
+					}
+					algo, ok := rhs.Value().(string)
+					if !ok {
+						return types.NewErr("hash: algorithm is not a string")
+					}
+					return types.String(nodeHash(n, algo))
+				}),
+			),
+		),
+	)
+}
+
+// purlType returns the type segment of a package URL string
+func purlType(purl string) string {
+	rest, ok := strings.CutPrefix(purl, "pkg:")
+	if !ok {
+		return ""
+	}
+	t, _, _ := strings.Cut(strings.TrimLeft(rest, "/"), "/")
+	return strings.ToLower(t)
+}
+
+// nodeHash returns the node hash of the named algorithm. The algorithm name
+// is matched case insensitively, accepting dashes (eg "sha-256", "sha3-256").
+func nodeHash(n *sbom.Node, algo string) string {
+	algo = strings.ToUpper(algo)
+	for _, name := range []string{algo, strings.ReplaceAll(algo, "-", "_"), strings.ReplaceAll(algo, "-", "")} {
+		if v, ok := sbom.HashAlgorithm_value[name]; ok {
+			return n.GetHashes()[v]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,206 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package policy evaluates policies written in the Common Expression Language
+// (CEL) over protobom documents.
+//
+// Policies are evaluated with the document exposed as typed variables:
+// `document` holds the whole sbom.Document and, for node-scoped policies,
+// `node` holds the node being evaluated. A policy requiring all OCI images
+// to have a SHA256 hash looks like this:
+//
+//	&policy.Policy{
+//		Name:      "oci-sha256",
+//		Match:     `purlType(node) == "oci"`,
+//		Condition: `hasHash(node, "SHA256")`,
+//	}
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Scope defines what a policy is evaluated against
+type Scope string
+
+const (
+	// ScopeNode policies are evaluated once for each node in the document
+	ScopeNode Scope = "node"
+
+	// ScopeDocument policies are evaluated once for the whole document
+	ScopeDocument Scope = "document"
+)
+
+// Policy is a rule expressed in CEL that documents must comply with.
+type Policy struct {
+	// Name identifies the policy in the violations
+	Name string
+
+	// Description is a human-readable explanation of the policy. It is
+	// used as the violation message.
+	Description string
+
+	// Scope controls if the policy is evaluated for each node or once for
+	// the document. Defaults to ScopeNode.
+	Scope Scope
+
+	// Match is an optional CEL expression that selects the nodes the
+	// policy applies to. It is ignored in document-scoped policies.
+	Match string
+
+	// Condition is the CEL expression that must evaluate to true for the
+	// document or node to comply with the policy.
+	Condition string
+}
+
+// Violation records a failed policy evaluation.
+type Violation struct {
+	// Policy is the name of the violated policy
+	Policy string
+
+	// NodeID is the identifier of the non-compliant node. It is empty
+	// for document-scoped policies.
+	NodeID string
+
+	// Message describes the violation
+	Message string
+}
+
+// Evaluator holds a set of compiled policies ready to be evaluated.
+type Evaluator struct {
+	policies []*compiledPolicy
+}
+
+type compiledPolicy struct {
+	*Policy
+	match     cel.Program
+	condition cel.Program
+}
+
+// NewEvaluator compiles the policies and returns an evaluator. It returns
+// an error if any of the expressions does not compile or does not evaluate
+// to a boolean.
+func NewEvaluator(policies ...*Policy) (*Evaluator, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL environment: %w", err)
+	}
+
+	e := &Evaluator{policies: []*compiledPolicy{}}
+	for i, p := range policies {
+		if p == nil {
+			return nil, fmt.Errorf("policy #%d is nil", i)
+		}
+		if p.Condition == "" {
+			return nil, fmt.Errorf("policy %q has no condition", p.Name)
+		}
+		switch p.Scope {
+		case "", ScopeNode, ScopeDocument:
+		default:
+			return nil, fmt.Errorf("policy %q has an unknown scope %q", p.Name, p.Scope)
+		}
+
+		cp := &compiledPolicy{Policy: p}
+		if cp.condition, err = compile(env, p.Condition); err != nil {
+			return nil, fmt.Errorf("compiling condition of policy %q: %w", p.Name, err)
+		}
+		if p.Match != "" && p.Scope != ScopeDocument {
+			if cp.match, err = compile(env, p.Match); err != nil {
+				return nil, fmt.Errorf("compiling match of policy %q: %w", p.Name, err)
+			}
+		}
+		e.policies = append(e.policies, cp)
+	}
+	return e, nil
+}
+
+// compile parses and checks a CEL expression, ensuring it returns a boolean
+func compile(env *cel.Env, expr string) (cel.Program, error) {
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("expression must return a bool, got %s", ast.OutputType())
+	}
+	return env.Program(ast)
+}
+
+// Evaluate runs all the policies against the document and returns the
+// violations found. An error is returned if an expression fails to evaluate.
+func (e *Evaluator) Evaluate(doc *sbom.Document) ([]*Violation, error) {
+	if doc == nil {
+		return nil, errors.New("document is nil")
+	}
+
+	violations := []*Violation{}
+	for _, p := range e.policies {
+		if p.Scope == ScopeDocument {
+			ok, err := eval(p.condition, doc, &sbom.Node{})
+			if err != nil {
+				return nil, fmt.Errorf("evaluating policy %q: %w", p.Name, err)
+			}
+			if !ok {
+				violations = append(violations, p.violation(""))
+			}
+			continue
+		}
+
+		for _, n := range doc.GetNodeList().GetNodes() {
+			if p.match != nil {
+				matches, err := eval(p.match, doc, n)
+				if err != nil {
+					return nil, fmt.Errorf("evaluating policy %q match on node %q: %w", p.Name, n.Id, err)
+				}
+				if !matches {
+					continue
+				}
+			}
+
+			ok, err := eval(p.condition, doc, n)
+			if err != nil {
+				return nil, fmt.Errorf("evaluating policy %q on node %q: %w", p.Name, n.Id, err)
+			}
+			if !ok {
+				violations = append(violations, p.violation(n.Id))
+			}
+		}
+	}
+	return violations, nil
+}
+
+func (p *compiledPolicy) violation(nodeID string) *Violation {
+	msg := p.Description
+	if msg == "" {
+		msg = fmt.Sprintf("condition not met: %s", p.Condition)
+	}
+	return &Violation{
+		Policy:  p.Name,
+		NodeID:  nodeID,
+		Message: msg,
+	}
+}
+
+// eval runs a compiled expression binding the document and node variables
+func eval(prg cel.Program, doc *sbom.Document, n *sbom.Node) (bool, error) {
+	out, _, err := prg.Eval(map[string]any{
+		"document": doc,
+		"node":     n,
+	})
+	if err != nil {
+		return false, err
+	}
+	res, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression returned %T, expected bool", out.Value())
+	}
+	return res, nil
+}
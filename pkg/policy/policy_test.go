@@ -0,0 +1,119 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func testDocument() *sbom.Document {
+	return &sbom.Document{
+		Metadata: &sbom.Metadata{Id: "doc", Name: "test"},
+		NodeList: &sbom.NodeList{
+			Nodes: []*sbom.Node{
+				{
+					Id: "image1", Name: "image1",
+					Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:oci/image1@sha256%3Aabc"},
+					Hashes:      map[int32]string{int32(sbom.HashAlgorithm_SHA256): "abc"},
+				},
+				{
+					Id: "image2", Name: "image2",
+					Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:oci/image2"},
+				},
+				{
+					Id: "lib", Name: "lib", Licenses: []string{"MIT"},
+					Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:npm/lib@1.0.0"},
+				},
+			},
+		},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy   *Policy
+		expected []*Violation
+	}{
+		"oci images must have sha256": {
+			policy: &Policy{
+				Name:        "oci-sha256",
+				Description: "OCI images must have a SHA256 hash",
+				Match:       `purlType(node) == "oci"`,
+				Condition:   `hasHash(node, "sha-256")`,
+			},
+			expected: []*Violation{
+				{Policy: "oci-sha256", NodeID: "image2", Message: "OCI images must have a SHA256 hash"},
+			},
+		},
+		"all nodes licensed": {
+			policy: &Policy{Name: "licensed", Condition: `size(node.licenses) > 0`},
+			expected: []*Violation{
+				{Policy: "licensed", NodeID: "image1", Message: "condition not met: size(node.licenses) > 0"},
+				{Policy: "licensed", NodeID: "image2", Message: "condition not met: size(node.licenses) > 0"},
+			},
+		},
+		"document scope pass": {
+			policy: &Policy{
+				Name: "has-name", Scope: ScopeDocument,
+				Condition: `document.metadata.name != ""`,
+			},
+			expected: []*Violation{},
+		},
+		"document scope fail": {
+			policy: &Policy{
+				Name: "npm-free", Scope: ScopeDocument, Description: "no npm packages",
+				Condition: `!document.node_list.nodes.exists(n, purlType(n) == "npm")`,
+			},
+			expected: []*Violation{{Policy: "npm-free", Message: "no npm packages"}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e, err := NewEvaluator(tc.policy)
+			require.NoError(t, err)
+			violations, err := e.Evaluate(testDocument())
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, violations)
+		})
+	}
+}
+
+func TestNewEvaluatorErrors(t *testing.T) {
+	for name, p := range map[string]*Policy{
+		"no condition":  {Name: "p"},
+		"syntax error":  {Name: "p", Condition: "node.name =="},
+		"not a bool":    {Name: "p", Condition: "node.name"},
+		"unknown field": {Name: "p", Condition: `node.color == "blue"`},
+		"bad scope":     {Name: "p", Condition: "true", Scope: "cluster"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewEvaluator(p)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPurlType(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		purl     string
+		expected string
+	}{
+		{"with version", "pkg:oci/image@sha256%3Aabc", "oci"},
+		{"uppercase type", "pkg:NPM/lib@1.0", "npm"},
+		{"slashes after scheme", "pkg://golang/x", "golang"},
+		{"empty", "", ""},
+		{"not a purl", "https://example.com", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, purlType(tc.purl))
+		})
+	}
+}
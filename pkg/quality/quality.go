@@ -0,0 +1,318 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package quality scores the quality of SBOMs. The score measures the
+// completeness of the document and component fields, the coverage of
+// identifiers to look up vulnerabilities, the density of the relationships
+// and the validity of the data.
+//
+// Scores range from 0 to 10. Each check scores a feature of the document,
+// checks are grouped in the categories used by sbomqs, so scores can be
+// compared with the ones computed by that tool.
+package quality
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/license"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// MaxScore is the score of a check fully satisfied
+const MaxScore = 10.0
+
+// Category groups the quality checks
+type Category string
+
+const (
+	CategoryNTIA       Category = "NTIA-minimum-elements"
+	CategoryStructural Category = "Structural"
+	CategorySemantic   Category = "Semantic"
+	CategoryQuality    Category = "Quality"
+)
+
+// Categories is the list of categories in the order they are reported
+var Categories = []Category{CategoryNTIA, CategoryStructural, CategorySemantic, CategoryQuality}
+
+// CheckResult is the score of a single feature of the document
+type CheckResult struct {
+	// Feature names the check, using the sbomqs feature names where one
+	// exists, eg "comp_with_version".
+	Feature  string
+	Category Category
+	Score    float64
+
+	// Description explains the score, eg "8/10 components have a version"
+	Description string
+}
+
+// Report is the quality score of a document
+type Report struct {
+	// Score is the average score of all checks
+	Score float64
+
+	// Categories has the average score of the checks in each category
+	Categories map[Category]float64
+
+	// Checks lists the results of the individual checks
+	Checks []*CheckResult
+
+	// LicenseListVersion is the SPDX license list version used to validate
+	// the component licenses.
+	LicenseListVersion string
+}
+
+// Check returns the result of a check by feature name, nil if not found
+func (r *Report) Check(feature string) *CheckResult {
+	for _, c := range r.Checks {
+		if c.Feature == feature {
+			return c
+		}
+	}
+	return nil
+}
+
+// Grade returns the letter grade of the score as assigned by sbomqs
+func (r *Report) Grade() string {
+	switch {
+	case r.Score >= 9:
+		return "A"
+	case r.Score >= 8:
+		return "B"
+	case r.Score >= 7:
+		return "C"
+	case r.Score >= 5:
+		return "D"
+	default:
+		return "F"
+	}
+}
+
+// componentCheck scores the share of components satisfying a condition
+type componentCheck struct {
+	feature  string
+	category Category
+	what     string
+	ok       func(*sbom.Node) bool
+}
+
+// Score computes the quality report of a document
+func Score(doc *sbom.Document) *Report {
+	list := license.Current()
+	report := &Report{
+		Categories:         map[Category]float64{},
+		LicenseListVersion: list.Version,
+	}
+
+	nodes := components(doc.GetNodeList())
+
+	validLicenses := func(n *sbom.Node) bool {
+		exprs := nodeLicenses(n)
+		if len(exprs) == 0 {
+			return false
+		}
+		for _, e := range exprs {
+			if !list.Validate(e).Valid() {
+				return false
+			}
+		}
+		return true
+	}
+	noDeprecatedLicenses := func(n *sbom.Node) bool {
+		for _, e := range nodeLicenses(n) {
+			if len(list.Validate(e).Deprecated) > 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	ids := uniqueIDs(nodes)
+	related := relatedNodes(doc.GetNodeList())
+	issues := map[string]struct{}{}
+	docIssues := 0
+	for _, i := range doc.Validate(nil) {
+		if i.NodeID == "" {
+			docIssues++
+			continue
+		}
+		issues[i.NodeID] = struct{}{}
+	}
+
+	for _, c := range []componentCheck{
+		{"comp_with_name", CategoryNTIA, "have a name", func(n *sbom.Node) bool { return n.GetName() != "" }},
+		{"comp_with_version", CategoryNTIA, "have a version", func(n *sbom.Node) bool { return n.GetVersion() != "" }},
+		{"comp_with_supplier", CategoryNTIA, "have a supplier", func(n *sbom.Node) bool { return len(n.GetSuppliers()) > 0 }},
+		{"comp_with_uniq_ids", CategoryNTIA, "have a unique identifier", func(n *sbom.Node) bool { return ids[n.GetId()] }},
+		{"comp_with_licenses", CategorySemantic, "have licenses", func(n *sbom.Node) bool { return len(nodeLicenses(n)) > 0 }},
+		{"comp_with_checksums", CategorySemantic, "have checksums", func(n *sbom.Node) bool { return len(n.GetHashes()) > 0 }},
+		{"comp_with_relationships", CategorySemantic, "are related to other elements", func(n *sbom.Node) bool {
+			_, ok := related[n.GetId()]
+			return ok
+		}},
+		{"comp_valid_licenses", CategoryQuality, "have valid SPDX licenses", validLicenses},
+		{"comp_no_deprecated_licenses", CategoryQuality, "have no deprecated licenses", noDeprecatedLicenses},
+		{"comp_with_primary_purpose", CategoryQuality, "have a primary purpose", func(n *sbom.Node) bool { return len(n.GetPrimaryPurpose()) > 0 }},
+		{"comp_with_any_vuln_lookup_id", CategoryQuality, "have a purl or CPE", func(n *sbom.Node) bool {
+			return n.Purl() != "" || cpe(n) != ""
+		}},
+		{"comp_with_multi_vuln_lookup_id", CategoryQuality, "have a purl and a CPE", func(n *sbom.Node) bool {
+			return n.Purl() != "" && cpe(n) != ""
+		}},
+		{"comp_valid", CategoryStructural, "pass validation", func(n *sbom.Node) bool {
+			_, ok := issues[n.GetId()]
+			return !ok
+		}},
+	} {
+		report.Checks = append(report.Checks, scoreComponents(c, nodes))
+	}
+
+	md := doc.GetMetadata()
+	report.Checks = append(report.Checks,
+		boolCheck("sbom_authors", CategoryNTIA, len(md.GetAuthors()) > 0, "the document has authors"),
+		boolCheck("sbom_creation_timestamp", CategoryNTIA, md.GetDate() != nil, "the document has a creation timestamp"),
+		boolCheck("sbom_dependencies", CategoryNTIA, hasRootDependencies(doc.GetNodeList()), "the primary components declare their relationships"),
+		boolCheck("sbom_primary_component", CategoryStructural, len(doc.GetRootNodes()) > 0, "the document has a primary component"),
+		boolCheck("sbom_tool", CategoryStructural, len(md.GetTools()) > 0, "the document lists the tools that generated it"),
+		boolCheck("sbom_valid", CategoryStructural, docIssues == 0, "the document metadata passes validation"),
+	)
+
+	total := 0.0
+	for _, cat := range Categories {
+		sum, count := 0.0, 0
+		for _, c := range report.Checks {
+			if c.Category == cat {
+				sum += c.Score
+				count++
+			}
+		}
+		if count > 0 {
+			report.Categories[cat] = round(sum / float64(count))
+		}
+	}
+	for _, c := range report.Checks {
+		total += c.Score
+	}
+	report.Score = round(total / float64(len(report.Checks)))
+	return report
+}
+
+// scoreComponents scores the share of components passing a check
+func scoreComponents(c componentCheck, nodes []*sbom.Node) *CheckResult {
+	res := &CheckResult{Feature: c.feature, Category: c.category}
+	if len(nodes) == 0 {
+		res.Description = "the document has no components"
+		return res
+	}
+	passed := 0
+	for _, n := range nodes {
+		if c.ok(n) {
+			passed++
+		}
+	}
+	res.Score = round(MaxScore * float64(passed) / float64(len(nodes)))
+	res.Description = fmt.Sprintf("%d/%d components %s", passed, len(nodes), c.what)
+	return res
+}
+
+// boolCheck scores a document-level feature
+func boolCheck(feature string, category Category, ok bool, description string) *CheckResult {
+	res := &CheckResult{Feature: feature, Category: category, Description: description}
+	if ok {
+		res.Score = MaxScore
+	} else {
+		res.Description = "not " + description
+	}
+	return res
+}
+
+// components returns the package nodes scored as components
+func components(nl *sbom.NodeList) []*sbom.Node {
+	ret := []*sbom.Node{}
+	for _, n := range nl.GetNodes() {
+		if n.GetType() == sbom.Node_PACKAGE {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// nodeLicenses returns the license expressions of a node
+func nodeLicenses(n *sbom.Node) []string {
+	ret := []string{}
+	for _, l := range append(slices.Clone(n.GetLicenses()), n.GetLicenseConcluded()) {
+		if l = strings.TrimSpace(l); l != "" && l != "NOASSERTION" && l != "NONE" {
+			ret = append(ret, l)
+		}
+	}
+	return ret
+}
+
+// cpe returns the CPE of a node, either 2.3 or 2.2
+func cpe(n *sbom.Node) string {
+	if c := n.GetIdentifiers()[int32(sbom.SoftwareIdentifierType_CPE23)]; c != "" {
+		return c
+	}
+	return n.GetIdentifiers()[int32(sbom.SoftwareIdentifierType_CPE22)]
+}
+
+// uniqueIDs returns the components with a purl or CPE not shared with
+// another component.
+func uniqueIDs(nodes []*sbom.Node) map[string]bool {
+	counts := map[string]int{}
+	keys := map[string]string{}
+	for _, n := range nodes {
+		key := string(n.Purl())
+		if key == "" {
+			key = cpe(n)
+		}
+		if key == "" {
+			continue
+		}
+		keys[n.GetId()] = key
+		counts[key]++
+	}
+	ret := map[string]bool{}
+	for id, key := range keys {
+		ret[id] = counts[key] == 1
+	}
+	return ret
+}
+
+// relatedNodes returns the IDs of the nodes in at least one relationship
+func relatedNodes(nl *sbom.NodeList) map[string]struct{} {
+	ret := map[string]struct{}{}
+	for _, e := range nl.GetEdges() {
+		if len(e.GetTo()) == 0 {
+			continue
+		}
+		ret[e.GetFrom()] = struct{}{}
+		for _, to := range e.GetTo() {
+			ret[to] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// hasRootDependencies returns true if any root element has relationships
+func hasRootDependencies(nl *sbom.NodeList) bool {
+	for _, id := range nl.GetRootElements() {
+		for _, e := range nl.GetEdges() {
+			if e.GetFrom() == id && len(e.GetTo()) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// round rounds a score to two decimals
+func round(f float64) float64 {
+	return math.Round(f*100) / 100
+}
@@ -0,0 +1,135 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package quality
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestScore(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		mutate func(doc *sbom.Document)
+		// complete expects the maximum score in every check
+		complete     bool
+		scores       map[string]float64
+		descriptions map[string]string
+		categories   map[Category]float64
+		below        []Category
+		grade        string
+	}{
+		{
+			name:     "complete",
+			complete: true,
+			grade:    "A",
+		},
+		{
+			name: "partial",
+			mutate: func(doc *sbom.Document) {
+				lib := doc.NodeList.GetNodeByID("lib")
+				lib.Version = ""
+				lib.Licenses = []string{"GPL-2.0+ OR Made-Up-1.0"}
+				lib.Identifiers = map[int32]string{}
+				doc.Metadata.Authors = nil
+			},
+			scores: map[string]float64{
+				"comp_with_name":                 10,
+				"comp_with_version":              5,
+				"comp_with_uniq_ids":             5,
+				"comp_with_licenses":             10,
+				"comp_valid_licenses":            5,
+				"comp_no_deprecated_licenses":    5,
+				"comp_with_any_vuln_lookup_id":   5,
+				"comp_with_multi_vuln_lookup_id": 5,
+				"sbom_authors":                   0,
+				"sbom_dependencies":              10,
+			},
+			descriptions: map[string]string{"comp_with_version": "1/2 components have a version"},
+			categories:   map[Category]float64{CategoryStructural: MaxScore},
+			below:        []Category{CategoryNTIA},
+			grade:        "C",
+		},
+		{
+			name: "duplicate identifiers",
+			mutate: func(doc *sbom.Document) {
+				doc.NodeList.GetNodeByID("lib").Identifiers = doc.NodeList.GetNodeByID("app").Identifiers
+			},
+			scores: map[string]float64{"comp_with_uniq_ids": 0},
+			grade:  "A",
+		},
+		{
+			name: "empty",
+			mutate: func(doc *sbom.Document) {
+				doc.Metadata = sbom.NewDocument().Metadata
+				doc.NodeList = sbom.NewNodeList()
+			},
+			scores: map[string]float64{"comp_with_name": 0},
+			grade:  "F",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Date = timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			doc.Metadata.Authors = []*sbom.Person{{Name: "ACME"}}
+			doc.Metadata.Tools = []*sbom.Tool{{Name: "protobom"}}
+			for _, name := range []string{"app", "lib"} {
+				doc.NodeList.AddNode(&sbom.Node{
+					Id:             name,
+					Type:           sbom.Node_PACKAGE,
+					Name:           name,
+					Version:        "1.0.0",
+					Suppliers:      []*sbom.Person{{Name: "ACME"}},
+					Licenses:       []string{"Apache-2.0"},
+					PrimaryPurpose: []sbom.Purpose{sbom.Purpose_LIBRARY},
+					Hashes: map[int32]string{
+						int32(sbom.HashAlgorithm_SHA256): "a948904f2f0f479b8f8197694b30184b0d2ed1c1cd2a1ec0fb85d299a192a447",
+					},
+					Identifiers: map[int32]string{
+						int32(sbom.SoftwareIdentifierType_PURL):  "pkg:generic/" + name + "@1.0.0",
+						int32(sbom.SoftwareIdentifierType_CPE23): "cpe:2.3:a:acme:" + name + ":1.0.0:*:*:*:*:*:*:*",
+					},
+				})
+			}
+			doc.NodeList.RootElements = []string{"app"}
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+			if tc.mutate != nil {
+				tc.mutate(doc)
+			}
+
+			r := Score(doc)
+			require.Len(t, r.Categories, len(Categories))
+			require.NotEmpty(t, r.LicenseListVersion)
+			require.Equal(t, tc.grade, r.Grade())
+			if tc.complete {
+				for _, c := range r.Checks {
+					require.Equal(t, MaxScore, c.Score, "%s: %s", c.Feature, c.Description)
+				}
+				require.Equal(t, MaxScore, r.Score)
+				return
+			}
+			require.Less(t, r.Score, MaxScore)
+			for feature, score := range tc.scores {
+				require.Equal(t, score, r.Check(feature).Score, feature)
+			}
+			for feature, description := range tc.descriptions {
+				require.Equal(t, description, r.Check(feature).Description, feature)
+			}
+			for category, score := range tc.categories {
+				require.Equal(t, score, r.Categories[category], category)
+			}
+			for _, category := range tc.below {
+				require.Less(t, r.Categories[category], MaxScore, category)
+			}
+		})
+	}
+}
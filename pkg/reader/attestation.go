@@ -0,0 +1,265 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// PayloadTypeInToto is the DSSE payload type of in-toto statements
+const PayloadTypeInToto = "application/vnd.in-toto+json"
+
+// inTotoStatementPrefix is the prefix of the in-toto statement types
+const inTotoStatementPrefix = "https://in-toto.io/Statement/"
+
+// predicateTypeCosignCustom is the predicate type used by cosign for
+// attestations of unknown types
+const predicateTypeCosignCustom = "https://cosign.sigstore.dev/attestation/v1"
+
+// unwrapSniffSize is the size of the prefix of the stream inspected to tell
+// if it is an attestation or an API response before buffering it
+const unwrapSniffSize = 4096
+
+// wrapperKeys are the keys of the envelopes, statements and API responses
+// expected at the start of the wrapping documents
+var wrapperKeys = [][]byte{
+	[]byte(`"payloadType"`), []byte(`"payload"`), []byte(`"_type"`), []byte(`"sbom"`),
+}
+
+// Envelope is a DSSE envelope, as produced by cosign attest
+type Envelope struct {
+	PayloadType string              `json:"payloadType"`
+	Payload     []byte              `json:"payload"`
+	Signatures  []EnvelopeSignature `json:"signatures"`
+}
+
+// EnvelopeSignature is a signature of a DSSE envelope
+type EnvelopeSignature struct {
+	KeyID string `json:"keyid"`
+	Sig   []byte `json:"sig"`
+}
+
+// Statement is an in-toto attestation statement
+type Statement struct {
+	Type          string             `json:"_type"`
+	PredicateType string             `json:"predicateType"`
+	Subject       []StatementSubject `json:"subject"`
+	Predicate     json.RawMessage    `json:"predicate"`
+}
+
+// StatementSubject is an artifact described by an in-toto statement
+type StatementSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// EnvelopeVerifier checks the signatures of DSSE envelopes. Verify is
+// called with the pre-authentication encoding of the envelope payload and
+// one of its signatures.
+type EnvelopeVerifier interface {
+	Verify(data, signature []byte) error
+}
+
+// publicKeyVerifier verifies signatures with a public key
+type publicKeyVerifier struct {
+	key crypto.PublicKey
+}
+
+// NewPublicKeyVerifier returns an EnvelopeVerifier checking signatures
+// with an ECDSA, Ed25519 or RSA public key. ECDSA and RSA (PKCS #1 v1.5)
+// signatures are expected over the SHA-256 digest of the data.
+func NewPublicKeyVerifier(key crypto.PublicKey) (EnvelopeVerifier, error) {
+	switch key.(type) {
+	case *ecdsa.PublicKey, ed25519.PublicKey, *rsa.PublicKey:
+		return &publicKeyVerifier{key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// Verify checks the signature of data
+func (v *publicKeyVerifier) Verify(data, signature []byte) error {
+	digest := sha256.Sum256(data)
+	switch key := v.key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid ECDSA signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid Ed25519 signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// PAE returns the DSSE pre-authentication encoding of a payload, the data
+// covered by the envelope signatures.
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// Verify checks that at least one of the envelope signatures is valid
+func (e *Envelope) Verify(v EnvelopeVerifier) error {
+	if len(e.Signatures) == 0 {
+		return errors.New("envelope has no signatures")
+	}
+	data := PAE(e.PayloadType, e.Payload)
+	var errs []error
+	for _, s := range e.Signatures {
+		err := v.Verify(data, s.Sig)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("verifying envelope signatures: %w", errors.Join(errs...))
+}
+
+// attestationProbe captures the top level fields that identify envelopes
+// and statements.
+type attestationProbe struct {
+	PayloadType string `json:"payloadType"`
+	Payload     []byte `json:"payload"`
+	Type        string `json:"_type"`
+}
+
+// UnwrapAttestation returns the SBOM in a DSSE envelope or in-toto
+// statement. If the data is neither, it returns false. When a verifier is
+// passed, the data must be a signed envelope and its signature is checked
+// before unwrapping it.
+func UnwrapAttestation(data []byte, verifier EnvelopeVerifier) ([]byte, bool, error) {
+	sbomData, ok, _, err := unwrapAttestation(data, verifier)
+	return sbomData, ok, err
+}
+
+// unwrapAttestation unwraps the attestation and also returns the records of
+// the envelope signatures.
+func unwrapAttestation(data []byte, verifier EnvelopeVerifier) ([]byte, bool, []*sbom.Signature, error) {
+	// Skip decoding documents that can't be attestations
+	if verifier == nil && !bytes.Contains(data, []byte(`"payloadType"`)) && !bytes.Contains(data, []byte(`"_type"`)) {
+		return nil, false, nil, nil
+	}
+
+	probe := &attestationProbe{}
+	if err := json.Unmarshal(data, probe); err != nil {
+		// Not JSON or not an object, it can't be an attestation
+		if verifier != nil {
+			return nil, false, nil, errors.New("document is not a signed envelope")
+		}
+		return nil, false, nil, nil //nolint:nilerr
+	}
+
+	if probe.PayloadType == "" {
+		if verifier != nil {
+			return nil, false, nil, errors.New("document is not a signed envelope")
+		}
+		if !strings.HasPrefix(probe.Type, inTotoStatementPrefix) {
+			return nil, false, nil, nil
+		}
+		predicate, err := statementPredicate(data)
+		return predicate, true, nil, err
+	}
+
+	envelope := &Envelope{}
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, true, nil, fmt.Errorf("parsing envelope: %w", err)
+	}
+	if verifier != nil {
+		if err := envelope.Verify(verifier); err != nil {
+			return nil, true, nil, err
+		}
+	}
+	signatures := envelopeSignatures(envelope, verifier)
+
+	if envelope.PayloadType != PayloadTypeInToto {
+		// The envelope may carry the SBOM directly
+		return envelope.Payload, true, signatures, nil
+	}
+	predicate, err := statementPredicate(envelope.Payload)
+	return predicate, true, signatures, err
+}
+
+// statementPredicate returns the predicate of an in-toto statement
+func statementPredicate(data []byte) ([]byte, error) {
+	statement := &Statement{}
+	if err := json.Unmarshal(data, statement); err != nil {
+		return nil, fmt.Errorf("parsing in-toto statement: %w", err)
+	}
+	if len(statement.Predicate) == 0 || string(statement.Predicate) == "null" {
+		return nil, fmt.Errorf("in-toto statement %q has no predicate", statement.PredicateType)
+	}
+
+	// Some tools embed the document as a JSON string, cosign does so in
+	// the Data field of its custom predicates.
+	var s string
+	if err := json.Unmarshal(statement.Predicate, &s); err == nil {
+		return []byte(s), nil
+	}
+	if statement.PredicateType == predicateTypeCosignCustom {
+		custom := struct {
+			Data string `json:"Data"`
+		}{}
+		if err := json.Unmarshal(statement.Predicate, &custom); err == nil && custom.Data != "" {
+			return []byte(custom.Data), nil
+		}
+	}
+	return statement.Predicate, nil
+}
+
+// unwrapStream reads an attestation or an API response from the stream and
+// returns the data read, the SBOM it carries and the signatures of the
+// envelope. Only a prefix of the stream is read to tell if it is wrapped,
+// the stream is buffered when it is, when a verifier is set or when buffer
+// is true. The returned data is nil when the stream was not buffered and the
+// returned SBOM is nil if the stream is a plain document.
+func unwrapStream(rs io.ReadSeeker, verifier EnvelopeVerifier, buffer bool) (data, sbomData []byte, signatures []*sbom.Signature, err error) {
+	if verifier == nil && !buffer {
+		prefix, err := io.ReadAll(io.LimitReader(rs, unwrapSniffSize))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("reading document: %w", err)
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, nil, fmt.Errorf("rewinding stream: %w", err)
+		}
+		if !slices.ContainsFunc(wrapperKeys, func(key []byte) bool { return bytes.Contains(prefix, key) }) {
+			return nil, nil, nil, nil
+		}
+	}
+
+	data, err = io.ReadAll(rs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("reading document: %w", err)
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return nil, nil, nil, fmt.Errorf("rewinding stream: %w", err)
+	}
+	sbomData, ok, signatures, err := unwrapAttestation(data, verifier)
+	if err != nil {
+		return data, nil, nil, err
+	}
+	if !ok {
+		// Documents from the GitHub SBOM API are wrapped too
+		sbomData, _ = unwrapGitHubSBOM(data)
+	}
+	return data, sbomData, signatures, nil
+}
@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const attestedSPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "attested",
+  "documentNamespace": "https://example.com/attested",
+  "creationInfo": {"created": "2024-01-01T00:00:00Z", "creators": ["Tool: test"]},
+  "packages": [{"SPDXID": "SPDXRef-Package-a", "name": "a", "versionInfo": "1.0", "downloadLocation": "NOASSERTION"}]
+}`
+
+func newStatement(t *testing.T, predicate any) []byte {
+	t.Helper()
+	data, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v0.1",
+		"predicateType": "https://spdx.dev/Document",
+		"subject":       []any{map[string]any{"name": "image", "digest": map[string]string{"sha256": "abc"}}},
+		"predicate":     predicate,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func newEnvelope(t *testing.T, payload []byte, sign func([]byte) []byte) []byte {
+	t.Helper()
+	env := &reader.Envelope{PayloadType: reader.PayloadTypeInToto, Payload: payload}
+	if sign != nil {
+		env.Signatures = []reader.EnvelopeSignature{{Sig: sign(reader.PAE(env.PayloadType, payload))}}
+	}
+	data, err := json.Marshal(env)
+	require.NoError(t, err)
+	return data
+}
+
+func parseAttestation(t *testing.T, data []byte, v reader.EnvelopeVerifier) (*sbom.Document, error) {
+	t.Helper()
+	return reader.New().ParseStreamWithOptions(bytes.NewReader(data), &reader.Options{
+		UnserializeOptions: &native.UnserializeOptions{TrackSource: true},
+		EnvelopeVerifier:   v,
+	})
+}
+
+func TestParseAttestation(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	signEd25519 := func(data []byte) []byte { return ed25519.Sign(priv, data) }
+	verifier, err := reader.NewPublicKeyVerifier(pub)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	signECDSA := func(data []byte) []byte {
+		digest := sha256.Sum256(data)
+		sig, err := ecdsa.SignASN1(rand.Reader, ecKey, digest[:])
+		require.NoError(t, err)
+		return sig
+	}
+	ecVerifier, err := reader.NewPublicKeyVerifier(&ecKey.PublicKey)
+	require.NoError(t, err)
+
+	statement := newStatement(t, json.RawMessage(attestedSPDX))
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		verifier reader.EnvelopeVerifier
+		mustErr  bool
+	}{
+		{"statement", statement, nil, false},
+		{"predicate-string", newStatement(t, attestedSPDX), nil, false},
+		{"cosign-custom", bytes.Replace(newStatement(t, map[string]string{"Data": attestedSPDX}),
+			[]byte("https://spdx.dev/Document"), []byte("https://cosign.sigstore.dev/attestation/v1"), 1), nil, false},
+		{"unsigned-envelope", newEnvelope(t, statement, nil), nil, false},
+		{"signed-ed25519", newEnvelope(t, statement, signEd25519), verifier, false},
+		{"signed-ecdsa", newEnvelope(t, statement, signECDSA), ecVerifier, false},
+		{"wrong-key", newEnvelope(t, statement, signECDSA), verifier, true},
+		{"unsigned-verified", newEnvelope(t, statement, nil), verifier, true},
+		{"plain-verified", []byte(attestedSPDX), verifier, true},
+		{"no-predicate", newStatement(t, nil), nil, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := parseAttestation(t, tc.data, tc.verifier)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "attested", doc.Metadata.Name)
+			require.Len(t, doc.NodeList.Nodes, 1)
+			// The source data describes the attestation
+			require.Equal(t, int64(len(tc.data)), doc.Metadata.SourceData.Size)
+		})
+	}
+
+	_, err = reader.NewPublicKeyVerifier("not a key")
+	require.Error(t, err)
+}
+
+func TestUnwrapAttestation(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    []byte
+		wrapped bool
+	}{
+		{name: "plain document", data: []byte(attestedSPDX)},
+		{name: "other type", data: []byte(`{"_type": "other"}`)},
+		{name: "statement", data: newStatement(t, json.RawMessage(attestedSPDX)), wrapped: true},
+		{name: "envelope", data: newEnvelope(t, newStatement(t, json.RawMessage(attestedSPDX)), nil), wrapped: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, ok, err := reader.UnwrapAttestation(tc.data, nil)
+			require.NoError(t, err)
+			require.Equal(t, tc.wrapped, ok)
+			if tc.wrapped {
+				require.JSONEq(t, attestedSPDX, string(data))
+			}
+		})
+	}
+}
+
+// countingReadSeeker counts the bytes read from the stream
+type countingReadSeeker struct {
+	*bytes.Reader
+	n int
+}
+
+func (c *countingReadSeeker) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	c.n += n
+	return n, err
+}
+
+func TestParseStreamBuffering(t *testing.T) {
+	// Pad the document well over the sniffed prefix
+	large := strings.Replace(attestedSPDX, `"name": "attested"`,
+		fmt.Sprintf(`"name": "attested", "comment": %q`, strings.Repeat("x", 64*1024)), 1)
+	statement := newStatement(t, json.RawMessage(large))
+
+	// Envelopes with the payload first
+	env := newEnvelope(t, statement, nil)
+	payloadFirst := map[string]any{}
+	require.NoError(t, json.Unmarshal(env, &payloadFirst))
+	payloadFirstEnv, err := json.Marshal(struct {
+		Payload     any `json:"payload"`
+		PayloadType any `json:"payloadType"`
+	}{payloadFirst["payload"], payloadFirst["payloadType"]})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name    string
+		data    []byte
+		options *reader.Options
+		passes  int
+	}{
+		{"plain document", []byte(large), &reader.Options{}, 1},
+		{"detached signature", []byte(large), &reader.Options{
+			DetachedSignature: &reader.DetachedSignature{Signature: []byte("sig")},
+		}, 2},
+		{"signature sidecar", []byte(large), &reader.Options{
+			Sidecars: []*reader.Sidecar{{Type: reader.SidecarSignature, Data: []byte("sig")}},
+		}, 2},
+		{"statement", statement, &reader.Options{}, 1},
+		{"envelope", env, &reader.Options{}, 1},
+		{"envelope with the payload first", payloadFirstEnv, &reader.Options{}, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := &countingReadSeeker{Reader: bytes.NewReader(tc.data)}
+			tc.options.UnserializeOptions = &native.UnserializeOptions{}
+			doc, err := reader.New().ParseStreamWithOptions(rs, tc.options)
+			require.NoError(t, err)
+			require.Equal(t, "attested", doc.Metadata.Name)
+			// Wrapped documents are parsed from the buffer after reading
+			// them once, plain ones are only buffered to check signatures
+			require.GreaterOrEqual(t, rs.n, tc.passes*len(tc.data))
+			require.Less(t, rs.n, (tc.passes+1)*len(tc.data))
+		})
+	}
+}
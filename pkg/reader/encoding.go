@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding identifies the character encoding of an SBOM stream
+type Encoding string
+
+const (
+	EncodingUTF8    Encoding = "UTF-8"
+	EncodingUTF8BOM Encoding = "UTF-8-BOM"
+	EncodingUTF16LE Encoding = "UTF-16LE"
+	EncodingUTF16BE Encoding = "UTF-16BE"
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// DetectEncoding reads the start of a stream to determine its encoding. The
+// stream is rewound before returning. Byte order marks are recognized and,
+// as SBOM documents always start with an ASCII character, unmarked UTF-16
+// is detected by the position of the null byte in the first code unit.
+func DetectEncoding(rs io.ReadSeeker) (Encoding, error) {
+	header := make([]byte, 3)
+	n, err := io.ReadFull(rs, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("reading stream header: %w", err)
+	}
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("rewinding stream: %w", err)
+	}
+
+	return detectEncoding(header[:n]), nil
+}
+
+// detectEncoding returns the encoding of data by looking at its first bytes
+func detectEncoding(data []byte) Encoding {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return EncodingUTF8BOM
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return EncodingUTF16LE
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return EncodingUTF16BE
+	case len(data) >= 2 && data[0] != 0 && data[0] < utf8.RuneSelf && data[1] == 0:
+		return EncodingUTF16LE
+	case len(data) >= 2 && data[0] == 0 && data[1] != 0 && data[1] < utf8.RuneSelf:
+		return EncodingUTF16BE
+	default:
+		return EncodingUTF8
+	}
+}
+
+// ToUTF8 converts data in the specified encoding to UTF-8, removing any
+// byte order mark.
+func ToUTF8(data []byte, encoding Encoding) ([]byte, error) {
+	switch encoding {
+	case EncodingUTF8:
+		return data, nil
+	case EncodingUTF8BOM:
+		return bytes.TrimPrefix(data, bomUTF8), nil
+	case EncodingUTF16LE, EncodingUTF16BE:
+		return utf16ToUTF8(data, encoding == EncodingUTF16BE)
+	default:
+		return nil, fmt.Errorf("unsupported encoding %q", encoding)
+	}
+}
+
+// utf16ToUTF8 decodes UTF-16 data into UTF-8
+func utf16ToUTF8(data []byte, bigEndian bool) ([]byte, error) {
+	if len(data)%2 != 0 {
+		return nil, errors.New("invalid UTF-16 data: odd number of bytes")
+	}
+
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+		}
+	}
+
+	// Drop the byte order mark, it is not part of the document
+	if len(units) > 0 && units[0] == 0xFEFF {
+		units = units[1:]
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(units))
+	for _, r := range utf16.Decode(units) {
+		buf.WriteRune(r)
+	}
+	return buf.Bytes(), nil
+}
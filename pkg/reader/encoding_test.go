@@ -0,0 +1,113 @@
+package reader_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+	"unicode/utf16"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/unserializers"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const encodingTestSPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "Überprüfung",
+  "documentNamespace": "https://example.com/test",
+  "creationInfo": {"creators": ["Tool: protobom"], "created": "2024-01-01T00:00:00Z"},
+  "packages": [
+    {"name": "日本語-pkg", "SPDXID": "SPDXRef-pkg", "downloadLocation": "NOASSERTION"}
+  ]
+}`
+
+// encodeUTF16 returns s encoded as UTF-16, optionally prefixed with a BOM
+func encodeUTF16(s string, bigEndian, bom bool) []byte {
+	units := utf16.Encode([]rune(s))
+	if bom {
+		units = append([]uint16{0xFEFF}, units...)
+	}
+	data := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		if bigEndian {
+			data = append(data, byte(u>>8), byte(u))
+		} else {
+			data = append(data, byte(u), byte(u>>8))
+		}
+	}
+	return data
+}
+
+func TestDetectEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		expected reader.Encoding
+	}{
+		{"utf8", []byte(encodingTestSPDX), reader.EncodingUTF8},
+		{"utf8-bom", append([]byte{0xEF, 0xBB, 0xBF}, encodingTestSPDX...), reader.EncodingUTF8BOM},
+		{"utf16le-bom", encodeUTF16(encodingTestSPDX, false, true), reader.EncodingUTF16LE},
+		{"utf16be-bom", encodeUTF16(encodingTestSPDX, true, true), reader.EncodingUTF16BE},
+		{"utf16le", encodeUTF16(encodingTestSPDX, false, false), reader.EncodingUTF16LE},
+		{"utf16be", encodeUTF16(encodingTestSPDX, true, false), reader.EncodingUTF16BE},
+		{"empty", []byte{}, reader.EncodingUTF8},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs := bytes.NewReader(tc.data)
+			enc, err := reader.DetectEncoding(rs)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, enc)
+
+			// The stream must be rewound
+			pos, err := rs.Seek(0, 1)
+			require.NoError(t, err)
+			require.Zero(t, pos)
+
+			converted, err := reader.ToUTF8(tc.data, enc)
+			require.NoError(t, err)
+			if len(tc.data) > 0 {
+				require.Equal(t, encodingTestSPDX, string(converted))
+			}
+		})
+	}
+
+	_, err := reader.ToUTF8([]byte{0xFF, 0xFE, 0x7B}, reader.EncodingUTF16LE)
+	require.Error(t, err)
+}
+
+func TestParseStreamEncodings(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"utf8-bom", append([]byte{0xEF, 0xBB, 0xBF}, encodingTestSPDX...)},
+		{"utf16le-bom", encodeUTF16(encodingTestSPDX, false, true)},
+		{"utf16be-bom", encodeUTF16(encodingTestSPDX, true, true)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := reader.New()
+			doc, err := r.ParseStreamWithOptions(bytes.NewReader(tc.data), &reader.Options{
+				UnserializeOptions: &native.UnserializeOptions{TrackSource: true},
+			})
+			require.NoError(t, err)
+			require.Equal(t, "Überprüfung", doc.Metadata.Name)
+			require.Len(t, doc.NodeList.Nodes, 1)
+			require.Equal(t, "日本語-pkg", doc.NodeList.Nodes[0].Name)
+
+			// Source data describes the original, unconverted bytes
+			require.Equal(t, int64(len(tc.data)), doc.Metadata.SourceData.Size)
+			require.Equal(t,
+				fmt.Sprintf("%x", sha256.Sum256(tc.data)),
+				doc.Metadata.SourceData.Hashes[int32(sbom.HashAlgorithm_SHA256)],
+			)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Fetcher downloads the documents referenced by the URIs of a scheme, such
+// as the tea:// references of the TEA client. ParseFile and Retrieve read
+// the URIs of the schemes with a registered fetcher through it and parse
+// the data with the reader options.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string) ([]byte, error)
+}
+
+var fetchers = map[string]Fetcher{}
+
+// RegisterFetcher registers the fetcher of the URIs of a scheme, replacing
+// any previously registered one.
+func RegisterFetcher(scheme string, f Fetcher) {
+	regMtx.Lock()
+	fetchers[scheme] = f
+	regMtx.Unlock()
+}
+
+// UnregisterFetcher removes the fetcher of a scheme
+func UnregisterFetcher(scheme string) {
+	regMtx.Lock()
+	delete(fetchers, scheme)
+	regMtx.Unlock()
+}
+
+// fetcherFor returns the fetcher registered for the scheme of uri, nil if
+// uri has no scheme or its scheme has no fetcher.
+func fetcherFor(uri string) Fetcher {
+	scheme, _, ok := strings.Cut(uri, "://")
+	if !ok {
+		return nil
+	}
+	regMtx.RLock()
+	defer regMtx.RUnlock()
+	return fetchers[scheme]
+}
+
+// fetchWithOptions downloads the document at uri with the fetcher and
+// parses it with the options.
+func (r *Reader) fetchWithOptions(f Fetcher, uri string, o *Options) (*sbom.Document, error) {
+	data, err := f.Fetch(context.Background(), uri)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", uri, err)
+	}
+	doc, err := r.ParseStreamWithOptions(bytes.NewReader(data), o)
+	if err != nil {
+		return nil, fmt.Errorf("parsing content: %w", err)
+	}
+	if doc.Metadata != nil && doc.Metadata.SourceData != nil && o.UnserializeOptions.TrackSource {
+		doc.Metadata.SourceData.Uri = &uri
+	}
+	return doc, nil
+}
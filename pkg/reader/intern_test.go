@@ -0,0 +1,54 @@
+package reader_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/unserializers"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// BenchmarkInternStrings parses the example documents with and without
+// string interning and reports the heap retained by the parsed documents.
+func BenchmarkInternStrings(b *testing.B) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	reader.RegisterUnserializer(formats.CDX14JSON, unserializers.NewCDX("1.4", formats.JSON))
+
+	for _, file := range []string{
+		"curl.spdx.json",
+		"nginx.spdx.json",
+		"vt.spdx.json",
+		"juice-shop-11.1.2.cdx.json",
+	} {
+		for _, interned := range []bool{false, true} {
+			b.Run(fmt.Sprintf("%s/intern=%v", file, interned), func(b *testing.B) {
+				r := reader.New(reader.WithUnserializeOptions(&native.UnserializeOptions{
+					InternStrings: interned,
+				}))
+				docs := make([]*sbom.Document, 0, b.N)
+
+				var before, after runtime.MemStats
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				b.ResetTimer()
+				for range b.N {
+					doc, err := r.ParseFile(filepath.Join("..", "..", "examples", file))
+					if err != nil {
+						b.Fatal(err)
+					}
+					docs = append(docs, doc)
+				}
+				b.StopTimer()
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+				b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(b.N), "retained-B/op")
+				runtime.KeepAlive(docs)
+			})
+		}
+	}
+}
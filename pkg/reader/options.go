@@ -2,12 +2,16 @@ package reader
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/protobom/protobom/pkg/datasink"
 	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/rules"
+	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/telemetry"
 )
 
 type Options struct {
@@ -15,7 +19,59 @@ type Options struct {
 	Listeners          []datasink.Listener
 	UnserializeOptions *native.UnserializeOptions
 	RetrieveOptions    *storage.RetrieveOptions
-	formatOptions      map[string]interface{}
+
+	// FallbackFormats are tried in order when the format of a document
+	// can't be sniffed. The document is parsed with each format until one
+	// succeeds, so lenient formats should go last.
+	FallbackFormats []formats.Format
+
+	// Rules is an optional rule set applied to the documents after they
+	// are parsed to normalize them.
+	Rules *rules.RuleSet
+
+	// OrphanReporter is called with the nodes of a parsed document that
+	// are not reachable from its root elements. It is not called when
+	// all nodes are reachable.
+	OrphanReporter func(doc *sbom.Document, unreachable []*sbom.Node)
+
+	// OrphanPolicy defines what to do with the orphaned nodes of the
+	// parsed documents. Defaults to leaving them as is.
+	OrphanPolicy sbom.OrphanPolicy
+
+	// HashReporter is called with the hashes of a parsed document whose
+	// values don't match their algorithm. It is not called when all hashes
+	// are valid.
+	HashReporter func(doc *sbom.Document, issues []*sbom.ValidationIssue)
+
+	// DropInvalidHashes removes the invalid hashes from parsed documents
+	// after they are reported.
+	DropInvalidHashes bool
+
+	// NormalizeVendorDocuments cleans up the quirks of the documents
+	// generated by the GitHub and GitLab dependency scanners, rewriting
+	// their package names, versions and dependency graph.
+	NormalizeVendorDocuments bool
+
+	// EnvelopeVerifier checks the signatures of DSSE envelopes. When set,
+	// only signed envelopes are accepted and at least one signature must
+	// verify before the SBOM inside them is parsed.
+	EnvelopeVerifier EnvelopeVerifier
+
+	// DetachedSignature is a signature of the document distributed apart
+	// from it. It is recorded in the document metadata with the result of
+	// its verification, a failed verification does not fail the read.
+	DetachedSignature *DetachedSignature
+
+	// Sidecars are files distributed with the document whose data is
+	// attached to it after parsing, such as signatures, provenance
+	// attestations, annotations or overlays.
+	Sidecars []*Sidecar
+
+	// Telemetry instruments the parsing of documents with spans and
+	// metrics. Instrumentation is disabled when nil.
+	Telemetry *telemetry.Telemetry
+
+	formatOptions map[string]interface{}
 }
 
 // argToOptsKeyVal returns a key value to access the options dictionary by using
@@ -48,6 +104,17 @@ func (o *Options) SetFormatOptions(key, opts interface{}) {
 	o.formatOptions[keyVal] = opts
 }
 
+// needsSignedData returns true if the options check signatures over the
+// data of the document as read.
+func (o *Options) needsSignedData() bool {
+	if o.DetachedSignature != nil {
+		return true
+	}
+	return slices.ContainsFunc(o.Sidecars, func(sc *Sidecar) bool {
+		return sc.Type == SidecarSignature
+	})
+}
+
 type ReaderOption func(*Reader)
 
 func WithFormatOptions(driverKey string, opts interface{}) ReaderOption {
@@ -72,6 +139,14 @@ func WithSniffer(s Sniffer) ReaderOption {
 	}
 }
 
+// WithFallbackFormats sets the formats tried, in order, to parse documents
+// whose format can't be sniffed.
+func WithFallbackFormats(f ...formats.Format) ReaderOption {
+	return func(r *Reader) {
+		r.Options.FallbackFormats = f
+	}
+}
+
 func WithStoreRetriever(sb storage.StoreRetriever) ReaderOption {
 	return func(r *Reader) {
 		if sb != nil {
@@ -105,6 +180,78 @@ func WithoutMod(m mod.Mod) ReaderOption {
 	}
 }
 
+// WithRules sets a rule set to normalize the documents when parsing them.
+func WithRules(rs *rules.RuleSet) ReaderOption {
+	return func(r *Reader) {
+		r.Options.Rules = rs
+	}
+}
+
+// WithOrphanReporter sets a function to report the nodes of parsed documents
+// not reachable from their root elements. Use it to detect broken generator
+// output.
+func WithOrphanReporter(f func(doc *sbom.Document, unreachable []*sbom.Node)) ReaderOption {
+	return func(r *Reader) {
+		r.Options.OrphanReporter = f
+	}
+}
+
+// WithHashReporter sets a function to report the hashes of parsed documents
+// whose values don't match the length or encoding of their algorithm.
+func WithHashReporter(f func(doc *sbom.Document, issues []*sbom.ValidationIssue)) ReaderOption {
+	return func(r *Reader) {
+		r.Options.HashReporter = f
+	}
+}
+
+// WithDropInvalidHashes controls if the hashes whose values don't match
+// their algorithm are removed from parsed documents.
+func WithDropInvalidHashes(drop bool) ReaderOption {
+	return func(r *Reader) {
+		r.Options.DropInvalidHashes = drop
+	}
+}
+
+// WithVendorNormalization controls if the quirks of the documents generated
+// by the GitHub and GitLab dependency scanners are cleaned up when parsing.
+func WithVendorNormalization(normalize bool) ReaderOption {
+	return func(r *Reader) {
+		r.Options.NormalizeVendorDocuments = normalize
+	}
+}
+
+// WithEnvelopeVerifier sets the verifier used to check the signatures of
+// the DSSE envelopes wrapping the documents.
+func WithEnvelopeVerifier(v EnvelopeVerifier) ReaderOption {
+	return func(r *Reader) {
+		r.Options.EnvelopeVerifier = v
+	}
+}
+
+// WithDetachedSignature sets a signature of the document to verify and
+// record in the parsed document metadata.
+func WithDetachedSignature(ds *DetachedSignature) ReaderOption {
+	return func(r *Reader) {
+		r.Options.DetachedSignature = ds
+	}
+}
+
+// WithSidecars adds sidecars to attach to the parsed documents
+func WithSidecars(sidecars ...*Sidecar) ReaderOption {
+	return func(r *Reader) {
+		r.Options.Sidecars = append(r.Options.Sidecars, sidecars...)
+	}
+}
+
+// WithOrphanPolicy sets the policy applied to the orphaned nodes of the
+// parsed documents. The orphan reporter, if any, sees the document before
+// the policy is applied.
+func WithOrphanPolicy(p sbom.OrphanPolicy) ReaderOption {
+	return func(r *Reader) {
+		r.Options.OrphanPolicy = p
+	}
+}
+
 func WithListener(l datasink.Listener) ReaderOption {
 	return func(r *Reader) {
 		r.Options.Listeners = append(r.Options.Listeners, l)
@@ -116,3 +263,18 @@ func WithTrackSource(t bool) ReaderOption {
 		r.Options.UnserializeOptions.TrackSource = t
 	}
 }
+
+// WithInternStrings makes the reader intern the repeated strings of the
+// parsed documents to reduce their memory footprint.
+func WithInternStrings(t bool) ReaderOption {
+	return func(r *Reader) {
+		r.Options.UnserializeOptions.InternStrings = t
+	}
+}
+
+// WithTelemetry instruments the reader with the tracer and meter in t
+func WithTelemetry(t *telemetry.Telemetry) ReaderOption {
+	return func(r *Reader) {
+		r.Options.Telemetry = t
+	}
+}
@@ -7,13 +7,17 @@ package reader
 
 import (
 	"bytes"
+	"cmp"
+	"context"
 	"crypto/sha1" //nolint:gosec // SHA1 is required in SPDX2
 	"crypto/sha256"
 	"crypto/sha512"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
 	"os"
+	"slices"
 	"sync"
 
 	"github.com/protobom/protobom/pkg/formats"
@@ -22,6 +26,7 @@ import (
 	drivers "github.com/protobom/protobom/pkg/native/unserializers"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/telemetry"
 )
 
 var (
@@ -49,7 +54,12 @@ func init() {
 	unserializers[formats.CDX14JSON] = drivers.NewCDX("1.4", formats.JSON)
 	unserializers[formats.CDX15JSON] = drivers.NewCDX("1.5", formats.JSON)
 	unserializers[formats.CDX16JSON] = drivers.NewCDX("1.6", formats.JSON)
+	unserializers[formats.CDX14XML] = drivers.NewCDX("1.4", formats.XML)
+	unserializers[formats.CDX15XML] = drivers.NewCDX("1.5", formats.XML)
+	unserializers[formats.CDX16XML] = drivers.NewCDX("1.6", formats.XML)
 	unserializers[formats.SPDX23JSON] = drivers.NewSPDX23()
+	unserializers[formats.SPDX23TV] = drivers.NewSPDX23TV()
+	unserializers[formats.SPDX22TV] = drivers.NewSPDX23TV()
 	regMtx.Unlock()
 }
 
@@ -68,6 +78,18 @@ func UnregisterUnserializer(format formats.Format) {
 	regMtx.Unlock()
 }
 
+// ListUnserializers returns the formats with a registered unserializer
+func ListUnserializers() []formats.Format {
+	regMtx.RLock()
+	defer regMtx.RUnlock()
+	ret := make([]formats.Format, 0, len(unserializers))
+	for f := range unserializers {
+		ret = append(ret, f)
+	}
+	slices.Sort(ret)
+	return ret
+}
+
 func GetFormatUnserializer(format formats.Format) (native.Unserializer, error) {
 	if _, ok := unserializers[format]; ok {
 		return unserializers[format], nil
@@ -111,8 +133,14 @@ func (r *Reader) ParseFile(path string) (*sbom.Document, error) {
 	return r.ParseFileWithOptions(path, r.Options)
 }
 
-// ParseFile reads a file and returns an sbom.Document
+// ParseFile reads a file and returns an sbom.Document. Paths with a scheme
+// that has a registered Fetcher, such as tea:// references, are downloaded
+// with it.
 func (r *Reader) ParseFileWithOptions(path string, o *Options) (*sbom.Document, error) {
+	if fetcher := fetcherFor(path); fetcher != nil {
+		return r.fetchWithOptions(fetcher, path, o)
+	}
+
 	f, err := os.Open(path)
 	if err != nil {
 		return nil, fmt.Errorf("opening SBOM file: %w", err)
@@ -134,18 +162,81 @@ func (r *Reader) ParseFileWithOptions(path string, o *Options) (*sbom.Document,
 
 // ParseStreamWithOptions returns a document from a ioreader, accept options for unserializer
 func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Document, error) {
+	return r.parseStream(context.Background(), f, o)
+}
+
+// ParseStreamContext returns a document from a ioreader. When telemetry is
+// not set in the reader options, it is taken from ctx. The parse span is a
+// child of the span in ctx.
+func (r *Reader) ParseStreamContext(ctx context.Context, f io.ReadSeeker) (*sbom.Document, error) {
+	return r.parseStream(ctx, f, r.Options)
+}
+
+// parseStream parses a document from f, instrumenting the operation with
+// the telemetry in the options or in ctx.
+func (r *Reader) parseStream(ctx context.Context, f io.ReadSeeker, o *Options) (doc *sbom.Document, err error) {
 	if o == nil {
 		return nil, fmt.Errorf("options cannot be nil")
 	}
 
+	t := o.Telemetry
+	if t == nil {
+		t = telemetry.FromContext(ctx)
+	}
+	_, op := t.Start(ctx, telemetry.SpanReaderParse)
+	defer func() { op.End(err) }()
+
+	// Documents not encoded in UTF-8 are converted before parsing. The
+	// original bytes are kept to feed the listeners and hashers.
+	encoding, err := DetectEncoding(f)
+	if err != nil {
+		return nil, fmt.Errorf("detecting document encoding: %w", err)
+	}
+
+	var original []byte
+	if encoding != EncodingUTF8 {
+		original, err = io.ReadAll(f)
+		if err != nil {
+			return nil, fmt.Errorf("reading document: %w", err)
+		}
+		converted, err := ToUTF8(original, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("converting document from %s: %w", encoding, err)
+		}
+		f = bytes.NewReader(converted)
+	}
+
+	// Attestations and API responses are unwrapped to parse the SBOM they
+	// carry. As with the encoding conversion, listeners and hashers see the
+	// original data. The stream is only buffered when it is wrapped or the
+	// signature options need the data read.
+	signed := original
+	data, sbomData, signatures, err := unwrapStream(f, o.EnvelopeVerifier, o.needsSignedData())
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping attestation: %w", err)
+	}
+	if signed == nil {
+		signed = data
+	}
+	if sbomData != nil {
+		if original == nil {
+			original = data
+		}
+		f = bytes.NewReader(sbomData)
+	}
+
 	format := o.Format
 	if o.Format == "" {
-		f, err := r.detectFormat(f)
+		detected, err := r.detectFormat(f)
+		if err != nil && len(o.FallbackFormats) > 0 && errors.Is(err, formats.ErrUnknownFormat) {
+			detected, err = r.probeFormat(f, o)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("detecting SBOM format: %w", err)
 		}
-		format = f
+		format = detected
 	}
+	op.SetAttributes(telemetry.String("format", string(format)))
 
 	unserializer, err := GetFormatUnserializer(format)
 	if err != nil {
@@ -178,21 +269,111 @@ func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Docu
 	// We aggregate all the data sinks into a single multireader
 	// that gets a copy of all the bytes read from the stream.
 	multiwriter := io.MultiWriter(sinks...)
-	tee := io.TeeReader(f, multiwriter)
+	var tee io.Reader = io.TeeReader(f, multiwriter)
+	if original != nil {
+		if _, err := multiwriter.Write(original); err != nil {
+			return nil, fmt.Errorf("writing document to listeners: %w", err)
+		}
+		tee = f
+	}
+
+	uopts := o.UnserializeOptions
+	if o.Rules != nil {
+		edgeTypes, err := o.Rules.CustomEdgeTypes()
+		if err != nil {
+			return nil, fmt.Errorf("reading rule set edge types: %w", err)
+		}
+		uoptsCopy := *o.UnserializeOptions
+		uoptsCopy.CustomEdgeTypes = edgeTypes
+		uopts = &uoptsCopy
+	}
+
+	// CycloneDX JSON documents may carry JSF signatures, copy them as they
+	// are read if the data was not buffered already
+	jsf := format.Type() == formats.CDXFORMAT && format.Encoding() == formats.JSON
+	docData := sbomData
+	if docData == nil {
+		docData = data
+	}
+	var jsfData *bytes.Buffer
+	if jsf && docData == nil {
+		jsfData = &bytes.Buffer{}
+		tee = io.TeeReader(tee, jsfData)
+	}
+
+	// Count the bytes read by the unserializer
+	read := &countingReader{r: tee}
 
 	// Call the format unserializer
-	doc, err := unserializer.Unserialize(
-		tee, o.UnserializeOptions, r.Options.GetFormatOptions(unserializer),
+	doc, err = unserializer.Unserialize(
+		read, uopts, r.Options.GetFormatOptions(unserializer),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unserializing %s: %w", format, err)
 	}
+	op.Count(telemetry.MetricBytesRead, read.n)
+	op.Count(telemetry.MetricNodesParsed, int64(len(doc.GetNodeList().GetNodes())))
+
+	if o.UnserializeOptions.InternStrings && doc != nil {
+		doc.InternStrings()
+	}
+
+	if o.NormalizeVendorDocuments && doc != nil {
+		normalizeVendorDocument(doc)
+	}
+
+	if o.Rules != nil {
+		if err := o.Rules.Apply(doc); err != nil {
+			return nil, fmt.Errorf("applying rules: %w", err)
+		}
+	}
+
+	if o.OrphanReporter != nil && doc.GetNodeList() != nil {
+		if unreachable := doc.NodeList.Unreachable(); len(unreachable) > 0 {
+			o.OrphanReporter(doc, unreachable)
+		}
+	}
+
+	if o.OrphanPolicy != "" && doc.GetNodeList() != nil {
+		if err := doc.NodeList.ReconnectOrphans(o.OrphanPolicy); err != nil {
+			return nil, fmt.Errorf("applying orphan policy: %w", err)
+		}
+	}
+
+	if o.HashReporter != nil && doc.GetNodeList() != nil {
+		if issues := doc.NodeList.ValidateHashes(); len(issues) > 0 {
+			o.HashReporter(doc, issues)
+		}
+	}
+
+	if o.DropInvalidHashes && doc.GetNodeList() != nil {
+		doc.NodeList.DropInvalidHashes()
+	}
 
 	// Protect in case the unserializer returns a nil document
 	if doc.Metadata == nil {
 		doc.Metadata = &sbom.Metadata{}
 	}
 
+	// Record the signatures of the document so they can be checked
+	// without the original bytes.
+	if jsf {
+		if jsfData != nil {
+			docData = jsfData.Bytes()
+		}
+		signatures = append(signatures, jsfSignatures(docData)...)
+	}
+	if o.DetachedSignature != nil {
+		signatures = append(signatures, o.DetachedSignature.signature(signed))
+	}
+	doc.Metadata.Signatures = append(doc.Metadata.Signatures, signatures...)
+
+	for _, sc := range o.Sidecars {
+		if err := sc.apply(doc, signed); err != nil {
+			return nil, fmt.Errorf("attaching sidecar %s: %w", cmp.Or(sc.Name, string(sc.Type)), err)
+		}
+	}
+
 	if o.UnserializeOptions.TrackSource {
 		doc.Metadata.SourceData = &sbom.SourceData{
 			Format: string(format),
@@ -207,11 +388,38 @@ func (r *Reader) ParseStreamWithOptions(f io.ReadSeeker, o *Options) (*sbom.Docu
 	return doc, err
 }
 
+// countingReader counts the bytes read through it
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
 // ParseStreamWithOptions returns a document from a ioreader
 func (r *Reader) ParseStream(f io.ReadSeeker) (*sbom.Document, error) {
 	return r.ParseStreamWithOptions(f, r.Options)
 }
 
+// ParseDocumentStream reads a batch of protobom documents written to a
+// single stream by writer.WriteDocumentStream. If encoding is empty, it is
+// detected from the stream contents.
+func (r *Reader) ParseDocumentStream(rd io.Reader, encoding sbom.StreamEncoding) ([]*sbom.Document, error) {
+	dec, err := sbom.NewDocumentDecoder(rd, encoding)
+	if err != nil {
+		return nil, fmt.Errorf("creating document decoder: %w", err)
+	}
+	docs, err := dec.DecodeAll()
+	if err != nil {
+		return nil, fmt.Errorf("parsing document stream: %w", err)
+	}
+	return docs, nil
+}
+
 func (r *Reader) detectFormat(rs io.ReadSeeker) (formats.Format, error) {
 	format, err := r.sniffer.SniffReader(rs)
 	if err != nil {
@@ -220,6 +428,37 @@ func (r *Reader) detectFormat(rs io.ReadSeeker) (formats.Format, error) {
 	return format, nil
 }
 
+// probeFormat tries to parse the stream with the unserializers of the
+// fallback formats, in order, returning the first format that parses it into
+// a document with an identifier or nodes. The stream is rewound after each
+// attempt.
+func (r *Reader) probeFormat(rs io.ReadSeeker, o *Options) (formats.Format, error) {
+	errs := []error{}
+	for _, format := range o.FallbackFormats {
+		unserializer, err := GetFormatUnserializer(format)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := rs.Seek(0, io.SeekStart); err != nil {
+			return "", fmt.Errorf("rewinding stream: %w", err)
+		}
+		doc, err := unserializer.Unserialize(rs, o.UnserializeOptions, r.Options.GetFormatOptions(unserializer))
+		if _, serr := rs.Seek(0, io.SeekStart); serr != nil {
+			return "", fmt.Errorf("rewinding stream: %w", serr)
+		}
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("parsing as %s: %w", format, err))
+		case doc.GetMetadata().GetId() == "" && len(doc.GetNodeList().GetNodes()) == 0:
+			errs = append(errs, fmt.Errorf("parsing as %s: empty document", format))
+		default:
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no fallback format could parse the document: %w", formats.ErrUnknownFormat, errors.Join(errs...))
+}
+
 // Retrieve reads a document from the configured storage backend using the
 // default options.
 func (r *Reader) Retrieve(id string) (*sbom.Document, error) {
@@ -227,12 +466,17 @@ func (r *Reader) Retrieve(id string) (*sbom.Document, error) {
 }
 
 // RetrieveWithOptions retrieves a document from the configured storage backend
-// using a set of options.
+// using a set of options. Identifiers with a scheme that has a registered
+// Fetcher are downloaded with it and parsed with the options instead.
 func (r *Reader) RetrieveWithOptions(id string, o *Options) (*sbom.Document, error) {
 	if id == "" {
 		return nil, fmt.Errorf("unable to retrieve document, no document identifier specified")
 	}
 
+	if fetcher := fetcherFor(id); fetcher != nil {
+		return r.fetchWithOptions(fetcher, id, o)
+	}
+
 	if r.Storage == nil {
 		return nil, fmt.Errorf("unable to retrieve document, no storage backend configured")
 	}
@@ -2,6 +2,7 @@ package reader_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -19,6 +20,8 @@ import (
 	"github.com/protobom/protobom/pkg/reader/readerfakes"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/telemetry"
+	"github.com/protobom/protobom/pkg/writer"
 )
 
 // A note about Unserializers and reader behavior:
@@ -503,3 +506,447 @@ func TestReaderSourceData(t *testing.T) {
 	require.Equal(t, "f042095476ef416fae33e9a76a4e406ff337cfc15a6f694894e6ff0070adb089", doc.Metadata.SourceData.Hashes[int32(sbom.HashAlgorithm_SHA256)])
 	require.Equal(t, "71b04d63bc55dc78b91dfb376484a20a4e410fd58db893ed6e20637ccb495f7bf83b1aa76ab377bd9a6ef96d0d19f8cfa834d152dbf4880c2400be9a89dea429", doc.Metadata.SourceData.Hashes[int32(sbom.HashAlgorithm_SHA512)])
 }
+
+func TestOrphanReporter(t *testing.T) {
+	t.Parallel()
+	data := []byte(`{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "orphans",
+  "documentNamespace": "https://example.com/orphans",
+  "creationInfo": {"creators": ["Tool: test"], "created": "2024-01-01T00:00:00Z"},
+  "packages": [
+    {"name": "app", "SPDXID": "SPDXRef-app", "downloadLocation": "NOASSERTION"},
+    {"name": "lib", "SPDXID": "SPDXRef-lib", "downloadLocation": "NOASSERTION"},
+    {"name": "stray", "SPDXID": "SPDXRef-stray", "downloadLocation": "NOASSERTION"}
+  ],
+  "relationships": [
+    {"spdxElementId": "SPDXRef-DOCUMENT", "relatedSpdxElement": "SPDXRef-app", "relationshipType": "DESCRIBES"},
+    {"spdxElementId": "SPDXRef-app", "relatedSpdxElement": "SPDXRef-lib", "relationshipType": "DEPENDS_ON"}
+  ]
+}`)
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+
+	for _, tc := range []struct {
+		name     string
+		policy   sbom.OrphanPolicy
+		reported []string
+		roots    []string
+		errIs    error
+	}{
+		{name: "report only", reported: []string{"stray"}, roots: []string{"app"}},
+		{name: "reconnect", policy: sbom.OrphanPolicyReconnect, reported: []string{"stray"}, roots: []string{"app", "stray"}},
+		{name: "error", policy: sbom.OrphanPolicyError, errIs: sbom.ErrOrphanedNodes},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var reported []string
+			doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(data), &reader.Options{
+				Format:             formats.SPDX23JSON,
+				UnserializeOptions: &native.UnserializeOptions{},
+				OrphanPolicy:       tc.policy,
+				OrphanReporter: func(_ *sbom.Document, unreachable []*sbom.Node) {
+					for _, n := range unreachable {
+						reported = append(reported, n.Id)
+					}
+				},
+			})
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.reported, reported)
+			require.ElementsMatch(t, tc.roots, doc.NodeList.RootElements)
+		})
+	}
+}
+
+func TestHashValidation(t *testing.T) {
+	t.Parallel()
+	data := []byte(`{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "hashes",
+  "documentNamespace": "https://example.com/hashes",
+  "creationInfo": {"creators": ["Tool: test"], "created": "2024-01-01T00:00:00Z"},
+  "packages": [
+    {
+      "name": "app", "SPDXID": "SPDXRef-app", "downloadLocation": "NOASSERTION",
+      "checksums": [
+        {"algorithm": "SHA1", "checksumValue": "da39a3ee5e6b4b0d3255bfef95601890afd80709"},
+        {"algorithm": "SHA256", "checksumValue": "d41d8cd98f00b204e9800998ecf8427e"}
+      ]
+    }
+  ]
+}`)
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+
+	for _, tc := range []struct {
+		name   string
+		drop   bool
+		hashes int
+	}{
+		{name: "report", hashes: 2},
+		{name: "drop invalid hashes", drop: true, hashes: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var reported []*sbom.ValidationIssue
+			doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(data), &reader.Options{
+				Format:             formats.SPDX23JSON,
+				UnserializeOptions: &native.UnserializeOptions{},
+				HashReporter: func(_ *sbom.Document, issues []*sbom.ValidationIssue) {
+					reported = append(reported, issues...)
+				},
+				DropInvalidHashes: tc.drop,
+			})
+			require.NoError(t, err)
+			require.Len(t, reported, 1)
+			require.Equal(t, "app", reported[0].NodeID)
+			require.Len(t, doc.NodeList.GetNodeByID("app").Hashes, tc.hashes)
+		})
+	}
+}
+
+func TestParseDocumentStream(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		encoding sbom.StreamEncoding
+		// data returns the stream to parse
+		data    func(*testing.T) []byte
+		ids     []string
+		mustErr bool
+	}{
+		{
+			name: "autodetected encoding",
+			data: func(t *testing.T) []byte {
+				t.Helper()
+				var buf bytes.Buffer
+				enc, err := sbom.NewDocumentEncoder(&buf, sbom.StreamEncodingProtobuf)
+				require.NoError(t, err)
+				for _, id := range []string{"doc-1", "doc-2"} {
+					doc := sbom.NewDocument()
+					doc.Metadata.Id = id
+					require.NoError(t, enc.Encode(doc))
+				}
+				return buf.Bytes()
+			},
+			ids: []string{"doc-1", "doc-2"},
+		},
+		{
+			name:     "jsonl",
+			encoding: sbom.StreamEncodingJSONL,
+			data: func(*testing.T) []byte {
+				return []byte(`{"metadata": {"id": "doc-1"}, "nodeList": {}}` + "\n")
+			},
+			ids: []string{"doc-1"},
+		},
+		{
+			name:     "truncated message",
+			encoding: sbom.StreamEncodingProtobuf,
+			data:     func(*testing.T) []byte { return []byte{0x05, 0x0a} },
+			mustErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			docs, err := reader.New().ParseDocumentStream(bytes.NewReader(tc.data(t)), tc.encoding)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			ids := []string{}
+			for _, doc := range docs {
+				ids = append(ids, doc.Metadata.Id)
+			}
+			require.Equal(t, tc.ids, ids)
+		})
+	}
+}
+
+func TestReaderTelemetry(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	spdxData := []byte(`{
+  "spdxVersion": "SPDX-2.3",
+  "dataLicense": "CC0-1.0",
+  "SPDXID": "SPDXRef-DOCUMENT",
+  "name": "telemetry",
+  "documentNamespace": "https://example.com/telemetry",
+  "creationInfo": {"creators": ["Tool: test"], "created": "2024-01-01T00:00:00Z"},
+  "packages": [
+    {"name": "app", "SPDXID": "SPDXRef-app", "downloadLocation": "NOASSERTION"},
+    {"name": "lib", "SPDXID": "SPDXRef-lib", "downloadLocation": "NOASSERTION"}
+  ]
+}`)
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+		// inContext passes the telemetry in the context, traced under a
+		// caller span, instead of the reader options
+		inContext bool
+		mustErr   bool
+		nodes     int64
+	}{
+		{name: "options telemetry", data: spdxData, nodes: 2},
+		{name: "context telemetry", data: spdxData, inContext: true, nodes: 2},
+		{name: "parse error", data: []byte("not an sbom"), inContext: true, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := telemetry.NewRecorder()
+			var parent telemetry.Span
+			var err error
+			if tc.inContext {
+				var ctx context.Context
+				ctx, parent = rec.Start(telemetry.ContextWithTelemetry(context.Background(), rec.Telemetry()), "request")
+				_, err = reader.New().ParseStreamContext(ctx, bytes.NewReader(tc.data))
+			} else {
+				_, err = reader.New().ParseStreamWithOptions(bytes.NewReader(tc.data), &reader.Options{
+					Format:             formats.SPDX23JSON,
+					UnserializeOptions: &native.UnserializeOptions{},
+					Telemetry:          rec.Telemetry(),
+				})
+			}
+
+			span := rec.Span(telemetry.SpanReaderParse)
+			require.NotNil(t, span)
+			require.True(t, span.Ended)
+			if parent != nil {
+				require.Equal(t, parent, span.Parent)
+			}
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Len(t, span.Errors, 1)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, span.Errors)
+			require.Equal(t, string(formats.SPDX23JSON), span.Attributes["format"])
+			require.Equal(t, int64(len(tc.data)), rec.Counter(telemetry.MetricBytesRead))
+			require.Equal(t, tc.nodes, rec.Counter(telemetry.MetricNodesParsed))
+			require.Len(t, rec.Durations(telemetry.SpanReaderParse+telemetry.DurationSuffix), 1)
+		})
+	}
+}
+
+func TestParseStreamFallbackFormats(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	reader.RegisterUnserializer(formats.CDX16JSON, unserializers.NewCDX("1.6", formats.JSON))
+	cdxData := `{"bomFormat": "CycloneDX", "specVersion": "1.6", "version": 1, "metadata": {},
+		"components": [{"bom-ref": "lib", "type": "library", "name": "lib"}]}`
+
+	for _, tc := range []struct {
+		name     string
+		data     string
+		fallback []formats.Format
+		errIs    error
+	}{
+		{
+			// Without fallback formats the sniffer error is returned
+			name:  "no fallback formats",
+			data:  cdxData,
+			errIs: formats.ErrUnknownFormat,
+		},
+		{
+			name:     "second fallback format",
+			data:     cdxData,
+			fallback: []formats.Format{formats.SPDX23JSON, formats.CDX16JSON},
+		},
+		{
+			name:     "first fallback format",
+			data:     cdxData,
+			fallback: []formats.Format{formats.CDX16JSON},
+		},
+		{
+			name:     "no fallback format parses",
+			data:     "not an sbom",
+			fallback: []formats.Format{formats.CDX16JSON},
+			errIs:    formats.ErrUnknownFormat,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeSniffer := &readerfakes.FakeSniffer{}
+			fakeSniffer.SniffReaderReturns("", formats.ErrUnknownFormat)
+			r := reader.New(reader.WithSniffer(fakeSniffer))
+
+			doc, err := r.ParseStreamWithOptions(bytes.NewReader([]byte(tc.data)), &reader.Options{
+				UnserializeOptions: &native.UnserializeOptions{},
+				FallbackFormats:    tc.fallback,
+			})
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, doc.NodeList.GetNodeByID("lib"))
+		})
+	}
+}
+
+func TestParseStreamCDXXML(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		format    formats.Format
+		namespace string
+	}{
+		{"cyclonedx 1.4", formats.CDX14XML, "http://cyclonedx.org/schema/bom/1.4"},
+		{"cyclonedx 1.5", formats.CDX15XML, "http://cyclonedx.org/schema/bom/1.5"},
+		{"cyclonedx 1.6", formats.CDX16XML, "http://cyclonedx.org/schema/bom/1.6"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79"
+			doc.NodeList.AddRootNode(&sbom.Node{
+				Id: "app", Name: "app", Version: "1.0", Licenses: []string{"MIT"},
+				Hashes:      map[int32]string{int32(sbom.HashAlgorithm_SHA256): "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+				Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:golang/example.com/app@1.0"},
+			})
+			doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Version: "2.0"})
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+			doc.NodeList.GetNodeByID("lib").SetLabel("team", "core")
+
+			var buf bytes.Buffer
+			require.NoError(t, writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{
+				Format:           tc.format,
+				RenderOptions:    &native.RenderOptions{Indent: 2},
+				SerializeOptions: &native.SerializeOptions{},
+			}))
+			require.Contains(t, buf.String(), `xmlns="`+tc.namespace+`"`)
+
+			// The format is detected from the XML namespace
+			parsed, err := reader.New().ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), &reader.Options{
+				UnserializeOptions: &native.UnserializeOptions{},
+			})
+			require.NoError(t, err)
+			require.Equal(t, doc.Metadata.Id, parsed.Metadata.Id)
+			app := parsed.NodeList.GetNodeByID("app")
+			require.NotNil(t, app)
+			require.Equal(t, "1.0", app.Version)
+			require.Equal(t, []string{"MIT"}, app.Licenses)
+			require.Equal(t, doc.NodeList.GetNodeByID("app").Hashes, app.Hashes)
+			require.Equal(t, "pkg:golang/example.com/app@1.0", app.Identifiers[int32(sbom.SoftwareIdentifierType_PURL)])
+			require.Len(t, parsed.NodeList.GetNodesByLabel("team", "core"), 1)
+			require.Equal(t, []string{"app"}, parsed.NodeList.RootElements)
+			require.Len(t, parsed.NodeList.Edges, 1)
+			require.Equal(t, []string{"lib"}, parsed.NodeList.Edges[0].To)
+		})
+	}
+}
+
+func TestParseStreamSPDXTagValue(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		read func(*testing.T) *sbom.Document
+		// keepsIDs is true when the node identifiers are valid SPDX
+		// element identifiers and are written unchanged
+		keepsIDs bool
+	}{
+		{
+			name: "protobom document",
+			read: func(*testing.T) *sbom.Document {
+				doc := sbom.NewDocument()
+				doc.Metadata.Id = "https://example.com/test"
+				doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0"})
+				doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Version: "2.0"})
+				doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+				return doc
+			},
+			keepsIDs: true,
+		},
+		{
+			// Syft uses purls as component references, they are not valid SPDX
+			// element identifiers
+			name: "purl identifiers",
+			read: func(t *testing.T) *sbom.Document {
+				t.Helper()
+				doc, err := reader.New().ParseFile("../../test/conformance/testdata/cyclonedx/1.5/json/syft-0.96.0_rails-5.0.0.cdx.json")
+				require.NoError(t, err)
+				require.NotNil(t, doc.NodeList.GetNodeByID("pkg:deb/debian/acl@2.2.52-2?arch=amd64&distro=debian-8&package-id=7ecb04e4ff57538e"))
+				return doc
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := tc.read(t)
+			var buf bytes.Buffer
+			require.NoError(t, writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{
+				Format:           formats.SPDX23TV,
+				RenderOptions:    &native.RenderOptions{},
+				SerializeOptions: &native.SerializeOptions{},
+			}))
+			require.NotContains(t, buf.String(), "SPDXID: SPDXRef-pkg:")
+
+			// The format is detected from the SPDXVersion tag
+			parsed, err := reader.New().ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), &reader.Options{
+				UnserializeOptions: &native.UnserializeOptions{},
+			})
+			require.NoError(t, err)
+			require.Len(t, parsed.NodeList.Nodes, len(doc.NodeList.Nodes))
+			require.Len(t, parsed.NodeList.RootElements, len(doc.NodeList.RootElements))
+			if tc.keepsIDs {
+				require.Equal(t, doc.NodeList.RootElements, parsed.NodeList.RootElements)
+				for _, n := range doc.NodeList.Nodes {
+					require.NotNil(t, parsed.NodeList.GetNodeByID(n.Id))
+					require.Equal(t, n.Version, parsed.NodeList.GetNodeByID(n.Id).Version)
+				}
+			}
+
+			edges := 0
+			for _, e := range doc.NodeList.Edges {
+				edges += len(e.To)
+			}
+			parsedEdges := 0
+			for _, e := range parsed.NodeList.Edges {
+				parsedEdges += len(e.To)
+				for _, to := range e.To {
+					require.NotNil(t, parsed.NodeList.GetNodeByID(to))
+				}
+			}
+			require.Equal(t, edges, parsedEdges)
+		})
+	}
+}
+
+func TestParseStreamCDXCustomLicenses(t *testing.T) {
+	reader.RegisterUnserializer(formats.CDX16JSON, unserializers.NewCDX("1.6", formats.JSON))
+	for _, tc := range []struct {
+		name    string
+		license *sbom.License
+	}{
+		{"generated ref", &sbom.License{Id: "LicenseRef-acme-1", Name: "ACME License", Text: "Do whatever"}},
+		{"ref matching the name", &sbom.License{Id: "LicenseRef-ACME-Internal", Name: "ACME Internal"}},
+		{"without name", &sbom.License{Id: "LicenseRef-noname", Text: "Some text"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "urn:uuid:0b5e7d1a-6a3c-4e1c-9a3b-2a3c4d5e6f70"
+			doc.Metadata.CustomLicenses = []*sbom.License{tc.license}
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Licenses: []string{tc.license.Id}})
+			doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib", Licenses: []string{"Apache-2.0"}})
+			doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "app", To: []string{"lib"}})
+
+			var buf bytes.Buffer
+			require.NoError(t, writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{
+				Format:           formats.CDX16JSON,
+				RenderOptions:    &native.RenderOptions{},
+				SerializeOptions: &native.SerializeOptions{},
+			}))
+
+			parsed, err := reader.New().ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), &reader.Options{
+				UnserializeOptions: &native.UnserializeOptions{},
+			})
+			require.NoError(t, err)
+			for _, n := range doc.NodeList.Nodes {
+				require.Equal(t, n.Licenses, parsed.NodeList.GetNodeByID(n.Id).Licenses)
+			}
+			require.Len(t, parsed.Metadata.CustomLicenses, 1)
+			custom := parsed.Metadata.GetCustomLicense(tc.license.Id)
+			require.NotNil(t, custom)
+			require.Equal(t, tc.license.Name, custom.Name)
+			require.Equal(t, tc.license.Text, custom.Text)
+		})
+	}
+}
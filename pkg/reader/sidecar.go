@@ -0,0 +1,255 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// SidecarType identifies the kind of data in a sidecar file
+type SidecarType string
+
+const (
+	// SidecarSignature is a detached signature of the document, eg a
+	// cosign .sig file, raw or base64 encoded.
+	SidecarSignature SidecarType = "signature"
+
+	// SidecarProvenance is an in-toto provenance attestation of the
+	// document, plain or in a DSSE envelope.
+	SidecarProvenance SidecarType = "provenance"
+
+	// SidecarAnnotations is a YAML file with properties to add to the
+	// document and its nodes.
+	SidecarAnnotations SidecarType = "annotations"
+
+	// SidecarOverlay is a protobom overlay in JSON to curate the nodes
+	SidecarOverlay SidecarType = "overlay"
+)
+
+// Properties recording the provenance sidecars in the document metadata
+const (
+	PropertyProvenancePredicateType = "protobom:provenance:predicate_type"
+	PropertyProvenanceBuilderID     = "protobom:provenance:builder_id"
+	PropertyProvenanceBuildType     = "protobom:provenance:build_type"
+	PropertyProvenanceSubject       = "protobom:provenance:subject"
+)
+
+// sidecarExtensions maps file name suffixes to the sidecar they hold.
+// Longer suffixes are checked first.
+var sidecarExtensions = map[string]SidecarType{
+	".sig":             SidecarSignature,
+	".intoto.jsonl":    SidecarProvenance,
+	".intoto.json":     SidecarProvenance,
+	".provenance.json": SidecarProvenance,
+	".dsse":            SidecarProvenance,
+	".att":             SidecarProvenance,
+	".yaml":            SidecarAnnotations,
+	".yml":             SidecarAnnotations,
+	".overlay.json":    SidecarOverlay,
+}
+
+// Sidecar is data distributed next to a document that enriches it when
+// parsed, such as its signature or provenance.
+type Sidecar struct {
+	Type SidecarType
+
+	// Name identifies the sidecar in errors, usually its path
+	Name string
+
+	Data []byte
+
+	// Verifier checks signature sidecars. Signatures are recorded
+	// unverified when not set.
+	Verifier EnvelopeVerifier
+}
+
+// SidecarTypeFromPath infers the type of a sidecar from its file name. It
+// returns an empty type if the name is not recognized.
+func SidecarTypeFromPath(path string) SidecarType {
+	name := strings.ToLower(filepath.Base(path))
+	suffixes := slices.Collect(maps.Keys(sidecarExtensions))
+	slices.SortFunc(suffixes, func(a, b string) int { return len(b) - len(a) })
+	for _, s := range suffixes {
+		if strings.HasSuffix(name, s) {
+			return sidecarExtensions[s]
+		}
+	}
+	return ""
+}
+
+// LoadSidecar reads a sidecar file, inferring its type from its name
+func LoadSidecar(path string) (*Sidecar, error) {
+	t := SidecarTypeFromPath(path)
+	if t == "" {
+		return nil, fmt.Errorf("unable to infer the sidecar type of %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading sidecar: %w", err)
+	}
+	return &Sidecar{Type: t, Name: path, Data: data}, nil
+}
+
+// sidecarAnnotations is the format of the annotations sidecars
+type sidecarAnnotations struct {
+	// Document has the properties to set in the document metadata
+	Document map[string]string `yaml:"document"`
+
+	// Nodes has the properties to set in the nodes matching the selectors
+	Nodes []struct {
+		Selector struct {
+			ID      string `yaml:"id"`
+			Purl    string `yaml:"purl"`
+			Name    string `yaml:"name"`
+			Version string `yaml:"version"`
+		} `yaml:"selector"`
+		Properties map[string]string `yaml:"properties"`
+	} `yaml:"nodes"`
+}
+
+// apply attaches the sidecar data to the document. signed is the data of
+// the document as read, used to check signatures.
+func (s *Sidecar) apply(doc *sbom.Document, signed []byte) error {
+	switch s.Type {
+	case SidecarSignature:
+		return s.applySignature(doc, signed)
+	case SidecarProvenance:
+		return s.applyProvenance(doc)
+	case SidecarAnnotations:
+		return s.applyAnnotations(doc)
+	case SidecarOverlay:
+		overlay := &sbom.Overlay{}
+		if err := protojson.Unmarshal(s.Data, overlay); err != nil {
+			return fmt.Errorf("parsing overlay: %w", err)
+		}
+		if _, err := doc.ApplyOverlay(overlay); err != nil {
+			return fmt.Errorf("applying overlay: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown sidecar type %q", s.Type)
+	}
+}
+
+// applySignature records the detached signature in the document
+func (s *Sidecar) applySignature(doc *sbom.Document, signed []byte) error {
+	sig := bytes.TrimSpace(s.Data)
+	if len(sig) == 0 {
+		return errors.New("signature is empty")
+	}
+	if decoded, err := decodeBase64(string(sig)); err == nil {
+		sig = decoded
+	}
+	ds := &DetachedSignature{Signature: sig, Verifier: s.Verifier}
+	doc.Metadata.Signatures = append(doc.Metadata.Signatures, ds.signature(signed))
+	return nil
+}
+
+// applyProvenance records the provenance statement in the document
+// properties. JSON lines files hold one attestation per line, the first one
+// is used.
+func (s *Sidecar) applyProvenance(doc *sbom.Document) error {
+	data := bytes.TrimSpace(s.Data)
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[:i]
+	}
+
+	envelope := &Envelope{}
+	if err := json.Unmarshal(data, envelope); err == nil && envelope.PayloadType != "" {
+		if envelope.PayloadType != PayloadTypeInToto {
+			return fmt.Errorf("envelope payload type %q is not an in-toto statement", envelope.PayloadType)
+		}
+		data = envelope.Payload
+	}
+
+	statement := &Statement{}
+	if err := json.Unmarshal(data, statement); err != nil {
+		return fmt.Errorf("parsing in-toto statement: %w", err)
+	}
+	if !strings.HasPrefix(statement.Type, inTotoStatementPrefix) {
+		return fmt.Errorf("not an in-toto statement: %q", statement.Type)
+	}
+
+	// SLSA v0.2 and v1 predicates store the builder and build type in
+	// different places
+	predicate := struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+		BuildType       string `json:"buildType"`
+		BuildDefinition struct {
+			BuildType string `json:"buildType"`
+		} `json:"buildDefinition"`
+		RunDetails struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+		} `json:"runDetails"`
+	}{}
+	if len(statement.Predicate) > 0 {
+		if err := json.Unmarshal(statement.Predicate, &predicate); err != nil {
+			return fmt.Errorf("parsing provenance predicate: %w", err)
+		}
+	}
+
+	md := doc.Metadata
+	md.SetProperty(PropertyProvenancePredicateType, statement.PredicateType)
+	if id := cmp.Or(predicate.Builder.ID, predicate.RunDetails.Builder.ID); id != "" {
+		md.SetProperty(PropertyProvenanceBuilderID, id)
+	}
+	if bt := cmp.Or(predicate.BuildType, predicate.BuildDefinition.BuildType); bt != "" {
+		md.SetProperty(PropertyProvenanceBuildType, bt)
+	}
+	md.RemoveProperty(PropertyProvenanceSubject)
+	for _, subject := range statement.Subject {
+		value := subject.Name
+		if d, ok := subject.Digest["sha256"]; ok {
+			value += "@sha256:" + d
+		}
+		md.Properties = append(md.Properties, &sbom.Property{Name: PropertyProvenanceSubject, Data: value})
+	}
+	return nil
+}
+
+// applyAnnotations adds the properties of the annotations to the document
+func (s *Sidecar) applyAnnotations(doc *sbom.Document) error {
+	annotations := &sidecarAnnotations{}
+	if err := yaml.Unmarshal(s.Data, annotations); err != nil {
+		return fmt.Errorf("parsing annotations: %w", err)
+	}
+	for _, name := range slices.Sorted(maps.Keys(annotations.Document)) {
+		doc.Metadata.SetProperty(name, annotations.Document[name])
+	}
+	for i, a := range annotations.Nodes {
+		selector := &sbom.NodeSelector{
+			Id: a.Selector.ID, Purl: a.Selector.Purl, Name: a.Selector.Name, Version: a.Selector.Version,
+		}
+		if selector.IsEmpty() {
+			return fmt.Errorf("node annotation #%d: %w", i, sbom.ErrEmptySelector)
+		}
+		for _, n := range doc.GetNodeList().GetNodes() {
+			if !selector.Matches(n) {
+				continue
+			}
+			for _, name := range slices.Sorted(maps.Keys(a.Properties)) {
+				n.Properties = append(n.Properties, &sbom.Property{Name: name, Data: a.Properties[name]})
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/unserializers"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestSidecarTypeFromPath(t *testing.T) {
+	for path, st := range map[string]reader.SidecarType{
+		"sbom.spdx.json.sig":         reader.SidecarSignature,
+		"build.intoto.jsonl":         reader.SidecarProvenance,
+		"dir/app.provenance.json":    reader.SidecarProvenance,
+		"annotations.YAML":           reader.SidecarAnnotations,
+		"curations.overlay.json":     reader.SidecarOverlay,
+		"sbom.spdx.json":             "",
+		"/tmp/sidecars/no-extension": "",
+	} {
+		t.Run(path, func(t *testing.T) {
+			require.Equal(t, st, reader.SidecarTypeFromPath(path))
+		})
+	}
+}
+
+func TestParseWithSidecars(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	verifier, err := reader.NewPublicKeyVerifier(pub)
+	require.NoError(t, err)
+
+	provenance, err := json.Marshal(map[string]any{
+		"_type":         "https://in-toto.io/Statement/v1",
+		"predicateType": "https://slsa.dev/provenance/v1",
+		"subject":       []any{map[string]any{"name": "app", "digest": map[string]string{"sha256": "abc"}}},
+		"predicate": map[string]any{
+			"buildDefinition": map[string]any{"buildType": "https://example.com/build/v1"},
+			"runDetails":      map[string]any{"builder": map[string]any{"id": "https://example.com/builder"}},
+		},
+	})
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name string
+		// file is the name of the sidecar loaded from disk, when empty the
+		// sidecar is passed as is
+		file     string
+		data     []byte
+		sidecar  *reader.Sidecar
+		verifier reader.EnvelopeVerifier
+		mustErr  bool
+		// signature is the verification state of the detached signature
+		signature      *sbom.Signature_Verification
+		properties     map[string]string
+		nodeProperties []*sbom.Property
+		license        string
+	}{
+		{
+			name:      "signature",
+			file:      "sbom.spdx.json.sig",
+			data:      []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(attestedSPDX))) + "\n"),
+			verifier:  verifier,
+			signature: sbom.Signature_VERIFIED.Enum(),
+		},
+		{
+			name:      "signature without verifier",
+			file:      "sbom.spdx.json.sig",
+			data:      []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(priv, []byte(attestedSPDX))) + "\n"),
+			signature: sbom.Signature_UNVERIFIED.Enum(),
+		},
+		{
+			name: "provenance",
+			file: "sbom.intoto.jsonl",
+			data: newEnvelope(t, provenance, nil),
+			properties: map[string]string{
+				reader.PropertyProvenancePredicateType: "https://slsa.dev/provenance/v1",
+				reader.PropertyProvenanceBuilderID:     "https://example.com/builder",
+				reader.PropertyProvenanceBuildType:     "https://example.com/build/v1",
+				reader.PropertyProvenanceSubject:       "app@sha256:abc",
+			},
+		},
+		{
+			name: "annotations",
+			file: "annotations.yaml",
+			data: []byte(`
+document:
+  release: unreleased
+nodes:
+  - selector:
+      name: a
+    properties:
+      team: platform
+`),
+			properties:     map[string]string{"release": "unreleased"},
+			nodeProperties: []*sbom.Property{{Name: "team", Data: "platform"}},
+		},
+		{
+			name: "overlay",
+			file: "curations.overlay.json",
+			data: []byte(`{
+  "corrections": [{"selector": {"name": "a"}, "patch": {"licenseConcluded": "MIT"}}]
+}`),
+			license: "MIT",
+		},
+		{
+			// Invalid sidecars fail the read
+			name:    "invalid provenance",
+			sidecar: &reader.Sidecar{Type: reader.SidecarProvenance, Name: "bad", Data: []byte("{}")},
+			mustErr: true,
+		},
+		{
+			name:    "unknown sidecar type",
+			file:    "sbom.json",
+			data:    []byte("{}"),
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := tc.sidecar
+			if tc.file != "" {
+				path := filepath.Join(t.TempDir(), tc.file)
+				require.NoError(t, os.WriteFile(path, tc.data, 0o600))
+				sc, err = reader.LoadSidecar(path)
+				if tc.mustErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				sc.Verifier = tc.verifier
+			}
+
+			doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader([]byte(attestedSPDX)), &reader.Options{
+				UnserializeOptions: &native.UnserializeOptions{},
+				Sidecars:           []*reader.Sidecar{sc},
+			})
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.signature == nil {
+				require.Empty(t, doc.Metadata.Signatures)
+			} else {
+				require.Len(t, doc.Metadata.Signatures, 1)
+				require.Equal(t, sbom.Signature_DETACHED, doc.Metadata.Signatures[0].Format)
+				require.Equal(t, *tc.signature, doc.Metadata.Signatures[0].Verification)
+			}
+			for name, value := range tc.properties {
+				require.Equal(t, value, doc.Metadata.GetProperty(name).GetData(), name)
+			}
+
+			node := doc.NodeList.GetNodeByID("Package-a")
+			require.NotNil(t, node)
+			require.Equal(t, tc.nodeProperties, node.Properties)
+			require.Equal(t, tc.license, node.LicenseConcluded)
+		})
+	}
+}
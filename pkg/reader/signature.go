@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// DetachedSignature is a signature of the document distributed separately
+// from it, eg a cosign .sig file. The signature is computed over the bytes
+// of the document as read.
+type DetachedSignature struct {
+	// Signature is the raw signature value
+	Signature []byte
+
+	// KeyID identifies the key used to sign, it is recorded as is
+	KeyID string
+
+	// Certificates is the certificate chain of the signer, leaf first.
+	// The chain is always recorded but it is only trusted when validated
+	// with VerifyOptions.
+	Certificates []*x509.Certificate
+
+	// VerifyOptions validates the certificate chain against its roots
+	// pool. The signer identity is only recorded from a validated leaf
+	// certificate. The rest of the chain is added to the intermediates.
+	VerifyOptions *x509.VerifyOptions
+
+	// Verifier checks the signature. When not set, the signature is
+	// checked with the key of the leaf certificate if it was validated
+	// and left unverified otherwise.
+	Verifier EnvelopeVerifier
+}
+
+// signature returns the record of the detached signature of data
+func (ds *DetachedSignature) signature(data []byte) *sbom.Signature {
+	s := &sbom.Signature{
+		Format: sbom.Signature_DETACHED,
+		KeyId:  ds.KeyID,
+		Data:   ds.Signature,
+	}
+	for _, c := range ds.Certificates {
+		s.Certificates = append(s.Certificates, c.Raw)
+	}
+
+	// Unvalidated certificates can be issued to anyone, so their key and
+	// identity are not used.
+	var leaf *x509.Certificate
+	if len(ds.Certificates) > 0 && ds.VerifyOptions != nil {
+		if err := ds.validateChain(); err != nil {
+			s.Verification = sbom.Signature_FAILED
+			s.VerificationError = err.Error()
+			return s
+		}
+		leaf = ds.Certificates[0]
+		s.Signer = certificateSigner(leaf)
+	}
+
+	verifier := ds.Verifier
+	if verifier == nil && leaf != nil {
+		v, err := NewPublicKeyVerifier(leaf.PublicKey)
+		if err != nil {
+			s.Verification = sbom.Signature_FAILED
+			s.VerificationError = err.Error()
+			return s
+		}
+		verifier = v
+	}
+	verifySignature(s, verifier, data)
+	return s
+}
+
+// validateChain checks the signer certificate chains to the roots in the
+// verify options
+func (ds *DetachedSignature) validateChain() error {
+	opts := *ds.VerifyOptions
+	if opts.Intermediates == nil {
+		opts.Intermediates = x509.NewCertPool()
+	} else {
+		opts.Intermediates = opts.Intermediates.Clone()
+	}
+	for _, c := range ds.Certificates[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	if _, err := ds.Certificates[0].Verify(opts); err != nil {
+		return fmt.Errorf("validating signer certificate: %w", err)
+	}
+	return nil
+}
+
+// verifySignature checks the signature of data and records the result
+func verifySignature(s *sbom.Signature, verifier EnvelopeVerifier, data []byte) {
+	if verifier == nil {
+		return
+	}
+	if err := verifier.Verify(data, s.Data); err != nil {
+		s.Verification = sbom.Signature_FAILED
+		s.VerificationError = err.Error()
+		return
+	}
+	s.Verification = sbom.Signature_VERIFIED
+}
+
+// envelopeSignatures returns the records of the signatures of a DSSE
+// envelope, checked with the verifier when one is set.
+func envelopeSignatures(e *Envelope, verifier EnvelopeVerifier) []*sbom.Signature {
+	ret := []*sbom.Signature{}
+	data := PAE(e.PayloadType, e.Payload)
+	for _, es := range e.Signatures {
+		s := &sbom.Signature{
+			Format:      sbom.Signature_DSSE,
+			KeyId:       es.KeyID,
+			Data:        es.Sig,
+			PayloadType: e.PayloadType,
+		}
+		verifySignature(s, verifier, data)
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// jsfSigner is a signer in a JSON Signature Format (JSF) signature
+type jsfSigner struct {
+	Algorithm       string   `json:"algorithm"`
+	KeyID           string   `json:"keyId"`
+	Value           string   `json:"value"`
+	CertificatePath []string `json:"certificatePath"`
+}
+
+// jsfSignature is a JSF signature. It holds a single signer or a list of
+// signers or a chain of signatures.
+type jsfSignature struct {
+	jsfSigner
+	Signers []jsfSigner `json:"signers"`
+	Chain   []jsfSigner `json:"chain"`
+}
+
+// jsfSignatures returns the records of the JSF signatures embedded in a
+// CycloneDX JSON document. JSF signatures are computed over the canonical
+// form of the document, they are recorded unverified. Signers that can't be
+// decoded are recorded as failed.
+func jsfSignatures(data []byte) []*sbom.Signature {
+	if !bytes.Contains(data, []byte(`"signature"`)) {
+		return nil
+	}
+	doc := struct {
+		Signature *jsfSignature `json:"signature"`
+	}{}
+	if err := json.Unmarshal(data, &doc); err != nil || doc.Signature == nil {
+		return nil
+	}
+
+	signers := append(doc.Signature.Signers, doc.Signature.Chain...) //nolint:gocritic
+	if doc.Signature.Value != "" {
+		signers = append(signers, doc.Signature.jsfSigner)
+	}
+
+	ret := []*sbom.Signature{}
+	for _, signer := range signers {
+		s := &sbom.Signature{
+			Format:    sbom.Signature_JSF,
+			Algorithm: signer.Algorithm,
+			KeyId:     signer.KeyID,
+		}
+		if err := decodeJSFSigner(s, &signer); err != nil {
+			s.Verification = sbom.Signature_FAILED
+			s.VerificationError = err.Error()
+		}
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// decodeJSFSigner records the signature value and certificates of a JSF
+// signer
+func decodeJSFSigner(s *sbom.Signature, signer *jsfSigner) error {
+	value, err := decodeBase64(signer.Value)
+	if err != nil {
+		return fmt.Errorf("decoding JSF signature value: %w", err)
+	}
+	s.Data = value
+	for i, pc := range signer.CertificatePath {
+		der, err := decodeBase64(pc)
+		if err != nil {
+			return fmt.Errorf("decoding JSF certificate: %w", err)
+		}
+		s.Certificates = append(s.Certificates, der)
+		if i == 0 {
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return fmt.Errorf("parsing JSF signer certificate: %w", err)
+			}
+			s.Signer = certificateSigner(cert)
+		}
+	}
+	return nil
+}
+
+// decodeBase64 decodes base64 data in any of its standard or URL-safe,
+// padded or unpadded forms. JSF uses unpadded base64url for the signature
+// values and standard base64 for the certificates.
+func decodeBase64(s string) ([]byte, error) {
+	var errs []error
+	for _, enc := range []*base64.Encoding{
+		base64.RawURLEncoding, base64.URLEncoding, base64.StdEncoding, base64.RawStdEncoding,
+	} {
+		data, err := enc.DecodeString(s)
+		if err == nil {
+			return data, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// certificateSigner returns the identity of the certificate subject: the
+// first email or URI subject alternative name, as issued by sigstore, or
+// the common name.
+func certificateSigner(cert *x509.Certificate) string {
+	if len(cert.EmailAddresses) > 0 {
+		return cert.EmailAddresses[0]
+	}
+	if len(cert.URIs) > 0 {
+		return cert.URIs[0].String()
+	}
+	return cert.Subject.CommonName
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/unserializers"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// newSignerCertificate returns a self-signed certificate for the key with
+// the email as subject alternative name.
+func newSignerCertificate(t *testing.T, pub ed25519.PublicKey, priv ed25519.PrivateKey, email string) *x509.Certificate {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(1),
+		Subject:        pkix.Name{CommonName: "signer"},
+		EmailAddresses: []string{email},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, pub, priv)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestParseSignatures(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+	reader.RegisterUnserializer(formats.CDX15JSON, unserializers.NewCDX("1.5", formats.JSON))
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	verifier, err := reader.NewPublicKeyVerifier(pub)
+	require.NoError(t, err)
+	cert := newSignerCertificate(t, pub, priv, "jane@example.com")
+
+	parse := func(t *testing.T, data []byte, o *reader.Options) *sbom.Document {
+		t.Helper()
+		o.UnserializeOptions = &native.UnserializeOptions{}
+		doc, err := reader.New().ParseStreamWithOptions(bytes.NewReader(data), o)
+		require.NoError(t, err)
+		return doc
+	}
+
+	t.Run("dsse", func(t *testing.T) {
+		envelope := newEnvelope(t, newStatement(t, json.RawMessage(attestedSPDX)), func(data []byte) []byte {
+			return ed25519.Sign(priv, data)
+		})
+
+		doc := parse(t, envelope, &reader.Options{})
+		require.Len(t, doc.Metadata.Signatures, 1)
+		require.Equal(t, sbom.Signature_DSSE, doc.Metadata.Signatures[0].Format)
+		require.Equal(t, reader.PayloadTypeInToto, doc.Metadata.Signatures[0].PayloadType)
+		require.Equal(t, sbom.Signature_UNVERIFIED, doc.Metadata.Signatures[0].Verification)
+
+		doc = parse(t, envelope, &reader.Options{EnvelopeVerifier: verifier})
+		require.Len(t, doc.Metadata.Signatures, 1)
+		require.Equal(t, sbom.Signature_VERIFIED, doc.Metadata.Signatures[0].Verification)
+	})
+
+	t.Run("detached", func(t *testing.T) {
+		sig := ed25519.Sign(priv, []byte(attestedSPDX))
+		badSig := bytes.Clone(sig)
+		badSig[0] ^= 0xff
+		roots := x509.NewCertPool()
+		roots.AddCert(cert)
+		otherPub, otherPriv, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		otherRoots := x509.NewCertPool()
+		otherRoots.AddCert(newSignerCertificate(t, otherPub, otherPriv, "ca@example.com"))
+
+		for name, tc := range map[string]struct {
+			ds           *reader.DetachedSignature
+			verification sbom.Signature_Verification
+			signer       string
+			signedBy     string
+		}{
+			"unvalidated certificate": {
+				ds:           &reader.DetachedSignature{Signature: sig, Certificates: []*x509.Certificate{cert}},
+				verification: sbom.Signature_UNVERIFIED,
+			},
+			"validated certificate": {
+				ds: &reader.DetachedSignature{
+					Signature: sig, Certificates: []*x509.Certificate{cert},
+					VerifyOptions: &x509.VerifyOptions{Roots: roots},
+				},
+				verification: sbom.Signature_VERIFIED,
+				signer:       "jane@example.com",
+				signedBy:     "jane@example.com",
+			},
+			"untrusted certificate": {
+				ds: &reader.DetachedSignature{
+					Signature: sig, Certificates: []*x509.Certificate{cert},
+					VerifyOptions: &x509.VerifyOptions{Roots: otherRoots},
+				},
+				verification: sbom.Signature_FAILED,
+			},
+			"verifier ignores the certificate identity": {
+				ds: &reader.DetachedSignature{
+					Signature: sig, KeyID: "key-1", Certificates: []*x509.Certificate{cert}, Verifier: verifier,
+				},
+				verification: sbom.Signature_VERIFIED,
+				signedBy:     "key-1",
+			},
+			"bad signature": {
+				ds:           &reader.DetachedSignature{Signature: badSig, KeyID: "key-1", Verifier: verifier},
+				verification: sbom.Signature_FAILED,
+			},
+		} {
+			t.Run(name, func(t *testing.T) {
+				doc := parse(t, []byte(attestedSPDX), &reader.Options{DetachedSignature: tc.ds})
+				require.Len(t, doc.Metadata.Signatures, 1)
+				s := doc.Metadata.Signatures[0]
+				require.Equal(t, sbom.Signature_DETACHED, s.Format)
+				require.Equal(t, tc.verification, s.Verification)
+				require.Equal(t, tc.verification == sbom.Signature_FAILED, s.VerificationError != "")
+				require.Equal(t, tc.signer, s.Signer)
+				require.Len(t, s.Certificates, len(tc.ds.Certificates))
+				// Only validated certificates vouch for the signer identity
+				require.Equal(t, tc.signer != "", doc.Metadata.IsSignedBy("jane@example.com"))
+				if tc.signedBy != "" {
+					require.True(t, doc.Metadata.IsSignedBy(tc.signedBy))
+				}
+			})
+		}
+	})
+
+	t.Run("jsf", func(t *testing.T) {
+		value := base64.RawURLEncoding.EncodeToString([]byte("signature"))
+		cdxJSON := fmt.Sprintf(`{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.5",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app"}},
+  "signature": {
+    "algorithm": "Ed25519",
+    "keyId": "key-1",
+    "certificatePath": [%q],
+    "value": %q
+  }
+}`, base64.StdEncoding.EncodeToString(cert.Raw), value)
+
+		doc := parse(t, []byte(cdxJSON), &reader.Options{})
+		require.Len(t, doc.Metadata.Signatures, 1)
+		s := doc.Metadata.Signatures[0]
+		require.Equal(t, sbom.Signature_JSF, s.Format)
+		require.Equal(t, "Ed25519", s.Algorithm)
+		require.Equal(t, "key-1", s.KeyId)
+		require.Equal(t, []byte("signature"), s.Data)
+		require.Equal(t, "jane@example.com", s.Signer)
+		require.Equal(t, sbom.Signature_UNVERIFIED, s.Verification)
+		require.False(t, doc.Metadata.IsSignedBy("jane@example.com"))
+
+		// Malformed signers are recorded as failed without failing the read
+		doc = parse(t, []byte(strings.Replace(cdxJSON, value, "not base64!", 1)), &reader.Options{})
+		require.Len(t, doc.Metadata.Signatures, 1)
+		require.Equal(t, sbom.Signature_FAILED, doc.Metadata.Signatures[0].Verification)
+		require.Contains(t, doc.Metadata.Signatures[0].VerificationError, "signature value")
+		require.NotEmpty(t, doc.NodeList.Nodes)
+	})
+}
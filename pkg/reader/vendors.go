@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Tool and property names identifying the documents generated by the
+// GitHub and GitLab dependency scanners.
+const (
+	githubDependencyGraphTool = "GitHub.com-Dependency-Graph"
+	gitlabPropertyPrefix      = "gitlab:"
+	gitlabInputFileProperty   = "gitlab:dependency_scanning:input_file:path"
+)
+
+// PropertyGitHubVersionRange records the version range of the packages
+// listed by GitHub with an unresolved version.
+const PropertyGitHubVersionRange = "github:version_range"
+
+// githubEcosystemPrefix matches the ecosystem prefix of GitHub package
+// names, eg the "npm:" in "npm:express".
+var githubEcosystemPrefix = regexp.MustCompile(`^[a-z]+:`)
+
+// unwrapGitHubSBOM returns the SPDX document in the responses of the GitHub
+// dependency graph SBOM API, which wrap it in an "sbom" field.
+func unwrapGitHubSBOM(data []byte) ([]byte, bool) {
+	if !bytes.Contains(data, []byte(`"sbom"`)) {
+		return nil, false
+	}
+	response := struct {
+		SBOM json.RawMessage `json:"sbom"`
+	}{}
+	if err := json.Unmarshal(data, &response); err != nil || len(response.SBOM) == 0 {
+		return nil, false
+	}
+	spdx := struct {
+		SPDXVersion string `json:"spdxVersion"`
+	}{}
+	if err := json.Unmarshal(response.SBOM, &spdx); err != nil || spdx.SPDXVersion == "" {
+		return nil, false
+	}
+	return response.SBOM, true
+}
+
+// IsGitHubDocument returns true if the document was generated by the GitHub
+// dependency graph.
+func IsGitHubDocument(doc *sbom.Document) bool {
+	for _, t := range doc.GetMetadata().GetTools() {
+		if strings.Contains(t.GetName(), githubDependencyGraphTool) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsGitLabDocument returns true if the document was generated by the GitLab
+// dependency scanners.
+func IsGitLabDocument(doc *sbom.Document) bool {
+	for _, p := range doc.GetMetadata().GetProperties() {
+		if strings.HasPrefix(p.GetName(), gitlabPropertyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeVendorDocument cleans up the quirks of the documents generated
+// by the GitHub and GitLab dependency scanners.
+func normalizeVendorDocument(doc *sbom.Document) {
+	if doc.GetNodeList() == nil {
+		return
+	}
+	switch {
+	case IsGitHubDocument(doc):
+		normalizeGitHubDocument(doc)
+	case IsGitLabDocument(doc):
+		normalizeGitLabDocument(doc)
+	}
+}
+
+// normalizeGitHubDocument removes the ecosystem prefix from the package
+// names and moves the unresolved version ranges to a property.
+func normalizeGitHubDocument(doc *sbom.Document) {
+	for _, n := range doc.NodeList.Nodes {
+		if n.Purl() != "" {
+			n.Name = githubEcosystemPrefix.ReplaceAllString(n.Name, "")
+		}
+		if isVersionRange(n.Version) {
+			n.Properties = append(n.Properties, &sbom.Property{Name: PropertyGitHubVersionRange, Data: n.Version})
+			n.Version = ""
+		}
+	}
+}
+
+// isVersionRange returns true if the version is a range specifier instead
+// of a resolved version.
+func isVersionRange(v string) bool {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return false
+	}
+	return strings.ContainsAny(v[:1], "^~<>=!") || strings.ContainsAny(v, " *,|")
+}
+
+// normalizeGitLabDocument builds the dependency graph missing from the
+// GitLab documents. The components are listed flat, so they are related
+// to a node describing the scanned manifest.
+func normalizeGitLabDocument(doc *sbom.Document) {
+	if len(doc.NodeList.Edges) > 0 {
+		return
+	}
+
+	path := ""
+	for _, p := range doc.Metadata.Properties {
+		if p.Name == gitlabInputFileProperty {
+			path = p.Data
+		}
+	}
+	if path == "" {
+		return
+	}
+
+	components := slices.Clone(doc.NodeList.RootElements)
+	root := &sbom.Node{
+		Id:   "gitlab-input-file-" + strings.NewReplacer("/", "-", " ", "-").Replace(path),
+		Type: sbom.Node_FILE,
+		Name: path,
+	}
+	if doc.NodeList.GetNodeByID(root.Id) != nil {
+		return
+	}
+	doc.NodeList.RootElements = []string{}
+	doc.NodeList.AddRootNode(root)
+	if len(components) > 0 {
+		doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: root.Id, To: components})
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package reader_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/native/unserializers"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func propertyValue(n *sbom.Node, name string) string {
+	for _, p := range n.Properties {
+		if p.Name == name {
+			return p.Data
+		}
+	}
+	return ""
+}
+
+func TestParseGitHubSBOM(t *testing.T) {
+	reader.RegisterUnserializer(formats.SPDX23JSON, unserializers.NewSPDX23())
+
+	type node struct {
+		name, version, versionRange string
+	}
+	for _, tc := range []struct {
+		name      string
+		normalize bool
+		nodes     map[string]node
+		root      string
+	}{
+		{
+			// The data is left as is unless the normalization is enabled
+			name: "not normalized",
+			nodes: map[string]node{
+				"npm-express-4.18.2":              {name: "npm:express", version: "4.18.2"},
+				"actions-actions-checkout-4.-.-.": {name: "actions:actions/checkout", version: "4.*.*"},
+				"pip-requests":                    {name: "pip:requests", version: ">= 2.31.0"},
+			},
+			root: "com.github.example/webapp",
+		},
+		{
+			name:      "normalized",
+			normalize: true,
+			nodes: map[string]node{
+				"npm-express-4.18.2":              {name: "express", version: "4.18.2"},
+				"actions-actions-checkout-4.-.-.": {name: "actions/checkout", versionRange: "4.*.*"},
+				"pip-requests":                    {name: "requests", versionRange: ">= 2.31.0"},
+			},
+			// The repository name has no ecosystem prefix
+			root: "com.github.example/webapp",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := reader.New().ParseFileWithOptions("testdata/github-sbom-api.json", &reader.Options{
+				UnserializeOptions:       &native.UnserializeOptions{},
+				NormalizeVendorDocuments: tc.normalize,
+			})
+			require.NoError(t, err)
+			require.True(t, reader.IsGitHubDocument(doc))
+			require.Len(t, doc.NodeList.Nodes, 4)
+			for id, expected := range tc.nodes {
+				n := doc.NodeList.GetNodeByID(id)
+				require.NotNil(t, n, id)
+				require.Equal(t, expected.name, n.Name)
+				require.Equal(t, expected.version, n.Version)
+				require.Equal(t, expected.versionRange, propertyValue(n, reader.PropertyGitHubVersionRange))
+			}
+			require.Equal(t, tc.root, doc.NodeList.GetRootNodes()[0].Name)
+		})
+	}
+}
+
+func TestParseGitLabCycloneDX(t *testing.T) {
+	reader.RegisterUnserializer(formats.CDX14JSON, unserializers.NewCDX("1.4", formats.JSON))
+
+	for _, tc := range []struct {
+		name      string
+		normalize bool
+		// manifest is the name of the root node, empty when the document
+		// is not normalized
+		manifest string
+		deps     int
+	}{
+		{name: "not normalized"},
+		{name: "normalized", normalize: true, manifest: "web/package-lock.json", deps: 2},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := reader.New().ParseFileWithOptions("testdata/gitlab-cyclonedx.json", &reader.Options{
+				UnserializeOptions:       &native.UnserializeOptions{},
+				NormalizeVendorDocuments: tc.normalize,
+			})
+			require.NoError(t, err)
+			require.True(t, reader.IsGitLabDocument(doc))
+			require.False(t, reader.IsGitHubDocument(doc))
+			if tc.manifest == "" {
+				require.Empty(t, doc.NodeList.Edges)
+				return
+			}
+
+			roots := doc.NodeList.GetRootNodes()
+			require.Len(t, roots, 1)
+			require.Equal(t, tc.manifest, roots[0].Name)
+			require.Equal(t, sbom.Node_FILE, roots[0].Type)
+
+			edge := doc.NodeList.GetEdgeByType(roots[0].Id, sbom.Edge_dependsOn)
+			require.NotNil(t, edge)
+			require.Len(t, edge.To, tc.deps)
+			require.Empty(t, doc.NodeList.Unreachable())
+		})
+	}
+}
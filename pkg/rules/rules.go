@@ -0,0 +1,282 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package rules implements a declarative engine to normalize documents at
+// ingest. Rule sets are written in YAML and can rename properties, map custom
+// relationship labels to protobom edge types and drop vendor-specific nodes:
+//
+//	edgeTypes:
+//	  BUILT_WITH: buildTool
+//	rules:
+//	  - name: normalize build id
+//	    renameProperties:
+//	      acme:build-id: build-id
+//	  - name: drop vendored files
+//	    match:
+//	      type: FILE
+//	      name: "^vendor/"
+//	    drop: true
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// RuleSet is a collection of rules applied to documents in order.
+type RuleSet struct {
+	// EdgeTypes maps relationship labels to protobom edge type names. Keys
+	// can be custom labels found in the source documents or protobom edge
+	// type names to retype existing edges.
+	EdgeTypes map[string]string `yaml:"edgeTypes,omitempty"`
+
+	// Rules is the list of node rules to apply
+	Rules []*Rule `yaml:"rules,omitempty"`
+
+	edgeTypes map[string]sbom.Edge_Type
+	compiled  bool
+}
+
+// Rule captures a set of transformations applied to the nodes selected
+// by its match criteria.
+type Rule struct {
+	// Name is a human-readable name to identify the rule
+	Name string `yaml:"name,omitempty"`
+
+	// Match selects the nodes the rule applies to. When not set, the rule
+	// applies to all nodes.
+	Match *Match `yaml:"match,omitempty"`
+
+	// RenameProperties maps property names to their new names
+	RenameProperties map[string]string `yaml:"renameProperties,omitempty"`
+
+	// Drop removes the matched nodes from the document
+	Drop bool `yaml:"drop,omitempty"`
+}
+
+// Match defines the criteria to select nodes. All non-empty fields must
+// match for a node to be selected.
+type Match struct {
+	// Type is the node type, PACKAGE or FILE
+	Type string `yaml:"type,omitempty"`
+
+	// Name is a regular expression matched against the node name
+	Name string `yaml:"name,omitempty"`
+
+	// Purl is a prefix matched against the node package URL
+	Purl string `yaml:"purl,omitempty"`
+
+	// Supplier is a regular expression matched against the supplier names
+	Supplier string `yaml:"supplier,omitempty"`
+
+	// Property selects nodes having a property with this name
+	Property string `yaml:"property,omitempty"`
+
+	nodeType   sbom.Node_NodeType
+	nameRe     *regexp.Regexp
+	supplierRe *regexp.Regexp
+}
+
+// Load reads a YAML rule set from r and validates it.
+func Load(r io.Reader) (*RuleSet, error) {
+	rs := &RuleSet{}
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(rs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("decoding rule set: %w", err)
+	}
+
+	if err := rs.compile(); err != nil {
+		return nil, err
+	}
+	return rs, nil
+}
+
+// LoadFile reads a YAML rule set from a file.
+func LoadFile(path string) (*RuleSet, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening rule set: %w", err)
+	}
+	defer f.Close()
+
+	return Load(f)
+}
+
+// Validate checks the rule set, returning an error if any of the edge
+// types or regular expressions is invalid.
+func (rs *RuleSet) Validate() error {
+	rs.compiled = false
+	return rs.compile()
+}
+
+// compile parses the edge types and regular expressions in the rule set
+func (rs *RuleSet) compile() error {
+	if rs.compiled {
+		return nil
+	}
+
+	rs.edgeTypes = map[string]sbom.Edge_Type{}
+	for label, typeName := range rs.EdgeTypes {
+		t, ok := sbom.Edge_Type_value[typeName]
+		if !ok {
+			return fmt.Errorf("unknown edge type %q mapped from %q", typeName, label)
+		}
+		rs.edgeTypes[label] = sbom.Edge_Type(t)
+	}
+
+	for i, r := range rs.Rules {
+		if r == nil {
+			return fmt.Errorf("rule #%d is empty", i)
+		}
+		if r.Match == nil {
+			continue
+		}
+		if err := r.Match.compile(); err != nil {
+			return fmt.Errorf("compiling rule #%d (%s): %w", i, r.Name, err)
+		}
+	}
+
+	rs.compiled = true
+	return nil
+}
+
+func (m *Match) compile() error {
+	var err error
+	if m.Type != "" {
+		t, ok := sbom.Node_NodeType_value[strings.ToUpper(m.Type)]
+		if !ok {
+			return fmt.Errorf("unknown node type %q", m.Type)
+		}
+		m.nodeType = sbom.Node_NodeType(t)
+	}
+
+	m.nameRe, m.supplierRe = nil, nil
+	if m.Name != "" {
+		if m.nameRe, err = regexp.Compile(m.Name); err != nil {
+			return fmt.Errorf("parsing name expression: %w", err)
+		}
+	}
+	if m.Supplier != "" {
+		if m.supplierRe, err = regexp.Compile(m.Supplier); err != nil {
+			return fmt.Errorf("parsing supplier expression: %w", err)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the node matches all the criteria defined. A nil
+// match selects all nodes.
+func (m *Match) Matches(n *sbom.Node) bool {
+	if m == nil {
+		return true
+	}
+	if m.Type != "" && n.Type != m.nodeType {
+		return false
+	}
+	if m.nameRe != nil && !m.nameRe.MatchString(n.Name) {
+		return false
+	}
+	if m.Purl != "" && !strings.HasPrefix(string(n.Purl()), m.Purl) {
+		return false
+	}
+	if m.supplierRe != nil {
+		found := false
+		for _, s := range n.Suppliers {
+			if m.supplierRe.MatchString(s.GetName()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if m.Property != "" {
+		found := false
+		for _, p := range n.Properties {
+			if p.GetName() == m.Property {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// CustomEdgeTypes returns the relationship labels mapped to edge types in
+// the rule set. The map is suitable to be set in the unserializer options.
+func (rs *RuleSet) CustomEdgeTypes() (map[string]sbom.Edge_Type, error) {
+	if err := rs.compile(); err != nil {
+		return nil, err
+	}
+	return maps.Clone(rs.edgeTypes), nil
+}
+
+// Apply runs the rule set on the document, modifying it in place.
+func (rs *RuleSet) Apply(doc *sbom.Document) error {
+	if err := rs.compile(); err != nil {
+		return err
+	}
+	if doc == nil || doc.NodeList == nil {
+		return nil
+	}
+
+	drop := []string{}
+	dropIdx := map[string]struct{}{}
+	for _, r := range rs.Rules {
+		for _, n := range doc.NodeList.Nodes {
+			if _, ok := dropIdx[n.Id]; ok {
+				continue
+			}
+			if !r.Match.Matches(n) {
+				continue
+			}
+
+			for _, p := range n.Properties {
+				if newName, ok := r.RenameProperties[p.GetName()]; ok {
+					p.Name = newName
+				}
+			}
+
+			if r.Drop {
+				drop = append(drop, n.Id)
+				dropIdx[n.Id] = struct{}{}
+			}
+		}
+	}
+
+	for _, e := range doc.NodeList.Edges {
+		if t, ok := rs.edgeTypes[e.Type.String()]; ok {
+			e.Type = t
+		}
+	}
+
+	if len(drop) > 0 {
+		doc.NodeList.RemoveNodes(drop)
+		roots := []string{}
+		for _, id := range doc.NodeList.RootElements {
+			if _, ok := dropIdx[id]; !ok {
+				roots = append(roots, id)
+			}
+		}
+		doc.NodeList.RootElements = roots
+	}
+
+	return nil
+}
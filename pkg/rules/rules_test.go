@@ -0,0 +1,187 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package rules
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+const testRuleSet = `
+edgeTypes:
+  BUILT_WITH: buildTool
+  other: dependsOn
+rules:
+  - name: normalize build id
+    renameProperties:
+      acme:build-id: build-id
+  - name: drop vendored files
+    match:
+      type: file
+      name: "^vendor/"
+    drop: true
+  - name: drop internal packages
+    match:
+      purl: pkg:generic/acme-internal
+    drop: true
+`
+
+func TestLoad(t *testing.T) {
+	for name, tc := range map[string]struct {
+		data    string
+		mustErr bool
+	}{
+		"valid":             {testRuleSet, false},
+		"empty":             {"", false},
+		"unknown edge type": {"edgeTypes:\n  BUILT_WITH: builtWith\n", true},
+		"unknown node type": {"rules:\n  - match:\n      type: DIRECTORY\n", true},
+		"invalid regexp":    {"rules:\n  - match:\n      name: \"[\"\n", true},
+		"unknown field":     {"rules:\n  - dorp: true\n", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tc.data))
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		rules      string
+		nodes      []string
+		properties []string
+		edges      []*sbom.Edge
+	}{
+		{
+			name:       "rename properties",
+			rules:      "rules:\n  - renameProperties:\n      acme:build-id: build-id\n",
+			nodes:      []string{"app", "file1", "file2", "internal"},
+			properties: []string{"build-id"},
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"file1", "file2"}},
+				{Type: sbom.Edge_other, From: "app", To: []string{"internal"}},
+			},
+		},
+		{
+			name:       "drop by type and name",
+			rules:      "rules:\n  - match:\n      type: file\n      name: \"^vendor/\"\n    drop: true\n",
+			nodes:      []string{"app", "file2", "internal"},
+			properties: []string{"acme:build-id"},
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"file2"}},
+				{Type: sbom.Edge_other, From: "app", To: []string{"internal"}},
+			},
+		},
+		{
+			name:       "drop by purl",
+			rules:      "rules:\n  - match:\n      purl: pkg:generic/acme-internal\n    drop: true\n",
+			nodes:      []string{"app", "file1", "file2"},
+			properties: []string{"acme:build-id"},
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"file1", "file2"}},
+			},
+		},
+		{
+			name:       "all rules",
+			rules:      testRuleSet,
+			nodes:      []string{"app", "file2"},
+			properties: []string{"build-id"},
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"file2"}},
+			},
+		},
+		{
+			name:       "no rules",
+			nodes:      []string{"app", "file1", "file2", "internal"},
+			properties: []string{"acme:build-id"},
+			edges: []*sbom.Edge{
+				{Type: sbom.Edge_contains, From: "app", To: []string{"file1", "file2"}},
+				{Type: sbom.Edge_other, From: "app", To: []string{"internal"}},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := Load(strings.NewReader(tc.rules))
+			require.NoError(t, err)
+
+			doc := &sbom.Document{
+				NodeList: &sbom.NodeList{
+					Nodes: []*sbom.Node{
+						{
+							Id: "app", Name: "app", Type: sbom.Node_PACKAGE,
+							Properties: []*sbom.Property{{Name: "acme:build-id", Data: "1234"}},
+						},
+						{Id: "file1", Name: "vendor/lib.go", Type: sbom.Node_FILE},
+						{Id: "file2", Name: "main.go", Type: sbom.Node_FILE},
+						{
+							Id: "internal", Name: "internal", Type: sbom.Node_PACKAGE,
+							Identifiers: map[int32]string{
+								int32(sbom.SoftwareIdentifierType_PURL): "pkg:generic/acme-internal@1.0",
+							},
+						},
+					},
+					Edges: []*sbom.Edge{
+						{Type: sbom.Edge_contains, From: "app", To: []string{"file1", "file2"}},
+						{Type: sbom.Edge_other, From: "app", To: []string{"internal"}},
+					},
+					RootElements: []string{"app"},
+				},
+			}
+
+			require.NoError(t, rs.Apply(doc))
+			ids := []string{}
+			for _, n := range doc.NodeList.Nodes {
+				ids = append(ids, n.Id)
+			}
+			require.Equal(t, tc.nodes, ids)
+			names := []string{}
+			for _, p := range doc.NodeList.GetNodeByID("app").Properties {
+				names = append(names, p.Name)
+			}
+			require.Equal(t, tc.properties, names)
+			require.Equal(t, tc.edges, doc.NodeList.Edges)
+		})
+	}
+}
+
+func TestCustomEdgeTypes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		rules    string
+		expected map[string]sbom.Edge_Type
+	}{
+		{
+			name:  "edge types",
+			rules: testRuleSet,
+			expected: map[string]sbom.Edge_Type{
+				"BUILT_WITH": sbom.Edge_buildTool,
+				"other":      sbom.Edge_dependsOn,
+			},
+		},
+		{
+			name:     "no edge types",
+			expected: map[string]sbom.Edge_Type{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rs, err := Load(strings.NewReader(tc.rules))
+			require.NoError(t, err)
+			edgeTypes, err := rs.CustomEdgeTypes()
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, edgeTypes)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package sbom
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Copy returns a duplicate of the AI model data
+func (m *AIModel) Copy() *AIModel {
+	if m == nil {
+		return nil
+	}
+	return &AIModel{
+		TypeOfModel:                     slices.Clone(m.TypeOfModel),
+		Task:                            m.Task,
+		ModelArchitecture:               m.ModelArchitecture,
+		Domain:                          slices.Clone(m.Domain),
+		InformationAboutApplication:     m.InformationAboutApplication,
+		InformationAboutTraining:        m.InformationAboutTraining,
+		Limitation:                      m.Limitation,
+		Metrics:                         maps.Clone(m.Metrics),
+		Hyperparameters:                 maps.Clone(m.Hyperparameters),
+		AutonomyType:                    m.AutonomyType,
+		SafetyRiskAssessment:            m.SafetyRiskAssessment,
+		UseSensitivePersonalInformation: m.UseSensitivePersonalInformation,
+	}
+}
+
+// flatString returns a deterministic string representing the AI model data
+func (m *AIModel) flatString() string {
+	return fmt.Sprintf(
+		"t(%s)k(%s)a(%s)d(%s)ia(%s)it(%s)l(%s)m(%s)h(%s)at(%s)s(%s)u(%s)",
+		strings.Join(m.TypeOfModel, ","), m.Task, m.ModelArchitecture, strings.Join(m.Domain, ","),
+		m.InformationAboutApplication, m.InformationAboutTraining, m.Limitation,
+		flatStringStrMap(m.Metrics), flatStringStrMap(m.Hyperparameters),
+		m.AutonomyType, m.SafetyRiskAssessment, m.UseSensitivePersonalInformation,
+	)
+}
+
+// Copy returns a duplicate of the dataset data
+func (d *Dataset) Copy() *Dataset {
+	if d == nil {
+		return nil
+	}
+	return &Dataset{
+		DatasetType:                     slices.Clone(d.DatasetType),
+		IntendedUse:                     d.IntendedUse,
+		DataCollectionProcess:           d.DataCollectionProcess,
+		DatasetSize:                     d.DatasetSize,
+		ConfidentialityLevel:            d.ConfidentialityLevel,
+		HasSensitivePersonalInformation: d.HasSensitivePersonalInformation,
+		KnownBias:                       slices.Clone(d.KnownBias),
+		DatasetAvailability:             d.DatasetAvailability,
+		AnonymizationMethodUsed:         slices.Clone(d.AnonymizationMethodUsed),
+	}
+}
+
+// flatString returns a deterministic string representing the dataset data
+func (d *Dataset) flatString() string {
+	return fmt.Sprintf(
+		"t(%s)u(%s)c(%s)s(%d)l(%s)p(%s)b(%s)a(%s)m(%s)",
+		strings.Join(d.DatasetType, ","), d.IntendedUse, d.DataCollectionProcess, d.DatasetSize,
+		d.ConfidentialityLevel, d.HasSensitivePersonalInformation, strings.Join(d.KnownBias, ","),
+		d.DatasetAvailability, strings.Join(d.AnonymizationMethodUsed, ","),
+	)
+}
+
+// flatStringStrMap returns a string of the key-value pairs of a map sorted
+// by key.
+func flatStringStrMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	ret := ""
+	for _, k := range keys {
+		ret += fmt.Sprintf("%s=%s;", k, m[k])
+	}
+	return ret
+}
@@ -0,0 +1,178 @@
+package sbom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// PropertyAnonymized marks the documents produced by Document.Anonymize.
+// Its value is the function used to compute the tokens.
+const PropertyAnonymized = "protobom:anonymization:tokens"
+
+// Token functions recorded in the PropertyAnonymized property
+const (
+	AnonymizationSHA256     = "sha256"
+	AnonymizationHMACSHA256 = "hmac-sha256"
+)
+
+// anonymizedNodeFields are the node fields replaced by tokens
+var anonymizedNodeFields = []string{"id", "name", "version", "identifiers"}
+
+// anonymizedMetadataFields are the metadata fields kept in anonymized
+// documents, besides the tokenized ID and name
+var anonymizedMetadataFields = []string{"id", "name", "version", "date", "documentTypes"}
+
+// AnonymizeOptions configures Document.Anonymize
+type AnonymizeOptions struct {
+	// Salt is the key used to compute the tokens. Documents anonymized with
+	// the same salt get the same tokens for the same values, so they can
+	// still be compared. Without a salt, the tokens of well known names can
+	// be reversed by hashing guesses, so always set one before sharing.
+	Salt string
+
+	// Keep lists the node fields copied verbatim to the anonymized nodes,
+	// relative to the Node, eg "licenses" or "hashes". Keeping the name,
+	// version, identifiers or id disables their tokenization.
+	Keep *fieldmaskpb.FieldMask
+}
+
+// DefaultAnonymizeOptions keeps the node types and purposes
+var DefaultAnonymizeOptions = AnonymizeOptions{
+	Keep: &fieldmaskpb.FieldMask{Paths: []string{"type", "primary_purpose"}},
+}
+
+// anonymizer computes the pseudonymous tokens
+type anonymizer struct {
+	salt string
+}
+
+// token returns the stable token of a value. The kind is part of the
+// token so equal values in different fields get different tokens.
+func (a *anonymizer) token(kind, value string) string {
+	if value == "" {
+		return ""
+	}
+	var sum []byte
+	if a.salt == "" {
+		s := sha256.Sum256([]byte(kind + "\x00" + value))
+		sum = s[:]
+	} else {
+		mac := hmac.New(sha256.New, []byte(a.salt))
+		mac.Write([]byte(kind + "\x00" + value)) //nolint:errcheck // hash writes never fail
+		sum = mac.Sum(nil)
+	}
+	return fmt.Sprintf("%s-%s", kind, hex.EncodeToString(sum[:8]))
+}
+
+// Anonymize returns a copy of the document with the names, versions,
+// identifiers and IDs of the nodes replaced by stable pseudonymous tokens,
+// to share the shape of the graph without disclosing the inventory. The
+// edges and root elements are preserved, pointing to the tokenized IDs.
+//
+// Node fields not listed in the Keep mask are cleared, as are the metadata
+// fields other than the version, date and document types. The document ID
+// and name are tokenized. Vulnerabilities are dropped as they identify the
+// affected components. The document is not modified.
+func (d *Document) Anonymize(opts *AnonymizeOptions) (*Document, error) {
+	if opts == nil {
+		opts = &DefaultAnonymizeOptions
+	}
+
+	nodeDesc := (&Node{}).ProtoReflect().Descriptor()
+	keep, err := newFieldMaskTree(nodeDesc, opts.Keep.GetPaths())
+	if err != nil {
+		return nil, fmt.Errorf("parsing fields to keep: %w", err)
+	}
+	retain, err := newFieldMaskTree(nodeDesc, slices.Concat(opts.Keep.GetPaths(), anonymizedNodeFields))
+	if err != nil {
+		return nil, fmt.Errorf("parsing fields to keep: %w", err)
+	}
+	tokenize := func(field string) bool {
+		_, ok := keep[field]
+		return !ok
+	}
+
+	a := &anonymizer{salt: opts.Salt}
+	doc := d.Clone()
+	if doc.Metadata == nil {
+		doc.Metadata = &Metadata{}
+	}
+	if doc.NodeList == nil {
+		doc.NodeList = &NodeList{}
+	}
+
+	metadataTree, err := newFieldMaskTree(doc.Metadata.ProtoReflect().Descriptor(), anonymizedMetadataFields)
+	if err != nil {
+		return nil, fmt.Errorf("parsing metadata fields: %w", err)
+	}
+	retainFields(doc.Metadata.ProtoReflect(), metadataTree)
+	doc.Metadata.Id = a.token("document", doc.Metadata.Id)
+	doc.Metadata.Name = a.token("document-name", doc.Metadata.Name)
+	doc.Metadata.SetProperty(PropertyAnonymized, a.function())
+	doc.Vulnerabilities = nil
+
+	for _, n := range doc.NodeList.Nodes {
+		retainFields(n.ProtoReflect(), retain)
+		if tokenize("name") {
+			n.Name = a.token("name", n.Name)
+		}
+		if tokenize("version") {
+			n.Version = a.token("version", n.Version)
+		}
+		if tokenize("identifiers") {
+			for t, v := range n.Identifiers {
+				n.Identifiers[t] = a.identifierToken(SoftwareIdentifierType(t), v)
+			}
+		}
+	}
+
+	if !tokenize("id") {
+		return doc, nil
+	}
+
+	// Edges and root elements may reference IDs not in the node list,
+	// those are tokenized too so no original ID is left in the document.
+	renames := map[string]string{}
+	add := func(id string) {
+		if _, ok := renames[id]; !ok {
+			renames[id] = a.token("node", id)
+		}
+	}
+	for _, n := range doc.NodeList.Nodes {
+		add(n.Id)
+	}
+	for _, e := range doc.NodeList.Edges {
+		add(e.From)
+		for _, id := range e.To {
+			add(id)
+		}
+	}
+	for _, id := range doc.NodeList.RootElements {
+		add(id)
+	}
+	doc.NodeList.renameNodes(renames)
+	return doc, nil
+}
+
+// identifierToken returns the token of a software identifier. Package URLs
+// are tokenized as generic purls so they can still be parsed.
+func (a *anonymizer) identifierToken(t SoftwareIdentifierType, value string) string {
+	token := a.token("identifier", value)
+	if t == SoftwareIdentifierType_PURL && token != "" {
+		return "pkg:generic/" + token
+	}
+	return token
+}
+
+// function returns the name of the function used to compute the tokens
+func (a *anonymizer) function() string {
+	if a.salt == "" {
+		return AnonymizationSHA256
+	}
+	return AnonymizationHMACSHA256
+}
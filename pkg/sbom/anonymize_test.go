@@ -0,0 +1,134 @@
+package sbom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestAnonymize(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    *AnonymizeOptions
+		mustErr bool
+		method  string
+		// purposes, licenses and version are the kept values, an empty
+		// version means the version is tokenized
+		purposes []Purpose
+		licenses []string
+		version  string
+		// compare anonymizes the document again, the tokens are the same
+		// when sameTokens is true
+		compare    *AnonymizeOptions
+		sameTokens bool
+	}{
+		{
+			// Tokens are stable with the same salt
+			name:       "salted",
+			opts:       &AnonymizeOptions{Salt: "s3cr3t", Keep: DefaultAnonymizeOptions.Keep},
+			method:     AnonymizationHMACSHA256,
+			purposes:   []Purpose{Purpose_APPLICATION},
+			compare:    &AnonymizeOptions{Salt: "s3cr3t"},
+			sameTokens: true,
+		},
+		{
+			// Tokens change with another salt
+			name:     "unsalted",
+			method:   AnonymizationSHA256,
+			purposes: []Purpose{Purpose_APPLICATION},
+			compare:  &AnonymizeOptions{Salt: "s3cr3t"},
+		},
+		{
+			// Kept fields are not tokenized
+			name:     "kept fields",
+			opts:     &AnonymizeOptions{Keep: &fieldmaskpb.FieldMask{Paths: []string{"licenses", "version"}}},
+			method:   AnonymizationSHA256,
+			licenses: []string{"MIT"},
+			version:  "1.0",
+		},
+		{
+			name:    "invalid field mask",
+			opts:    &AnonymizeOptions{Keep: &fieldmaskpb.FieldMask{Paths: []string{"bogus"}}},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.Metadata.Id = "https://example.com/sboms/app"
+			doc.Metadata.Name = "app SBOM"
+			doc.Metadata.Date = timestamppb.New(time.Unix(1700000000, 0))
+			doc.Metadata.Authors = []*Person{{Name: "ACME"}}
+			doc.NodeList.AddRootNode(&Node{
+				Id: "app", Type: Node_PACKAGE, Name: "app", Version: "1.0", Licenses: []string{"MIT"},
+				PrimaryPurpose: []Purpose{Purpose_APPLICATION},
+				Identifiers:    map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/app@1.0"},
+				Hashes:         map[int32]string{int32(HashAlgorithm_SHA256): "abc"},
+				Suppliers:      []*Person{{Name: "ACME"}},
+			})
+			doc.NodeList.AddNode(&Node{Id: "lib", Type: Node_PACKAGE, Name: "lib", Version: "1.0"})
+			doc.NodeList.AddNode(&Node{Id: "main.go", Type: Node_FILE, Name: "main.go"})
+			doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib", "missing"}})
+			doc.NodeList.AddEdge(&Edge{Type: Edge_contains, From: "app", To: []string{"main.go"}})
+			doc.Vulnerabilities = []*Vulnerability{{Id: "CVE-2024-0001", Affects: []string{"lib"}}}
+
+			anon, err := doc.Anonymize(tc.opts)
+
+			// The original document is not modified
+			require.Equal(t, "app", doc.NodeList.Nodes[0].Name)
+			require.Len(t, doc.Vulnerabilities, 1)
+
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEqual(t, doc.Metadata.Id, anon.Metadata.Id)
+			require.True(t, strings.HasPrefix(anon.Metadata.Id, "document-"))
+			require.Equal(t, doc.Metadata.Date.AsTime(), anon.Metadata.Date.AsTime())
+			require.Empty(t, anon.Metadata.Authors)
+			require.Equal(t, tc.method, anon.Metadata.GetProperty(PropertyAnonymized).Data)
+			require.Empty(t, anon.Vulnerabilities)
+
+			// The graph keeps its shape
+			nl := anon.NodeList
+			require.Len(t, nl.Nodes, 3)
+			app := nl.Nodes[0]
+			require.Equal(t, []string{app.Id}, nl.RootElements)
+			require.Equal(t, app.Id, nl.Edges[0].From)
+			require.Equal(t, []string{nl.Nodes[1].Id, nl.Edges[0].To[1]}, nl.Edges[0].To)
+			require.Equal(t, []string{nl.Nodes[2].Id}, nl.Edges[1].To)
+			require.NotContains(t, nl.Edges[0].To, "missing")
+
+			require.Equal(t, Node_PACKAGE, app.Type)
+			require.Equal(t, tc.purposes, app.PrimaryPurpose)
+			require.True(t, strings.HasPrefix(app.Name, "name-"))
+			require.True(t, strings.HasPrefix(app.Identifiers[int32(SoftwareIdentifierType_PURL)], "pkg:generic/identifier-"))
+			require.Equal(t, tc.licenses, app.Licenses)
+			require.Empty(t, app.Hashes)
+			require.Empty(t, app.Suppliers)
+			if tc.version != "" {
+				require.Equal(t, tc.version, app.Version)
+			} else {
+				require.NotEqual(t, doc.NodeList.Nodes[0].Version, app.Version)
+			}
+			// Equal values get equal tokens
+			require.Equal(t, app.Version, nl.Nodes[1].Version)
+			require.NotEqual(t, app.Name, nl.Nodes[1].Name)
+
+			if tc.compare == nil {
+				return
+			}
+			again, err := doc.Anonymize(tc.compare)
+			require.NoError(t, err)
+			if tc.sameTokens {
+				require.Equal(t, app.Id, again.NodeList.Nodes[0].Id)
+			} else {
+				require.NotEqual(t, app.Id, again.NodeList.Nodes[0].Id)
+			}
+		})
+	}
+}
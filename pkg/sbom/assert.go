@@ -0,0 +1,112 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrContradictoryEdge is returned when asserting a relationship that
+// contradicts an existing edge between the same nodes
+var ErrContradictoryEdge = errors.New("relationship contradicts an existing edge")
+
+// AssertOptions controls the checks of the relationship assertions
+type AssertOptions struct {
+	// Contradictions maps edge types to the types that can't relate the same
+	// pair of nodes. The relation is symmetric, listing a pair once is
+	// enough. Reverse types are compared in the direction of the
+	// relationship: A contains B contradicts B DEV_DEPENDENCY_OF A.
+	Contradictions map[Edge_Type][]Edge_Type
+}
+
+// DefaultAssertOptions rejects relationships contradicting whether a
+// component ships with its parent: a node can't contain a component it only
+// uses to develop, build or test.
+var DefaultAssertOptions = &AssertOptions{
+	Contradictions: map[Edge_Type][]Edge_Type{
+		Edge_contains: {
+			Edge_devDependency, Edge_devTool, Edge_buildTool,
+			Edge_testDependency, Edge_testTool, Edge_providedDependency,
+		},
+		Edge_runtimeDependency: {Edge_devDependency, Edge_testDependency},
+	},
+}
+
+// contradicts returns true if edges of types a and b can't relate the same
+// nodes
+func (o *AssertOptions) contradicts(a, b Edge_Type) bool {
+	return slices.Contains(o.Contradictions[a], b) || slices.Contains(o.Contradictions[b], a)
+}
+
+// relationshipEnds returns the ends of a relationship of type t from a node
+// to another one in the direction of the forward types, eg B DEV_DEPENDENCY_OF
+// A is returned as A, B like A contains B.
+func relationshipEnds(t Edge_Type, from, to string) (parent, child string) {
+	if t.IsReverse() {
+		return to, from
+	}
+	return from, to
+}
+
+// Assert records that the node fromID is related to the nodes toIDs with
+// an edge of type edgeType, using the DefaultAssertOptions. See
+// AssertWithOptions.
+func (d *Document) Assert(fromID string, edgeType Edge_Type, toIDs ...string) error {
+	return d.AssertWithOptions(DefaultAssertOptions, fromID, edgeType, toIDs...)
+}
+
+// AssertWithOptions records that the node fromID is related to the nodes
+// toIDs with an edge of type edgeType. In contrast to AddEdge, the nodes at
+// both ends must exist in the document, the relationship must not contradict
+// the existing edges and it is merged into the edge from the node with the
+// same type if there is one. Nothing is modified if an error is returned.
+func (d *Document) AssertWithOptions(opts *AssertOptions, fromID string, edgeType Edge_Type, toIDs ...string) error {
+	if opts == nil {
+		opts = DefaultAssertOptions
+	}
+	if edgeType == Edge_UNKNOWN {
+		return errors.New("unable to assert relationship of unknown type")
+	}
+	if len(toIDs) == 0 {
+		return fmt.Errorf("no nodes to relate to %q", fromID)
+	}
+
+	nl := d.GetNodeList()
+	nodes := nl.indexNodes()
+	if _, ok := nodes[fromID]; !ok {
+		return fmt.Errorf("node %q not found in document", fromID)
+	}
+	for _, id := range toIDs {
+		if _, ok := nodes[id]; !ok {
+			return fmt.Errorf("node %q not found in document", id)
+		}
+		if id == fromID {
+			return fmt.Errorf("node %q can't be related to itself", id)
+		}
+	}
+
+	// Edges are compared in the same direction as reverse types point
+	// from the child to the parent
+	for _, e := range nl.GetEdges() {
+		if !opts.contradicts(e.Type, edgeType) {
+			continue
+		}
+		for _, id := range toIDs {
+			parent, child := relationshipEnds(edgeType, fromID, id)
+			for _, to := range e.To {
+				if p, c := relationshipEnds(e.Type, e.From, to); p == parent && c == child {
+					return fmt.Errorf("%s %s %s: %w (%s)", fromID, edgeType, id, ErrContradictoryEdge, e.Type)
+				}
+			}
+		}
+	}
+
+	if e := nl.GetEdgeByType(fromID, edgeType); e != nil {
+		e.AddDestinationById(toIDs...)
+		return nil
+	}
+	e := &Edge{Type: edgeType, From: fromID, To: []string{}}
+	e.AddDestinationById(toIDs...)
+	nl.AddEdge(e)
+	return nil
+}
@@ -0,0 +1,98 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func assertTestDocument() *Document {
+	doc := NewDocument()
+	doc.NodeList.AddRootNode(&Node{Id: "app"})
+	doc.NodeList.AddNode(&Node{Id: "lib"})
+	doc.NodeList.AddNode(&Node{Id: "linter"})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib"}})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_devDependency, From: "linter", To: []string{"app"}})
+	return doc
+}
+
+func TestAssert(t *testing.T) {
+	for name, tc := range map[string]struct {
+		opts     *AssertOptions
+		existing *Edge
+		from     string
+		t        Edge_Type
+		to       []string
+		mustErr  bool
+		errIs    error
+		edges    []*Edge
+	}{
+		"merges into existing edge": {
+			from: "app", t: Edge_dependsOn, to: []string{"linter", "lib"},
+			edges: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"lib", "linter"}},
+				{Type: Edge_devDependency, From: "linter", To: []string{"app"}},
+			},
+		},
+		"adds new edge": {
+			from: "lib", t: Edge_contains, to: []string{"linter", "linter"},
+			edges: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"lib"}},
+				{Type: Edge_devDependency, From: "linter", To: []string{"app"}},
+				{Type: Edge_contains, From: "lib", To: []string{"linter"}},
+			},
+		},
+		"unknown source": {from: "nope", t: Edge_contains, to: []string{"lib"}, mustErr: true},
+		"unknown target": {from: "app", t: Edge_contains, to: []string{"lib", "nope"}, mustErr: true},
+		"self loop":      {from: "app", t: Edge_contains, to: []string{"app"}, mustErr: true},
+		"no targets":     {from: "app", t: Edge_contains, mustErr: true},
+		"unknown type":   {from: "app", t: Edge_UNKNOWN, to: []string{"lib"}, mustErr: true},
+		"contradiction":  {from: "app", t: Edge_contains, to: []string{"lib", "linter"}, mustErr: true, errIs: ErrContradictoryEdge},
+		"contradiction of a reverse edge": {
+			existing: &Edge{Type: Edge_contains, From: "app", To: []string{"lib"}},
+			from:     "lib", t: Edge_testDependency, to: []string{"app"}, mustErr: true, errIs: ErrContradictoryEdge,
+		},
+		"reverse edge in the other direction": {
+			existing: &Edge{Type: Edge_contains, From: "app", To: []string{"lib"}},
+			from:     "app", t: Edge_testDependency, to: []string{"lib"},
+			edges: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"lib"}},
+				{Type: Edge_devDependency, From: "linter", To: []string{"app"}},
+				{Type: Edge_contains, From: "app", To: []string{"lib"}},
+				{Type: Edge_testDependency, From: "app", To: []string{"lib"}},
+			},
+		},
+		"contradiction reversed": {
+			opts: &AssertOptions{Contradictions: map[Edge_Type][]Edge_Type{Edge_devDependency: {Edge_dependsOn}}},
+			from: "app", t: Edge_dependsOn, to: []string{"linter"}, mustErr: true, errIs: ErrContradictoryEdge,
+		},
+		"contradictions disabled": {
+			opts: &AssertOptions{},
+			from: "app", t: Edge_contains, to: []string{"linter"},
+			edges: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"lib"}},
+				{Type: Edge_devDependency, From: "linter", To: []string{"app"}},
+				{Type: Edge_contains, From: "app", To: []string{"linter"}},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := assertTestDocument()
+			if tc.existing != nil {
+				doc.NodeList.AddEdge(tc.existing)
+			}
+			original := doc.NodeList.Copy()
+			err := doc.AssertWithOptions(tc.opts, tc.from, tc.t, tc.to...)
+			if tc.mustErr {
+				require.Error(t, err)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+				}
+				require.Equal(t, original.Edges, doc.NodeList.Edges)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.edges, doc.NodeList.Edges)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package sbom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fillMessage populates every field of m with values derived from seed,
+// recursing into messages up to depth levels.
+func fillMessage(m protoreflect.Message, seed, depth int) {
+	if depth == 0 {
+		return
+	}
+	fields := m.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		switch {
+		case fd.IsMap():
+			mp := m.Mutable(fd).Map()
+			for j := 1; j <= 2; j++ {
+				key := scalarValue(fd.MapKey(), seed*10+j).MapKey()
+				if fd.MapValue().Kind() == protoreflect.MessageKind {
+					v := mp.NewValue()
+					fillMessage(v.Message(), seed*10+j, depth-1)
+					mp.Set(key, v)
+					continue
+				}
+				mp.Set(key, scalarValue(fd.MapValue(), seed*10+j))
+			}
+		case fd.IsList():
+			l := m.Mutable(fd).List()
+			for j := 1; j <= 2; j++ {
+				if fd.Kind() == protoreflect.MessageKind {
+					v := l.NewElement()
+					fillMessage(v.Message(), seed*10+j, depth-1)
+					l.Append(v)
+					continue
+				}
+				l.Append(scalarValue(fd, seed*10+j))
+			}
+		case fd.Kind() == protoreflect.MessageKind:
+			fillMessage(m.Mutable(fd).Message(), seed, depth-1)
+		default:
+			m.Set(fd, scalarValue(fd, seed))
+		}
+	}
+}
+
+func scalarValue(fd protoreflect.FieldDescriptor, seed int) protoreflect.Value {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return protoreflect.ValueOfBool(seed%2 == 1)
+	case protoreflect.EnumKind:
+		values := fd.Enum().Values()
+		return protoreflect.ValueOfEnum(values.Get(1 + seed%(values.Len()-1)).Number())
+	case protoreflect.Int32Kind:
+		return protoreflect.ValueOfInt32(int32(seed))
+	case protoreflect.Int64Kind:
+		return protoreflect.ValueOfInt64(int64(seed))
+	case protoreflect.DoubleKind:
+		return protoreflect.ValueOfFloat64(float64(seed) / 10)
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(fmt.Sprintf("%s-%d", fd.Name(), seed)))
+	default:
+		return protoreflect.ValueOfString(fmt.Sprintf("%s-%d", fd.Name(), seed))
+	}
+}
+
+func testFullDocument(seed int) *Document {
+	doc := &Document{}
+	fillMessage(doc.ProtoReflect(), seed, 5)
+	return doc
+}
+
+func TestDocumentClone(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		var doc *Document
+		require.Nil(t, doc.Clone())
+		require.Nil(t, (&Document{}).Clone().GetMetadata())
+	})
+	t.Run("all-fields", func(t *testing.T) {
+		doc := testFullDocument(1)
+		clone := doc.Clone()
+		require.True(t, proto.Equal(doc, clone), "clone differs from the original")
+	})
+	t.Run("no-aliasing", func(t *testing.T) {
+		doc := testFullDocument(1)
+		clone := doc.Clone()
+		snapshot := proto.Clone(clone)
+
+		// Writing new data over every field in the original
+		// must not alter the clone
+		fillMessage(doc.ProtoReflect(), 2, 5)
+		require.False(t, proto.Equal(doc, clone))
+		require.True(t, proto.Equal(snapshot, clone), "clone shares data with the original")
+	})
+	t.Run("mutations", func(t *testing.T) {
+		doc := testFullDocument(1)
+		clone := doc.Clone()
+		n := doc.NodeList.Nodes[0]
+		cn := clone.NodeList.Nodes[0]
+
+		n.Identifiers[int32(SoftwareIdentifierType_PURL)] = "pkg:generic/changed"
+		n.Hashes[int32(HashAlgorithm_SHA256)] = "changed"
+		n.PrimaryPurpose[0] = Purpose_FIRMWARE
+		n.Suppliers[0].Contacts[0].Name = "changed"
+		n.ExternalReferences[0].Hashes[int32(HashAlgorithm_SHA1)] = "changed"
+		doc.NodeList.Edges[0].To[0] = "changed"
+		doc.Metadata.SourceData.Hashes[int32(HashAlgorithm_SHA256)] = "changed"
+		doc.Metadata.Signatures[0].Data[0] = 'X'
+		*doc.Metadata.DocumentTypes[0].Name = "changed"
+
+		require.NotEqual(t, "pkg:generic/changed", cn.Identifiers[int32(SoftwareIdentifierType_PURL)])
+		require.NotEqual(t, "changed", cn.Hashes[int32(HashAlgorithm_SHA256)])
+		require.NotEqual(t, Purpose_FIRMWARE, cn.PrimaryPurpose[0])
+		require.NotEqual(t, "changed", cn.Suppliers[0].Contacts[0].Name)
+		require.NotEqual(t, "changed", cn.ExternalReferences[0].Hashes[int32(HashAlgorithm_SHA1)])
+		require.NotEqual(t, "changed", clone.NodeList.Edges[0].To[0])
+		require.NotEqual(t, "changed", clone.Metadata.SourceData.Hashes[int32(HashAlgorithm_SHA256)])
+		require.NotEqual(t, byte('X'), clone.Metadata.Signatures[0].Data[0])
+		require.NotEqual(t, "changed", clone.Metadata.DocumentTypes[0].GetName())
+	})
+}
+
+func TestNodeCopyAllFields(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// depth is the number of message levels filled in the node
+		depth int
+	}{
+		{name: "empty node", depth: 0},
+		{name: "top level fields", depth: 1},
+		{name: "all fields", depth: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{}
+			fillMessage(n.ProtoReflect(), 1, tc.depth)
+			c := n.Copy()
+			require.True(t, proto.Equal(n, c), "node copy differs from the original")
+
+			// Writing over the original must not alter the copy
+			snapshot := proto.Clone(c)
+			fillMessage(n.ProtoReflect(), 2, tc.depth)
+			require.True(t, proto.Equal(snapshot, c), "copy shares data with the original")
+		})
+	}
+}
+
+func BenchmarkNodeCopy(b *testing.B) {
+	n := &Node{}
+	fillMessage(n.ProtoReflect(), 1, 4)
+	b.Run("Copy", func(b *testing.B) {
+		for range b.N {
+			n.Copy()
+		}
+	})
+	b.Run("proto.Clone", func(b *testing.B) {
+		for range b.N {
+			proto.Clone(n)
+		}
+	})
+}
+
+func BenchmarkDocumentClone(b *testing.B) {
+	doc := testFullDocument(1)
+	for i := range 500 {
+		n := doc.NodeList.Nodes[0].Copy()
+		n.Id = fmt.Sprintf("node-%04d", i)
+		doc.NodeList.Nodes = append(doc.NodeList.Nodes, n)
+	}
+	b.Run("Clone", func(b *testing.B) {
+		for range b.N {
+			doc.Clone()
+		}
+	})
+	b.Run("proto.Clone", func(b *testing.B) {
+		for range b.N {
+			proto.Clone(doc)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package sbom
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// This file keeps the accessors of the fields removed or retyped in the
+// protobom schema, backed by the fields replacing them, so code written
+// against the old model keeps building while it is migrated. Each accessor
+// logs a warning the first time it is called.
+
+// warnedDeprecations records the deprecated accessors already reported
+var warnedDeprecations sync.Map
+
+// warnDeprecated logs a warning the first time a deprecated accessor is used
+func warnDeprecated(name, replacement string) {
+	if _, loaded := warnedDeprecations.LoadOrStore(name, struct{}{}); loaded {
+		return
+	}
+	logrus.Warnf("%s is deprecated and will be removed in an upcoming version, please use %s", name, replacement)
+}
+
+// Deprecated: GetTypeString is deprecated and will be removed in an upcoming
+// version, Please use GetType. The type of external references was a string
+// before ExternalReferenceType, see https://github.com/protobom/protobom/issues/148.
+// GetTypeString returns the type of the reference as the old lowercase string,
+// eg "build-system".
+func (x *ExternalReference) GetTypeString() string {
+	warnDeprecated("ExternalReference.GetTypeString", "ExternalReference.GetType")
+	if x == nil || x.Type == ExternalReference_UNKNOWN {
+		return ""
+	}
+	return strings.ReplaceAll(strings.ToLower(x.Type.String()), "_", "-")
+}
+
+// Deprecated: SetTypeString is deprecated and will be removed in an upcoming
+// version, Please set the Type field. Unknown strings set the type to OTHER.
+func (x *ExternalReference) SetTypeString(t string) {
+	warnDeprecated("ExternalReference.SetTypeString", "the ExternalReference Type field")
+	x.Type = ExternalReference_UNKNOWN
+	if t == "" {
+		return
+	}
+	x.Type = ExternalReference_OTHER
+	if v, ok := ExternalReference_ExternalReferenceType_value[strings.ToUpper(strings.ReplaceAll(t, "-", "_"))]; ok {
+		x.Type = ExternalReference_ExternalReferenceType(v)
+	}
+}
+
+// Deprecated: GetSupplier is deprecated and will be removed in an upcoming
+// version, Please use GetSuppliers. Nodes had a single supplier before they
+// could list several with their contacts.
+// GetSupplier returns the first supplier of the node.
+func (x *Node) GetSupplier() *Person {
+	warnDeprecated("Node.GetSupplier", "Node.GetSuppliers")
+	if len(x.GetSuppliers()) == 0 {
+		return nil
+	}
+	return x.Suppliers[0]
+}
+
+// Deprecated: SetSupplier is deprecated and will be removed in an upcoming
+// version, Please set the Suppliers field. SetSupplier replaces the first
+// supplier of the node, a nil supplier removes it.
+func (x *Node) SetSupplier(p *Person) {
+	warnDeprecated("Node.SetSupplier", "the Node Suppliers field")
+	switch {
+	case p == nil && len(x.Suppliers) > 0:
+		x.Suppliers = x.Suppliers[1:]
+	case p == nil:
+	case len(x.Suppliers) == 0:
+		x.Suppliers = []*Person{p}
+	default:
+		x.Suppliers[0] = p
+	}
+}
+
+// Deprecated: GetStringHashes is deprecated and will be removed in an
+// upcoming version, Please use GetHashes. The hashes were keyed by the
+// algorithm name, see https://github.com/protobom/protobom/issues/89.
+// GetStringHashes returns the hashes of the node keyed by algorithm name.
+func (x *Node) GetStringHashes() map[string]string {
+	warnDeprecated("Node.GetStringHashes", "Node.GetHashes")
+	hashes := map[string]string{}
+	for algo, value := range x.GetHashes() {
+		hashes[HashAlgorithm(algo).String()] = value
+	}
+	return hashes
+}
+
+// Deprecated: SetStringHash is deprecated and will be removed in an upcoming
+// version, Please set the Hashes field. Hashes of unknown algorithms are
+// ignored.
+func (x *Node) SetStringHash(algorithm, value string) {
+	warnDeprecated("Node.SetStringHash", "the Node Hashes field")
+	algo, ok := HashAlgorithm_value[strings.ToUpper(strings.ReplaceAll(algorithm, "-", "_"))]
+	if !ok || algo == int32(HashAlgorithm_UNKNOWN) {
+		return
+	}
+	if x.Hashes == nil {
+		x.Hashes = map[int32]string{}
+	}
+	x.Hashes[algo] = value
+}
+
+// Deprecated: GetPrimaryPurposeString is deprecated and will be removed in an
+// upcoming version, Please use GetPrimaryPurpose. The purpose was a single
+// string, see https://github.com/protobom/protobom/issues/124.
+// GetPrimaryPurposeString returns the name of the first purpose of the node.
+func (x *Node) GetPrimaryPurposeString() string {
+	warnDeprecated("Node.GetPrimaryPurposeString", "Node.GetPrimaryPurpose")
+	if len(x.GetPrimaryPurpose()) == 0 || x.PrimaryPurpose[0] == Purpose_UNKNOWN_PURPOSE {
+		return ""
+	}
+	return strings.ReplaceAll(x.PrimaryPurpose[0].String(), "_", "-")
+}
+
+// Deprecated: SetPrimaryPurposeString is deprecated and will be removed in an
+// upcoming version, Please set the PrimaryPurpose field. Unknown purposes
+// are set as OTHER.
+func (x *Node) SetPrimaryPurposeString(purpose string) {
+	warnDeprecated("Node.SetPrimaryPurposeString", "the Node PrimaryPurpose field")
+	if purpose == "" {
+		x.PrimaryPurpose = nil
+		return
+	}
+	p := Purpose_OTHER
+	if v, ok := Purpose_value[strings.ToUpper(strings.ReplaceAll(purpose, "-", "_"))]; ok {
+		p = Purpose(v)
+	}
+	x.PrimaryPurpose = []Purpose{p}
+}
@@ -0,0 +1,162 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalReferenceTypeString(t *testing.T) {
+	str := func(s string) *string { return &s }
+	for _, tc := range []struct {
+		name     string
+		sut      *ExternalReference
+		set      *string
+		expected ExternalReference_ExternalReferenceType
+		asString string
+	}{
+		{name: "get", sut: &ExternalReference{Type: ExternalReference_BUILD_SYSTEM}, expected: ExternalReference_BUILD_SYSTEM, asString: "build-system"},
+		{name: "set", sut: &ExternalReference{}, set: str("vcs"), expected: ExternalReference_VCS, asString: "vcs"},
+		{name: "set with dashes", sut: &ExternalReference{}, set: str("issue-tracker"), expected: ExternalReference_ISSUE_TRACKER, asString: "issue-tracker"},
+		{name: "set unknown string", sut: &ExternalReference{}, set: str("something-else"), expected: ExternalReference_OTHER, asString: "other"},
+		{name: "set empty", sut: &ExternalReference{Type: ExternalReference_VCS}, set: str(""), expected: ExternalReference_UNKNOWN},
+		{name: "nil reference", expected: ExternalReference_UNKNOWN},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.set != nil {
+				tc.sut.SetTypeString(*tc.set)
+			}
+			require.Equal(t, tc.expected, tc.sut.GetType())
+			require.Equal(t, tc.asString, tc.sut.GetTypeString())
+		})
+	}
+}
+
+func TestNodeSupplier(t *testing.T) {
+	acme := &Person{Name: "Acme", IsOrg: true}
+	other := &Person{Name: "Other"}
+	replacement := &Person{Name: "Acme Corp", IsOrg: true}
+	for _, tc := range []struct {
+		name      string
+		suppliers []*Person
+		set       *Person
+		expected  []*Person
+	}{
+		{name: "set on empty node", set: acme, expected: []*Person{acme}},
+		{name: "replace first supplier", suppliers: []*Person{acme, other}, set: replacement, expected: []*Person{replacement, other}},
+		{name: "remove first supplier", suppliers: []*Person{replacement, other}, expected: []*Person{other}},
+		{name: "remove from empty node"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Suppliers: tc.suppliers}
+			if len(tc.suppliers) > 0 {
+				require.Same(t, tc.suppliers[0], n.GetSupplier())
+			} else {
+				require.Nil(t, n.GetSupplier())
+			}
+
+			n.SetSupplier(tc.set)
+			require.Equal(t, tc.expected, n.Suppliers)
+		})
+	}
+}
+
+func TestNodeStringHashes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		set      map[string]string
+		hashes   map[int32]string
+		expected map[string]string
+	}{
+		{
+			name:     "no hashes",
+			expected: map[string]string{},
+		},
+		{
+			name: "algorithm names are normalized",
+			set:  map[string]string{"SHA256": "abc", "sha3-512": "def"},
+			hashes: map[int32]string{
+				int32(HashAlgorithm_SHA256):   "abc",
+				int32(HashAlgorithm_SHA3_512): "def",
+			},
+			expected: map[string]string{"SHA256": "abc", "SHA3_512": "def"},
+		},
+		{
+			name:     "unknown algorithms are ignored",
+			set:      map[string]string{"crc32": "ignored"},
+			expected: map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{}
+			for algo, value := range tc.set {
+				n.SetStringHash(algo, value)
+			}
+			require.Equal(t, tc.hashes, n.Hashes)
+			require.Equal(t, tc.expected, n.GetStringHashes())
+		})
+	}
+}
+
+func TestNodePrimaryPurposeString(t *testing.T) {
+	str := func(s string) *string { return &s }
+	for _, tc := range []struct {
+		name     string
+		purposes []Purpose
+		set      *string
+		expected []Purpose
+		asString string
+	}{
+		{
+			name:     "first purpose",
+			purposes: []Purpose{Purpose_OPERATING_SYSTEM, Purpose_LIBRARY},
+			expected: []Purpose{Purpose_OPERATING_SYSTEM, Purpose_LIBRARY},
+			asString: "OPERATING-SYSTEM",
+		},
+		{
+			name:     "set replaces all purposes",
+			purposes: []Purpose{Purpose_OPERATING_SYSTEM, Purpose_LIBRARY},
+			set:      str("library"),
+			expected: []Purpose{Purpose_LIBRARY},
+			asString: "LIBRARY",
+		},
+		{name: "set unknown string", set: str("unheard-of"), expected: []Purpose{Purpose_OTHER}, asString: "OTHER"},
+		{name: "set empty", purposes: []Purpose{Purpose_LIBRARY}, set: str("")},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{PrimaryPurpose: tc.purposes}
+			if tc.set != nil {
+				n.SetPrimaryPurposeString(*tc.set)
+			}
+			require.Equal(t, tc.expected, n.PrimaryPurpose)
+			require.Equal(t, tc.asString, n.GetPrimaryPurposeString())
+		})
+	}
+}
+
+func TestWarnDeprecatedOnce(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		deprecated  string
+		replacement string
+		calls       int
+	}{
+		{name: "single call", deprecated: "Test.Single", replacement: "Test.New", calls: 1},
+		{name: "repeated calls", deprecated: "Test.Repeated", replacement: "Test.New", calls: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			out := logrus.StandardLogger().Out
+			logrus.SetOutput(&buf)
+			t.Cleanup(func() { logrus.SetOutput(out) })
+
+			for range tc.calls {
+				warnDeprecated(tc.deprecated, tc.replacement)
+			}
+			require.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(tc.deprecated+" is deprecated")))
+			require.Contains(t, buf.String(), "please use "+tc.replacement)
+		})
+	}
+}
@@ -44,3 +44,24 @@ func NewDocument() *Document {
 func (d *Document) GetRootNodes() []*Node {
 	return d.NodeList.GetRootNodes()
 }
+
+// Clone returns a deep copy of the document, including its metadata, the
+// node list and the vulnerabilities. Clone is the supported way to duplicate a Document: the clone
+// shares no maps, slices or messages with the original, so changes to one
+// never show up in the other. Prefer it over proto.Clone, it copies the
+// fields explicitly and avoids the cost of reflection.
+func (d *Document) Clone() *Document {
+	if d == nil {
+		return nil
+	}
+	ret := &Document{
+		Metadata: d.Metadata.Copy(),
+	}
+	if d.NodeList != nil {
+		ret.NodeList = d.NodeList.Copy()
+	}
+	for _, v := range d.Vulnerabilities {
+		ret.Vulnerabilities = append(ret.Vulnerabilities, v.Copy())
+	}
+	return ret
+}
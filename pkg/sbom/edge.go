@@ -1,6 +1,7 @@
 package sbom
 
 import (
+	"slices"
 	"sort"
 	"strings"
 )
@@ -17,7 +18,7 @@ func (e *Edge) Copy() *Edge {
 	return &Edge{
 		Type: e.Type,
 		From: e.From,
-		To:   e.To,
+		To:   slices.Clone(e.To),
 	}
 }
 
@@ -126,6 +127,9 @@ func (et Edge_Type) ToSPDX2() string {
 		return "TEST_TOOL_OF"
 	case Edge_variant:
 		return "VARIANT_OF"
+	case Edge_trainedOn, Edge_testedOn:
+		// SPDX 2 has no relationships for AI models and datasets
+		return "OTHER"
 	default:
 		return ""
 	}
@@ -232,6 +236,94 @@ func EdgeTypeFromSPDX2(spdx2Type string) Edge_Type {
 	}
 }
 
+// SPDX 3.0 lifecycle scopes of the relationships
+const (
+	SPDX3ScopeBuild       = "build"
+	SPDX3ScopeDevelopment = "development"
+	SPDX3ScopeRuntime     = "runtime"
+	SPDX3ScopeTest        = "test"
+)
+
+// SPDX3Relationship is the SPDX 3.0 relationship an edge type is written as
+type SPDX3Relationship struct {
+	// Type is the SPDX 3.0 relationship type
+	Type string
+
+	// Scope is the lifecycle scope of the relationship, empty when it is a
+	// plain relationship
+	Scope string
+
+	// Reverse is set when the relationship runs from the edge destinations
+	// to its source. SPDX 3.0 dropped most of the inverse relationship types
+	// of SPDX 2, eg A DEV_DEPENDENCY_OF B is written as B dependsOn A.
+	Reverse bool
+}
+
+// edgeTypesToSPDX3 maps the edge types to the SPDX 3.0 relationships
+// following the SPDX 2.3 to 3.0 migration guide
+var edgeTypesToSPDX3 = map[Edge_Type]SPDX3Relationship{
+	Edge_amends:               {Type: "amendedBy", Reverse: true},
+	Edge_ancestor:             {Type: "ancestorOf"},
+	Edge_buildDependency:      {Type: "dependsOn", Scope: SPDX3ScopeBuild, Reverse: true},
+	Edge_buildTool:            {Type: "usesTool", Scope: SPDX3ScopeBuild, Reverse: true},
+	Edge_contains:             {Type: "contains"},
+	Edge_contained_by:         {Type: "contains", Reverse: true},
+	Edge_copy:                 {Type: "copiedTo", Reverse: true},
+	Edge_dataFile:             {Type: "hasDataFile", Reverse: true},
+	Edge_dependencyManifest:   {Type: "hasDependencyManifest", Reverse: true},
+	Edge_dependsOn:            {Type: "dependsOn"},
+	Edge_dependencyOf:         {Type: "dependsOn", Reverse: true},
+	Edge_descendant:           {Type: "descendantOf"},
+	Edge_describes:            {Type: "describes"},
+	Edge_describedBy:          {Type: "describes", Reverse: true},
+	Edge_devDependency:        {Type: "dependsOn", Scope: SPDX3ScopeDevelopment, Reverse: true},
+	Edge_devTool:              {Type: "usesTool", Scope: SPDX3ScopeDevelopment, Reverse: true},
+	Edge_distributionArtifact: {Type: "hasDistributionArtifact"},
+	Edge_documentation:        {Type: "hasDocumentation", Reverse: true},
+	Edge_dynamicLink:          {Type: "hasDynamicLink"},
+	Edge_example:              {Type: "hasExample", Reverse: true},
+	Edge_expandedFromArchive:  {Type: "expandsTo", Reverse: true},
+	Edge_fileAdded:            {Type: "hasAddedFile", Reverse: true},
+	Edge_fileDeleted:          {Type: "hasDeletedFile", Reverse: true},
+	Edge_fileModified:         {Type: "modifiedBy"},
+	Edge_generates:            {Type: "generates"},
+	Edge_generatedFrom:        {Type: "generates", Reverse: true},
+	Edge_metafile:             {Type: "hasMetadata", Reverse: true},
+	Edge_optionalComponent:    {Type: "hasOptionalComponent", Reverse: true},
+	Edge_optionalDependency:   {Type: "hasOptionalDependency", Reverse: true},
+	Edge_other:                {Type: "other"},
+	Edge_packages:             {Type: "packagedBy", Reverse: true},
+	Edge_patch:                {Type: "patchedBy", Reverse: true},
+	Edge_prerequisite:         {Type: "hasPrerequisite"},
+	Edge_prerequisiteFor:      {Type: "hasPrerequisite", Reverse: true},
+	Edge_providedDependency:   {Type: "hasProvidedDependency", Reverse: true},
+	Edge_requirementFor:       {Type: "hasRequirement", Reverse: true},
+	Edge_runtimeDependency:    {Type: "dependsOn", Scope: SPDX3ScopeRuntime, Reverse: true},
+	Edge_specificationFor:     {Type: "hasSpecification", Reverse: true},
+	Edge_staticLink:           {Type: "hasStaticLink"},
+	Edge_test:                 {Type: "hasTest", Reverse: true},
+	Edge_testCase:             {Type: "hasTestCase", Reverse: true},
+	Edge_testDependency:       {Type: "dependsOn", Scope: SPDX3ScopeTest, Reverse: true},
+	Edge_testTool:             {Type: "usesTool", Scope: SPDX3ScopeTest, Reverse: true},
+	Edge_variant:              {Type: "hasVariant", Reverse: true},
+	Edge_trainedOn:            {Type: "trainedOn"},
+	Edge_testedOn:             {Type: "testedOn"},
+}
+
+// IsReverse returns true if the edges of the type run from the destination
+// of the relationship to its source, like the SPDX 2 *_OF relationships:
+// A DEV_DEPENDENCY_OF B means B depends on A. Code following dependencies
+// or containment must swap the ends of these edges.
+func (et Edge_Type) IsReverse() bool {
+	return edgeTypesToSPDX3[et].Reverse
+}
+
+// ToSPDX3 returns the SPDX 3.0 relationship of the edge type. The returned
+// type is empty for UNKNOWN edges.
+func (et Edge_Type) ToSPDX3() SPDX3Relationship {
+	return edgeTypesToSPDX3[et]
+}
+
 // Equal compares the current edge to another (e2) and returns true if they are identical.
 // It checks if both edges have the same source, type, and destination nodes.
 func (e *Edge) Equal(e2 *Edge) bool {
@@ -26,3 +26,23 @@ func TestAddDestinationById(t *testing.T) {
 		})
 	}
 }
+
+func TestEdgeTypesToSPDX3(t *testing.T) {
+	for i := range len(Edge_Type_name) {
+		et := Edge_Type(i) //nolint:gosec
+		t.Run(et.String(), func(t *testing.T) {
+			if et == Edge_UNKNOWN {
+				require.Empty(t, et.ToSPDX3().Type)
+				return
+			}
+			require.NotEmpty(t, et.ToSPDX3().Type)
+
+			// Both directions of a relationship are written the same way, except
+			// ancestor and descendant which SPDX 3 kept
+			if inverse := et.Inverse(); inverse != Edge_UNKNOWN && et != Edge_ancestor && et != Edge_descendant {
+				require.Equal(t, et.ToSPDX3().Type, inverse.ToSPDX3().Type)
+				require.NotEqual(t, et.ToSPDX3().Reverse, inverse.ToSPDX3().Reverse)
+			}
+		})
+	}
+}
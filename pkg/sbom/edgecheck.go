@@ -0,0 +1,150 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+)
+
+// inverseEdgeTypes maps the edge types expressing a relationship in its
+// inverse direction to the canonical type, eg B contained_by A is the
+// inverse of A contains B.
+var inverseEdgeTypes = map[Edge_Type]Edge_Type{
+	Edge_contained_by:    Edge_contains,
+	Edge_dependencyOf:    Edge_dependsOn,
+	Edge_describedBy:     Edge_describes,
+	Edge_generatedFrom:   Edge_generates,
+	Edge_prerequisiteFor: Edge_prerequisite,
+	Edge_descendant:      Edge_ancestor,
+}
+
+// Inverse returns the type of the edge expressing the same relationship in
+// the opposite direction. It returns UNKNOWN if the type has no inverse.
+func (et Edge_Type) Inverse() Edge_Type {
+	if t, ok := inverseEdgeTypes[et]; ok {
+		return t
+	}
+	for inverse, canonical := range inverseEdgeTypes {
+		if canonical == et {
+			return inverse
+		}
+	}
+	return Edge_UNKNOWN
+}
+
+// EdgeFixOptions selects the problems repaired by FixEdges
+type EdgeFixOptions struct {
+	// RemoveSelfLoops removes the edge destinations pointing to the edge
+	// source node
+	RemoveSelfLoops bool
+
+	// RemoveInverseDuplicates removes the destinations of the inverse edges
+	// already expressed by an edge in the canonical direction, eg B
+	// contained_by A when A contains B.
+	RemoveInverseDuplicates bool
+
+	// Canonicalize rewrites the inverse edges in the canonical direction,
+	// merging them into the existing edges. Inverse duplicates are removed
+	// too.
+	Canonicalize bool
+}
+
+// EdgeFixReport counts the edge destinations changed by FixEdges
+type EdgeFixReport struct {
+	SelfLoops         int
+	InverseDuplicates int
+	Canonicalized     int
+}
+
+// canonicalIndex indexes the destinations of the canonical edges by source
+// node and type
+func (nl *NodeList) canonicalIndex() map[string]map[Edge_Type][]string {
+	index := map[string]map[Edge_Type][]string{}
+	for _, e := range nl.Edges {
+		if _, inverse := inverseEdgeTypes[e.Type]; inverse || e.Type.Inverse() == Edge_UNKNOWN {
+			continue
+		}
+		if _, ok := index[e.From]; !ok {
+			index[e.From] = map[Edge_Type][]string{}
+		}
+		index[e.From][e.Type] = append(index[e.From][e.Type], e.To...)
+	}
+	return index
+}
+
+// ValidateEdges checks the edge directions, flagging the edges that point
+// to their own source node and the inverse edges duplicating an edge in the
+// canonical direction. These usually appear after merging relationship sets
+// converted from SPDX.
+func (nl *NodeList) ValidateEdges() []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	canonical := nl.canonicalIndex()
+	for _, e := range nl.GetEdges() {
+		if slices.Contains(e.To, e.From) {
+			issues = append(issues, &ValidationIssue{
+				NodeID: e.From, Field: "edges",
+				Message: fmt.Sprintf("%s edge points to its own source node", e.Type),
+			})
+		}
+		ct, ok := inverseEdgeTypes[e.Type]
+		if !ok {
+			continue
+		}
+		for _, id := range e.To {
+			if slices.Contains(canonical[id][ct], e.From) {
+				issues = append(issues, &ValidationIssue{
+					NodeID: e.From, Field: "edges",
+					Message: fmt.Sprintf("%s edge to %s duplicates %s %s %s", e.Type, id, id, ct, e.From),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// FixEdges repairs the problems found by ValidateEdges selected in the
+// options. Edges left without destinations are removed.
+func (nl *NodeList) FixEdges(opts *EdgeFixOptions) *EdgeFixReport {
+	report := &EdgeFixReport{}
+	if opts == nil {
+		return report
+	}
+
+	canonical := nl.canonicalIndex()
+	rewritten := []*Edge{}
+	for _, e := range nl.Edges {
+		if opts.RemoveSelfLoops {
+			e.To = slices.DeleteFunc(e.To, func(id string) bool {
+				if id == e.From {
+					report.SelfLoops++
+					return true
+				}
+				return false
+			})
+		}
+
+		ct, ok := inverseEdgeTypes[e.Type]
+		if !ok {
+			continue
+		}
+		if opts.RemoveInverseDuplicates || opts.Canonicalize {
+			e.To = slices.DeleteFunc(e.To, func(id string) bool {
+				if slices.Contains(canonical[id][ct], e.From) {
+					report.InverseDuplicates++
+					return true
+				}
+				return false
+			})
+		}
+		if opts.Canonicalize {
+			for _, id := range e.To {
+				rewritten = append(rewritten, &Edge{Type: ct, From: id, To: []string{e.From}})
+				report.Canonicalized++
+			}
+			e.To = []string{}
+		}
+	}
+
+	nl.Edges = slices.DeleteFunc(nl.Edges, func(e *Edge) bool { return len(e.To) == 0 })
+	nl.MergeEdges(rewritten)
+	return report
+}
@@ -0,0 +1,138 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEdgeTypeInverse(t *testing.T) {
+	for _, tc := range []struct {
+		sut      Edge_Type
+		expected Edge_Type
+	}{
+		{Edge_contains, Edge_contained_by},
+		{Edge_contained_by, Edge_contains},
+		{Edge_dependsOn, Edge_dependencyOf},
+		{Edge_dependencyOf, Edge_dependsOn},
+		{Edge_staticLink, Edge_UNKNOWN},
+		{Edge_UNKNOWN, Edge_UNKNOWN},
+	} {
+		t.Run(tc.sut.String(), func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.sut.Inverse())
+		})
+	}
+}
+
+func TestValidateEdges(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		edges    []*Edge
+		expected []string
+	}{
+		{
+			name:     "self loop",
+			edges:    []*Edge{{Type: Edge_contains, From: "a", To: []string{"a", "b"}}},
+			expected: []string{"node a: edges: contains edge points to its own source node"},
+		},
+		{
+			name: "inverse duplicates",
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b"}},
+				{Type: Edge_contained_by, From: "b", To: []string{"a"}},
+				{Type: Edge_dependencyOf, From: "c", To: []string{"a", "b"}},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+			},
+			expected: []string{
+				"node b: edges: contained_by edge to a duplicates a contains b",
+				"node c: edges: dependencyOf edge to b duplicates b dependsOn c",
+			},
+		},
+		{
+			name: "valid edges",
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b"}},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+			},
+			expected: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes:        []*Node{{Id: "a"}, {Id: "b"}, {Id: "c"}},
+				Edges:        tc.edges,
+				RootElements: []string{"a"},
+			}
+			issues := []string{}
+			for _, issue := range nl.ValidateEdges() {
+				issues = append(issues, issue.String())
+			}
+			require.Equal(t, tc.expected, issues)
+		})
+	}
+}
+
+func TestFixEdges(t *testing.T) {
+	for name, tc := range map[string]struct {
+		opts     *EdgeFixOptions
+		expected *EdgeFixReport
+		edges    []*Edge
+	}{
+		"nil options": {
+			expected: &EdgeFixReport{},
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"a", "b"}},
+				{Type: Edge_contained_by, From: "b", To: []string{"a"}},
+				{Type: Edge_dependencyOf, From: "c", To: []string{"a", "b"}},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+			},
+		},
+		"self loops": {
+			opts:     &EdgeFixOptions{RemoveSelfLoops: true},
+			expected: &EdgeFixReport{SelfLoops: 1},
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b"}},
+				{Type: Edge_contained_by, From: "b", To: []string{"a"}},
+				{Type: Edge_dependencyOf, From: "c", To: []string{"a", "b"}},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+			},
+		},
+		"inverse duplicates": {
+			opts:     &EdgeFixOptions{RemoveInverseDuplicates: true},
+			expected: &EdgeFixReport{InverseDuplicates: 2},
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"a", "b"}},
+				{Type: Edge_dependencyOf, From: "c", To: []string{"a"}},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+			},
+		},
+		"canonicalize": {
+			opts:     &EdgeFixOptions{RemoveSelfLoops: true, Canonicalize: true},
+			expected: &EdgeFixReport{SelfLoops: 1, InverseDuplicates: 2, Canonicalized: 1},
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b"}},
+				{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+				{Type: Edge_dependsOn, From: "a", To: []string{"c"}},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes: []*Node{{Id: "a"}, {Id: "b"}, {Id: "c"}},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "a", To: []string{"a", "b"}},
+					{Type: Edge_contained_by, From: "b", To: []string{"a"}},
+					{Type: Edge_dependencyOf, From: "c", To: []string{"a", "b"}},
+					{Type: Edge_dependsOn, From: "b", To: []string{"c"}},
+				},
+				RootElements: []string{"a"},
+			}
+			report := nl.FixEdges(tc.opts)
+			require.Equal(t, tc.expected, report)
+			require.Equal(t, tc.edges, nl.Edges)
+			if tc.opts != nil && tc.opts.RemoveSelfLoops && tc.opts.Canonicalize {
+				require.Empty(t, nl.ValidateEdges())
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package sbom
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// timestampFields are the node fields ignored by IgnoreTimestamps
+var timestampFields = []string{"release_date", "build_date", "valid_until_date"}
+
+// descriptionFields are the node fields ignored by IgnoreDescriptions
+var descriptionFields = []string{"description", "summary", "comment"}
+
+// EquivalenceOptions controls the tolerance of NodeList.EquivalentTo
+type EquivalenceOptions struct {
+	// IgnoreFields lists the protobuf names of node fields that are not
+	// compared, for example "source_info" or "file_name". Names that don't
+	// match a Node field have no effect.
+	IgnoreFields []string
+
+	// IgnoreTimestamps skips comparing the node release, build and valid
+	// until dates.
+	IgnoreTimestamps bool
+
+	// IgnoreDescriptions skips comparing the node description, summary
+	// and comment.
+	IgnoreDescriptions bool
+
+	// IgnoreGeneratedIDs pairs the nodes with identifiers generated by
+	// protobom by their contents instead of by their IDs.
+	IgnoreGeneratedIDs bool
+
+	// MatchByPurl considers two nodes with the same package URL equal
+	// regardless of their IDs and the rest of their data.
+	MatchByPurl bool
+}
+
+// excludedFields returns the set of node fields left out of the comparison.
+// The node ID is always excluded as nodes are paired by their keys.
+func (o *EquivalenceOptions) excludedFields() map[protoreflect.Name]struct{} {
+	names := slices.Concat([]string{"id"}, o.IgnoreFields)
+	if o.IgnoreTimestamps {
+		names = append(names, timestampFields...)
+	}
+	if o.IgnoreDescriptions {
+		names = append(names, descriptionFields...)
+	}
+	ret := make(map[protoreflect.Name]struct{}, len(names))
+	for _, name := range names {
+		ret[protoreflect.Name(name)] = struct{}{}
+	}
+	return ret
+}
+
+// isGeneratedID returns true if the identifier was generated by protobom
+func isGeneratedID(id string) bool {
+	return strings.HasPrefix(id, NodeIdentifierPrefix+"-")
+}
+
+// equivalenceKeys returns the key that pairs each node in the list with its
+// counterpart and the data of each node to compare, indexed by the node ID.
+func (nl *NodeList) equivalenceKeys(opts *EquivalenceOptions, exclude map[protoreflect.Name]struct{}) (keys, data map[string]string) {
+	keys = make(map[string]string, len(nl.Nodes))
+	data = make(map[string]string, len(nl.Nodes))
+	for _, n := range nl.Nodes {
+		if purl := n.Purl(); opts.MatchByPurl && purl != "" {
+			keys[n.Id] = "purl:" + string(purl)
+			data[n.Id] = ""
+			continue
+		}
+
+		flat := n.flatStringExcluding(exclude)
+		if opts.IgnoreGeneratedIDs && isGeneratedID(n.Id) {
+			keys[n.Id] = fmt.Sprintf("content:%x", sha256.Sum256([]byte(flat)))
+		} else {
+			keys[n.Id] = "id:" + n.Id
+		}
+		data[n.Id] = flat
+	}
+	return keys, data
+}
+
+// equivalenceIndex is the normalized representation of a NodeList compared
+// by EquivalentTo.
+type equivalenceIndex struct {
+	nodes []string
+	edges []string
+	roots []string
+}
+
+// newEquivalenceIndex builds the sorted, ID independent representation of
+// the nodes, edges and root elements in the list.
+func (nl *NodeList) newEquivalenceIndex(opts *EquivalenceOptions, exclude map[protoreflect.Name]struct{}) *equivalenceIndex {
+	keys, data := nl.equivalenceKeys(opts, exclude)
+	key := func(id string) string {
+		if k, ok := keys[id]; ok {
+			return k
+		}
+		return "id:" + id
+	}
+
+	idx := &equivalenceIndex{}
+	for _, n := range nl.Nodes {
+		idx.nodes = append(idx.nodes, keys[n.Id]+"|"+data[n.Id])
+	}
+
+	// Edges are compared as a set of relationships, so documents that
+	// split or merge the edges of a node are still equivalent.
+	edges := map[string]struct{}{}
+	for _, e := range nl.Edges {
+		for _, to := range e.To {
+			edges[key(e.From)+"|"+e.Type.String()+"|"+key(to)] = struct{}{}
+		}
+	}
+	for e := range edges {
+		idx.edges = append(idx.edges, e)
+	}
+
+	for _, id := range nl.RootElements {
+		idx.roots = append(idx.roots, key(id))
+	}
+
+	slices.Sort(idx.nodes)
+	slices.Sort(idx.edges)
+	idx.roots = slices.Compact(slices.Sorted(slices.Values(idx.roots)))
+	return idx
+}
+
+// EquivalentTo compares the NodeList to nl2 with the tolerance set in the
+// options. While Equal requires both lists to be identical, EquivalentTo
+// can ignore fields and match nodes by content or package URL, which is
+// useful to compare the SBOMs produced by different tools for the same
+// artifact. A nil options value compares the lists like Equal, except that
+// edges are compared as a set of relationships.
+func (nl *NodeList) EquivalentTo(nl2 *NodeList, opts *EquivalenceOptions) bool {
+	if nl2 == nil {
+		return false
+	}
+	if opts == nil {
+		opts = &EquivalenceOptions{}
+	}
+
+	exclude := opts.excludedFields()
+	idx1 := nl.newEquivalenceIndex(opts, exclude)
+	idx2 := nl2.newEquivalenceIndex(opts, exclude)
+
+	return slices.Equal(idx1.nodes, idx2.nodes) &&
+		slices.Equal(idx1.edges, idx2.edges) &&
+		slices.Equal(idx1.roots, idx2.roots)
+}
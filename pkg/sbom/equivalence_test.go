@@ -0,0 +1,111 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestEquivalentTo(t *testing.T) {
+	build := func(rootID, libID, description string, date time.Time) *NodeList {
+		nl := NewNodeList()
+		nl.AddRootNode(&Node{Id: rootID, Name: "app", Version: "1.0"})
+		nl.AddNode(&Node{
+			Id:          libID,
+			Name:        "lib",
+			Version:     "2.0",
+			Description: description,
+			ReleaseDate: timestamppb.New(date),
+			Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/lib@2.0",
+			},
+		})
+		nl.AddEdge(&Edge{Type: Edge_dependsOn, From: rootID, To: []string{libID}})
+		return nl
+	}
+
+	now := time.Now()
+	for _, tc := range []struct {
+		name     string
+		nl1, nl2 *NodeList
+		opts     *EquivalenceOptions
+		equal    bool
+	}{
+		{
+			name:  "identical",
+			nl1:   build("app", "lib", "a library", now),
+			nl2:   build("app", "lib", "a library", now),
+			equal: true,
+		},
+		{
+			name: "different timestamps",
+			nl1:  build("app", "lib", "a library", now),
+			nl2:  build("app", "lib", "a library", now.Add(-time.Hour)),
+		},
+		{
+			name:  "ignore timestamps",
+			nl1:   build("app", "lib", "a library", now),
+			nl2:   build("app", "lib", "a library", now.Add(-time.Hour)),
+			opts:  &EquivalenceOptions{IgnoreTimestamps: true},
+			equal: true,
+		},
+		{
+			name:  "ignore descriptions",
+			nl1:   build("app", "lib", "a library", now),
+			nl2:   build("app", "lib", "the library", now),
+			opts:  &EquivalenceOptions{IgnoreDescriptions: true},
+			equal: true,
+		},
+		{
+			name:  "ignore fields",
+			nl1:   build("app", "lib", "a library", now),
+			nl2:   build("app", "lib", "the library", now),
+			opts:  &EquivalenceOptions{IgnoreFields: []string{"description"}},
+			equal: true,
+		},
+		{
+			name: "generated IDs",
+			nl1:  build("protobom-auto--000000001", "lib", "a library", now),
+			nl2:  build("protobom-auto--000000007", "lib", "a library", now),
+		},
+		{
+			name:  "ignore generated IDs",
+			nl1:   build("protobom-auto--000000001", "lib", "a library", now),
+			nl2:   build("protobom-auto--000000007", "lib", "a library", now),
+			opts:  &EquivalenceOptions{IgnoreGeneratedIDs: true},
+			equal: true,
+		},
+		{
+			name: "ignore generated IDs, other IDs differ",
+			nl1:  build("app", "lib", "a library", now),
+			nl2:  build("application", "lib", "a library", now),
+			opts: &EquivalenceOptions{IgnoreGeneratedIDs: true},
+		},
+		{
+			name:  "match by purl",
+			nl1:   build("app", "SPDXRef-lib", "a library", now),
+			nl2:   build("app", "pkg:golang/example.com/lib@2.0", "other", now.Add(time.Hour)),
+			opts:  &EquivalenceOptions{MatchByPurl: true},
+			equal: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.equal, tc.nl1.EquivalentTo(tc.nl2, tc.opts))
+			require.Equal(t, tc.equal, tc.nl2.EquivalentTo(tc.nl1, tc.opts))
+		})
+	}
+
+	// Split edges are equivalent to merged ones
+	nl1 := NewNodeList()
+	nl1.AddRootNode(&Node{Id: "app"})
+	nl1.AddNode(&Node{Id: "a"})
+	nl1.AddNode(&Node{Id: "b"})
+	nl2 := nl1.Copy()
+	nl1.AddEdge(&Edge{Type: Edge_contains, From: "app", To: []string{"a", "b"}})
+	nl2.AddEdge(&Edge{Type: Edge_contains, From: "app", To: []string{"b"}})
+	nl2.AddEdge(&Edge{Type: Edge_contains, From: "app", To: []string{"a"}})
+	require.True(t, nl1.EquivalentTo(nl2, nil))
+	require.False(t, nl1.EquivalentTo(nil, nil))
+}
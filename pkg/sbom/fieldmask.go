@@ -0,0 +1,166 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// structuralFieldPaths are the document fields needed to keep the graph
+// consistent and the document identifiable. They are always retained when
+// applying an inclusion mask.
+var structuralFieldPaths = []string{
+	"metadata.id",
+	"node_list.nodes.id",
+	"node_list.nodes.type",
+	"node_list.edges",
+	"node_list.root_elements",
+}
+
+// fieldMaskTree is a parsed representation of a set of field mask paths
+type fieldMaskTree map[string]fieldMaskTree
+
+// newFieldMaskTree parses the mask paths into a tree, validating them against
+// the message descriptor. Unlike the stock FieldMask validation, paths can
+// traverse repeated and map fields of message type. A path covers all the
+// paths below it, eg "metadata" covers "metadata.id".
+func newFieldMaskTree(md protoreflect.MessageDescriptor, paths []string) (fieldMaskTree, error) {
+	tree := fieldMaskTree{}
+	for _, p := range paths {
+		current := tree
+		desc := md
+		names := strings.Split(p, ".")
+		for i, name := range names {
+			if desc == nil {
+				return nil, fmt.Errorf("invalid field mask path %q: %q is not a message", p, name)
+			}
+			fd := desc.Fields().ByName(protoreflect.Name(name))
+			if fd == nil {
+				return nil, fmt.Errorf("invalid field mask path %q: unknown field %q", p, name)
+			}
+			switch subtree, ok := current[name]; {
+			case current == nil:
+				// Already covered by a shorter path, only validate it
+			case i == len(names)-1:
+				current[name] = fieldMaskTree{}
+			case !ok:
+				current[name] = fieldMaskTree{}
+				current = current[name]
+			case len(subtree) == 0:
+				current = nil
+			default:
+				current = subtree
+			}
+
+			desc = nil
+			switch {
+			case fd.IsMap():
+				desc = fd.MapValue().Message()
+			case fd.Message() != nil:
+				desc = fd.Message()
+			}
+		}
+	}
+	return tree, nil
+}
+
+// ApplyFieldMask returns a copy of the document with a field mask applied to
+// it. The document is not modified.
+//
+// The include mask follows the protobuf FieldMask semantics: when set, only
+// the listed fields are retained, all other fields are cleared. The exclude
+// mask clears the listed fields. Paths are relative to the Document and may
+// traverse repeated fields, for example "node_list.nodes.hashes" or
+// "metadata.authors.email".
+//
+// When an include mask is used, the fields required to keep the graph
+// consistent (document ID, node IDs and types, edges and root elements) are
+// always kept.
+func (d *Document) ApplyFieldMask(include, exclude *fieldmaskpb.FieldMask) (*Document, error) {
+	doc := d.Clone()
+
+	md := doc.ProtoReflect().Descriptor()
+	if len(include.GetPaths()) > 0 {
+		tree, err := newFieldMaskTree(md, slices.Concat(include.GetPaths(), structuralFieldPaths))
+		if err != nil {
+			return nil, err
+		}
+		retainFields(doc.ProtoReflect(), tree)
+	}
+
+	if len(exclude.GetPaths()) > 0 {
+		tree, err := newFieldMaskTree(md, exclude.GetPaths())
+		if err != nil {
+			return nil, err
+		}
+		clearFields(doc.ProtoReflect(), tree)
+	}
+
+	return doc, nil
+}
+
+// forEachSubmessage calls f for every message held in field fd of msg
+func forEachSubmessage(msg protoreflect.Message, fd protoreflect.FieldDescriptor, f func(protoreflect.Message)) {
+	switch {
+	case fd.IsList():
+		if fd.Message() == nil {
+			return
+		}
+		list := msg.Get(fd).List()
+		for i := range list.Len() {
+			f(list.Get(i).Message())
+		}
+	case fd.IsMap():
+		if fd.MapValue().Message() == nil {
+			return
+		}
+		msg.Get(fd).Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			f(v.Message())
+			return true
+		})
+	case fd.Message() != nil:
+		f(msg.Get(fd).Message())
+	}
+}
+
+// retainFields clears all fields in msg that are not in the mask tree
+func retainFields(msg protoreflect.Message, tree fieldMaskTree) {
+	// Collect the fields first, msg should not be mutated while ranging it
+	fields := []protoreflect.FieldDescriptor{}
+	msg.Range(func(fd protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		subtree, ok := tree[string(fd.Name())]
+		switch {
+		case !ok:
+			msg.Clear(fd)
+		case len(subtree) > 0:
+			forEachSubmessage(msg, fd, func(m protoreflect.Message) {
+				retainFields(m, subtree)
+			})
+		}
+	}
+}
+
+// clearFields clears all the fields in msg listed in the mask tree
+func clearFields(msg protoreflect.Message, tree fieldMaskTree) {
+	for name, subtree := range tree {
+		fd := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !msg.Has(fd) {
+			continue
+		}
+		if len(subtree) == 0 {
+			msg.Clear(fd)
+			continue
+		}
+		forEachSubmessage(msg, fd, func(m protoreflect.Message) {
+			clearFields(m, subtree)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func testFieldMaskDocument() *Document {
+	doc := NewDocument()
+	doc.Metadata.Id = "doc"
+	doc.Metadata.Name = "test"
+	doc.Metadata.Authors = []*Person{{Name: "John", Email: "john@example.com"}}
+	doc.NodeList.AddRootNode(&Node{
+		Id: "root", Name: "app", Version: "1.0",
+		Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "abc"},
+	})
+	doc.NodeList.AddNode(&Node{Id: "file", Name: "README", Type: Node_FILE, Hashes: map[int32]string{int32(HashAlgorithm_SHA1): "def"}})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_contains, From: "root", To: []string{"file"}})
+	return doc
+}
+
+func TestApplyFieldMask(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		include []string
+		exclude []string
+		mustErr bool
+		check   func(*testing.T, *Document)
+	}{
+		{
+			name:    "exclude hashes and emails",
+			exclude: []string{"node_list.nodes.hashes", "metadata.authors.email"},
+			check: func(t *testing.T, d *Document) {
+				require.Equal(t, "test", d.Metadata.Name)
+				require.Equal(t, "John", d.Metadata.Authors[0].Name)
+				require.Empty(t, d.Metadata.Authors[0].Email)
+				for _, n := range d.NodeList.Nodes {
+					require.Empty(t, n.Hashes)
+				}
+			},
+		},
+		{
+			name:    "include names only",
+			include: []string{"node_list.nodes.name"},
+			check: func(t *testing.T, d *Document) {
+				require.Equal(t, "doc", d.Metadata.Id)
+				require.Empty(t, d.Metadata.Name)
+				require.Len(t, d.NodeList.Nodes, 2)
+				require.Equal(t, "root", d.NodeList.Nodes[0].Id)
+				require.Equal(t, "app", d.NodeList.Nodes[0].Name)
+				require.Empty(t, d.NodeList.Nodes[0].Version)
+				require.Empty(t, d.NodeList.Nodes[0].Hashes)
+				require.Len(t, d.NodeList.Edges, 1)
+				require.Equal(t, []string{"root"}, d.NodeList.RootElements)
+			},
+		},
+		{
+			name:    "include and exclude",
+			include: []string{"metadata", "node_list.nodes.version"},
+			exclude: []string{"metadata.authors"},
+			check: func(t *testing.T, d *Document) {
+				require.Equal(t, "test", d.Metadata.Name)
+				require.Empty(t, d.Metadata.Authors)
+				require.Equal(t, "1.0", d.NodeList.Nodes[0].Version)
+				require.Empty(t, d.NodeList.Nodes[0].Name)
+			},
+		},
+		{
+			name:    "invalid path",
+			exclude: []string{"node_list.nodes.bogus"},
+			mustErr: true,
+		},
+		{
+			name:    "traversing scalar",
+			exclude: []string{"metadata.name.length"},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := testFieldMaskDocument()
+			var include, exclude *fieldmaskpb.FieldMask
+			if tc.include != nil {
+				include = &fieldmaskpb.FieldMask{Paths: tc.include}
+			}
+			if tc.exclude != nil {
+				exclude = &fieldmaskpb.FieldMask{Paths: tc.exclude}
+			}
+			res, err := doc.ApplyFieldMask(include, exclude)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			tc.check(t, res)
+
+			// The original document must not be modified
+			require.True(t, doc.NodeList.Equal(testFieldMaskDocument().NodeList))
+			require.Equal(t, "john@example.com", doc.Metadata.Authors[0].Email)
+		})
+	}
+}
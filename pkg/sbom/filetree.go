@@ -0,0 +1,108 @@
+package sbom
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+)
+
+// FileTree is a node and the nodes it contains, arranged as a directory
+// hierarchy
+type FileTree struct {
+	// Node is the node at this level of the tree
+	Node *Node
+
+	// Path is the location of the node relative to the root of the tree,
+	// the root itself is "/"
+	Path string
+
+	// Children are the trees of the nodes contained by Node, sorted by path
+	Children []*FileTree
+}
+
+// FileTree arranges the nodes contained by the node rootID, directly or
+// through other nodes, in a tree following the contains and contained_by
+// edges. The path of each node is its name joined to the path of its
+// parent, except for names already holding a path below the parent which
+// are used as is, so both the file names relative to their directory and
+// the full paths written by the SPDX tools produce the same layout.
+//
+// Nodes contained by more than one node appear under each of them. Edges
+// closing a cycle are not followed.
+func (nl *NodeList) FileTree(rootID string) (*FileTree, error) {
+	nodes := nl.indexNodes()
+	root, ok := nodes[rootID]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found", rootID)
+	}
+
+	contents, _ := nl.containmentIndex()
+
+	var build func(n *Node, p string, ancestors map[string]struct{}) *FileTree
+	build = func(n *Node, p string, ancestors map[string]struct{}) *FileTree {
+		t := &FileTree{Node: n, Path: p, Children: []*FileTree{}}
+		ancestors[n.Id] = struct{}{}
+		defer delete(ancestors, n.Id)
+
+		for _, id := range contents[n.Id] {
+			child, ok := nodes[id]
+			if !ok {
+				continue
+			}
+			if _, ok := ancestors[id]; ok {
+				continue
+			}
+			t.Children = append(t.Children, build(child, childPath(p, child.Name), ancestors))
+		}
+		slices.SortStableFunc(t.Children, func(a, b *FileTree) int {
+			return cmp.Compare(a.Path, b.Path)
+		})
+		return t
+	}
+	return build(root, "/", map[string]struct{}{}), nil
+}
+
+// childPath returns the path of a node named name contained in the node at
+// parent
+func childPath(parent, name string) string {
+	if name == "" {
+		return parent
+	}
+	p := path.Clean("/" + name)
+	if parent != "/" && strings.HasPrefix(p, parent+"/") {
+		return p
+	}
+	return path.Join(parent, p)
+}
+
+// Walk calls fn for every level of the tree, depth first and in path
+// order. It stops at the first error returned by fn.
+func (t *FileTree) Walk(fn func(*FileTree) error) error {
+	if err := fn(t); err != nil {
+		return err
+	}
+	for _, c := range t.Children {
+		if err := c.Walk(fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteFileListing writes the paths in the tree to w, one per line, as
+// listed by find(1) when run in the root of the tree
+func (t *FileTree) WriteFileListing(w io.Writer) error {
+	return t.Walk(func(ft *FileTree) error {
+		line := "."
+		if ft.Path != "/" {
+			line += ft.Path
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return fmt.Errorf("writing file listing: %w", err)
+		}
+		return nil
+	})
+}
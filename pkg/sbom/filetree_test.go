@@ -0,0 +1,87 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileTree(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		root    string
+		mustErr bool
+		// paths lists the tree paths in walk order
+		paths   []string
+		listing string
+	}{
+		{
+			name:  "image",
+			root:  "image",
+			paths: []string{"/", "/etc", "/etc/passwd", "/usr", "/usr/bin", "/usr/bin/cat", "/usr/bin/ls"},
+			listing: `.
+./etc
+./etc/passwd
+./usr
+./usr/bin
+./usr/bin/cat
+./usr/bin/ls
+`,
+		},
+		{
+			// usr contains bin again, the cycle is not followed
+			name:    "subtree with a cycle",
+			root:    "bin",
+			paths:   []string{"/", "/cat", "/usr", "/usr/bin/ls"},
+			listing: ".\n./cat\n./usr\n./usr/bin/ls\n",
+		},
+		{
+			name:    "single file",
+			root:    "passwd",
+			paths:   []string{"/"},
+			listing: ".\n",
+		},
+		{
+			name:    "missing root",
+			root:    "missing",
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{}
+			nl.AddRootNode(&Node{Id: "image", Name: "image"})
+			nl.AddNode(&Node{Id: "usr", Name: "usr", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "bin", Name: "bin", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "ls", Name: "/usr/bin/ls", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "cat", Name: "cat", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "etc", Name: "./etc", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "passwd", Name: "passwd", Type: Node_FILE})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "image", To: []string{"usr", "etc", "missing"}})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "usr", To: []string{"bin"}})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "bin", To: []string{"ls", "cat", "usr"}})
+			nl.AddEdge(&Edge{Type: Edge_contained_by, From: "passwd", To: []string{"etc"}})
+			nl.AddEdge(&Edge{Type: Edge_dependsOn, From: "image", To: []string{"cat"}})
+
+			tree, err := nl.FileTree(tc.root)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "/", tree.Path)
+			require.Equal(t, tc.root, tree.Node.Id)
+
+			paths := []string{}
+			require.NoError(t, tree.Walk(func(ft *FileTree) error {
+				paths = append(paths, ft.Path)
+				return nil
+			}))
+			require.Equal(t, tc.paths, paths)
+
+			var buf bytes.Buffer
+			require.NoError(t, tree.WriteFileListing(&buf))
+			require.Equal(t, tc.listing, buf.String())
+		})
+	}
+}
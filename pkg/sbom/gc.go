@@ -0,0 +1,87 @@
+package sbom
+
+import "slices"
+
+// GCReport lists the document data removed by Document.GC
+type GCReport struct {
+	// RootElements has the root element entries that pointed to nodes
+	// no longer in the document.
+	RootElements []string
+
+	// CustomLicenses has the identifiers of the custom licenses removed
+	// from the metadata as no node referenced them anymore.
+	CustomLicenses []string
+}
+
+// Len returns the total number of entries removed
+func (r *GCReport) Len() int {
+	return len(r.RootElements) + len(r.CustomLicenses)
+}
+
+// GC removes the document data left unreferenced after removing nodes from
+// the NodeList, usually after filtering a document:
+//
+//   - Root elements pointing to nodes not in the NodeList.
+//   - Edges from or to missing nodes (see NodeList.NormalizeEdges).
+//   - Custom licenses in the metadata not referenced in the licenses of any
+//     node.
+//
+// Two kinds of data are out of its scope. The tools listed in the metadata
+// are kept as protobom does not record which tool produced each node. The
+// model has no document level references to external documents: SPDX
+// external document references are not read, and the references to other
+// BOMs are node external references, removed along with their nodes. GC
+// returns a report of the data removed.
+func (d *Document) GC() *GCReport {
+	report := &GCReport{
+		RootElements:   []string{},
+		CustomLicenses: []string{},
+	}
+	if d.GetNodeList() == nil {
+		return report
+	}
+
+	nodes := d.NodeList.indexNodes()
+	roots := []string{}
+	for _, id := range d.NodeList.RootElements {
+		if _, ok := nodes[id]; ok {
+			roots = append(roots, id)
+			continue
+		}
+		report.RootElements = append(report.RootElements, id)
+	}
+	d.NodeList.RootElements = roots
+	d.NodeList.cleanEdges()
+
+	if d.GetMetadata() == nil || len(d.Metadata.CustomLicenses) == 0 {
+		return report
+	}
+
+	refs := d.NodeList.licenseRefs()
+	licenses := []*License{}
+	for _, l := range d.Metadata.CustomLicenses {
+		if _, ok := refs[l.Id]; ok {
+			licenses = append(licenses, l)
+			continue
+		}
+		report.CustomLicenses = append(report.CustomLicenses, l.Id)
+	}
+	d.Metadata.CustomLicenses = licenses
+	return report
+}
+
+// licenseRefs returns the set of custom license identifiers (LicenseRef-*)
+// found in the licenses of the nodes.
+func (nl *NodeList) licenseRefs() map[string]struct{} {
+	ret := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		for _, expression := range slices.Concat(n.Licenses, []string{n.LicenseConcluded}) {
+			for _, token := range tokenizeLicenseExpression(expression) {
+				if IsLicenseRef(token) {
+					ret[token] = struct{}{}
+				}
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,88 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentGC(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		sut            *Document
+		rootElements   []string
+		customLicenses []string
+		edgeTargets    [][]string
+		keptLicenses   []string
+	}{
+		{
+			name: "dangling data",
+			sut: func() *Document {
+				doc := NewDocument()
+				doc.NodeList.AddRootNode(&Node{Id: "root", Licenses: []string{"MIT OR LicenseRef-root"}})
+				doc.NodeList.AddRootNode(&Node{Id: "removed-root", LicenseConcluded: "LicenseRef-removed"})
+				doc.NodeList.AddNode(&Node{Id: "child", LicenseConcluded: "(Apache-2.0 AND LicenseRef-child)"})
+				doc.NodeList.AddEdge(&Edge{Type: Edge_contains, From: "root", To: []string{"child", "removed"}})
+				doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "removed", To: []string{"child"}})
+				for _, id := range []string{"LicenseRef-root", "LicenseRef-removed", "LicenseRef-child", "LicenseRef-unused"} {
+					doc.Metadata.AddCustomLicense(&License{Id: id})
+				}
+				doc.Metadata.Tools = []*Tool{{Name: "tool"}}
+
+				// Remove the node directly to leave the dangling data in place
+				doc.NodeList.Nodes = []*Node{doc.NodeList.Nodes[0], doc.NodeList.Nodes[2]}
+				return doc
+			}(),
+			rootElements:   []string{"removed-root"},
+			customLicenses: []string{"LicenseRef-removed", "LicenseRef-unused"},
+			edgeTargets:    [][]string{{"child"}},
+			keptLicenses:   []string{"LicenseRef-root", "LicenseRef-child"},
+		},
+		{
+			name: "nothing to collect",
+			sut: func() *Document {
+				doc := NewDocument()
+				doc.NodeList.AddRootNode(&Node{Id: "root", LicenseConcluded: "LicenseRef-root"})
+				doc.NodeList.AddNode(&Node{Id: "child"})
+				doc.NodeList.AddEdge(&Edge{Type: Edge_contains, From: "root", To: []string{"child"}})
+				doc.Metadata.AddCustomLicense(&License{Id: "LicenseRef-root"})
+				doc.Metadata.Tools = []*Tool{{Name: "tool"}}
+				return doc
+			}(),
+			rootElements:   []string{},
+			customLicenses: []string{},
+			edgeTargets:    [][]string{{"child"}},
+			keptLicenses:   []string{"LicenseRef-root"},
+		},
+		{
+			name:           "empty document",
+			sut:            &Document{},
+			rootElements:   []string{},
+			customLicenses: []string{},
+			edgeTargets:    [][]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tools := len(tc.sut.GetMetadata().GetTools())
+			report := tc.sut.GC()
+			require.Equal(t, tc.rootElements, report.RootElements)
+			require.Equal(t, tc.customLicenses, report.CustomLicenses)
+			require.Equal(t, len(tc.rootElements)+len(tc.customLicenses), report.Len())
+
+			targets := [][]string{}
+			for _, e := range tc.sut.GetNodeList().GetEdges() {
+				targets = append(targets, e.To)
+			}
+			require.Equal(t, tc.edgeTargets, targets)
+			require.Len(t, tc.sut.GetMetadata().GetCustomLicenses(), len(tc.keptLicenses))
+			for _, id := range tc.keptLicenses {
+				require.NotNil(t, tc.sut.Metadata.GetCustomLicense(id))
+			}
+			// Tools are out of the scope of the collector
+			require.Len(t, tc.sut.GetMetadata().GetTools(), tools)
+
+			// A second pass finds nothing to collect
+			require.Zero(t, tc.sut.GC().Len())
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package sbom
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
 	cdx "github.com/CycloneDX/cyclonedx-go"
 	"github.com/spdx/tools-golang/spdx/v2/common"
 )
@@ -128,6 +133,10 @@ func HashAlgorithmFromSPDX(spdxAlgo common.ChecksumAlgorithm) HashAlgorithm {
 // https://github.com/spdx/spdx-3-model/blob/main/model/Core/Vocabularies/HashAlgorithm.md
 func (ha HashAlgorithm) ToSPDX3() string {
 	switch ha {
+	case HashAlgorithm_ADLER32:
+		return "adler32"
+	case HashAlgorithm_MD2:
+		return "md2"
 	case HashAlgorithm_MD4:
 		return "md4"
 	case HashAlgorithm_MD5:
@@ -162,3 +171,78 @@ func (ha HashAlgorithm) ToSPDX3() string {
 		return ""
 	}
 }
+
+// HexLength returns the number of hexadecimal digits of a digest computed
+// with the algorithm. It returns 0 for the algorithms with variable length
+// output and for the unknown algorithm.
+func (ha HashAlgorithm) HexLength() int {
+	switch ha {
+	case HashAlgorithm_ADLER32:
+		return 8
+	case HashAlgorithm_MD2, HashAlgorithm_MD4, HashAlgorithm_MD5:
+		return 32
+	case HashAlgorithm_SHA1:
+		return 40
+	case HashAlgorithm_SHA224:
+		return 56
+	case HashAlgorithm_SHA256, HashAlgorithm_SHA3_256, HashAlgorithm_BLAKE2B_256, HashAlgorithm_BLAKE3:
+		return 64
+	case HashAlgorithm_SHA384, HashAlgorithm_SHA3_384, HashAlgorithm_BLAKE2B_384:
+		return 96
+	case HashAlgorithm_SHA512, HashAlgorithm_SHA3_512, HashAlgorithm_BLAKE2B_512:
+		return 128
+	default:
+		return 0
+	}
+}
+
+// ValidateValue checks that a hash value is consistent with the algorithm:
+// digests must be hexadecimal strings of the length produced by the
+// algorithm. Fuzzy hashes are checked for their own format and other
+// algorithms with variable length output only get their encoding checked.
+func (ha HashAlgorithm) ValidateValue(value string) error {
+	if value == "" {
+		return errors.New("hash value is empty")
+	}
+	if ha == HashAlgorithm_SSDEEP {
+		return validateSSDEEP(value)
+	}
+	if strings.Trim(value, "0123456789abcdefABCDEF") != "" && ha.HexLength() > 0 {
+		return fmt.Errorf("%s value is not a hexadecimal string", ha)
+	}
+
+	expected := ha.HexLength()
+	if expected == 0 || len(value) == expected {
+		return nil
+	}
+
+	// Name the algorithms matching the length, mislabeled hashes are common
+	candidates := []string{}
+	for i := range len(HashAlgorithm_name) {
+		if a := HashAlgorithm(i); a.HexLength() == len(value) { //nolint:gosec
+			candidates = append(candidates, a.String())
+		}
+	}
+	if len(candidates) > 0 {
+		return fmt.Errorf(
+			"%s value has %d hex digits, expected %d (length matches %s)",
+			ha, len(value), expected, strings.Join(candidates, ", "),
+		)
+	}
+	return fmt.Errorf("%s value has %d hex digits, expected %d", ha, len(value), expected)
+}
+
+// validateSSDEEP checks that a value has the ssdeep blocksize:hash:hash form
+func validateSSDEEP(value string) error {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return errors.New("SSDEEP value must have the form blocksize:hash:hash")
+	}
+	if _, err := strconv.ParseUint(parts[0], 10, 32); err != nil {
+		return fmt.Errorf("SSDEEP value has an invalid block size %q", parts[0])
+	}
+	if parts[1] == "" {
+		return errors.New("SSDEEP value has an empty hash")
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package sbom
+
+import (
+	"crypto/md5"  //nolint:gosec // MD5 is still found in SBOMs
+	"crypto/sha1" //nolint:gosec // SHA1 is required in SPDX2 and gitoids
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+// GitoidPrefix is the scheme of the gitoid URIs used as OmniBOR artifact IDs
+const GitoidPrefix = "gitoid:blob:"
+
+// newHasher returns a hash.Hash for the algorithms that can be computed
+// with the standard library.
+func newHasher(algo HashAlgorithm) (hash.Hash, error) {
+	switch algo {
+	case HashAlgorithm_MD5:
+		return md5.New(), nil //nolint:gosec
+	case HashAlgorithm_SHA1:
+		return sha1.New(), nil //nolint:gosec
+	case HashAlgorithm_SHA224:
+		return sha256.New224(), nil
+	case HashAlgorithm_SHA256:
+		return sha256.New(), nil
+	case HashAlgorithm_SHA384:
+		return sha512.New384(), nil
+	case HashAlgorithm_SHA512:
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("computing %s hashes is not supported", algo)
+	}
+}
+
+// gitoidAlgorithmName returns the name of a hash algorithm in gitoid URIs.
+// OmniBOR supports SHA-256 and, for compatibility with git, SHA-1.
+func gitoidAlgorithmName(algo HashAlgorithm) (string, error) {
+	switch algo {
+	case HashAlgorithm_SHA1:
+		return "sha1", nil
+	case HashAlgorithm_SHA256:
+		return "sha256", nil
+	default:
+		return "", fmt.Errorf("gitoids cannot be computed with %s", algo)
+	}
+}
+
+// newHashers returns the hashers for a list of algorithms
+func newHashers(algos []HashAlgorithm) (map[HashAlgorithm]hash.Hash, error) {
+	ret := map[HashAlgorithm]hash.Hash{}
+	for _, algo := range algos {
+		h, err := newHasher(algo)
+		if err != nil {
+			return nil, err
+		}
+		ret[algo] = h
+	}
+	return ret, nil
+}
+
+// hashSums returns the hex digests of the hashers keyed by algorithm
+func hashSums(hashers map[HashAlgorithm]hash.Hash) map[int32]string {
+	ret := map[int32]string{}
+	for algo, h := range hashers {
+		ret[int32(algo)] = hex.EncodeToString(h.Sum(nil))
+	}
+	return ret
+}
+
+// HashReader computes the hashes of the data read from r with the specified
+// algorithms. The returned map can be assigned to the hashes of a node.
+func HashReader(r io.Reader, algos ...HashAlgorithm) (map[int32]string, error) {
+	hashers, err := newHashers(algos)
+	if err != nil {
+		return nil, err
+	}
+	writers := []io.Writer{}
+	for _, h := range hashers {
+		writers = append(writers, h)
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), r); err != nil {
+		return nil, fmt.Errorf("hashing data: %w", err)
+	}
+	return hashSums(hashers), nil
+}
+
+// newGitoidHasher returns a hasher primed with the git object header of a
+// blob of size bytes and the gitoid URI prefix for the algorithm.
+func newGitoidHasher(size int64, algo HashAlgorithm) (hash.Hash, string, error) {
+	name, err := gitoidAlgorithmName(algo)
+	if err != nil {
+		return nil, "", err
+	}
+	h, err := newHasher(algo)
+	if err != nil {
+		return nil, "", err
+	}
+	fmt.Fprintf(h, "blob %d\x00", size)
+	return h, GitoidPrefix + name + ":", nil
+}
+
+// GitoidFromReader computes the gitoid of a blob of size bytes read from r.
+// The gitoid is the git object hash of the blob and is returned as a URI,
+// eg gitoid:blob:sha256:<hex>, ready to be used as an OmniBOR artifact ID.
+func GitoidFromReader(r io.Reader, size int64, algo HashAlgorithm) (string, error) {
+	h, prefix, err := newGitoidHasher(size, algo)
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(h, r)
+	if err != nil {
+		return "", fmt.Errorf("hashing blob: %w", err)
+	}
+	if n != size {
+		return "", fmt.Errorf("blob size mismatch: read %d bytes, expected %d", n, size)
+	}
+	return prefix + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// ParseGitoid splits a gitoid URI in its hash algorithm and hex digest
+func ParseGitoid(gitoid string) (HashAlgorithm, string, error) {
+	rest, ok := strings.CutPrefix(gitoid, GitoidPrefix)
+	if !ok {
+		return HashAlgorithm_UNKNOWN, "", fmt.Errorf("gitoid %q does not start with %s", gitoid, GitoidPrefix)
+	}
+	name, digest, ok := strings.Cut(rest, ":")
+	if !ok {
+		return HashAlgorithm_UNKNOWN, "", errors.New("gitoid is missing the hash algorithm")
+	}
+
+	var algo HashAlgorithm
+	switch name {
+	case "sha1":
+		algo = HashAlgorithm_SHA1
+	case "sha256":
+		algo = HashAlgorithm_SHA256
+	default:
+		return HashAlgorithm_UNKNOWN, "", fmt.Errorf("unsupported gitoid hash algorithm %q", name)
+	}
+	if err := algo.ValidateValue(digest); err != nil {
+		return HashAlgorithm_UNKNOWN, "", fmt.Errorf("invalid gitoid digest: %w", err)
+	}
+	return algo, digest, nil
+}
+
+// HashContent computes the hashes of size bytes of content read from r and
+// adds them to the node. The SHA-256 gitoid of the content is computed in
+// the same pass and set as the node's GITOID identifier (its OmniBOR ID).
+func (n *Node) HashContent(r io.Reader, size int64, algos ...HashAlgorithm) error {
+	hashers, err := newHashers(algos)
+	if err != nil {
+		return err
+	}
+	gitoidHasher, prefix, err := newGitoidHasher(size, HashAlgorithm_SHA256)
+	if err != nil {
+		return err
+	}
+
+	writers := []io.Writer{gitoidHasher}
+	for _, h := range hashers {
+		writers = append(writers, h)
+	}
+	read, err := io.Copy(io.MultiWriter(writers...), r)
+	if err != nil {
+		return fmt.Errorf("hashing content: %w", err)
+	}
+	if read != size {
+		return fmt.Errorf("content size mismatch: read %d bytes, expected %d", read, size)
+	}
+
+	for algo, value := range hashSums(hashers) {
+		n.AddHash(HashAlgorithm(algo), value)
+	}
+	if n.Identifiers == nil {
+		n.Identifiers = map[int32]string{}
+	}
+	n.Identifiers[int32(SoftwareIdentifierType_GITOID)] = prefix + hex.EncodeToString(gitoidHasher.Sum(nil))
+	return nil
+}
@@ -0,0 +1,111 @@
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitoidFromReader(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		size    int64
+		algo    HashAlgorithm
+		id      string
+		mustErr bool
+	}{
+		// Same as git hash-object
+		{name: "sha1", size: 6, algo: HashAlgorithm_SHA1, id: "gitoid:blob:sha1:ce013625030ba8dba906f756967f9e9ca394464a"},
+		{name: "sha256", size: 6, algo: HashAlgorithm_SHA256, id: "gitoid:blob:sha256:2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4"},
+		{name: "size mismatch", size: 10, algo: HashAlgorithm_SHA256, mustErr: true},
+		{name: "unsupported algorithm", size: 6, algo: HashAlgorithm_MD5, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := GitoidFromReader(strings.NewReader("hello\n"), tc.size, tc.algo)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.id, id)
+		})
+	}
+}
+
+func TestParseGitoid(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		gitoid  string
+		algo    HashAlgorithm
+		digest  string
+		mustErr bool
+	}{
+		{
+			name: "sha1", gitoid: "gitoid:blob:sha1:ce013625030ba8dba906f756967f9e9ca394464a",
+			algo: HashAlgorithm_SHA1, digest: "ce013625030ba8dba906f756967f9e9ca394464a",
+		},
+		{
+			name: "sha256", gitoid: "gitoid:blob:sha256:2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4",
+			algo: HashAlgorithm_SHA256, digest: "2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4",
+		},
+		{name: "digest length mismatch", gitoid: "gitoid:blob:sha256:ce013625030ba8dba906f756967f9e9ca394464a", mustErr: true},
+		{name: "not a gitoid", gitoid: "pkg:npm/left-pad", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			algo, digest, err := ParseGitoid(tc.gitoid)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.algo, algo)
+			require.Equal(t, tc.digest, digest)
+		})
+	}
+}
+
+func TestHashContent(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		size    int64
+		algos   []HashAlgorithm
+		hashes  map[int32]string
+		mustErr bool
+	}{
+		{
+			name:  "multiple algorithms",
+			size:  6,
+			algos: []HashAlgorithm{HashAlgorithm_MD5, HashAlgorithm_SHA256},
+			hashes: map[int32]string{
+				int32(HashAlgorithm_MD5):    "b1946ac92492d2347c6235b4d2611184",
+				int32(HashAlgorithm_SHA256): "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03",
+			},
+		},
+		{
+			// The gitoid is always computed
+			name: "only gitoid",
+			size: 6,
+		},
+		{name: "unsupported algorithm", size: 6, algos: []HashAlgorithm{HashAlgorithm_BLAKE3}, mustErr: true},
+		{name: "size mismatch", size: 10, algos: []HashAlgorithm{HashAlgorithm_MD5}, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Id: "file"}
+			err := n.HashContent(strings.NewReader("hello\n"), tc.size, tc.algos...)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, len(tc.hashes), len(n.Hashes))
+			for algo, value := range tc.hashes {
+				require.Equal(t, value, n.Hashes[algo])
+			}
+			require.Equal(t,
+				"gitoid:blob:sha256:2cf8d83d9ee29543b34a87727421fdecb7e3f3a183d337639025de576db9ebb4",
+				n.Identifiers[int32(SoftwareIdentifierType_GITOID)],
+			)
+		})
+	}
+}
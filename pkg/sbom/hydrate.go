@@ -0,0 +1,100 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Hydrator is implemented by the sources and storage backends able to load
+// the full data of a node, for example a mapped document from the storage
+// package or a fully parsed document.
+type Hydrator interface {
+	// GetNodeByID returns the complete node with the specified ID
+	GetNodeByID(id string) (*Node, error)
+}
+
+// nodeListHydrator hydrates nodes from an in-memory NodeList
+type nodeListHydrator struct {
+	nl *NodeList
+}
+
+// GetNodeByID returns the node with the specified ID from the NodeList
+func (h *nodeListHydrator) GetNodeByID(id string) (*Node, error) {
+	n := h.nl.GetNodeByID(id)
+	if n == nil {
+		return nil, fmt.Errorf("node %q not found", id)
+	}
+	return n, nil
+}
+
+// NewNodeListHydrator returns a Hydrator that loads the node data from a
+// NodeList, for example one kept from the original parsed document.
+func NewNodeListHydrator(nl *NodeList) Hydrator {
+	return &nodeListHydrator{nl: nl}
+}
+
+// Dehydrate clears the lazily loadable fields of the node: the large, rarely
+// used texts (license comments, copyright, source info, comment, summary and
+// description). They can be loaded back with Hydrate.
+func (n *Node) Dehydrate() {
+	n.LicenseComments = ""
+	n.Copyright = ""
+	n.SourceInfo = ""
+	n.Comment = ""
+	n.Summary = ""
+	n.Description = ""
+}
+
+// IsDehydrated returns true when none of the lazily loadable fields is set
+func (n *Node) IsDehydrated() bool {
+	return n.LicenseComments == "" && n.Copyright == "" && n.SourceInfo == "" &&
+		n.Comment == "" && n.Summary == "" && n.Description == ""
+}
+
+// Hydrate loads the lazily loadable fields of the node from h. Fields
+// already set in the node are not overwritten.
+func (n *Node) Hydrate(h Hydrator) error {
+	if h == nil {
+		return errors.New("unable to hydrate node, no hydrator set")
+	}
+	full, err := h.GetNodeByID(n.Id)
+	if err != nil {
+		return fmt.Errorf("hydrating node %q: %w", n.Id, err)
+	}
+	if n.LicenseComments == "" {
+		n.LicenseComments = full.LicenseComments
+	}
+	if n.Copyright == "" {
+		n.Copyright = full.Copyright
+	}
+	if n.SourceInfo == "" {
+		n.SourceInfo = full.SourceInfo
+	}
+	if n.Comment == "" {
+		n.Comment = full.Comment
+	}
+	if n.Summary == "" {
+		n.Summary = full.Summary
+	}
+	if n.Description == "" {
+		n.Description = full.Description
+	}
+	return nil
+}
+
+// Dehydrate clears the lazily loadable fields of all nodes in the NodeList
+func (nl *NodeList) Dehydrate() {
+	for _, n := range nl.Nodes {
+		n.Dehydrate()
+	}
+}
+
+// Hydrate loads the lazily loadable fields of all nodes in the NodeList
+func (nl *NodeList) Hydrate(h Hydrator) error {
+	for _, n := range nl.Nodes {
+		if err := n.Hydrate(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHydrate(t *testing.T) {
+	full := NewNodeList()
+	full.AddNode(&Node{
+		Id:              "pkg",
+		Name:            "pkg",
+		Description:     "A long description",
+		Copyright:       "Copyright ACME",
+		LicenseComments: "License notes",
+	})
+
+	for _, tc := range []struct {
+		name string
+		// sut returns the node to hydrate
+		sut      func() *Node
+		hydrator Hydrator
+		mustErr  bool
+		expected *Node
+	}{
+		{
+			name: "dehydrated node",
+			sut: func() *Node {
+				light := full.Copy()
+				light.Dehydrate()
+				return light.GetNodeByID("pkg")
+			},
+			hydrator: NewNodeListHydrator(full),
+			expected: &Node{
+				Id: "pkg", Name: "pkg", Description: "A long description",
+				Copyright: "Copyright ACME", LicenseComments: "License notes",
+			},
+		},
+		{
+			// Fields set in the node are preserved
+			name: "fields set in the node",
+			sut: func() *Node {
+				light := full.Copy()
+				light.Dehydrate()
+				n := light.GetNodeByID("pkg")
+				n.Copyright = "Copyright Example"
+				return n
+			},
+			hydrator: NewNodeListHydrator(full),
+			expected: &Node{
+				Id: "pkg", Name: "pkg", Description: "A long description",
+				Copyright: "Copyright Example", LicenseComments: "License notes",
+			},
+		},
+		{
+			name:    "nil hydrator",
+			sut:     func() *Node { return &Node{Id: "pkg"} },
+			mustErr: true,
+		},
+		{
+			name:     "missing node",
+			sut:      func() *Node { return &Node{Id: "missing"} },
+			hydrator: NewNodeListHydrator(full),
+			mustErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := tc.sut()
+			err := n.Hydrate(tc.hydrator)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, n.IsDehydrated())
+			require.Equal(t, tc.expected.Name, n.Name)
+			require.Equal(t, tc.expected.Description, n.Description)
+			require.Equal(t, tc.expected.Copyright, n.Copyright)
+			require.Equal(t, tc.expected.LicenseComments, n.LicenseComments)
+
+			// The source nodelist is not modified
+			require.False(t, full.GetNodeByID("pkg").IsDehydrated())
+			require.Equal(t, "Copyright ACME", full.GetNodeByID("pkg").Copyright)
+		})
+	}
+}
+
+func TestDehydrate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		sut  *Node
+	}{
+		{name: "license comments", sut: &Node{Id: "pkg", Name: "pkg", LicenseComments: "License notes"}},
+		{name: "copyright", sut: &Node{Id: "pkg", Name: "pkg", Copyright: "Copyright ACME"}},
+		{name: "source info", sut: &Node{Id: "pkg", Name: "pkg", SourceInfo: "Built from source"}},
+		{name: "comment", sut: &Node{Id: "pkg", Name: "pkg", Comment: "A comment"}},
+		{name: "summary", sut: &Node{Id: "pkg", Name: "pkg", Summary: "A summary"}},
+		{name: "description", sut: &Node{Id: "pkg", Name: "pkg", Description: "A long description"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := NewNodeList()
+			nl.AddNode(tc.sut)
+			require.False(t, tc.sut.IsDehydrated())
+
+			light := nl.Copy()
+			light.Dehydrate()
+			n := light.GetNodeByID("pkg")
+			require.True(t, n.IsDehydrated())
+			require.Equal(t, "pkg", n.Name)
+
+			// The original nodelist is not modified
+			require.False(t, tc.sut.IsDehydrated())
+
+			require.NoError(t, light.Hydrate(NewNodeListHydrator(nl)))
+			require.True(t, n.Equal(tc.sut))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// IDConflictPolicy defines what to do when merging a NodeList with a node
+// whose ID is taken by a different component in the receiving list.
+type IDConflictPolicy string
+
+const (
+	// IDConflictMerge merges the data of the nodes as if they were the
+	// same component. This is the default.
+	IDConflictMerge IDConflictPolicy = "merge"
+
+	// IDConflictRenameIncoming adds the incoming node with a new ID,
+	// updating the edges and root elements of its list to point to it.
+	IDConflictRenameIncoming IDConflictPolicy = "rename-incoming"
+
+	// IDConflictPreferExisting keeps the existing node and drops the data
+	// of the incoming one. The edges of the incoming list still point to
+	// the ID.
+	IDConflictPreferExisting IDConflictPolicy = "prefer-existing"
+
+	// IDConflictError fails the operation
+	IDConflictError IDConflictPolicy = "error"
+)
+
+// ErrIDConflict is returned when merging nodes with the same ID describing
+// different components and the conflict resolves to IDConflictError.
+var ErrIDConflict = errors.New("node ID conflict")
+
+// IDConflictResolver returns the policy applied to a pair of nodes with the
+// same ID that describe different components
+type IDConflictResolver func(existing, incoming *Node) IDConflictPolicy
+
+// WithIDConflictPolicy applies a policy to all the ID conflicts found when
+// merging NodeLists with Add, Union or TryUnion. See WithIDConflictResolver.
+func WithIDConflictPolicy(p IDConflictPolicy) NodeListOption {
+	return WithIDConflictResolver(func(_, _ *Node) IDConflictPolicy { return p })
+}
+
+// WithIDConflictResolver sets the function that decides how to resolve
+// each ID conflict found when merging NodeLists with Add, Union or
+// TryUnion. Nodes are in conflict when they have the same ID but, as
+// reported by Node.ConflictsWith, describe different components.
+//
+// Union can't return errors, it renames the incoming node when the resolver
+// returns IDConflictError. Use Add or TryUnion to get the error.
+func WithIDConflictResolver(r IDConflictResolver) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.idConflictResolver = r
+	}
+}
+
+// ConflictsWith returns true if n2 is demonstrably a different component
+// than the node: their package URLs, ignoring the qualifiers and subpath,
+// differ or they have different hashes computed with the same algorithm.
+// Missing data is not a conflict.
+func (n *Node) ConflictsWith(n2 *Node) bool {
+	if n == nil || n2 == nil {
+		return false
+	}
+	purlBase := func(purl PackageURL) string {
+		base, _, _ := strings.Cut(string(purl), "?")
+		base, _, _ = strings.Cut(base, "#")
+		return base
+	}
+	if p1, p2 := purlBase(n.Purl()), purlBase(n2.Purl()); p1 != "" && p2 != "" && !strings.EqualFold(p1, p2) {
+		return true
+	}
+	for algo, h := range n.Hashes {
+		if h2, ok := n2.Hashes[algo]; ok && h != "" && h2 != "" && !strings.EqualFold(h, h2) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveIDConflicts returns nl2 with the ID conflicts against nl resolved:
+// nodes to rename get a new ID not used in either list and nodes yielding
+// to the existing ones are removed. If there is nothing to change, nl2 is
+// returned unchanged.
+func (nl *NodeList) resolveIDConflicts(nl2 *NodeList, resolver IDConflictResolver) (*NodeList, error) {
+	existing := nl.indexNodes()
+	renames := map[string]string{}
+	dropped := map[string]struct{}{}
+	var taken map[string]struct{}
+	for _, n := range nl2.GetNodes() {
+		e, ok := existing[n.Id]
+		if !ok || !e.ConflictsWith(n) {
+			continue
+		}
+		switch p := resolver(e, n); p {
+		case IDConflictMerge, "":
+		case IDConflictRenameIncoming:
+			if taken == nil {
+				taken = map[string]struct{}{}
+				for _, nodes := range [][]*Node{nl.GetNodes(), nl2.GetNodes()} {
+					for _, n := range nodes {
+						taken[n.Id] = struct{}{}
+					}
+				}
+			}
+			renames[n.Id] = freeNodeID(n.Id, taken)
+		case IDConflictPreferExisting:
+			dropped[n.Id] = struct{}{}
+		case IDConflictError:
+			return nil, fmt.Errorf("%w: %q is %q and %q", ErrIDConflict, n.Id, diffNodeLabel(e), diffNodeLabel(n))
+		default:
+			return nil, fmt.Errorf("unknown ID conflict policy %q", p)
+		}
+	}
+	if len(renames) == 0 && len(dropped) == 0 {
+		return nl2, nil
+	}
+
+	ret := nl2.Copy()
+	ret.renameNodes(renames)
+	nodes := []*Node{}
+	for _, n := range ret.Nodes {
+		if _, ok := dropped[n.Id]; !ok {
+			nodes = append(nodes, n)
+		}
+	}
+	ret.Nodes = nodes
+	return ret, nil
+}
+
+// freeNodeID returns the first ID derived from id not in taken, and
+// records it as taken
+func freeNodeID(id string, taken map[string]struct{}) string {
+	for i := 2; ; i++ {
+		newID := fmt.Sprintf("%s-%d", id, i)
+		if _, ok := taken[newID]; !ok {
+			taken[newID] = struct{}{}
+			return newID
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeConflictsWith(t *testing.T) {
+	sha256 := int32(HashAlgorithm_SHA256)
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	for name, tc := range map[string]struct {
+		n1, n2   *Node
+		conflict bool
+	}{
+		"no data":         {&Node{Id: "a"}, &Node{Id: "a", Name: "other"}, false},
+		"same purl":       {&Node{Identifiers: purl("pkg:npm/a@1")}, &Node{Identifiers: purl("pkg:npm/a@1")}, false},
+		"qualifiers":      {&Node{Identifiers: purl("pkg:npm/a@1?arch=x")}, &Node{Identifiers: purl("pkg:npm/a@1")}, false},
+		"different purl":  {&Node{Identifiers: purl("pkg:npm/a@1")}, &Node{Identifiers: purl("pkg:npm/b@1")}, true},
+		"one purl":        {&Node{Identifiers: purl("pkg:npm/a@1")}, &Node{}, false},
+		"same hash":       {&Node{Hashes: map[int32]string{sha256: "ABC"}}, &Node{Hashes: map[int32]string{sha256: "abc"}}, false},
+		"different hash":  {&Node{Hashes: map[int32]string{sha256: "abc"}}, &Node{Hashes: map[int32]string{sha256: "def"}}, true},
+		"other algorithm": {&Node{Hashes: map[int32]string{sha256: "abc"}}, &Node{Hashes: map[int32]string{int32(HashAlgorithm_SHA1): "def"}}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.conflict, tc.n1.ConflictsWith(tc.n2))
+			require.Equal(t, tc.conflict, tc.n2.ConflictsWith(tc.n1))
+		})
+	}
+}
+
+func TestIDConflicts(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	existing := func() *NodeList {
+		return &NodeList{
+			Nodes: []*Node{
+				{Id: "Package-1", Name: "a", Identifiers: purl("pkg:npm/a@1")},
+				{Id: "Package-1-2", Name: "taken"},
+			},
+			RootElements: []string{"Package-1"},
+		}
+	}
+	incoming := func() *NodeList {
+		return &NodeList{
+			Nodes: []*Node{
+				{Id: "Package-1", Name: "b", Identifiers: purl("pkg:npm/b@1")},
+				{Id: "Package-2", Name: "c"},
+			},
+			Edges:        []*Edge{{From: "Package-1", Type: Edge_dependsOn, To: []string{"Package-2"}}},
+			RootElements: []string{"Package-1"},
+		}
+	}
+
+	t.Run("merge", func(t *testing.T) {
+		nl := existing()
+		nl.Add(incoming())
+		require.Len(t, nl.Nodes, 3)
+		require.Equal(t, "a", nl.GetNodeByID("Package-1").Name)
+	})
+
+	t.Run("rename incoming", func(t *testing.T) {
+		check := func(nl *NodeList) {
+			require.Len(t, nl.Nodes, 4)
+			require.Equal(t, "a", nl.GetNodeByID("Package-1").Name)
+			require.Equal(t, "b", nl.GetNodeByID("Package-1-3").Name)
+			require.Equal(t, []string{"Package-1", "Package-1-3"}, nl.RootElements)
+			require.Equal(t, []string{"Package-2"}, nl.GetEdgeByType("Package-1-3", Edge_dependsOn).To)
+			require.Nil(t, nl.GetEdgeByType("Package-1", Edge_dependsOn))
+		}
+		nl2 := incoming()
+		nl := existing()
+		nl.Add(nl2, WithIDConflictPolicy(IDConflictRenameIncoming))
+		check(nl)
+		check(existing().Union(nl2, WithIDConflictPolicy(IDConflictRenameIncoming)))
+
+		// The incoming list is not modified
+		require.Equal(t, incoming(), nl2)
+	})
+
+	t.Run("prefer existing", func(t *testing.T) {
+		nl := existing()
+		nl.Add(incoming(), WithIDConflictPolicy(IDConflictPreferExisting))
+		require.Len(t, nl.Nodes, 3)
+		require.Equal(t, "a", nl.GetNodeByID("Package-1").Name)
+		require.Equal(t, purl("pkg:npm/a@1"), nl.GetNodeByID("Package-1").Identifiers)
+		require.Equal(t, []string{"Package-2"}, nl.GetEdgeByType("Package-1", Edge_dependsOn).To)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		nl := existing()
+		err := nl.TryAdd(incoming(), WithIDConflictPolicy(IDConflictError))
+		require.ErrorIs(t, err, ErrIDConflict)
+		require.Equal(t, existing(), nl)
+		require.NoError(t, nl.TryAdd(incoming(), WithIDConflictPolicy(IDConflictRenameIncoming)))
+		require.Len(t, nl.Nodes, 4)
+
+		// Add can't fail either, the conflicting nodes are kept apart
+		nl = existing()
+		nl.Add(incoming(), WithIDConflictPolicy(IDConflictError))
+		require.Len(t, nl.Nodes, 4)
+		require.Equal(t, "b", nl.GetNodeByID("Package-1-3").Name)
+
+		_, err = existing().TryUnion(incoming(), WithIDConflictPolicy(IDConflictError))
+		require.ErrorIs(t, err, ErrIDConflict)
+		union, err := existing().TryUnion(incoming(), WithIDConflictPolicy(IDConflictRenameIncoming))
+		require.NoError(t, err)
+		require.Len(t, union.Nodes, 4)
+
+		// Union can't fail, the conflicting nodes are kept apart
+		union = existing().Union(incoming(), WithIDConflictPolicy(IDConflictError))
+		require.Len(t, union.Nodes, 4)
+		require.Equal(t, "a", union.GetNodeByID("Package-1").Name)
+		require.Equal(t, "b", union.GetNodeByID("Package-1-3").Name)
+	})
+
+	t.Run("resolver", func(t *testing.T) {
+		var calls int
+		nl := existing()
+		nl.Add(incoming(), WithIDConflictResolver(func(e, i *Node) IDConflictPolicy {
+			calls++
+			require.Equal(t, "a", e.Name)
+			require.Equal(t, "b", i.Name)
+			return IDConflictPreferExisting
+		}))
+		require.Equal(t, 1, calls)
+		require.Equal(t, "a", nl.GetNodeByID("Package-1").Name)
+	})
+}
@@ -1,6 +1,8 @@
 package sbom
 
 import (
+	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/protobom/protobom/pkg/formats/spdx"
@@ -20,6 +22,10 @@ func SoftwareIdentifierTypeFromString(queryString string) SoftwareIdentifierType
 		return SoftwareIdentifierType_CPE22
 	case "cpe23", "cpe2.3":
 		return SoftwareIdentifierType_CPE23
+	case "omnibor", "omniborid":
+		return SoftwareIdentifierType_GITOID
+	case "swhid":
+		return SoftwareIdentifierType_SWHID
 	default:
 		return SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE
 	}
@@ -38,6 +44,8 @@ func SoftwareIdentifierTypeFromSPDXExtRefType(spdxType string) SoftwareIdentifie
 		return SoftwareIdentifierType_CPE23
 	case spdx.ExtRefTypeGitoid:
 		return SoftwareIdentifierType_GITOID
+	case spdx.ExtRefTypeSWHID:
+		return SoftwareIdentifierType_SWHID
 	default:
 		return SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE
 	}
@@ -50,7 +58,7 @@ func (i SoftwareIdentifierType) ToSPDX2Category() string {
 		return spdx.CategorySecurity
 	case "maven-central", "npm", "nuget", "bower", spdx.ExtRefTypePurl:
 		return spdx.CategoryPackageManager
-	case "swh", spdx.ExtRefTypeGitoid:
+	case spdx.ExtRefTypeSWHID, spdx.ExtRefTypeGitoid:
 		return spdx.CategoryPersistentID
 	default:
 		return spdx.CategoryOther
@@ -68,7 +76,55 @@ func (i SoftwareIdentifierType) ToSPDX2Type() string {
 		return spdx.ExtRefTypeCPE23
 	case SoftwareIdentifierType_GITOID:
 		return spdx.ExtRefTypeGitoid
+	case SoftwareIdentifierType_SWHID:
+		return spdx.ExtRefTypeSWHID
 	default:
 		return ""
 	}
 }
+
+// swhidObjectTypes are the object types of SWHID core identifiers
+var swhidObjectTypes = []string{"cnt", "dir", "rev", "rel", "snp"}
+
+// ValidateValue checks the syntax of identifiers with a well defined format.
+// Identifier types without one are not checked.
+func (i SoftwareIdentifierType) ValidateValue(value string) error {
+	switch i {
+	case SoftwareIdentifierType_GITOID:
+		_, _, err := ParseGitoid(value)
+		return err
+	case SoftwareIdentifierType_SWHID:
+		return ValidateSWHID(value)
+	default:
+		return nil
+	}
+}
+
+// ValidateSWHID checks that a string is a valid SWHID, a core identifier
+// of the form swh:1:<type>:<sha1> optionally followed by ;key=value
+// qualifiers, eg swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2
+func ValidateSWHID(swhid string) error {
+	core, qualifiers, _ := strings.Cut(swhid, ";")
+	parts := strings.Split(core, ":")
+	if len(parts) != 4 || parts[0] != "swh" {
+		return fmt.Errorf("SWHID %q must have the form swh:1:<type>:<id>", swhid)
+	}
+	if parts[1] != "1" {
+		return fmt.Errorf("unsupported SWHID version %q", parts[1])
+	}
+	if !slices.Contains(swhidObjectTypes, parts[2]) {
+		return fmt.Errorf("invalid SWHID object type %q", parts[2])
+	}
+	if len(parts[3]) != 40 || strings.Trim(parts[3], "0123456789abcdef") != "" {
+		return fmt.Errorf("SWHID object id %q is not a lowercase SHA1 hex digest", parts[3])
+	}
+	if qualifiers == "" {
+		return nil
+	}
+	for _, q := range strings.Split(qualifiers, ";") {
+		if k, v, ok := strings.Cut(q, "="); !ok || k == "" || v == "" {
+			return fmt.Errorf("invalid SWHID qualifier %q", q)
+		}
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ func TestIToSPDX2Category(t *testing.T) {
 		{SoftwareIdentifierType_CPE23, spdx.CategorySecurity},
 		{SoftwareIdentifierType_CPE22, spdx.CategorySecurity},
 		{SoftwareIdentifierType_GITOID, spdx.CategoryPersistentID},
+		{SoftwareIdentifierType_SWHID, spdx.CategoryPersistentID},
 		{SoftwareIdentifierType(328742873), spdx.CategoryOther},
 	} {
 		require.Equal(t, tc.expected, tc.sut.ToSPDX2Category())
@@ -32,8 +33,85 @@ func TestIToSPDX2Type(t *testing.T) {
 		{SoftwareIdentifierType_CPE23, spdx.ExtRefTypeCPE23},
 		{SoftwareIdentifierType_CPE22, spdx.ExtRefTypeCPE22},
 		{SoftwareIdentifierType_GITOID, spdx.ExtRefTypeGitoid},
+		{SoftwareIdentifierType_SWHID, spdx.ExtRefTypeSWHID},
 		{SoftwareIdentifierType(1234123415), ""},
 	} {
 		require.Equal(t, tc.expected, tc.sut.ToSPDX2Type())
 	}
 }
+
+func TestValidateIdentifiers(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		identifiers map[int32]string
+		// messages are substrings of the expected issue messages
+		messages []string
+	}{
+		{
+			name: "valid identifiers",
+			identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL):  "pkg:npm/left-pad@1.3.0",
+				int32(SoftwareIdentifierType_SWHID): "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2",
+			},
+		},
+		{
+			name: "invalid gitoid",
+			identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL):   "pkg:npm/left-pad@1.3.0",
+				int32(SoftwareIdentifierType_GITOID): "gitoid:blob:sha1:abc",
+			},
+			messages: []string{"gitoid"},
+		},
+		{
+			name: "invalid swhid",
+			identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_SWHID): "swh:2:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2",
+			},
+			messages: []string{"SWHID"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{{Id: "node", Identifiers: tc.identifiers}}}
+			issues := nl.ValidateIdentifiers()
+			require.Len(t, issues, len(tc.messages))
+			for i, msg := range tc.messages {
+				require.Equal(t, "node", issues[i].NodeID)
+				require.Contains(t, issues[i].Message, msg)
+			}
+		})
+	}
+}
+
+func TestValidateSWHID(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		swhid   string
+		mustErr bool
+	}{
+		{"content", "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2", false},
+		{"qualified directory", "swh:1:dir:d198bc9d7a6bcf6db04f476d29314f157507d505;origin=https://github.com/example/repo;visit=swh:1:snp:c7c108084bc0bf3d81436bf980b46e98bd338453", false},
+		{"unknown version", "swh:2:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2", true},
+		{"unknown object type", "swh:1:blob:94a9ed024d3859793618152ea559a168bbcbb5e2", true},
+		{"uppercase digest", "swh:1:cnt:94A9ED024D3859793618152EA559A168BBCBB5E2", true},
+		{"short digest", "swh:1:cnt:94a9ed02", true},
+		{"qualifier without value", "swh:1:cnt:94a9ed024d3859793618152ea559a168bbcbb5e2;origin", true},
+		{"not a swhid", "pkg:npm/left-pad", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSWHID(tc.swhid)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSoftwareIdentifierTypeFromStringSWHID(t *testing.T) {
+	for _, s := range []string{"swh", "SWHID"} {
+		t.Run(s, func(t *testing.T) {
+			require.Equal(t, SoftwareIdentifierType_SWHID, SoftwareIdentifierTypeFromString(s))
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// IdentityDatabase maps the names and identifiers a component is known by
+// to a canonical identity. Organizations keep such mappings to correlate
+// the packages of distributions with their upstream projects, for example
+// "openssl", "libssl1.1" and "pkg:deb/debian/libssl1.1".
+//
+// Aliases starting with "pkg:" are package URLs, matched ignoring their
+// version, qualifiers and subpath. Aliases starting with "cpe:" are CPEs
+// (2.2 or 2.3), matched by their vendor and product. Anything else is a
+// component name, matched ignoring case and punctuation.
+type IdentityDatabase struct {
+	aliases map[string]string
+}
+
+// IdentityEntry is an identity in the database file
+type IdentityEntry struct {
+	ID      string   `json:"id"`
+	Aliases []string `json:"aliases"`
+}
+
+// identityDatabaseFile is the JSON representation of an IdentityDatabase
+type identityDatabaseFile struct {
+	Identities []IdentityEntry `json:"identities"`
+}
+
+// NewIdentityDatabase returns an empty identity database
+func NewIdentityDatabase() *IdentityDatabase {
+	return &IdentityDatabase{
+		aliases: map[string]string{},
+	}
+}
+
+// LoadIdentityDatabase reads an identity database in JSON format:
+//
+//	{
+//	  "identities": [
+//	    {"id": "openssl", "aliases": ["libssl1.1", "pkg:deb/debian/libssl1.1"]}
+//	  ]
+//	}
+func LoadIdentityDatabase(r io.Reader) (*IdentityDatabase, error) {
+	f := &identityDatabaseFile{}
+	if err := json.NewDecoder(r).Decode(f); err != nil {
+		return nil, fmt.Errorf("decoding identity database: %w", err)
+	}
+	db := NewIdentityDatabase()
+	for i, e := range f.Identities {
+		if e.ID == "" {
+			return nil, fmt.Errorf("identity #%d has no id", i)
+		}
+		if err := db.Add(e.ID, e.Aliases...); err != nil {
+			return nil, err
+		}
+	}
+	return db, nil
+}
+
+// LoadIdentityDatabaseFile reads an identity database from a JSON file
+func LoadIdentityDatabaseFile(path string) (*IdentityDatabase, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening identity database: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+	return LoadIdentityDatabase(f)
+}
+
+// Add registers an identity and its aliases. The identity ID is an alias of
+// itself. It returns an error if an alias already belongs to a different
+// identity.
+func (db *IdentityDatabase) Add(id string, aliases ...string) error {
+	for _, alias := range append([]string{id}, aliases...) {
+		key := identityKey(alias)
+		if key == "" {
+			continue
+		}
+		if existing, ok := db.aliases[key]; ok && existing != id {
+			return fmt.Errorf("alias %q of %q is already an alias of %q", alias, id, existing)
+		}
+		db.aliases[key] = id
+	}
+	return nil
+}
+
+// ResolveAlias returns the identity of a name or identifier. It returns an
+// empty string if the alias is not in the database.
+func (db *IdentityDatabase) ResolveAlias(alias string) string {
+	return db.aliases[identityKey(alias)]
+}
+
+// Resolve returns the identity of a node, looked up by its package URL,
+// CPEs and name, in that order. It returns an empty string if the node is
+// not in the database.
+func (db *IdentityDatabase) Resolve(n *Node) string {
+	for _, alias := range []string{
+		string(n.Purl()),
+		n.GetIdentifiers()[int32(SoftwareIdentifierType_CPE23)],
+		n.GetIdentifiers()[int32(SoftwareIdentifierType_CPE22)],
+		n.GetName(),
+	} {
+		if id := db.ResolveAlias(alias); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// Match implements NodeMatcher. Nodes match when both resolve to the same
+// identity.
+func (db *IdentityDatabase) Match(n1, n2 *Node) bool {
+	if n1 == nil || n2 == nil {
+		return false
+	}
+	id := db.Resolve(n1)
+	return id != "" && id == db.Resolve(n2)
+}
+
+// identityKey returns the key used to index an alias in the database
+func identityKey(alias string) string {
+	alias = strings.TrimSpace(alias)
+	switch {
+	case alias == "":
+		return ""
+	case strings.HasPrefix(alias, "pkg:"):
+		return "purl:" + versionlessPurl(alias)
+	case strings.HasPrefix(strings.ToLower(alias), "cpe:"):
+		parts := strings.Split(strings.ToLower(alias), ":")
+		// cpe:2.3:part:vendor:product:... or cpe:/part:vendor:product:...
+		if strings.HasPrefix(parts[1], "/") {
+			parts = append([]string{parts[0], ""}, parts[1:]...)
+		}
+		if len(parts) < 5 {
+			return ""
+		}
+		return "cpe:" + parts[3] + ":" + parts[4]
+	default:
+		if n := normalizeName(alias); n != "" {
+			return "name:" + n
+		}
+		return ""
+	}
+}
+
+// versionlessPurl strips the version, qualifiers and subpath from a package
+// URL and lowercases its type.
+func versionlessPurl(purl string) string {
+	purl, _, _ = strings.Cut(purl, "#")
+	purl, _, _ = strings.Cut(purl, "?")
+	if i := strings.LastIndex(purl, "@"); i > strings.LastIndex(purl, "/") {
+		purl = purl[:i]
+	}
+	ptype, rest, _ := strings.Cut(strings.TrimPrefix(purl, "pkg:"), "/")
+	return "pkg:" + strings.ToLower(ptype) + "/" + rest
+}
+
+// WithIdentityDatabase resolves the identities of the nodes through the
+// database in the NodeList set operations. Nodes of the second NodeList
+// with the same identity and version as a node of the first one are taken
+// as the same node, regardless of their IDs.
+func WithIdentityDatabase(db *IdentityDatabase) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.identities = db
+	}
+}
+
+// alignIdentities returns nl2 with the IDs of its nodes replaced by the IDs
+// of the nodes in nl with the same identity and version. If no node needs
+// to be renamed, nl2 is returned unchanged.
+func (nl *NodeList) alignIdentities(nl2 *NodeList, db *IdentityDatabase) *NodeList {
+	nodeKey := func(n *Node) string {
+		if id := db.Resolve(n); id != "" {
+			return id + "@" + n.Version
+		}
+		return ""
+	}
+
+	index := map[string]string{}
+	for _, n := range nl.Nodes {
+		if key := nodeKey(n); key != "" {
+			if _, ok := index[key]; !ok {
+				index[key] = n.Id
+			}
+		}
+	}
+
+	renames := map[string]string{}
+	for _, n := range nl2.Nodes {
+		if id, ok := index[nodeKey(n)]; ok && id != n.Id {
+			renames[n.Id] = id
+		}
+	}
+	if len(renames) == 0 {
+		return nl2
+	}
+
+	ret := nl2.Copy()
+	ret.renameNodes(renames)
+	return ret
+}
+
+// GetNodesByIdentity returns the nodes that resolve in the database to the
+// same identity as alias, which can be the identity ID or any of its names
+// or identifiers.
+func (nl *NodeList) GetNodesByIdentity(db *IdentityDatabase, alias string) []*Node {
+	ret := []*Node{}
+	id := db.ResolveAlias(alias)
+	if id == "" {
+		return ret
+	}
+	for _, n := range nl.Nodes {
+		if db.Resolve(n) == id {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
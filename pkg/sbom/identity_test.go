@@ -0,0 +1,229 @@
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testIdentityDatabase = `{
+  "identities": [
+    {"id": "openssl", "aliases": ["libssl1.1", "libssl3", "pkg:deb/debian/libssl1.1", "cpe:2.3:a:openssl:openssl:*:*:*:*:*:*:*:*"]},
+    {"id": "zlib", "aliases": ["zlib1g", "pkg:apk/alpine/zlib"]}
+  ]
+}`
+
+func TestIdentityDatabase(t *testing.T) {
+	db, err := LoadIdentityDatabase(strings.NewReader(testIdentityDatabase))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		alias string
+		id    string
+	}{
+		{name: "identity id", alias: "openssl", id: "openssl"},
+		{name: "name alias ignores case", alias: "LibSSL1.1", id: "openssl"},
+		{name: "purl with version and qualifiers", alias: "pkg:deb/debian/libssl1.1@1.1.1n-0?arch=amd64", id: "openssl"},
+		{name: "cpe 2.3 with version", alias: "cpe:2.3:a:openssl:openssl:3.0.2:*:*:*:*:*:*:*", id: "openssl"},
+		{name: "cpe 2.2", alias: "cpe:/a:openssl:openssl:1.1.1", id: "openssl"},
+		{name: "purl type ignores case", alias: "pkg:APK/alpine/zlib@1.3-r0", id: "zlib"},
+		{name: "name alias", alias: "zlib1g", id: "zlib"},
+		{name: "unknown purl", alias: "pkg:deb/debian/zlib1g@1.2.13"},
+		{name: "unknown name", alias: "curl"},
+		{name: "empty", alias: ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.id, db.ResolveAlias(tc.alias))
+		})
+	}
+}
+
+func TestIdentityDatabaseMatch(t *testing.T) {
+	db, err := LoadIdentityDatabase(strings.NewReader(testIdentityDatabase))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name  string
+		n1    *Node
+		n2    *Node
+		id    string
+		match bool
+	}{
+		{
+			name: "name and cpe",
+			n1:   &Node{Id: "a", Name: "libssl3"},
+			n2: &Node{Id: "b", Name: "openssl-fips", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_CPE23): "cpe:2.3:a:openssl:openssl:3.0.2:*:*:*:*:*:*:*",
+			}},
+			id:    "openssl",
+			match: true,
+		},
+		{
+			name: "different identities",
+			n1:   &Node{Id: "a", Name: "libssl3"},
+			n2:   &Node{Name: "zlib"},
+			id:   "openssl",
+		},
+		{
+			name: "unknown nodes",
+			n1:   &Node{Name: "curl"},
+			n2:   &Node{Name: "curl"},
+		},
+		{
+			name: "nil node",
+			n1:   &Node{Name: "libssl3"},
+			id:   "openssl",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.id, db.Resolve(tc.n1))
+			require.Equal(t, tc.match, db.Match(tc.n1, tc.n2))
+		})
+	}
+}
+
+func TestLoadIdentityDatabase(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    string
+		mustErr bool
+	}{
+		{name: "valid", data: testIdentityDatabase},
+		{name: "identity without id", data: `{"identities": [{"aliases": ["x"]}]}`, mustErr: true},
+		{name: "alias in two identities", data: `{"identities": [{"id": "a", "aliases": ["x"]}, {"id": "b", "aliases": ["x"]}]}`, mustErr: true},
+		{name: "invalid json", data: `{`, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := LoadIdentityDatabase(strings.NewReader(tc.data))
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, db)
+		})
+	}
+}
+
+func TestIdentityDatabaseAdd(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		id      string
+		aliases []string
+		mustErr bool
+	}{
+		{name: "new identity", id: "libressl", aliases: []string{"libressl3", "pkg:apk/alpine/libressl"}},
+		{name: "existing identity", id: "zlib", aliases: []string{"libz"}},
+		{name: "alias of another identity", id: "libressl", aliases: []string{"libssl3"}, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, err := LoadIdentityDatabase(strings.NewReader(testIdentityDatabase))
+			require.NoError(t, err)
+
+			err = db.Add(tc.id, tc.aliases...)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, alias := range tc.aliases {
+				require.Equal(t, tc.id, db.ResolveAlias(alias))
+			}
+		})
+	}
+}
+
+func TestIdentityDatabaseNodeList(t *testing.T) {
+	db, err := LoadIdentityDatabase(strings.NewReader(testIdentityDatabase))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name string
+		op   func(nl1, nl2 *NodeList) *NodeList
+		// nodes has the IDs of the resulting nodes
+		nodes     []string
+		licenses  map[string][]string
+		dependsOn map[string][]string
+	}{
+		{
+			name: "union",
+			op: func(nl1, nl2 *NodeList) *NodeList {
+				return nl1.Union(nl2, WithIdentityDatabase(db))
+			},
+			nodes:     []string{"app", "openssl", "deb-libssl3", "deb-zlib"},
+			licenses:  map[string][]string{"openssl": {"OpenSSL"}},
+			dependsOn: map[string][]string{"app": {"openssl"}, "openssl": {"deb-zlib"}},
+		},
+		{
+			name: "intersection",
+			op: func(nl1, nl2 *NodeList) *NodeList {
+				return nl1.Intersect(nl2, WithIdentityDatabase(db))
+			},
+			nodes: []string{"openssl"},
+		},
+		{
+			name: "intersection without database",
+			op: func(nl1, nl2 *NodeList) *NodeList {
+				return nl1.Intersect(nl2)
+			},
+			nodes: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl1 := &NodeList{
+				Nodes: []*Node{
+					{Id: "app", Name: "app"},
+					{Id: "openssl", Name: "openssl", Version: "1.1.1n"},
+				},
+				Edges:        []*Edge{{Type: Edge_dependsOn, From: "app", To: []string{"openssl"}}},
+				RootElements: []string{"app"},
+			}
+			nl2 := &NodeList{
+				Nodes: []*Node{
+					{Id: "deb-libssl", Name: "libssl1.1", Version: "1.1.1n", Licenses: []string{"OpenSSL"}},
+					{Id: "deb-libssl3", Name: "libssl3", Version: "3.0.2"},
+					{Id: "deb-zlib", Name: "zlib1g", Version: "1.2.13"},
+				},
+				Edges: []*Edge{{Type: Edge_dependsOn, From: "deb-libssl", To: []string{"deb-zlib"}}},
+			}
+
+			res := tc.op(nl1, nl2)
+			require.ElementsMatch(t, tc.nodes, ids(res.Nodes))
+			for id, licenses := range tc.licenses {
+				require.Equal(t, licenses, res.GetNodeByID(id).Licenses)
+			}
+			for from, to := range tc.dependsOn {
+				require.Equal(t, to, res.GetEdgeByType(from, Edge_dependsOn).To)
+			}
+			require.Equal(t, "deb-libssl", nl2.Nodes[0].Id, "original nodelist modified")
+		})
+	}
+}
+
+func TestGetNodesByIdentity(t *testing.T) {
+	db, err := LoadIdentityDatabase(strings.NewReader(testIdentityDatabase))
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name     string
+		alias    string
+		expected []string
+	}{
+		{name: "identity id", alias: "openssl", expected: []string{"deb-libssl", "deb-libssl3"}},
+		{name: "purl alias", alias: "pkg:apk/alpine/zlib", expected: []string{"deb-zlib"}},
+		{name: "unknown alias", alias: "curl", expected: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes: []*Node{
+					{Id: "deb-libssl", Name: "libssl1.1", Version: "1.1.1n"},
+					{Id: "deb-libssl3", Name: "libssl3", Version: "3.0.2"},
+					{Id: "deb-zlib", Name: "zlib1g", Version: "1.2.13"},
+				},
+			}
+			require.Equal(t, tc.expected, ids(nl.GetNodesByIdentity(db, tc.alias)))
+		})
+	}
+}
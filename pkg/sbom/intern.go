@@ -0,0 +1,91 @@
+package sbom
+
+import "unique"
+
+// intern returns the canonical copy of s. Equal strings passed through
+// intern share the same backing memory.
+func intern(s string) string {
+	if s == "" {
+		return s
+	}
+	return unique.Make(s).Value()
+}
+
+// internSlice interns all the strings in a slice
+func internSlice(ss []string) {
+	for i := range ss {
+		ss[i] = intern(ss[i])
+	}
+}
+
+// InternStrings replaces the highly repetitive strings in the document with
+// canonical copies to reduce the memory used by large graphs. Node IDs are
+// shared with the edges and root elements that reference them, and license,
+// supplier, copyright and version values are shared across nodes.
+func (d *Document) InternStrings() {
+	if d.GetMetadata() != nil {
+		for _, p := range d.Metadata.Authors {
+			p.internStrings()
+		}
+		for _, t := range d.Metadata.Tools {
+			t.Name = intern(t.Name)
+			t.Version = intern(t.Version)
+			t.Vendor = intern(t.Vendor)
+		}
+	}
+	if d.GetNodeList() != nil {
+		d.NodeList.InternStrings()
+	}
+}
+
+// InternStrings replaces the repetitive strings of the nodes and edges in
+// the NodeList with canonical copies.
+func (nl *NodeList) InternStrings() {
+	for _, n := range nl.Nodes {
+		n.InternStrings()
+	}
+	for _, e := range nl.Edges {
+		e.From = intern(e.From)
+		internSlice(e.To)
+	}
+	internSlice(nl.RootElements)
+}
+
+// InternStrings replaces the repetitive strings in the node with canonical
+// copies. Unique values such as names, hashes and identifiers are left as is.
+func (n *Node) InternStrings() {
+	n.Id = intern(n.Id)
+	n.Version = intern(n.Version)
+	n.LicenseConcluded = intern(n.LicenseConcluded)
+	n.Copyright = intern(n.Copyright)
+	internSlice(n.Licenses)
+	internSlice(n.Attribution)
+	internSlice(n.FileTypes)
+
+	for _, p := range n.Suppliers {
+		p.internStrings()
+	}
+	for _, p := range n.Originators {
+		p.internStrings()
+	}
+	for _, er := range n.ExternalReferences {
+		er.Authority = intern(er.Authority)
+	}
+	for _, p := range n.Properties {
+		p.Name = intern(p.Name)
+	}
+}
+
+// internStrings interns the strings of a person and its contacts
+func (p *Person) internStrings() {
+	if p == nil {
+		return
+	}
+	p.Name = intern(p.Name)
+	p.Email = intern(p.Email)
+	p.Url = intern(p.Url)
+	p.Phone = intern(p.Phone)
+	for _, c := range p.Contacts {
+		c.internStrings()
+	}
+}
@@ -0,0 +1,84 @@
+package sbom
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sameMemory returns true when both strings share their backing data
+func sameMemory(a, b string) bool {
+	return unsafe.StringData(a) == unsafe.StringData(b)
+}
+
+func TestInternStrings(t *testing.T) {
+	// Build the strings at runtime so they don't share memory already
+	dup := func(s string) string { return string([]byte(s)) }
+
+	for _, tc := range []struct {
+		name string
+		// a and b return the two strings expected to share memory
+		a func(*Document) string
+		b func(*Document) string
+	}{
+		{
+			name: "licenses",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[0].Licenses[0] },
+			b:    func(doc *Document) string { return doc.NodeList.Nodes[1].Licenses[0] },
+		},
+		{
+			name: "versions",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[0].Version },
+			b:    func(doc *Document) string { return doc.NodeList.Nodes[1].Version },
+		},
+		{
+			name: "supplier names",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[0].Suppliers[0].Name },
+			b:    func(doc *Document) string { return doc.NodeList.Nodes[1].Suppliers[0].Name },
+		},
+		{
+			name: "contact emails",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[0].Suppliers[0].Contacts[0].Email },
+			b:    func(doc *Document) string { return doc.NodeList.Nodes[1].Suppliers[0].Contacts[0].Email },
+		},
+		{
+			name: "edge source",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[0].Id },
+			b:    func(doc *Document) string { return doc.NodeList.Edges[0].From },
+		},
+		{
+			name: "edge target",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[1].Id },
+			b:    func(doc *Document) string { return doc.NodeList.Edges[0].To[0] },
+		},
+		{
+			name: "root elements",
+			a:    func(doc *Document) string { return doc.NodeList.Nodes[0].Id },
+			b:    func(doc *Document) string { return doc.NodeList.RootElements[0] },
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.NodeList.AddNode(&Node{
+				Id:        dup("node-a"),
+				Licenses:  []string{dup("Apache-2.0")},
+				Suppliers: []*Person{{Name: dup("ACME"), Contacts: []*Person{{Email: dup("oss@acme.com")}}}},
+				Version:   dup("1.0"),
+			})
+			doc.NodeList.AddNode(&Node{
+				Id:        dup("node-b"),
+				Licenses:  []string{dup("Apache-2.0")},
+				Suppliers: []*Person{{Name: dup("ACME"), Contacts: []*Person{{Email: dup("oss@acme.com")}}}, nil},
+				Version:   dup("1.0"),
+			})
+			doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: dup("node-a"), To: []string{dup("node-b")}})
+			doc.NodeList.RootElements = []string{dup("node-a")}
+
+			require.False(t, sameMemory(tc.a(doc), tc.b(doc)))
+			doc.InternStrings()
+			require.Equal(t, tc.a(doc), tc.b(doc))
+			require.True(t, sameMemory(tc.a(doc), tc.b(doc)))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package sbom
+
+import "iter"
+
+// AllNodes returns an iterator over the nodes of the NodeList
+func (nl *NodeList) AllNodes() iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		for _, n := range nl.GetNodes() {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// AllEdges returns an iterator over the edges of the NodeList
+func (nl *NodeList) AllEdges() iter.Seq[*Edge] {
+	return func(yield func(*Edge) bool) {
+		for _, e := range nl.GetEdges() {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// Reachable returns an iterator over the nodes reachable from the node with
+// ID root, following the edges of any type breadth first. The root node is
+// yielded first and every node is yielded once. Edges pointing to nodes not
+// in the NodeList are ignored. The graph is indexed when the iteration
+// starts and traversed as the nodes are consumed, stopping the iteration
+// early skips the rest of the traversal.
+func (nl *NodeList) Reachable(root string) iter.Seq[*Node] {
+	return func(yield func(*Node) bool) {
+		nodes := nl.indexNodes()
+		if _, ok := nodes[root]; !ok {
+			return
+		}
+		edges := map[string][]*Edge{}
+		for _, e := range nl.GetEdges() {
+			edges[e.From] = append(edges[e.From], e)
+		}
+
+		seen := map[string]struct{}{root: {}}
+		queue := []string{root}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if !yield(nodes[id]) {
+				return
+			}
+			for _, e := range edges[id] {
+				for _, to := range e.To {
+					if _, ok := seen[to]; ok {
+						continue
+					}
+					if _, ok := nodes[to]; !ok {
+						continue
+					}
+					seen[to] = struct{}{}
+					queue = append(queue, to)
+				}
+			}
+		}
+	}
+}
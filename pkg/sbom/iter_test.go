@@ -0,0 +1,96 @@
+package sbom
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllNodesAndEdges(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		sut  *NodeList
+		// stopAfter breaks out of the node iteration after the number of
+		// nodes, zero reads them all.
+		stopAfter int
+		expected  int
+	}{
+		{
+			name: "all nodes",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: "root"}, {Id: "a"}, {Id: "b"}},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "root", To: []string{"a"}},
+					{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+				},
+			},
+			expected: 3,
+		},
+		{
+			name: "early break",
+			sut: &NodeList{
+				Nodes: []*Node{{Id: "root"}, {Id: "a"}, {Id: "b"}},
+				Edges: []*Edge{{Type: Edge_contains, From: "root", To: []string{"a", "b"}}},
+			},
+			stopAfter: 2,
+			expected:  2,
+		},
+		{
+			name: "empty list",
+			sut:  &NodeList{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.sut.Nodes, slices.Collect(tc.sut.AllNodes()))
+			require.Equal(t, tc.sut.Edges, slices.Collect(tc.sut.AllEdges()))
+
+			count := 0
+			for range tc.sut.AllNodes() {
+				count++
+				if count == tc.stopAfter {
+					break
+				}
+			}
+			require.Equal(t, tc.expected, count)
+		})
+	}
+}
+
+func TestReachable(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		root string
+		// stopAt breaks out of the iteration after visiting the node
+		stopAt   string
+		expected []string
+	}{
+		{name: "from the root", root: "root", expected: []string{"root", "a", "b", "c", "d"}},
+		{name: "from a subtree", root: "c", expected: []string{"c", "d"}},
+		{name: "unconnected node", root: "island", expected: []string{"island"}},
+		{name: "missing node", root: "missing", expected: []string{}},
+		{name: "early break", root: "root", stopAt: "a", expected: []string{"root", "a"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes: []*Node{{Id: "root"}, {Id: "a"}, {Id: "b"}, {Id: "c"}, {Id: "d"}, {Id: "island"}},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "root", To: []string{"a", "b"}},
+					{Type: Edge_dependsOn, From: "a", To: []string{"c", "missing"}},
+					{Type: Edge_dependsOn, From: "b", To: []string{"c", "root"}},
+					{Type: Edge_contains, From: "c", To: []string{"d"}},
+				},
+				RootElements: []string{"root"},
+			}
+
+			visited := []string{}
+			for n := range nl.Reachable(tc.root) {
+				visited = append(visited, n.Id)
+				if n.Id == tc.stopAt {
+					break
+				}
+			}
+			require.Equal(t, tc.expected, visited)
+		})
+	}
+}
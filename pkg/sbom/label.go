@@ -0,0 +1,106 @@
+package sbom
+
+import (
+	"slices"
+	"strings"
+)
+
+// PropertyLabelPrefix prefixes the names of the properties that store the
+// free-form labels of documents and nodes. A label key=value is stored as
+// a property named "protobom:label:key" with value as its data so labels
+// are preserved by the formats that support properties.
+const PropertyLabelPrefix = "protobom:label:"
+
+// getLabel returns the value of the label key stored in props
+func getLabel(props []*Property, key string) (string, bool) {
+	for _, p := range props {
+		if p.GetName() == PropertyLabelPrefix+key {
+			return p.GetData(), true
+		}
+	}
+	return "", false
+}
+
+// setLabel sets the label key in props, replacing any existing values
+func setLabel(props []*Property, key, value string) []*Property {
+	props = removeLabel(props, key)
+	return append(props, &Property{Name: PropertyLabelPrefix + key, Data: value})
+}
+
+// removeLabel removes the label key from props
+func removeLabel(props []*Property, key string) []*Property {
+	return slices.DeleteFunc(props, func(p *Property) bool {
+		return p.GetName() == PropertyLabelPrefix+key
+	})
+}
+
+// labels returns the labels stored in props. If a label is repeated, the
+// first value is returned.
+func labels(props []*Property) map[string]string {
+	ret := map[string]string{}
+	for _, p := range props {
+		key, ok := strings.CutPrefix(p.GetName(), PropertyLabelPrefix)
+		if !ok {
+			continue
+		}
+		if _, ok := ret[key]; !ok {
+			ret[key] = p.GetData()
+		}
+	}
+	return ret
+}
+
+// GetLabel returns the value of the node label key and true if the node
+// is labeled with it.
+func (n *Node) GetLabel(key string) (string, bool) {
+	return getLabel(n.GetProperties(), key)
+}
+
+// SetLabel labels the node with key=value, replacing the previous value
+// of the label.
+func (n *Node) SetLabel(key, value string) {
+	n.Properties = setLabel(n.Properties, key, value)
+}
+
+// RemoveLabel removes the label key from the node
+func (n *Node) RemoveLabel(key string) {
+	n.Properties = removeLabel(n.Properties, key)
+}
+
+// Labels returns the labels of the node indexed by key
+func (n *Node) Labels() map[string]string {
+	return labels(n.GetProperties())
+}
+
+// GetLabel returns the value of the document label key and true if the
+// document is labeled with it.
+func (m *Metadata) GetLabel(key string) (string, bool) {
+	return getLabel(m.GetProperties(), key)
+}
+
+// SetLabel labels the document with key=value, replacing the previous
+// value of the label.
+func (m *Metadata) SetLabel(key, value string) {
+	m.Properties = setLabel(m.Properties, key, value)
+}
+
+// RemoveLabel removes the label key from the document
+func (m *Metadata) RemoveLabel(key string) {
+	m.Properties = removeLabel(m.Properties, key)
+}
+
+// Labels returns the labels of the document indexed by key
+func (m *Metadata) Labels() map[string]string {
+	return labels(m.GetProperties())
+}
+
+// GetNodesByLabel returns the nodes labeled with key=value
+func (nl *NodeList) GetNodesByLabel(key, value string) []*Node {
+	ret := []*Node{}
+	for _, n := range nl.GetNodes() {
+		if v, ok := n.GetLabel(key); ok && v == value {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
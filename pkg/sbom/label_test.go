@@ -0,0 +1,143 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func propertyNames(props []*Property) []string {
+	ret := []string{}
+	for _, p := range props {
+		ret = append(ret, p.Name)
+	}
+	return ret
+}
+
+func TestNodeLabels(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// set has the key and value of the labels set, in order
+		set        [][2]string
+		remove     []string
+		labels     map[string]string
+		properties []string
+	}{
+		{
+			name:       "unlabeled",
+			labels:     map[string]string{},
+			properties: []string{"build"},
+		},
+		{
+			name:       "set labels",
+			set:        [][2]string{{"team", "core"}, {"tier", ""}},
+			labels:     map[string]string{"team": "core", "tier": ""},
+			properties: []string{"build", PropertyLabelPrefix + "team", PropertyLabelPrefix + "tier"},
+		},
+		{
+			// Setting a label replaces its value
+			name:       "replace value",
+			set:        [][2]string{{"team", "core"}, {"tier", ""}, {"team", "platform"}},
+			labels:     map[string]string{"team": "platform", "tier": ""},
+			properties: []string{"build", PropertyLabelPrefix + "tier", PropertyLabelPrefix + "team"},
+		},
+		{
+			name:       "remove label",
+			set:        [][2]string{{"team", "platform"}, {"tier", ""}},
+			remove:     []string{"tier"},
+			labels:     map[string]string{"team": "platform"},
+			properties: []string{"build", PropertyLabelPrefix + "team"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Id: "a", Properties: []*Property{{Name: "build", Data: "1"}}}
+			for _, l := range tc.set {
+				n.SetLabel(l[0], l[1])
+			}
+			for _, key := range tc.remove {
+				n.RemoveLabel(key)
+			}
+
+			require.Equal(t, tc.labels, n.Labels())
+			require.Equal(t, tc.properties, propertyNames(n.Properties))
+			for key, value := range tc.labels {
+				v, ok := n.GetLabel(key)
+				require.True(t, ok)
+				require.Equal(t, value, v)
+			}
+			_, ok := n.GetLabel("missing")
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestMetadataLabels(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		set        map[string]string
+		remove     []string
+		labels     map[string]string
+		properties []string
+	}{
+		{
+			name:       "unlabeled",
+			labels:     map[string]string{},
+			properties: []string{},
+		},
+		{
+			name:       "set label",
+			set:        map[string]string{"env": "prod"},
+			labels:     map[string]string{"env": "prod"},
+			properties: []string{PropertyLabelPrefix + "env"},
+		},
+		{
+			name:       "remove label",
+			set:        map[string]string{"env": "prod"},
+			remove:     []string{"env"},
+			labels:     map[string]string{},
+			properties: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			md := &Metadata{}
+			for key, value := range tc.set {
+				md.SetLabel(key, value)
+			}
+			for _, key := range tc.remove {
+				md.RemoveLabel(key)
+			}
+
+			require.Equal(t, tc.labels, md.Labels())
+			require.Equal(t, tc.properties, propertyNames(md.Properties))
+			for key, value := range tc.labels {
+				v, ok := md.GetLabel(key)
+				require.True(t, ok)
+				require.Equal(t, value, v)
+				require.Equal(t, value, md.GetProperty(PropertyLabelPrefix+key).Data)
+			}
+		})
+	}
+}
+
+func TestGetNodesByLabel(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		key      string
+		value    string
+		expected []string
+	}{
+		{name: "matching nodes", key: "team", value: "core", expected: []string{"a", "c"}},
+		{name: "single node", key: "team", value: "platform", expected: []string{"b"}},
+		{name: "unknown value", key: "team", value: "security", expected: []string{}},
+		{name: "unknown key", key: "owner", value: "core", expected: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{{Id: "a"}, {Id: "b"}, {Id: "c"}}}
+			nl.Nodes[0].SetLabel("team", "core")
+			nl.Nodes[1].SetLabel("team", "platform")
+			nl.Nodes[2].SetLabel("team", "core")
+
+			require.Equal(t, tc.expected, ids(nl.GetNodesByLabel(tc.key, tc.value)))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package sbom
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LicenseRefPrefix is the prefix used to identify custom licenses in SPDX
+// license expressions.
+const LicenseRefPrefix = "LicenseRef-"
+
+// invalidLicenseRefCharsRe matches the characters not allowed in the idstring
+// of an SPDX LicenseRef identifier.
+var invalidLicenseRefCharsRe = regexp.MustCompile(`[^a-zA-Z0-9-.]+`)
+
+// NewLicenseRef returns a LicenseRef-* identifier built from a license name.
+// If the name is already a LicenseRef identifier it is returned unchanged.
+func NewLicenseRef(name string) string {
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, LicenseRefPrefix) {
+		return name
+	}
+	idstring := strings.Trim(invalidLicenseRefCharsRe.ReplaceAllString(name, "-"), "-")
+	if idstring == "" {
+		return ""
+	}
+	return LicenseRefPrefix + idstring
+}
+
+// IsLicenseRef returns true if the license identifier is an SPDX custom
+// license reference (LicenseRef-*).
+func IsLicenseRef(id string) bool {
+	return strings.HasPrefix(id, LicenseRefPrefix)
+}
+
+// Copy returns a new License pointer which is a duplicate of License l.
+func (l *License) Copy() *License {
+	return &License{
+		Id:      l.Id,
+		Name:    l.Name,
+		Text:    l.Text,
+		Urls:    append([]string{}, l.Urls...),
+		Comment: l.Comment,
+	}
+}
+
+// GetCustomLicense returns the custom license registered in the metadata with
+// the specified identifier. If not found it returns nil.
+func (m *Metadata) GetCustomLicense(id string) *License {
+	for _, l := range m.GetCustomLicenses() {
+		if l.Id == id {
+			return l
+		}
+	}
+	return nil
+}
+
+// AddCustomLicense registers a custom license in the document metadata. If a
+// license with the same identifier already exists, it gets replaced.
+func (m *Metadata) AddCustomLicense(l *License) {
+	if l == nil {
+		return
+	}
+	for i := range m.CustomLicenses {
+		if m.CustomLicenses[i].Id == l.Id {
+			m.CustomLicenses[i] = l
+			return
+		}
+	}
+	m.CustomLicenses = append(m.CustomLicenses, l)
+}
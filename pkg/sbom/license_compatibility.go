@@ -0,0 +1,353 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/formats/spdx"
+)
+
+// LicenseCategory classifies licenses by the obligations they impose on
+// the software that uses the licensed code.
+type LicenseCategory string
+
+const (
+	LicenseCategoryUnknown        LicenseCategory = "unknown"
+	LicenseCategoryPermissive     LicenseCategory = "permissive"
+	LicenseCategoryWeakCopyleft   LicenseCategory = "weak-copyleft"
+	LicenseCategoryStrongCopyleft LicenseCategory = "strong-copyleft"
+	LicenseCategoryProprietary    LicenseCategory = "proprietary"
+)
+
+// licenseCategories classifies the most common SPDX license identifiers
+var licenseCategories = map[string]LicenseCategory{
+	"0BSD":              LicenseCategoryPermissive,
+	"Apache-1.1":        LicenseCategoryPermissive,
+	"Apache-2.0":        LicenseCategoryPermissive,
+	"BSD-2-Clause":      LicenseCategoryPermissive,
+	"BSD-3-Clause":      LicenseCategoryPermissive,
+	"BSL-1.0":           LicenseCategoryPermissive,
+	"CC0-1.0":           LicenseCategoryPermissive,
+	"ISC":               LicenseCategoryPermissive,
+	"MIT":               LicenseCategoryPermissive,
+	"MIT-0":             LicenseCategoryPermissive,
+	"NCSA":              LicenseCategoryPermissive,
+	"PostgreSQL":        LicenseCategoryPermissive,
+	"Python-2.0":        LicenseCategoryPermissive,
+	"Unlicense":         LicenseCategoryPermissive,
+	"X11":               LicenseCategoryPermissive,
+	"Zlib":              LicenseCategoryPermissive,
+	"curl":              LicenseCategoryPermissive,
+	"CDDL-1.0":          LicenseCategoryWeakCopyleft,
+	"CDDL-1.1":          LicenseCategoryWeakCopyleft,
+	"EPL-1.0":           LicenseCategoryWeakCopyleft,
+	"EPL-2.0":           LicenseCategoryWeakCopyleft,
+	"LGPL-2.0-only":     LicenseCategoryWeakCopyleft,
+	"LGPL-2.0-or-later": LicenseCategoryWeakCopyleft,
+	"LGPL-2.1-only":     LicenseCategoryWeakCopyleft,
+	"LGPL-2.1-or-later": LicenseCategoryWeakCopyleft,
+	"LGPL-3.0-only":     LicenseCategoryWeakCopyleft,
+	"LGPL-3.0-or-later": LicenseCategoryWeakCopyleft,
+	"MPL-1.1":           LicenseCategoryWeakCopyleft,
+	"MPL-2.0":           LicenseCategoryWeakCopyleft,
+	"AGPL-3.0-only":     LicenseCategoryStrongCopyleft,
+	"AGPL-3.0-or-later": LicenseCategoryStrongCopyleft,
+	"GPL-2.0-only":      LicenseCategoryStrongCopyleft,
+	"GPL-2.0-or-later":  LicenseCategoryStrongCopyleft,
+	"GPL-3.0-only":      LicenseCategoryStrongCopyleft,
+	"GPL-3.0-or-later":  LicenseCategoryStrongCopyleft,
+}
+
+// deprecatedLicenseIDs maps deprecated SPDX identifiers to their replacements
+var deprecatedLicenseIDs = map[string]string{
+	"AGPL-3.0":  "AGPL-3.0-only",
+	"GPL-2.0":   "GPL-2.0-only",
+	"GPL-2.0+":  "GPL-2.0-or-later",
+	"GPL-3.0":   "GPL-3.0-only",
+	"GPL-3.0+":  "GPL-3.0-or-later",
+	"LGPL-2.0":  "LGPL-2.0-only",
+	"LGPL-2.0+": "LGPL-2.0-or-later",
+	"LGPL-2.1":  "LGPL-2.1-only",
+	"LGPL-2.1+": "LGPL-2.1-or-later",
+	"LGPL-3.0":  "LGPL-3.0-only",
+	"LGPL-3.0+": "LGPL-3.0-or-later",
+}
+
+// linkingExceptions are the license exceptions that allow linking copyleft
+// code without extending the license to the linking software.
+var linkingExceptions = []string{
+	"Classpath-exception-2.0",
+	"GCC-exception-2.0",
+	"GCC-exception-3.1",
+	"LLVM-exception",
+}
+
+// incompatibleLicenses lists the dependency licenses that can't be combined
+// with a dependent license beyond the rules derived from their categories.
+var incompatibleLicenses = map[string][]string{
+	"GPL-2.0-only": {
+		"AGPL-3.0-only", "AGPL-3.0-or-later", "Apache-2.0", "CDDL-1.0", "CDDL-1.1",
+		"EPL-1.0", "EPL-2.0", "GPL-3.0-only", "GPL-3.0-or-later",
+		"LGPL-3.0-only", "LGPL-3.0-or-later", "MPL-1.1",
+	},
+}
+
+// licenseDependencyEdges are the edge types that combine the code of a
+// dependency with its dependent. Reverse types point from the dependency
+// to the dependent.
+var licenseDependencyEdges = []Edge_Type{
+	Edge_contains,
+	Edge_contained_by,
+	Edge_dependsOn,
+	Edge_dependencyOf,
+	Edge_dynamicLink,
+	Edge_optionalComponent,
+	Edge_optionalDependency,
+	Edge_runtimeDependency,
+	Edge_staticLink,
+}
+
+// canonicalLicenseID returns the current SPDX identifier of a license
+func canonicalLicenseID(id string) string {
+	if replacement, ok := deprecatedLicenseIDs[id]; ok {
+		return replacement
+	}
+	return id
+}
+
+// LicenseCategoryOf returns the category of a license identifier. The
+// identifier may include an exception ("GPL-2.0-only WITH
+// Classpath-exception-2.0"), copyleft licenses with a linking exception are
+// considered weak copyleft. Custom LicenseRef-* licenses are considered
+// proprietary.
+func LicenseCategoryOf(license string) LicenseCategory {
+	id, exception, _ := strings.Cut(license, " WITH ")
+	id = canonicalLicenseID(strings.TrimSpace(id))
+
+	if IsLicenseRef(id) || strings.EqualFold(id, "proprietary") {
+		return LicenseCategoryProprietary
+	}
+
+	category, ok := licenseCategories[id]
+	if !ok {
+		return LicenseCategoryUnknown
+	}
+	if category == LicenseCategoryStrongCopyleft && slices.Contains(linkingExceptions, strings.TrimSpace(exception)) {
+		return LicenseCategoryWeakCopyleft
+	}
+	return category
+}
+
+// licensePairIssue returns why code under the dependency license can't be
+// used by a component under the dependent license. It returns an empty
+// string when the licenses are compatible or their compatibility is unknown.
+func licensePairIssue(dependent, dependency string) string {
+	dependentID, _, _ := strings.Cut(dependent, " WITH ")
+	dependencyID, _, _ := strings.Cut(dependency, " WITH ")
+	dependentID = canonicalLicenseID(dependentID)
+	dependencyID = canonicalLicenseID(dependencyID)
+	if dependentID == dependencyID {
+		return ""
+	}
+
+	if slices.Contains(incompatibleLicenses[dependentID], dependencyID) {
+		return fmt.Sprintf("%s is not compatible with %s", dependentID, dependencyID)
+	}
+
+	dependentCategory := LicenseCategoryOf(dependent)
+	dependencyCategory := LicenseCategoryOf(dependency)
+	switch {
+	case dependentCategory == LicenseCategoryUnknown || dependencyCategory == LicenseCategoryUnknown:
+		return ""
+	case dependencyCategory == LicenseCategoryStrongCopyleft && dependentCategory != LicenseCategoryStrongCopyleft:
+		return fmt.Sprintf("%s component depends on strong copyleft %s", dependentCategory, dependency)
+	case dependentCategory == LicenseCategoryStrongCopyleft && dependencyCategory == LicenseCategoryProprietary:
+		return fmt.Sprintf("%s component depends on proprietary %s", dependent, dependency)
+	}
+	return ""
+}
+
+// licenseExpressionsIssue compares the license choices of a dependent and a
+// dependency. If no combination of choices is compatible it returns the
+// issue of the preferred (first) choices.
+func licenseExpressionsIssue(dependent, dependency *LicenseExpression) string {
+	issue := ""
+	for _, a := range dependent.Alternatives() {
+		for _, b := range dependency.Alternatives() {
+			pairIssue := ""
+			for _, l1 := range a {
+				for _, l2 := range b {
+					if pairIssue = licensePairIssue(l1, l2); pairIssue != "" {
+						break
+					}
+				}
+				if pairIssue != "" {
+					break
+				}
+			}
+			if pairIssue == "" {
+				return ""
+			}
+			if issue == "" {
+				issue = pairIssue
+			}
+		}
+	}
+	return issue
+}
+
+// LicenseExpression returns the parsed license of the node. The concluded
+// license is used when set, otherwise the declared licenses are joined with
+// AND. It returns nil when the node has no license data.
+func (n *Node) LicenseExpression() (*LicenseExpression, error) {
+	if lc := strings.TrimSpace(n.LicenseConcluded); lc != "" && lc != spdx.NOASSERTION && lc != spdx.NONE {
+		return ParseLicenseExpression(lc)
+	}
+
+	parts := []string{}
+	for _, l := range n.Licenses {
+		l = strings.TrimSpace(l)
+		if l == "" || l == spdx.NOASSERTION || l == spdx.NONE {
+			continue
+		}
+		parts = append(parts, "("+l+")")
+	}
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	return ParseLicenseExpression(strings.Join(parts, " AND "))
+}
+
+// LicenseCompatibilityFinding describes a dependency whose license is not
+// compatible with the license of the component using it.
+type LicenseCompatibilityFinding struct {
+	// Dependent is the ID of the node using the dependency
+	Dependent string
+	// Dependency is the ID of the node being used
+	Dependency string
+	// EdgeType is the type of the relationship between the nodes
+	EdgeType Edge_Type
+
+	DependentLicense  string
+	DependencyLicense string
+
+	// Reason explains the incompatibility
+	Reason string
+
+	// Path lists the node IDs from a root element to the dependency
+	Path []string
+}
+
+// String returns a human readable representation of the finding
+func (f *LicenseCompatibilityFinding) String() string {
+	return fmt.Sprintf("%s (%s) -> %s (%s): %s",
+		f.Dependent, f.DependentLicense, f.Dependency, f.DependencyLicense, f.Reason,
+	)
+}
+
+// LicenseCompatibility walks the dependency edges of the NodeList and
+// reports the dependencies with licenses incompatible with the license of
+// the component using them, for example a proprietary component depending
+// on GPL-3.0-only code. Nodes without license data or with licenses of
+// unknown category are not reported.
+func (nl *NodeList) LicenseCompatibility() []*LicenseCompatibilityFinding {
+	findings := []*LicenseCompatibilityFinding{}
+	nodes := nl.indexNodes()
+
+	expressions := map[string]*LicenseExpression{}
+	expression := func(id string) *LicenseExpression {
+		if expr, ok := expressions[id]; ok {
+			return expr
+		}
+		var expr *LicenseExpression
+		if n, ok := nodes[id]; ok {
+			// Unparseable licenses are skipped, they can't be analyzed
+			expr, _ = n.LicenseExpression() //nolint:errcheck
+		}
+		expressions[id] = expr
+		return expr
+	}
+
+	check := func(dependent, dependency string, t Edge_Type) {
+		e1 := expression(dependent)
+		e2 := expression(dependency)
+		if e1 == nil || e2 == nil {
+			return
+		}
+		if issue := licenseExpressionsIssue(e1, e2); issue != "" {
+			findings = append(findings, &LicenseCompatibilityFinding{
+				Dependent:         dependent,
+				Dependency:        dependency,
+				EdgeType:          t,
+				DependentLicense:  e1.String(),
+				DependencyLicense: e2.String(),
+				Reason:            issue,
+			})
+		}
+	}
+
+	for _, e := range nl.Edges {
+		if !slices.Contains(licenseDependencyEdges, e.Type) {
+			continue
+		}
+		for _, to := range e.To {
+			if e.Type.IsReverse() {
+				check(to, e.From, e.Type)
+			} else {
+				check(e.From, to, e.Type)
+			}
+		}
+	}
+
+	if len(findings) > 0 {
+		paths := nl.pathsFromRoots()
+		for _, f := range findings {
+			f.Path = append(slices.Clone(paths[f.Dependent]), f.Dependency)
+		}
+	}
+	return findings
+}
+
+// pathsFromRoots returns the shortest path from a root element to each
+// node in the list, following the edges from dependent to dependency. Nodes
+// not reachable from the roots get a path with only their ID.
+func (nl *NodeList) pathsFromRoots() map[string][]string {
+	children := map[string][]string{}
+	for _, e := range nl.Edges {
+		for _, to := range e.To {
+			if e.Type.IsReverse() {
+				children[to] = append(children[to], e.From)
+			} else {
+				children[e.From] = append(children[e.From], to)
+			}
+		}
+	}
+
+	paths := map[string][]string{}
+	queue := []string{}
+	for _, id := range nl.RootElements {
+		if _, ok := paths[id]; !ok {
+			paths[id] = []string{id}
+			queue = append(queue, id)
+		}
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, id := range children[current] {
+			if _, ok := paths[id]; ok {
+				continue
+			}
+			paths[id] = append(slices.Clone(paths[current]), id)
+			queue = append(queue, id)
+		}
+	}
+
+	for _, n := range nl.Nodes {
+		if _, ok := paths[n.Id]; !ok {
+			paths[n.Id] = []string{n.Id}
+		}
+	}
+	return paths
+}
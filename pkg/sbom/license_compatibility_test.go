@@ -0,0 +1,164 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLicenseCategoryOf(t *testing.T) {
+	for _, tc := range []struct {
+		license  string
+		category LicenseCategory
+	}{
+		{"MIT", LicenseCategoryPermissive},
+		{"GPL-3.0+", LicenseCategoryStrongCopyleft},
+		{"GPL-2.0-only WITH Classpath-exception-2.0", LicenseCategoryWeakCopyleft},
+		{"LicenseRef-ACME-EULA", LicenseCategoryProprietary},
+		{"Something-1.0", LicenseCategoryUnknown},
+	} {
+		t.Run(tc.license, func(t *testing.T) {
+			require.Equal(t, tc.category, LicenseCategoryOf(tc.license))
+		})
+	}
+}
+
+func TestLicenseCompatibility(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *NodeList
+		expected []*LicenseCompatibilityFinding
+	}{
+		{
+			name: "proprietary depends on strong copyleft",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", LicenseConcluded: "LicenseRef-ACME-EULA"},
+					{Id: "gpl2", LicenseConcluded: "GPL-2.0-only"},
+				},
+				Edges:        []*Edge{{Type: Edge_dependsOn, From: "app", To: []string{"gpl2"}}},
+				RootElements: []string{"app"},
+			},
+			expected: []*LicenseCompatibilityFinding{
+				{
+					Dependent: "app", Dependency: "gpl2", EdgeType: Edge_dependsOn,
+					DependentLicense: "LicenseRef-ACME-EULA", DependencyLicense: "GPL-2.0-only",
+					Reason: "proprietary component depends on strong copyleft GPL-2.0-only",
+					Path:   []string{"app", "gpl2"},
+				},
+			},
+		},
+		{
+			name: "transitive weak copyleft depends on strong copyleft",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", LicenseConcluded: "LicenseRef-ACME-EULA"},
+					{Id: "lgpl", Licenses: []string{"LGPL-2.1-or-later"}},
+					{Id: "gpl", Licenses: []string{"GPL-3.0-only"}},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"lgpl"}},
+					{Type: Edge_dependsOn, From: "lgpl", To: []string{"gpl"}},
+				},
+				RootElements: []string{"app"},
+			},
+			expected: []*LicenseCompatibilityFinding{
+				{
+					Dependent: "lgpl", Dependency: "gpl", EdgeType: Edge_dependsOn,
+					DependentLicense: "LGPL-2.1-or-later", DependencyLicense: "GPL-3.0-only",
+					Reason: "weak-copyleft component depends on strong copyleft GPL-3.0-only",
+					Path:   []string{"app", "lgpl", "gpl"},
+				},
+			},
+		},
+		{
+			name: "inverse edge",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app"},
+					{Id: "gpl2", LicenseConcluded: "GPL-2.0-only"},
+					{Id: "apache", Licenses: []string{"Apache-2.0"}},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"gpl2"}},
+					{Type: Edge_dependencyOf, From: "apache", To: []string{"gpl2"}},
+				},
+				RootElements: []string{"app"},
+			},
+			expected: []*LicenseCompatibilityFinding{
+				{
+					Dependent: "gpl2", Dependency: "apache", EdgeType: Edge_dependencyOf,
+					DependentLicense: "GPL-2.0-only", DependencyLicense: "Apache-2.0",
+					Reason: "GPL-2.0-only is not compatible with Apache-2.0",
+					Path:   []string{"app", "gpl2", "apache"},
+				},
+			},
+		},
+		{
+			name: "compatible dependencies",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", LicenseConcluded: "LicenseRef-ACME-EULA"},
+					{Id: "dual", Licenses: []string{"GPL-3.0-only OR MIT"}},
+					{Id: "classpath", Licenses: []string{"GPL-2.0-only WITH Classpath-exception-2.0"}},
+					{Id: "unknown", Licenses: []string{"NOASSERTION"}},
+					{Id: "gpl", Licenses: []string{"GPL-3.0-only"}},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"dual", "classpath", "unknown"}},
+					// Build tools are not linked in the component
+					{Type: Edge_buildTool, From: "app", To: []string{"gpl"}},
+				},
+				RootElements: []string{"app"},
+			},
+			expected: []*LicenseCompatibilityFinding{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.sut.LicenseCompatibility())
+		})
+	}
+}
+
+func TestLicenseCompatibilityEdgeDirection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		edge     *Edge
+		findings int
+	}{
+		"depends on": {
+			edge:     &Edge{Type: EdgeTypeFromSPDX2("DEPENDS_ON"), From: "app", To: []string{"gpl"}},
+			findings: 1,
+		},
+		"runtime dependency of": {
+			edge:     &Edge{Type: EdgeTypeFromSPDX2("RUNTIME_DEPENDENCY_OF"), From: "gpl", To: []string{"app"}},
+			findings: 1,
+		},
+		"optional dependency of": {
+			edge:     &Edge{Type: EdgeTypeFromSPDX2("OPTIONAL_DEPENDENCY_OF"), From: "gpl", To: []string{"app"}},
+			findings: 1,
+		},
+		"optional component of": {
+			edge:     &Edge{Type: EdgeTypeFromSPDX2("OPTIONAL_COMPONENT_OF"), From: "gpl", To: []string{"app"}},
+			findings: 1,
+		},
+		"contained by": {
+			edge:     &Edge{Type: Edge_contained_by, From: "gpl", To: []string{"app"}},
+			findings: 1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			nl := NewNodeList()
+			nl.AddRootNode(&Node{Id: "app", LicenseConcluded: "LicenseRef-ACME-EULA"})
+			nl.AddNode(&Node{Id: "gpl", Licenses: []string{"GPL-3.0-only"}})
+			nl.AddEdge(tc.edge)
+
+			findings := nl.LicenseCompatibility()
+			require.Len(t, findings, tc.findings)
+			for _, f := range findings {
+				require.Equal(t, "app", f.Dependent)
+				require.Equal(t, "gpl", f.Dependency)
+				require.Equal(t, []string{"app", "gpl"}, f.Path)
+			}
+		})
+	}
+}
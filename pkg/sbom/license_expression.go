@@ -0,0 +1,236 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// License expression operators
+const (
+	LicenseOperatorAND = "AND"
+	LicenseOperatorOR  = "OR"
+)
+
+// maxLicenseAlternatives caps the number of license choices computed from an
+// expression to protect against exponential growth.
+const maxLicenseAlternatives = 256
+
+// LicenseExpression is a parsed SPDX license expression. Leaf expressions
+// have a license identifier and an optional exception, compound expressions
+// have an operator and two or more arguments.
+type LicenseExpression struct {
+	License   string
+	Exception string
+	Operator  string
+	Args      []*LicenseExpression
+}
+
+// ParseLicenseExpression parses an SPDX license expression. Operators are
+// case insensitive, AND binds tighter than OR and parentheses can be used
+// for grouping.
+func ParseLicenseExpression(expression string) (*LicenseExpression, error) {
+	p := &licenseParser{tokens: tokenizeLicenseExpression(expression)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty license expression")
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("parsing license expression %q: %w", expression, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("parsing license expression %q: unexpected %q", expression, p.tokens[p.pos])
+	}
+	return expr, nil
+}
+
+// String returns the expression in its canonical SPDX form
+func (e *LicenseExpression) String() string {
+	if e.Operator == "" {
+		if e.Exception != "" {
+			return e.License + " WITH " + e.Exception
+		}
+		return e.License
+	}
+	parts := make([]string, 0, len(e.Args))
+	for _, a := range e.Args {
+		s := a.String()
+		// Parenthesize ORs nested in ANDs to preserve the precedence
+		if a.Operator == LicenseOperatorOR && e.Operator == LicenseOperatorAND {
+			s = "(" + s + ")"
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, " "+e.Operator+" ")
+}
+
+// Licenses returns the sorted list of unique license identifiers in the
+// expression.
+func (e *LicenseExpression) Licenses() []string {
+	ret := []string{}
+	if e.Operator == "" {
+		return append(ret, e.License)
+	}
+	for _, a := range e.Args {
+		ret = append(ret, a.Licenses()...)
+	}
+	slices.Sort(ret)
+	return slices.Compact(ret)
+}
+
+// Alternatives returns the license choices allowed by the expression, each
+// one being a set of licenses that apply together. For example, the
+// expression "MIT AND (GPL-2.0-only OR Apache-2.0)" has the alternatives
+// [MIT GPL-2.0-only] and [MIT Apache-2.0]. Licenses with an exception are
+// returned with it, for example "GPL-2.0-only WITH Classpath-exception-2.0".
+func (e *LicenseExpression) Alternatives() [][]string {
+	switch e.Operator {
+	case "":
+		return [][]string{{e.String()}}
+	case LicenseOperatorOR:
+		ret := [][]string{}
+		for _, a := range e.Args {
+			ret = append(ret, a.Alternatives()...)
+			if len(ret) >= maxLicenseAlternatives {
+				return ret[:maxLicenseAlternatives]
+			}
+		}
+		return ret
+	default:
+		ret := [][]string{{}}
+		for _, a := range e.Args {
+			next := [][]string{}
+			alts := a.Alternatives()
+			for _, prefix := range ret {
+				for _, alt := range alts {
+					if len(next) >= maxLicenseAlternatives {
+						break
+					}
+					next = append(next, slices.Concat(prefix, alt))
+				}
+			}
+			ret = next
+		}
+		return ret
+	}
+}
+
+// tokenizeLicenseExpression splits an expression into identifiers,
+// operators and parentheses.
+func tokenizeLicenseExpression(expression string) []string {
+	tokens := []string{}
+	for _, field := range strings.Fields(expression) {
+		current := ""
+		for _, r := range field {
+			if r == '(' || r == ')' {
+				if current != "" {
+					tokens = append(tokens, current)
+					current = ""
+				}
+				tokens = append(tokens, string(r))
+				continue
+			}
+			current += string(r)
+		}
+		if current != "" {
+			tokens = append(tokens, current)
+		}
+	}
+	return tokens
+}
+
+// licenseParser is a recursive descent parser for license expressions
+type licenseParser struct {
+	tokens []string
+	pos    int
+}
+
+// peekOperator returns true if the next token is the operator op
+func (p *licenseParser) peekOperator(op string) bool {
+	return p.pos < len(p.tokens) && strings.EqualFold(p.tokens[p.pos], op)
+}
+
+// parseOr parses a list of AND expressions joined by OR
+func (p *licenseParser) parseOr() (*LicenseExpression, error) {
+	return p.parseCompound(LicenseOperatorOR, p.parseAnd)
+}
+
+// parseAnd parses a list of simple expressions joined by AND
+func (p *licenseParser) parseAnd() (*LicenseExpression, error) {
+	return p.parseCompound(LicenseOperatorAND, p.parseWith)
+}
+
+// parseCompound parses the operands returned by next joined by op. Nested
+// expressions with the same operator are flattened.
+func (p *licenseParser) parseCompound(op string, next func() (*LicenseExpression, error)) (*LicenseExpression, error) {
+	first, err := next()
+	if err != nil {
+		return nil, err
+	}
+	args := []*LicenseExpression{first}
+	for p.peekOperator(op) {
+		p.pos++
+		arg, err := next()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) == 1 {
+		return first, nil
+	}
+
+	expr := &LicenseExpression{Operator: op}
+	for _, a := range args {
+		if a.Operator == op {
+			expr.Args = append(expr.Args, a.Args...)
+		} else {
+			expr.Args = append(expr.Args, a)
+		}
+	}
+	return expr, nil
+}
+
+// parseWith parses a license with an optional exception or a parenthesized
+// expression.
+func (p *licenseParser) parseWith() (*LicenseExpression, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, errors.New("unexpected end of expression")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch {
+	case token == "(":
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return expr, nil
+	case token == ")" || isLicenseOperator(token):
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+
+	expr := &LicenseExpression{License: token}
+	if p.peekOperator("WITH") {
+		p.pos++
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] == "(" || p.tokens[p.pos] == ")" || isLicenseOperator(p.tokens[p.pos]) {
+			return nil, errors.New("missing license exception")
+		}
+		expr.Exception = p.tokens[p.pos]
+		p.pos++
+	}
+	return expr, nil
+}
+
+// isLicenseOperator returns true if the token is an expression operator
+func isLicenseOperator(token string) bool {
+	return strings.EqualFold(token, LicenseOperatorAND) ||
+		strings.EqualFold(token, LicenseOperatorOR) ||
+		strings.EqualFold(token, "WITH")
+}
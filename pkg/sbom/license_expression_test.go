@@ -0,0 +1,66 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLicenseExpression(t *testing.T) {
+	for _, tc := range []struct {
+		expression   string
+		canonical    string
+		licenses     []string
+		alternatives [][]string
+		mustErr      bool
+	}{
+		{
+			expression:   "MIT",
+			canonical:    "MIT",
+			licenses:     []string{"MIT"},
+			alternatives: [][]string{{"MIT"}},
+		},
+		{
+			expression:   "MIT and (GPL-2.0-only or Apache-2.0)",
+			canonical:    "MIT AND (GPL-2.0-only OR Apache-2.0)",
+			licenses:     []string{"Apache-2.0", "GPL-2.0-only", "MIT"},
+			alternatives: [][]string{{"MIT", "GPL-2.0-only"}, {"MIT", "Apache-2.0"}},
+		},
+		{
+			expression:   "MIT OR Apache-2.0 AND BSD-3-Clause",
+			canonical:    "MIT OR Apache-2.0 AND BSD-3-Clause",
+			licenses:     []string{"Apache-2.0", "BSD-3-Clause", "MIT"},
+			alternatives: [][]string{{"MIT"}, {"Apache-2.0", "BSD-3-Clause"}},
+		},
+		{
+			expression:   "((MIT)) AND (ISC AND Zlib)",
+			canonical:    "MIT AND ISC AND Zlib",
+			licenses:     []string{"ISC", "MIT", "Zlib"},
+			alternatives: [][]string{{"MIT", "ISC", "Zlib"}},
+		},
+		{
+			expression:   "GPL-2.0-only WITH Classpath-exception-2.0 OR MIT",
+			canonical:    "GPL-2.0-only WITH Classpath-exception-2.0 OR MIT",
+			licenses:     []string{"GPL-2.0-only", "MIT"},
+			alternatives: [][]string{{"GPL-2.0-only WITH Classpath-exception-2.0"}, {"MIT"}},
+		},
+		{expression: "", mustErr: true},
+		{expression: "MIT AND", mustErr: true},
+		{expression: "(MIT OR ISC", mustErr: true},
+		{expression: "MIT ISC", mustErr: true},
+		{expression: "GPL-2.0-only WITH", mustErr: true},
+		{expression: "OR MIT", mustErr: true},
+	} {
+		t.Run(tc.expression, func(t *testing.T) {
+			expr, err := ParseLicenseExpression(tc.expression)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.canonical, expr.String())
+			require.Equal(t, tc.licenses, expr.Licenses())
+			require.Equal(t, tc.alternatives, expr.Alternatives())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestNewLicenseRef(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      string
+		expected string
+	}{
+		{"simple name", "Custom", "LicenseRef-Custom"},
+		{"spaces and symbols", "ACME Corp. License (v2)", "LicenseRef-ACME-Corp.-License-v2"},
+		{"already a ref", "LicenseRef-acme", "LicenseRef-acme"},
+		{"empty", "  ", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, NewLicenseRef(tc.sut))
+		})
+	}
+}
+
+func TestMetadataCustomLicenses(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		add   []*License
+		id    string
+		text  string
+		count int
+	}{
+		{
+			name: "no licenses",
+			id:   "LicenseRef-test",
+		},
+		{
+			name:  "add",
+			add:   []*License{{Id: "LicenseRef-test", Text: "text 1"}, {Id: "LicenseRef-other", Text: "other"}},
+			id:    "LicenseRef-test",
+			text:  "text 1",
+			count: 2,
+		},
+		{
+			// Adding a license with the same ID replaces it
+			name: "replace",
+			add: []*License{
+				{Id: "LicenseRef-test", Text: "text 1"},
+				{Id: "LicenseRef-other", Text: "other"},
+				{Id: "LicenseRef-test", Text: "text 2"},
+			},
+			id:    "LicenseRef-test",
+			text:  "text 2",
+			count: 2,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			md := &Metadata{}
+			for _, l := range tc.add {
+				md.AddCustomLicense(l)
+			}
+			require.Len(t, md.CustomLicenses, tc.count)
+			l := md.GetCustomLicense(tc.id)
+			if tc.text == "" {
+				require.Nil(t, l)
+				return
+			}
+			require.NotNil(t, l)
+			require.Equal(t, tc.text, l.Text)
+		})
+	}
+}
+
+func TestLicenseCopy(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		sut  *License
+	}{
+		{"all fields", &License{Id: "LicenseRef-a", Name: "A", Text: "t", Urls: []string{"https://example.com/"}, Comment: "c"}},
+		{"without urls", &License{Id: "LicenseRef-b", Text: "t"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.sut.Copy()
+			require.True(t, proto.Equal(tc.sut, c))
+			if len(c.Urls) > 0 {
+				c.Urls[0] = "changed"
+				require.NotEqual(t, "changed", tc.sut.Urls[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package sbom
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NodeMatcher decides if two nodes describe the same component. Matchers
+// are used to correlate the components of different SBOMs when they can't
+// be paired by their IDs.
+type NodeMatcher interface {
+	Match(n1, n2 *Node) bool
+}
+
+// NodeMatcherFunc adapts a function to the NodeMatcher interface
+type NodeMatcherFunc func(n1, n2 *Node) bool
+
+// Match calls f(n1, n2)
+func (f NodeMatcherFunc) Match(n1, n2 *Node) bool {
+	return f(n1, n2)
+}
+
+// GetNodesMatching returns the nodes in the NodeList that the matcher
+// considers the same component as node.
+func (nl *NodeList) GetNodesMatching(node *Node, matcher NodeMatcher) []*Node {
+	ret := []*Node{}
+	for _, n := range nl.Nodes {
+		if matcher.Match(node, n) {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// DefaultNamePrefixes are the prefixes that distributions commonly add to
+// the names of the packages of language ecosystems.
+var DefaultNamePrefixes = []string{
+	"python3-", "python-", "py3-", "golang-", "perl-", "ruby-", "rubygem-",
+	"node-", "php-", "lua-", "r-cran-",
+}
+
+// DefaultNameSuffixes are the suffixes that distributions commonly add to
+// the names of split packages.
+var DefaultNameSuffixes = []string{
+	"-dev", "-devel", "-libs", "-common", "-bin", "-doc", "-utils",
+}
+
+// minFuzzyNameLength is the shortest name compared by edit distance. Short
+// names are too close to each other to be matched fuzzily.
+const minFuzzyNameLength = 5
+
+// NameMatcher is a NodeMatcher that pairs nodes by their names when there
+// are no package URLs to compare, for example to correlate the packages of
+// a distribution with their upstream projects. Names are compared ignoring
+// case and punctuation, after removing the distribution prefixes and
+// suffixes, and resolving the registered aliases. Names that still differ
+// can match when they are within MaxDistance edits of each other.
+type NameMatcher struct {
+	// MaxDistance is the number of edits (insertions, deletions or
+	// substitutions) tolerated between the names. Zero disables the
+	// fuzzy comparison.
+	MaxDistance int
+
+	// MatchVersions requires the nodes to have the same version, when
+	// both have one.
+	MatchVersions bool
+
+	// Prefixes and Suffixes are removed from the names before comparing
+	// them.
+	Prefixes []string
+	Suffixes []string
+
+	// aliases maps the normalized names to the canonical name of their
+	// alias group
+	aliases map[string]string
+}
+
+// NewNameMatcher returns a NameMatcher tolerating one edit and stripping
+// the default distribution prefixes and suffixes.
+func NewNameMatcher() *NameMatcher {
+	return &NameMatcher{
+		MaxDistance: 1,
+		Prefixes:    DefaultNamePrefixes,
+		Suffixes:    DefaultNameSuffixes,
+	}
+}
+
+// AddAliases registers a group of names that refer to the same component,
+// for example "libssl3", "openssl". If any of the names is already part of
+// a group, the groups are merged.
+func (m *NameMatcher) AddAliases(names ...string) {
+	if m.aliases == nil {
+		m.aliases = map[string]string{}
+	}
+	normalized := []string{}
+	for _, name := range names {
+		if n := normalizeName(name); n != "" {
+			normalized = append(normalized, n)
+		}
+	}
+	if len(normalized) == 0 {
+		return
+	}
+
+	canonical := normalized[0]
+	if c, ok := m.aliases[canonical]; ok {
+		canonical = c
+	}
+	for _, n := range normalized {
+		old, ok := m.aliases[n]
+		if !ok || old == canonical {
+			m.aliases[n] = canonical
+			continue
+		}
+		// Merge the group of the name into the canonical one
+		for alias, c := range m.aliases {
+			if c == old {
+				m.aliases[alias] = canonical
+			}
+		}
+	}
+}
+
+// Match returns true if both nodes have names referring to the same
+// component.
+func (m *NameMatcher) Match(n1, n2 *Node) bool {
+	if n1 == nil || n2 == nil {
+		return false
+	}
+	if m.MatchVersions && n1.Version != "" && n2.Version != "" && n1.Version != n2.Version {
+		return false
+	}
+
+	names1, names2 := m.nameVariants(n1.Name), m.nameVariants(n2.Name)
+	if len(names1) == 0 || len(names2) == 0 {
+		return false
+	}
+	for _, a := range names1 {
+		for _, b := range names2 {
+			if a == b {
+				return true
+			}
+		}
+	}
+
+	if m.MaxDistance <= 0 {
+		return false
+	}
+
+	// The fuzzy comparison uses the most reduced form of the names
+	a, b := []rune(names1[len(names1)-1]), []rune(names2[len(names2)-1])
+	if len(a) < minFuzzyNameLength || len(b) < minFuzzyNameLength {
+		return false
+	}
+	return editDistance(a, b, m.MaxDistance) <= m.MaxDistance
+}
+
+// nameVariants returns the normalized forms of a name: as is and without
+// the distribution affixes, each replaced by the canonical name of its
+// alias group if it has one.
+func (m *NameMatcher) nameVariants(name string) []string {
+	ret := []string{}
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, v := range []string{name, m.stripAffixes(name)} {
+		n := normalizeName(v)
+		if n == "" {
+			continue
+		}
+		if c, ok := m.aliases[n]; ok {
+			n = c
+		}
+		if len(ret) == 0 || ret[len(ret)-1] != n {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// stripAffixes removes the first matching prefix and suffix of the name
+func (m *NameMatcher) stripAffixes(name string) string {
+	for _, p := range m.Prefixes {
+		if s, ok := strings.CutPrefix(name, strings.ToLower(p)); ok && s != "" {
+			name = s
+			break
+		}
+	}
+	for _, p := range m.Suffixes {
+		if s, ok := strings.CutSuffix(name, strings.ToLower(p)); ok && s != "" {
+			name = s
+			break
+		}
+	}
+	return name
+}
+
+// normalizeName lowercases a name and removes all characters other than
+// letters and digits.
+func normalizeName(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,92 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameMatcher(t *testing.T) {
+	m := NewNameMatcher()
+	m.AddAliases("libssl3", "openssl")
+	m.AddAliases("libssl-dev", "libssl3")
+	m.AddAliases("zlib1g", "zlib")
+
+	for _, tc := range []struct {
+		name1, name2 string
+		match        bool
+	}{
+		{"requests", "requests", true},
+		{"PyYAML", "pyyaml", true},
+		{"python3-requests", "requests", true},
+		{"perl-JSON-XS", "JSON::XS", true},
+		{"ruby-rack", "rack", true},
+		{"curl-dev", "curl", true},
+		{"libssl3", "openssl", true},
+		{"libssl-dev", "OpenSSL", true},
+		{"zlib1g-dev", "zlib", true},
+		{"requets", "requests", true},
+		{"requests", "urllib3", false},
+		{"xz", "xa", false},
+		{"openssl", "libressl", false},
+		{"", "", false},
+	} {
+		t.Run(tc.name1+"/"+tc.name2, func(t *testing.T) {
+			require.Equal(t, tc.match, m.Match(&Node{Name: tc.name1}, &Node{Name: tc.name2}))
+			require.Equal(t, tc.match, m.Match(&Node{Name: tc.name2}, &Node{Name: tc.name1}))
+		})
+	}
+
+	t.Run("versions", func(t *testing.T) {
+		n1 := &Node{Name: "curl", Version: "8.5.0"}
+		n2 := &Node{Name: "curl", Version: "8.6.0"}
+		require.True(t, m.Match(n1, n2))
+		m.MatchVersions = true
+		require.False(t, m.Match(n1, n2))
+		require.True(t, m.Match(n1, &Node{Name: "curl"}))
+	})
+
+	t.Run("no-fuzzy", func(t *testing.T) {
+		strict := &NameMatcher{}
+		require.False(t, strict.Match(&Node{Name: "requets"}, &Node{Name: "requests"}))
+		require.False(t, strict.Match(&Node{Name: "python3-requests"}, &Node{Name: "requests"}))
+		require.True(t, strict.Match(&Node{Name: "Requests"}, &Node{Name: "requests"}))
+	})
+}
+
+func TestGetNodesMatching(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		node     *Node
+		matcher  NodeMatcher
+		expected []string
+	}{
+		{
+			name:     "name matcher",
+			node:     &Node{Name: "requests"},
+			matcher:  NewNameMatcher(),
+			expected: []string{"1", "3"},
+		},
+		{
+			name:     "matcher func",
+			node:     &Node{Id: "2"},
+			matcher:  NodeMatcherFunc(func(n1, n2 *Node) bool { return n1.Id == n2.Id }),
+			expected: []string{"2"},
+		},
+		{
+			name:     "no matches",
+			node:     &Node{Name: "curl"},
+			matcher:  NewNameMatcher(),
+			expected: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{
+				{Id: "1", Name: "python3-requests"},
+				{Id: "2", Name: "python3-urllib3"},
+				{Id: "3", Name: "requests-doc"},
+			}}
+			require.Equal(t, tc.expected, ids(nl.GetNodesMatching(tc.node, tc.matcher)))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package sbom
+
+import (
+	"cmp"
+	"slices"
+)
+
+// FieldPrecedence defines which data wins when Add, Union or Intersect find
+// the same node in both NodeLists
+type FieldPrecedence string
+
+const (
+	// FieldPrecedenceExisting keeps the values of the node in the receiving
+	// NodeList, the incoming node only fills its empty fields (see
+	// Node.Augment). This is the default of Add.
+	FieldPrecedenceExisting FieldPrecedence = "existing"
+
+	// FieldPrecedenceIncoming overwrites the values of the node in the
+	// receiving NodeList with the non empty fields of the incoming node (see
+	// Node.Update). This is the default of Union and Intersect.
+	FieldPrecedenceIncoming FieldPrecedence = "incoming"
+)
+
+// EdgeMergePolicy defines how the edges of two NodeLists are combined when
+// both have edges of the same type from the same node
+type EdgeMergePolicy string
+
+const (
+	// EdgeMergeUnion combines the destinations of the edges. This is the
+	// default of all the operations.
+	EdgeMergeUnion EdgeMergePolicy = "union"
+
+	// EdgeMergeExisting keeps the edge of the receiving NodeList
+	EdgeMergeExisting EdgeMergePolicy = "existing"
+
+	// EdgeMergeIncoming replaces the edge of the receiving NodeList with the
+	// incoming one
+	EdgeMergeIncoming EdgeMergePolicy = "incoming"
+)
+
+// RootPolicy defines the root elements of the result of combining two
+// NodeLists. Intersect only keeps the root elements of the common nodes.
+type RootPolicy string
+
+const (
+	// RootsUnion keeps the root elements of both NodeLists. This is the
+	// default of all the operations.
+	RootsUnion RootPolicy = "union"
+
+	// RootsExisting keeps the root elements of the receiving NodeList
+	RootsExisting RootPolicy = "existing"
+
+	// RootsIncoming keeps the root elements of the incoming NodeList
+	RootsIncoming RootPolicy = "incoming"
+)
+
+// MergeOptions controls how Add, Union and Intersect combine the nodes,
+// edges and root elements of two NodeLists. The zero value keeps the
+// default of each operation, noted in the policy constants.
+type MergeOptions struct {
+	// FieldPrecedence defines the data kept from nodes in both lists
+	FieldPrecedence FieldPrecedence
+
+	// EdgeMerge defines how edges with the same source and type are merged
+	EdgeMerge EdgeMergePolicy
+
+	// Roots defines the root elements of the result
+	Roots RootPolicy
+}
+
+// WithMergeOptions sets how Add, Union and Intersect combine the NodeLists
+func WithMergeOptions(mo MergeOptions) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.merge = mo
+	}
+}
+
+// mergeNode merges incoming into existing following the field precedence,
+// or def when none is set
+func (mo *MergeOptions) mergeNode(existing, incoming *Node, def FieldPrecedence) {
+	if cmp.Or(mo.FieldPrecedence, def) == FieldPrecedenceExisting {
+		existing.Augment(incoming)
+	} else {
+		existing.Update(incoming)
+	}
+}
+
+// edgesToMerge returns the edges of both lists to combine, dropping those
+// that lose to an edge of the other list under the edge merge policy. The
+// returned edges are merged by source and type as in EdgeMergeUnion.
+func (mo *MergeOptions) edgesToMerge(edges, edges2 []*Edge) (existing, incoming []*Edge) {
+	keys := func(es []*Edge) map[edgeKey]struct{} {
+		ret := map[edgeKey]struct{}{}
+		for _, e := range es {
+			ret[edgeKey{from: e.GetFrom(), t: e.GetType()}] = struct{}{}
+		}
+		return ret
+	}
+	drop := func(es []*Edge, others []*Edge) []*Edge {
+		index := keys(others)
+		return slices.DeleteFunc(slices.Clone(es), func(e *Edge) bool {
+			_, ok := index[edgeKey{from: e.GetFrom(), t: e.GetType()}]
+			return ok
+		})
+	}
+
+	switch mo.EdgeMerge {
+	case EdgeMergeExisting:
+		return edges, drop(edges2, edges)
+	case EdgeMergeIncoming:
+		return drop(edges, edges2), edges2
+	default:
+		return edges, edges2
+	}
+}
+
+// rootElements returns the root elements of the result, without duplicates.
+// It returns nil when both lists are nil.
+func (mo *MergeOptions) rootElements(roots, roots2 []string) []string {
+	var lists [][]string
+	switch mo.Roots {
+	case RootsExisting:
+		lists = [][]string{roots}
+	case RootsIncoming:
+		lists = [][]string{roots2}
+	default:
+		lists = [][]string{roots, roots2}
+	}
+
+	if roots == nil && roots2 == nil {
+		return nil
+	}
+	ret := []string{}
+	seen := map[string]struct{}{}
+	for _, l := range lists {
+		for _, id := range l {
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ret = append(ret, id)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,102 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeOptions(t *testing.T) {
+	existing := func() *NodeList {
+		return &NodeList{
+			Nodes: []*Node{
+				{Id: "app", Name: "app", Version: "1.0"},
+				{Id: "lib", Name: "lib"},
+				{Id: "old", Name: "old"},
+			},
+			Edges: []*Edge{
+				{From: "app", Type: Edge_dependsOn, To: []string{"lib", "old"}},
+			},
+			RootElements: []string{"app"},
+		}
+	}
+	incoming := func() *NodeList {
+		return &NodeList{
+			Nodes: []*Node{
+				{Id: "app", Name: "app", Version: "2.0", Description: "the app"},
+				{Id: "lib", Name: "lib"},
+				{Id: "new", Name: "new"},
+			},
+			Edges: []*Edge{
+				{From: "app", Type: Edge_dependsOn, To: []string{"lib", "new"}},
+				{From: "lib", Type: Edge_contains, To: []string{"new"}},
+			},
+			RootElements: []string{"lib"},
+		}
+	}
+	add := func(mo MergeOptions) *NodeList {
+		nl := existing()
+		nl.Add(incoming(), WithMergeOptions(mo))
+		return nl
+	}
+	union := func(mo MergeOptions) *NodeList {
+		return existing().Union(incoming(), WithMergeOptions(mo))
+	}
+	parallelUnion := func(mo MergeOptions) *NodeList {
+		return existing().Union(incoming(), WithMergeOptions(mo), WithParallelism(4))
+	}
+	intersect := func(mo MergeOptions) *NodeList {
+		return existing().Intersect(incoming(), WithMergeOptions(mo))
+	}
+	parallelIntersect := func(mo MergeOptions) *NodeList {
+		return existing().Intersect(incoming(), WithMergeOptions(mo), WithParallelism(4))
+	}
+	dependencies := func(nl *NodeList) []string {
+		return nl.GetEdgeByType("app", Edge_dependsOn).To
+	}
+
+	t.Run("field precedence", func(t *testing.T) {
+		for _, op := range []func(MergeOptions) *NodeList{union, parallelUnion, intersect, parallelIntersect} {
+			nl := op(MergeOptions{})
+			require.Equal(t, "2.0", nl.GetNodeByID("app").Version)
+			nl = op(MergeOptions{FieldPrecedence: FieldPrecedenceExisting})
+			require.Equal(t, "1.0", nl.GetNodeByID("app").Version)
+			require.Equal(t, "the app", nl.GetNodeByID("app").Description)
+		}
+
+		require.Equal(t, "1.0", add(MergeOptions{}).GetNodeByID("app").Version)
+		nl := add(MergeOptions{FieldPrecedence: FieldPrecedenceIncoming})
+		require.Equal(t, "2.0", nl.GetNodeByID("app").Version)
+	})
+
+	t.Run("edge merge", func(t *testing.T) {
+		for _, op := range []func(MergeOptions) *NodeList{add, union, parallelUnion} {
+			require.ElementsMatch(t, []string{"lib", "new", "old"}, dependencies(op(MergeOptions{})))
+			require.ElementsMatch(t, []string{"lib", "new", "old"}, dependencies(op(MergeOptions{EdgeMerge: EdgeMergeUnion})))
+
+			nl := op(MergeOptions{EdgeMerge: EdgeMergeExisting})
+			require.ElementsMatch(t, []string{"lib", "old"}, dependencies(nl))
+			require.Equal(t, []string{"new"}, nl.GetEdgeByType("lib", Edge_contains).To)
+
+			nl = op(MergeOptions{EdgeMerge: EdgeMergeIncoming})
+			require.ElementsMatch(t, []string{"lib", "new"}, dependencies(nl))
+		}
+		for _, op := range []func(MergeOptions) *NodeList{intersect, parallelIntersect} {
+			require.Equal(t, []string{"lib"}, dependencies(op(MergeOptions{})))
+		}
+
+		// The merged lists are not modified
+		nl1, nl2 := existing(), incoming()
+		nl1.Union(nl2, WithMergeOptions(MergeOptions{EdgeMerge: EdgeMergeIncoming}))
+		require.Equal(t, existing(), nl1)
+		require.Equal(t, incoming(), nl2)
+	})
+
+	t.Run("roots", func(t *testing.T) {
+		for _, op := range []func(MergeOptions) *NodeList{add, union, parallelUnion, intersect, parallelIntersect} {
+			require.ElementsMatch(t, []string{"app", "lib"}, op(MergeOptions{}).RootElements)
+			require.Equal(t, []string{"app"}, op(MergeOptions{Roots: RootsExisting}).RootElements)
+			require.Equal(t, []string{"lib"}, op(MergeOptions{Roots: RootsIncoming}).RootElements)
+		}
+	})
+}
@@ -0,0 +1,176 @@
+package sbom
+
+import (
+	"maps"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetProperty returns the first document property with the specified name
+// or nil if not found.
+func (m *Metadata) GetProperty(name string) *Property {
+	for _, p := range m.GetProperties() {
+		if p.GetName() == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// SetProperty sets the value of a document property. If a property with
+// the same name exists, its data is replaced, otherwise a new one is added.
+func (m *Metadata) SetProperty(name, data string) {
+	if p := m.GetProperty(name); p != nil {
+		p.Data = data
+		return
+	}
+	m.Properties = append(m.Properties, &Property{Name: name, Data: data})
+}
+
+// RemoveProperty removes all the document properties with the specified name.
+func (m *Metadata) RemoveProperty(name string) {
+	props := []*Property{}
+	for _, p := range m.GetProperties() {
+		if p.GetName() != name {
+			props = append(props, p)
+		}
+	}
+	m.Properties = props
+}
+
+// VerifiedSignatures returns the signatures of the original document that
+// were verified when it was read.
+func (m *Metadata) VerifiedSignatures() []*Signature {
+	ret := []*Signature{}
+	for _, s := range m.GetSignatures() {
+		if s.GetVerification() == Signature_VERIFIED {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// IsSignedBy returns true if the original document has a verified signature
+// made by identity. The identity is matched against the signer and the key
+// ID of the signatures. Readers only record the signer of verified
+// signatures from validated certificates.
+func (m *Metadata) IsSignedBy(identity string) bool {
+	if identity == "" {
+		return false
+	}
+	for _, s := range m.VerifiedSignatures() {
+		if s.GetSigner() == identity || s.GetKeyId() == identity {
+			return true
+		}
+	}
+	return false
+}
+
+// Copy returns a deep copy of the document metadata. Like Node.Copy, the
+// fields are copied explicitly and the result shares no data with the
+// original. Copying a nil Metadata returns nil.
+func (m *Metadata) Copy() *Metadata {
+	if m == nil {
+		return nil
+	}
+	mo := &Metadata{
+		Id:             m.Id,
+		Version:        m.Version,
+		Name:           m.Name,
+		Tools:          []*Tool{},
+		Authors:        []*Person{},
+		Comment:        m.Comment,
+		SourceData:     m.SourceData.Copy(),
+		CreatorComment: m.CreatorComment,
+	}
+	if m.Date != nil {
+		mo.Date = timestamppb.New(m.Date.AsTime())
+	}
+	for _, t := range m.Tools {
+		mo.Tools = append(mo.Tools, t.Copy())
+	}
+	for _, p := range m.Authors {
+		mo.Authors = append(mo.Authors, p.Copy())
+	}
+	for _, dt := range m.DocumentTypes {
+		mo.DocumentTypes = append(mo.DocumentTypes, dt.Copy())
+	}
+	for _, l := range m.CustomLicenses {
+		mo.CustomLicenses = append(mo.CustomLicenses, l.Copy())
+	}
+	for _, r := range m.Revisions {
+		mo.Revisions = append(mo.Revisions, r.Copy())
+	}
+	for _, p := range m.Properties {
+		mo.Properties = append(mo.Properties, p.Copy())
+	}
+	for _, s := range m.Signatures {
+		mo.Signatures = append(mo.Signatures, s.Copy())
+	}
+	return mo
+}
+
+// Copy returns a duplicate of the tool.
+func (t *Tool) Copy() *Tool {
+	return &Tool{
+		Name:    t.Name,
+		Version: t.Version,
+		Vendor:  t.Vendor,
+	}
+}
+
+// Copy returns a duplicate of the document type.
+func (dt *DocumentType) Copy() *DocumentType {
+	ret := &DocumentType{}
+	if dt.Type != nil {
+		t := *dt.Type
+		ret.Type = &t
+	}
+	if dt.Name != nil {
+		name := *dt.Name
+		ret.Name = &name
+	}
+	if dt.Description != nil {
+		desc := *dt.Description
+		ret.Description = &desc
+	}
+	return ret
+}
+
+// Copy returns a duplicate of the source data. Copying a nil SourceData
+// returns nil.
+func (sd *SourceData) Copy() *SourceData {
+	if sd == nil {
+		return nil
+	}
+	ret := &SourceData{
+		Format: sd.Format,
+		Hashes: maps.Clone(sd.Hashes),
+		Size:   sd.Size,
+	}
+	if sd.Uri != nil {
+		uri := *sd.Uri
+		ret.Uri = &uri
+	}
+	return ret
+}
+
+// Copy returns a duplicate of the signature, including its raw data and
+// certificates.
+func (s *Signature) Copy() *Signature {
+	ret := &Signature{
+		Format:            s.Format,
+		Algorithm:         s.Algorithm,
+		KeyId:             s.KeyId,
+		Data:              slices.Clone(s.Data),
+		Signer:            s.Signer,
+		Verification:      s.Verification,
+		VerificationError: s.VerificationError,
+		PayloadType:       s.PayloadType,
+	}
+	for _, c := range s.Certificates {
+		ret.Certificates = append(ret.Certificates, slices.Clone(c))
+	}
+	return ret
+}
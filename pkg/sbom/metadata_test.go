@@ -0,0 +1,60 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetadataProperties(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		sut      *Metadata
+		set      [][2]string
+		remove   string
+		expected []*Property
+	}{
+		{
+			name:     "empty",
+			sut:      &Metadata{},
+			expected: nil,
+		},
+		{
+			name:     "set",
+			sut:      &Metadata{},
+			set:      [][2]string{{"build", "1"}, {"pipeline", "release"}},
+			expected: []*Property{{Name: "build", Data: "1"}, {Name: "pipeline", Data: "release"}},
+		},
+		{
+			// Setting an existing property replaces its data
+			name:     "replace",
+			sut:      &Metadata{Properties: []*Property{{Name: "build", Data: "1"}, {Name: "pipeline", Data: "release"}}},
+			set:      [][2]string{{"build", "2"}},
+			expected: []*Property{{Name: "build", Data: "2"}, {Name: "pipeline", Data: "release"}},
+		},
+		{
+			name: "remove duplicates",
+			sut: &Metadata{Properties: []*Property{
+				{Name: "build", Data: "2"}, {Name: "pipeline", Data: "release"}, {Name: "build", Data: "3"},
+			}},
+			remove:   "build",
+			expected: []*Property{{Name: "pipeline", Data: "release"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, p := range tc.set {
+				tc.sut.SetProperty(p[0], p[1])
+			}
+			if tc.remove != "" {
+				tc.sut.RemoveProperty(tc.remove)
+				require.Nil(t, tc.sut.GetProperty(tc.remove))
+			}
+			require.Len(t, tc.sut.Properties, len(tc.expected))
+			for i, p := range tc.expected {
+				require.Equal(t, p.Name, tc.sut.Properties[i].Name)
+				require.Equal(t, p.Data, tc.sut.Properties[i].Data)
+				require.Equal(t, p.Data, tc.sut.GetProperty(p.Name).Data)
+			}
+		})
+	}
+}
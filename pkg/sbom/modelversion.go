@@ -0,0 +1,119 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// ModelVersion is the version of the protobom message schema compiled in
+// this module. It is increased with every change to the schema that needs
+// a migration to read the documents serialized with the previous one.
+const ModelVersion uint32 = 1
+
+// modelVersionField is the number of the field recording the model version
+// in the serialized documents. It is not defined in the Document message so
+// proto.Unmarshal, and protobom releases predating the versioning, read the
+// tagged documents skipping it as an unknown field.
+const modelVersionField protowire.Number = 1000
+
+// ErrUnsupportedModelVersion is returned when reading documents serialized
+// with a model version newer than ModelVersion or with no migration path
+var ErrUnsupportedModelVersion = errors.New("unsupported protobom model version")
+
+// Migration upgrades serialized documents from a model version to the next
+// one. Migrations work on the wire data so they can read fields renumbered
+// or retyped in the current schema.
+type Migration struct {
+	// From is the model version of the documents migrated. They are
+	// upgraded to From+1.
+	From uint32
+
+	// Description explains the changes in the schema
+	Description string
+
+	// Migrate converts the serialized document
+	Migrate func([]byte) ([]byte, error)
+}
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = map[uint32]*Migration{}
+)
+
+// RegisterMigration registers the migration of the documents of a model
+// version, replacing any previous one.
+func RegisterMigration(m *Migration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+	migrations[m.From] = m
+}
+
+// MarshalVersioned serializes a document to the protobuf wire format,
+// starting with a field recording the model version. The data is a valid
+// Document message. Use UnmarshalVersioned to read it back, upgrading it if
+// it was written by an older version.
+func MarshalVersioned(d *Document) ([]byte, error) {
+	data, err := proto.Marshal(d)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling document: %w", err)
+	}
+	buf := make([]byte, 0, protowire.SizeTag(modelVersionField)+protowire.SizeVarint(uint64(ModelVersion))+len(data))
+	buf = protowire.AppendTag(buf, modelVersionField, protowire.VarintType)
+	buf = protowire.AppendVarint(buf, uint64(ModelVersion))
+	return append(buf, data...), nil
+}
+
+// ModelVersionOf returns the model version of a serialized document and
+// its protobuf data without the version field. Untagged documents,
+// serialized before the model was versioned, are version 1.
+func ModelVersionOf(data []byte) (version uint32, payload []byte, err error) {
+	num, typ, n := protowire.ConsumeTag(data)
+	if n < 0 || num != modelVersionField {
+		return 1, data, nil
+	}
+	if typ != protowire.VarintType {
+		return 0, nil, fmt.Errorf("invalid model version field type %d", typ)
+	}
+	v, m := protowire.ConsumeVarint(data[n:])
+	if m < 0 {
+		return 0, nil, fmt.Errorf("reading model version: %w", protowire.ParseError(m))
+	}
+	if v > math.MaxUint32 {
+		return 0, nil, fmt.Errorf("invalid model version %d", v)
+	}
+	return uint32(v), data[n+m:], nil
+}
+
+// UnmarshalVersioned parses a serialized document into d, tagged with its
+// model version or not, running the migrations registered to upgrade it to
+// the current ModelVersion.
+func UnmarshalVersioned(data []byte, d *Document) error {
+	version, payload, err := ModelVersionOf(data)
+	if err != nil {
+		return err
+	}
+	if version > ModelVersion {
+		return fmt.Errorf("%w: document is version %d, this protobom reads up to %d",
+			ErrUnsupportedModelVersion, version, ModelVersion)
+	}
+	for ; version < ModelVersion; version++ {
+		migrationsMu.RLock()
+		m, ok := migrations[version]
+		migrationsMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("%w: no migration from version %d", ErrUnsupportedModelVersion, version)
+		}
+		if payload, err = m.Migrate(payload); err != nil {
+			return fmt.Errorf("migrating document from model version %d: %w", version, err)
+		}
+	}
+	if err := proto.Unmarshal(payload, d); err != nil {
+		return fmt.Errorf("unmarshaling document: %w", err)
+	}
+	return nil
+}
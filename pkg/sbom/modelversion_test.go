@@ -0,0 +1,179 @@
+package sbom
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestMarshalVersioned(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		marshal   func(*Document) ([]byte, error)
+		unmarshal func([]byte, *Document) error
+		// unknown is true when the version is read as an unknown field
+		unknown bool
+	}{
+		{
+			name:      "versioned",
+			marshal:   MarshalVersioned,
+			unmarshal: UnmarshalVersioned,
+		},
+		{
+			// The tagged data is a valid document for readers unaware of
+			// versions, the version is an unknown field
+			name:      "reader unaware of versions",
+			marshal:   MarshalVersioned,
+			unmarshal: func(data []byte, d *Document) error { return proto.Unmarshal(data, d) },
+			unknown:   true,
+		},
+		{
+			// Documents serialized before versioning are read as version 1
+			name:      "untagged document",
+			marshal:   func(d *Document) ([]byte, error) { return proto.Marshal(d) },
+			unmarshal: UnmarshalVersioned,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.Metadata.Id = "doc"
+			doc.NodeList.AddRootNode(&Node{Id: "app", Name: "app"})
+
+			data, err := tc.marshal(doc)
+			require.NoError(t, err)
+			got := &Document{}
+			require.NoError(t, tc.unmarshal(data, got))
+			if tc.unknown {
+				require.NotEmpty(t, got.ProtoReflect().GetUnknown())
+				got.ProtoReflect().SetUnknown(nil)
+			} else {
+				require.Empty(t, got.ProtoReflect().GetUnknown())
+			}
+			require.True(t, proto.Equal(doc, got))
+		})
+	}
+}
+
+func TestModelVersionOf(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    []byte
+		version uint32
+		payload []byte
+		mustErr bool
+	}{
+		{
+			name:    "tagged",
+			data:    protowire.AppendVarint(protowire.AppendTag(nil, modelVersionField, protowire.VarintType), 7),
+			version: 7,
+			payload: []byte{},
+		},
+		{
+			name:    "untagged",
+			data:    []byte{0x0a, 0x00},
+			version: 1,
+			payload: []byte{0x0a, 0x00},
+		},
+		{
+			name:    "empty",
+			version: 1,
+		},
+		{
+			name:    "missing version",
+			data:    protowire.AppendTag(nil, modelVersionField, protowire.VarintType),
+			mustErr: true,
+		},
+		{
+			name:    "wrong wire type",
+			data:    protowire.AppendTag(nil, modelVersionField, protowire.BytesType),
+			mustErr: true,
+		},
+		{
+			name:    "version overflow",
+			data:    protowire.AppendVarint(protowire.AppendTag(nil, modelVersionField, protowire.VarintType), math.MaxUint32+1),
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			version, payload, err := ModelVersionOf(tc.data)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.version, version)
+			require.Equal(t, tc.payload, payload)
+		})
+	}
+}
+
+func TestUnmarshalVersionedMigrations(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		version   uint32
+		migration *Migration
+		errIs     error
+		expected  *Metadata
+	}{
+		{
+			name:    "no migration path",
+			version: 0,
+			errIs:   ErrUnsupportedModelVersion,
+		},
+		{
+			name:    "newer than this build",
+			version: ModelVersion + 1,
+			errIs:   ErrUnsupportedModelVersion,
+		},
+		{
+			name:    "migrated",
+			version: 0,
+			migration: &Migration{
+				From:        0,
+				Description: "document names moved to the comment",
+				Migrate: func(data []byte) ([]byte, error) {
+					d := &Document{}
+					if err := proto.Unmarshal(data, d); err != nil {
+						return nil, err
+					}
+					d.Metadata.Comment, d.Metadata.Name = d.Metadata.Name, ""
+					return proto.Marshal(d)
+				},
+			},
+			expected: &Metadata{Comment: "legacy"},
+		},
+		{
+			name:     "current version",
+			version:  ModelVersion,
+			expected: &Metadata{Name: "legacy"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.migration != nil {
+				RegisterMigration(tc.migration)
+				defer func() {
+					migrationsMu.Lock()
+					delete(migrations, tc.migration.From)
+					migrationsMu.Unlock()
+				}()
+			}
+
+			data, err := proto.Marshal(&Document{Metadata: &Metadata{Name: "legacy"}})
+			require.NoError(t, err)
+			tagged := protowire.AppendTag(nil, modelVersionField, protowire.VarintType)
+			tagged = protowire.AppendVarint(tagged, uint64(tc.version))
+
+			got := &Document{}
+			err = UnmarshalVersioned(append(tagged, data...), got)
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.True(t, proto.Equal(tc.expected, got.Metadata))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// platformKey returns the platform of a node as read from its purl
+// qualifiers (eg "amd64" or "arm/v7"). Returns an empty string if the node
+// has no arch qualifier.
+func platformKey(n *Node) string {
+	qualifiers := n.Purl().Qualifiers()
+	arch := qualifiers["arch"]
+	if arch == "" {
+		return ""
+	}
+	if variant := qualifiers["variant"]; variant != "" {
+		return arch + "/" + variant
+	}
+	return arch
+}
+
+// platformNodes returns the nodes describing the platform-specific images of
+// a multi-arch SBOM keyed by platform. Platform nodes are nodes with an arch
+// qualifier in their purl. They are looked up, in order of preference, among
+// the variants of the root elements, the root elements themselves and the
+// nodes related directly from the root elements.
+func (nl *NodeList) platformNodes() map[string][]*Node {
+	rootIdx := nl.indexRootElements()
+	variants := map[string]struct{}{}
+	children := map[string]struct{}{}
+	for _, e := range nl.Edges {
+		if e.Type == Edge_variant {
+			if slices.ContainsFunc(e.To, func(id string) bool {
+				_, ok := rootIdx[id]
+				return ok
+			}) {
+				variants[e.From] = struct{}{}
+			}
+			continue
+		}
+		if _, ok := rootIdx[e.From]; ok {
+			for _, to := range e.To {
+				children[to] = struct{}{}
+			}
+		}
+	}
+
+	for _, candidates := range []map[string]struct{}{variants, rootIdx, children} {
+		ret := map[string][]*Node{}
+		for _, n := range nl.Nodes {
+			if _, ok := candidates[n.Id]; !ok {
+				continue
+			}
+			if key := platformKey(n); key != "" {
+				ret[key] = append(ret[key], n)
+			}
+		}
+		if len(ret) > 0 {
+			return ret
+		}
+	}
+	return map[string][]*Node{}
+}
+
+// platformGraph returns a new NodeList with copies of the platform nodes as
+// root elements and all the nodes reachable from them. Traversal follows the
+// edges out of the nodes, except VARIANT_OF edges, and stops at the root
+// elements of the original NodeList.
+func (nl *NodeList) platformGraph(platformNodes []*Node) *NodeList {
+	rootIdx := nl.indexRootElements()
+	edgeIdx := nl.indexEdges()
+	nodeIdx := nl.indexNodes()
+
+	ret := &NodeList{
+		Nodes:        []*Node{},
+		Edges:        []*Edge{},
+		RootElements: []string{},
+	}
+	seen := map[string]struct{}{}
+	queue := []string{}
+	for _, n := range platformNodes {
+		ret.RootElements = append(ret.RootElements, n.Id)
+		seen[n.Id] = struct{}{}
+		queue = append(queue, n.Id)
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		ret.Nodes = append(ret.Nodes, nodeIdx[id].Copy())
+
+		for t, edges := range edgeIdx[id] {
+			if t == Edge_variant {
+				continue
+			}
+			for _, e := range edges {
+				ret.Edges = append(ret.Edges, e.Copy())
+				for _, to := range e.To {
+					if _, ok := seen[to]; ok {
+						continue
+					}
+					if _, ok := rootIdx[to]; ok {
+						continue
+					}
+					if _, ok := nodeIdx[to]; !ok {
+						continue
+					}
+					seen[to] = struct{}{}
+					queue = append(queue, to)
+				}
+			}
+		}
+	}
+
+	ret.cleanEdges()
+	return ret
+}
+
+// SplitByArch splits a multi-arch image SBOM into per-architecture documents.
+// The platform images are identified by the arch (and variant) qualifiers of
+// their package URLs. Each resulting document contains the platform image as
+// its root element and all of its descendants. The returned map is keyed by
+// platform (eg "amd64" or "arm/v7").
+func (d *Document) SplitByArch() (map[string]*Document, error) {
+	if d.GetNodeList() == nil {
+		return nil, errors.New("document has no nodes")
+	}
+
+	platforms := d.NodeList.platformNodes()
+	if len(platforms) == 0 {
+		return nil, errors.New("no platform-specific nodes found in document")
+	}
+
+	ret := map[string]*Document{}
+	for platform, nodes := range platforms {
+		doc := &Document{
+			Metadata: &Metadata{},
+			NodeList: &NodeList{},
+		}
+		if md := d.GetMetadata().Copy(); md != nil {
+			doc.Metadata = md
+		}
+		if doc.Metadata.Id != "" {
+			doc.Metadata.Id = fmt.Sprintf("%s-%s", doc.Metadata.Id, platform)
+		}
+
+		doc.NodeList = d.NodeList.platformGraph(nodes)
+		ret[platform] = doc
+	}
+	return ret, nil
+}
+
+// MergeArchDocuments combines per-architecture documents into an index-level
+// SBOM. The index node becomes a root element of the new document and the
+// root elements of each per-arch document are related to it with VARIANT_OF
+// edges.
+func MergeArchDocuments(index *Node, docs ...*Document) (*Document, error) {
+	if index == nil || index.Id == "" {
+		return nil, errors.New("index node must have an ID")
+	}
+
+	merged := NewDocument()
+	merged.NodeList.AddRootNode(index.Copy())
+
+	for i, d := range docs {
+		if d.GetNodeList() == nil || len(d.NodeList.RootElements) == 0 {
+			return nil, fmt.Errorf("document #%d has no root elements", i)
+		}
+		nl := d.NodeList.Copy()
+		for _, n := range nl.Nodes {
+			if merged.NodeList.GetNodeByID(n.Id) == nil {
+				merged.NodeList.AddNode(n)
+			}
+		}
+		merged.NodeList.MergeEdges(nl.Edges)
+		for _, id := range nl.RootElements {
+			if id == index.Id {
+				continue
+			}
+			if !slices.Contains(merged.NodeList.RootElements, id) {
+				merged.NodeList.RootElements = append(merged.NodeList.RootElements, id)
+			}
+			merged.NodeList.MergeEdges([]*Edge{{
+				Type: Edge_variant,
+				From: id,
+				To:   []string{index.Id},
+			}})
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,141 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testMultiArchDocument() *Document {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	return &Document{
+		Metadata: &Metadata{Id: "doc"},
+		NodeList: &NodeList{
+			Nodes: []*Node{
+				{Id: "index", Identifiers: purl("pkg:oci/image@sha256%3Aindex")},
+				{Id: "amd64", Identifiers: purl("pkg:oci/image@sha256%3A1?arch=amd64")},
+				{Id: "armv7", Identifiers: purl("pkg:oci/image@sha256%3A2?arch=arm&variant=v7")},
+				{Id: "libc-amd64", Identifiers: purl("pkg:apk/wolfi/glibc@2.39?arch=amd64")},
+				{Id: "libc-arm", Identifiers: purl("pkg:apk/wolfi/glibc@2.39?arch=arm")},
+				{Id: "ca-certs", Identifiers: purl("pkg:apk/wolfi/ca-certs@1")},
+			},
+			Edges: []*Edge{
+				{Type: Edge_contains, From: "index", To: []string{"amd64", "armv7"}},
+				{Type: Edge_contains, From: "amd64", To: []string{"libc-amd64", "ca-certs"}},
+				{Type: Edge_contains, From: "armv7", To: []string{"libc-arm", "ca-certs"}},
+			},
+			RootElements: []string{"index"},
+		},
+	}
+}
+
+func TestSplitByArch(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sut     *Document
+		mustErr bool
+		// platform has the nodes of each platform document, its root first
+		platform map[string][]string
+	}{
+		{
+			name: "platform documents",
+			sut:  testMultiArchDocument(),
+			platform: map[string][]string{
+				"amd64":  {"amd64", "libc-amd64", "ca-certs"},
+				"arm/v7": {"armv7", "libc-arm", "ca-certs"},
+			},
+		},
+		{
+			name:    "no platforms",
+			sut:     &Document{NodeList: &NodeList{Nodes: []*Node{{Id: "a"}}, RootElements: []string{"a"}}},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			docs, err := tc.sut.SplitByArch()
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, docs, len(tc.platform))
+			for platform, nodes := range tc.platform {
+				doc, ok := docs[platform]
+				require.True(t, ok)
+				require.Equal(t, "doc-"+platform, doc.Metadata.Id)
+				require.Equal(t, nodes[:1], doc.NodeList.RootElements)
+				require.ElementsMatch(t, nodes, ids(doc.NodeList.Nodes))
+			}
+		})
+	}
+}
+
+func TestMergeArchDocuments(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		index     *Node
+		platforms []string
+		extra     *Document
+		mustErr   bool
+		roots     []string
+	}{
+		{
+			name:      "merge",
+			index:     &Node{Id: "new-index", Name: "image"},
+			platforms: []string{"amd64", "arm/v7"},
+			roots:     []string{"new-index", "amd64", "armv7"},
+		},
+		{
+			name:      "single platform",
+			index:     &Node{Id: "new-index", Name: "image"},
+			platforms: []string{"amd64"},
+			roots:     []string{"new-index", "amd64"},
+		},
+		{
+			name:      "no index",
+			platforms: []string{"amd64"},
+			mustErr:   true,
+		},
+		{
+			name:    "document without root",
+			index:   &Node{Id: "new-index", Name: "image"},
+			extra:   NewDocument(),
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			split, err := testMultiArchDocument().SplitByArch()
+			require.NoError(t, err)
+			docs := []*Document{}
+			for _, p := range tc.platforms {
+				docs = append(docs, split[p])
+			}
+			if tc.extra != nil {
+				docs = append(docs, tc.extra)
+			}
+
+			merged, err := MergeArchDocuments(tc.index, docs...)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.roots, merged.NodeList.RootElements)
+			for _, id := range tc.roots[1:] {
+				e := merged.NodeList.GetEdgeByType(id, Edge_variant)
+				require.NotNil(t, e)
+				require.Equal(t, []string{tc.index.Id}, e.To)
+			}
+
+			// Splitting the merged document yields the platform documents again
+			resplit, err := merged.SplitByArch()
+			require.NoError(t, err)
+			require.Len(t, resplit, len(tc.platforms))
+			for _, p := range tc.platforms {
+				require.ElementsMatch(t, ids(split[p].NodeList.Nodes), ids(resplit[p].NodeList.Nodes))
+			}
+		})
+	}
+}
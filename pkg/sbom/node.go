@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"maps"
+	"net/url"
 	"slices"
 	"sort"
 	"strings"
@@ -109,6 +110,12 @@ func (n *Node) Update(n2 *Node) {
 	if len(n2.Properties) > 0 {
 		n.Properties = n2.Properties
 	}
+	if n2.AiModel != nil {
+		n.AiModel = n2.AiModel
+	}
+	if n2.Dataset != nil {
+		n.Dataset = n2.Dataset
+	}
 }
 
 // Augment updates fields in n with data from n2 which is not already defined
@@ -189,9 +196,18 @@ func (n *Node) Augment(n2 *Node) {
 	if len(n.Properties) == 0 && len(n2.Properties) > 0 {
 		n.Properties = n2.Properties
 	}
+	if n.AiModel == nil && n2.AiModel != nil {
+		n.AiModel = n2.AiModel
+	}
+	if n.Dataset == nil && n2.Dataset != nil {
+		n.Dataset = n2.Dataset
+	}
 }
 
-// Copy returns a duplicate of the Node.
+// Copy returns a deep copy of the Node. The copy shares no maps, slices or
+// messages with the original, so either one can be modified without affecting
+// the other. Fields are copied explicitly instead of through proto.Clone which
+// relies on reflection and is considerably slower.
 func (n *Node) Copy() *Node {
 	no := &Node{
 		Id:                 n.Id,
@@ -207,7 +223,7 @@ func (n *Node) Copy() *Node {
 		Copyright:          n.Copyright,
 		Hashes:             maps.Clone(n.Hashes),
 		SourceInfo:         n.SourceInfo,
-		PrimaryPurpose:     n.PrimaryPurpose,
+		PrimaryPurpose:     slices.Clone(n.PrimaryPurpose),
 		Comment:            n.Comment,
 		Summary:            n.Summary,
 		Description:        n.Description,
@@ -220,6 +236,8 @@ func (n *Node) Copy() *Node {
 		ExternalReferences: []*ExternalReference{},
 		Identifiers:        maps.Clone(n.Identifiers),
 		FileTypes:          slices.Clone(n.FileTypes),
+		AiModel:            n.AiModel.Copy(),
+		Dataset:            n.Dataset.Copy(),
 	}
 
 	if n.ReleaseDate != nil {
@@ -259,8 +277,17 @@ func (n *Node) Equal(n2 *Node) bool {
 // flatString returns a serialized representation of the node as a string,
 // suitable for indexing or comparison of the contents of the current node.
 func (n *Node) flatString() string {
+	return n.flatStringExcluding(nil)
+}
+
+// flatStringExcluding returns the flat string of the node leaving out the
+// fields whose names are in the exclude set.
+func (n *Node) flatStringExcluding(exclude map[protoreflect.Name]struct{}) string {
 	pairs := []string{}
 	n.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if _, ok := exclude[fd.Name()]; ok {
+			return true
+		}
 		switch fd.FullName() {
 		case "protobom.protobom.Node.external_references":
 			for _, ex := range n.ExternalReferences {
@@ -307,6 +334,10 @@ func (n *Node) flatString() string {
 			for i, p := range n.Properties {
 				pairs = append(pairs, fmt.Sprintf("properties[%d]:%s", i, p.flatString()))
 			}
+		case "protobom.protobom.Node.ai_model":
+			pairs = append(pairs, "ai_model:"+n.AiModel.flatString())
+		case "protobom.protobom.Node.dataset":
+			pairs = append(pairs, "dataset:"+n.Dataset.flatString())
 		default:
 			pairs = append(pairs, string(fd.FullName())+":"+v.String())
 		}
@@ -361,6 +392,28 @@ func (n *Node) Checksum() string {
 // PackageURL represents a Package URL (PURL) for identifying and locating software packages.
 type PackageURL string
 
+// Qualifiers returns the qualifiers of the package URL as a map. Keys are
+// normalized to lowercase and values are percent-decoded.
+func (purl PackageURL) Qualifiers() map[string]string {
+	ret := map[string]string{}
+	_, qs, ok := strings.Cut(string(purl), "?")
+	if !ok {
+		return ret
+	}
+	qs, _, _ = strings.Cut(qs, "#")
+	for _, pair := range strings.Split(qs, "&") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || k == "" || v == "" {
+			continue
+		}
+		if dv, err := url.PathUnescape(v); err == nil {
+			v = dv
+		}
+		ret[strings.ToLower(k)] = v
+	}
+	return ret
+}
+
 // Purl returns the node's Package URL (PURL) as a string.
 // If the node is of type FILE empty PURL is returned.
 func (n *Node) Purl() PackageURL {
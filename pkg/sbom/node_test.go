@@ -291,6 +291,11 @@ func TestNodeCopy(t *testing.T) {
 				Email: "jane@doe.com",
 			},
 		},
+		AiModel: &AIModel{
+			TypeOfModel: []string{"transformer"},
+			Metrics:     map[string]string{"accuracy": "0.91"},
+		},
+		Dataset: &Dataset{KnownBias: []string{"english"}},
 	}
 
 	copied := original.Copy()
@@ -301,6 +306,9 @@ func TestNodeCopy(t *testing.T) {
 	original.Licenses[0] = "Licenses Copy Failed"
 	original.Hashes[int32(HashAlgorithm_SHA1)] = "Hashes Copy Failed"
 	original.FileTypes[0] = "FileTypes Copy Failed"
+	original.AiModel.TypeOfModel[0] = "TypeOfModel Copy Failed"
+	original.AiModel.Metrics["accuracy"] = "Metrics Copy Failed"
+	original.Dataset.KnownBias[0] = "KnownBias Copy Failed"
 
 	// The copied Node should reflect the original values, not the subsequent changes.
 	require.Equal(t, "John Doe", copied.Suppliers[0].Name)
@@ -308,6 +316,9 @@ func TestNodeCopy(t *testing.T) {
 	require.Equal(t, "Apache-2.0", copied.Licenses[0])
 	require.Equal(t, "f3ae11065cafc14e27a1410ae8be28e600bb8336", copied.Hashes[int32(HashAlgorithm_SHA1)])
 	require.Equal(t, "TEXT", copied.FileTypes[0])
+	require.Equal(t, "transformer", copied.AiModel.TypeOfModel[0])
+	require.Equal(t, "0.91", copied.AiModel.Metrics["accuracy"])
+	require.Equal(t, "english", copied.Dataset.KnownBias[0])
 }
 
 func TestNodeDescendants(t *testing.T) {
@@ -708,3 +719,21 @@ func TestMergeEdges(t *testing.T) {
 		})
 	}
 }
+
+func TestPackageURLQualifiers(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		purl     PackageURL
+		expected map[string]string
+	}{
+		{"qualifiers", "pkg:deb/debian/curl@8.0?arch=amd64&distro=debian-12", map[string]string{"arch": "amd64", "distro": "debian-12"}},
+		{"escaped value and subpath", "pkg:oci/image?Repository_URL=ghcr.io%2Fimage#sub", map[string]string{"repository_url": "ghcr.io/image"}},
+		{"no qualifiers", "pkg:npm/lib@1.0.0", map[string]string{}},
+		{"empty qualifiers", "pkg:npm/lib@1.0.0?empty=&=novalue&noequals", map[string]string{}},
+		{"empty purl", "", map[string]string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.purl.Qualifiers())
+		})
+	}
+}
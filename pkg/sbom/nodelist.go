@@ -3,10 +3,12 @@ package sbom
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"sort"
-	"strings"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/protobom/protobom/pkg/telemetry"
 )
 
 // This file adds a few methods to the NodeList type which
@@ -27,6 +29,9 @@ type hashIndex map[string][]*Node
 // purlIndex captures the SBOM nodelist ordered by package url
 type purlIndex map[PackageURL][]*Node
 
+// purlQualifierIndex indexes nodes by their purl qualifier keys and values
+type purlQualifierIndex map[string]map[string][]*Node
+
 //nolint:errname // cannot change this without compatibility break
 var ErrorMoreThanOneMatch = fmt.Errorf("more than one node matches")
 
@@ -108,55 +113,51 @@ func (nl *NodeList) indexNodesByPurl() map[PackageURL][]*Node {
 // cleanEdges is a utility function that removes broken
 // connection and orphaned edges
 func (nl *NodeList) cleanEdges() {
-	// Build a catalog of the elements ids
-	nodeIndex := nl.indexNodes()
-
-	// Add a seen cache to dedupe edges when
-	// cleaning them up
-	seenCache := map[string]*Edge{}
-	newTos := map[string]map[string]string{}
-
-	// Now list all edges and rebuild the list
-	for _, edge := range nl.Edges {
-		// If the from node is not in the index, skip it
-		if _, ok := nodeIndex[edge.From]; !ok {
-			continue
-		}
+	nl.NormalizeEdges()
+}
 
-		// Use a string key for a simpler datastruct
-		edgeKey := edge.From + "+++" + edge.Type.String()
-		if _, ok := newTos[edgeKey]; !ok {
-			newTos[edgeKey] = map[string]string{}
+// NormalizeEdges restores the invariants of the NodeList edges, useful
+// after modifying the Edges slice directly. Once normalized:
+//
+//   - There is at most one edge per source node and edge type. Edges
+//     sharing source and type are merged in the position of the first one.
+//   - The destinations of each edge are unique and sorted.
+//   - Edges only reference nodes in the NodeList. Destinations not found
+//     are removed, as are edges from unknown nodes or left without
+//     destinations.
+func (nl *NodeList) NormalizeEdges() {
+	nodes := nl.indexNodes()
+
+	merged := map[string]*Edge{}
+	edges := []*Edge{}
+	for _, e := range nl.Edges {
+		if _, ok := nodes[e.From]; !ok {
+			continue
 		}
 
-		// If we already saw an equivalent edge, reuse it
-		if _, ok := seenCache[edgeKey]; !ok {
-			seenCache[edgeKey] = &Edge{
-				Type: edge.Type,
-				From: edge.From,
-				To:   []string{},
-			}
+		key := e.From + "+++" + e.Type.String()
+		edge, ok := merged[key]
+		if !ok {
+			edge = &Edge{Type: e.Type, From: e.From, To: []string{}}
+			merged[key] = edge
+			edges = append(edges, edge)
 		}
 
-		for _, s := range edge.To {
-			if _, ok := nodeIndex[s]; !ok {
-				continue
+		for _, id := range e.To {
+			if _, ok := nodes[id]; ok {
+				edge.To = append(edge.To, id)
 			}
-			newTos[edgeKey][s] = s
 		}
 	}
 
-	newEdges := []*Edge{}
-	for f := range seenCache {
-		for s := range newTos[f] {
-			seenCache[f].To = append(seenCache[f].To, s)
-		}
-		if len(seenCache[f].To) > 0 {
-			newEdges = append(newEdges, seenCache[f])
+	nl.Edges = []*Edge{}
+	for _, e := range edges {
+		slices.Sort(e.To)
+		e.To = slices.Compact(e.To)
+		if len(e.To) > 0 {
+			nl.Edges = append(nl.Edges, e)
 		}
 	}
-
-	nl.Edges = newEdges
 }
 
 // AddEdge adds a new edge to the Node List.
@@ -216,27 +217,61 @@ func (nl *NodeList) AddNode(n *Node) {
 // Add combines the nodes and edges from NodeList (nl2) into the current NodeList (nl).
 // It modifies current NodeList (nl) by adding new roots, nodes and edges or updating existing ones.
 // It is the equivalent to the Union of both NodeLists, but it modifies the current NodeList (nl) in place.
-func (nl *NodeList) Add(nl2 *NodeList) {
+// Unlike Union, the nodes in both lists keep their existing values and only
+// their empty fields are filled with the incoming data. Pass
+// WithMergeOptions to change how nodes, edges and root elements are merged.
+// Pass WithIDConflictPolicy or WithIDConflictResolver to handle nodes with
+// the same ID describing different components. As with Union, when the
+// resolver returns IDConflictError the incoming node is renamed as with
+// IDConflictRenameIncoming. Use TryAdd to get the error instead.
+func (nl *NodeList) Add(nl2 *NodeList, opts ...NodeListOption) {
+	o := buildNodeListOptions(opts)
+	if resolver := o.idConflictResolver; resolver != nil {
+		o.idConflictResolver = func(existing, incoming *Node) IDConflictPolicy {
+			if p := resolver(existing, incoming); p != IDConflictError {
+				return p
+			}
+			return IDConflictRenameIncoming
+		}
+	}
+	// The resolver never returns IDConflictError, add can't fail
+	_ = nl.add(nl2, o) //nolint:errcheck
+}
+
+// TryAdd is Add returning an error wrapping ErrIDConflict when an ID
+// conflict resolves to IDConflictError. The NodeList is not modified.
+func (nl *NodeList) TryAdd(nl2 *NodeList, opts ...NodeListOption) error {
+	return nl.add(nl2, buildNodeListOptions(opts))
+}
+
+// add implements Add and TryAdd
+func (nl *NodeList) add(nl2 *NodeList, o *nodeListOptions) error {
+	if o.idConflictResolver != nil {
+		var err error
+		nl2, err = nl.resolveIDConflicts(nl2, o.idConflictResolver)
+		if err != nil {
+			return fmt.Errorf("resolving ID conflicts: %w", err)
+		}
+	}
+
 	existingNodes := nl.indexNodes()
 	for i := range nl2.Nodes {
 		if n, ok := existingNodes[nl2.Nodes[i].Id]; ok {
-			existingNodes[nl2.Nodes[i].Id].Augment(n)
+			o.merge.mergeNode(n, nl2.Nodes[i], FieldPrecedenceExisting)
 		} else {
 			nl.Nodes = append(nl.Nodes, nl2.Nodes[i])
 		}
 	}
 
 	// Merge the edges into the existing edge set
-	nl.MergeEdges(nl2.Edges)
+	edges, edges2 := o.merge.edgesToMerge(nl.Edges, nl2.Edges)
+	nl.Edges = edges
+	nl.MergeEdges(edges2)
 
-	rootElements := nl.indexRootElements()
-	for _, id := range nl2.RootElements {
-		if _, ok := rootElements[id]; !ok {
-			nl.RootElements = append(nl.RootElements, id)
-		}
-	}
+	nl.RootElements = o.merge.rootElements(nl.RootElements, nl2.RootElements)
 
 	nl.cleanEdges()
+	return nil
 }
 
 // RemoveNodes removes nodes with specified IDs from the NodeList.
@@ -303,13 +338,32 @@ func (nl *NodeList) Copy() *NodeList {
 // Intersect returns a new NodeList that represents the intersection
 // of nodes and their relationships between nl and nl2.
 // The resulting NodeList contains common nodes and edges copied from nl, and updates them with data from nl2.
-func (nl *NodeList) Intersect(nl2 *NodeList) *NodeList {
-	rootElements := nl.indexRootElements()
-	rootElements2 := nl2.indexRootElements()
+// The root elements of either list that are common nodes are kept.
+// Pass WithMergeOptions to change how nodes, edges and root elements are
+// merged, WithParallelism to process the node keyspace in concurrent shards,
+// WithIdentityDatabase to pair the nodes by their identity and WithContext
+// to trace the operation.
+func (nl *NodeList) Intersect(nl2 *NodeList, opts ...NodeListOption) *NodeList {
+	o := buildNodeListOptions(opts)
+	op := o.startOperation(telemetry.SpanIntersect, telemetry.Int("nodes", len(nl.Nodes)), telemetry.Int("nodes2", len(nl2.Nodes)))
+	defer op.End(nil)
+
+	if o.identities != nil {
+		nl2 = nl.alignIdentities(nl2, o.identities)
+	}
+	if o.parallelism > 1 {
+		return nl.shardedIntersect(nl2, o.parallelism, &o.merge)
+	}
+
+	rootElements := map[string]struct{}{}
+	for _, id := range o.merge.rootElements(nl.RootElements, nl2.RootElements) {
+		rootElements[id] = struct{}{}
+	}
+	edges, edges2 := o.merge.edgesToMerge(nl.Edges, nl2.Edges)
 
 	ret := &NodeList{
 		Nodes:        []*Node{},
-		Edges:        copyEdgeList(nl.Edges), // copied as they will be cleaned
+		Edges:        copyEdgeList(edges), // copied as they will be cleaned
 		RootElements: []string{},
 	}
 	var ni1, ni2 nodeIndex
@@ -323,19 +377,16 @@ func (nl *NodeList) Intersect(nl2 *NodeList) *NodeList {
 		}
 		// Clone the node
 		newnode := node.Copy()
-		newnode.Update(ni2[id])
+		o.merge.mergeNode(newnode, ni2[id], FieldPrecedenceIncoming)
 		ret.Nodes = append(ret.Nodes, newnode)
 
-		_, ok := rootElements[id]
-		_, ok2 := rootElements2[id]
-
-		if ok || ok2 {
+		if _, ok := rootElements[id]; ok {
 			ret.RootElements = append(ret.RootElements, id)
 		}
 	}
 
 	// Copy root elements
-	for _, e := range nl2.Edges {
+	for _, e := range edges2 {
 		existingEdge := ret.GetEdgeByType(e.From, e.Type)
 		if existingEdge == nil {
 			ret.Edges = append(ret.Edges, e.Copy())
@@ -363,11 +414,63 @@ func (nl *NodeList) Intersect(nl2 *NodeList) *NodeList {
 // Union returns a new NodeList representing the combination of nodes and their relationships
 // from nl and nl2.
 // The resulting NodeList contains common nodes and edges copied from nl, and updates them with data from nl2.
-func (nl *NodeList) Union(nl2 *NodeList) *NodeList {
+// The root elements of both lists are kept.
+// Pass WithMergeOptions to change how nodes, edges and root elements are
+// merged, WithParallelism to process the node keyspace in concurrent shards,
+// WithIdentityDatabase to pair the nodes by their identity, WithContext
+// to trace the operation and WithIDConflictResolver to handle nodes with the
+// same ID describing different components. Union can't return errors: when
+// the resolver returns IDConflictError the incoming node is renamed as with
+// IDConflictRenameIncoming. Use TryUnion to get the error instead.
+func (nl *NodeList) Union(nl2 *NodeList, opts ...NodeListOption) *NodeList {
+	o := buildNodeListOptions(opts)
+	op := o.startOperation(telemetry.SpanUnion, telemetry.Int("nodes", len(nl.Nodes)), telemetry.Int("nodes2", len(nl2.Nodes)))
+	defer op.End(nil)
+
+	if resolver := o.idConflictResolver; resolver != nil {
+		o.idConflictResolver = func(existing, incoming *Node) IDConflictPolicy {
+			if p := resolver(existing, incoming); p != IDConflictError {
+				return p
+			}
+			return IDConflictRenameIncoming
+		}
+	}
+	// The resolver never returns IDConflictError, union can't fail
+	ret, _ := nl.union(nl2, o)
+	return ret
+}
+
+// TryUnion is Union returning an error wrapping ErrIDConflict when an ID
+// conflict resolves to IDConflictError. The lists are not modified.
+func (nl *NodeList) TryUnion(nl2 *NodeList, opts ...NodeListOption) (ret *NodeList, err error) {
+	o := buildNodeListOptions(opts)
+	op := o.startOperation(telemetry.SpanUnion, telemetry.Int("nodes", len(nl.Nodes)), telemetry.Int("nodes2", len(nl2.Nodes)))
+	defer func() { op.End(err) }()
+
+	return nl.union(nl2, o)
+}
+
+// union implements Union and TryUnion
+func (nl *NodeList) union(nl2 *NodeList, o *nodeListOptions) (*NodeList, error) {
+	if o.identities != nil {
+		nl2 = nl.alignIdentities(nl2, o.identities)
+	}
+	if o.idConflictResolver != nil {
+		var err error
+		nl2, err = nl.resolveIDConflicts(nl2, o.idConflictResolver)
+		if err != nil {
+			return nil, fmt.Errorf("resolving ID conflicts: %w", err)
+		}
+	}
+	if o.parallelism > 1 {
+		return nl.shardedUnion(nl2, o.parallelism, &o.merge), nil
+	}
+
+	edges, edges2 := o.merge.edgesToMerge(nl.Edges, nl2.Edges)
 	ret := &NodeList{
 		Nodes:        []*Node{},
-		Edges:        copyEdgeList(nl.Edges),
-		RootElements: nl.RootElements,
+		Edges:        copyEdgeList(edges),
+		RootElements: o.merge.rootElements(nl.RootElements, nl2.RootElements),
 	}
 
 	// Copy all nodes from the original nodelist
@@ -379,14 +482,14 @@ func (nl *NodeList) Union(nl2 *NodeList) *NodeList {
 	nodeindex := ret.indexNodes()
 	for _, n := range nl2.Nodes {
 		if _, ok := nodeindex[n.Id]; ok {
-			nodeindex[n.Id].Update(n)
+			o.merge.mergeNode(nodeindex[n.Id], n, FieldPrecedenceIncoming)
 		} else {
 			ret.Nodes = append(ret.Nodes, n)
 		}
 	}
 
 	// Add or append all edges from nl2
-	for _, e := range nl2.Edges {
+	for _, e := range edges2 {
 		existingEdge := ret.GetEdgeByType(e.From, e.Type)
 		if existingEdge == nil {
 			ret.Edges = append(ret.Edges, e.Copy())
@@ -401,15 +504,7 @@ func (nl *NodeList) Union(nl2 *NodeList) *NodeList {
 
 	ret.cleanEdges()
 
-	// Copy all root nodes from nl2
-	rootNodes := ret.indexRootElements()
-	for _, rootEl := range nl2.RootElements {
-		if _, ok := rootNodes[rootEl]; !ok {
-			ret.RootElements = append(ret.RootElements, rootEl)
-		}
-	}
-
-	return ret
+	return ret, nil
 }
 
 // GetNodesByName returns a list of node with the specified name.
@@ -556,6 +651,7 @@ func (nl *NodeList) GetRootNodes() []*Node {
 }
 
 // Equal compares the current NodeList to another (n2) and returns true if they are identical.
+// Use EquivalentTo for comparisons that tolerate differences in selected fields.
 func (nl *NodeList) Equal(nl2 *NodeList) bool {
 	if nl2 == nil {
 		return false
@@ -709,51 +805,36 @@ func (nl *NodeList) RelateNodeListAtID(nl2 *NodeList, nodeID string, edgeType Ed
 
 // GetNodesByPurlType retrieves nodes with a specific Package URL type (purlType) from the current NodeList (nl).
 // Returns a new NodeList with matching nodes and their relationships.
-// If no nodes match, an empty NodeList is returned.
-func (nl *NodeList) GetNodesByPurlType(purlType string) *NodeList {
-	ret := &NodeList{}
-	if nl == nil {
-		return ret
-	}
-
-	for _, n := range nl.Nodes {
-		// I think the SPDX libraries have a bug where an extra slash is added when parsing purls
-		if strings.HasPrefix(string(n.Purl()), fmt.Sprintf("pkg:%s/", purlType)) ||
-			strings.HasPrefix(string(n.Purl()), fmt.Sprintf("pkg:/%s/", purlType)) {
-			ret.Nodes = append(ret.Nodes, n)
-		}
-	}
-
-	index := ret.indexNodes()
-	for _, e := range nl.Edges {
-		if _, ok := index[e.From]; ok {
-			ret.Edges = append(ret.Edges, e.Copy())
-		}
-	}
-
-	ret.reconnectOrphanNodes()
-	ret.cleanEdges()
-
-	return ret
+// If no nodes match, an empty NodeList is returned. The nodes left without
+// parents in the new list are added to its root elements unless a different
+// policy is set with WithOrphanPolicy.
+func (nl *NodeList) GetNodesByPurlType(purlType string, opts ...NodeListOption) *NodeList {
+	return nl.Query(ByPurlType(purlType), opts...)
 }
 
-// reconnectOrphanNodes cleans the nodelist graph structure by reconnecting all
-// orphaned nodes to the top of the nodelist
-func (nl *NodeList) reconnectOrphanNodes() {
-	edgeIndex := nl.indexEdges()
-	rootIndex := nl.indexRootElements()
-
-	for _, id := range nl.RootElements {
-		rootIndex[id] = struct{}{}
+// GetNodesByPurlQualifier returns a new NodeList with the nodes that have a
+// qualifier key with the specified value in their package URL (for example
+// arch=amd64 or distro=debian-12) and their relationships. If value is empty,
+// all nodes having the qualifier are returned regardless of its value. The
+// orphaned nodes in the result are handled as in GetNodesByPurlType.
+//
+// Each call indexes the NodeList, use a PurlQualifierIndex to run several
+// queries on the same nodes.
+func (nl *NodeList) GetNodesByPurlQualifier(key, value string, opts ...NodeListOption) *NodeList {
+	if nl == nil {
+		return &NodeList{}
 	}
+	return NewPurlQualifierIndex(nl).GetNodesByPurlQualifier(key, value, opts...)
+}
 
-	for _, n := range nl.Nodes {
-		if _, ok := edgeIndex[n.Id]; !ok {
-			if _, ok := rootIndex[n.Id]; !ok {
-				nl.RootElements = append(nl.RootElements, n.Id)
-			}
-		}
+// applyQueryOrphanPolicy applies the orphan policy to the result of a query.
+// As queries don't return errors, OrphanPolicyError leaves the orphans as is.
+func (nl *NodeList) applyQueryOrphanPolicy(policy OrphanPolicy) {
+	if policy == OrphanPolicyError {
+		return
 	}
+	// Errors can only come from unknown policies, those are ignored
+	_ = nl.ReconnectOrphans(policy) //nolint:errcheck
 }
 
 // NodeGraph retruns a new NodeList representing the full dependency
@@ -883,6 +964,8 @@ func (nl *NodeList) NodeSiblings(id string) *NodeList {
 // by id and returns a new node list with elements related at a maximal distance
 // of maxDepth levels. If the specified id is not found, the NodeList will be
 // empty. Traversing the graph will stop if any of the related nodes is a RootNode.
+// The descendants are related to the node with ancestor edges, use NodeClosure
+// to keep the original edges.
 func (nl *NodeList) NodeDescendants(id string, maxDepth int) *NodeList {
 	rootIdx := nl.indexRootElements()
 	edgeIdx := nl.indexEdges()
@@ -898,6 +981,8 @@ func (nl *NodeList) NodeDescendants(id string, maxDepth int) *NodeList {
 	}
 
 	descendants := nodeIndex{}
+	// order records the discovery order to keep the output deterministic
+	order := []*Node{}
 
 	var loopNodes []*Node
 	newLoopNodes := []*Node{}
@@ -916,6 +1001,7 @@ func (nl *NodeList) NodeDescendants(id string, maxDepth int) *NodeList {
 			}
 
 			descendants[n.Id] = n
+			order = append(order, n)
 
 			// If node has no relationships, we're done
 			if _, ok := edgeIdx[n.Id]; !ok {
@@ -945,7 +1031,7 @@ func (nl *NodeList) NodeDescendants(id string, maxDepth int) *NodeList {
 	}
 
 	// Assign found nodes to nodelist and connect them
-	for _, n := range descendants {
+	for _, n := range order {
 		if n.Id == id {
 			continue
 		}
@@ -955,3 +1041,36 @@ func (nl *NodeList) NodeDescendants(id string, maxDepth int) *NodeList {
 	}
 	return &nl2
 }
+
+// NodeClosure returns a new NodeList with the node specified by id and all the
+// nodes reachable from it, following the edges of the types or all edges if
+// none are set. The traversal stops at maxDepth levels from the node, a
+// negative maxDepth follows the edges to the end of the graph. The original
+// edges of the followed types among the returned nodes are preserved and the
+// node becomes the only root element. If the id is not found, the NodeList will be empty.
+//
+// The nodes are shared with the original NodeList, use Copy on the result to
+// extract an independent fragment.
+func (nl *NodeList) NodeClosure(id string, maxDepth int, types ...Edge_Type) *NodeList {
+	ret := &NodeList{Nodes: []*Node{}, Edges: []*Edge{}, RootElements: []string{}}
+	err := nl.WalkBreadthFirst(id, func(n *Node, depth int) error {
+		ret.Nodes = append(ret.Nodes, n)
+		if maxDepth >= 0 && depth >= maxDepth {
+			return ErrSkipChildren
+		}
+		return nil
+	}, types...)
+	if err != nil {
+		return &NodeList{}
+	}
+
+	ret.Edges = copyEdgeList(nl.Edges)
+	if len(types) > 0 {
+		ret.Edges = slices.DeleteFunc(ret.Edges, func(e *Edge) bool {
+			return !slices.Contains(types, e.Type)
+		})
+	}
+	ret.RootElements = []string{id}
+	ret.cleanEdges()
+	return ret
+}
@@ -197,6 +197,28 @@ func TestAdd(t *testing.T) {
 				},
 			},
 		},
+		// Existing nodes keep their data and get the missing fields from
+		// the incoming ones
+		{
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "test1", Name: "one", Version: "1.0"},
+				},
+			},
+			prepare: func(n *NodeList) {
+				n.Add(&NodeList{
+					Nodes: []*Node{
+						{Id: "test1", Name: "other", Version: "2.0", Description: "the first node"},
+					},
+				})
+			},
+			expect: &NodeList{
+				Nodes: []*Node{
+					{Id: "test1", Name: "one", Version: "1.0", Description: "the first node"},
+				},
+				Edges: []*Edge{},
+			},
+		},
 	} {
 		tc.prepare(tc.sut)
 		require.Equal(t, tc.sut, tc.expect)
@@ -1394,3 +1416,170 @@ func TestRelateNodeListAtId(t *testing.T) {
 		})
 	}
 }
+
+func TestGetNodesByPurlQualifier(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "image", Identifiers: purl("pkg:oci/image@sha256%3Aabc")},
+			{Id: "amd64", Identifiers: purl("pkg:oci/image@sha256%3A123?arch=amd64&os=linux")},
+			{Id: "arm64", Identifiers: purl("pkg:oci/image@sha256%3A456?ARCH=arm64&os=linux")},
+			{Id: "deb", Identifiers: purl("pkg:deb/debian/curl@8.0?arch=amd64&distro=debian-12#src")},
+			{Id: "file", Type: Node_FILE, Identifiers: purl("pkg:generic/file?arch=amd64")},
+		},
+		Edges: []*Edge{
+			{Type: Edge_contains, From: "image", To: []string{"amd64", "arm64"}},
+			{Type: Edge_contains, From: "amd64", To: []string{"deb"}},
+		},
+		RootElements: []string{"image"},
+	}
+
+	for name, tc := range map[string]struct {
+		key, value string
+		expected   []string
+	}{
+		"arch amd64":    {"arch", "amd64", []string{"amd64", "deb"}},
+		"key uppercase": {"ARCH", "arm64", []string{"arm64"}},
+		"distro":        {"distro", "debian-12", []string{"deb"}},
+		"any value":     {"os", "", []string{"amd64", "arm64"}},
+		"no match":      {"arch", "s390x", []string{}},
+		"no key":        {"repository_url", "", []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res := nl.GetNodesByPurlQualifier(tc.key, tc.value)
+			ids := []string{}
+			for _, n := range res.Nodes {
+				ids = append(ids, n.Id)
+			}
+			require.ElementsMatch(t, tc.expected, ids)
+		})
+	}
+
+	// Edges between the selected nodes are preserved
+	res := nl.GetNodesByPurlQualifier("arch", "amd64")
+	require.Len(t, res.Edges, 1)
+	require.Equal(t, "amd64", res.Edges[0].From)
+	require.Equal(t, []string{"deb"}, res.Edges[0].To)
+
+	// Only the nodes without parents are reconnected to the root
+	require.Equal(t, []string{"amd64"}, res.RootElements)
+	res = nl.GetNodesByPurlQualifier("arch", "amd64", WithOrphanPolicy(OrphanPolicyLeave))
+	require.Empty(t, res.RootElements)
+}
+
+func TestNormalizeEdges(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		edges    []*Edge
+		expected []*Edge
+	}{
+		{
+			name: "merged and sorted targets",
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"c", "b"}},
+				{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+				{Type: Edge_contains, From: "a", To: []string{"b", "c"}},
+			},
+			expected: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b", "c"}},
+				{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+			},
+		},
+		{
+			name: "dangling edges",
+			edges: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b", "missing"}},
+				{Type: Edge_contains, From: "missing", To: []string{"a"}},
+				{Type: Edge_contains, From: "b", To: []string{"missing"}},
+			},
+			expected: []*Edge{
+				{Type: Edge_contains, From: "a", To: []string{"b"}},
+			},
+		},
+		{
+			name: "empty edges",
+			edges: []*Edge{
+				{Type: Edge_dependsOn, From: "c", To: []string{}},
+			},
+			expected: []*Edge{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes:        []*Node{{Id: "a"}, {Id: "b"}, {Id: "c"}},
+				Edges:        tc.edges,
+				RootElements: []string{"a"},
+			}
+			nl.NormalizeEdges()
+			require.Equal(t, tc.expected, nl.Edges)
+		})
+	}
+}
+
+func TestNodeClosure(t *testing.T) {
+	nl := traversalTestNodeList()
+	nl.Nodes = append(nl.Nodes, &Node{Id: "other"})
+	nl.Edges = append(nl.Edges, &Edge{From: "other", Type: Edge_dependsOn, To: []string{"lib"}})
+
+	for name, tc := range map[string]struct {
+		id       string
+		maxDepth int
+		types    []Edge_Type
+		nodes    []string
+		edges    []*Edge
+	}{
+		"full closure": {
+			id: "app", maxDepth: -1,
+			nodes: []string{"app", "lib", "util", "mock", "base"},
+			edges: []*Edge{
+				{From: "app", Type: Edge_dependsOn, To: []string{"lib", "util"}},
+				{From: "lib", Type: Edge_dependsOn, To: []string{"base", "util"}},
+				{From: "app", Type: Edge_testDependency, To: []string{"mock"}},
+			},
+		},
+		"subtree": {
+			id: "lib", maxDepth: -1,
+			nodes: []string{"lib", "util", "base"},
+			edges: []*Edge{{From: "lib", Type: Edge_dependsOn, To: []string{"base", "util"}}},
+		},
+		"max depth": {
+			id: "app", maxDepth: 1,
+			nodes: []string{"app", "lib", "util", "mock"},
+			edges: []*Edge{
+				{From: "app", Type: Edge_dependsOn, To: []string{"lib", "util"}},
+				{From: "lib", Type: Edge_dependsOn, To: []string{"util"}},
+				{From: "app", Type: Edge_testDependency, To: []string{"mock"}},
+			},
+		},
+		"node only": {
+			id: "app", maxDepth: 0,
+			nodes: []string{"app"},
+			edges: []*Edge{},
+		},
+		"edge types": {
+			id: "app", maxDepth: -1, types: []Edge_Type{Edge_testDependency},
+			nodes: []string{"app", "mock"},
+			edges: []*Edge{{From: "app", Type: Edge_testDependency, To: []string{"mock"}}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			res := nl.NodeClosure(tc.id, tc.maxDepth, tc.types...)
+			require.Equal(t, tc.nodes, nodeIDs(res.Nodes))
+			require.Equal(t, tc.edges, res.Edges)
+			require.Equal(t, []string{tc.id}, res.RootElements)
+		})
+	}
+
+	require.Empty(t, nl.NodeClosure("missing", -1).Nodes)
+
+	// Edges of other types between the returned nodes are dropped
+	nl.Edges = append(nl.Edges, &Edge{From: "util", Type: Edge_devDependency, To: []string{"base"}})
+	res := nl.NodeClosure("app", -1, Edge_dependsOn)
+	require.Equal(t, []string{"app", "lib", "util", "base"}, nodeIDs(res.Nodes))
+	require.Equal(t, []*Edge{
+		{From: "app", Type: Edge_dependsOn, To: []string{"lib", "util"}},
+		{From: "lib", Type: Edge_dependsOn, To: []string{"base", "util"}},
+	}, res.Edges)
+}
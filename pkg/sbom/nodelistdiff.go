@@ -0,0 +1,464 @@
+package sbom
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NodeListDiff captures the node and edge changes between two NodeLists
+type NodeListDiff struct {
+	// Added are the nodes found only in the second list
+	Added []*Node
+	// Removed are the nodes found only in the first list
+	Removed []*Node
+	// Changed are the nodes present in both lists with different data
+	Changed []*NodeChange
+	// AddedEdges are the relationships found only in the second list. The
+	// targets added to a node with the same edge type are grouped in one
+	// edge.
+	AddedEdges []*Edge
+	// RemovedEdges are the relationships found only in the first list,
+	// grouped as the added edges.
+	RemovedEdges []*Edge
+}
+
+// NodeChange is a node whose data changed between two NodeLists
+type NodeChange struct {
+	Before *Node
+	After  *Node
+	Fields []*FieldChange
+}
+
+// FieldChange records the values removed from and added to a node field.
+// Scalar fields have at most one value on each side, repeated and map
+// fields list the entries that changed.
+type FieldChange struct {
+	Field   string
+	Removed []string
+	Added   []string
+}
+
+// DiffFormat is the output format of a rendered NodeListDiff
+type DiffFormat string
+
+const (
+	// DiffFormatText renders the diff as plain text
+	DiffFormatText DiffFormat = "text"
+	// DiffFormatTerminal renders the diff as text colored with ANSI escapes
+	DiffFormatTerminal DiffFormat = "terminal"
+	// DiffFormatMarkdown renders the diff as markdown, suitable for bots
+	// posting comments to issues or pull requests
+	DiffFormatMarkdown DiffFormat = "markdown"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// Diff compares the nodes in the NodeList with those in nl2, matching
+// them by ID, and returns the nodes added, removed and changed in nl2.
+// Edges are compared by their source, type and target, the diff lists the
+// relationships added and removed in nl2.
+//
+// Changes in the fields ignored with WithIgnoredFields are not reported.
+// If the node IDs are ignored, nodes removed and added with the same data
+// are considered the same node and left out of the diff, their edges are
+// compared with the node renamed.
+func (nl *NodeList) Diff(nl2 *NodeList, opts ...NodeListOption) *NodeListDiff {
+	o := buildNodeListOptions(opts)
+	ret := &NodeListDiff{
+		Added:        []*Node{},
+		Removed:      []*Node{},
+		Changed:      []*NodeChange{},
+		AddedEdges:   []*Edge{},
+		RemovedEdges: []*Edge{},
+	}
+
+	index := nl.indexNodes()
+	index2 := nl2.indexNodes()
+
+	for _, n := range nl2.GetNodes() {
+		before, ok := index[n.Id]
+		if !ok {
+			ret.Added = append(ret.Added, n)
+			continue
+		}
+		if fields := diffNodeFields(before, n, o.ignoredFields); len(fields) > 0 {
+			ret.Changed = append(ret.Changed, &NodeChange{Before: before, After: n, Fields: fields})
+		}
+	}
+
+	for _, n := range nl.GetNodes() {
+		if _, ok := index2[n.Id]; !ok {
+			ret.Removed = append(ret.Removed, n)
+		}
+	}
+
+	renames := map[string]string{}
+	if _, ok := o.ignoredFields["id"]; ok {
+		renames = ret.dropRenamed(o.ignoredFields)
+	}
+
+	edges := edgeTargets(nl.GetEdges(), renames)
+	edges2 := edgeTargets(nl2.GetEdges(), nil)
+	ret.AddedEdges = diffEdges(nl2.GetEdges(), nil, edges)
+	ret.RemovedEdges = diffEdges(nl.GetEdges(), renames, edges2)
+
+	return ret
+}
+
+// edgeKey identifies the edges of a type from a node
+type edgeKey struct {
+	from string
+	t    Edge_Type
+}
+
+// edgeTargets indexes the targets of the edges, with the node IDs
+// replaced as mapped in renames
+func edgeTargets(edges []*Edge, renames map[string]string) map[edgeKey]map[string]struct{} {
+	ret := map[edgeKey]map[string]struct{}{}
+	for _, e := range edges {
+		key := edgeKey{cmp.Or(renames[e.From], e.From), e.Type}
+		if _, ok := ret[key]; !ok {
+			ret[key] = map[string]struct{}{}
+		}
+		for _, id := range e.To {
+			ret[key][cmp.Or(renames[id], id)] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// diffEdges returns the relationships in edges not found in the other
+// index, grouped by source and type in the order they appear
+func diffEdges(edges []*Edge, renames map[string]string, other map[edgeKey]map[string]struct{}) []*Edge {
+	ret := []*Edge{}
+	grouped := map[edgeKey]*Edge{}
+	for _, e := range edges {
+		key := edgeKey{cmp.Or(renames[e.From], e.From), e.Type}
+		for _, id := range e.To {
+			if _, ok := other[key][cmp.Or(renames[id], id)]; ok {
+				continue
+			}
+			if _, ok := grouped[key]; !ok {
+				grouped[key] = &Edge{Type: e.Type, From: e.From, To: []string{}}
+				ret = append(ret, grouped[key])
+			}
+			if !slices.Contains(grouped[key].To, id) {
+				grouped[key].To = append(grouped[key].To, id)
+			}
+		}
+	}
+	return ret
+}
+
+// dropRenamed removes from the diff the nodes added with the same data as
+// a removed node, except the fields in the mask. It returns the new IDs of
+// the dropped nodes indexed by their former ID.
+func (d *NodeListDiff) dropRenamed(ignore fieldMaskTree) map[string]string {
+	removed := map[string][]int{}
+	for i, n := range d.Removed {
+		key := n.semanticString(ignore)
+		removed[key] = append(removed[key], i)
+	}
+	dropped := map[int]string{}
+	d.Added = slices.DeleteFunc(d.Added, func(n *Node) bool {
+		key := n.semanticString(ignore)
+		if len(removed[key]) == 0 {
+			return false
+		}
+		dropped[removed[key][0]] = n.Id
+		removed[key] = removed[key][1:]
+		return true
+	})
+	renames := map[string]string{}
+	ret := []*Node{}
+	for i, n := range d.Removed {
+		if newID, ok := dropped[i]; ok {
+			renames[n.Id] = newID
+			continue
+		}
+		ret = append(ret, n)
+	}
+	d.Removed = ret
+	return renames
+}
+
+// IsEmpty returns true if the diff has no changes
+func (d *NodeListDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0 &&
+		len(d.AddedEdges) == 0 && len(d.RemovedEdges) == 0
+}
+
+// diffNodeFields returns the changes in the fields of two nodes, leaving out
+// the fields in the ignore mask
+func diffNodeFields(n1, n2 *Node, ignore fieldMaskTree) []*FieldChange {
+	ret := []*FieldChange{}
+	if len(ignore) > 0 {
+		if n1.semanticString(ignore) == n2.semanticString(ignore) {
+			return ret
+		}
+		n1, _ = proto.Clone(n1).(*Node)
+		n2, _ = proto.Clone(n2).(*Node)
+		clearFields(n1.ProtoReflect(), ignore)
+		clearFields(n2.ProtoReflect(), ignore)
+	}
+	m1 := n1.ProtoReflect()
+	m2 := n2.ProtoReflect()
+	fields := m1.Descriptor().Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		if fd.Name() == "id" {
+			continue
+		}
+		v1 := diffFieldValues(m1, fd)
+		v2 := diffFieldValues(m2, fd)
+		removed := []string{}
+		for _, v := range v1 {
+			if !slices.Contains(v2, v) {
+				removed = append(removed, v)
+			}
+		}
+		added := []string{}
+		for _, v := range v2 {
+			if !slices.Contains(v1, v) {
+				added = append(added, v)
+			}
+		}
+		if len(removed) > 0 || len(added) > 0 {
+			ret = append(ret, &FieldChange{Field: string(fd.Name()), Removed: removed, Added: added})
+		}
+	}
+	return ret
+}
+
+// diffFieldValues returns the values of a field formatted for display.
+// The values of repeated and map fields are sorted.
+func diffFieldValues(m protoreflect.Message, fd protoreflect.FieldDescriptor) []string {
+	// Singular enums are always displayed, their zero value is meaningful
+	if !m.Has(fd) && (fd.Kind() != protoreflect.EnumKind || fd.IsList()) {
+		return nil
+	}
+	v := m.Get(fd)
+
+	ret := []string{}
+	switch {
+	case fd.IsMap():
+		v.Map().Range(func(k protoreflect.MapKey, mv protoreflect.Value) bool {
+			ret = append(ret, formatDiffMapKey(fd, k)+": "+formatDiffValue(fd.MapValue(), mv))
+			return true
+		})
+	case fd.IsList():
+		for i := range v.List().Len() {
+			ret = append(ret, formatDiffValue(fd, v.List().Get(i)))
+		}
+	default:
+		return []string{formatDiffValue(fd, v)}
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+// formatDiffMapKey returns the name of the identifier and hash keys
+func formatDiffMapKey(fd protoreflect.FieldDescriptor, k protoreflect.MapKey) string {
+	switch fd.Name() {
+	case "identifiers":
+		return SoftwareIdentifierType(k.Int()).String() //nolint:gosec
+	case "hashes":
+		return HashAlgorithm(k.Int()).String() //nolint:gosec
+	default:
+		return k.String()
+	}
+}
+
+// formatDiffValue returns a readable representation of a field value
+func formatDiffValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) string {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByNumber(v.Enum()); ev != nil {
+			return string(ev.Name())
+		}
+		return fmt.Sprint(v.Enum())
+	case protoreflect.MessageKind:
+		switch msg := v.Message().Interface().(type) {
+		case *timestamppb.Timestamp:
+			return msg.AsTime().UTC().Format(time.RFC3339)
+		case *Person:
+			return msg.ToSPDX2ClientString()
+		case *ExternalReference:
+			return fmt.Sprintf("%s %s", msg.Type, msg.Url)
+		case *Property:
+			return fmt.Sprintf("%s=%s", msg.Name, msg.Data)
+		}
+	}
+	return v.String()
+}
+
+// String returns the diff rendered as plain text
+func (d *NodeListDiff) String() string {
+	s, _ := d.Render(DiffFormatText) //nolint:errcheck // text is always supported
+	return s
+}
+
+// Render returns the diff as a readable report in the specified format.
+// The report lists the added and removed nodes and, for the changed nodes,
+// the values of each field that changed.
+func (d *NodeListDiff) Render(format DiffFormat) (string, error) {
+	var sb strings.Builder
+	switch format {
+	case DiffFormatText:
+		d.renderText(&sb, false)
+	case DiffFormatTerminal:
+		d.renderText(&sb, true)
+	case DiffFormatMarkdown:
+		d.renderMarkdown(&sb)
+	default:
+		return "", fmt.Errorf("unsupported diff format %q", format)
+	}
+	return sb.String(), nil
+}
+
+// diffNodeLabel returns the name used to identify a node in reports
+func diffNodeLabel(n *Node) string {
+	label := n.Name
+	if label == "" {
+		return n.Id
+	}
+	if n.Version != "" {
+		label += "@" + n.Version
+	}
+	return label
+}
+
+// renderText writes the diff as text, optionally with ANSI colors
+func (d *NodeListDiff) renderText(sb *strings.Builder, color bool) {
+	paint := func(c, s string) string {
+		if !color {
+			return s
+		}
+		return c + s + ansiReset
+	}
+
+	if d.IsEmpty() {
+		sb.WriteString("No changes\n")
+		return
+	}
+
+	if len(d.Added) > 0 {
+		fmt.Fprintf(sb, "Added nodes (%d):\n", len(d.Added))
+		for _, n := range d.Added {
+			sb.WriteString(paint(ansiGreen, fmt.Sprintf("  + %s (%s)", diffNodeLabel(n), n.Id)) + "\n")
+		}
+	}
+
+	if len(d.Removed) > 0 {
+		fmt.Fprintf(sb, "Removed nodes (%d):\n", len(d.Removed))
+		for _, n := range d.Removed {
+			sb.WriteString(paint(ansiRed, fmt.Sprintf("  - %s (%s)", diffNodeLabel(n), n.Id)) + "\n")
+		}
+	}
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintf(sb, "Changed nodes (%d):\n", len(d.Changed))
+		for _, c := range d.Changed {
+			sb.WriteString(paint(ansiYellow, fmt.Sprintf("  ~ %s (%s)", diffNodeLabel(c.After), c.After.Id)) + "\n")
+			for _, f := range c.Fields {
+				for _, v := range f.Removed {
+					sb.WriteString(paint(ansiRed, fmt.Sprintf("      - %s: %s", f.Field, v)) + "\n")
+				}
+				for _, v := range f.Added {
+					sb.WriteString(paint(ansiGreen, fmt.Sprintf("      + %s: %s", f.Field, v)) + "\n")
+				}
+			}
+		}
+	}
+
+	if len(d.AddedEdges) > 0 {
+		fmt.Fprintf(sb, "Added edges (%d):\n", len(d.AddedEdges))
+		for _, e := range d.AddedEdges {
+			sb.WriteString(paint(ansiGreen, "  + "+diffEdgeLabel(e)) + "\n")
+		}
+	}
+
+	if len(d.RemovedEdges) > 0 {
+		fmt.Fprintf(sb, "Removed edges (%d):\n", len(d.RemovedEdges))
+		for _, e := range d.RemovedEdges {
+			sb.WriteString(paint(ansiRed, "  - "+diffEdgeLabel(e)) + "\n")
+		}
+	}
+}
+
+// diffEdgeLabel returns the description of an edge used in reports
+func diffEdgeLabel(e *Edge) string {
+	return fmt.Sprintf("%s %s %s", e.From, e.Type, strings.Join(e.To, ", "))
+}
+
+// markdownEscaper escapes the characters with meaning in markdown tables
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ", "\r", "")
+
+// renderMarkdown writes the diff as markdown
+func (d *NodeListDiff) renderMarkdown(sb *strings.Builder) {
+	if d.IsEmpty() {
+		sb.WriteString("No changes\n")
+		return
+	}
+
+	if len(d.Added) > 0 {
+		fmt.Fprintf(sb, "### Added (%d)\n\n", len(d.Added))
+		for _, n := range d.Added {
+			fmt.Fprintf(sb, "- `%s` (%s)\n", diffNodeLabel(n), n.Id)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(d.Removed) > 0 {
+		fmt.Fprintf(sb, "### Removed (%d)\n\n", len(d.Removed))
+		for _, n := range d.Removed {
+			fmt.Fprintf(sb, "- `%s` (%s)\n", diffNodeLabel(n), n.Id)
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(d.Changed) > 0 {
+		fmt.Fprintf(sb, "### Changed (%d)\n\n", len(d.Changed))
+		for _, c := range d.Changed {
+			fmt.Fprintf(sb, "#### `%s` (%s)\n\n", diffNodeLabel(c.After), c.After.Id)
+			sb.WriteString("| Field | Removed | Added |\n")
+			sb.WriteString("| --- | --- | --- |\n")
+			for _, f := range c.Fields {
+				fmt.Fprintf(sb, "| %s | %s | %s |\n", f.Field,
+					markdownEscaper.Replace(strings.Join(f.Removed, ", ")),
+					markdownEscaper.Replace(strings.Join(f.Added, ", ")),
+				)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	for _, section := range []struct {
+		title string
+		edges []*Edge
+	}{
+		{"Added edges", d.AddedEdges},
+		{"Removed edges", d.RemovedEdges},
+	} {
+		if len(section.edges) == 0 {
+			continue
+		}
+		fmt.Fprintf(sb, "### %s (%d)\n\n", section.title, len(section.edges))
+		for _, e := range section.edges {
+			fmt.Fprintf(sb, "- `%s`\n", diffEdgeLabel(e))
+		}
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,223 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeListDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		sut     *NodeList
+		other   *NodeList
+		added   []string
+		removed []string
+		changed map[string][]*FieldChange
+		str     string
+	}{
+		{
+			name: "added, removed and changed nodes",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", Name: "app", Version: "1.0"},
+					{Id: "old", Name: "old-lib", Version: "0.1"},
+					{Id: "lib", Name: "lib", Version: "1.0", Licenses: []string{"MIT"}, Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "abc"}},
+				},
+				RootElements: []string{"app"},
+			},
+			other: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", Name: "app", Version: "1.0"},
+					{Id: "new", Name: "new-lib", Version: "3.0"},
+					{
+						Id: "lib", Type: Node_FILE, Name: "lib", Version: "2.0", Licenses: []string{"MIT", "Apache-2.0"},
+						Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "def"},
+					},
+				},
+				RootElements: []string{"app"},
+			},
+			added:   []string{"new"},
+			removed: []string{"old"},
+			changed: map[string][]*FieldChange{
+				"lib": {
+					{Field: "type", Removed: []string{"PACKAGE"}, Added: []string{"FILE"}},
+					{Field: "version", Removed: []string{"1.0"}, Added: []string{"2.0"}},
+					{Field: "licenses", Removed: []string{}, Added: []string{"Apache-2.0"}},
+					{Field: "hashes", Removed: []string{"SHA256: abc"}, Added: []string{"SHA256: def"}},
+				},
+			},
+			str: `Added nodes (1):
+  + new-lib@3.0 (new)
+Removed nodes (1):
+  - old-lib@0.1 (old)
+Changed nodes (1):
+  ~ lib@2.0 (lib)
+      - type: PACKAGE
+      + type: FILE
+      - version: 1.0
+      + version: 2.0
+      + licenses: Apache-2.0
+      - hashes: SHA256: abc
+      + hashes: SHA256: def
+`,
+		},
+		{
+			name:  "equal",
+			sut:   &NodeList{Nodes: []*Node{{Id: "app", Name: "app", Version: "1.0"}}, RootElements: []string{"app"}},
+			other: &NodeList{Nodes: []*Node{{Id: "app", Name: "app", Version: "1.0"}}, RootElements: []string{"app"}},
+			str:   "No changes\n",
+		},
+		{
+			name:  "empty",
+			sut:   NewNodeList(),
+			other: NewNodeList(),
+			str:   "No changes\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d := tc.sut.Diff(tc.other)
+			require.Equal(t, len(tc.added)+len(tc.removed)+len(tc.changed) == 0, d.IsEmpty())
+			added := []string{}
+			for _, n := range d.Added {
+				added = append(added, n.Id)
+			}
+			require.ElementsMatch(t, tc.added, added)
+			removed := []string{}
+			for _, n := range d.Removed {
+				removed = append(removed, n.Id)
+			}
+			require.ElementsMatch(t, tc.removed, removed)
+			require.Len(t, d.Changed, len(tc.changed))
+			for _, c := range d.Changed {
+				require.Equal(t, tc.changed[c.After.Id], c.Fields)
+			}
+			require.Equal(t, tc.str, d.String())
+		})
+	}
+}
+
+func TestNodeListDiffRender(t *testing.T) {
+	nl1 := &NodeList{Nodes: []*Node{{Id: "app", Name: "app", Version: "1.0"}, {Id: "lib", Name: "lib", Version: "1.0"}}}
+	nl2 := &NodeList{Nodes: []*Node{
+		{Id: "app", Name: "app", Version: "1.0"}, {Id: "lib", Name: "lib", Version: "2.0"}, {Id: "new", Name: "new-lib", Version: "3.0"},
+	}}
+	d := nl1.Diff(nl2)
+
+	for _, tc := range []struct {
+		name     string
+		format   DiffFormat
+		contains []string
+		mustErr  bool
+	}{
+		{name: "text", format: DiffFormatText, contains: []string{"  + new-lib@3.0 (new)\n"}},
+		{name: "terminal", format: DiffFormatTerminal, contains: []string{ansiGreen + "  + new-lib@3.0 (new)" + ansiReset}},
+		{name: "markdown", format: DiffFormatMarkdown, contains: []string{"### Added (1)\n\n- `new-lib@3.0` (new)\n", "| version | 1.0 | 2.0 |\n"}},
+		{name: "unknown format", format: DiffFormat("html"), mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := d.Render(tc.format)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tc.contains {
+				require.Contains(t, s, want)
+			}
+		})
+	}
+}
+
+func TestNodeListDiffEdges(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		other    *NodeList
+		opts     []NodeListOption
+		nodes    int
+		added    []*Edge
+		removed  []*Edge
+		text     string
+		markdown string
+	}{
+		{
+			name: "added and removed edges",
+			other: &NodeList{
+				Nodes: []*Node{{Id: "app"}, {Id: "lib"}, {Id: "new", Name: "new"}, {Id: "gen-2", Name: "util"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"lib", "gen-2"}},
+					{Type: Edge_dependsOn, From: "app", To: []string{"new"}},
+					{Type: Edge_devDependency, From: "app", To: []string{"lib"}},
+				},
+			},
+			nodes: 2,
+			added: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"gen-2", "new"}},
+				{Type: Edge_devDependency, From: "app", To: []string{"lib"}},
+			},
+			removed: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"old", "gen-1"}},
+				{Type: Edge_contains, From: "app", To: []string{"lib"}},
+			},
+			text: "Added edges (2):\n  + app dependsOn gen-2, new\n  + app devDependency lib\n" +
+				"Removed edges (2):\n  - app dependsOn old, gen-1\n  - app contains lib\n",
+			markdown: "### Removed edges (2)\n\n- `app dependsOn old, gen-1`\n",
+		},
+		{
+			// Edges to renamed nodes are matched when the IDs are ignored
+			name: "ignored ids",
+			other: &NodeList{
+				Nodes: []*Node{{Id: "app"}, {Id: "lib"}, {Id: "new", Name: "new"}, {Id: "gen-2", Name: "util"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"lib", "gen-2"}},
+					{Type: Edge_dependsOn, From: "app", To: []string{"new"}},
+					{Type: Edge_devDependency, From: "app", To: []string{"lib"}},
+				},
+			},
+			opts:  []NodeListOption{WithIgnoredFields(EphemeralNodeFields)},
+			nodes: 1,
+			added: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"new"}},
+				{Type: Edge_devDependency, From: "app", To: []string{"lib"}},
+			},
+			removed: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"old"}},
+				{Type: Edge_contains, From: "app", To: []string{"lib"}},
+			},
+		},
+		{
+			name: "only edges changed",
+			other: &NodeList{
+				Nodes: []*Node{{Id: "app"}, {Id: "lib"}, {Id: "old", Name: "old"}, {Id: "gen-1", Name: "util"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"lib", "old", "gen-1"}},
+				},
+			},
+			added: []*Edge{},
+			removed: []*Edge{
+				{Type: Edge_contains, From: "app", To: []string{"lib"}},
+			},
+			text: "Removed edges (1):\n  - app contains lib\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes: []*Node{{Id: "app"}, {Id: "lib"}, {Id: "old", Name: "old"}, {Id: "gen-1", Name: "util"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"lib", "old", "gen-1"}},
+					{Type: Edge_contains, From: "app", To: []string{"lib"}},
+				},
+			}
+
+			d := nl.Diff(tc.other, tc.opts...)
+			require.False(t, d.IsEmpty())
+			require.Len(t, d.Added, tc.nodes)
+			require.Equal(t, tc.added, d.AddedEdges)
+			require.Equal(t, tc.removed, d.RemovedEdges)
+			require.Contains(t, d.String(), tc.text)
+			s, err := d.Render(DiffFormatMarkdown)
+			require.NoError(t, err)
+			require.Contains(t, s, tc.markdown)
+		})
+	}
+}
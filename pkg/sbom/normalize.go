@@ -0,0 +1,157 @@
+package sbom
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NormalizeOptions controls the fixes applied by Document.Normalize
+type NormalizeOptions struct {
+	// RepairTimestamps replaces missing, zero and future document creation
+	// dates with the current time and removes invalid node dates.
+	RepairTimestamps bool
+
+	// SanitizeStrings replaces invalid UTF-8 sequences in all the document
+	// strings and removes byte order marks and control characters other
+	// than tabs and line breaks.
+	SanitizeStrings bool
+
+	// DropInvalidHashes removes the hashes whose values don't match the
+	// length or encoding of their algorithm.
+	DropInvalidHashes bool
+
+	// Vendors maps the node suppliers to the canonical organizations in
+	// the registry when set. See NodeList.NormalizeSuppliers.
+	Vendors *VendorRegistry
+
+	// Clock returns the current time used when repairing timestamps.
+	// Defaults to time.Now.
+	Clock func() time.Time
+
+	// ClockSkew is the tolerance allowed when comparing timestamps with
+	// the current time.
+	ClockSkew time.Duration
+}
+
+// DefaultNormalizeOptions is the set of options used when none are specified
+var DefaultNormalizeOptions = &NormalizeOptions{
+	RepairTimestamps: true,
+	SanitizeStrings:  true,
+	Clock:            time.Now,
+	ClockSkew:        5 * time.Minute,
+}
+
+// Normalize fixes common problems in the document data according to the
+// options. Use Validate to get a list of the problems before normalizing.
+func (d *Document) Normalize(opts *NormalizeOptions) {
+	if opts == nil {
+		opts = DefaultNormalizeOptions
+	}
+
+	if opts.RepairTimestamps {
+		d.repairTimestamps(opts)
+	}
+
+	if opts.SanitizeStrings {
+		sanitizeStrings(d.ProtoReflect())
+	}
+
+	if opts.DropInvalidHashes && d.GetNodeList() != nil {
+		d.NodeList.DropInvalidHashes()
+	}
+
+	if opts.Vendors != nil && d.GetNodeList() != nil {
+		d.NodeList.NormalizeSuppliers(opts.Vendors)
+	}
+}
+
+// sanitizeString returns s as valid UTF-8 without byte order marks and
+// control characters, except tabs and line breaks.
+func sanitizeString(s string) string {
+	if utf8.ValidString(s) && strings.IndexFunc(s, isUnwantedRune) == -1 {
+		return s
+	}
+	return strings.Map(func(r rune) rune {
+		if isUnwantedRune(r) {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(s, string(utf8.RuneError)))
+}
+
+// isUnwantedRune returns true for runes removed by sanitizeString
+func isUnwantedRune(r rune) bool {
+	if r == '\t' || r == '\n' || r == '\r' {
+		return false
+	}
+	return r == '\uFEFF' || unicode.IsControl(r)
+}
+
+// sanitizeStrings cleans all the string fields in msg and its submessages
+func sanitizeStrings(msg protoreflect.Message) {
+	type stringField struct {
+		fd protoreflect.FieldDescriptor
+		v  protoreflect.Value
+	}
+
+	// Collect the fields first, msg should not be mutated while ranging it
+	fields := []stringField{}
+	msg.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fields = append(fields, stringField{fd, v})
+		return true
+	})
+
+	for _, f := range fields {
+		switch {
+		case f.fd.IsMap():
+			if f.fd.MapValue().Kind() == protoreflect.StringKind {
+				m := f.v.Map()
+				m.Range(func(k protoreflect.MapKey, v protoreflect.Value) bool {
+					m.Set(k, protoreflect.ValueOfString(sanitizeString(v.String())))
+					return true
+				})
+			}
+		case f.fd.Kind() != protoreflect.StringKind:
+			forEachSubmessage(msg, f.fd, sanitizeStrings)
+		case f.fd.IsList():
+			list := f.v.List()
+			for i := range list.Len() {
+				list.Set(i, protoreflect.ValueOfString(sanitizeString(list.Get(i).String())))
+			}
+		default:
+			msg.Set(f.fd, protoreflect.ValueOfString(sanitizeString(f.v.String())))
+		}
+	}
+}
+
+// repairTimestamps fixes the timestamps flagged by validateTimestamps
+func (d *Document) repairTimestamps(opts *NormalizeOptions) {
+	now := time.Now()
+	if opts.Clock != nil {
+		now = opts.Clock()
+	}
+
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+	if timestampProblem(d.Metadata.Date, true, false, now, opts.ClockSkew) != "" {
+		d.Metadata.Date = timestamppb.New(now)
+	}
+
+	for _, n := range d.GetNodeList().GetNodes() {
+		if timestampProblem(n.ReleaseDate, false, false, now, opts.ClockSkew) != "" {
+			n.ReleaseDate = nil
+		}
+		if timestampProblem(n.BuildDate, false, false, now, opts.ClockSkew) != "" {
+			n.BuildDate = nil
+		}
+		if timestampProblem(n.ValidUntilDate, false, true, now, opts.ClockSkew) != "" {
+			n.ValidUntilDate = nil
+		}
+	}
+}
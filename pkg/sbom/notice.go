@@ -0,0 +1,227 @@
+package sbom
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/protobom/protobom/pkg/formats/spdx"
+)
+
+// NoticeFormat is the output format of an attribution notice
+type NoticeFormat string
+
+const (
+	NoticeFormatText     NoticeFormat = "text"
+	NoticeFormatHTML     NoticeFormat = "html"
+	NoticeFormatMarkdown NoticeFormat = "markdown"
+)
+
+// Notice is a NOTICE-style document that aggregates the legal attribution
+// data (copyright texts, attribution texts and licenses) found in the graph.
+type Notice struct {
+	// Entries has one entry per component with attribution data, sorted by
+	// name and version.
+	Entries []*NoticeEntry
+
+	// LicenseTexts maps license identifiers to their full text when known,
+	// for example from the document's custom licenses.
+	LicenseTexts map[string]string
+}
+
+// NoticeEntry captures the attribution data of a single component
+type NoticeEntry struct {
+	Name         string
+	Version      string
+	Copyrights   []string
+	Attributions []string
+	Licenses     []string
+}
+
+// LicenseIDs returns a sorted list of all licenses referenced in the notice.
+func (notice *Notice) LicenseIDs() []string {
+	ids := map[string]struct{}{}
+	for _, e := range notice.Entries {
+		for _, l := range e.Licenses {
+			ids[l] = struct{}{}
+		}
+	}
+	ret := []string{}
+	for id := range ids {
+		ret = append(ret, id)
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+// AttributionNotice aggregates the copyright, attribution and license data
+// of all nodes in the NodeList into a Notice. Nodes without any copyright,
+// attribution or license data are not included.
+func (nl *NodeList) AttributionNotice() *Notice {
+	notice := &Notice{
+		Entries:      []*NoticeEntry{},
+		LicenseTexts: map[string]string{},
+	}
+
+	for _, n := range nl.GetNodes() {
+		entry := &NoticeEntry{
+			Name:         n.Name,
+			Version:      n.Version,
+			Copyrights:   []string{},
+			Attributions: []string{},
+			Licenses:     []string{},
+		}
+
+		if c := strings.TrimSpace(n.Copyright); c != "" && c != spdx.NOASSERTION && c != spdx.NONE {
+			entry.Copyrights = append(entry.Copyrights, c)
+		}
+
+		for _, a := range n.Attribution {
+			if a = strings.TrimSpace(a); a != "" && !slices.Contains(entry.Attributions, a) {
+				entry.Attributions = append(entry.Attributions, a)
+			}
+		}
+
+		for _, l := range append(slices.Clone(n.Licenses), n.LicenseConcluded) {
+			if l = strings.TrimSpace(l); l != "" && l != spdx.NOASSERTION && l != spdx.NONE &&
+				!slices.Contains(entry.Licenses, l) {
+				entry.Licenses = append(entry.Licenses, l)
+			}
+		}
+
+		if len(entry.Copyrights) == 0 && len(entry.Attributions) == 0 && len(entry.Licenses) == 0 {
+			continue
+		}
+		notice.Entries = append(notice.Entries, entry)
+	}
+
+	sort.SliceStable(notice.Entries, func(i, j int) bool {
+		if notice.Entries[i].Name == notice.Entries[j].Name {
+			return notice.Entries[i].Version < notice.Entries[j].Version
+		}
+		return notice.Entries[i].Name < notice.Entries[j].Name
+	})
+
+	return notice
+}
+
+// AttributionNotice returns the attribution notice of the document's NodeList
+// including the full texts of the custom licenses defined in the metadata.
+func (d *Document) AttributionNotice() *Notice {
+	notice := d.GetNodeList().AttributionNotice()
+	for _, l := range d.GetMetadata().GetCustomLicenses() {
+		if l.Id != "" && l.Text != "" {
+			notice.LicenseTexts[l.Id] = l.Text
+		}
+	}
+	return notice
+}
+
+const noticeTextTemplate = `NOTICE
+{{ range .Entries }}
+================================================================================
+{{ .Name }}{{ if .Version }} {{ .Version }}{{ end }}
+{{ range .Copyrights }}
+{{ . }}
+{{- end }}
+{{- range .Attributions }}
+{{ . }}
+{{- end }}
+{{- if .Licenses }}
+
+Licenses: {{ join .Licenses ", " }}
+{{- end }}
+{{ end }}
+{{- range $id, $text := .LicenseTexts }}
+================================================================================
+{{ $id }}
+
+{{ $text }}
+{{ end }}`
+
+const noticeMarkdownTemplate = `# NOTICE
+{{ range .Entries }}
+## {{ .Name }}{{ if .Version }} {{ .Version }}{{ end }}
+{{ range .Copyrights }}
+> {{ . }}
+{{- end }}
+{{- range .Attributions }}
+
+{{ . }}
+{{- end }}
+{{- if .Licenses }}
+
+**Licenses:** {{ range $i, $l := .Licenses }}{{ if $i }}, {{ end }}` + "`{{ $l }}`" + `{{ end }}
+{{- end }}
+{{ end }}
+{{- if .LicenseTexts }}
+# License Texts
+{{ range $id, $text := .LicenseTexts }}
+## {{ $id }}
+
+` + "```" + `
+{{ $text }}
+` + "```" + `
+{{ end }}
+{{- end }}`
+
+const noticeHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>NOTICE</title></head>
+<body>
+<h1>NOTICE</h1>
+{{- range .Entries }}
+<section>
+<h2>{{ .Name }}{{ if .Version }} {{ .Version }}{{ end }}</h2>
+{{- range .Copyrights }}
+<pre>{{ . }}</pre>
+{{- end }}
+{{- range .Attributions }}
+<p>{{ . }}</p>
+{{- end }}
+{{- if .Licenses }}
+<p>Licenses: {{ range $i, $l := .Licenses }}{{ if $i }}, {{ end }}<code>{{ $l }}</code>{{ end }}</p>
+{{- end }}
+</section>
+{{- end }}
+{{- if .LicenseTexts }}
+<h1>License Texts</h1>
+{{- range $id, $text := .LicenseTexts }}
+<section>
+<h2 id="{{ $id }}">{{ $id }}</h2>
+<pre>{{ $text }}</pre>
+</section>
+{{- end }}
+{{- end }}
+</body>
+</html>
+`
+
+// Render writes the notice to w in the specified format
+func (notice *Notice) Render(w io.Writer, format NoticeFormat) error {
+	funcs := map[string]any{"join": strings.Join}
+	switch format {
+	case NoticeFormatText, "":
+		tmpl := template.Must(template.New("notice").Funcs(funcs).Parse(noticeTextTemplate))
+		if err := tmpl.Execute(w, notice); err != nil {
+			return fmt.Errorf("rendering text notice: %w", err)
+		}
+	case NoticeFormatMarkdown:
+		tmpl := template.Must(template.New("notice").Funcs(funcs).Parse(noticeMarkdownTemplate))
+		if err := tmpl.Execute(w, notice); err != nil {
+			return fmt.Errorf("rendering markdown notice: %w", err)
+		}
+	case NoticeFormatHTML:
+		tmpl := htmltemplate.Must(htmltemplate.New("notice").Funcs(funcs).Parse(noticeHTMLTemplate))
+		if err := tmpl.Execute(w, notice); err != nil {
+			return fmt.Errorf("rendering html notice: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown notice format %q", format)
+	}
+	return nil
+}
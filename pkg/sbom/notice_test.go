@@ -0,0 +1,130 @@
+package sbom
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttributionNotice(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		nodes   []*Node
+		entries []*NoticeEntry
+	}{
+		{
+			name: "sorted by name and version",
+			nodes: []*Node{
+				{Id: "b", Name: "bravo", Version: "2.0", Licenses: []string{"MIT"}},
+				{Id: "a2", Name: "alpha", Version: "2.0", Licenses: []string{"MIT"}},
+				{Id: "a1", Name: "alpha", Version: "1.0", Licenses: []string{"MIT"}},
+			},
+			entries: []*NoticeEntry{
+				{Name: "alpha", Version: "1.0", Copyrights: []string{}, Attributions: []string{}, Licenses: []string{"MIT"}},
+				{Name: "alpha", Version: "2.0", Copyrights: []string{}, Attributions: []string{}, Licenses: []string{"MIT"}},
+				{Name: "bravo", Version: "2.0", Copyrights: []string{}, Attributions: []string{}, Licenses: []string{"MIT"}},
+			},
+		},
+		{
+			name: "duplicates removed",
+			nodes: []*Node{{
+				Id: "a", Name: "alpha", Version: "1.0", Copyright: "Copyright 2023 Alpha <alpha@example.com>",
+				Licenses: []string{"Apache-2.0"}, LicenseConcluded: "Apache-2.0",
+				Attribution: []string{"Includes software developed by Alpha", "Includes software developed by Alpha"},
+			}},
+			entries: []*NoticeEntry{{
+				Name: "alpha", Version: "1.0",
+				Copyrights:   []string{"Copyright 2023 Alpha <alpha@example.com>"},
+				Attributions: []string{"Includes software developed by Alpha"},
+				Licenses:     []string{"Apache-2.0"},
+			}},
+		},
+		{
+			name: "no attribution data",
+			nodes: []*Node{
+				{Id: "c", Name: "charlie", Copyright: "NOASSERTION", LicenseConcluded: "NONE"},
+			},
+			entries: []*NoticeEntry{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: tc.nodes}
+			notice := nl.AttributionNotice()
+			require.Equal(t, tc.entries, notice.Entries)
+			require.Empty(t, notice.LicenseTexts)
+		})
+	}
+}
+
+func TestDocumentAttributionNotice(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		licenses []*License
+		ids      []string
+		texts    map[string]string
+	}{
+		{
+			name:     "custom license text",
+			licenses: []*License{{Id: "LicenseRef-acme", Text: "ACME License text"}},
+			ids:      []string{"Apache-2.0", "LicenseRef-acme"},
+			texts:    map[string]string{"LicenseRef-acme": "ACME License text"},
+		},
+		{
+			name:     "custom license without text",
+			licenses: []*License{{Id: "LicenseRef-acme"}},
+			ids:      []string{"Apache-2.0", "LicenseRef-acme"},
+			texts:    map[string]string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.Metadata.CustomLicenses = tc.licenses
+			doc.NodeList.AddNode(&Node{Id: "b", Name: "bravo", Licenses: []string{"LicenseRef-acme"}})
+			doc.NodeList.AddNode(&Node{Id: "a", Name: "alpha", LicenseConcluded: "Apache-2.0"})
+
+			notice := doc.AttributionNotice()
+			require.Equal(t, tc.ids, notice.LicenseIDs())
+			require.Equal(t, tc.texts, notice.LicenseTexts)
+		})
+	}
+}
+
+func TestNoticeRender(t *testing.T) {
+	for _, tc := range []struct {
+		format   NoticeFormat
+		contains []string
+		mustErr  bool
+	}{
+		{NoticeFormatText, []string{"alpha 1.0", "Copyright 2024 Bravo Inc", "Licenses: Apache-2.0", "ACME License text"}, false},
+		{NoticeFormatMarkdown, []string{"## alpha 1.0", "`LicenseRef-acme`", "# License Texts"}, false},
+		{NoticeFormatHTML, []string{"<h2>alpha 1.0</h2>", "&lt;alpha@example.com&gt;", "<code>Apache-2.0</code>"}, false},
+		{NoticeFormat("pdf"), nil, true},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			doc := NewDocument()
+			doc.Metadata.CustomLicenses = []*License{
+				{Id: "LicenseRef-acme", Text: "ACME License text"},
+			}
+			doc.NodeList.AddNode(&Node{
+				Id: "b", Name: "bravo", Version: "2.0", Copyright: "Copyright 2024 Bravo Inc",
+				Licenses: []string{"LicenseRef-acme"},
+			})
+			doc.NodeList.AddNode(&Node{
+				Id: "a", Name: "alpha", Version: "1.0", Copyright: "Copyright 2023 Alpha <alpha@example.com>",
+				Licenses: []string{"Apache-2.0"}, LicenseConcluded: "Apache-2.0",
+			})
+
+			var b bytes.Buffer
+			err := doc.AttributionNotice().Render(&b, tc.format)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tc.contains {
+				require.Contains(t, b.String(), s)
+			}
+		})
+	}
+}
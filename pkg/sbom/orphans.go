@@ -0,0 +1,138 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OrphanPolicy defines what to do with the orphaned nodes of a NodeList,
+// the nodes that are not root elements and have no edges pointing to them.
+type OrphanPolicy string
+
+const (
+	// OrphanPolicyReconnect adds the orphaned nodes to the root elements
+	OrphanPolicyReconnect OrphanPolicy = "reconnect-to-root"
+
+	// OrphanPolicySyntheticRoot creates a new node that becomes the only
+	// root element. It contains the previous root elements and the
+	// orphaned nodes.
+	OrphanPolicySyntheticRoot OrphanPolicy = "create-synthetic-root"
+
+	// OrphanPolicyLeave leaves the orphaned nodes as they are
+	OrphanPolicyLeave OrphanPolicy = "leave-as-is"
+
+	// OrphanPolicyError returns an error when there are orphaned nodes
+	OrphanPolicyError OrphanPolicy = "error"
+)
+
+// SyntheticRootName is the name of the root node created by
+// OrphanPolicySyntheticRoot.
+const SyntheticRootName = "synthetic root"
+
+// ErrOrphanedNodes is returned by OrphanPolicyError when the NodeList has
+// orphaned nodes.
+var ErrOrphanedNodes = errors.New("nodelist has orphaned nodes")
+
+// WithOrphanPolicy sets the policy applied to the orphaned nodes of the
+// NodeLists returned by the query methods. Query methods that don't return
+// errors leave the orphaned nodes as they are when set to OrphanPolicyError.
+func WithOrphanPolicy(p OrphanPolicy) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.orphanPolicy = p
+	}
+}
+
+// ReconnectOrphans applies an orphan policy to the NodeList. It returns an
+// error if the policy is unknown or when using OrphanPolicyError and
+// orphaned nodes are found.
+func (nl *NodeList) ReconnectOrphans(policy OrphanPolicy) error {
+	switch policy {
+	case OrphanPolicyLeave:
+		return nil
+	case OrphanPolicyReconnect, OrphanPolicySyntheticRoot, OrphanPolicyError:
+	default:
+		return fmt.Errorf("unknown orphan policy %q", policy)
+	}
+
+	orphans := nl.Orphans()
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	switch policy {
+	case OrphanPolicyReconnect:
+		for _, n := range orphans {
+			nl.RootElements = append(nl.RootElements, n.Id)
+		}
+	case OrphanPolicySyntheticRoot:
+		root := &Node{
+			Id:   NewNodeIdentifier("auto", "synthetic-root"),
+			Name: SyntheticRootName,
+		}
+		edge := &Edge{Type: Edge_contains, From: root.Id, To: append([]string{}, nl.RootElements...)}
+		for _, n := range orphans {
+			edge.To = append(edge.To, n.Id)
+		}
+		nl.AddNode(root)
+		nl.AddEdge(edge)
+		nl.RootElements = []string{root.Id}
+	case OrphanPolicyError:
+		ids := []string{}
+		for _, n := range orphans {
+			ids = append(ids, n.Id)
+		}
+		return fmt.Errorf("%w: %s", ErrOrphanedNodes, strings.Join(ids, ", "))
+	}
+	return nil
+}
+
+// Orphans returns the nodes that are not root elements and are not the
+// destination of any edge. Orphaned nodes usually point to a problem in
+// the tool that generated the SBOM.
+func (nl *NodeList) Orphans() []*Node {
+	targets := nl.indexRootElements()
+	for _, e := range nl.Edges {
+		for _, id := range e.To {
+			targets[id] = struct{}{}
+		}
+	}
+
+	ret := []*Node{}
+	for _, n := range nl.Nodes {
+		if _, ok := targets[n.Id]; !ok {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
+
+// Unreachable returns the nodes that can't be reached by following the
+// edges from any of the root elements. This includes the orphaned nodes
+// and the subgraphs hanging from them.
+func (nl *NodeList) Unreachable() []*Node {
+	children := map[string][]string{}
+	for _, e := range nl.Edges {
+		children[e.From] = append(children[e.From], e.To...)
+	}
+
+	reached := map[string]struct{}{}
+	queue := append([]string{}, nl.RootElements...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := reached[id]; ok {
+			continue
+		}
+		reached[id] = struct{}{}
+		queue = append(queue, children[id]...)
+	}
+
+	ret := []*Node{}
+	for _, n := range nl.Nodes {
+		if _, ok := reached[n.Id]; !ok {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,113 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrphansAndUnreachable(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		sut         *NodeList
+		orphans     []string
+		unreachable []string
+	}{
+		{
+			name: "orphans and islands",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app"}, {Id: "a"}, {Id: "b"}, {Id: "orphan"}, {Id: "orphan-child"}, {Id: "island-1"}, {Id: "island-2"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "app", To: []string{"a"}},
+					{Type: Edge_dependsOn, From: "a", To: []string{"b"}},
+					{Type: Edge_dependsOn, From: "orphan", To: []string{"orphan-child"}},
+					// A cycle disconnected from the root has no orphans but is unreachable
+					{Type: Edge_dependsOn, From: "island-1", To: []string{"island-2"}},
+					{Type: Edge_dependsOn, From: "island-2", To: []string{"island-1"}},
+				},
+				RootElements: []string{"app"},
+			},
+			orphans:     []string{"orphan"},
+			unreachable: []string{"orphan", "orphan-child", "island-1", "island-2"},
+		},
+		{
+			name: "connected",
+			sut: &NodeList{
+				Nodes:        []*Node{{Id: "app"}, {Id: "a"}},
+				Edges:        []*Edge{{Type: Edge_contains, From: "app", To: []string{"a"}}},
+				RootElements: []string{"app"},
+			},
+			orphans:     []string{},
+			unreachable: []string{},
+		},
+		{
+			name:        "empty node list",
+			sut:         NewNodeList(),
+			orphans:     []string{},
+			unreachable: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.orphans, ids(tc.sut.Orphans()))
+			require.Equal(t, tc.unreachable, ids(tc.sut.Unreachable()))
+		})
+	}
+}
+
+func TestReconnectOrphans(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy OrphanPolicy
+		// orphan adds a node not connected to the root
+		orphan bool
+		roots  []string
+		// synthetic is the expected contents of the synthetic root
+		synthetic []string
+		errIs     error
+		mustErr   bool
+	}{
+		{name: "leave", policy: OrphanPolicyLeave, orphan: true, roots: []string{"app"}},
+		{name: "reconnect", policy: OrphanPolicyReconnect, orphan: true, roots: []string{"app", "orphan"}},
+		{name: "synthetic root", policy: OrphanPolicySyntheticRoot, orphan: true, synthetic: []string{"app", "orphan"}},
+		{name: "error", policy: OrphanPolicyError, orphan: true, errIs: ErrOrphanedNodes},
+		{name: "unknown policy", policy: OrphanPolicy("bogus"), orphan: true, mustErr: true},
+		// Without orphans, no policy changes the list
+		{name: "error without orphans", policy: OrphanPolicyError, roots: []string{"app"}},
+		{name: "synthetic root without orphans", policy: OrphanPolicySyntheticRoot, roots: []string{"app"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes:        []*Node{{Id: "app"}, {Id: "a"}},
+				Edges:        []*Edge{{Type: Edge_contains, From: "app", To: []string{"a"}}},
+				RootElements: []string{"app"},
+			}
+			if tc.orphan {
+				nl.AddNode(&Node{Id: "orphan"})
+			}
+
+			err := nl.ReconnectOrphans(tc.policy)
+			switch {
+			case tc.errIs != nil:
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			case tc.mustErr:
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			if tc.synthetic == nil {
+				require.Equal(t, tc.roots, nl.RootElements)
+				return
+			}
+			require.Len(t, nl.RootElements, 1)
+			root := nl.GetNodeByID(nl.RootElements[0])
+			require.NotNil(t, root)
+			require.Equal(t, SyntheticRootName, root.Name)
+			require.Equal(t, tc.synthetic, nl.GetEdgeByType(root.Id, Edge_contains).To)
+			require.Empty(t, nl.Orphans())
+		})
+	}
+}
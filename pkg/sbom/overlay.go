@@ -0,0 +1,144 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrEmptySelector is returned when an overlay entry has an empty selector
+var ErrEmptySelector = errors.New("node selector is empty")
+
+// OverlayReport lists the node IDs modified when applying an overlay
+type OverlayReport struct {
+	Corrected []string
+	Removed   []string
+}
+
+// NewOverlay returns a new empty overlay
+func NewOverlay() *Overlay {
+	return &Overlay{
+		Corrections: []*Overlay_Correction{},
+		Removals:    []*NodeSelector{},
+	}
+}
+
+// AddCorrection registers a correction in the overlay. The non-empty fields
+// in patch will be applied to the nodes matching the selector.
+func (o *Overlay) AddCorrection(selector *NodeSelector, patch *Node) {
+	o.Corrections = append(o.Corrections, &Overlay_Correction{
+		Selector: selector,
+		Patch:    patch,
+	})
+}
+
+// AddRemoval registers the nodes matching the selector to be removed
+func (o *Overlay) AddRemoval(selector *NodeSelector) {
+	o.Removals = append(o.Removals, selector)
+}
+
+// IsEmpty returns true if the selector has no fields to match.
+func (s *NodeSelector) IsEmpty() bool {
+	return s == nil || (s.Id == "" && s.Purl == "" && s.Name == "" && s.Version == "")
+}
+
+// Matches returns true if the node matches all the non-empty fields in the
+// selector. An empty selector does not match any node.
+func (s *NodeSelector) Matches(n *Node) bool {
+	if s.IsEmpty() || n == nil {
+		return false
+	}
+	if s.Id != "" && s.Id != n.Id {
+		return false
+	}
+	if s.Name != "" && s.Name != n.Name {
+		return false
+	}
+	if s.Version != "" && s.Version != n.Version {
+		return false
+	}
+	if s.Purl != "" && !purlMatches(s.Purl, string(n.Purl())) {
+		return false
+	}
+	return true
+}
+
+// purlMatches compares a selector purl with a node purl. When the selector
+// has no version, the node purl matches any version.
+func purlMatches(selector, purl string) bool {
+	if purl == "" {
+		return false
+	}
+	if selector == purl {
+		return true
+	}
+	if strings.Contains(selector, "@") {
+		return false
+	}
+	base, _, _ := strings.Cut(purl, "?")
+	base, _, _ = strings.Cut(base, "#")
+	base, _, _ = strings.Cut(base, "@")
+	return base == selector
+}
+
+// ApplyOverlay applies the curations in the overlay to the document. Removals
+// are processed after all corrections. Applying the same overlay more than
+// once yields the same document.
+func (d *Document) ApplyOverlay(o *Overlay) (*OverlayReport, error) {
+	report := &OverlayReport{
+		Corrected: []string{},
+		Removed:   []string{},
+	}
+	if o == nil || d.NodeList == nil {
+		return report, nil
+	}
+
+	for i, c := range o.GetCorrections() {
+		if c.GetSelector().IsEmpty() {
+			return nil, fmt.Errorf("correction #%d: %w", i, ErrEmptySelector)
+		}
+		if c.GetPatch() == nil {
+			return nil, fmt.Errorf("correction #%d has no patch", i)
+		}
+	}
+	for i, s := range o.GetRemovals() {
+		if s.IsEmpty() {
+			return nil, fmt.Errorf("removal #%d: %w", i, ErrEmptySelector)
+		}
+	}
+
+	for _, c := range o.GetCorrections() {
+		patch := c.GetPatch().Copy()
+		for _, n := range d.NodeList.Nodes {
+			if !c.Selector.Matches(n) {
+				continue
+			}
+			before := n.flatString()
+			n.Update(patch)
+			if before != n.flatString() {
+				report.Corrected = append(report.Corrected, n.Id)
+			}
+		}
+	}
+
+	for _, s := range o.GetRemovals() {
+		for _, n := range d.NodeList.Nodes {
+			if s.Matches(n) {
+				report.Removed = append(report.Removed, n.Id)
+			}
+		}
+	}
+
+	if len(report.Removed) > 0 {
+		d.NodeList.RemoveNodes(report.Removed)
+		rootElements := []string{}
+		for _, id := range d.NodeList.RootElements {
+			if d.NodeList.GetNodeByID(id) != nil {
+				rootElements = append(rootElements, id)
+			}
+		}
+		d.NodeList.RootElements = rootElements
+	}
+
+	return report, nil
+}
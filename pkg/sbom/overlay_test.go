@@ -0,0 +1,139 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeSelectorMatches(t *testing.T) {
+	node := &Node{
+		Id: "lib1", Name: "lib1", Version: "1.0.0",
+		Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:npm/lib1@1.0.0?arch=x"},
+	}
+	for name, tc := range map[string]struct {
+		selector *NodeSelector
+		expected bool
+	}{
+		"empty":              {&NodeSelector{}, false},
+		"nil":                {nil, false},
+		"id":                 {&NodeSelector{Id: "lib1"}, true},
+		"id mismatch":        {&NodeSelector{Id: "lib2"}, false},
+		"name and version":   {&NodeSelector{Name: "lib1", Version: "1.0.0"}, true},
+		"version mismatch":   {&NodeSelector{Name: "lib1", Version: "2.0.0"}, false},
+		"purl any version":   {&NodeSelector{Purl: "pkg:npm/lib1"}, true},
+		"purl exact":         {&NodeSelector{Purl: "pkg:npm/lib1@1.0.0?arch=x"}, true},
+		"purl other version": {&NodeSelector{Purl: "pkg:npm/lib1@2.0.0"}, false},
+		"purl other package": {&NodeSelector{Purl: "pkg:npm/lib"}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.selector.Matches(node))
+		})
+	}
+}
+
+func TestApplyOverlay(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		overlay *Overlay
+		// passes is the number of times the overlay is applied, the
+		// report is the one of the last pass
+		passes    int
+		errIs     error
+		corrected []string
+		removed   []string
+		licenses  []string
+		supplier  string
+		edgeTo    []string
+	}{
+		{
+			name: "correction",
+			overlay: func() *Overlay {
+				o := NewOverlay()
+				o.AddCorrection(&NodeSelector{Name: "lib1"}, &Node{
+					Licenses:  []string{"MIT"},
+					Suppliers: []*Person{{Name: "ACME", IsOrg: true}},
+				})
+				return o
+			}(),
+			passes:    1,
+			corrected: []string{"lib1"},
+			removed:   []string{},
+			licenses:  []string{"MIT"},
+			supplier:  "ACME",
+			edgeTo:    []string{"lib1", "lib2"},
+		},
+		{
+			name: "removal",
+			overlay: func() *Overlay {
+				o := NewOverlay()
+				o.AddRemoval(&NodeSelector{Id: "lib2"})
+				return o
+			}(),
+			passes:    1,
+			corrected: []string{},
+			removed:   []string{"lib2"},
+			licenses:  []string{"NOASSERTION"},
+			edgeTo:    []string{"lib1"},
+		},
+		{
+			// Applying the overlay again does not change the document
+			name: "second pass",
+			overlay: func() *Overlay {
+				o := NewOverlay()
+				o.AddCorrection(&NodeSelector{Name: "lib1"}, &Node{Licenses: []string{"MIT"}})
+				o.AddRemoval(&NodeSelector{Id: "lib2"})
+				return o
+			}(),
+			passes:    2,
+			corrected: []string{},
+			removed:   []string{},
+			licenses:  []string{"MIT"},
+			edgeTo:    []string{"lib1"},
+		},
+		{
+			name: "empty selector",
+			overlay: func() *Overlay {
+				o := NewOverlay()
+				o.AddRemoval(&NodeSelector{})
+				return o
+			}(),
+			passes: 1,
+			errIs:  ErrEmptySelector,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := &Document{
+				NodeList: &NodeList{
+					Nodes: []*Node{
+						{Id: "app", Name: "app"},
+						{Id: "lib1", Name: "lib1", Licenses: []string{"NOASSERTION"}},
+						{Id: "lib2", Name: "lib2"},
+					},
+					Edges: []*Edge{
+						{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib2"}},
+					},
+					RootElements: []string{"app"},
+				},
+			}
+
+			var report *OverlayReport
+			var err error
+			for range tc.passes {
+				report, err = doc.ApplyOverlay(tc.overlay)
+			}
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.corrected, report.Corrected)
+			require.Equal(t, tc.removed, report.Removed)
+			require.Equal(t, tc.licenses, doc.NodeList.GetNodeByID("lib1").Licenses)
+			if tc.supplier != "" {
+				require.Equal(t, tc.supplier, doc.NodeList.GetNodeByID("lib1").Suppliers[0].Name)
+			}
+			require.Equal(t, tc.edgeTo, doc.NodeList.Edges[0].To)
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package sbom
+
+import "slices"
+
+// containmentIndex indexes the contains and contained_by edges, returning
+// the sorted IDs of the nodes each node contains and of the nodes
+// containing it
+func (nl *NodeList) containmentIndex() (contents, containers map[string][]string) {
+	contents, containers = map[string][]string{}, map[string][]string{}
+	add := func(parent, child string) {
+		contents[parent] = append(contents[parent], child)
+		containers[child] = append(containers[child], parent)
+	}
+	for _, e := range nl.GetEdges() {
+		for _, id := range e.To {
+			switch e.Type {
+			case Edge_contains:
+				add(e.From, id)
+			case Edge_contained_by:
+				add(id, e.From)
+			}
+		}
+	}
+	for _, index := range []map[string][]string{contents, containers} {
+		for id, ids := range index {
+			slices.Sort(ids)
+			index[id] = slices.Compact(ids)
+		}
+	}
+	return contents, containers
+}
+
+// FilesOf returns the files owned by the package packageID: the files it
+// contains directly or through directories and other files, eg an archive.
+// Files inside nested packages belong to them and are not returned. The
+// files are returned breadth first, each one once.
+func (nl *NodeList) FilesOf(packageID string) []*Node {
+	nodes := nl.indexNodes()
+	if _, ok := nodes[packageID]; !ok {
+		return []*Node{}
+	}
+	contents, _ := nl.containmentIndex()
+
+	ret := []*Node{}
+	seen := map[string]struct{}{packageID: {}}
+	queue := []string{packageID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, childID := range contents[id] {
+			child, ok := nodes[childID]
+			if !ok || child.Type != Node_FILE {
+				continue
+			}
+			if _, ok := seen[childID]; ok {
+				continue
+			}
+			seen[childID] = struct{}{}
+			ret = append(ret, child)
+			queue = append(queue, childID)
+		}
+	}
+	return ret
+}
+
+// OwnerOf returns the package owning the file fileID, the closest package
+// up its containment chain. When packages at the same distance contain the
+// file, the one with the lowest ID is returned. It returns nil if the file
+// is not contained in any package.
+func (nl *NodeList) OwnerOf(fileID string) *Node {
+	_, containers := nl.containmentIndex()
+	return ownerOf(fileID, nl.indexNodes(), containers)
+}
+
+// ownerOf returns the package owning a file from the node and containers
+// indexes
+func ownerOf(fileID string, nodes nodeIndex, containers map[string][]string) *Node {
+	if _, ok := nodes[fileID]; !ok {
+		return nil
+	}
+	seen := map[string]struct{}{fileID: {}}
+	level := []string{fileID}
+	for len(level) > 0 {
+		var owner *Node
+		next := []string{}
+		for _, id := range level {
+			for _, parentID := range containers[id] {
+				parent, ok := nodes[parentID]
+				if !ok {
+					continue
+				}
+				if parent.Type == Node_PACKAGE {
+					if owner == nil || parent.Id < owner.Id {
+						owner = parent
+					}
+					continue
+				}
+				if _, ok := seen[parentID]; !ok {
+					seen[parentID] = struct{}{}
+					next = append(next, parentID)
+				}
+			}
+		}
+		if owner != nil {
+			return owner
+		}
+		level = next
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesOf(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		owner    string
+		expected []string
+	}{
+		{name: "contained files", owner: "openssl", expected: []string{"lib", "libcrypto", "libssl"}},
+		{name: "contained_by edges", owner: "libssl-dev", expected: []string{"header", "libssl"}},
+		// Files of the nested openssl package are not owned by the image
+		{name: "nested package", owner: "image", expected: []string{"motd"}},
+		{name: "missing node", owner: "missing", expected: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{}
+			nl.AddRootNode(&Node{Id: "image", Name: "image"})
+			nl.AddNode(&Node{Id: "openssl", Name: "openssl"})
+			nl.AddNode(&Node{Id: "libssl-dev", Name: "libssl-dev"})
+			nl.AddNode(&Node{Id: "lib", Name: "/usr/lib", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "libssl", Name: "/usr/lib/libssl.so", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "libcrypto", Name: "/usr/lib/libcrypto.so", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "header", Name: "/usr/include/ssl.h", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "motd", Name: "/etc/motd", Type: Node_FILE})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "image", To: []string{"openssl", "motd"}})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "openssl", To: []string{"lib"}})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "lib", To: []string{"libssl", "libcrypto"}})
+			nl.AddEdge(&Edge{Type: Edge_contained_by, From: "header", To: []string{"libssl-dev"}})
+			nl.AddEdge(&Edge{Type: Edge_contained_by, From: "libssl", To: []string{"libssl-dev"}})
+
+			require.Equal(t, tc.expected, ids(nl.FilesOf(tc.owner)))
+		})
+	}
+}
+
+func TestOwnerOf(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		file  string
+		owner string
+	}{
+		{name: "file in directory", file: "libcrypto", owner: "openssl"},
+		{name: "contained_by edge", file: "header", owner: "libssl-dev"},
+		// libssl-dev contains the file directly, openssl through /usr/lib
+		{name: "direct owner wins", file: "libssl", owner: "libssl-dev"},
+		{name: "file in root", file: "motd", owner: "image"},
+		{name: "package", file: "openssl", owner: "image"},
+		{name: "root", file: "image"},
+		{name: "missing node", file: "missing"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{}
+			nl.AddRootNode(&Node{Id: "image", Name: "image"})
+			nl.AddNode(&Node{Id: "openssl", Name: "openssl"})
+			nl.AddNode(&Node{Id: "libssl-dev", Name: "libssl-dev"})
+			nl.AddNode(&Node{Id: "lib", Name: "/usr/lib", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "libssl", Name: "/usr/lib/libssl.so", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "libcrypto", Name: "/usr/lib/libcrypto.so", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "header", Name: "/usr/include/ssl.h", Type: Node_FILE})
+			nl.AddNode(&Node{Id: "motd", Name: "/etc/motd", Type: Node_FILE})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "image", To: []string{"openssl", "motd"}})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "openssl", To: []string{"lib"}})
+			nl.AddEdge(&Edge{Type: Edge_contains, From: "lib", To: []string{"libssl", "libcrypto"}})
+			nl.AddEdge(&Edge{Type: Edge_contained_by, From: "header", To: []string{"libssl-dev"}})
+			nl.AddEdge(&Edge{Type: Edge_contained_by, From: "libssl", To: []string{"libssl-dev"}})
+
+			require.Equal(t, tc.owner, nl.OwnerOf(tc.file).GetId())
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package sbom
+
+import (
+	"container/heap"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// cursorPrefix versions the contents of the pagination cursors
+const cursorPrefix = "v1:"
+
+// ErrInvalidCursor is returned when a pagination cursor can't be decoded
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// NodePage is a page of nodes returned by NodeList.Iterate
+type NodePage struct {
+	// Nodes in the page, sorted by their ID
+	Nodes []*Node
+
+	// NextCursor points to the next page. It is empty on the last page.
+	NextCursor string
+}
+
+// encodeCursor returns the cursor pointing to the nodes after id
+func encodeCursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + id))
+}
+
+// decodeCursor returns the ID of the last node seen from a cursor
+func decodeCursor(cursor string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	id, ok := strings.CutPrefix(string(data), cursorPrefix)
+	if !ok {
+		return "", ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// nodeHeap is a max-heap of nodes sorted by ID. It keeps the nodes with
+// the lowest IDs seen when selecting the nodes of a page.
+type nodeHeap []*Node
+
+func (h nodeHeap) Len() int           { return len(h) }
+func (h nodeHeap) Less(i, j int) bool { return h[i].Id > h[j].Id }
+func (h nodeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *nodeHeap) Push(x any)        { *h = append(*h, x.(*Node)) } //nolint:forcetypeassert
+func (h *nodeHeap) Pop() any {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// Iterate returns a page of up to pageSize nodes starting at cursor. An
+// empty cursor starts at the first page, the following pages are read by
+// passing the NextCursor of the previous one.
+//
+// Pages are sorted by node ID and the cursors record the last ID returned,
+// so pagination is stable when nodes are added or removed between calls:
+// no node present during the whole iteration is skipped or returned twice.
+// Cursors are opaque and can be handed to API clients.
+func (nl *NodeList) Iterate(cursor string, pageSize int) (*NodePage, error) {
+	if pageSize < 1 {
+		return nil, fmt.Errorf("invalid page size %d", pageSize)
+	}
+
+	var after string
+	if cursor != "" {
+		var err error
+		after, err = decodeCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Select the nodes after the cursor with the lowest IDs, plus one
+	// to know if there is a next page, without sorting the whole list.
+	limit := min(pageSize, len(nl.GetNodes())) + 1
+	h := make(nodeHeap, 0, limit)
+	for _, n := range nl.GetNodes() {
+		switch {
+		case cursor != "" && n.Id <= after:
+		case h.Len() < limit:
+			heap.Push(&h, n)
+		case n.Id < h[0].Id:
+			h[0] = n
+			heap.Fix(&h, 0)
+		}
+	}
+	candidates := []*Node(h)
+	slices.SortFunc(candidates, func(a, b *Node) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+
+	page := &NodePage{Nodes: candidates}
+	if len(candidates) > pageSize {
+		page.Nodes = candidates[:pageSize]
+		page.NextCursor = encodeCursor(page.Nodes[pageSize-1].Id)
+	}
+	return page, nil
+}
+
+// NodeIterator reads the nodes of a NodeList in pages. The NodeList is
+// paginated with NodeList.Iterate, so the iteration tolerates changes to
+// the NodeList between pages.
+//
+//	it := nl.Iterator(100)
+//	for it.Next() {
+//		for _, n := range it.Page() { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type NodeIterator struct {
+	nl       *NodeList
+	pageSize int
+	cursor   string
+	page     []*Node
+	done     bool
+	err      error
+}
+
+// Iterator returns an iterator reading the NodeList nodes in pages of
+// pageSize nodes.
+func (nl *NodeList) Iterator(pageSize int) *NodeIterator {
+	return &NodeIterator{nl: nl, pageSize: pageSize}
+}
+
+// IteratorAt returns an iterator reading the NodeList nodes in pages of
+// pageSize nodes, starting at the page pointed to by cursor.
+func (nl *NodeList) IteratorAt(cursor string, pageSize int) *NodeIterator {
+	return &NodeIterator{nl: nl, pageSize: pageSize, cursor: cursor}
+}
+
+// Next fetches the next page. It returns false when there are no more
+// pages or an error occurred.
+func (it *NodeIterator) Next() bool {
+	if it.done {
+		it.page = nil
+		return false
+	}
+	page, err := it.nl.Iterate(it.cursor, it.pageSize)
+	if err != nil {
+		it.err = err
+		it.done = true
+		it.page = nil
+		return false
+	}
+	it.cursor = page.NextCursor
+	it.done = page.NextCursor == ""
+	it.page = page.Nodes
+	return len(page.Nodes) > 0
+}
+
+// Page returns the nodes of the current page
+func (it *NodeIterator) Page() []*Node {
+	return it.page
+}
+
+// Cursor returns the cursor of the page after the current one, empty when
+// the current page is the last one. It can be used to resume the iteration
+// later with NodeList.IteratorAt.
+func (it *NodeIterator) Cursor() string {
+	return it.cursor
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *NodeIterator) Err() error {
+	return it.err
+}
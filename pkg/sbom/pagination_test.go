@@ -0,0 +1,183 @@
+package sbom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIterate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		nodes    int
+		pageSize int
+		// mutate runs after the first page is read
+		mutate func(*NodeList)
+		pages  [][]string
+	}{
+		{
+			name:     "pages sorted by id",
+			nodes:    5,
+			pageSize: 2,
+			pages:    [][]string{{"node-00", "node-01"}, {"node-02", "node-03"}, {"node-04"}},
+		},
+		{
+			name:     "single page",
+			nodes:    5,
+			pageSize: 10,
+			pages:    [][]string{{"node-00", "node-01", "node-02", "node-03", "node-04"}},
+		},
+		{
+			name:     "empty list",
+			pageSize: 10,
+			pages:    [][]string{{}},
+		},
+		{
+			name:     "changes between pages",
+			nodes:    5,
+			pageSize: 2,
+			mutate: func(nl *NodeList) {
+				nl.RemoveNodes([]string{"node-00", "node-02"})
+				nl.AddNode(&Node{Id: "node-00a"})
+			},
+			pages: [][]string{{"node-00", "node-01"}, {"node-03", "node-04"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{}
+			// Add the nodes in reverse to check the pages are sorted by ID
+			for i := tc.nodes - 1; i >= 0; i-- {
+				nl.Nodes = append(nl.Nodes, &Node{Id: fmt.Sprintf("node-%02d", i)})
+			}
+
+			pages := [][]string{}
+			cursor := ""
+			for {
+				page, err := nl.Iterate(cursor, tc.pageSize)
+				require.NoError(t, err)
+				pages = append(pages, ids(page.Nodes))
+				if page.NextCursor == "" {
+					break
+				}
+				if len(pages) == 1 && tc.mutate != nil {
+					tc.mutate(nl)
+				}
+				cursor = page.NextCursor
+			}
+			require.Equal(t, tc.pages, pages)
+		})
+	}
+}
+
+func TestIterateErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		cursor   string
+		pageSize int
+		errIs    error
+	}{
+		{name: "zero page size", pageSize: 0},
+		{name: "cursor not base64", cursor: "not a cursor!", pageSize: 2, errIs: ErrInvalidCursor},
+		{name: "malformed cursor", cursor: "bm9kZS0wMA", pageSize: 2, errIs: ErrInvalidCursor},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{{Id: "node-00"}, {Id: "node-01"}}}
+			_, err := nl.Iterate(tc.cursor, tc.pageSize)
+			require.Error(t, err)
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+			}
+		})
+	}
+}
+
+func TestNodeIterator(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pageSize int
+		pages    int
+		// resume has the nodes read by an iterator resumed from the
+		// cursor of the first page
+		resume  []string
+		mustErr bool
+	}{
+		{
+			name:     "resume from cursor",
+			pageSize: 3,
+			pages:    3,
+			resume:   []string{"node-03", "node-04", "node-05", "node-06"},
+		},
+		{
+			name:     "zero page size",
+			pageSize: 0,
+			mustErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{}
+			expected := []string{}
+			for i := 6; i >= 0; i-- {
+				nl.Nodes = append(nl.Nodes, &Node{Id: fmt.Sprintf("node-%02d", i)})
+				expected = append([]string{fmt.Sprintf("node-%02d", i)}, expected...)
+			}
+
+			it := nl.Iterator(tc.pageSize)
+			seen := []string{}
+			pages := 0
+			for it.Next() {
+				pages++
+				seen = append(seen, ids(it.Page())...)
+				if pages == 1 {
+					rest := nl.IteratorAt(it.Cursor(), 10)
+					require.True(t, rest.Next())
+					require.Equal(t, tc.resume, ids(rest.Page()))
+					require.False(t, rest.Next())
+				}
+			}
+			require.False(t, it.Next())
+			if tc.mustErr {
+				require.Error(t, it.Err())
+				return
+			}
+			require.NoError(t, it.Err())
+			require.Equal(t, tc.pages, pages)
+			require.Equal(t, expected, seen)
+		})
+	}
+}
+
+func TestIteratePageSizes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pageSize int
+		pages    int
+	}{
+		{"single node pages", 1, 10},
+		{"uneven pages", 3, 4},
+		{"exact pages", 5, 2},
+		{"one page", 10, 1},
+		{"page larger than the list", 1 << 30, 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{}
+			for _, i := range []int{7, 2, 9, 0, 4, 8, 1, 6, 3, 5} {
+				nl.Nodes = append(nl.Nodes, &Node{Id: fmt.Sprintf("node-%02d", i)})
+			}
+			expected := []string{}
+			for i := range 10 {
+				expected = append(expected, fmt.Sprintf("node-%02d", i))
+			}
+
+			seen := []string{}
+			pages := 0
+			for it := nl.Iterator(tc.pageSize); it.Next(); {
+				pages++
+				require.LessOrEqual(t, len(it.Page()), tc.pageSize)
+				seen = append(seen, ids(it.Page())...)
+			}
+			require.Equal(t, tc.pages, pages)
+			require.Equal(t, expected, seen)
+		})
+	}
+}
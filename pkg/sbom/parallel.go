@@ -0,0 +1,320 @@
+package sbom
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"sync"
+
+	"github.com/protobom/protobom/pkg/telemetry"
+)
+
+// NodeListOption configures the NodeList set operations
+type NodeListOption func(*nodeListOptions)
+
+type nodeListOptions struct {
+	parallelism        int
+	orphanPolicy       OrphanPolicy
+	identities         *IdentityDatabase
+	ignoredFields      fieldMaskTree
+	idConflictResolver IDConflictResolver
+	merge              MergeOptions
+	ctx                context.Context
+}
+
+// WithContext sets the context of the NodeList operation. When the context
+// carries telemetry (see telemetry.ContextWithTelemetry), the operation is
+// traced as a child of the span in ctx.
+func WithContext(ctx context.Context) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.ctx = ctx
+	}
+}
+
+// startOperation starts the instrumentation of a NodeList operation with
+// the telemetry in the options context. It returns nil, a no-op operation,
+// when there is no telemetry.
+func (o *nodeListOptions) startOperation(name string, attrs ...telemetry.Attribute) *telemetry.Operation {
+	if o.ctx == nil {
+		return nil
+	}
+	attrs = append(attrs, telemetry.Int("parallelism", o.parallelism))
+	_, op := telemetry.FromContext(o.ctx).Start(o.ctx, name, attrs...)
+	return op
+}
+
+// WithParallelism splits the node keyspace of the NodeList operations in n
+// shards that are processed concurrently. Values lower than 2 run the
+// operation in the calling goroutine.
+func WithParallelism(n int) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.parallelism = n
+	}
+}
+
+// buildNodeListOptions returns the options set by opts
+func buildNodeListOptions(opts []NodeListOption) *nodeListOptions {
+	o := &nodeListOptions{parallelism: 1, orphanPolicy: OrphanPolicyReconnect}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.parallelism < 1 {
+		o.parallelism = 1
+	}
+	return o
+}
+
+// shardOf returns the shard assigned to a node ID
+func shardOf(id string, shards int) int {
+	h := fnv.New32a()
+	h.Write([]byte(id))                    //nolint:errcheck,gosec // hash writes never fail
+	return int(h.Sum32() % uint32(shards)) //nolint:gosec // shards is always positive
+}
+
+// shardPositions returns the positions of the nodes split by the shard of
+// their IDs.
+func shardPositions(nodes []*Node, shards int) [][]int {
+	positions := make([][]int, shards)
+	for i, n := range nodes {
+		s := shardOf(n.GetId(), shards)
+		positions[s] = append(positions[s], i)
+	}
+	return positions
+}
+
+// runShards calls f concurrently for every shard and waits for them to finish
+func runShards(shards int, f func(shard int)) {
+	if shards == 1 {
+		f(0)
+		return
+	}
+	var wg sync.WaitGroup
+	for s := range shards {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			f(s)
+		}()
+	}
+	wg.Wait()
+}
+
+// shardedMergeEdges merges the edges in the lists by source and type. Edges
+// from or to nodes missing in the ID sets are dropped. The ID sets are
+// indexed by shard.
+func shardedMergeEdges(lists [][]*Edge, ids []map[string]struct{}) []*Edge {
+	shards := len(ids)
+	parts := make([][]*Edge, shards)
+	for _, list := range lists {
+		for _, e := range list {
+			s := shardOf(e.GetFrom(), shards)
+			parts[s] = append(parts[s], e)
+		}
+	}
+
+	results := make([][]*Edge, shards)
+	runShards(shards, func(s int) {
+		merged := map[string]*Edge{}
+		seen := map[string]map[string]struct{}{}
+		keys := []string{}
+		for _, e := range parts[s] {
+			if _, ok := ids[s][e.GetFrom()]; !ok {
+				continue
+			}
+			key := e.GetFrom() + "+++" + e.GetType().String()
+			if _, ok := merged[key]; !ok {
+				merged[key] = &Edge{Type: e.GetType(), From: e.GetFrom(), To: []string{}}
+				seen[key] = map[string]struct{}{}
+				keys = append(keys, key)
+			}
+			for _, to := range e.GetTo() {
+				if _, ok := ids[shardOf(to, shards)][to]; !ok {
+					continue
+				}
+				if _, ok := seen[key][to]; ok {
+					continue
+				}
+				seen[key][to] = struct{}{}
+				merged[key].To = append(merged[key].To, to)
+			}
+		}
+		for _, key := range keys {
+			if len(merged[key].To) > 0 {
+				results[s] = append(results[s], merged[key])
+			}
+		}
+	})
+
+	return slices.Concat(results...)
+}
+
+// collectPositions returns the positions from all shards sorted
+func collectPositions(shards [][]int) []int {
+	positions := slices.Concat(shards...)
+	slices.Sort(positions)
+	return positions
+}
+
+// shardedUnion is the parallel implementation of Union
+func (nl *NodeList) shardedUnion(nl2 *NodeList, shards int, mo *MergeOptions) *NodeList {
+	pos1 := shardPositions(nl.Nodes, shards)
+	pos2 := shardPositions(nl2.Nodes, shards)
+
+	nodes := make([]*Node, len(nl.Nodes))
+	added := make([][]int, shards)
+	ids := make([]map[string]struct{}, shards)
+
+	runShards(shards, func(s int) {
+		existing := make(map[string]*Node, len(pos1[s]))
+		ids[s] = make(map[string]struct{}, len(pos1[s])+len(pos2[s]))
+		for _, i := range pos1[s] {
+			nodes[i] = nl.Nodes[i].Copy()
+			existing[nodes[i].Id] = nodes[i]
+			ids[s][nodes[i].Id] = struct{}{}
+		}
+		for _, i := range pos2[s] {
+			n := nl2.Nodes[i]
+			if e, ok := existing[n.Id]; ok {
+				mo.mergeNode(e, n, FieldPrecedenceIncoming)
+				continue
+			}
+			added[s] = append(added[s], i)
+			ids[s][n.Id] = struct{}{}
+		}
+	})
+
+	ret := &NodeList{
+		Nodes:        nodes,
+		RootElements: mo.rootElements(nl.RootElements, nl2.RootElements),
+	}
+	for _, i := range collectPositions(added) {
+		ret.Nodes = append(ret.Nodes, nl2.Nodes[i])
+	}
+
+	edges, edges2 := mo.edgesToMerge(nl.Edges, nl2.Edges)
+	ret.Edges = shardedMergeEdges([][]*Edge{edges, edges2}, ids)
+
+	return ret
+}
+
+// shardedIntersect is the parallel implementation of Intersect
+func (nl *NodeList) shardedIntersect(nl2 *NodeList, shards int, mo *MergeOptions) *NodeList {
+	pos1 := shardPositions(nl.Nodes, shards)
+	pos2 := shardPositions(nl2.Nodes, shards)
+
+	nodes := make([]*Node, len(nl.Nodes))
+	ids := make([]map[string]struct{}, shards)
+
+	runShards(shards, func(s int) {
+		others := make(map[string]*Node, len(pos2[s]))
+		for _, i := range pos2[s] {
+			others[nl2.Nodes[i].Id] = nl2.Nodes[i]
+		}
+
+		// When a list has duplicate IDs, the last node wins
+		latest := map[string]int{}
+		for _, i := range pos1[s] {
+			if _, ok := others[nl.Nodes[i].Id]; ok {
+				latest[nl.Nodes[i].Id] = i
+			}
+		}
+
+		ids[s] = make(map[string]struct{}, len(latest))
+		for id, i := range latest {
+			nodes[i] = nl.Nodes[i].Copy()
+			mo.mergeNode(nodes[i], others[id], FieldPrecedenceIncoming)
+			ids[s][id] = struct{}{}
+		}
+	})
+
+	rootElements := map[string]struct{}{}
+	for _, id := range mo.rootElements(nl.RootElements, nl2.RootElements) {
+		rootElements[id] = struct{}{}
+	}
+	ret := &NodeList{
+		Nodes:        []*Node{},
+		RootElements: []string{},
+	}
+	for _, n := range nodes {
+		if n == nil {
+			continue
+		}
+		ret.Nodes = append(ret.Nodes, n)
+		if _, ok := rootElements[n.Id]; ok {
+			ret.RootElements = append(ret.RootElements, n.Id)
+		}
+	}
+
+	edges, edges2 := mo.edgesToMerge(nl.Edges, nl2.Edges)
+	ret.Edges = shardedMergeEdges([][]*Edge{edges, edges2}, ids)
+	return ret
+}
+
+// Dedupe merges the nodes in the NodeList that share the same ID. The first
+// node with each ID is kept and augmented with the data of its duplicates.
+// Edges are consolidated by source and type and the root elements list is
+// deduplicated. Dedupe modifies the NodeList in place.
+//
+// When the comparisons ignore fields (see WithIgnoredFields), nodes equal
+// to a previous one except in the ignored fields are merged into it too,
+// regardless of their IDs.
+func (nl *NodeList) Dedupe(opts ...NodeListOption) {
+	o := buildNodeListOptions(opts)
+	op := o.startOperation(telemetry.SpanDedupe, telemetry.Int("nodes", len(nl.Nodes)))
+	defer op.End(nil)
+
+	if len(o.ignoredFields) > 0 {
+		first := map[string]string{}
+		renames := map[string]string{}
+		for _, n := range nl.Nodes {
+			key := n.semanticString(o.ignoredFields)
+			if id, ok := first[key]; ok && id != n.Id {
+				renames[n.Id] = id
+				continue
+			}
+			first[key] = n.Id
+		}
+		nl.renameNodes(renames)
+	}
+
+	shards := o.parallelism
+	positions := shardPositions(nl.Nodes, shards)
+
+	keep := make([][]int, shards)
+	ids := make([]map[string]struct{}, shards)
+
+	runShards(shards, func(s int) {
+		first := map[string]*Node{}
+		for _, i := range positions[s] {
+			n := nl.Nodes[i]
+			if f, ok := first[n.Id]; ok {
+				f.Augment(n)
+				continue
+			}
+			first[n.Id] = n
+			keep[s] = append(keep[s], i)
+		}
+		ids[s] = make(map[string]struct{}, len(first))
+		for id := range first {
+			ids[s][id] = struct{}{}
+		}
+	})
+
+	nodes := make([]*Node, 0, len(nl.Nodes))
+	for _, i := range collectPositions(keep) {
+		nodes = append(nodes, nl.Nodes[i])
+	}
+	nl.Nodes = nodes
+	nl.Edges = shardedMergeEdges([][]*Edge{nl.Edges}, ids)
+
+	seen := map[string]struct{}{}
+	roots := []string{}
+	for _, id := range nl.RootElements {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		roots = append(roots, id)
+	}
+	nl.RootElements = roots
+}
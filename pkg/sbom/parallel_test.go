@@ -0,0 +1,188 @@
+package sbom
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/telemetry"
+)
+
+// testShardedNodeList returns a nodelist with count nodes starting at offset,
+// every node depends on the next one and the first one is the root.
+func testShardedNodeList(offset, count int, version string) *NodeList {
+	nl := NewNodeList()
+	for i := offset; i < offset+count; i++ {
+		id := fmt.Sprintf("node-%04d", i)
+		nl.AddNode(&Node{Id: id, Name: id, Version: version})
+		if i > offset {
+			nl.AddEdge(&Edge{Type: Edge_dependsOn, From: fmt.Sprintf("node-%04d", i-1), To: []string{id}})
+		}
+	}
+	nl.RootElements = []string{fmt.Sprintf("node-%04d", offset)}
+	return nl
+}
+
+func TestShardedUnion(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// offset and count define the nodes of the second nodelist, the
+		// first one always has node-0000 to node-0299
+		offset      int
+		count       int
+		parallelism int
+		nodes       int
+	}{
+		{name: "overlapping in two shards", offset: 200, count: 300, parallelism: 2, nodes: 500},
+		{name: "overlapping in three shards", offset: 200, count: 300, parallelism: 3, nodes: 500},
+		{name: "overlapping in eight shards", offset: 200, count: 300, parallelism: 8, nodes: 500},
+		{name: "disjoint", offset: 300, count: 100, parallelism: 4, nodes: 400},
+		{name: "contained", offset: 50, count: 100, parallelism: 4, nodes: 300},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl1 := testShardedNodeList(0, 300, "1.0")
+			nl2 := testShardedNodeList(tc.offset, tc.count, "2.0")
+
+			parallel := nl1.Union(nl2, WithParallelism(tc.parallelism))
+			require.Len(t, parallel.Nodes, tc.nodes)
+			require.True(t, nl1.Union(nl2).Equal(parallel))
+
+			// Node order is deterministic
+			require.Equal(t, "node-0000", parallel.Nodes[0].Id)
+			require.Equal(t, fmt.Sprintf("node-%04d", tc.nodes-1), parallel.Nodes[tc.nodes-1].Id)
+			require.Equal(t, "2.0", parallel.GetNodeByID(fmt.Sprintf("node-%04d", tc.offset)).Version)
+
+			// The original nodelists are not modified
+			require.Equal(t, "1.0", nl1.GetNodeByID("node-0000").Version)
+			require.Len(t, nl1.Nodes, 300)
+		})
+	}
+}
+
+func TestShardedIntersect(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		offset      int
+		count       int
+		parallelism int
+		nodes       int
+		roots       []string
+	}{
+		{name: "overlapping in two shards", offset: 200, count: 300, parallelism: 2, nodes: 100, roots: []string{"node-0200"}},
+		{name: "overlapping in three shards", offset: 200, count: 300, parallelism: 3, nodes: 100, roots: []string{"node-0200"}},
+		{name: "overlapping in eight shards", offset: 200, count: 300, parallelism: 8, nodes: 100, roots: []string{"node-0200"}},
+		{name: "contained", offset: 50, count: 100, parallelism: 4, nodes: 100, roots: []string{"node-0050"}},
+		{name: "disjoint", offset: 300, count: 100, parallelism: 4, nodes: 0, roots: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl1 := testShardedNodeList(0, 300, "1.0")
+			nl2 := testShardedNodeList(tc.offset, tc.count, "2.0")
+
+			parallel := nl1.Intersect(nl2, WithParallelism(tc.parallelism))
+			require.Len(t, parallel.Nodes, tc.nodes)
+			require.True(t, nl1.Intersect(nl2).Equal(parallel))
+			require.ElementsMatch(t, tc.roots, parallel.RootElements)
+			if tc.nodes > 0 {
+				require.Equal(t, fmt.Sprintf("node-%04d", tc.offset), parallel.Nodes[0].Id)
+			}
+		})
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		parallelism int
+	}{
+		{name: "serial", parallelism: 1},
+		{name: "parallel", parallelism: 4},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := testShardedNodeList(0, 50, "")
+			dupes := testShardedNodeList(0, 50, "1.0")
+			nl.Nodes = append(nl.Nodes, dupes.Nodes...)
+			nl.Edges = append(nl.Edges, dupes.Edges...)
+			nl.RootElements = append(nl.RootElements, dupes.RootElements...)
+			nl.Edges = append(nl.Edges, &Edge{Type: Edge_dependsOn, From: "node-0000", To: []string{"missing"}})
+
+			nl.Dedupe(WithParallelism(tc.parallelism))
+			require.Len(t, nl.Nodes, 50)
+			require.Len(t, nl.Edges, 49)
+			require.Equal(t, []string{"node-0000"}, nl.RootElements)
+			require.Equal(t, "node-0000", nl.Nodes[0].Id)
+
+			// Duplicates augment the first node
+			require.Equal(t, "1.0", nl.GetNodeByID("node-0010").Version)
+			require.Equal(t, []string{"node-0001"}, nl.GetEdgeByType("node-0000", Edge_dependsOn).To)
+		})
+	}
+}
+
+func BenchmarkUnion(b *testing.B) {
+	nl1 := testShardedNodeList(0, 5000, "1.0")
+	nl2 := testShardedNodeList(2500, 5000, "2.0")
+	for _, n := range []int{1, 4} {
+		b.Run(fmt.Sprintf("parallelism-%d", n), func(b *testing.B) {
+			for range b.N {
+				nl1.Union(nl2, WithParallelism(n))
+			}
+		})
+	}
+}
+
+func TestNodeListTelemetry(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		op         func(ctx context.Context, nl1, nl2 *NodeList)
+		spans      []string
+		attributes map[string]any
+	}{
+		{
+			name: "union",
+			op: func(ctx context.Context, nl1, nl2 *NodeList) {
+				nl1.Union(nl2, WithContext(ctx), WithParallelism(2))
+			},
+			spans:      []string{telemetry.SpanUnion},
+			attributes: map[string]any{"nodes": int64(10), "parallelism": int64(2)},
+		},
+		{
+			name: "intersect",
+			op: func(ctx context.Context, nl1, nl2 *NodeList) {
+				nl1.Intersect(nl2, WithContext(ctx))
+			},
+			spans: []string{telemetry.SpanIntersect},
+		},
+		{
+			name: "dedupe",
+			op: func(ctx context.Context, nl1, _ *NodeList) {
+				nl1.Dedupe(WithContext(ctx))
+			},
+			spans: []string{telemetry.SpanDedupe},
+		},
+		{
+			name: "no context",
+			op: func(_ context.Context, nl1, nl2 *NodeList) {
+				nl1.Union(nl2)
+			},
+			spans: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := telemetry.NewRecorder()
+			ctx := telemetry.ContextWithTelemetry(context.Background(), rec.Telemetry())
+			tc.op(ctx, testShardedNodeList(0, 10, "1.0"), testShardedNodeList(5, 10, "2.0"))
+
+			names := []string{}
+			for _, s := range rec.Spans() {
+				require.True(t, s.Ended)
+				names = append(names, s.Name)
+			}
+			require.Equal(t, tc.spans, names)
+			for k, v := range tc.attributes {
+				require.Equal(t, v, rec.Spans()[0].Attributes[k])
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+package sbom
+
+import (
+	"strings"
+
+	"github.com/protobom/protobom/pkg/formats/spdx"
+)
+
+// ProvenanceReport lists the nodes of unknown provenance in a NodeList,
+// grouped by the subtree of the graph where they are found.
+type ProvenanceReport struct {
+	// Groups has one entry per subtree with findings, in graph order
+	Groups []*ProvenanceGroup
+}
+
+// ProvenanceGroup collects the findings of a subtree. Subtrees are rooted
+// at the root elements and their direct descendants. Nodes not reachable
+// from any root are grouped under an empty RootID.
+type ProvenanceGroup struct {
+	RootID   string
+	Name     string
+	Findings []*ProvenanceFinding
+}
+
+// ProvenanceFinding describes a node of unknown provenance
+type ProvenanceFinding struct {
+	NodeID  string
+	Name    string
+	Version string
+
+	// MissingIdentifier is set when the node has no purl, CPE, gitoid or
+	// hash that can be used to verify what the component is.
+	MissingIdentifier bool
+
+	// MissingSupplier is set when the node has no supplier data.
+	MissingSupplier bool
+}
+
+// Len returns the total number of findings in the report
+func (r *ProvenanceReport) Len() int {
+	n := 0
+	for _, g := range r.Groups {
+		n += len(g.Findings)
+	}
+	return n
+}
+
+// HasVerifiableIdentifier returns true if the node has a software
+// identifier or a hash that can be used to verify the component.
+func (n *Node) HasVerifiableIdentifier() bool {
+	for t, v := range n.Identifiers {
+		if t != int32(SoftwareIdentifierType_UNKNOWN_IDENTIFIER_TYPE) && strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	for _, v := range n.Hashes {
+		if strings.TrimSpace(v) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSupplier returns true if the node has at least one supplier with a
+// name, email or URL.
+func (n *Node) HasSupplier() bool {
+	for _, s := range n.Suppliers {
+		name := strings.TrimSpace(s.GetName())
+		if (name != "" && name != spdx.NOASSERTION) || s.GetEmail() != "" || s.GetUrl() != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// UnknownProvenance analyzes the nodes in the NodeList and reports those
+// lacking a verifiable identifier or supplier data. Findings are grouped
+// by subtree so reviewers can see which parts of the graph need work.
+func (nl *NodeList) UnknownProvenance() *ProvenanceReport {
+	report := &ProvenanceReport{Groups: []*ProvenanceGroup{}}
+	nodes := nl.indexNodes()
+	assigned := map[string]struct{}{}
+
+	// Index the destinations of each node, keeping the edge order
+	children := map[string][]string{}
+	for _, e := range nl.Edges {
+		children[e.From] = append(children[e.From], e.To...)
+	}
+
+	addGroup := func(rootID string, members []string) {
+		group := &ProvenanceGroup{RootID: rootID, Findings: []*ProvenanceFinding{}}
+		if n, ok := nodes[rootID]; ok {
+			group.Name = n.Name
+		}
+		for _, id := range members {
+			n, ok := nodes[id]
+			if !ok {
+				continue
+			}
+			f := &ProvenanceFinding{
+				NodeID:            n.Id,
+				Name:              n.Name,
+				Version:           n.Version,
+				MissingIdentifier: !n.HasVerifiableIdentifier(),
+				MissingSupplier:   !n.HasSupplier(),
+			}
+			if f.MissingIdentifier || f.MissingSupplier {
+				group.Findings = append(group.Findings, f)
+			}
+		}
+		if len(group.Findings) > 0 {
+			report.Groups = append(report.Groups, group)
+		}
+	}
+
+	// collect walks the graph from id, returning the nodes not assigned
+	// to a group yet.
+	collect := func(id string) []string {
+		members := []string{}
+		queue := []string{id}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			if _, ok := assigned[current]; ok {
+				continue
+			}
+			assigned[current] = struct{}{}
+			members = append(members, current)
+			queue = append(queue, children[current]...)
+		}
+		return members
+	}
+
+	for _, rootID := range nl.RootElements {
+		if _, ok := assigned[rootID]; ok {
+			continue
+		}
+		assigned[rootID] = struct{}{}
+		addGroup(rootID, []string{rootID})
+
+		for _, id := range children[rootID] {
+			if members := collect(id); len(members) > 0 {
+				addGroup(id, members)
+			}
+		}
+	}
+
+	// Nodes not reachable from the roots
+	unattached := []string{}
+	for _, n := range nl.Nodes {
+		if _, ok := assigned[n.Id]; !ok {
+			assigned[n.Id] = struct{}{}
+			unattached = append(unattached, n.Id)
+		}
+	}
+	addGroup("", unattached)
+
+	return report
+}
@@ -0,0 +1,87 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownProvenance(t *testing.T) {
+	supplier := []*Person{{Name: "ACME"}}
+	purl := map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:generic/x@1"}
+
+	for _, tc := range []struct {
+		name     string
+		sut      *NodeList
+		len      int
+		expected []*ProvenanceGroup
+	}{
+		{
+			name: "grouped by top level component",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", Name: "app", Suppliers: supplier, Identifiers: purl},
+					{Id: "a", Name: "a", Suppliers: supplier, Identifiers: purl},
+					{Id: "a1", Name: "a1", Suppliers: supplier},
+					{Id: "b", Name: "b", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "abc"}},
+					{Id: "b1", Name: "b1", Suppliers: []*Person{{Name: "NOASSERTION"}}},
+					{Id: "c", Name: "c", Suppliers: supplier, Identifiers: purl},
+					{Id: "orphan", Name: "orphan", Suppliers: supplier},
+				},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "app", To: []string{"a", "b", "c"}},
+					{Type: Edge_dependsOn, From: "a", To: []string{"a1", "b1"}},
+					{Type: Edge_dependsOn, From: "b", To: []string{"b1"}},
+				},
+				RootElements: []string{"app"},
+			},
+			len: 4,
+			expected: []*ProvenanceGroup{
+				{
+					RootID: "a", Name: "a",
+					Findings: []*ProvenanceFinding{
+						{NodeID: "a1", Name: "a1", MissingIdentifier: true},
+						{NodeID: "b1", Name: "b1", MissingIdentifier: true, MissingSupplier: true},
+					},
+				},
+				{
+					RootID: "b", Name: "b",
+					Findings: []*ProvenanceFinding{
+						{NodeID: "b", Name: "b", MissingSupplier: true},
+					},
+				},
+				{
+					RootID: "",
+					Findings: []*ProvenanceFinding{
+						{NodeID: "orphan", Name: "orphan", MissingIdentifier: true},
+					},
+				},
+			},
+		},
+		{
+			name: "known provenance",
+			sut: &NodeList{
+				Nodes: []*Node{
+					{Id: "app", Name: "app", Suppliers: supplier, Identifiers: purl},
+					{Id: "a", Name: "a", Suppliers: supplier, Identifiers: purl},
+				},
+				Edges:        []*Edge{{Type: Edge_contains, From: "app", To: []string{"a"}}},
+				RootElements: []string{"app"},
+			},
+		},
+		{
+			name: "empty node list",
+			sut:  NewNodeList(),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			report := tc.sut.UnknownProvenance()
+			require.Equal(t, tc.len, report.Len())
+			if tc.expected == nil {
+				require.Empty(t, report.Groups)
+				return
+			}
+			require.Equal(t, tc.expected, report.Groups)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package sbom
+
+import "slices"
+
+// Prune removes the nodes matching the predicate from the NodeList.
+//
+// When restitch is false, the edges to the removed nodes are dropped, which
+// may leave their descendants disconnected. When restitch is true, removed
+// nodes are collapsed: edges pointing to them are rewired to their children,
+// keeping the type of the edge from the parent, and removed root elements
+// are replaced by their children. This is useful to cut wrapper or
+// metapackage nodes out of the graph without orphaning their subtrees.
+func (nl *NodeList) Prune(pred func(*Node) bool, restitch bool) {
+	pruned := map[string]struct{}{}
+	for _, n := range nl.Nodes {
+		if pred(n) {
+			pruned[n.Id] = struct{}{}
+		}
+	}
+	if len(pruned) == 0 {
+		return
+	}
+
+	children := map[string][]string{}
+	for _, e := range nl.Edges {
+		children[e.From] = append(children[e.From], e.To...)
+	}
+
+	// replacements returns the IDs that take the place of id in the graph:
+	// the ID itself if it is kept or its nearest kept descendants.
+	var replacements func(id string, seen map[string]struct{}) []string
+	replacements = func(id string, seen map[string]struct{}) []string {
+		if _, ok := pruned[id]; !ok {
+			return []string{id}
+		}
+		if !restitch {
+			return nil
+		}
+		if _, ok := seen[id]; ok {
+			return nil
+		}
+		seen[id] = struct{}{}
+		ret := []string{}
+		for _, child := range children[id] {
+			ret = append(ret, replacements(child, seen)...)
+		}
+		return ret
+	}
+
+	edges := []*Edge{}
+	for _, e := range nl.Edges {
+		if _, ok := pruned[e.From]; ok {
+			continue
+		}
+		to := []string{}
+		for _, id := range e.To {
+			for _, r := range replacements(id, map[string]struct{}{}) {
+				// Skip the loops created when collapsing cycles
+				if r != e.From && !slices.Contains(to, r) {
+					to = append(to, r)
+				}
+			}
+		}
+		if len(to) > 0 {
+			edges = append(edges, &Edge{Type: e.Type, From: e.From, To: to})
+		}
+	}
+
+	roots := []string{}
+	for _, id := range nl.RootElements {
+		for _, r := range replacements(id, map[string]struct{}{}) {
+			if !slices.Contains(roots, r) {
+				roots = append(roots, r)
+			}
+		}
+	}
+
+	nodes := []*Node{}
+	for _, n := range nl.Nodes {
+		if _, ok := pruned[n.Id]; !ok {
+			nodes = append(nodes, n)
+		}
+	}
+
+	nl.Nodes = nodes
+	nl.Edges = edges
+	nl.RootElements = roots
+}
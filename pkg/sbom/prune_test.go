@@ -0,0 +1,91 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	isMeta := func(n *Node) bool { return n.Name == "metapackage" }
+	isApp := func(n *Node) bool { return n.Id == "app" }
+
+	for _, tc := range []struct {
+		name      string
+		match     func(*Node) bool
+		reconnect bool
+		nodes     []string
+		roots     []string
+		edges     []*Edge
+	}{
+		{
+			name:      "collapse metapackages",
+			match:     isMeta,
+			reconnect: true,
+			nodes:     []string{"app", "a", "b", "c"},
+			roots:     []string{"app"},
+			edges:     []*Edge{{Type: Edge_dependsOn, From: "app", To: []string{"a", "b", "c"}}},
+		},
+		{
+			name:  "drop metapackages",
+			match: isMeta,
+			nodes: []string{"app", "a", "b", "c"},
+			roots: []string{"app"},
+			edges: []*Edge{{Type: Edge_dependsOn, From: "app", To: []string{"c"}}},
+		},
+		{
+			// Collapsing a root promotes its children
+			name:      "collapse root",
+			match:     isApp,
+			reconnect: true,
+			nodes:     []string{"meta", "inner", "a", "b", "c"},
+			roots:     []string{"meta", "c"},
+			edges: []*Edge{
+				{Type: Edge_contains, From: "meta", To: []string{"a", "inner"}},
+				{Type: Edge_contains, From: "inner", To: []string{"b", "c", "meta"}},
+			},
+		},
+		{
+			name:  "drop root",
+			match: isApp,
+			nodes: []string{"meta", "inner", "a", "b", "c"},
+			roots: []string{},
+			edges: []*Edge{
+				{Type: Edge_contains, From: "meta", To: []string{"a", "inner"}},
+				{Type: Edge_contains, From: "inner", To: []string{"b", "c", "meta"}},
+			},
+		},
+		{
+			name:      "nothing matches",
+			match:     func(*Node) bool { return false },
+			reconnect: true,
+			nodes:     []string{"app", "meta", "inner", "a", "b", "c"},
+			roots:     []string{"app"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes: []*Node{
+					{Id: "app"}, {Id: "meta", Name: "metapackage"}, {Id: "inner", Name: "metapackage"},
+					{Id: "a"}, {Id: "b"}, {Id: "c"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"meta", "c"}},
+					{Type: Edge_contains, From: "meta", To: []string{"a", "inner"}},
+					{Type: Edge_contains, From: "inner", To: []string{"b", "c", "meta"}},
+				},
+				RootElements: []string{"app"},
+			}
+			original := nl.Copy()
+
+			nl.Prune(tc.match, tc.reconnect)
+			require.Equal(t, tc.nodes, ids(nl.Nodes))
+			require.Equal(t, tc.roots, nl.RootElements)
+			if tc.edges == nil {
+				require.True(t, nl.Equal(original))
+				return
+			}
+			require.Equal(t, tc.edges, nl.Edges)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package sbom
+
+import (
+	"slices"
+	"strings"
+)
+
+// PurlQualifierIndex indexes the nodes of a NodeList by the qualifiers in
+// their package URLs to run repeated qualifier queries without scanning the
+// nodes each time. The index captures the nodes and edges when it is built,
+// changes to the NodeList are not reflected until the index is built again.
+type PurlQualifierIndex struct {
+	nodes []*Node
+
+	// qualifiers maps the qualifier keys and values to the nodes having
+	// them and positions has the position of each node in nodes.
+	qualifiers purlQualifierIndex
+	positions  map[*Node]int
+
+	// edges has the edges of the NodeList and edgesFrom the positions of
+	// the edges of each source node.
+	edges     []*Edge
+	edgesFrom map[string][]int
+}
+
+// NewPurlQualifierIndex builds an index of the NodeList nodes by the
+// qualifiers in their package URLs.
+func NewPurlQualifierIndex(nl *NodeList) *PurlQualifierIndex {
+	idx := &PurlQualifierIndex{
+		nodes:      slices.Clone(nl.GetNodes()),
+		qualifiers: purlQualifierIndex{},
+		positions:  map[*Node]int{},
+		edges:      slices.Clone(nl.GetEdges()),
+		edgesFrom:  map[string][]int{},
+	}
+	for i, n := range idx.nodes {
+		idx.positions[n] = i
+		for k, v := range n.Purl().Qualifiers() {
+			if _, ok := idx.qualifiers[k]; !ok {
+				idx.qualifiers[k] = map[string][]*Node{}
+			}
+			idx.qualifiers[k][v] = append(idx.qualifiers[k][v], n)
+		}
+	}
+	for i, e := range idx.edges {
+		idx.edgesFrom[e.From] = append(idx.edgesFrom[e.From], i)
+	}
+	return idx
+}
+
+// GetNodesByPurlQualifier returns a new NodeList with the indexed nodes that
+// have a qualifier key with the specified value in their package URL and
+// their relationships, see NodeList.GetNodesByPurlQualifier.
+func (idx *PurlQualifierIndex) GetNodesByPurlQualifier(key, value string, opts ...NodeListOption) *NodeList {
+	ret := &NodeList{}
+	values, ok := idx.qualifiers[strings.ToLower(key)]
+	if !ok {
+		return ret
+	}
+
+	if value != "" {
+		ret.Nodes = append(ret.Nodes, values[value]...)
+	} else {
+		// Preserve the original node order when collecting all values
+		seen := map[*Node]struct{}{}
+		for _, nodes := range values {
+			for _, n := range nodes {
+				if _, ok := seen[n]; !ok {
+					seen[n] = struct{}{}
+					ret.Nodes = append(ret.Nodes, n)
+				}
+			}
+		}
+		slices.SortFunc(ret.Nodes, func(a, b *Node) int {
+			return idx.positions[a] - idx.positions[b]
+		})
+	}
+
+	// Copy the edges of the selected nodes in their original order
+	edges := []int{}
+	for id := range ret.indexNodes() {
+		edges = append(edges, idx.edgesFrom[id]...)
+	}
+	slices.Sort(edges)
+	for _, i := range edges {
+		ret.Edges = append(ret.Edges, idx.edges[i].Copy())
+	}
+
+	ret.cleanEdges()
+	ret.applyQueryOrphanPolicy(buildNodeListOptions(opts).orphanPolicy)
+
+	return ret
+}
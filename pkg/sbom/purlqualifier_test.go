@@ -0,0 +1,103 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurlQualifierIndex(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		qualifier string
+		value     string
+		nodes     []string
+		edgesFrom []string
+	}{
+		{
+			// Nodes keep their original order across qualifier values
+			name:      "any value",
+			qualifier: "ARCH",
+			nodes:     []string{"arm64", "amd64", "deb"},
+			edgesFrom: []string{"amd64", "deb"},
+		},
+		{
+			name:      "value",
+			qualifier: "arch",
+			value:     "amd64",
+			nodes:     []string{"amd64", "deb"},
+			edgesFrom: []string{"amd64", "deb"},
+		},
+		{
+			name:      "single node",
+			qualifier: "distro",
+			value:     "debian-12",
+			nodes:     []string{"deb"},
+			edgesFrom: []string{},
+		},
+		{
+			name:      "no matches",
+			qualifier: "distro",
+			value:     "debian-11",
+			nodes:     []string{},
+			edgesFrom: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			purl := func(p string) map[int32]string {
+				return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+			}
+			nl := &NodeList{
+				Nodes: []*Node{
+					{Id: "image", Identifiers: purl("pkg:oci/image@sha256%3Aabc")},
+					{Id: "arm64", Identifiers: purl("pkg:oci/image@sha256%3A456?arch=arm64&os=linux")},
+					{Id: "amd64", Identifiers: purl("pkg:oci/image@sha256%3A123?arch=amd64&os=linux")},
+					{Id: "deb", Identifiers: purl("pkg:deb/debian/curl@8.0?arch=amd64&distro=debian-12")},
+				},
+				Edges: []*Edge{
+					{Type: Edge_contains, From: "image", To: []string{"amd64", "arm64"}},
+					{Type: Edge_contains, From: "amd64", To: []string{"deb"}},
+					{Type: Edge_dependsOn, From: "deb", To: []string{"amd64"}},
+				},
+				RootElements: []string{"image"},
+			}
+
+			res := NewPurlQualifierIndex(nl).GetNodesByPurlQualifier(tc.qualifier, tc.value)
+			require.Equal(t, tc.nodes, ids(res.Nodes))
+			from := []string{}
+			for _, e := range res.Edges {
+				from = append(from, e.From)
+			}
+			require.Equal(t, tc.edgesFrom, from)
+
+			// The index returns the same nodes as the NodeList method
+			require.Equal(t, tc.nodes, ids(nl.GetNodesByPurlQualifier(tc.qualifier, tc.value).Nodes))
+		})
+	}
+}
+
+func TestPurlQualifierIndexStale(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		rebuild bool
+		nodes   int
+	}{
+		// Changes to the NodeList are not reflected until the index is rebuilt
+		{name: "stale index", nodes: 0},
+		{name: "rebuilt index", rebuild: true, nodes: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{{Id: "image", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:oci/image@sha256%3A123?arch=amd64",
+			}}}}
+			idx := NewPurlQualifierIndex(nl)
+			nl.AddNode(&Node{Id: "riscv", Identifiers: map[int32]string{
+				int32(SoftwareIdentifierType_PURL): "pkg:oci/image@sha256%3A789?arch=riscv64",
+			}})
+			if tc.rebuild {
+				idx = NewPurlQualifierIndex(nl)
+			}
+			require.Len(t, idx.GetNodesByPurlQualifier("arch", "riscv64").Nodes, tc.nodes)
+		})
+	}
+}
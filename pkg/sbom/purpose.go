@@ -0,0 +1,205 @@
+package sbom
+
+import (
+	cdx "github.com/CycloneDX/cyclonedx-go"
+)
+
+// SPDX 2.3 primary package purposes
+const (
+	SPDX2PurposeApplication     = "APPLICATION"
+	SPDX2PurposeFramework       = "FRAMEWORK"
+	SPDX2PurposeLibrary         = "LIBRARY"
+	SPDX2PurposeContainer       = "CONTAINER"
+	SPDX2PurposeOperatingSystem = "OPERATING-SYSTEM"
+	SPDX2PurposeDevice          = "DEVICE"
+	SPDX2PurposeFirmware        = "FIRMWARE"
+	SPDX2PurposeSource          = "SOURCE"
+	SPDX2PurposeArchive         = "ARCHIVE"
+	SPDX2PurposeFile            = "FILE"
+	SPDX2PurposeInstall         = "INSTALL"
+	SPDX2PurposeOther           = "OTHER"
+)
+
+// purposesToSPDX2 maps the protobom purposes to SPDX 2.3 primary package
+// purposes. Purposes with no SPDX equivalent are written as OTHER:
+//
+//	APPLICATION, EXECUTABLE  -> APPLICATION
+//	LIBRARY, MODULE          -> LIBRARY
+//	DEVICE, DEVICE_DRIVER    -> DEVICE
+//	SOURCE, PATCH            -> SOURCE
+//	FRAMEWORK, CONTAINER, OPERATING_SYSTEM, FIRMWARE, ARCHIVE, FILE and
+//	INSTALL map to the SPDX purpose of the same name. Everything else is OTHER.
+var purposesToSPDX2 = map[Purpose]string{
+	Purpose_APPLICATION:            SPDX2PurposeApplication,
+	Purpose_EXECUTABLE:             SPDX2PurposeApplication,
+	Purpose_FRAMEWORK:              SPDX2PurposeFramework,
+	Purpose_LIBRARY:                SPDX2PurposeLibrary,
+	Purpose_MODULE:                 SPDX2PurposeLibrary,
+	Purpose_CONTAINER:              SPDX2PurposeContainer,
+	Purpose_OPERATING_SYSTEM:       SPDX2PurposeOperatingSystem,
+	Purpose_DEVICE:                 SPDX2PurposeDevice,
+	Purpose_DEVICE_DRIVER:          SPDX2PurposeDevice,
+	Purpose_FIRMWARE:               SPDX2PurposeFirmware,
+	Purpose_SOURCE:                 SPDX2PurposeSource,
+	Purpose_PATCH:                  SPDX2PurposeSource,
+	Purpose_ARCHIVE:                SPDX2PurposeArchive,
+	Purpose_FILE:                   SPDX2PurposeFile,
+	Purpose_INSTALL:                SPDX2PurposeInstall,
+	Purpose_OTHER:                  SPDX2PurposeOther,
+	Purpose_DATA:                   SPDX2PurposeOther,
+	Purpose_BOM:                    SPDX2PurposeOther,
+	Purpose_CONFIGURATION:          SPDX2PurposeOther,
+	Purpose_DOCUMENTATION:          SPDX2PurposeOther,
+	Purpose_EVIDENCE:               SPDX2PurposeOther,
+	Purpose_MANIFEST:               SPDX2PurposeOther,
+	Purpose_REQUIREMENT:            SPDX2PurposeOther,
+	Purpose_SPECIFICATION:          SPDX2PurposeOther,
+	Purpose_TEST:                   SPDX2PurposeOther,
+	Purpose_MACHINE_LEARNING_MODEL: SPDX2PurposeOther,
+	Purpose_MODEL:                  SPDX2PurposeOther,
+	Purpose_PLATFORM:               SPDX2PurposeOther,
+}
+
+// spdx2ToPurposes maps each SPDX 2.3 primary package purpose to the
+// protobom purpose of the same name.
+var spdx2ToPurposes = map[string]Purpose{
+	SPDX2PurposeApplication:     Purpose_APPLICATION,
+	SPDX2PurposeFramework:       Purpose_FRAMEWORK,
+	SPDX2PurposeLibrary:         Purpose_LIBRARY,
+	SPDX2PurposeContainer:       Purpose_CONTAINER,
+	SPDX2PurposeOperatingSystem: Purpose_OPERATING_SYSTEM,
+	SPDX2PurposeDevice:          Purpose_DEVICE,
+	SPDX2PurposeFirmware:        Purpose_FIRMWARE,
+	SPDX2PurposeSource:          Purpose_SOURCE,
+	SPDX2PurposeArchive:         Purpose_ARCHIVE,
+	SPDX2PurposeFile:            Purpose_FILE,
+	SPDX2PurposeInstall:         Purpose_INSTALL,
+	SPDX2PurposeOther:           Purpose_OTHER,
+}
+
+// purposesToCDX maps the protobom purposes to CycloneDX component types:
+//
+//	APPLICATION, EXECUTABLE, INSTALL        -> application
+//	LIBRARY, MODULE                         -> library
+//	FILE, SOURCE, PATCH, ARCHIVE            -> file
+//	MACHINE_LEARNING_MODEL, MODEL           -> machine-learning-model
+//	FRAMEWORK, CONTAINER, PLATFORM, OPERATING_SYSTEM, DEVICE, DEVICE_DRIVER
+//	and FIRMWARE map to the type of the same name. Everything else is data.
+var purposesToCDX = map[Purpose]cdx.ComponentType{
+	Purpose_APPLICATION:            cdx.ComponentTypeApplication,
+	Purpose_EXECUTABLE:             cdx.ComponentTypeApplication,
+	Purpose_INSTALL:                cdx.ComponentTypeApplication,
+	Purpose_FRAMEWORK:              cdx.ComponentTypeFramework,
+	Purpose_LIBRARY:                cdx.ComponentTypeLibrary,
+	Purpose_MODULE:                 cdx.ComponentTypeLibrary,
+	Purpose_CONTAINER:              cdx.ComponentTypeContainer,
+	Purpose_PLATFORM:               cdx.ComponentTypePlatform,
+	Purpose_OPERATING_SYSTEM:       cdx.ComponentTypeOS,
+	Purpose_DEVICE:                 cdx.ComponentTypeDevice,
+	Purpose_DEVICE_DRIVER:          cdx.ComponentTypeDeviceDriver,
+	Purpose_FIRMWARE:               cdx.ComponentTypeFirmware,
+	Purpose_FILE:                   cdx.ComponentTypeFile,
+	Purpose_SOURCE:                 cdx.ComponentTypeFile,
+	Purpose_PATCH:                  cdx.ComponentTypeFile,
+	Purpose_ARCHIVE:                cdx.ComponentTypeFile,
+	Purpose_MACHINE_LEARNING_MODEL: cdx.ComponentTypeMachineLearningModel,
+	Purpose_MODEL:                  cdx.ComponentTypeMachineLearningModel,
+	Purpose_DATA:                   cdx.ComponentTypeData,
+	Purpose_BOM:                    cdx.ComponentTypeData,
+	Purpose_CONFIGURATION:          cdx.ComponentTypeData,
+	Purpose_DOCUMENTATION:          cdx.ComponentTypeData,
+	Purpose_EVIDENCE:               cdx.ComponentTypeData,
+	Purpose_MANIFEST:               cdx.ComponentTypeData,
+	Purpose_REQUIREMENT:            cdx.ComponentTypeData,
+	Purpose_SPECIFICATION:          cdx.ComponentTypeData,
+	Purpose_TEST:                   cdx.ComponentTypeData,
+	Purpose_OTHER:                  cdx.ComponentTypeData,
+}
+
+// purposesToSPDX3 maps the protobom purposes to the SPDX 3.0 software
+// purposes. DEVICE_DRIVER is written as device, MACHINE_LEARNING_MODEL as
+// model and PLATFORM as other, the rest map to the purpose of the same name.
+var purposesToSPDX3 = map[Purpose]string{
+	Purpose_APPLICATION:            "application",
+	Purpose_ARCHIVE:                "archive",
+	Purpose_BOM:                    "bom",
+	Purpose_CONFIGURATION:          "configuration",
+	Purpose_CONTAINER:              "container",
+	Purpose_DATA:                   "data",
+	Purpose_DEVICE:                 "device",
+	Purpose_DEVICE_DRIVER:          "device",
+	Purpose_DOCUMENTATION:          "documentation",
+	Purpose_EVIDENCE:               "evidence",
+	Purpose_EXECUTABLE:             "executable",
+	Purpose_FILE:                   "file",
+	Purpose_FIRMWARE:               "firmware",
+	Purpose_FRAMEWORK:              "framework",
+	Purpose_INSTALL:                "install",
+	Purpose_LIBRARY:                "library",
+	Purpose_MANIFEST:               "manifest",
+	Purpose_MACHINE_LEARNING_MODEL: "model",
+	Purpose_MODEL:                  "model",
+	Purpose_MODULE:                 "module",
+	Purpose_OPERATING_SYSTEM:       "operatingSystem",
+	Purpose_PATCH:                  "patch",
+	Purpose_REQUIREMENT:            "requirement",
+	Purpose_SOURCE:                 "source",
+	Purpose_SPECIFICATION:          "specification",
+	Purpose_TEST:                   "test",
+	Purpose_OTHER:                  "other",
+	Purpose_PLATFORM:               "other",
+}
+
+// cdxToPurposes maps the CycloneDX component types to protobom purposes.
+// Cryptographic assets have no protobom equivalent and are read as OTHER.
+var cdxToPurposes = map[cdx.ComponentType]Purpose{
+	cdx.ComponentTypeApplication:          Purpose_APPLICATION,
+	cdx.ComponentTypeFramework:            Purpose_FRAMEWORK,
+	cdx.ComponentTypeLibrary:              Purpose_LIBRARY,
+	cdx.ComponentTypeContainer:            Purpose_CONTAINER,
+	cdx.ComponentTypePlatform:             Purpose_PLATFORM,
+	cdx.ComponentTypeOS:                   Purpose_OPERATING_SYSTEM,
+	cdx.ComponentTypeDevice:               Purpose_DEVICE,
+	cdx.ComponentTypeDeviceDriver:         Purpose_DEVICE_DRIVER,
+	cdx.ComponentTypeFirmware:             Purpose_FIRMWARE,
+	cdx.ComponentTypeFile:                 Purpose_FILE,
+	cdx.ComponentTypeMachineLearningModel: Purpose_MACHINE_LEARNING_MODEL,
+	cdx.ComponentTypeData:                 Purpose_DATA,
+	cdx.ComponentTypeCryptographicAsset:   Purpose_OTHER,
+}
+
+// PurposeFromSPDX2 returns the protobom purpose of an SPDX 2.3 primary
+// package purpose. Unknown values return UNKNOWN_PURPOSE.
+func PurposeFromSPDX2(spdxPurpose string) Purpose {
+	if p, ok := spdx2ToPurposes[spdxPurpose]; ok {
+		return p
+	}
+	return Purpose_UNKNOWN_PURPOSE
+}
+
+// ToSPDX2 returns the SPDX 2.3 primary package purpose of the purpose. It
+// returns an empty string for UNKNOWN_PURPOSE.
+func (p Purpose) ToSPDX2() string {
+	return purposesToSPDX2[p]
+}
+
+// PurposeFromCDX returns the protobom purpose of a CycloneDX component
+// type. Unknown types return UNKNOWN_PURPOSE.
+func PurposeFromCDX(componentType cdx.ComponentType) Purpose {
+	if p, ok := cdxToPurposes[componentType]; ok {
+		return p
+	}
+	return Purpose_UNKNOWN_PURPOSE
+}
+
+// ToCDX returns the CycloneDX component type of the purpose. It returns an
+// empty string for UNKNOWN_PURPOSE.
+func (p Purpose) ToCDX() cdx.ComponentType {
+	return purposesToCDX[p]
+}
+
+// ToSPDX3 returns the SPDX 3.0 software purpose of the purpose. It returns
+// an empty string for UNKNOWN_PURPOSE.
+func (p Purpose) ToSPDX3() string {
+	return purposesToSPDX3[p]
+}
@@ -0,0 +1,60 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurposeMappings(t *testing.T) {
+	// Every purpose has a label in all formats
+	for i := range len(Purpose_name) {
+		p := Purpose(i) //nolint:gosec
+		t.Run(p.String(), func(t *testing.T) {
+			if p == Purpose_UNKNOWN_PURPOSE {
+				require.Empty(t, p.ToSPDX2())
+				require.Empty(t, p.ToCDX())
+				require.Empty(t, p.ToSPDX3())
+				return
+			}
+			require.NotEmpty(t, p.ToSPDX2())
+			require.NotEmpty(t, p.ToCDX())
+			require.NotEmpty(t, p.ToSPDX3())
+		})
+	}
+}
+
+func TestPurposeRoundTrip(t *testing.T) {
+	// Purposes read from the formats are written back unchanged
+	for label := range spdx2ToPurposes {
+		t.Run("spdx2 "+label, func(t *testing.T) {
+			require.Equal(t, label, PurposeFromSPDX2(label).ToSPDX2())
+		})
+	}
+	for componentType, p := range cdxToPurposes {
+		if p == Purpose_OTHER {
+			continue
+		}
+		t.Run("cdx "+string(componentType), func(t *testing.T) {
+			require.Equal(t, componentType, PurposeFromCDX(componentType).ToCDX())
+		})
+	}
+}
+
+func TestPurposeFrom(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		purpose  Purpose
+		expected Purpose
+	}{
+		{"spdx2", PurposeFromSPDX2("OPERATING-SYSTEM"), Purpose_OPERATING_SYSTEM},
+		{"cdx", PurposeFromCDX(cdx.ComponentTypeContainer), Purpose_CONTAINER},
+		{"unknown spdx2", PurposeFromSPDX2("SPACESHIP"), Purpose_UNKNOWN_PURPOSE},
+		{"unknown cdx", PurposeFromCDX("spaceship"), Purpose_UNKNOWN_PURPOSE},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, tc.purpose)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NodePredicate selects nodes in NodeList.Query. Predicates are combined
+// with And, Or and Not.
+type NodePredicate func(*Node) bool
+
+// Query returns a new NodeList with the nodes matching pred and the edges
+// among them. Edges pointing to nodes not selected are trimmed. The nodes
+// left without parents in the new list are added to its root elements
+// unless a different policy is set with WithOrphanPolicy. The nodes are
+// shared with the original NodeList, the edges are copied.
+func (nl *NodeList) Query(pred NodePredicate, opts ...NodeListOption) *NodeList {
+	ret := &NodeList{}
+	if nl == nil || pred == nil {
+		return ret
+	}
+
+	for _, n := range nl.Nodes {
+		if pred(n) {
+			ret.Nodes = append(ret.Nodes, n)
+		}
+	}
+
+	index := ret.indexNodes()
+	for _, e := range nl.Edges {
+		if _, ok := index[e.From]; ok {
+			ret.Edges = append(ret.Edges, e.Copy())
+		}
+	}
+
+	ret.cleanEdges()
+	ret.applyQueryOrphanPolicy(buildNodeListOptions(opts).orphanPolicy)
+
+	return ret
+}
+
+// ByName matches the nodes named name
+func ByName(name string) NodePredicate {
+	return func(n *Node) bool {
+		return n.GetName() == name
+	}
+}
+
+// ByPurlType matches the nodes with a package URL of type purlType, eg
+// "golang" or "npm"
+func ByPurlType(purlType string) NodePredicate {
+	return func(n *Node) bool {
+		purl := string(n.Purl())
+		// I think the SPDX libraries have a bug where an extra slash is added when parsing purls
+		return strings.HasPrefix(purl, fmt.Sprintf("pkg:%s/", purlType)) ||
+			strings.HasPrefix(purl, fmt.Sprintf("pkg:/%s/", purlType))
+	}
+}
+
+// ByLicense matches the nodes whose concluded or declared licenses include
+// the license identifier, alone or as part of an expression. Deprecated
+// SPDX identifiers match their current replacement.
+func ByLicense(license string) NodePredicate {
+	license = canonicalLicenseID(license)
+	return func(n *Node) bool {
+		for _, l := range append([]string{n.GetLicenseConcluded()}, n.GetLicenses()...) {
+			expr, err := ParseLicenseExpression(l)
+			if err != nil {
+				continue
+			}
+			for _, id := range expr.Licenses() {
+				if strings.EqualFold(canonicalLicenseID(id), license) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// ByHash matches the nodes with the hash value computed with algorithm
+func ByHash(algorithm HashAlgorithm, value string) NodePredicate {
+	return func(n *Node) bool {
+		h, ok := n.GetHashes()[int32(algorithm)]
+		return ok && value != "" && strings.EqualFold(h, value)
+	}
+}
+
+// And matches the nodes matching all the predicates
+func And(preds ...NodePredicate) NodePredicate {
+	return func(n *Node) bool {
+		for _, p := range preds {
+			if !p(n) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches the nodes matching any of the predicates
+func Or(preds ...NodePredicate) NodePredicate {
+	return func(n *Node) bool {
+		for _, p := range preds {
+			if p(n) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches the nodes not matching pred
+func Not(pred NodePredicate) NodePredicate {
+	return func(n *Node) bool {
+		return !pred(n)
+	}
+}
@@ -0,0 +1,74 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	purl := func(p string) map[int32]string {
+		return map[int32]string{int32(SoftwareIdentifierType_PURL): p}
+	}
+	nl := &NodeList{
+		Nodes: []*Node{
+			{Id: "app", Name: "app", Identifiers: purl("pkg:oci/app@sha256:1"), Licenses: []string{"Apache-2.0"}},
+			{Id: "lib", Name: "lib", Identifiers: purl("pkg:golang/example.com/lib@1.0"), LicenseConcluded: "MIT OR GPL-2.0"},
+			{Id: "dep", Name: "dep", Identifiers: purl("pkg:golang/example.com/dep@2.0"), Licenses: []string{"MIT"}},
+			{
+				Id: "tool", Name: "tool", Identifiers: purl("pkg:npm/tool@3"),
+				Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "ABCDEF"},
+			},
+		},
+		Edges: []*Edge{
+			{From: "app", Type: Edge_dependsOn, To: []string{"lib", "tool"}},
+			{From: "lib", Type: Edge_dependsOn, To: []string{"dep"}},
+		},
+		RootElements: []string{"app"},
+	}
+	ids := func(nl *NodeList) []string {
+		ret := []string{}
+		for _, n := range nl.Nodes {
+			ret = append(ret, n.Id)
+		}
+		return ret
+	}
+
+	for name, tc := range map[string]struct {
+		pred   NodePredicate
+		expect []string
+	}{
+		"name":               {ByName("lib"), []string{"lib"}},
+		"purl type":          {ByPurlType("golang"), []string{"lib", "dep"}},
+		"license":            {ByLicense("MIT"), []string{"lib", "dep"}},
+		"deprecated license": {ByLicense("GPL-2.0-only"), []string{"lib"}},
+		"hash":               {ByHash(HashAlgorithm_SHA256, "abcdef"), []string{"tool"}},
+		"hash algorithm":     {ByHash(HashAlgorithm_SHA1, "abcdef"), []string{}},
+		"and":                {And(ByPurlType("golang"), ByLicense("MIT"), Not(ByName("lib"))), []string{"dep"}},
+		"or":                 {Or(ByName("app"), ByHash(HashAlgorithm_SHA256, "abcdef")), []string{"app", "tool"}},
+		"not":                {Not(ByPurlType("golang")), []string{"app", "tool"}},
+		"empty and":          {And(), []string{"app", "lib", "dep", "tool"}},
+		"empty or":           {Or(), []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.expect, ids(nl.Query(tc.pred)))
+		})
+	}
+
+	// Edges among the selected nodes are preserved, the rest are trimmed
+	res := nl.Query(Or(ByName("app"), ByName("lib"), ByName("dep")))
+	require.Equal(t, []*Edge{
+		{From: "app", Type: Edge_dependsOn, To: []string{"lib"}},
+		{From: "lib", Type: Edge_dependsOn, To: []string{"dep"}},
+	}, res.Edges)
+	require.Equal(t, []string{"app"}, res.RootElements)
+	require.Len(t, nl.Edges[0].To, 2)
+
+	// Orphans become root elements unless a different policy is set
+	res = nl.Query(ByPurlType("golang"))
+	require.Equal(t, []string{"lib"}, res.RootElements)
+	res = nl.Query(ByPurlType("golang"), WithOrphanPolicy(OrphanPolicyLeave))
+	require.Empty(t, res.RootElements)
+
+	require.Empty(t, (*NodeList)(nil).Query(ByName("app")).Nodes)
+}
@@ -0,0 +1,236 @@
+package sbom
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RefreshOptions controls how a new scan is applied to an existing document
+type RefreshOptions struct {
+	// Roots limits the removal of vanished nodes to those found under the
+	// specified root node IDs. When empty, all nodes in the document are
+	// considered.
+	Roots []string
+
+	// KeepVanished instructs Refresh to keep the nodes not found in the new
+	// scan instead of removing them.
+	KeepVanished bool
+}
+
+// RefreshReport lists the node IDs changed when refreshing a document
+type RefreshReport struct {
+	Added   []string
+	Updated []string
+	Removed []string
+}
+
+// Refresh applies the data of a new scan of the same software to the document.
+//
+// Nodes in the new scan are matched to existing nodes by ID, then by package
+// URL (ignoring the version) and finally by type and name. Matched nodes get
+// their scanner-owned fields (version, hashes, identifiers, file name, file
+// types and download location) updated, while any other data in the existing
+// node (licenses, suppliers, properties, comments, etc) is preserved and only
+// filled in when missing. Nodes not found in the document are added and nodes
+// in the document that vanished from the new scan are removed. Added nodes
+// whose ID is used by another node in the document get a new ID.
+func (d *Document) Refresh(newScan *Document, opts *RefreshOptions) *RefreshReport {
+	if opts == nil {
+		opts = &RefreshOptions{}
+	}
+	report := &RefreshReport{
+		Added:   []string{},
+		Updated: []string{},
+		Removed: []string{},
+	}
+	if newScan == nil || newScan.NodeList == nil {
+		return report
+	}
+	if d.NodeList == nil {
+		d.NodeList = NewNodeList()
+	}
+
+	// Index the existing nodes
+	byPurl := map[string][]*Node{}
+	byName := map[string][]*Node{}
+	for _, n := range d.NodeList.Nodes {
+		if p := unversionedPurl(n); p != "" {
+			byPurl[p] = append(byPurl[p], n)
+		}
+		byName[n.Type.String()+":"+n.Name] = append(byName[n.Type.String()+":"+n.Name], n)
+	}
+	existing := d.NodeList.indexNodes()
+
+	// idMap translates the node IDs in the new scan to those in the document
+	idMap := map[string]string{}
+	matched := map[string]struct{}{}
+	var taken map[string]struct{}
+	for _, newNode := range newScan.NodeList.Nodes {
+		target := matchRefreshNode(newNode, existing, byPurl, byName, matched)
+		if target == nil {
+			n := newNode.Copy()
+			// The ID may be used by an existing node matched to another one
+			if _, ok := existing[n.Id]; ok {
+				if taken == nil {
+					taken = map[string]struct{}{}
+					for _, nodes := range [][]*Node{d.NodeList.Nodes, newScan.NodeList.Nodes} {
+						for _, tn := range nodes {
+							taken[tn.Id] = struct{}{}
+						}
+					}
+				}
+				n.Id = freeNodeID(n.Id, taken)
+			}
+			d.NodeList.AddNode(n)
+			existing[n.Id] = n
+			idMap[newNode.Id] = n.Id
+			matched[n.Id] = struct{}{}
+			report.Added = append(report.Added, n.Id)
+			continue
+		}
+
+		idMap[newNode.Id] = target.Id
+		matched[target.Id] = struct{}{}
+		if refreshNode(target, newNode) {
+			report.Updated = append(report.Updated, target.Id)
+		}
+	}
+
+	// Merge the edges from the new scan
+	edges := []*Edge{}
+	for _, e := range newScan.NodeList.Edges {
+		ne := &Edge{Type: e.Type, From: idMap[e.From], To: []string{}}
+		for _, to := range e.To {
+			if id, ok := idMap[to]; ok {
+				ne.To = append(ne.To, id)
+			}
+		}
+		if ne.From != "" && len(ne.To) > 0 {
+			edges = append(edges, ne)
+		}
+	}
+	d.NodeList.MergeEdges(edges)
+
+	for _, id := range newScan.NodeList.RootElements {
+		if id, ok := idMap[id]; ok && !slices.Contains(d.NodeList.RootElements, id) {
+			d.NodeList.RootElements = append(d.NodeList.RootElements, id)
+		}
+	}
+
+	if !opts.KeepVanished {
+		report.Removed = d.removeVanishedNodes(opts.Roots, matched)
+	}
+
+	d.NodeList.cleanEdges()
+	return report
+}
+
+// removeVanishedNodes removes the nodes under roots that were not matched
+// in the new scan. It returns the IDs of the removed nodes.
+func (d *Document) removeVanishedNodes(roots []string, matched map[string]struct{}) []string {
+	candidates := map[string]struct{}{}
+	if len(roots) == 0 {
+		for _, n := range d.NodeList.Nodes {
+			candidates[n.Id] = struct{}{}
+		}
+	} else {
+		for _, r := range roots {
+			for _, n := range d.NodeList.NodeDescendants(r, len(d.NodeList.Nodes)).Nodes {
+				candidates[n.Id] = struct{}{}
+			}
+		}
+	}
+
+	removed := []string{}
+	for _, n := range d.NodeList.Nodes {
+		if _, ok := candidates[n.Id]; !ok {
+			continue
+		}
+		if _, ok := matched[n.Id]; ok {
+			continue
+		}
+		removed = append(removed, n.Id)
+	}
+
+	if len(removed) > 0 {
+		d.NodeList.RemoveNodes(removed)
+		d.NodeList.RootElements = slices.DeleteFunc(d.NodeList.RootElements, func(id string) bool {
+			return slices.Contains(removed, id)
+		})
+	}
+	return removed
+}
+
+// unversionedPurl returns the package URL of the node without its version
+func unversionedPurl(n *Node) string {
+	p := string(n.Purl())
+	if p == "" {
+		return ""
+	}
+	// Drop qualifiers and subpath before trimming the version
+	p, _, _ = strings.Cut(p, "?")
+	p, _, _ = strings.Cut(p, "#")
+	p, _, _ = strings.Cut(p, "@")
+	return p
+}
+
+// matchRefreshNode looks for the node in the document matching a node from
+// a new scan. Nodes already matched are not considered.
+func matchRefreshNode(
+	n *Node, byID nodeIndex, byPurl, byName map[string][]*Node, matched map[string]struct{},
+) *Node {
+	if existing, ok := byID[n.Id]; ok {
+		if _, ok := matched[existing.Id]; !ok {
+			return existing
+		}
+	}
+
+	firstUnmatched := func(nodes []*Node) *Node {
+		for _, c := range nodes {
+			if _, ok := matched[c.Id]; !ok {
+				return c
+			}
+		}
+		return nil
+	}
+
+	if p := unversionedPurl(n); p != "" {
+		if c := firstUnmatched(byPurl[p]); c != nil {
+			return c
+		}
+	}
+
+	if n.Name != "" {
+		return firstUnmatched(byName[n.Type.String()+":"+n.Name])
+	}
+	return nil
+}
+
+// refreshNode updates the scanner-owned fields of n with the data in
+// n2 and fills any missing data. Returns true if the node was modified.
+func refreshNode(n, n2 *Node) bool {
+	before := n.flatString()
+
+	if n2.Version != "" {
+		n.Version = n2.Version
+	}
+	if len(n2.Hashes) > 0 {
+		n.Hashes = maps.Clone(n2.Hashes)
+	}
+	if len(n2.Identifiers) > 0 {
+		n.Identifiers = maps.Clone(n2.Identifiers)
+	}
+	if n2.FileName != "" {
+		n.FileName = n2.FileName
+	}
+	if len(n2.FileTypes) > 0 {
+		n.FileTypes = slices.Clone(n2.FileTypes)
+	}
+	if n2.UrlDownload != "" {
+		n.UrlDownload = n2.UrlDownload
+	}
+
+	n.Augment(n2.Copy())
+	return before != n.flatString()
+}
@@ -0,0 +1,162 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testRefreshDocument() *Document {
+	return &Document{
+		Metadata: &Metadata{Id: "doc"},
+		NodeList: &NodeList{
+			Nodes: []*Node{
+				{Id: "app", Name: "app", Version: "1.0.0"},
+				{
+					Id: "lib1", Name: "lib1", Version: "1.0.0",
+					Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/lib1@1.0.0"},
+					Licenses:    []string{"MIT"},
+					Comment:     "reviewed by legal",
+				},
+				{Id: "lib2", Name: "lib2", Version: "2.0.0"},
+			},
+			Edges: []*Edge{
+				{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib2"}},
+			},
+			RootElements: []string{"app"},
+		},
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	for name, tc := range map[string]struct {
+		newScan  *Document
+		opts     *RefreshOptions
+		expected *RefreshReport
+		check    func(*testing.T, *Document)
+	}{
+		"updates matched by purl and preserves curated data": {
+			newScan: &Document{
+				NodeList: &NodeList{
+					Nodes: []*Node{
+						{Id: "app", Name: "app", Version: "1.0.0"},
+						{
+							Id: "scan-lib1", Name: "lib1", Version: "1.1.0",
+							Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/lib1@1.1.0"},
+							Hashes:      map[int32]string{int32(HashAlgorithm_SHA256): "abc"},
+							Licenses:    []string{"Apache-2.0"},
+						},
+						{Id: "lib2", Name: "lib2", Version: "2.0.0"},
+					},
+					Edges: []*Edge{
+						{Type: Edge_dependsOn, From: "app", To: []string{"scan-lib1", "lib2"}},
+					},
+					RootElements: []string{"app"},
+				},
+			},
+			expected: &RefreshReport{Added: []string{}, Updated: []string{"lib1"}, Removed: []string{}},
+			check: func(t *testing.T, doc *Document) {
+				t.Helper()
+				lib1 := doc.NodeList.GetNodeByID("lib1")
+				require.NotNil(t, lib1)
+				require.Equal(t, "1.1.0", lib1.Version)
+				require.Equal(t, "abc", lib1.Hashes[int32(HashAlgorithm_SHA256)])
+				require.Equal(t, []string{"MIT"}, lib1.Licenses)
+				require.Equal(t, "reviewed by legal", lib1.Comment)
+				require.Nil(t, doc.NodeList.GetNodeByID("scan-lib1"))
+				require.Len(t, doc.NodeList.Edges, 1)
+				require.Len(t, doc.NodeList.Edges[0].To, 2)
+			},
+		},
+		"adds new nodes and removes vanished ones": {
+			newScan: &Document{
+				NodeList: &NodeList{
+					Nodes: []*Node{
+						{Id: "app", Name: "app", Version: "1.0.0"},
+						{Id: "lib1", Name: "lib1", Version: "1.0.0"},
+						{Id: "lib3", Name: "lib3", Version: "3.0.0"},
+					},
+					Edges: []*Edge{
+						{Type: Edge_dependsOn, From: "app", To: []string{"lib1", "lib3"}},
+					},
+					RootElements: []string{"app"},
+				},
+			},
+			expected: &RefreshReport{Added: []string{"lib3"}, Updated: []string{}, Removed: []string{"lib2"}},
+			check: func(t *testing.T, doc *Document) {
+				t.Helper()
+				require.Len(t, doc.NodeList.Nodes, 3)
+				require.Nil(t, doc.NodeList.GetNodeByID("lib2"))
+				require.NotNil(t, doc.NodeList.GetNodeByID("lib3"))
+				require.ElementsMatch(t, []string{"lib1", "lib3"}, doc.NodeList.Edges[0].To)
+			},
+		},
+		"renames added nodes with taken IDs": {
+			newScan: &Document{
+				NodeList: &NodeList{
+					Nodes: []*Node{
+						{Id: "app", Name: "app", Version: "1.0.0"},
+						{
+							Id: "x", Name: "lib1", Version: "2.0.0",
+							Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/lib1@2.0.0"},
+						},
+						{
+							Id: "lib1", Name: "bar", Version: "1.0.0",
+							Identifiers: map[int32]string{int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/bar@1.0.0"},
+						},
+					},
+					Edges: []*Edge{
+						{Type: Edge_dependsOn, From: "app", To: []string{"x", "lib1"}},
+						{Type: Edge_dependsOn, From: "lib1", To: []string{"x"}},
+					},
+					RootElements: []string{"app", "lib1"},
+				},
+			},
+			expected: &RefreshReport{Added: []string{"lib1-2"}, Updated: []string{"lib1"}, Removed: []string{"lib2"}},
+			check: func(t *testing.T, doc *Document) {
+				t.Helper()
+				require.Len(t, doc.NodeList.Nodes, 3)
+				require.Equal(t, "2.0.0", doc.NodeList.GetNodeByID("lib1").Version)
+				require.Equal(t, "bar", doc.NodeList.GetNodeByID("lib1-2").Name)
+				require.ElementsMatch(t, []string{"lib1", "lib1-2"}, doc.NodeList.GetEdgeByType("app", Edge_dependsOn).To)
+				require.Equal(t, []string{"lib1"}, doc.NodeList.GetEdgeByType("lib1-2", Edge_dependsOn).To)
+				require.Equal(t, []string{"app", "lib1-2"}, doc.NodeList.RootElements)
+			},
+		},
+		"keep vanished": {
+			newScan: &Document{
+				NodeList: &NodeList{
+					Nodes:        []*Node{{Id: "app", Name: "app", Version: "1.0.0"}},
+					RootElements: []string{"app"},
+				},
+			},
+			opts:     &RefreshOptions{KeepVanished: true},
+			expected: &RefreshReport{Added: []string{}, Updated: []string{}, Removed: []string{}},
+			check: func(t *testing.T, doc *Document) {
+				t.Helper()
+				require.Len(t, doc.NodeList.Nodes, 3)
+			},
+		},
+		"removal limited to roots": {
+			newScan: &Document{
+				NodeList: &NodeList{
+					Nodes: []*Node{{Id: "app", Name: "app", Version: "1.0.0"}},
+				},
+			},
+			opts:     &RefreshOptions{Roots: []string{"lib2"}},
+			expected: &RefreshReport{Added: []string{}, Updated: []string{}, Removed: []string{"lib2"}},
+			check: func(t *testing.T, doc *Document) {
+				t.Helper()
+				require.NotNil(t, doc.NodeList.GetNodeByID("lib1"))
+				require.Nil(t, doc.NodeList.GetNodeByID("lib2"))
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := testRefreshDocument()
+			report := doc.Refresh(tc.newScan, tc.opts)
+			require.Equal(t, tc.expected, report)
+			tc.check(t, doc)
+		})
+	}
+}
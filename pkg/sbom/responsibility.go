@@ -0,0 +1,209 @@
+package sbom
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Properties recording the party responsible for a node. They are written
+// as properties so the assignments travel with the SBOM in the formats
+// that support them, eg CycloneDX properties or SPDX annotations.
+const (
+	PropertyResponsibilityTeam       = "protobom:responsibility:team"
+	PropertyResponsibilityContact    = "protobom:responsibility:contact"
+	PropertyResponsibilityEscalation = "protobom:responsibility:escalation"
+	PropertyResponsibilityScope      = "protobom:responsibility:scope"
+)
+
+// ResponsibilityScope defines the nodes covered by an assignment
+type ResponsibilityScope string
+
+const (
+	// ResponsibilityScopeNode assignments only cover the assigned node
+	ResponsibilityScopeNode ResponsibilityScope = "node"
+
+	// ResponsibilityScopeSubtree assignments cover the assigned node and
+	// the nodes descending from it that are not assigned to another party
+	ResponsibilityScopeSubtree ResponsibilityScope = "subtree"
+)
+
+// responsibilityProperties are the properties storing an assignment, in
+// the order they are written
+var responsibilityProperties = []string{
+	PropertyResponsibilityTeam,
+	PropertyResponsibilityContact,
+	PropertyResponsibilityEscalation,
+	PropertyResponsibilityScope,
+}
+
+// Responsibility captures the party responsible for a component
+type Responsibility struct {
+	// Team is the name of the team owning the component
+	Team string
+
+	// Contact is where to reach the team, eg an email or chat channel
+	Contact string
+
+	// Escalation is the contact to use when the team doesn't respond
+	Escalation string
+
+	// Scope is the extent of the assignment, it defaults to the node
+	Scope ResponsibilityScope
+}
+
+// Validate checks that the assignment has a team and a known scope
+func (r *Responsibility) Validate() error {
+	if r.Team == "" {
+		return errors.New("responsibility has no team")
+	}
+	switch r.Scope {
+	case "", ResponsibilityScopeNode, ResponsibilityScopeSubtree:
+		return nil
+	default:
+		return fmt.Errorf("unknown responsibility scope %q", r.Scope)
+	}
+}
+
+// GetResponsibility returns the responsibility assigned directly to the
+// node or nil if it has none. Assignments inherited from other nodes are
+// returned by NodeList.ResponsibilityOf.
+func (n *Node) GetResponsibility() *Responsibility {
+	r := &Responsibility{}
+	for _, p := range n.GetProperties() {
+		var field *string
+		switch p.GetName() {
+		case PropertyResponsibilityTeam:
+			field = &r.Team
+		case PropertyResponsibilityContact:
+			field = &r.Contact
+		case PropertyResponsibilityEscalation:
+			field = &r.Escalation
+		case PropertyResponsibilityScope:
+			field = (*string)(&r.Scope)
+		default:
+			continue
+		}
+		if *field == "" {
+			*field = p.GetData()
+		}
+	}
+	if r.Team == "" {
+		return nil
+	}
+	if r.Scope == "" {
+		r.Scope = ResponsibilityScopeNode
+	}
+	return r
+}
+
+// SetResponsibility replaces the responsibility assigned to the node with
+// r. A nil responsibility removes the assignment.
+func (n *Node) SetResponsibility(r *Responsibility) {
+	n.Properties = slices.DeleteFunc(n.Properties, func(p *Property) bool {
+		return slices.Contains(responsibilityProperties, p.GetName())
+	})
+	if r == nil {
+		return
+	}
+
+	scope := cmp.Or(r.Scope, ResponsibilityScopeNode)
+	values := []string{r.Team, r.Contact, r.Escalation, string(scope)}
+	for i, name := range responsibilityProperties {
+		if values[i] == "" {
+			continue
+		}
+		n.Properties = append(n.Properties, &Property{Name: name, Data: values[i]})
+	}
+}
+
+// AssignResponsibility assigns the node id to the party in r. Assigning a
+// subtree makes r the responsible party of the nodes descending from id
+// that don't have an assignment of their own.
+func (nl *NodeList) AssignResponsibility(id string, r *Responsibility) error {
+	if r == nil {
+		return errors.New("responsibility is nil")
+	}
+	if err := r.Validate(); err != nil {
+		return fmt.Errorf("invalid responsibility: %w", err)
+	}
+	n := nl.GetNodeByID(id)
+	if n == nil {
+		return fmt.Errorf("node %q not found", id)
+	}
+	n.SetResponsibility(r)
+	return nil
+}
+
+// ResponsibilityIndex returns the party responsible for each node, indexed
+// by node ID. Nodes are covered by their own assignment or by the closest
+// subtree assignment above them, following the edges from the assigned
+// node as NodeDescendants does. When two subtree assignments are at the
+// same distance, the one of the node listed first wins. Nodes without a
+// responsible party are not in the index.
+func (nl *NodeList) ResponsibilityIndex() map[string]*Responsibility {
+	children := map[string][]string{}
+	for _, e := range nl.GetEdges() {
+		children[e.From] = append(children[e.From], e.To...)
+	}
+
+	// Subtree assignments are propagated breadth first from all the
+	// assigned nodes at once so the closest one covers each node
+	inherited := map[string]*Responsibility{}
+	direct := map[string]*Responsibility{}
+	queue := []string{}
+	for _, n := range nl.GetNodes() {
+		r := n.GetResponsibility()
+		if r == nil {
+			continue
+		}
+		direct[n.Id] = r
+		if r.Scope == ResponsibilityScopeSubtree {
+			inherited[n.Id] = r
+			queue = append(queue, n.Id)
+		}
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, childID := range children[id] {
+			if _, ok := inherited[childID]; ok {
+				continue
+			}
+			inherited[childID] = inherited[id]
+			queue = append(queue, childID)
+		}
+	}
+
+	ret := map[string]*Responsibility{}
+	for _, n := range nl.GetNodes() {
+		if r, ok := direct[n.Id]; ok {
+			ret[n.Id] = r
+		} else if r, ok := inherited[n.Id]; ok {
+			ret[n.Id] = r
+		}
+	}
+	return ret
+}
+
+// ResponsibilityOf returns the party responsible for the node id, either
+// assigned to the node or inherited from a subtree assignment, or nil if
+// the node has no responsible party.
+func (nl *NodeList) ResponsibilityOf(id string) *Responsibility {
+	return nl.ResponsibilityIndex()[id]
+}
+
+// NodesOwnedBy returns the nodes the team is responsible for, directly or
+// through a subtree assignment.
+func (nl *NodeList) NodesOwnedBy(team string) []*Node {
+	index := nl.ResponsibilityIndex()
+	ret := []*Node{}
+	for _, n := range nl.GetNodes() {
+		if r, ok := index[n.Id]; ok && r.Team == team {
+			ret = append(ret, n)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,160 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeResponsibility(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// set are the responsibilities set on the node, in order
+		set        []*Responsibility
+		expected   *Responsibility
+		properties int
+	}{
+		{
+			name:       "unassigned",
+			properties: 1,
+		},
+		{
+			name:       "default scope",
+			set:        []*Responsibility{{Team: "core", Contact: "core@example.com"}},
+			expected:   &Responsibility{Team: "core", Contact: "core@example.com", Scope: ResponsibilityScopeNode},
+			properties: 4,
+		},
+		{
+			// Assignments are replaced, not merged
+			name: "replaced",
+			set: []*Responsibility{
+				{Team: "core", Contact: "core@example.com"},
+				{Team: "platform", Escalation: "oncall", Scope: ResponsibilityScopeSubtree},
+			},
+			expected:   &Responsibility{Team: "platform", Escalation: "oncall", Scope: ResponsibilityScopeSubtree},
+			properties: 4,
+		},
+		{
+			name:       "removed",
+			set:        []*Responsibility{{Team: "core", Contact: "core@example.com"}, nil},
+			properties: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n := &Node{Id: "a", Properties: []*Property{{Name: "build", Data: "1"}}}
+			for _, r := range tc.set {
+				n.SetResponsibility(r)
+			}
+			require.Equal(t, tc.expected, n.GetResponsibility())
+			require.Len(t, n.Properties, tc.properties)
+			require.Equal(t, "build", n.Properties[0].Name)
+			if tc.expected != nil {
+				require.Equal(t, PropertyResponsibilityTeam, n.Properties[1].Name)
+			}
+		})
+	}
+}
+
+func TestAssignResponsibility(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		id      string
+		sut     *Responsibility
+		mustErr bool
+	}{
+		{name: "valid", id: "app", sut: &Responsibility{Team: "app", Scope: ResponsibilityScopeSubtree}},
+		{name: "missing node", id: "missing", sut: &Responsibility{Team: "app", Scope: ResponsibilityScopeSubtree}, mustErr: true},
+		{name: "nil responsibility", id: "app", mustErr: true},
+		{name: "no team", id: "app", sut: &Responsibility{Scope: ResponsibilityScopeNode}, mustErr: true},
+		{name: "invalid scope", id: "app", sut: &Responsibility{Team: "app", Scope: "everything"}, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{{Id: "app"}}, RootElements: []string{"app"}}
+			err := nl.AssignResponsibility(tc.id, tc.sut)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Nil(t, nl.ResponsibilityOf("app"))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.sut, nl.ResponsibilityOf(tc.id))
+		})
+	}
+}
+
+func TestResponsibilityIndex(t *testing.T) {
+	assignments := map[string]*Responsibility{
+		"app": {Team: "app", Scope: ResponsibilityScopeSubtree},
+		"net": {Team: "network", Scope: ResponsibilityScopeSubtree},
+		"lib": {Team: "libs"},
+	}
+	for _, tc := range []struct {
+		name        string
+		assignments map[string]*Responsibility
+		// index maps the node IDs to the team responsible for them
+		index map[string]string
+		team  string
+		owned []string
+	}{
+		{
+			name:  "no assignments",
+			index: map[string]string{},
+			team:  "network",
+			owned: []string{},
+		},
+		{
+			// util descends from both subtrees, the net assignment is closer
+			name:        "closest assignment",
+			assignments: assignments,
+			index: map[string]string{
+				"app": "app", "lib": "libs", "net": "network", "util": "network", "vendored": "network",
+			},
+			team:  "network",
+			owned: []string{"net", "util", "vendored"},
+		},
+		{
+			name:        "node scope",
+			assignments: assignments,
+			index: map[string]string{
+				"app": "app", "lib": "libs", "net": "network", "util": "network", "vendored": "network",
+			},
+			team:  "libs",
+			owned: []string{"lib"},
+		},
+		{
+			name:        "unknown team",
+			assignments: assignments,
+			index: map[string]string{
+				"app": "app", "lib": "libs", "net": "network", "util": "network", "vendored": "network",
+			},
+			team:  "security",
+			owned: []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// app -> lib -> util -> vendored
+			//     -> net -> util
+			nl := &NodeList{
+				Nodes: []*Node{{Id: "app"}, {Id: "lib"}, {Id: "net"}, {Id: "util"}, {Id: "vendored"}, {Id: "other"}},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "app", To: []string{"lib", "net"}},
+					{Type: Edge_dependsOn, From: "lib", To: []string{"util"}},
+					{Type: Edge_dependsOn, From: "net", To: []string{"util"}},
+					{Type: Edge_contains, From: "util", To: []string{"vendored"}},
+				},
+				RootElements: []string{"app"},
+			}
+			for id, r := range tc.assignments {
+				require.NoError(t, nl.AssignResponsibility(id, r))
+			}
+
+			index := map[string]string{}
+			for id, r := range nl.ResponsibilityIndex() {
+				index[id] = r.Team
+			}
+			require.Equal(t, tc.index, index)
+			require.Nil(t, nl.ResponsibilityOf("other"))
+			require.Equal(t, tc.owned, ids(nl.NodesOwnedBy(tc.team)))
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package sbom
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Bump increments the document version and records the revision with its
+// reason in the metadata. The version is handled as an integer following the
+// CycloneDX convention; an empty version is considered to be 1. The document
+// date is updated to the time of the revision.
+//
+// Document identifiers are not modified: CycloneDX keeps the serial number
+// across versions while the SPDX serializer derives a new namespace for each
+// revision.
+func (d *Document) Bump(reason string) error {
+	return d.BumpAt(reason, time.Now())
+}
+
+// BumpAt is like Bump but records the revision at the specified time.
+func (d *Document) BumpAt(reason string, t time.Time) error {
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+
+	current := 1
+	if d.Metadata.Version != "" {
+		v, err := strconv.Atoi(d.Metadata.Version)
+		if err != nil {
+			return fmt.Errorf("parsing document version %q: %w", d.Metadata.Version, err)
+		}
+		current = v
+	}
+
+	d.Metadata.Version = strconv.Itoa(current + 1)
+	d.Metadata.Date = timestamppb.New(t)
+	d.Metadata.Revisions = append(d.Metadata.Revisions, &Revision{
+		Version: d.Metadata.Version,
+		Date:    timestamppb.New(t),
+		Reason:  reason,
+	})
+	return nil
+}
+
+// LatestRevision returns the most recent revision recorded in the metadata
+// or nil if the document has never been revised.
+func (m *Metadata) LatestRevision() *Revision {
+	if len(m.GetRevisions()) == 0 {
+		return nil
+	}
+	return m.Revisions[len(m.Revisions)-1]
+}
+
+// Copy returns a duplicate of the revision.
+func (r *Revision) Copy() *Revision {
+	ret := &Revision{
+		Version: r.Version,
+		Reason:  r.Reason,
+	}
+	if r.Date != nil {
+		ret.Date = timestamppb.New(r.Date.AsTime())
+	}
+	return ret
+}
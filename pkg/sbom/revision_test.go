@@ -0,0 +1,48 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBump(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for name, tc := range map[string]struct {
+		version  string
+		expected string
+		mustErr  bool
+	}{
+		"empty version": {"", "2", false},
+		"version 1":     {"1", "2", false},
+		"version 9":     {"9", "10", false},
+		"non integer":   {"1.0.0", "", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.Metadata.Version = tc.version
+			err := doc.BumpAt("fixed licenses", now)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Nil(t, doc.Metadata.LatestRevision())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, doc.Metadata.Version)
+			require.Equal(t, now, doc.Metadata.Date.AsTime())
+
+			rev := doc.Metadata.LatestRevision()
+			require.NotNil(t, rev)
+			require.Equal(t, tc.expected, rev.Version)
+			require.Equal(t, "fixed licenses", rev.Reason)
+		})
+	}
+
+	doc := &Document{}
+	require.NoError(t, doc.Bump("first"))
+	require.NoError(t, doc.Bump("second"))
+	require.Equal(t, "3", doc.Metadata.Version)
+	require.Len(t, doc.Metadata.Revisions, 2)
+	require.Equal(t, "second", doc.Metadata.LatestRevision().Reason)
+}
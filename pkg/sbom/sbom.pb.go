@@ -68,6 +68,9 @@ const (
 	HashAlgorithm_MD6 HashAlgorithm = 16
 	// SHA-224 hash algorithm, not supported by SPDX formats..
 	HashAlgorithm_SHA224 HashAlgorithm = 17
+	// ssdeep context triggered piecewise hash, not supported by SPDX or
+	// CycloneDX. CycloneDX documents record it as a component property.
+	HashAlgorithm_SSDEEP HashAlgorithm = 18
 )
 
 // Enum value maps for HashAlgorithm.
@@ -91,6 +94,7 @@ var (
 		15: "MD4",
 		16: "MD6",
 		17: "SHA224",
+		18: "SSDEEP",
 	}
 	HashAlgorithm_value = map[string]int32{
 		"UNKNOWN":     0,
@@ -111,6 +115,7 @@ var (
 		"MD4":         15,
 		"MD6":         16,
 		"SHA224":      17,
+		"SSDEEP":      18,
 	}
 )
 
@@ -312,8 +317,10 @@ const (
 	SoftwareIdentifierType_CPE22 SoftwareIdentifierType = 2
 	// Common Platform Enumeration (CPE) version 2.3 identifier type.
 	SoftwareIdentifierType_CPE23 SoftwareIdentifierType = 3
-	// Git Object Identifier (OID) identifier type.
+	// Git Object Identifier (OID) identifier type, used as OmniBOR artifact ID.
 	SoftwareIdentifierType_GITOID SoftwareIdentifierType = 4
+	// Software Heritage persistent identifier (SWHID).
+	SoftwareIdentifierType_SWHID SoftwareIdentifierType = 5
 )
 
 // Enum value maps for SoftwareIdentifierType.
@@ -324,6 +331,7 @@ var (
 		2: "CPE22",
 		3: "CPE23",
 		4: "GITOID",
+		5: "SWHID",
 	}
 	SoftwareIdentifierType_value = map[string]int32{
 		"UNKNOWN_IDENTIFIER_TYPE": 0,
@@ -331,6 +339,7 @@ var (
 		"CPE22":                   2,
 		"CPE23":                   3,
 		"GITOID":                  4,
+		"SWHID":                   5,
 	}
 )
 
@@ -435,7 +444,7 @@ func (x DocumentType_SBOMType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DocumentType_SBOMType.Descriptor instead.
 func (DocumentType_SBOMType) EnumDescriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{1, 0}
+	return file_sbom_proto_rawDescGZIP(), []int{3, 0}
 }
 
 // buf:lint:ignore ENUM_VALUE_UPPER_SNAKE_CASE
@@ -533,6 +542,10 @@ const (
 	Edge_testTool Edge_Type = 43
 	// Variant relationship type.
 	Edge_variant Edge_Type = 44
+	// Trained on relationship type, from an AI model to a dataset. (SPDX3)
+	Edge_trainedOn Edge_Type = 45
+	// Tested on relationship type, from an AI model to a dataset. (SPDX3)
+	Edge_testedOn Edge_Type = 46
 )
 
 // Enum value maps for Edge_Type.
@@ -583,6 +596,8 @@ var (
 		42: "testDependency",
 		43: "testTool",
 		44: "variant",
+		45: "trainedOn",
+		46: "testedOn",
 	}
 	Edge_Type_value = map[string]int32{
 		"UNKNOWN":              0,
@@ -630,6 +645,8 @@ var (
 		"testDependency":       42,
 		"testTool":             43,
 		"variant":              44,
+		"trainedOn":            45,
+		"testedOn":             46,
 	}
 )
 
@@ -657,7 +674,7 @@ func (x Edge_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Edge_Type.Descriptor instead.
 func (Edge_Type) EnumDescriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{2, 0}
+	return file_sbom_proto_rawDescGZIP(), []int{4, 0}
 }
 
 // Type enumerator representing of the external reference.
@@ -942,7 +959,7 @@ func (x ExternalReference_ExternalReferenceType) Number() protoreflect.EnumNumbe
 
 // Deprecated: Use ExternalReference_ExternalReferenceType.Descriptor instead.
 func (ExternalReference_ExternalReferenceType) EnumDescriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{3, 0}
+	return file_sbom_proto_rawDescGZIP(), []int{5, 0}
 }
 
 // Type of the software component.
@@ -991,170 +1008,454 @@ func (x Node_NodeType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Node_NodeType.Descriptor instead.
 func (Node_NodeType) EnumDescriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{5, 0}
+	return file_sbom_proto_rawDescGZIP(), []int{8, 0}
 }
 
-// Document is the top-level structure representing the entire Software Bill of Materials (SBOM).
-// It serves as the core neutral ground for the SBOM translation process, encapsulating metadata,
-// components (nodes), and the graph structure (edges).
-type Document struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+// Format is the signature scheme the signature was found in.
+type Signature_Format int32
 
-	// Metadata associated with the SBOM document
-	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	// List of nodes and edges forming the SBOM graph
-	NodeList *NodeList `protobuf:"bytes,2,opt,name=node_list,json=nodeList,proto3" json:"node_list,omitempty"`
-}
+const (
+	// Unknown format.
+	Signature_UNKNOWN_FORMAT Signature_Format = 0
+	// Signature of a DSSE envelope wrapping the document.
+	Signature_DSSE Signature_Format = 1
+	// JSON Signature Format signature embedded in a CycloneDX document.
+	Signature_JSF Signature_Format = 2
+	// Signature provided separately from the document.
+	Signature_DETACHED Signature_Format = 3
+)
 
-func (x *Document) Reset() {
-	*x = Document{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[0]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+// Enum value maps for Signature_Format.
+var (
+	Signature_Format_name = map[int32]string{
+		0: "UNKNOWN_FORMAT",
+		1: "DSSE",
+		2: "JSF",
+		3: "DETACHED",
+	}
+	Signature_Format_value = map[string]int32{
+		"UNKNOWN_FORMAT": 0,
+		"DSSE":           1,
+		"JSF":            2,
+		"DETACHED":       3,
 	}
+)
+
+func (x Signature_Format) Enum() *Signature_Format {
+	p := new(Signature_Format)
+	*p = x
+	return p
 }
 
-func (x *Document) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x Signature_Format) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (*Document) ProtoMessage() {}
+func (Signature_Format) Descriptor() protoreflect.EnumDescriptor {
+	return file_sbom_proto_enumTypes[7].Descriptor()
+}
 
-func (x *Document) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[0]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
+func (Signature_Format) Type() protoreflect.EnumType {
+	return &file_sbom_proto_enumTypes[7]
 }
 
-// Deprecated: Use Document.ProtoReflect.Descriptor instead.
-func (*Document) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{0}
+func (x Signature_Format) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (x *Document) GetMetadata() *Metadata {
-	if x != nil {
-		return x.Metadata
-	}
-	return nil
+// Deprecated: Use Signature_Format.Descriptor instead.
+func (Signature_Format) EnumDescriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{15, 0}
 }
 
-func (x *Document) GetNodeList() *NodeList {
-	if x != nil {
-		return x.NodeList
+// Verification is the result of checking the signature.
+type Signature_Verification int32
+
+const (
+	// The signature was not checked.
+	Signature_UNVERIFIED Signature_Verification = 0
+	// The signature is valid.
+	Signature_VERIFIED Signature_Verification = 1
+	// The signature is not valid.
+	Signature_FAILED Signature_Verification = 2
+)
+
+// Enum value maps for Signature_Verification.
+var (
+	Signature_Verification_name = map[int32]string{
+		0: "UNVERIFIED",
+		1: "VERIFIED",
+		2: "FAILED",
 	}
-	return nil
+	Signature_Verification_value = map[string]int32{
+		"UNVERIFIED": 0,
+		"VERIFIED":   1,
+		"FAILED":     2,
+	}
+)
+
+func (x Signature_Verification) Enum() *Signature_Verification {
+	p := new(Signature_Verification)
+	*p = x
+	return p
 }
 
-// DocumentType represents the type of document in the Software Bill of Materials (SBOM) ecosystem.
-// It categorizes the SBOM document based on its purpose or stage in the software development lifecycle.
-type DocumentType struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x Signature_Verification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
 
-	// SBOM document type.
-	Type *DocumentType_SBOMType `protobuf:"varint,1,opt,name=type,proto3,enum=protobom.protobom.DocumentType_SBOMType,oneof" json:"type,omitempty"`
-	// Name associated with the document type.
-	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	// Description of the document type.
-	Description *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+func (Signature_Verification) Descriptor() protoreflect.EnumDescriptor {
+	return file_sbom_proto_enumTypes[8].Descriptor()
 }
 
-func (x *DocumentType) Reset() {
-	*x = DocumentType{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[1]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
-	}
+func (Signature_Verification) Type() protoreflect.EnumType {
+	return &file_sbom_proto_enumTypes[8]
 }
 
-func (x *DocumentType) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x Signature_Verification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
 }
 
-func (*DocumentType) ProtoMessage() {}
+// Deprecated: Use Signature_Verification.Descriptor instead.
+func (Signature_Verification) EnumDescriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{15, 1}
+}
 
-func (x *DocumentType) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[1]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+// Severity is the canonical severity of a vulnerability, comparable
+// across sources and scoring methods.
+type Vulnerability_Severity int32
+
+const (
+	// The severity is not known.
+	Vulnerability_UNKNOWN_SEVERITY Vulnerability_Severity = 0
+	// The vulnerability has no impact, eg CVSS v3 score 0.0.
+	Vulnerability_NONE Vulnerability_Severity = 1
+	// Informational, no direct impact.
+	Vulnerability_INFO Vulnerability_Severity = 2
+	// Low severity.
+	Vulnerability_LOW Vulnerability_Severity = 3
+	// Medium severity.
+	Vulnerability_MEDIUM Vulnerability_Severity = 4
+	// High severity.
+	Vulnerability_HIGH Vulnerability_Severity = 5
+	// Critical severity.
+	Vulnerability_CRITICAL Vulnerability_Severity = 6
+)
+
+// Enum value maps for Vulnerability_Severity.
+var (
+	Vulnerability_Severity_name = map[int32]string{
+		0: "UNKNOWN_SEVERITY",
+		1: "NONE",
+		2: "INFO",
+		3: "LOW",
+		4: "MEDIUM",
+		5: "HIGH",
+		6: "CRITICAL",
 	}
-	return mi.MessageOf(x)
+	Vulnerability_Severity_value = map[string]int32{
+		"UNKNOWN_SEVERITY": 0,
+		"NONE":             1,
+		"INFO":             2,
+		"LOW":              3,
+		"MEDIUM":           4,
+		"HIGH":             5,
+		"CRITICAL":         6,
+	}
+)
+
+func (x Vulnerability_Severity) Enum() *Vulnerability_Severity {
+	p := new(Vulnerability_Severity)
+	*p = x
+	return p
 }
 
-// Deprecated: Use DocumentType.ProtoReflect.Descriptor instead.
-func (*DocumentType) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{1}
+func (x Vulnerability_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
 }
 
-func (x *DocumentType) GetType() DocumentType_SBOMType {
-	if x != nil && x.Type != nil {
-		return *x.Type
+func (Vulnerability_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_sbom_proto_enumTypes[9].Descriptor()
+}
+
+func (Vulnerability_Severity) Type() protoreflect.EnumType {
+	return &file_sbom_proto_enumTypes[9]
+}
+
+func (x Vulnerability_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Vulnerability_Severity.Descriptor instead.
+func (Vulnerability_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18, 0}
+}
+
+// State of the impact analysis.
+type Vulnerability_Analysis_State int32
+
+const (
+	// The vulnerability has not been analyzed.
+	Vulnerability_Analysis_UNKNOWN_STATE Vulnerability_Analysis_State = 0
+	// The vulnerability was remediated.
+	Vulnerability_Analysis_RESOLVED Vulnerability_Analysis_State = 1
+	// The vulnerability was remediated and evidence of the changes is provided.
+	Vulnerability_Analysis_RESOLVED_WITH_PEDIGREE Vulnerability_Analysis_State = 2
+	// The vulnerability may be directly or indirectly exploitable.
+	Vulnerability_Analysis_EXPLOITABLE Vulnerability_Analysis_State = 3
+	// The vulnerability is being investigated.
+	Vulnerability_Analysis_IN_TRIAGE Vulnerability_Analysis_State = 4
+	// The vulnerability does not apply, it was reported in error.
+	Vulnerability_Analysis_FALSE_POSITIVE Vulnerability_Analysis_State = 5
+	// The vulnerability does not affect the nodes.
+	Vulnerability_Analysis_NOT_AFFECTED Vulnerability_Analysis_State = 6
+)
+
+// Enum value maps for Vulnerability_Analysis_State.
+var (
+	Vulnerability_Analysis_State_name = map[int32]string{
+		0: "UNKNOWN_STATE",
+		1: "RESOLVED",
+		2: "RESOLVED_WITH_PEDIGREE",
+		3: "EXPLOITABLE",
+		4: "IN_TRIAGE",
+		5: "FALSE_POSITIVE",
+		6: "NOT_AFFECTED",
 	}
-	return DocumentType_OTHER
+	Vulnerability_Analysis_State_value = map[string]int32{
+		"UNKNOWN_STATE":          0,
+		"RESOLVED":               1,
+		"RESOLVED_WITH_PEDIGREE": 2,
+		"EXPLOITABLE":            3,
+		"IN_TRIAGE":              4,
+		"FALSE_POSITIVE":         5,
+		"NOT_AFFECTED":           6,
+	}
+)
+
+func (x Vulnerability_Analysis_State) Enum() *Vulnerability_Analysis_State {
+	p := new(Vulnerability_Analysis_State)
+	*p = x
+	return p
 }
 
-func (x *DocumentType) GetName() string {
-	if x != nil && x.Name != nil {
-		return *x.Name
+func (x Vulnerability_Analysis_State) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Vulnerability_Analysis_State) Descriptor() protoreflect.EnumDescriptor {
+	return file_sbom_proto_enumTypes[10].Descriptor()
+}
+
+func (Vulnerability_Analysis_State) Type() protoreflect.EnumType {
+	return &file_sbom_proto_enumTypes[10]
+}
+
+func (x Vulnerability_Analysis_State) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Vulnerability_Analysis_State.Descriptor instead.
+func (Vulnerability_Analysis_State) EnumDescriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18, 0, 0}
+}
+
+// Justification explains why the nodes are not affected.
+type Vulnerability_Analysis_Justification int32
+
+const (
+	// No justification given.
+	Vulnerability_Analysis_UNKNOWN_JUSTIFICATION Vulnerability_Analysis_Justification = 0
+	// The vulnerable code was removed or is not included.
+	Vulnerability_Analysis_CODE_NOT_PRESENT Vulnerability_Analysis_Justification = 1
+	// The vulnerable code is present but never executed.
+	Vulnerability_Analysis_CODE_NOT_REACHABLE Vulnerability_Analysis_Justification = 2
+	// Exploiting the vulnerability requires a configuration not in use.
+	Vulnerability_Analysis_REQUIRES_CONFIGURATION Vulnerability_Analysis_Justification = 3
+	// Exploiting the vulnerability requires a dependency not present.
+	Vulnerability_Analysis_REQUIRES_DEPENDENCY Vulnerability_Analysis_Justification = 4
+	// Exploiting the vulnerability requires an environment not in use.
+	Vulnerability_Analysis_REQUIRES_ENVIRONMENT Vulnerability_Analysis_Justification = 5
+	// Exploitation is prevented by compiler protections.
+	Vulnerability_Analysis_PROTECTED_BY_COMPILER Vulnerability_Analysis_Justification = 6
+	// Exploitation is prevented by runtime protections.
+	Vulnerability_Analysis_PROTECTED_AT_RUNTIME Vulnerability_Analysis_Justification = 7
+	// Exploitation is prevented by network perimeter protections.
+	Vulnerability_Analysis_PROTECTED_AT_PERIMETER Vulnerability_Analysis_Justification = 8
+	// Exploitation is prevented by other mitigating controls.
+	Vulnerability_Analysis_PROTECTED_BY_MITIGATING_CONTROL Vulnerability_Analysis_Justification = 9
+)
+
+// Enum value maps for Vulnerability_Analysis_Justification.
+var (
+	Vulnerability_Analysis_Justification_name = map[int32]string{
+		0: "UNKNOWN_JUSTIFICATION",
+		1: "CODE_NOT_PRESENT",
+		2: "CODE_NOT_REACHABLE",
+		3: "REQUIRES_CONFIGURATION",
+		4: "REQUIRES_DEPENDENCY",
+		5: "REQUIRES_ENVIRONMENT",
+		6: "PROTECTED_BY_COMPILER",
+		7: "PROTECTED_AT_RUNTIME",
+		8: "PROTECTED_AT_PERIMETER",
+		9: "PROTECTED_BY_MITIGATING_CONTROL",
 	}
-	return ""
+	Vulnerability_Analysis_Justification_value = map[string]int32{
+		"UNKNOWN_JUSTIFICATION":           0,
+		"CODE_NOT_PRESENT":                1,
+		"CODE_NOT_REACHABLE":              2,
+		"REQUIRES_CONFIGURATION":          3,
+		"REQUIRES_DEPENDENCY":             4,
+		"REQUIRES_ENVIRONMENT":            5,
+		"PROTECTED_BY_COMPILER":           6,
+		"PROTECTED_AT_RUNTIME":            7,
+		"PROTECTED_AT_PERIMETER":          8,
+		"PROTECTED_BY_MITIGATING_CONTROL": 9,
+	}
+)
+
+func (x Vulnerability_Analysis_Justification) Enum() *Vulnerability_Analysis_Justification {
+	p := new(Vulnerability_Analysis_Justification)
+	*p = x
+	return p
 }
 
-func (x *DocumentType) GetDescription() string {
-	if x != nil && x.Description != nil {
-		return *x.Description
+func (x Vulnerability_Analysis_Justification) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Vulnerability_Analysis_Justification) Descriptor() protoreflect.EnumDescriptor {
+	return file_sbom_proto_enumTypes[11].Descriptor()
+}
+
+func (Vulnerability_Analysis_Justification) Type() protoreflect.EnumType {
+	return &file_sbom_proto_enumTypes[11]
+}
+
+func (x Vulnerability_Analysis_Justification) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Vulnerability_Analysis_Justification.Descriptor instead.
+func (Vulnerability_Analysis_Justification) EnumDescriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18, 0, 1}
+}
+
+// Response is an action taken in response to the vulnerability.
+type Vulnerability_Analysis_Response int32
+
+const (
+	// No response given.
+	Vulnerability_Analysis_UNKNOWN_RESPONSE Vulnerability_Analysis_Response = 0
+	// The vulnerability can not be fixed.
+	Vulnerability_Analysis_CAN_NOT_FIX Vulnerability_Analysis_Response = 1
+	// The vulnerability will not be fixed.
+	Vulnerability_Analysis_WILL_NOT_FIX Vulnerability_Analysis_Response = 2
+	// Update to a version fixing the vulnerability.
+	Vulnerability_Analysis_UPDATE Vulnerability_Analysis_Response = 3
+	// Roll back to a version not affected by the vulnerability.
+	Vulnerability_Analysis_ROLLBACK Vulnerability_Analysis_Response = 4
+	// A workaround is available.
+	Vulnerability_Analysis_WORKAROUND_AVAILABLE Vulnerability_Analysis_Response = 5
+)
+
+// Enum value maps for Vulnerability_Analysis_Response.
+var (
+	Vulnerability_Analysis_Response_name = map[int32]string{
+		0: "UNKNOWN_RESPONSE",
+		1: "CAN_NOT_FIX",
+		2: "WILL_NOT_FIX",
+		3: "UPDATE",
+		4: "ROLLBACK",
+		5: "WORKAROUND_AVAILABLE",
 	}
-	return ""
+	Vulnerability_Analysis_Response_value = map[string]int32{
+		"UNKNOWN_RESPONSE":     0,
+		"CAN_NOT_FIX":          1,
+		"WILL_NOT_FIX":         2,
+		"UPDATE":               3,
+		"ROLLBACK":             4,
+		"WORKAROUND_AVAILABLE": 5,
+	}
+)
+
+func (x Vulnerability_Analysis_Response) Enum() *Vulnerability_Analysis_Response {
+	p := new(Vulnerability_Analysis_Response)
+	*p = x
+	return p
 }
 
-// Edge represents relationships between nodes in the Software Bill of Materials (SBOM) graph.
-// Each Edge captures the type of relationship and the nodes involved, providing a structured
-// way to model dependencies and connections within the SBOM.
-type Edge struct {
+func (x Vulnerability_Analysis_Response) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Vulnerability_Analysis_Response) Descriptor() protoreflect.EnumDescriptor {
+	return file_sbom_proto_enumTypes[12].Descriptor()
+}
+
+func (Vulnerability_Analysis_Response) Type() protoreflect.EnumType {
+	return &file_sbom_proto_enumTypes[12]
+}
+
+func (x Vulnerability_Analysis_Response) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Vulnerability_Analysis_Response.Descriptor instead.
+func (Vulnerability_Analysis_Response) EnumDescriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18, 0, 2}
+}
+
+// AIModel holds the data of nodes describing artificial intelligence models.
+// It captures the fields of the SPDX 3 AI profile and the CycloneDX model cards.
+type AIModel struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Type enumerator representing the node relationship.
-	Type Edge_Type `protobuf:"varint,1,opt,name=type,proto3,enum=protobom.protobom.Edge_Type" json:"type,omitempty"`
-	// Source node of the edge.
-	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
-	// Target nodes of the edge.
-	To []string `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
-}
-
-func (x *Edge) Reset() {
-	*x = Edge{}
+	// Types of the model, eg "transformer" or "random forest".
+	TypeOfModel []string `protobuf:"bytes,1,rep,name=type_of_model,json=typeOfModel,proto3" json:"type_of_model,omitempty"`
+	// Task performed by the model, eg "text-generation".
+	Task string `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	// Architecture of the model, eg "LLaMA".
+	ModelArchitecture string `protobuf:"bytes,3,opt,name=model_architecture,json=modelArchitecture,proto3" json:"model_architecture,omitempty"`
+	// Domains where the model can be used, eg "medical".
+	Domain []string `protobuf:"bytes,4,rep,name=domain,proto3" json:"domain,omitempty"`
+	// Description of the intended applications of the model.
+	InformationAboutApplication string `protobuf:"bytes,5,opt,name=information_about_application,json=informationAboutApplication,proto3" json:"information_about_application,omitempty"`
+	// Description of the methods used to train the model.
+	InformationAboutTraining string `protobuf:"bytes,6,opt,name=information_about_training,json=informationAboutTraining,proto3" json:"information_about_training,omitempty"`
+	// Known limitations of the model.
+	Limitation string `protobuf:"bytes,7,opt,name=limitation,proto3" json:"limitation,omitempty"`
+	// Metrics used to evaluate the model and their values.
+	Metrics map[string]string `protobuf:"bytes,8,rep,name=metrics,proto3" json:"metrics,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Hyperparameters used to build the model and their values.
+	Hyperparameters map[string]string `protobuf:"bytes,9,rep,name=hyperparameters,proto3" json:"hyperparameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Whether the model can act without human intervention: yes, no or noAssertion.
+	AutonomyType string `protobuf:"bytes,10,opt,name=autonomy_type,json=autonomyType,proto3" json:"autonomy_type,omitempty"`
+	// Risk assessment of the model under the EU AI act: serious, high, medium or low.
+	SafetyRiskAssessment string `protobuf:"bytes,11,opt,name=safety_risk_assessment,json=safetyRiskAssessment,proto3" json:"safety_risk_assessment,omitempty"`
+	// Whether the model uses sensitive personal information: yes, no or noAssertion.
+	UseSensitivePersonalInformation string `protobuf:"bytes,12,opt,name=use_sensitive_personal_information,json=useSensitivePersonalInformation,proto3" json:"use_sensitive_personal_information,omitempty"`
+}
+
+func (x *AIModel) Reset() {
+	*x = AIModel{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[2]
+		mi := &file_sbom_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Edge) String() string {
+func (x *AIModel) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Edge) ProtoMessage() {}
+func (*AIModel) ProtoMessage() {}
 
-func (x *Edge) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[2]
+func (x *AIModel) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1165,163 +1466,139 @@ func (x *Edge) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
-func (*Edge) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use AIModel.ProtoReflect.Descriptor instead.
+func (*AIModel) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *Edge) GetType() Edge_Type {
+func (x *AIModel) GetTypeOfModel() []string {
 	if x != nil {
-		return x.Type
+		return x.TypeOfModel
 	}
-	return Edge_UNKNOWN
+	return nil
 }
 
-func (x *Edge) GetFrom() string {
+func (x *AIModel) GetTask() string {
 	if x != nil {
-		return x.From
+		return x.Task
 	}
 	return ""
 }
 
-func (x *Edge) GetTo() []string {
+func (x *AIModel) GetModelArchitecture() string {
 	if x != nil {
-		return x.To
+		return x.ModelArchitecture
+	}
+	return ""
+}
+
+func (x *AIModel) GetDomain() []string {
+	if x != nil {
+		return x.Domain
 	}
 	return nil
 }
 
-// ExternalReference is an entry linking an element to a resource defined outside the SBOM standard.
-type ExternalReference struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *AIModel) GetInformationAboutApplication() string {
+	if x != nil {
+		return x.InformationAboutApplication
+	}
+	return ""
+}
 
-	// URL providing reference to an external resource.
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	// Comments associated with the external reference.
-	Comment string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
-	// Authority responsible for the external reference.
-	Authority string `protobuf:"bytes,4,opt,name=authority,proto3" json:"authority,omitempty"`
-	// Hashes associated with the external reference.
-	Hashes map[int32]string `protobuf:"bytes,6,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Type of the external reference, Replaced field 2.
-	Type ExternalReference_ExternalReferenceType `protobuf:"varint,7,opt,name=type,proto3,enum=protobom.protobom.ExternalReference_ExternalReferenceType" json:"type,omitempty"`
-}
-
-func (x *ExternalReference) Reset() {
-	*x = ExternalReference{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *AIModel) GetInformationAboutTraining() string {
+	if x != nil {
+		return x.InformationAboutTraining
 	}
+	return ""
 }
 
-func (x *ExternalReference) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ExternalReference) ProtoMessage() {}
-
-func (x *ExternalReference) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *AIModel) GetLimitation() string {
+	if x != nil {
+		return x.Limitation
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ExternalReference.ProtoReflect.Descriptor instead.
-func (*ExternalReference) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{3}
+	return ""
 }
 
-func (x *ExternalReference) GetUrl() string {
+func (x *AIModel) GetMetrics() map[string]string {
 	if x != nil {
-		return x.Url
+		return x.Metrics
 	}
-	return ""
+	return nil
 }
 
-func (x *ExternalReference) GetComment() string {
+func (x *AIModel) GetHyperparameters() map[string]string {
 	if x != nil {
-		return x.Comment
+		return x.Hyperparameters
 	}
-	return ""
+	return nil
 }
 
-func (x *ExternalReference) GetAuthority() string {
+func (x *AIModel) GetAutonomyType() string {
 	if x != nil {
-		return x.Authority
+		return x.AutonomyType
 	}
 	return ""
 }
 
-func (x *ExternalReference) GetHashes() map[int32]string {
+func (x *AIModel) GetSafetyRiskAssessment() string {
 	if x != nil {
-		return x.Hashes
+		return x.SafetyRiskAssessment
 	}
-	return nil
+	return ""
 }
 
-func (x *ExternalReference) GetType() ExternalReference_ExternalReferenceType {
+func (x *AIModel) GetUseSensitivePersonalInformation() string {
 	if x != nil {
-		return x.Type
+		return x.UseSensitivePersonalInformation
 	}
-	return ExternalReference_UNKNOWN
+	return ""
 }
 
-// Metadata encapsulates document-related details about the Software Bill of Materials (SBOM) document.
-// It includes information such as the document's identifier, version, authorship, creation date,
-// associated tools, and document types.
-type Metadata struct {
+// Dataset holds the data of nodes describing datasets. It captures the fields
+// of the SPDX 3 Dataset profile and the CycloneDX component data.
+type Dataset struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Unique identifier for the document. Serial number in CycloneDX foramts, SPDXID in spdx formats.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Version of the document. In Cyclone formats the version is translated from `Int` field in to a more general `String` field.
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	// Name associated with the document.
-	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	// Created date of the Document. In SPDX formats mapped to the created date field.
-	Date *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
-	// Tools used in the creation or processing of the document.
-	Tools []*Tool `protobuf:"bytes,5,rep,name=tools,proto3" json:"tools,omitempty"`
-	// Individuals or organizations involved in the creation or maintenance of the document.
-	Authors []*Person `protobuf:"bytes,6,rep,name=authors,proto3" json:"authors,omitempty"`
-	// Comments on the document.
-	Comment string `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
-	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
-	// Types categorizing the document based on its purpose or stage in the software development lifecycle.
-	DocumentTypes []*DocumentType `protobuf:"bytes,8,rep,name=documentTypes,proto3" json:"documentTypes,omitempty"`
-	// Field for preserving original format information and additional metadata
-	SourceData *SourceData `protobuf:"bytes,9,opt,name=source_data,json=sourceData,proto3" json:"source_data,omitempty"`
-}
-
-func (x *Metadata) Reset() {
-	*x = Metadata{}
+	// Types of the data in the dataset, eg "text" or "image".
+	DatasetType []string `protobuf:"bytes,1,rep,name=dataset_type,json=datasetType,proto3" json:"dataset_type,omitempty"`
+	// Description of the intended uses of the dataset.
+	IntendedUse string `protobuf:"bytes,2,opt,name=intended_use,json=intendedUse,proto3" json:"intended_use,omitempty"`
+	// Description of how the data was collected.
+	DataCollectionProcess string `protobuf:"bytes,3,opt,name=data_collection_process,json=dataCollectionProcess,proto3" json:"data_collection_process,omitempty"`
+	// Size of the dataset in bytes.
+	DatasetSize int64 `protobuf:"varint,4,opt,name=dataset_size,json=datasetSize,proto3" json:"dataset_size,omitempty"`
+	// Confidentiality level of the data: red, amber, green or clear.
+	ConfidentialityLevel string `protobuf:"bytes,5,opt,name=confidentiality_level,json=confidentialityLevel,proto3" json:"confidentiality_level,omitempty"`
+	// Whether the dataset holds sensitive personal information: yes, no or noAssertion.
+	HasSensitivePersonalInformation string `protobuf:"bytes,6,opt,name=has_sensitive_personal_information,json=hasSensitivePersonalInformation,proto3" json:"has_sensitive_personal_information,omitempty"`
+	// Known biases of the dataset.
+	KnownBias []string `protobuf:"bytes,7,rep,name=known_bias,json=knownBias,proto3" json:"known_bias,omitempty"`
+	// How the dataset can be obtained, eg "directDownload" or "query".
+	DatasetAvailability string `protobuf:"bytes,8,opt,name=dataset_availability,json=datasetAvailability,proto3" json:"dataset_availability,omitempty"`
+	// Methods used to anonymize the dataset.
+	AnonymizationMethodUsed []string `protobuf:"bytes,9,rep,name=anonymization_method_used,json=anonymizationMethodUsed,proto3" json:"anonymization_method_used,omitempty"`
+}
+
+func (x *Dataset) Reset() {
+	*x = Dataset{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[4]
+		mi := &file_sbom_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Metadata) String() string {
+func (x *Dataset) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Metadata) ProtoMessage() {}
+func (*Dataset) ProtoMessage() {}
 
-func (x *Metadata) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[4]
+func (x *Dataset) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1332,160 +1609,107 @@ func (x *Metadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
-func (*Metadata) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Dataset.ProtoReflect.Descriptor instead.
+func (*Dataset) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Metadata) GetId() string {
+func (x *Dataset) GetDatasetType() []string {
 	if x != nil {
-		return x.Id
+		return x.DatasetType
 	}
-	return ""
+	return nil
 }
 
-func (x *Metadata) GetVersion() string {
+func (x *Dataset) GetIntendedUse() string {
 	if x != nil {
-		return x.Version
+		return x.IntendedUse
 	}
 	return ""
 }
 
-func (x *Metadata) GetName() string {
+func (x *Dataset) GetDataCollectionProcess() string {
 	if x != nil {
-		return x.Name
+		return x.DataCollectionProcess
 	}
 	return ""
 }
 
-func (x *Metadata) GetDate() *timestamppb.Timestamp {
+func (x *Dataset) GetDatasetSize() int64 {
 	if x != nil {
-		return x.Date
+		return x.DatasetSize
 	}
-	return nil
+	return 0
 }
 
-func (x *Metadata) GetTools() []*Tool {
+func (x *Dataset) GetConfidentialityLevel() string {
 	if x != nil {
-		return x.Tools
+		return x.ConfidentialityLevel
 	}
-	return nil
+	return ""
 }
 
-func (x *Metadata) GetAuthors() []*Person {
+func (x *Dataset) GetHasSensitivePersonalInformation() string {
 	if x != nil {
-		return x.Authors
+		return x.HasSensitivePersonalInformation
 	}
-	return nil
+	return ""
 }
 
-func (x *Metadata) GetComment() string {
+func (x *Dataset) GetKnownBias() []string {
 	if x != nil {
-		return x.Comment
+		return x.KnownBias
 	}
-	return ""
+	return nil
 }
 
-func (x *Metadata) GetDocumentTypes() []*DocumentType {
+func (x *Dataset) GetDatasetAvailability() string {
 	if x != nil {
-		return x.DocumentTypes
+		return x.DatasetAvailability
 	}
-	return nil
+	return ""
 }
 
-func (x *Metadata) GetSourceData() *SourceData {
+func (x *Dataset) GetAnonymizationMethodUsed() []string {
 	if x != nil {
-		return x.SourceData
+		return x.AnonymizationMethodUsed
 	}
 	return nil
 }
 
-// Node represents a central element within the Software Bill of Materials (SBOM) graph,
-// serving as a vertex that captures vital information about a software component.
-// Each Node in the SBOM graph signifies a distinct software component, forming the vertices of the graph.
-type Node struct {
+// Document is the top-level structure representing the entire Software Bill of Materials (SBOM).
+// It serves as the core neutral ground for the SBOM translation process, encapsulating metadata,
+// components (nodes), and the graph structure (edges).
+type Document struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Unique identifier for the node.
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	// Type of the software component.
-	Type Node_NodeType `protobuf:"varint,2,opt,name=type,proto3,enum=protobom.protobom.Node_NodeType" json:"type,omitempty"`
-	// Name of the software component.
-	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	// Version string of the software component.
-	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
-	// Package filename when there is one.
-	FileName string `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	// Website of the package.
-	UrlHome string `protobuf:"bytes,6,opt,name=url_home,json=urlHome,proto3" json:"url_home,omitempty"`
-	// Location to download the package.
-	UrlDownload string `protobuf:"bytes,7,opt,name=url_download,json=urlDownload,proto3" json:"url_download,omitempty"`
-	// Multiple licenses applicable to the software component,
-	// Multiple licenses can be specified for CycloneDX 1.4 and files in SPDX.
-	Licenses []string `protobuf:"bytes,8,rep,name=licenses,proto3" json:"licenses,omitempty"`
-	// Concluded license applicable to the software component,
-	// This is only in SPDX and it is just one.
-	LicenseConcluded string `protobuf:"bytes,9,opt,name=license_concluded,json=licenseConcluded,proto3" json:"license_concluded,omitempty"`
-	// Comments on the license.
-	LicenseComments string `protobuf:"bytes,10,opt,name=license_comments,json=licenseComments,proto3" json:"license_comments,omitempty"`
-	// Copyright information applicable to the software component.
-	Copyright string `protobuf:"bytes,11,opt,name=copyright,proto3" json:"copyright,omitempty"`
-	// This field is intended to capture details related to the source or origin of the software component.
-	// It may include any relevant background information or additional comments.
-	SourceInfo string `protobuf:"bytes,13,opt,name=source_info,json=sourceInfo,proto3" json:"source_info,omitempty"`
-	// Comments on the software component.
-	Comment string `protobuf:"bytes,15,opt,name=comment,proto3" json:"comment,omitempty"`
-	// Concise description of the software component (short description).
-	Summary string `protobuf:"bytes,16,opt,name=summary,proto3" json:"summary,omitempty"`
-	// Detailed description of the software component (full description).
-	Description string `protobuf:"bytes,17,opt,name=description,proto3" json:"description,omitempty"`
-	// One or more contributions or acknowledgments associated with the software component.
-	Attribution []string `protobuf:"bytes,18,rep,name=attribution,proto3" json:"attribution,omitempty"`
-	// One or more entities providing the software component.
-	Suppliers []*Person `protobuf:"bytes,19,rep,name=suppliers,proto3" json:"suppliers,omitempty"`
-	// One or more entities involved in the creation or maintenance of the software component.
-	Originators []*Person `protobuf:"bytes,20,rep,name=originators,proto3" json:"originators,omitempty"`
-	// Release date of the software component.
-	ReleaseDate *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
-	// Build date of the software component.
-	BuildDate *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
-	// Valid until date of the software component.
-	ValidUntilDate *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=valid_until_date,json=validUntilDate,proto3" json:"valid_until_date,omitempty"`
-	// External references associated with the software component.
-	ExternalReferences []*ExternalReference `protobuf:"bytes,24,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`
-	// File types associated with the component
-	FileTypes []string `protobuf:"bytes,27,rep,name=file_types,json=fileTypes,proto3" json:"file_types,omitempty"`
-	// Software identifer map used by the component.
-	// Maps between the software identifier types and the identifier values.
-	Identifiers map[int32]string `protobuf:"bytes,28,rep,name=identifiers,proto3" json:"identifiers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Hashes map associated with the software component.
-	// Maps between hash algorithms types and hash values.
-	Hashes map[int32]string `protobuf:"bytes,29,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// Primary purpose or role assigned to the software component.
-	PrimaryPurpose []Purpose `protobuf:"varint,30,rep,packed,name=primary_purpose,json=primaryPurpose,proto3,enum=protobom.protobom.Purpose" json:"primary_purpose,omitempty"`
-	// Property collection of the node.
-	Properties []*Property `protobuf:"bytes,31,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Metadata associated with the SBOM document
+	Metadata *Metadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// List of nodes and edges forming the SBOM graph
+	NodeList *NodeList `protobuf:"bytes,2,opt,name=node_list,json=nodeList,proto3" json:"node_list,omitempty"`
+	// Vulnerabilities known to affect the nodes of the document
+	Vulnerabilities []*Vulnerability `protobuf:"bytes,3,rep,name=vulnerabilities,proto3" json:"vulnerabilities,omitempty"`
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+func (x *Document) Reset() {
+	*x = Document{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[5]
+		mi := &file_sbom_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Node) String() string {
+func (x *Document) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*Document) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[5]
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1496,232 +1720,1484 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Node) GetId() string {
+func (x *Document) GetMetadata() *Metadata {
 	if x != nil {
-		return x.Id
+		return x.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (x *Node) GetType() Node_NodeType {
+func (x *Document) GetNodeList() *NodeList {
 	if x != nil {
-		return x.Type
+		return x.NodeList
 	}
-	return Node_PACKAGE
+	return nil
 }
 
-func (x *Node) GetName() string {
+func (x *Document) GetVulnerabilities() []*Vulnerability {
 	if x != nil {
-		return x.Name
+		return x.Vulnerabilities
 	}
-	return ""
+	return nil
 }
 
-func (x *Node) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
-}
+// DocumentType represents the type of document in the Software Bill of Materials (SBOM) ecosystem.
+// It categorizes the SBOM document based on its purpose or stage in the software development lifecycle.
+type DocumentType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-func (x *Node) GetFileName() string {
-	if x != nil {
-		return x.FileName
-	}
-	return ""
+	// SBOM document type.
+	Type *DocumentType_SBOMType `protobuf:"varint,1,opt,name=type,proto3,enum=protobom.protobom.DocumentType_SBOMType,oneof" json:"type,omitempty"`
+	// Name associated with the document type.
+	Name *string `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	// Description of the document type.
+	Description *string `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
 }
 
-func (x *Node) GetUrlHome() string {
-	if x != nil {
-		return x.UrlHome
+func (x *DocumentType) Reset() {
+	*x = DocumentType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DocumentType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentType) ProtoMessage() {}
+
+func (x *DocumentType) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentType.ProtoReflect.Descriptor instead.
+func (*DocumentType) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DocumentType) GetType() DocumentType_SBOMType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return DocumentType_OTHER
+}
+
+func (x *DocumentType) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *DocumentType) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+// Edge represents relationships between nodes in the Software Bill of Materials (SBOM) graph.
+// Each Edge captures the type of relationship and the nodes involved, providing a structured
+// way to model dependencies and connections within the SBOM.
+type Edge struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Type enumerator representing the node relationship.
+	Type Edge_Type `protobuf:"varint,1,opt,name=type,proto3,enum=protobom.protobom.Edge_Type" json:"type,omitempty"`
+	// Source node of the edge.
+	From string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	// Target nodes of the edge.
+	To []string `protobuf:"bytes,3,rep,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *Edge) Reset() {
+	*x = Edge{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Edge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Edge) ProtoMessage() {}
+
+func (x *Edge) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Edge.ProtoReflect.Descriptor instead.
+func (*Edge) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Edge) GetType() Edge_Type {
+	if x != nil {
+		return x.Type
+	}
+	return Edge_UNKNOWN
+}
+
+func (x *Edge) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *Edge) GetTo() []string {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// ExternalReference is an entry linking an element to a resource defined outside the SBOM standard.
+type ExternalReference struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// URL providing reference to an external resource.
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Comments associated with the external reference.
+	Comment string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	// Authority responsible for the external reference.
+	Authority string `protobuf:"bytes,4,opt,name=authority,proto3" json:"authority,omitempty"`
+	// Hashes associated with the external reference.
+	Hashes map[int32]string `protobuf:"bytes,6,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Type of the external reference, Replaced field 2.
+	Type ExternalReference_ExternalReferenceType `protobuf:"varint,7,opt,name=type,proto3,enum=protobom.protobom.ExternalReference_ExternalReferenceType" json:"type,omitempty"`
+}
+
+func (x *ExternalReference) Reset() {
+	*x = ExternalReference{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExternalReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExternalReference) ProtoMessage() {}
+
+func (x *ExternalReference) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExternalReference.ProtoReflect.Descriptor instead.
+func (*ExternalReference) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExternalReference) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetAuthority() string {
+	if x != nil {
+		return x.Authority
+	}
+	return ""
+}
+
+func (x *ExternalReference) GetHashes() map[int32]string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *ExternalReference) GetType() ExternalReference_ExternalReferenceType {
+	if x != nil {
+		return x.Type
+	}
+	return ExternalReference_UNKNOWN
+}
+
+// License captures a custom (non SPDX list) license declared in the document.
+// In SPDX these are the extracted licensing info entries identified with LicenseRef-*
+// identifiers, in CycloneDX they are mapped to named licenses with their full text.
+type License struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// License identifier, usually a LicenseRef-* string.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Human readable name of the license.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Full text of the license.
+	Text string `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	// URLs pointing to the license text.
+	Urls []string `protobuf:"bytes,4,rep,name=urls,proto3" json:"urls,omitempty"`
+	// Comments on the license.
+	Comment string `protobuf:"bytes,5,opt,name=comment,proto3" json:"comment,omitempty"`
+}
+
+func (x *License) Reset() {
+	*x = License{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *License) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*License) ProtoMessage() {}
+
+func (x *License) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use License.ProtoReflect.Descriptor instead.
+func (*License) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *License) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *License) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *License) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *License) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *License) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+// Metadata encapsulates document-related details about the Software Bill of Materials (SBOM) document.
+// It includes information such as the document's identifier, version, authorship, creation date,
+// associated tools, and document types.
+type Metadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique identifier for the document. Serial number in CycloneDX foramts, SPDXID in spdx formats.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Version of the document. In Cyclone formats the version is translated from `Int` field in to a more general `String` field.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Name associated with the document.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Created date of the Document. In SPDX formats mapped to the created date field.
+	Date *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	// Tools used in the creation or processing of the document.
+	Tools []*Tool `protobuf:"bytes,5,rep,name=tools,proto3" json:"tools,omitempty"`
+	// Individuals or organizations involved in the creation or maintenance of the document.
+	Authors []*Person `protobuf:"bytes,6,rep,name=authors,proto3" json:"authors,omitempty"`
+	// Comments on the document.
+	Comment string `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	// buf:lint:ignore FIELD_LOWER_SNAKE_CASE
+	// Types categorizing the document based on its purpose or stage in the software development lifecycle.
+	DocumentTypes []*DocumentType `protobuf:"bytes,8,rep,name=documentTypes,proto3" json:"documentTypes,omitempty"`
+	// Field for preserving original format information and additional metadata
+	SourceData *SourceData `protobuf:"bytes,9,opt,name=source_data,json=sourceData,proto3" json:"source_data,omitempty"`
+	// Custom licenses (SPDX LicenseRef-*) declared in the document, including their full text.
+	CustomLicenses []*License `protobuf:"bytes,10,rep,name=custom_licenses,json=customLicenses,proto3" json:"custom_licenses,omitempty"`
+	// History of the revisions of the document, oldest first.
+	Revisions []*Revision `protobuf:"bytes,11,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	// Comments from the document creators. Mapped to the creator comment in SPDX formats.
+	CreatorComment string `protobuf:"bytes,12,opt,name=creator_comment,json=creatorComment,proto3" json:"creator_comment,omitempty"`
+	// Document-level properties. Mapped to the metadata properties in CycloneDX formats.
+	Properties []*Property `protobuf:"bytes,13,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Signatures found on the original document when it was parsed, with the
+	// result of their verification.
+	Signatures []*Signature `protobuf:"bytes,14,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *Metadata) Reset() {
+	*x = Metadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Metadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Metadata) ProtoMessage() {}
+
+func (x *Metadata) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Metadata.ProtoReflect.Descriptor instead.
+func (*Metadata) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Metadata) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Metadata) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Metadata) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Metadata) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Metadata) GetTools() []*Tool {
+	if x != nil {
+		return x.Tools
+	}
+	return nil
+}
+
+func (x *Metadata) GetAuthors() []*Person {
+	if x != nil {
+		return x.Authors
+	}
+	return nil
+}
+
+func (x *Metadata) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Metadata) GetDocumentTypes() []*DocumentType {
+	if x != nil {
+		return x.DocumentTypes
+	}
+	return nil
+}
+
+func (x *Metadata) GetSourceData() *SourceData {
+	if x != nil {
+		return x.SourceData
+	}
+	return nil
+}
+
+func (x *Metadata) GetCustomLicenses() []*License {
+	if x != nil {
+		return x.CustomLicenses
+	}
+	return nil
+}
+
+func (x *Metadata) GetRevisions() []*Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+func (x *Metadata) GetCreatorComment() string {
+	if x != nil {
+		return x.CreatorComment
+	}
+	return ""
+}
+
+func (x *Metadata) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Metadata) GetSignatures() []*Signature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+// Node represents a central element within the Software Bill of Materials (SBOM) graph,
+// serving as a vertex that captures vital information about a software component.
+// Each Node in the SBOM graph signifies a distinct software component, forming the vertices of the graph.
+type Node struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unique identifier for the node.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type of the software component.
+	Type Node_NodeType `protobuf:"varint,2,opt,name=type,proto3,enum=protobom.protobom.Node_NodeType" json:"type,omitempty"`
+	// Name of the software component.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Version string of the software component.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// Package filename when there is one.
+	FileName string `protobuf:"bytes,5,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	// Website of the package.
+	UrlHome string `protobuf:"bytes,6,opt,name=url_home,json=urlHome,proto3" json:"url_home,omitempty"`
+	// Location to download the package.
+	UrlDownload string `protobuf:"bytes,7,opt,name=url_download,json=urlDownload,proto3" json:"url_download,omitempty"`
+	// Multiple licenses applicable to the software component,
+	// Multiple licenses can be specified for CycloneDX 1.4 and files in SPDX.
+	Licenses []string `protobuf:"bytes,8,rep,name=licenses,proto3" json:"licenses,omitempty"`
+	// Concluded license applicable to the software component,
+	// This is only in SPDX and it is just one.
+	LicenseConcluded string `protobuf:"bytes,9,opt,name=license_concluded,json=licenseConcluded,proto3" json:"license_concluded,omitempty"`
+	// Comments on the license.
+	LicenseComments string `protobuf:"bytes,10,opt,name=license_comments,json=licenseComments,proto3" json:"license_comments,omitempty"`
+	// Copyright information applicable to the software component.
+	Copyright string `protobuf:"bytes,11,opt,name=copyright,proto3" json:"copyright,omitempty"`
+	// This field is intended to capture details related to the source or origin of the software component.
+	// It may include any relevant background information or additional comments.
+	SourceInfo string `protobuf:"bytes,13,opt,name=source_info,json=sourceInfo,proto3" json:"source_info,omitempty"`
+	// Comments on the software component.
+	Comment string `protobuf:"bytes,15,opt,name=comment,proto3" json:"comment,omitempty"`
+	// Concise description of the software component (short description).
+	Summary string `protobuf:"bytes,16,opt,name=summary,proto3" json:"summary,omitempty"`
+	// Detailed description of the software component (full description).
+	Description string `protobuf:"bytes,17,opt,name=description,proto3" json:"description,omitempty"`
+	// One or more contributions or acknowledgments associated with the software component.
+	Attribution []string `protobuf:"bytes,18,rep,name=attribution,proto3" json:"attribution,omitempty"`
+	// One or more entities providing the software component.
+	Suppliers []*Person `protobuf:"bytes,19,rep,name=suppliers,proto3" json:"suppliers,omitempty"`
+	// One or more entities involved in the creation or maintenance of the software component.
+	Originators []*Person `protobuf:"bytes,20,rep,name=originators,proto3" json:"originators,omitempty"`
+	// Release date of the software component.
+	ReleaseDate *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=release_date,json=releaseDate,proto3" json:"release_date,omitempty"`
+	// Build date of the software component.
+	BuildDate *timestamppb.Timestamp `protobuf:"bytes,22,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"`
+	// Valid until date of the software component.
+	ValidUntilDate *timestamppb.Timestamp `protobuf:"bytes,23,opt,name=valid_until_date,json=validUntilDate,proto3" json:"valid_until_date,omitempty"`
+	// External references associated with the software component.
+	ExternalReferences []*ExternalReference `protobuf:"bytes,24,rep,name=external_references,json=externalReferences,proto3" json:"external_references,omitempty"`
+	// File types associated with the component
+	FileTypes []string `protobuf:"bytes,27,rep,name=file_types,json=fileTypes,proto3" json:"file_types,omitempty"`
+	// Software identifer map used by the component.
+	// Maps between the software identifier types and the identifier values.
+	Identifiers map[int32]string `protobuf:"bytes,28,rep,name=identifiers,proto3" json:"identifiers,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Hashes map associated with the software component.
+	// Maps between hash algorithms types and hash values.
+	Hashes map[int32]string `protobuf:"bytes,29,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Primary purpose or role assigned to the software component.
+	PrimaryPurpose []Purpose `protobuf:"varint,30,rep,packed,name=primary_purpose,json=primaryPurpose,proto3,enum=protobom.protobom.Purpose" json:"primary_purpose,omitempty"`
+	// Property collection of the node.
+	Properties []*Property `protobuf:"bytes,31,rep,name=properties,proto3" json:"properties,omitempty"`
+	// Model data of nodes describing AI models.
+	AiModel *AIModel `protobuf:"bytes,32,opt,name=ai_model,json=aiModel,proto3" json:"ai_model,omitempty"`
+	// Dataset data of nodes describing datasets.
+	Dataset *Dataset `protobuf:"bytes,33,opt,name=dataset,proto3" json:"dataset,omitempty"`
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Node) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Node) GetType() Node_NodeType {
+	if x != nil {
+		return x.Type
+	}
+	return Node_PACKAGE
+}
+
+func (x *Node) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Node) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Node) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *Node) GetUrlHome() string {
+	if x != nil {
+		return x.UrlHome
+	}
+	return ""
+}
+
+func (x *Node) GetUrlDownload() string {
+	if x != nil {
+		return x.UrlDownload
+	}
+	return ""
+}
+
+func (x *Node) GetLicenses() []string {
+	if x != nil {
+		return x.Licenses
+	}
+	return nil
+}
+
+func (x *Node) GetLicenseConcluded() string {
+	if x != nil {
+		return x.LicenseConcluded
+	}
+	return ""
+}
+
+func (x *Node) GetLicenseComments() string {
+	if x != nil {
+		return x.LicenseComments
+	}
+	return ""
+}
+
+func (x *Node) GetCopyright() string {
+	if x != nil {
+		return x.Copyright
+	}
+	return ""
+}
+
+func (x *Node) GetSourceInfo() string {
+	if x != nil {
+		return x.SourceInfo
+	}
+	return ""
+}
+
+func (x *Node) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Node) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Node) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Node) GetAttribution() []string {
+	if x != nil {
+		return x.Attribution
+	}
+	return nil
+}
+
+func (x *Node) GetSuppliers() []*Person {
+	if x != nil {
+		return x.Suppliers
+	}
+	return nil
+}
+
+func (x *Node) GetOriginators() []*Person {
+	if x != nil {
+		return x.Originators
+	}
+	return nil
+}
+
+func (x *Node) GetReleaseDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleaseDate
+	}
+	return nil
+}
+
+func (x *Node) GetBuildDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BuildDate
+	}
+	return nil
+}
+
+func (x *Node) GetValidUntilDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ValidUntilDate
+	}
+	return nil
+}
+
+func (x *Node) GetExternalReferences() []*ExternalReference {
+	if x != nil {
+		return x.ExternalReferences
+	}
+	return nil
+}
+
+func (x *Node) GetFileTypes() []string {
+	if x != nil {
+		return x.FileTypes
+	}
+	return nil
+}
+
+func (x *Node) GetIdentifiers() map[int32]string {
+	if x != nil {
+		return x.Identifiers
+	}
+	return nil
+}
+
+func (x *Node) GetHashes() map[int32]string {
+	if x != nil {
+		return x.Hashes
+	}
+	return nil
+}
+
+func (x *Node) GetPrimaryPurpose() []Purpose {
+	if x != nil {
+		return x.PrimaryPurpose
+	}
+	return nil
+}
+
+func (x *Node) GetProperties() []*Property {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *Node) GetAiModel() *AIModel {
+	if x != nil {
+		return x.AiModel
+	}
+	return nil
+}
+
+func (x *Node) GetDataset() *Dataset {
+	if x != nil {
+		return x.Dataset
+	}
+	return nil
+}
+
+// NodeList represents a collection of nodes and edges forming the Software Bill of Materials (SBOM) graph.
+// It encapsulates the fundamental components of the SBOM, including software entities (nodes) and their relationships (edges).
+type NodeList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// List of software components (nodes) in the SBOM graph.
+	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// List of relationships (edges) between nodes in the SBOM graph.
+	Edges []*Edge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	// List of root elements in the SBOM graph.
+	RootElements []string `protobuf:"bytes,3,rep,name=root_elements,json=rootElements,proto3" json:"root_elements,omitempty"`
+}
+
+func (x *NodeList) Reset() {
+	*x = NodeList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeList) ProtoMessage() {}
+
+func (x *NodeList) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeList.ProtoReflect.Descriptor instead.
+func (*NodeList) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NodeList) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *NodeList) GetEdges() []*Edge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *NodeList) GetRootElements() []string {
+	if x != nil {
+		return x.RootElements
+	}
+	return nil
+}
+
+// NodeSelector matches nodes in a document. A node is selected when all the
+// non-empty fields in the selector match.
+type NodeSelector struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Identifier of the node.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Package URL of the node. A purl without version matches all versions.
+	Purl string `protobuf:"bytes,2,opt,name=purl,proto3" json:"purl,omitempty"`
+	// Name of the node.
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the node.
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *NodeSelector) Reset() {
+	*x = NodeSelector{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeSelector) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeSelector) ProtoMessage() {}
+
+func (x *NodeSelector) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeSelector.ProtoReflect.Descriptor instead.
+func (*NodeSelector) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *NodeSelector) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *NodeSelector) GetPurl() string {
+	if x != nil {
+		return x.Purl
+	}
+	return ""
+}
+
+func (x *NodeSelector) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Node) GetUrlDownload() string {
+func (x *NodeSelector) GetVersion() string {
 	if x != nil {
-		return x.UrlDownload
+		return x.Version
 	}
 	return ""
 }
 
-func (x *Node) GetLicenses() []string {
+// Overlay captures a set of manual curations (license fixes, supplier corrections,
+// false positive removals) that can be applied on top of a generated document.
+// Overlays are kept separate from the document so they can be applied again
+// each time the SBOM is regenerated.
+type Overlay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Corrections to apply to the nodes in the document.
+	Corrections []*Overlay_Correction `protobuf:"bytes,1,rep,name=corrections,proto3" json:"corrections,omitempty"`
+	// Selectors of the nodes to remove from the document.
+	Removals []*NodeSelector `protobuf:"bytes,2,rep,name=removals,proto3" json:"removals,omitempty"`
+	// Comment describing the overlay.
+	Comment string `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+}
+
+func (x *Overlay) Reset() {
+	*x = Overlay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Overlay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Overlay) ProtoMessage() {}
+
+func (x *Overlay) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Overlay.ProtoReflect.Descriptor instead.
+func (*Overlay) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Overlay) GetCorrections() []*Overlay_Correction {
 	if x != nil {
-		return x.Licenses
+		return x.Corrections
 	}
 	return nil
 }
 
-func (x *Node) GetLicenseConcluded() string {
+func (x *Overlay) GetRemovals() []*NodeSelector {
 	if x != nil {
-		return x.LicenseConcluded
+		return x.Removals
+	}
+	return nil
+}
+
+func (x *Overlay) GetComment() string {
+	if x != nil {
+		return x.Comment
 	}
 	return ""
 }
 
-func (x *Node) GetLicenseComments() string {
+// Person represents an individual or organization involved in the creation or maintenance
+// of the document or node.
+type Person struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the person or organization.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Indicates whether the entity is an organization (true) or an individual (false).
+	IsOrg bool `protobuf:"varint,2,opt,name=is_org,json=isOrg,proto3" json:"is_org,omitempty"`
+	// Email address of the person or organization.
+	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	// URL associated with the person or organization.
+	Url string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	// Phone number associated with the person or organization.
+	Phone string `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
+	// Contacts associated with the person or organization.
+	Contacts []*Person `protobuf:"bytes,6,rep,name=contacts,proto3" json:"contacts,omitempty"`
+}
+
+func (x *Person) Reset() {
+	*x = Person{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Person) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Person) ProtoMessage() {}
+
+func (x *Person) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Person.ProtoReflect.Descriptor instead.
+func (*Person) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Person) GetName() string {
 	if x != nil {
-		return x.LicenseComments
+		return x.Name
 	}
 	return ""
 }
 
-func (x *Node) GetCopyright() string {
+func (x *Person) GetIsOrg() bool {
 	if x != nil {
-		return x.Copyright
+		return x.IsOrg
+	}
+	return false
+}
+
+func (x *Person) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *Person) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Person) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *Person) GetContacts() []*Person {
+	if x != nil {
+		return x.Contacts
+	}
+	return nil
+}
+
+// Property type, key-value store of data.
+type Property struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Name of the property
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Property value
+	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Property) Reset() {
+	*x = Property{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Property) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Property) ProtoMessage() {}
+
+func (x *Property) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Property.ProtoReflect.Descriptor instead.
+func (*Property) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Property) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Property) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// Revision records a change in the version of a document.
+type Revision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Version of the document after the revision.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Date of the revision.
+	Date *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	// Reason explaining why the document was revised.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *Revision) Reset() {
+	*x = Revision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Revision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Revision) ProtoMessage() {}
+
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Revision) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Revision) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Revision) GetReason() string {
+	if x != nil {
+		return x.Reason
 	}
 	return ""
 }
 
-func (x *Node) GetSourceInfo() string {
-	if x != nil {
-		return x.SourceInfo
-	}
-	return ""
+// Signature records a signature of the original SBOM document and the result
+// of its verification when the document was read.
+type Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Format of the signature.
+	Format Signature_Format `protobuf:"varint,1,opt,name=format,proto3,enum=protobom.protobom.Signature_Format" json:"format,omitempty"`
+	// Signature algorithm, eg "ES256". Empty when the format does not state it.
+	Algorithm string `protobuf:"bytes,2,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Identifier of the key used to sign.
+	KeyId string `protobuf:"bytes,3,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// Raw signature value.
+	Data []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// Certificate chain of the signer as DER-encoded certificates, leaf first.
+	Certificates [][]byte `protobuf:"bytes,5,rep,name=certificates,proto3" json:"certificates,omitempty"`
+	// Identity of the signer taken from the leaf certificate: its first email
+	// or URI subject alternative name or its common name.
+	Signer string `protobuf:"bytes,6,opt,name=signer,proto3" json:"signer,omitempty"`
+	// Result of the signature verification.
+	Verification Signature_Verification `protobuf:"varint,7,opt,name=verification,proto3,enum=protobom.protobom.Signature_Verification" json:"verification,omitempty"`
+	// Error returned when the verification failed.
+	VerificationError string `protobuf:"bytes,8,opt,name=verification_error,json=verificationError,proto3" json:"verification_error,omitempty"`
+	// Payload type of the DSSE envelope.
+	PayloadType string `protobuf:"bytes,9,opt,name=payload_type,json=payloadType,proto3" json:"payload_type,omitempty"`
+}
+
+func (x *Signature) Reset() {
+	*x = Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Signature) ProtoMessage() {}
+
+func (x *Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Signature.ProtoReflect.Descriptor instead.
+func (*Signature) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Node) GetComment() string {
+func (x *Signature) GetFormat() Signature_Format {
 	if x != nil {
-		return x.Comment
+		return x.Format
 	}
-	return ""
+	return Signature_UNKNOWN_FORMAT
 }
 
-func (x *Node) GetSummary() string {
+func (x *Signature) GetAlgorithm() string {
 	if x != nil {
-		return x.Summary
+		return x.Algorithm
 	}
 	return ""
 }
 
-func (x *Node) GetDescription() string {
+func (x *Signature) GetKeyId() string {
 	if x != nil {
-		return x.Description
+		return x.KeyId
 	}
 	return ""
 }
 
-func (x *Node) GetAttribution() []string {
+func (x *Signature) GetData() []byte {
 	if x != nil {
-		return x.Attribution
+		return x.Data
 	}
 	return nil
 }
 
-func (x *Node) GetSuppliers() []*Person {
+func (x *Signature) GetCertificates() [][]byte {
 	if x != nil {
-		return x.Suppliers
+		return x.Certificates
 	}
 	return nil
 }
 
-func (x *Node) GetOriginators() []*Person {
+func (x *Signature) GetSigner() string {
 	if x != nil {
-		return x.Originators
+		return x.Signer
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetReleaseDate() *timestamppb.Timestamp {
+func (x *Signature) GetVerification() Signature_Verification {
 	if x != nil {
-		return x.ReleaseDate
+		return x.Verification
 	}
-	return nil
+	return Signature_UNVERIFIED
 }
 
-func (x *Node) GetBuildDate() *timestamppb.Timestamp {
+func (x *Signature) GetVerificationError() string {
 	if x != nil {
-		return x.BuildDate
+		return x.VerificationError
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetValidUntilDate() *timestamppb.Timestamp {
+func (x *Signature) GetPayloadType() string {
 	if x != nil {
-		return x.ValidUntilDate
+		return x.PayloadType
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetExternalReferences() []*ExternalReference {
-	if x != nil {
-		return x.ExternalReferences
+// SourceData message encapsulates additional metadata related to the original SBOM document.
+type SourceData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The original format string of the SBOM document (e.g., text/spdx+json;version=2.3).
+	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	// The hashes of the original SBOM document to ensure integrity.
+	Hashes map[int32]string `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The original size of the SBOM document in bytes.
+	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	// The original URI of the SBOM document.
+	Uri *string `protobuf:"bytes,4,opt,name=uri,proto3,oneof" json:"uri,omitempty"`
+}
+
+func (x *SourceData) Reset() {
+	*x = SourceData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sbom_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *Node) GetFileTypes() []string {
-	if x != nil {
-		return x.FileTypes
+func (x *SourceData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SourceData) ProtoMessage() {}
+
+func (x *SourceData) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Node) GetIdentifiers() map[int32]string {
+// Deprecated: Use SourceData.ProtoReflect.Descriptor instead.
+func (*SourceData) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SourceData) GetFormat() string {
 	if x != nil {
-		return x.Identifiers
+		return x.Format
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetHashes() map[int32]string {
+func (x *SourceData) GetHashes() map[int32]string {
 	if x != nil {
 		return x.Hashes
 	}
 	return nil
 }
 
-func (x *Node) GetPrimaryPurpose() []Purpose {
+func (x *SourceData) GetSize() int64 {
 	if x != nil {
-		return x.PrimaryPurpose
+		return x.Size
 	}
-	return nil
+	return 0
 }
 
-func (x *Node) GetProperties() []*Property {
-	if x != nil {
-		return x.Properties
+func (x *SourceData) GetUri() string {
+	if x != nil && x.Uri != nil {
+		return *x.Uri
 	}
-	return nil
+	return ""
 }
 
-// NodeList represents a collection of nodes and edges forming the Software Bill of Materials (SBOM) graph.
-// It encapsulates the fundamental components of the SBOM, including software entities (nodes) and their relationships (edges).
-type NodeList struct {
+// Tool represents a software tool used in the creation or processing of the Software Bill of Materials (SBOM) document.
+type Tool struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// List of software components (nodes) in the SBOM graph.
-	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	// List of relationships (edges) between nodes in the SBOM graph.
-	Edges []*Edge `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
-	// List of root elements in the SBOM graph.
-	RootElements []string `protobuf:"bytes,3,rep,name=root_elements,json=rootElements,proto3" json:"root_elements,omitempty"`
+	// Name of the software tool.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Version of the software tool.
+	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Vendor or creator of the software tool.
+	Vendor string `protobuf:"bytes,3,opt,name=vendor,proto3" json:"vendor,omitempty"`
 }
 
-func (x *NodeList) Reset() {
-	*x = NodeList{}
+func (x *Tool) Reset() {
+	*x = Tool{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[6]
+		mi := &file_sbom_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *NodeList) String() string {
+func (x *Tool) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NodeList) ProtoMessage() {}
+func (*Tool) ProtoMessage() {}
 
-func (x *NodeList) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[6]
+func (x *Tool) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1732,70 +3208,93 @@ func (x *NodeList) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NodeList.ProtoReflect.Descriptor instead.
-func (*NodeList) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Tool.ProtoReflect.Descriptor instead.
+func (*Tool) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *NodeList) GetNodes() []*Node {
+func (x *Tool) GetName() string {
 	if x != nil {
-		return x.Nodes
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *NodeList) GetEdges() []*Edge {
+func (x *Tool) GetVersion() string {
 	if x != nil {
-		return x.Edges
+		return x.Version
 	}
-	return nil
+	return ""
 }
 
-func (x *NodeList) GetRootElements() []string {
+func (x *Tool) GetVendor() string {
 	if x != nil {
-		return x.RootElements
+		return x.Vendor
 	}
-	return nil
+	return ""
 }
 
-// Person represents an individual or organization involved in the creation or maintenance
-// of the document or node.
-type Person struct {
+// Vulnerability describes a known vulnerability and the nodes it affects. It
+// maps to the CycloneDX vulnerabilities and the SPDX security external
+// references.
+type Vulnerability struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Name of the person or organization.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Indicates whether the entity is an organization (true) or an individual (false).
-	IsOrg bool `protobuf:"varint,2,opt,name=is_org,json=isOrg,proto3" json:"is_org,omitempty"`
-	// Email address of the person or organization.
-	Email string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	// URL associated with the person or organization.
-	Url string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
-	// Phone number associated with the person or organization.
-	Phone string `protobuf:"bytes,5,opt,name=phone,proto3" json:"phone,omitempty"`
-	// Contacts associated with the person or organization.
-	Contacts []*Person `protobuf:"bytes,6,rep,name=contacts,proto3" json:"contacts,omitempty"`
-}
-
-func (x *Person) Reset() {
-	*x = Person{}
+	// Identifier of the vulnerability, eg "CVE-2024-1234".
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Name of the database publishing the vulnerability, eg "NVD".
+	SourceName string `protobuf:"bytes,2,opt,name=source_name,json=sourceName,proto3" json:"source_name,omitempty"`
+	// URL of the vulnerability in the database.
+	SourceUrl string `protobuf:"bytes,3,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	// Severity ratings of the vulnerability.
+	Ratings []*Vulnerability_Rating `protobuf:"bytes,4,rep,name=ratings,proto3" json:"ratings,omitempty"`
+	// Description of the vulnerability.
+	Description string `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	// Detailed description of the vulnerability.
+	Detail string `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+	// Recommended remediation.
+	Recommendation string `protobuf:"bytes,7,opt,name=recommendation,proto3" json:"recommendation,omitempty"`
+	// URLs of the advisories about the vulnerability.
+	Advisories []string `protobuf:"bytes,8,rep,name=advisories,proto3" json:"advisories,omitempty"`
+	// Date the vulnerability was published.
+	Published *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=published,proto3" json:"published,omitempty"`
+	// Date the vulnerability was last updated.
+	Updated *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated,proto3" json:"updated,omitempty"`
+	// Identifiers of the nodes affected by the vulnerability.
+	Affects []string `protobuf:"bytes,11,rep,name=affects,proto3" json:"affects,omitempty"`
+	// Impact analysis of the vulnerability.
+	Analysis *Vulnerability_Analysis `protobuf:"bytes,12,opt,name=analysis,proto3" json:"analysis,omitempty"`
+	// Identifiers of the same vulnerability in other databases, eg "GHSA-xxxx-xxxx-xxxx".
+	Aliases []string `protobuf:"bytes,13,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// Impact analyses of specific nodes keyed by node identifier. They take
+	// precedence over the analysis of the vulnerability.
+	NodeAnalyses map[string]*Vulnerability_Analysis `protobuf:"bytes,14,rep,name=node_analyses,json=nodeAnalyses,proto3" json:"node_analyses,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Canonical severity normalized from the ratings, which keep the severities
+	// as expressed by their sources.
+	Severity Vulnerability_Severity `protobuf:"varint,15,opt,name=severity,proto3,enum=protobom.protobom.Vulnerability_Severity" json:"severity,omitempty"`
+	// Weaknesses of the vulnerability as CWE identifiers, eg 79 for CWE-79.
+	Cwes []int32 `protobuf:"varint,16,rep,packed,name=cwes,proto3" json:"cwes,omitempty"`
+}
+
+func (x *Vulnerability) Reset() {
+	*x = Vulnerability{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[7]
+		mi := &file_sbom_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Person) String() string {
+func (x *Vulnerability) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Person) ProtoMessage() {}
+func (*Vulnerability) ProtoMessage() {}
 
-func (x *Person) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[7]
+func (x *Vulnerability) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1806,82 +3305,152 @@ func (x *Person) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Person.ProtoReflect.Descriptor instead.
-func (*Person) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Vulnerability.ProtoReflect.Descriptor instead.
+func (*Vulnerability) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *Person) GetName() string {
+func (x *Vulnerability) GetId() string {
 	if x != nil {
-		return x.Name
+		return x.Id
 	}
 	return ""
 }
 
-func (x *Person) GetIsOrg() bool {
+func (x *Vulnerability) GetSourceName() string {
 	if x != nil {
-		return x.IsOrg
+		return x.SourceName
 	}
-	return false
+	return ""
 }
 
-func (x *Person) GetEmail() string {
+func (x *Vulnerability) GetSourceUrl() string {
 	if x != nil {
-		return x.Email
+		return x.SourceUrl
 	}
 	return ""
 }
 
-func (x *Person) GetUrl() string {
+func (x *Vulnerability) GetRatings() []*Vulnerability_Rating {
 	if x != nil {
-		return x.Url
+		return x.Ratings
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetDescription() string {
+	if x != nil {
+		return x.Description
 	}
 	return ""
 }
 
-func (x *Person) GetPhone() string {
+func (x *Vulnerability) GetDetail() string {
 	if x != nil {
-		return x.Phone
+		return x.Detail
 	}
 	return ""
 }
 
-func (x *Person) GetContacts() []*Person {
+func (x *Vulnerability) GetRecommendation() string {
 	if x != nil {
-		return x.Contacts
+		return x.Recommendation
+	}
+	return ""
+}
+
+func (x *Vulnerability) GetAdvisories() []string {
+	if x != nil {
+		return x.Advisories
 	}
 	return nil
 }
 
-// Property type, key-value store of data.
-type Property struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *Vulnerability) GetPublished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Published
+	}
+	return nil
+}
 
-	// Name of the property
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Property value
-	Data string `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+func (x *Vulnerability) GetUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Updated
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetAffects() []string {
+	if x != nil {
+		return x.Affects
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetAnalysis() *Vulnerability_Analysis {
+	if x != nil {
+		return x.Analysis
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetNodeAnalyses() map[string]*Vulnerability_Analysis {
+	if x != nil {
+		return x.NodeAnalyses
+	}
+	return nil
+}
+
+func (x *Vulnerability) GetSeverity() Vulnerability_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return Vulnerability_UNKNOWN_SEVERITY
+}
+
+func (x *Vulnerability) GetCwes() []int32 {
+	if x != nil {
+		return x.Cwes
+	}
+	return nil
+}
+
+// Correction replaces data in the nodes matched by its selector.
+type Overlay_Correction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Selector of the nodes to correct.
+	Selector *NodeSelector `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// Node carrying the corrected data. Only its non-empty fields are applied.
+	Patch *Node `protobuf:"bytes,2,opt,name=patch,proto3" json:"patch,omitempty"`
 }
 
-func (x *Property) Reset() {
-	*x = Property{}
+func (x *Overlay_Correction) Reset() {
+	*x = Overlay_Correction{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[8]
+		mi := &file_sbom_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Property) String() string {
+func (x *Overlay_Correction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Property) ProtoMessage() {}
+func (*Overlay_Correction) ProtoMessage() {}
 
-func (x *Property) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[8]
+func (x *Overlay_Correction) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1892,58 +3461,62 @@ func (x *Property) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Property.ProtoReflect.Descriptor instead.
-func (*Property) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Overlay_Correction.ProtoReflect.Descriptor instead.
+func (*Overlay_Correction) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{11, 0}
 }
 
-func (x *Property) GetName() string {
+func (x *Overlay_Correction) GetSelector() *NodeSelector {
 	if x != nil {
-		return x.Name
+		return x.Selector
 	}
-	return ""
+	return nil
 }
 
-func (x *Property) GetData() string {
+func (x *Overlay_Correction) GetPatch() *Node {
 	if x != nil {
-		return x.Data
+		return x.Patch
 	}
-	return ""
+	return nil
 }
 
-// SourceData message encapsulates additional metadata related to the original SBOM document.
-type SourceData struct {
+// Analysis records the assessment of the impact of the vulnerability.
+type Vulnerability_Analysis struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The original format string of the SBOM document (e.g., text/spdx+json;version=2.3).
-	Format string `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
-	// The hashes of the original SBOM document to ensure integrity.
-	Hashes map[int32]string `protobuf:"bytes,2,rep,name=hashes,proto3" json:"hashes,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// The original size of the SBOM document in bytes.
-	Size int64 `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
-	// The original URI of the SBOM document.
-	Uri *string `protobuf:"bytes,4,opt,name=uri,proto3,oneof" json:"uri,omitempty"`
-}
-
-func (x *SourceData) Reset() {
-	*x = SourceData{}
+	// State of the analysis.
+	State Vulnerability_Analysis_State `protobuf:"varint,1,opt,name=state,proto3,enum=protobom.protobom.Vulnerability_Analysis_State" json:"state,omitempty"`
+	// Details of the analysis.
+	Detail string `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+	// Justification of a NOT_AFFECTED state.
+	Justification Vulnerability_Analysis_Justification `protobuf:"varint,3,opt,name=justification,proto3,enum=protobom.protobom.Vulnerability_Analysis_Justification" json:"justification,omitempty"`
+	// Responses to the vulnerability.
+	Responses []Vulnerability_Analysis_Response `protobuf:"varint,4,rep,packed,name=responses,proto3,enum=protobom.protobom.Vulnerability_Analysis_Response" json:"responses,omitempty"`
+	// Date the analysis was first issued.
+	FirstIssued *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_issued,json=firstIssued,proto3" json:"first_issued,omitempty"`
+	// Date the analysis was last updated.
+	LastUpdated *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+}
+
+func (x *Vulnerability_Analysis) Reset() {
+	*x = Vulnerability_Analysis{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[9]
+		mi := &file_sbom_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SourceData) String() string {
+func (x *Vulnerability_Analysis) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SourceData) ProtoMessage() {}
+func (*Vulnerability_Analysis) ProtoMessage() {}
 
-func (x *SourceData) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[9]
+func (x *Vulnerability_Analysis) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1954,70 +3527,88 @@ func (x *SourceData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SourceData.ProtoReflect.Descriptor instead.
-func (*SourceData) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use Vulnerability_Analysis.ProtoReflect.Descriptor instead.
+func (*Vulnerability_Analysis) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18, 0}
 }
 
-func (x *SourceData) GetFormat() string {
+func (x *Vulnerability_Analysis) GetState() Vulnerability_Analysis_State {
 	if x != nil {
-		return x.Format
+		return x.State
+	}
+	return Vulnerability_Analysis_UNKNOWN_STATE
+}
+
+func (x *Vulnerability_Analysis) GetDetail() string {
+	if x != nil {
+		return x.Detail
 	}
 	return ""
 }
 
-func (x *SourceData) GetHashes() map[int32]string {
+func (x *Vulnerability_Analysis) GetJustification() Vulnerability_Analysis_Justification {
 	if x != nil {
-		return x.Hashes
+		return x.Justification
+	}
+	return Vulnerability_Analysis_UNKNOWN_JUSTIFICATION
+}
+
+func (x *Vulnerability_Analysis) GetResponses() []Vulnerability_Analysis_Response {
+	if x != nil {
+		return x.Responses
 	}
 	return nil
 }
 
-func (x *SourceData) GetSize() int64 {
+func (x *Vulnerability_Analysis) GetFirstIssued() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Size
+		return x.FirstIssued
 	}
-	return 0
+	return nil
 }
 
-func (x *SourceData) GetUri() string {
-	if x != nil && x.Uri != nil {
-		return *x.Uri
+func (x *Vulnerability_Analysis) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
 	}
-	return ""
+	return nil
 }
 
-// Tool represents a software tool used in the creation or processing of the Software Bill of Materials (SBOM) document.
-type Tool struct {
+// Rating is a score of the severity of the vulnerability.
+type Vulnerability_Rating struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// Name of the software tool.
-	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	// Version of the software tool.
-	Version string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
-	// Vendor or creator of the software tool.
-	Vendor string `protobuf:"bytes,3,opt,name=vendor,proto3" json:"vendor,omitempty"`
+	// Name of the source of the rating, eg "NVD".
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	// Numeric score, eg the CVSS base score.
+	Score *float64 `protobuf:"fixed64,2,opt,name=score,proto3,oneof" json:"score,omitempty"`
+	// Textual severity as expressed by the source, eg "high".
+	Severity string `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"`
+	// Scoring method, eg "CVSSv31".
+	Method string `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	// Vector string of the scoring method.
+	Vector string `protobuf:"bytes,5,opt,name=vector,proto3" json:"vector,omitempty"`
 }
 
-func (x *Tool) Reset() {
-	*x = Tool{}
+func (x *Vulnerability_Rating) Reset() {
+	*x = Vulnerability_Rating{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_sbom_proto_msgTypes[10]
+		mi := &file_sbom_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Tool) String() string {
+func (x *Vulnerability_Rating) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tool) ProtoMessage() {}
+func (*Vulnerability_Rating) ProtoMessage() {}
 
-func (x *Tool) ProtoReflect() protoreflect.Message {
-	mi := &file_sbom_proto_msgTypes[10]
+func (x *Vulnerability_Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_sbom_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2028,28 +3619,42 @@ func (x *Tool) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tool.ProtoReflect.Descriptor instead.
-func (*Tool) Descriptor() ([]byte, []int) {
-	return file_sbom_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use Vulnerability_Rating.ProtoReflect.Descriptor instead.
+func (*Vulnerability_Rating) Descriptor() ([]byte, []int) {
+	return file_sbom_proto_rawDescGZIP(), []int{18, 1}
 }
 
-func (x *Tool) GetName() string {
+func (x *Vulnerability_Rating) GetSource() string {
 	if x != nil {
-		return x.Name
+		return x.Source
 	}
 	return ""
 }
 
-func (x *Tool) GetVersion() string {
+func (x *Vulnerability_Rating) GetScore() float64 {
+	if x != nil && x.Score != nil {
+		return *x.Score
+	}
+	return 0
+}
+
+func (x *Vulnerability_Rating) GetSeverity() string {
 	if x != nil {
-		return x.Version
+		return x.Severity
 	}
 	return ""
 }
 
-func (x *Tool) GetVendor() string {
+func (x *Vulnerability_Rating) GetMethod() string {
 	if x != nil {
-		return x.Vendor
+		return x.Method
+	}
+	return ""
+}
+
+func (x *Vulnerability_Rating) GetVector() string {
+	if x != nil {
+		return x.Vector
 	}
 	return ""
 }
@@ -2061,398 +3666,702 @@ var file_sbom_proto_rawDesc = []byte{
 	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x1a,
 	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
 	0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x7d, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a, 0x08,
-	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b,
+	0x22, 0xf0, 0x05, 0x0a, 0x07, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x22, 0x0a, 0x0d,
+	0x74, 0x79, 0x70, 0x65, 0x5f, 0x6f, 0x66, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x0b, 0x74, 0x79, 0x70, 0x65, 0x4f, 0x66, 0x4d, 0x6f, 0x64, 0x65, 0x6c,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x61, 0x73, 0x6b, 0x12, 0x2d, 0x0a, 0x12, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x5f, 0x61, 0x72,
+	0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x11, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x41, 0x72, 0x63, 0x68, 0x69, 0x74, 0x65, 0x63, 0x74,
+	0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x42, 0x0a, 0x1d, 0x69,
+	0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x62, 0x6f, 0x75, 0x74,
+	0x5f, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x1b, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x41,
+	0x62, 0x6f, 0x75, 0x74, 0x41, 0x70, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x3c, 0x0a, 0x1a, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61,
+	0x62, 0x6f, 0x75, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x18, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x41, 0x62, 0x6f, 0x75, 0x74, 0x54, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x1e, 0x0a,
+	0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x41, 0x0a,
+	0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65, 0x74,
-	0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6c, 0x69,
-	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64,
-	0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x22,
-	0xb7, 0x02, 0x0a, 0x0c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x41, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2e,
-	0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x88, 0x01, 0x01, 0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x48, 0x02, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x88, 0x01, 0x01, 0x22, 0x81, 0x01, 0x0a, 0x08, 0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44,
-	0x45, 0x53, 0x49, 0x47, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x55, 0x52, 0x43,
-	0x45, 0x10, 0x02, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x0c,
-	0x0a, 0x08, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x5a, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08,
-	0x44, 0x45, 0x50, 0x4c, 0x4f, 0x59, 0x45, 0x44, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55,
-	0x4e, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x49, 0x53, 0x43, 0x4f,
-	0x56, 0x45, 0x52, 0x59, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x43, 0x4f, 0x4d, 0x49,
-	0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x08, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x74, 0x79, 0x70, 0x65,
-	0x42, 0x07, 0x0a, 0x05, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe1, 0x06, 0x0a, 0x04, 0x45, 0x64,
-	0x67, 0x65, 0x12, 0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x1c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x82, 0x06, 0x0a, 0x04, 0x54, 0x79, 0x70,
-	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a,
-	0x0a, 0x06, 0x61, 0x6d, 0x65, 0x6e, 0x64, 0x73, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x61, 0x6e,
-	0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x62, 0x75, 0x69, 0x6c,
-	0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x03, 0x12, 0x0d, 0x0a,
-	0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x63, 0x6f,
-	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04,
-	0x63, 0x6f, 0x70, 0x79, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69,
-	0x6c, 0x65, 0x10, 0x08, 0x12, 0x16, 0x0a, 0x12, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e,
-	0x63, 0x79, 0x4d, 0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09,
-	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c, 0x64,
-	0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4f, 0x66, 0x10, 0x0b, 0x12, 0x0e, 0x0a,
-	0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x10, 0x0c, 0x12, 0x0d, 0x0a,
-	0x09, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x73, 0x10, 0x0d, 0x12, 0x0f, 0x0a, 0x0b,
-	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x42, 0x79, 0x10, 0x0e, 0x12, 0x11, 0x0a,
-	0x0d, 0x64, 0x65, 0x76, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x0f,
-	0x12, 0x0b, 0x0a, 0x07, 0x64, 0x65, 0x76, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x10, 0x12, 0x18, 0x0a,
-	0x14, 0x64, 0x69, 0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x10, 0x11, 0x12, 0x11, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x12, 0x12, 0x0f, 0x0a, 0x0b, 0x64, 0x79,
-	0x6e, 0x61, 0x6d, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x65,
-	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x10, 0x14, 0x12, 0x17, 0x0a, 0x13, 0x65, 0x78, 0x70, 0x61,
-	0x6e, 0x64, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x10,
-	0x15, 0x12, 0x0d, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x65, 0x64, 0x10, 0x16,
-	0x12, 0x0f, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10,
-	0x17, 0x12, 0x10, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65,
-	0x64, 0x10, 0x18, 0x12, 0x0d, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73,
-	0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x46,
-	0x72, 0x6f, 0x6d, 0x10, 0x1a, 0x12, 0x0c, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x66, 0x69, 0x6c,
-	0x65, 0x10, 0x1b, 0x12, 0x15, 0x0a, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x10, 0x1c, 0x12, 0x16, 0x0a, 0x12, 0x6f, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79,
-	0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x10, 0x1e, 0x12, 0x0c, 0x0a,
-	0x08, 0x70, 0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x10, 0x1f, 0x12, 0x09, 0x0a, 0x05, 0x70,
-	0x61, 0x74, 0x63, 0x68, 0x10, 0x20, 0x12, 0x10, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71,
-	0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x10, 0x21, 0x12, 0x13, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x72,
-	0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x10, 0x22, 0x12, 0x16, 0x0a,
-	0x12, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65,
-	0x6e, 0x63, 0x79, 0x10, 0x23, 0x12, 0x12, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x10, 0x24, 0x12, 0x15, 0x0a, 0x11, 0x72, 0x75, 0x6e,
-	0x74, 0x69, 0x6d, 0x65, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x25,
-	0x12, 0x14, 0x0a, 0x10, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x46, 0x6f, 0x72, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63,
-	0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x27, 0x12, 0x08, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x10, 0x28,
-	0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x10, 0x29, 0x12, 0x12,
-	0x0a, 0x0e, 0x74, 0x65, 0x73, 0x74, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79,
-	0x10, 0x2a, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x2b,
-	0x12, 0x0b, 0x0a, 0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x10, 0x2c, 0x22, 0x97, 0x0c,
-	0x0a, 0x11, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x48, 0x0a,
-	0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e,
+	0x6f, 0x6d, 0x2e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x12, 0x59, 0x0a, 0x0f, 0x68, 0x79, 0x70, 0x65, 0x72, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x41, 0x49,
+	0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x48, 0x79, 0x70, 0x65, 0x72, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x65, 0x74, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f, 0x68, 0x79, 0x70, 0x65,
+	0x72, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x61,
+	0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x61, 0x75, 0x74, 0x6f, 0x6e, 0x6f, 0x6d, 0x79, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x34, 0x0a, 0x16, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x5f, 0x72, 0x69, 0x73, 0x6b, 0x5f,
+	0x61, 0x73, 0x73, 0x65, 0x73, 0x73, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x14, 0x73, 0x61, 0x66, 0x65, 0x74, 0x79, 0x52, 0x69, 0x73, 0x6b, 0x41, 0x73, 0x73, 0x65,
+	0x73, 0x73, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x4b, 0x0a, 0x22, 0x75, 0x73, 0x65, 0x5f, 0x73, 0x65,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x1f, 0x75, 0x73, 0x65, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65,
+	0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x1a, 0x3a, 0x0a, 0x0c, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x42, 0x0a, 0x14, 0x48, 0x79, 0x70, 0x65, 0x72, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xba, 0x03, 0x0a, 0x07, 0x44, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x12,
+	0x21, 0x0a, 0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x5f, 0x75,
+	0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x64,
+	0x65, 0x64, 0x55, 0x73, 0x65, 0x12, 0x36, 0x0a, 0x17, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x63, 0x6f,
+	0x6c, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x64, 0x61, 0x74, 0x61, 0x43, 0x6f, 0x6c, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0b, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x53, 0x69, 0x7a, 0x65,
+	0x12, 0x33, 0x0a, 0x15, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c,
+	0x69, 0x74, 0x79, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x14, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x74, 0x79,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x4b, 0x0a, 0x22, 0x68, 0x61, 0x73, 0x5f, 0x73, 0x65, 0x6e,
+	0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x1f, 0x68, 0x61, 0x73, 0x53, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x50,
+	0x65, 0x72, 0x73, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x62, 0x69, 0x61, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x42, 0x69, 0x61,
+	0x73, 0x12, 0x31, 0x0a, 0x14, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x5f, 0x61, 0x76, 0x61,
+	0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x13, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x3a, 0x0a, 0x19, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x5f, 0x75, 0x73, 0x65,
+	0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x17, 0x61, 0x6e, 0x6f, 0x6e, 0x79, 0x6d, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x55, 0x73, 0x65, 0x64,
+	0x22, 0xc9, 0x01, 0x0a, 0x08, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x37, 0x0a,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08, 0x6d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x38, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6c,
+	0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x4a, 0x0a, 0x0f, 0x76, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75,
+	0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x52, 0x0f, 0x76, 0x75, 0x6c,
+	0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0xb7, 0x02, 0x0a,
+	0x0c, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x41, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
+	0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x2e, 0x53, 0x42, 0x4f,
+	0x4d, 0x54, 0x79, 0x70, 0x65, 0x48, 0x00, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x88, 0x01, 0x01,
+	0x12, 0x17, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x88, 0x01, 0x01, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x02,
+	0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01,
+	0x22, 0x81, 0x01, 0x0a, 0x08, 0x53, 0x42, 0x4f, 0x4d, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
+	0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x53, 0x49,
+	0x47, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x10, 0x02,
+	0x12, 0x09, 0x0a, 0x05, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08, 0x41,
+	0x4e, 0x41, 0x4c, 0x59, 0x5a, 0x45, 0x44, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x50,
+	0x4c, 0x4f, 0x59, 0x45, 0x44, 0x10, 0x05, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x55, 0x4e, 0x54, 0x49,
+	0x4d, 0x45, 0x10, 0x06, 0x12, 0x0d, 0x0a, 0x09, 0x44, 0x49, 0x53, 0x43, 0x4f, 0x56, 0x45, 0x52,
+	0x59, 0x10, 0x07, 0x12, 0x0f, 0x0a, 0x0b, 0x44, 0x45, 0x43, 0x4f, 0x4d, 0x49, 0x53, 0x53, 0x49,
+	0x4f, 0x4e, 0x10, 0x08, 0x42, 0x07, 0x0a, 0x05, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x42, 0x07, 0x0a,
+	0x05, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xfe, 0x06, 0x0a, 0x04, 0x45, 0x64, 0x67, 0x65, 0x12,
+	0x30, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
-	0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x4e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x65,
-	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70,
-	0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68, 0x65,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x22, 0xd6, 0x09, 0x0a, 0x15, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52,
-	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
-	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x54, 0x54,
-	0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49,
-	0x4e, 0x41, 0x52, 0x59, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f, 0x4d, 0x10, 0x03, 0x12,
-	0x09, 0x0a, 0x05, 0x42, 0x4f, 0x57, 0x45, 0x52, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x55,
-	0x49, 0x4c, 0x44, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x55,
-	0x49, 0x4c, 0x44, 0x5f, 0x53, 0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14,
-	0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45,
-	0x50, 0x4f, 0x52, 0x54, 0x10, 0x07, 0x12, 0x08, 0x0a, 0x04, 0x43, 0x48, 0x41, 0x54, 0x10, 0x08,
-	0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f, 0x44, 0x49, 0x46, 0x49, 0x45, 0x44, 0x5f, 0x49, 0x4e, 0x46,
-	0x52, 0x41, 0x53, 0x54, 0x52, 0x55, 0x43, 0x54, 0x55, 0x52, 0x45, 0x10, 0x09, 0x12, 0x1d, 0x0a,
-	0x19, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59,
-	0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x0a, 0x12, 0x11, 0x0a, 0x0d,
-	0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0b, 0x12,
-	0x17, 0x0a, 0x13, 0x44, 0x49, 0x53, 0x54, 0x52, 0x49, 0x42, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
-	0x49, 0x4e, 0x54, 0x41, 0x4b, 0x45, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x4f, 0x43, 0x55,
-	0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0d, 0x12, 0x0c, 0x0a, 0x08, 0x44,
-	0x4f, 0x57, 0x4e, 0x4c, 0x4f, 0x41, 0x44, 0x10, 0x0e, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x59, 0x4e,
-	0x41, 0x4d, 0x49, 0x43, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45,
-	0x50, 0x4f, 0x52, 0x54, 0x10, 0x0f, 0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x4f, 0x4c, 0x5f, 0x4e, 0x4f,
-	0x54, 0x49, 0x43, 0x45, 0x10, 0x10, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45, 0x4e,
-	0x43, 0x45, 0x10, 0x11, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x43,
-	0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e,
-	0x54, 0x10, 0x12, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x4f, 0x52, 0x4d, 0x55, 0x4c, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x46, 0x55, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10,
-	0x14, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x4b,
-	0x45, 0x52, 0x10, 0x15, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45, 0x10,
-	0x16, 0x12, 0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x47, 0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x41,
-	0x49, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4c, 0x49, 0x53, 0x54, 0x10, 0x18, 0x12, 0x13, 0x0a, 0x0f,
-	0x4d, 0x41, 0x54, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10,
-	0x19, 0x12, 0x11, 0x0a, 0x0d, 0x4d, 0x41, 0x56, 0x45, 0x4e, 0x5f, 0x43, 0x45, 0x4e, 0x54, 0x52,
-	0x41, 0x4c, 0x10, 0x1a, 0x12, 0x0b, 0x0a, 0x07, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x53, 0x10,
-	0x1b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x10,
-	0x1c, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x50, 0x4d, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x55,
-	0x47, 0x45, 0x54, 0x10, 0x1e, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x1f,
-	0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f, 0x41, 0x4d, 0x10, 0x20, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52,
-	0x49, 0x56, 0x41, 0x43, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54,
-	0x10, 0x21, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x5f, 0x4d, 0x45,
-	0x54, 0x41, 0x44, 0x41, 0x54, 0x41, 0x10, 0x22, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x55, 0x52, 0x43,
-	0x48, 0x41, 0x53, 0x45, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x10, 0x23, 0x12, 0x1d, 0x0a, 0x19,
-	0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45,
-	0x4e, 0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x24, 0x12, 0x13, 0x0a, 0x0f, 0x51,
-	0x55, 0x41, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x53, 0x10, 0x25,
-	0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x48, 0x49, 0x53, 0x54,
-	0x4f, 0x52, 0x59, 0x10, 0x26, 0x12, 0x11, 0x0a, 0x0d, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45,
-	0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x53, 0x10, 0x27, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x49, 0x53, 0x4b,
-	0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x28, 0x12, 0x1b, 0x0a,
-	0x17, 0x52, 0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49,
-	0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x29, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45,
-	0x43, 0x55, 0x52, 0x45, 0x5f, 0x53, 0x4f, 0x46, 0x54, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x41, 0x54,
-	0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x2a, 0x12, 0x1c, 0x0a, 0x18, 0x53,
-	0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x56, 0x45, 0x52, 0x53, 0x41, 0x52,
-	0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x2b, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x43,
-	0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x44, 0x56, 0x49, 0x53, 0x4f, 0x52, 0x59, 0x10, 0x2c,
-	0x12, 0x14, 0x0a, 0x10, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x4f, 0x4e,
-	0x54, 0x41, 0x43, 0x54, 0x10, 0x2d, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49,
-	0x54, 0x59, 0x5f, 0x46, 0x49, 0x58, 0x10, 0x2e, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x43, 0x55,
-	0x52, 0x49, 0x54, 0x59, 0x5f, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x2f, 0x12, 0x1b, 0x0a, 0x17,
-	0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x45, 0x4e, 0x54, 0x45, 0x53, 0x54,
-	0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x30, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x43,
-	0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x10, 0x31, 0x12, 0x11,
-	0x0a, 0x0d, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x57, 0x49, 0x44, 0x10,
-	0x32, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x48,
-	0x52, 0x45, 0x41, 0x54, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x33, 0x12, 0x0a, 0x0a, 0x06,
-	0x53, 0x4f, 0x43, 0x49, 0x41, 0x4c, 0x10, 0x34, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52,
-	0x43, 0x45, 0x5f, 0x41, 0x52, 0x54, 0x49, 0x46, 0x41, 0x43, 0x54, 0x10, 0x35, 0x12, 0x1a, 0x0a,
-	0x16, 0x53, 0x54, 0x41, 0x54, 0x49, 0x43, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53,
-	0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x36, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x50,
-	0x50, 0x4f, 0x52, 0x54, 0x10, 0x37, 0x12, 0x07, 0x0a, 0x03, 0x56, 0x43, 0x53, 0x10, 0x38, 0x12,
-	0x1b, 0x0a, 0x17, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59,
-	0x5f, 0x41, 0x53, 0x53, 0x45, 0x52, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x39, 0x12, 0x23, 0x0a, 0x1f,
-	0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x44, 0x49,
-	0x53, 0x43, 0x4c, 0x4f, 0x53, 0x55, 0x52, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10,
-	0x3a, 0x12, 0x2b, 0x0a, 0x27, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49,
-	0x54, 0x59, 0x5f, 0x45, 0x58, 0x50, 0x4c, 0x4f, 0x49, 0x54, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54,
-	0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x3b, 0x12, 0x0b,
-	0x0a, 0x07, 0x57, 0x45, 0x42, 0x53, 0x49, 0x54, 0x45, 0x10, 0x3c, 0x4a, 0x04, 0x08, 0x02, 0x10,
-	0x03, 0x4a, 0x04, 0x08, 0x05, 0x10, 0x06, 0x22, 0xfd, 0x02, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61,
-	0x64, 0x61, 0x74, 0x61, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61,
-	0x74, 0x65, 0x12, 0x2d, 0x0a, 0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x74, 0x6f, 0x6f, 0x6c,
-	0x73, 0x12, 0x33, 0x0a, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x07, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
-	0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74,
-	0x12, 0x45, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65,
-	0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63, 0x75,
-	0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63,
-	0x65, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70,
+	0x6d, 0x2e, 0x45, 0x64, 0x67, 0x65, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x9f, 0x06, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b,
+	0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x61,
+	0x6d, 0x65, 0x6e, 0x64, 0x73, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x65,
+	0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x62, 0x75,
+	0x69, 0x6c, 0x64, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x04, 0x12, 0x0c, 0x0a, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x73, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x10, 0x06, 0x12, 0x08, 0x0a, 0x04, 0x63, 0x6f, 0x70,
+	0x79, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x64, 0x61, 0x74, 0x61, 0x46, 0x69, 0x6c, 0x65, 0x10,
+	0x08, 0x12, 0x16, 0x0a, 0x12, 0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4d,
+	0x61, 0x6e, 0x69, 0x66, 0x65, 0x73, 0x74, 0x10, 0x09, 0x12, 0x0d, 0x0a, 0x09, 0x64, 0x65, 0x70,
+	0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x10, 0x0a, 0x12, 0x10, 0x0a, 0x0c, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x4f, 0x66, 0x10, 0x0b, 0x12, 0x0e, 0x0a, 0x0a, 0x64, 0x65,
+	0x73, 0x63, 0x65, 0x6e, 0x64, 0x61, 0x6e, 0x74, 0x10, 0x0c, 0x12, 0x0d, 0x0a, 0x09, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x73, 0x10, 0x0d, 0x12, 0x0f, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x64, 0x42, 0x79, 0x10, 0x0e, 0x12, 0x11, 0x0a, 0x0d, 0x64, 0x65,
+	0x76, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x0f, 0x12, 0x0b, 0x0a,
+	0x07, 0x64, 0x65, 0x76, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x10, 0x12, 0x18, 0x0a, 0x14, 0x64, 0x69,
+	0x73, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x10, 0x11, 0x12, 0x11, 0x0a, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x12, 0x12, 0x0f, 0x0a, 0x0b, 0x64, 0x79, 0x6e, 0x61, 0x6d,
+	0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x10, 0x13, 0x12, 0x0b, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x10, 0x14, 0x12, 0x17, 0x0a, 0x13, 0x65, 0x78, 0x70, 0x61, 0x6e, 0x64, 0x65,
+	0x64, 0x46, 0x72, 0x6f, 0x6d, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x10, 0x15, 0x12, 0x0d,
+	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x41, 0x64, 0x64, 0x65, 0x64, 0x10, 0x16, 0x12, 0x0f, 0x0a,
+	0x0b, 0x66, 0x69, 0x6c, 0x65, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x10, 0x17, 0x12, 0x10,
+	0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x18,
+	0x12, 0x0d, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x73, 0x10, 0x19, 0x12,
+	0x11, 0x0a, 0x0d, 0x67, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d,
+	0x10, 0x1a, 0x12, 0x0c, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x66, 0x69, 0x6c, 0x65, 0x10, 0x1b,
+	0x12, 0x15, 0x0a, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x10, 0x1c, 0x12, 0x16, 0x0a, 0x12, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x1d, 0x12,
+	0x09, 0x0a, 0x05, 0x6f, 0x74, 0x68, 0x65, 0x72, 0x10, 0x1e, 0x12, 0x0c, 0x0a, 0x08, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x73, 0x10, 0x1f, 0x12, 0x09, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63,
+	0x68, 0x10, 0x20, 0x12, 0x10, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x10, 0x21, 0x12, 0x13, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x73, 0x69, 0x74, 0x65, 0x46, 0x6f, 0x72, 0x10, 0x22, 0x12, 0x16, 0x0a, 0x12, 0x70, 0x72,
+	0x6f, 0x76, 0x69, 0x64, 0x65, 0x64, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79,
+	0x10, 0x23, 0x12, 0x12, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x46, 0x6f, 0x72, 0x10, 0x24, 0x12, 0x15, 0x0a, 0x11, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
+	0x65, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x25, 0x12, 0x14, 0x0a,
+	0x10, 0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x46, 0x6f,
+	0x72, 0x10, 0x26, 0x12, 0x0e, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x69, 0x63, 0x4c, 0x69, 0x6e,
+	0x6b, 0x10, 0x27, 0x12, 0x08, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x10, 0x28, 0x12, 0x0c, 0x0a,
+	0x08, 0x74, 0x65, 0x73, 0x74, 0x43, 0x61, 0x73, 0x65, 0x10, 0x29, 0x12, 0x12, 0x0a, 0x0e, 0x74,
+	0x65, 0x73, 0x74, 0x44, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x65, 0x6e, 0x63, 0x79, 0x10, 0x2a, 0x12,
+	0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73, 0x74, 0x54, 0x6f, 0x6f, 0x6c, 0x10, 0x2b, 0x12, 0x0b, 0x0a,
+	0x07, 0x76, 0x61, 0x72, 0x69, 0x61, 0x6e, 0x74, 0x10, 0x2c, 0x12, 0x0d, 0x0a, 0x09, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x65, 0x64, 0x4f, 0x6e, 0x10, 0x2d, 0x12, 0x0c, 0x0a, 0x08, 0x74, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x4f, 0x6e, 0x10, 0x2e, 0x22, 0x97, 0x0c, 0x0a, 0x11, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x75, 0x74,
+	0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x75,
+	0x74, 0x68, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x48, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65,
+	0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x48, 0x61,
+	0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x12, 0x4e, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x3a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd6, 0x09, 0x0a,
+	0x15, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57,
+	0x4e, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10, 0x02,
+	0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f, 0x4d, 0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x4f, 0x57,
+	0x45, 0x52, 0x10, 0x04, 0x12, 0x0e, 0x0a, 0x0a, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x4d, 0x45,
+	0x54, 0x41, 0x10, 0x05, 0x12, 0x10, 0x0a, 0x0c, 0x42, 0x55, 0x49, 0x4c, 0x44, 0x5f, 0x53, 0x59,
+	0x53, 0x54, 0x45, 0x4d, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46,
+	0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x07,
+	0x12, 0x08, 0x0a, 0x04, 0x43, 0x48, 0x41, 0x54, 0x10, 0x08, 0x12, 0x1b, 0x0a, 0x17, 0x43, 0x4f,
+	0x44, 0x49, 0x46, 0x49, 0x45, 0x44, 0x5f, 0x49, 0x4e, 0x46, 0x52, 0x41, 0x53, 0x54, 0x52, 0x55,
+	0x43, 0x54, 0x55, 0x52, 0x45, 0x10, 0x09, 0x12, 0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4d, 0x50, 0x4f,
+	0x4e, 0x45, 0x4e, 0x54, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45,
+	0x50, 0x4f, 0x52, 0x54, 0x10, 0x0a, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47,
+	0x55, 0x52, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x0b, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x49, 0x53,
+	0x54, 0x52, 0x49, 0x42, 0x55, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x54, 0x41, 0x4b, 0x45,
+	0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x0d, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x4f, 0x57, 0x4e, 0x4c, 0x4f, 0x41,
+	0x44, 0x10, 0x0e, 0x12, 0x1b, 0x0a, 0x17, 0x44, 0x59, 0x4e, 0x41, 0x4d, 0x49, 0x43, 0x5f, 0x41,
+	0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x0f,
+	0x12, 0x0e, 0x0a, 0x0a, 0x45, 0x4f, 0x4c, 0x5f, 0x4e, 0x4f, 0x54, 0x49, 0x43, 0x45, 0x10, 0x10,
+	0x12, 0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x11, 0x12, 0x1d,
+	0x0a, 0x19, 0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c,
+	0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x12, 0x12, 0x0f, 0x0a,
+	0x0b, 0x46, 0x4f, 0x52, 0x4d, 0x55, 0x4c, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x13, 0x12, 0x0b,
+	0x0a, 0x07, 0x46, 0x55, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x14, 0x12, 0x11, 0x0a, 0x0d, 0x49,
+	0x53, 0x53, 0x55, 0x45, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x52, 0x10, 0x15, 0x12, 0x0b,
+	0x0a, 0x07, 0x4c, 0x49, 0x43, 0x45, 0x4e, 0x53, 0x45, 0x10, 0x16, 0x12, 0x07, 0x0a, 0x03, 0x4c,
+	0x4f, 0x47, 0x10, 0x17, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x41, 0x49, 0x4c, 0x49, 0x4e, 0x47, 0x5f,
+	0x4c, 0x49, 0x53, 0x54, 0x10, 0x18, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x54, 0x55, 0x52, 0x49,
+	0x54, 0x59, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x19, 0x12, 0x11, 0x0a, 0x0d, 0x4d,
+	0x41, 0x56, 0x45, 0x4e, 0x5f, 0x43, 0x45, 0x4e, 0x54, 0x52, 0x41, 0x4c, 0x10, 0x1a, 0x12, 0x0b,
+	0x0a, 0x07, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x53, 0x10, 0x1b, 0x12, 0x0e, 0x0a, 0x0a, 0x4d,
+	0x4f, 0x44, 0x45, 0x4c, 0x5f, 0x43, 0x41, 0x52, 0x44, 0x10, 0x1c, 0x12, 0x07, 0x0a, 0x03, 0x4e,
+	0x50, 0x4d, 0x10, 0x1d, 0x12, 0x09, 0x0a, 0x05, 0x4e, 0x55, 0x47, 0x45, 0x54, 0x10, 0x1e, 0x12,
+	0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x1f, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x4f,
+	0x41, 0x4d, 0x10, 0x20, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x49, 0x56, 0x41, 0x43, 0x59, 0x5f,
+	0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x21, 0x12, 0x14, 0x0a, 0x10,
+	0x50, 0x52, 0x4f, 0x44, 0x55, 0x43, 0x54, 0x5f, 0x4d, 0x45, 0x54, 0x41, 0x44, 0x41, 0x54, 0x41,
+	0x10, 0x22, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x55, 0x52, 0x43, 0x48, 0x41, 0x53, 0x45, 0x5f, 0x4f,
+	0x52, 0x44, 0x45, 0x52, 0x10, 0x23, 0x12, 0x1d, 0x0a, 0x19, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54,
+	0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x50,
+	0x4f, 0x52, 0x54, 0x10, 0x24, 0x12, 0x13, 0x0a, 0x0f, 0x51, 0x55, 0x41, 0x4c, 0x49, 0x54, 0x59,
+	0x5f, 0x4d, 0x45, 0x54, 0x52, 0x49, 0x43, 0x53, 0x10, 0x25, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x45,
+	0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x48, 0x49, 0x53, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x26, 0x12,
+	0x11, 0x0a, 0x0d, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x45, 0x53,
+	0x10, 0x27, 0x12, 0x13, 0x0a, 0x0f, 0x52, 0x49, 0x53, 0x4b, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x53,
+	0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x28, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x55, 0x4e, 0x54, 0x49,
+	0x4d, 0x45, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f,
+	0x52, 0x54, 0x10, 0x29, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x45, 0x43, 0x55, 0x52, 0x45, 0x5f, 0x53,
+	0x4f, 0x46, 0x54, 0x57, 0x41, 0x52, 0x45, 0x5f, 0x41, 0x54, 0x54, 0x45, 0x53, 0x54, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x2a, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54,
+	0x59, 0x5f, 0x41, 0x44, 0x56, 0x45, 0x52, 0x53, 0x41, 0x52, 0x59, 0x5f, 0x4d, 0x4f, 0x44, 0x45,
+	0x4c, 0x10, 0x2b, 0x12, 0x15, 0x0a, 0x11, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x41, 0x44, 0x56, 0x49, 0x53, 0x4f, 0x52, 0x59, 0x10, 0x2c, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x45,
+	0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x43, 0x54, 0x10, 0x2d,
+	0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x46, 0x49, 0x58,
+	0x10, 0x2e, 0x12, 0x12, 0x0a, 0x0e, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4f,
+	0x54, 0x48, 0x45, 0x52, 0x10, 0x2f, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49,
+	0x54, 0x59, 0x5f, 0x50, 0x45, 0x4e, 0x54, 0x45, 0x53, 0x54, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52,
+	0x54, 0x10, 0x30, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f,
+	0x50, 0x4f, 0x4c, 0x49, 0x43, 0x59, 0x10, 0x31, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x45, 0x43, 0x55,
+	0x52, 0x49, 0x54, 0x59, 0x5f, 0x53, 0x57, 0x49, 0x44, 0x10, 0x32, 0x12, 0x19, 0x0a, 0x15, 0x53,
+	0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x54, 0x48, 0x52, 0x45, 0x41, 0x54, 0x5f, 0x4d,
+	0x4f, 0x44, 0x45, 0x4c, 0x10, 0x33, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f, 0x43, 0x49, 0x41, 0x4c,
+	0x10, 0x34, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x41, 0x52, 0x54,
+	0x49, 0x46, 0x41, 0x43, 0x54, 0x10, 0x35, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x54, 0x41, 0x54, 0x49,
+	0x43, 0x5f, 0x41, 0x4e, 0x41, 0x4c, 0x59, 0x53, 0x49, 0x53, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52,
+	0x54, 0x10, 0x36, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x55, 0x50, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x37,
+	0x12, 0x07, 0x0a, 0x03, 0x56, 0x43, 0x53, 0x10, 0x38, 0x12, 0x1b, 0x0a, 0x17, 0x56, 0x55, 0x4c,
+	0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45, 0x52,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x39, 0x12, 0x23, 0x0a, 0x1f, 0x56, 0x55, 0x4c, 0x4e, 0x45, 0x52,
+	0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x44, 0x49, 0x53, 0x43, 0x4c, 0x4f, 0x53, 0x55,
+	0x52, 0x45, 0x5f, 0x52, 0x45, 0x50, 0x4f, 0x52, 0x54, 0x10, 0x3a, 0x12, 0x2b, 0x0a, 0x27, 0x56,
+	0x55, 0x4c, 0x4e, 0x45, 0x52, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x45, 0x58, 0x50,
+	0x4c, 0x4f, 0x49, 0x54, 0x41, 0x42, 0x49, 0x4c, 0x49, 0x54, 0x59, 0x5f, 0x41, 0x53, 0x53, 0x45,
+	0x53, 0x53, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x3b, 0x12, 0x0b, 0x0a, 0x07, 0x57, 0x45, 0x42, 0x53,
+	0x49, 0x54, 0x45, 0x10, 0x3c, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x4a, 0x04, 0x08, 0x05, 0x10,
+	0x06, 0x22, 0x6f, 0x0a, 0x07, 0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65,
+	0x6e, 0x74, 0x22, 0xa1, 0x05, 0x0a, 0x08, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a,
+	0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x2d, 0x0a,
+	0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
-	0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x73, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x22, 0xe7, 0x0a, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65,
-	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
-	0x12, 0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d,
-	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d,
-	0x65, 0x12, 0x19, 0x0a, 0x08, 0x75, 0x72, 0x6c, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x75, 0x72, 0x6c, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c,
-	0x75, 0x72, 0x6c, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0b, 0x75, 0x72, 0x6c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12,
-	0x1a, 0x0a, 0x08, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x08, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6c,
-	0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x43,
-	0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x69, 0x63, 0x65,
-	0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x70, 0x79, 0x72, 0x69, 0x67, 0x68, 0x74,
-	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x70, 0x79, 0x72, 0x69, 0x67, 0x68,
-	0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f,
-	0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07,
-	0x73, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
-	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x74, 0x74, 0x72,
-	0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61,
-	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x75,
-	0x70, 0x70, 0x6c, 0x69, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x2e, 0x54, 0x6f, 0x6f, 0x6c, 0x52, 0x05, 0x74, 0x6f, 0x6f, 0x6c, 0x73, 0x12, 0x33, 0x0a, 0x07,
+	0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x07, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x0d, 0x64,
+	0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x44, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x0d, 0x64, 0x6f, 0x63, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70,
+	0x65, 0x73, 0x12, 0x3e, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x53, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x12, 0x43, 0x0a, 0x0f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x5f, 0x6c, 0x69, 0x63,
+	0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
+	0x4c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x52, 0x0e, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x4c,
+	0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x52,
+	0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x63, 0x6f,
+	0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x6f, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x3b, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x12, 0x3c, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x22, 0xd4, 0x0b, 0x0a, 0x04, 0x4e, 0x6f, 0x64, 0x65, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x34, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
-	0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69,
-	0x65, 0x72, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
-	0x72, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72,
-	0x73, 0x6f, 0x6e, 0x52, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x73,
-	0x12, 0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65,
-	0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
-	0x6d, 0x70, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12,
-	0x39, 0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20,
+	0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x75, 0x72, 0x6c, 0x5f, 0x68, 0x6f, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x75, 0x72, 0x6c, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x75,
+	0x72, 0x6c, 0x5f, 0x64, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x75, 0x72, 0x6c, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a,
+	0x0a, 0x08, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x6c, 0x69,
+	0x63, 0x65, 0x6e, 0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x6f,
+	0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x64, 0x12, 0x29, 0x0a, 0x10, 0x6c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x6c, 0x69, 0x63, 0x65, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x70, 0x79, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x70, 0x79, 0x72, 0x69, 0x67, 0x68, 0x74,
+	0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73,
+	0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x75,
+	0x6d, 0x6d, 0x61, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x74, 0x74, 0x72, 0x69,
+	0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x12, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x74,
+	0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x73, 0x75, 0x70,
+	0x70, 0x6c, 0x69, 0x65, 0x72, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x69, 0x65,
+	0x72, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72,
+	0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73,
+	0x6f, 0x6e, 0x52, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x12,
+	0x3d, 0x0a, 0x0c, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0b, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x44, 0x61, 0x74, 0x65, 0x12, 0x39,
+	0x0a, 0x0a, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09,
+	0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x17, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x09, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x44, 0x0a, 0x10, 0x76, 0x61,
-	0x6c, 0x69, 0x64, 0x5f, 0x75, 0x6e, 0x74, 0x69, 0x6c, 0x5f, 0x64, 0x61, 0x74, 0x65, 0x18, 0x17,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x44, 0x61, 0x74, 0x65,
-	0x12, 0x55, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
-	0x6d, 0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65,
-	0x6e, 0x63, 0x65, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f,
-	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c,
-	0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x4a, 0x0a, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x66, 0x69, 0x65, 0x72, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72,
+	0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x55, 0x6e, 0x74, 0x69, 0x6c, 0x44, 0x61, 0x74, 0x65, 0x12,
+	0x55, 0x0a, 0x13, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x18, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x45, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x52, 0x12, 0x65, 0x78, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x52, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74,
+	0x79, 0x70, 0x65, 0x73, 0x18, 0x1b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x4a, 0x0a, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66,
+	0x69, 0x65, 0x72, 0x73, 0x18, 0x1c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e,
+	0x6f, 0x64, 0x65, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
+	0x73, 0x12, 0x3b, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x1d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x43,
+	0x0a, 0x0f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x70, 0x75, 0x72, 0x70, 0x6f, 0x73,
+	0x65, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x75, 0x72, 0x70,
+	0x6f, 0x73, 0x65, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50, 0x75, 0x72, 0x70,
+	0x6f, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x70,
+	0x65, 0x72, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73,
+	0x12, 0x35, 0x0a, 0x08, 0x61, 0x69, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x18, 0x20, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x41, 0x49, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x52, 0x07,
+	0x61, 0x69, 0x4d, 0x6f, 0x64, 0x65, 0x6c, 0x12, 0x34, 0x0a, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73,
+	0x65, 0x74, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x44, 0x61, 0x74,
+	0x61, 0x73, 0x65, 0x74, 0x52, 0x07, 0x64, 0x61, 0x74, 0x61, 0x73, 0x65, 0x74, 0x1a, 0x3e, 0x0a,
+	0x10, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a,
+	0x0b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x21, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x43, 0x4b, 0x41, 0x47, 0x45, 0x10,
+	0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x4a, 0x04, 0x08, 0x0c, 0x10,
+	0x0d, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x4a, 0x04, 0x08, 0x19, 0x10, 0x1a, 0x22, 0x8d, 0x01,
+	0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x65, 0x64, 0x67,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x45, 0x64, 0x67,
+	0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x6f, 0x6f, 0x74,
+	0x5f, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x60, 0x0a,
+	0x0c, 0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a,
+	0x04, 0x70, 0x75, 0x72, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x75, 0x72,
+	0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0xa3, 0x02, 0x0a, 0x07, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x12, 0x47, 0x0a, 0x0b, 0x63,
+	0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x25, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4f, 0x76, 0x65, 0x72, 0x6c, 0x61, 0x79, 0x2e, 0x43, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3b, 0x0a, 0x08, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x53,
+	0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x61, 0x6c,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x78, 0x0a, 0x0a, 0x43,
+	0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x08, 0x73, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
+	0x4e, 0x6f, 0x64, 0x65, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x52, 0x08, 0x73, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x12, 0x2d, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05,
+	0x70, 0x61, 0x74, 0x63, 0x68, 0x22, 0xa8, 0x01, 0x0a, 0x06, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x6f, 0x72, 0x67, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x69, 0x73, 0x4f, 0x72, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69,
+	0x6c, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x75, 0x72, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x63, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
-	0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0b, 0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65,
-	0x72, 0x73, 0x12, 0x3b, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x1d, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x48, 0x61, 0x73, 0x68,
-	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12,
-	0x43, 0x0a, 0x0f, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x5f, 0x70, 0x75, 0x72, 0x70, 0x6f,
-	0x73, 0x65, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x75, 0x72,
-	0x70, 0x6f, 0x73, 0x65, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x6d, 0x61, 0x72, 0x79, 0x50, 0x75, 0x72,
-	0x70, 0x6f, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
-	0x65, 0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x72, 0x6f,
-	0x70, 0x65, 0x72, 0x74, 0x79, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65,
-	0x73, 0x1a, 0x3e, 0x0a, 0x10, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x73,
-	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x73,
+	0x22, 0x32, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x6c, 0x0a, 0x08, 0x52, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x04, 0x64, 0x61,
+	0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0xe7, 0x03, 0x0a, 0x09, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x12, 0x3b, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x23, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x61, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x15, 0x0a, 0x06, 0x6b,
+	0x65, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x65, 0x79,
+	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x12, 0x4d, 0x0a, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x2e, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x76, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x76,
+	0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54,
+	0x79, 0x70, 0x65, 0x22, 0x3d, 0x0a, 0x06, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x12, 0x0a,
+	0x0e, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x10,
+	0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x53, 0x53, 0x45, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x4a,
+	0x53, 0x46, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x44, 0x45, 0x54, 0x41, 0x43, 0x48, 0x45, 0x44,
+	0x10, 0x03, 0x22, 0x38, 0x0a, 0x0c, 0x56, 0x65, 0x72, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x0a, 0x55, 0x4e, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x56, 0x45, 0x52, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x01,
+	0x12, 0x0a, 0x0a, 0x06, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x22, 0xd5, 0x01, 0x0a,
+	0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x61,
+	0x74, 0x61, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x15, 0x0a, 0x03, 0x75, 0x72,
+	0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x75, 0x72, 0x69, 0x88, 0x01,
 	0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
 	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b,
 	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x21, 0x0a, 0x08,
-	0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x43, 0x4b,
-	0x41, 0x47, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x01, 0x4a,
-	0x04, 0x08, 0x0c, 0x10, 0x0d, 0x4a, 0x04, 0x08, 0x0e, 0x10, 0x0f, 0x4a, 0x04, 0x08, 0x19, 0x10,
-	0x1a, 0x22, 0x8d, 0x01, 0x0a, 0x08, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d,
-	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
-	0x6d, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x2d, 0x0a,
-	0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
-	0x2e, 0x45, 0x64, 0x67, 0x65, 0x52, 0x05, 0x65, 0x64, 0x67, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d,
-	0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x6f, 0x6f, 0x74, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x73, 0x22, 0xa8, 0x01, 0x0a, 0x06, 0x50, 0x65, 0x72, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x06, 0x0a, 0x04,
+	0x5f, 0x75, 0x72, 0x69, 0x22, 0x4c, 0x0a, 0x04, 0x54, 0x6f, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04,
 	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x5f, 0x6f, 0x72, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x05, 0x69, 0x73, 0x4f, 0x72, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a,
-	0x03, 0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
-	0x14, 0x0a, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x70, 0x68, 0x6f, 0x6e, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74,
-	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x65, 0x72, 0x73,
-	0x6f, 0x6e, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x73, 0x22, 0x32, 0x0a, 0x08,
-	0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61,
-	0x22, 0xd5, 0x01, 0x0a, 0x0a, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x12,
-	0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x41, 0x0a, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65,
-	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x44, 0x61, 0x74, 0x61, 0x2e, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x06, 0x68, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x15,
-	0x0a, 0x03, 0x75, 0x72, 0x69, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x03, 0x75,
-	0x72, 0x69, 0x88, 0x01, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x45,
-	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
-	0x42, 0x06, 0x0a, 0x04, 0x5f, 0x75, 0x72, 0x69, 0x22, 0x4c, 0x0a, 0x04, 0x54, 0x6f, 0x6f, 0x6c,
-	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
-	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16,
-	0x0a, 0x06, 0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x76, 0x65, 0x6e, 0x64, 0x6f, 0x72, 0x2a, 0xf0, 0x01, 0x0a, 0x0d, 0x48, 0x61, 0x73, 0x68, 0x41,
-	0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e,
-	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x35, 0x10, 0x01, 0x12, 0x08,
-	0x0a, 0x04, 0x53, 0x48, 0x41, 0x31, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x32,
-	0x35, 0x36, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x33, 0x38, 0x34, 0x10, 0x04,
-	0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32, 0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08,
-	0x53, 0x48, 0x41, 0x33, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x06, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48,
-	0x41, 0x33, 0x5f, 0x33, 0x38, 0x34, 0x10, 0x07, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33,
-	0x5f, 0x35, 0x31, 0x32, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32,
-	0x42, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x09, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45,
-	0x32, 0x42, 0x5f, 0x33, 0x38, 0x34, 0x10, 0x0a, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b,
-	0x45, 0x32, 0x42, 0x5f, 0x35, 0x31, 0x32, 0x10, 0x0b, 0x12, 0x0a, 0x0a, 0x06, 0x42, 0x4c, 0x41,
-	0x4b, 0x45, 0x33, 0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x32, 0x10, 0x0d, 0x12, 0x0b,
-	0x0a, 0x07, 0x41, 0x44, 0x4c, 0x45, 0x52, 0x33, 0x32, 0x10, 0x0e, 0x12, 0x07, 0x0a, 0x03, 0x4d,
-	0x44, 0x34, 0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x36, 0x10, 0x10, 0x12, 0x0a, 0x0a,
-	0x06, 0x53, 0x48, 0x41, 0x32, 0x32, 0x34, 0x10, 0x11, 0x2a, 0xb7, 0x03, 0x0a, 0x07, 0x50, 0x75,
-	0x72, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
-	0x5f, 0x50, 0x55, 0x52, 0x50, 0x4f, 0x53, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x41, 0x50,
-	0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x41,
-	0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f, 0x4d, 0x10,
-	0x03, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x41, 0x54, 0x49,
-	0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49, 0x4e, 0x45,
-	0x52, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x10, 0x06, 0x12, 0x0a, 0x0a,
-	0x06, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x07, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x45, 0x56,
-	0x49, 0x43, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x08, 0x12, 0x11, 0x0a, 0x0d,
-	0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x09, 0x12,
-	0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x0a, 0x12, 0x0e, 0x0a,
-	0x0a, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x0b, 0x12, 0x08, 0x0a,
-	0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x0c, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x49, 0x52, 0x4d, 0x57,
-	0x41, 0x52, 0x45, 0x10, 0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x52, 0x41, 0x4d, 0x45, 0x57, 0x4f,
-	0x52, 0x4b, 0x10, 0x0e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c, 0x4c, 0x10,
-	0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x42, 0x52, 0x41, 0x52, 0x59, 0x10, 0x10, 0x12, 0x1a,
-	0x0a, 0x16, 0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45, 0x5f, 0x4c, 0x45, 0x41, 0x52, 0x4e, 0x49,
-	0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x11, 0x12, 0x0c, 0x0a, 0x08, 0x4d, 0x41,
-	0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x10, 0x12, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4f, 0x44, 0x45,
-	0x4c, 0x10, 0x13, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10, 0x14, 0x12,
-	0x14, 0x0a, 0x10, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x53, 0x59, 0x53,
-	0x54, 0x45, 0x4d, 0x10, 0x15, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52, 0x10, 0x16,
-	0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x17, 0x12, 0x0c, 0x0a, 0x08, 0x50,
-	0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x10, 0x18, 0x12, 0x0f, 0x0a, 0x0b, 0x52, 0x45, 0x51,
-	0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x19, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x4f,
-	0x55, 0x52, 0x43, 0x45, 0x10, 0x1a, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
-	0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x1b, 0x12, 0x08, 0x0a, 0x04, 0x54, 0x45, 0x53,
-	0x54, 0x10, 0x1c, 0x2a, 0x61, 0x0a, 0x16, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72, 0x65, 0x49,
-	0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a,
-	0x17, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x46,
-	0x49, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x50, 0x55,
-	0x52, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x32, 0x10, 0x02, 0x12,
-	0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x33, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x49,
-	0x54, 0x4f, 0x49, 0x44, 0x10, 0x04, 0x42, 0xae, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
-	0x42, 0x09, 0x53, 0x62, 0x6f, 0x6d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x25, 0x67,
-	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
-	0x73, 0x62, 0x6f, 0x6d, 0xa2, 0x02, 0x03, 0x50, 0x50, 0x58, 0xaa, 0x02, 0x11, 0x50, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0xca, 0x02,
-	0x11, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x6f, 0x6d, 0xe2, 0x02, 0x1d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x5c, 0x50, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
-	0x74, 0x61, 0xea, 0x02, 0x12, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x3a, 0x3a, 0x50,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x65,
+	0x6e, 0x64, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x6e, 0x64,
+	0x6f, 0x72, 0x22, 0xe7, 0x0f, 0x0a, 0x0d, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x75, 0x72, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x55, 0x72, 0x6c, 0x12, 0x41, 0x0a, 0x07, 0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x52, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07,
+	0x72, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x63, 0x6f, 0x6d,
+	0x6d, 0x65, 0x6e, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x64, 0x76,
+	0x69, 0x73, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x61,
+	0x64, 0x76, 0x69, 0x73, 0x6f, 0x72, 0x69, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x09, 0x70, 0x75, 0x62,
+	0x6c, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x07, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x66, 0x66, 0x65,
+	0x63, 0x74, 0x73, 0x12, 0x45, 0x0a, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73,
+	0x52, 0x08, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c,
+	0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6c, 0x69,
+	0x61, 0x73, 0x65, 0x73, 0x12, 0x57, 0x0a, 0x0d, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x61, 0x6e, 0x61,
+	0x6c, 0x79, 0x73, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
+	0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x4e, 0x6f,
+	0x64, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0c, 0x6e, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x12, 0x45, 0x0a,
+	0x08, 0x73, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x79, 0x2e, 0x53, 0x65, 0x76, 0x65, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65,
+	0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x77, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x05, 0x52, 0x04, 0x63, 0x77, 0x65, 0x73, 0x1a, 0xbe, 0x07, 0x0a, 0x08, 0x41, 0x6e, 0x61,
+	0x6c, 0x79, 0x73, 0x69, 0x73, 0x12, 0x45, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61,
+	0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65,
+	0x74, 0x61, 0x69, 0x6c, 0x12, 0x5d, 0x0a, 0x0d, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e,
+	0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6e,
+	0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x2e, 0x4a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x50, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f,
+	0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65,
+	0x72, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69,
+	0x73, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x09, 0x72, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x69,
+	0x73, 0x73, 0x75, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x66, 0x69, 0x72, 0x73, 0x74, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x64, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x64, 0x22, 0x8a, 0x01, 0x0a, 0x05, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x11, 0x0a,
+	0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x00,
+	0x12, 0x0c, 0x0a, 0x08, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1a,
+	0x0a, 0x16, 0x52, 0x45, 0x53, 0x4f, 0x4c, 0x56, 0x45, 0x44, 0x5f, 0x57, 0x49, 0x54, 0x48, 0x5f,
+	0x50, 0x45, 0x44, 0x49, 0x47, 0x52, 0x45, 0x45, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x58,
+	0x50, 0x4c, 0x4f, 0x49, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x49,
+	0x4e, 0x5f, 0x54, 0x52, 0x49, 0x41, 0x47, 0x45, 0x10, 0x04, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x41,
+	0x4c, 0x53, 0x45, 0x5f, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x49, 0x56, 0x45, 0x10, 0x05, 0x12, 0x10,
+	0x0a, 0x0c, 0x4e, 0x4f, 0x54, 0x5f, 0x41, 0x46, 0x46, 0x45, 0x43, 0x54, 0x45, 0x44, 0x10, 0x06,
+	0x22, 0x9d, 0x02, 0x0a, 0x0d, 0x4a, 0x75, 0x73, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x15, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x4a, 0x55,
+	0x53, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x00, 0x12, 0x14, 0x0a,
+	0x10, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x50, 0x52, 0x45, 0x53, 0x45, 0x4e,
+	0x54, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
+	0x52, 0x45, 0x41, 0x43, 0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x1a, 0x0a, 0x16, 0x52,
+	0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x53, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x45, 0x51, 0x55, 0x49,
+	0x52, 0x45, 0x53, 0x5f, 0x44, 0x45, 0x50, 0x45, 0x4e, 0x44, 0x45, 0x4e, 0x43, 0x59, 0x10, 0x04,
+	0x12, 0x18, 0x0a, 0x14, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x53, 0x5f, 0x45, 0x4e, 0x56,
+	0x49, 0x52, 0x4f, 0x4e, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x05, 0x12, 0x19, 0x0a, 0x15, 0x50, 0x52,
+	0x4f, 0x54, 0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x42, 0x59, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x49,
+	0x4c, 0x45, 0x52, 0x10, 0x06, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x4f, 0x54, 0x45, 0x43, 0x54,
+	0x45, 0x44, 0x5f, 0x41, 0x54, 0x5f, 0x52, 0x55, 0x4e, 0x54, 0x49, 0x4d, 0x45, 0x10, 0x07, 0x12,
+	0x1a, 0x0a, 0x16, 0x50, 0x52, 0x4f, 0x54, 0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x54, 0x5f,
+	0x50, 0x45, 0x52, 0x49, 0x4d, 0x45, 0x54, 0x45, 0x52, 0x10, 0x08, 0x12, 0x23, 0x0a, 0x1f, 0x50,
+	0x52, 0x4f, 0x54, 0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x42, 0x59, 0x5f, 0x4d, 0x49, 0x54, 0x49,
+	0x47, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x10, 0x09,
+	0x22, 0x77, 0x0a, 0x08, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x10,
+	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e, 0x53, 0x45,
+	0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x41, 0x4e, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x46, 0x49,
+	0x58, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x57, 0x49, 0x4c, 0x4c, 0x5f, 0x4e, 0x4f, 0x54, 0x5f,
+	0x46, 0x49, 0x58, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45, 0x10,
+	0x03, 0x12, 0x0c, 0x0a, 0x08, 0x52, 0x4f, 0x4c, 0x4c, 0x42, 0x41, 0x43, 0x4b, 0x10, 0x04, 0x12,
+	0x18, 0x0a, 0x14, 0x57, 0x4f, 0x52, 0x4b, 0x41, 0x52, 0x4f, 0x55, 0x4e, 0x44, 0x5f, 0x41, 0x56,
+	0x41, 0x49, 0x4c, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x05, 0x1a, 0x91, 0x01, 0x0a, 0x06, 0x52, 0x61,
+	0x74, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x05,
+	0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x05, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x65, 0x76, 0x65, 0x72,
+	0x69, 0x74, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x65, 0x63, 0x74, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x65, 0x63,
+	0x74, 0x6f, 0x72, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x1a, 0x6a, 0x0a,
+	0x11, 0x4e, 0x6f, 0x64, 0x65, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x3f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x56, 0x75, 0x6c, 0x6e, 0x65, 0x72, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x79, 0x2e, 0x41, 0x6e, 0x61, 0x6c, 0x79, 0x73, 0x69, 0x73, 0x52, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x61, 0x0a, 0x08, 0x53, 0x65, 0x76,
+	0x65, 0x72, 0x69, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x10, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e,
+	0x5f, 0x53, 0x45, 0x56, 0x45, 0x52, 0x49, 0x54, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4e,
+	0x4f, 0x4e, 0x45, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x02, 0x12,
+	0x07, 0x0a, 0x03, 0x4c, 0x4f, 0x57, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x45, 0x44, 0x49,
+	0x55, 0x4d, 0x10, 0x04, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x49, 0x47, 0x48, 0x10, 0x05, 0x12, 0x0c,
+	0x0a, 0x08, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x06, 0x2a, 0xfc, 0x01, 0x0a,
+	0x0d, 0x48, 0x61, 0x73, 0x68, 0x41, 0x6c, 0x67, 0x6f, 0x72, 0x69, 0x74, 0x68, 0x6d, 0x12, 0x0b,
+	0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4d,
+	0x44, 0x35, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x53, 0x48, 0x41, 0x31, 0x10, 0x02, 0x12, 0x0a,
+	0x0a, 0x06, 0x53, 0x48, 0x41, 0x32, 0x35, 0x36, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48,
+	0x41, 0x33, 0x38, 0x34, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x35, 0x31, 0x32,
+	0x10, 0x05, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x06,
+	0x12, 0x0c, 0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x33, 0x38, 0x34, 0x10, 0x07, 0x12, 0x0c,
+	0x0a, 0x08, 0x53, 0x48, 0x41, 0x33, 0x5f, 0x35, 0x31, 0x32, 0x10, 0x08, 0x12, 0x0f, 0x0a, 0x0b,
+	0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x32, 0x35, 0x36, 0x10, 0x09, 0x12, 0x0f, 0x0a,
+	0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x33, 0x38, 0x34, 0x10, 0x0a, 0x12, 0x0f,
+	0x0a, 0x0b, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x32, 0x42, 0x5f, 0x35, 0x31, 0x32, 0x10, 0x0b, 0x12,
+	0x0a, 0x0a, 0x06, 0x42, 0x4c, 0x41, 0x4b, 0x45, 0x33, 0x10, 0x0c, 0x12, 0x07, 0x0a, 0x03, 0x4d,
+	0x44, 0x32, 0x10, 0x0d, 0x12, 0x0b, 0x0a, 0x07, 0x41, 0x44, 0x4c, 0x45, 0x52, 0x33, 0x32, 0x10,
+	0x0e, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44, 0x34, 0x10, 0x0f, 0x12, 0x07, 0x0a, 0x03, 0x4d, 0x44,
+	0x36, 0x10, 0x10, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x48, 0x41, 0x32, 0x32, 0x34, 0x10, 0x11, 0x12,
+	0x0a, 0x0a, 0x06, 0x53, 0x53, 0x44, 0x45, 0x45, 0x50, 0x10, 0x12, 0x2a, 0xb7, 0x03, 0x0a, 0x07,
+	0x50, 0x75, 0x72, 0x70, 0x6f, 0x73, 0x65, 0x12, 0x13, 0x0a, 0x0f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
+	0x57, 0x4e, 0x5f, 0x50, 0x55, 0x52, 0x50, 0x4f, 0x53, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b,
+	0x41, 0x50, 0x50, 0x4c, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0b, 0x0a,
+	0x07, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x42, 0x4f,
+	0x4d, 0x10, 0x03, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x0d, 0x0a, 0x09, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x49,
+	0x4e, 0x45, 0x52, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x41, 0x54, 0x41, 0x10, 0x06, 0x12,
+	0x0a, 0x0a, 0x06, 0x44, 0x45, 0x56, 0x49, 0x43, 0x45, 0x10, 0x07, 0x12, 0x11, 0x0a, 0x0d, 0x44,
+	0x45, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x44, 0x52, 0x49, 0x56, 0x45, 0x52, 0x10, 0x08, 0x12, 0x11,
+	0x0a, 0x0d, 0x44, 0x4f, 0x43, 0x55, 0x4d, 0x45, 0x4e, 0x54, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10,
+	0x09, 0x12, 0x0c, 0x0a, 0x08, 0x45, 0x56, 0x49, 0x44, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x0a, 0x12,
+	0x0e, 0x0a, 0x0a, 0x45, 0x58, 0x45, 0x43, 0x55, 0x54, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x0b, 0x12,
+	0x08, 0x0a, 0x04, 0x46, 0x49, 0x4c, 0x45, 0x10, 0x0c, 0x12, 0x0c, 0x0a, 0x08, 0x46, 0x49, 0x52,
+	0x4d, 0x57, 0x41, 0x52, 0x45, 0x10, 0x0d, 0x12, 0x0d, 0x0a, 0x09, 0x46, 0x52, 0x41, 0x4d, 0x45,
+	0x57, 0x4f, 0x52, 0x4b, 0x10, 0x0e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x53, 0x54, 0x41, 0x4c,
+	0x4c, 0x10, 0x0f, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49, 0x42, 0x52, 0x41, 0x52, 0x59, 0x10, 0x10,
+	0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x41, 0x43, 0x48, 0x49, 0x4e, 0x45, 0x5f, 0x4c, 0x45, 0x41, 0x52,
+	0x4e, 0x49, 0x4e, 0x47, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x4c, 0x10, 0x11, 0x12, 0x0c, 0x0a, 0x08,
+	0x4d, 0x41, 0x4e, 0x49, 0x46, 0x45, 0x53, 0x54, 0x10, 0x12, 0x12, 0x09, 0x0a, 0x05, 0x4d, 0x4f,
+	0x44, 0x45, 0x4c, 0x10, 0x13, 0x12, 0x0a, 0x0a, 0x06, 0x4d, 0x4f, 0x44, 0x55, 0x4c, 0x45, 0x10,
+	0x14, 0x12, 0x14, 0x0a, 0x10, 0x4f, 0x50, 0x45, 0x52, 0x41, 0x54, 0x49, 0x4e, 0x47, 0x5f, 0x53,
+	0x59, 0x53, 0x54, 0x45, 0x4d, 0x10, 0x15, 0x12, 0x09, 0x0a, 0x05, 0x4f, 0x54, 0x48, 0x45, 0x52,
+	0x10, 0x16, 0x12, 0x09, 0x0a, 0x05, 0x50, 0x41, 0x54, 0x43, 0x48, 0x10, 0x17, 0x12, 0x0c, 0x0a,
+	0x08, 0x50, 0x4c, 0x41, 0x54, 0x46, 0x4f, 0x52, 0x4d, 0x10, 0x18, 0x12, 0x0f, 0x0a, 0x0b, 0x52,
+	0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x4d, 0x45, 0x4e, 0x54, 0x10, 0x19, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x10, 0x1a, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x1b, 0x12, 0x08, 0x0a, 0x04, 0x54,
+	0x45, 0x53, 0x54, 0x10, 0x1c, 0x2a, 0x6c, 0x0a, 0x16, 0x53, 0x6f, 0x66, 0x74, 0x77, 0x61, 0x72,
+	0x65, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x1b, 0x0a, 0x17, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x49, 0x44, 0x45, 0x4e, 0x54,
+	0x49, 0x46, 0x49, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
+	0x50, 0x55, 0x52, 0x4c, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x32, 0x10,
+	0x02, 0x12, 0x09, 0x0a, 0x05, 0x43, 0x50, 0x45, 0x32, 0x33, 0x10, 0x03, 0x12, 0x0a, 0x0a, 0x06,
+	0x47, 0x49, 0x54, 0x4f, 0x49, 0x44, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x53, 0x57, 0x48, 0x49,
+	0x44, 0x10, 0x05, 0x42, 0xae, 0x01, 0x0a, 0x15, 0x63, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x42, 0x09, 0x53,
+	0x62, 0x6f, 0x6d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x62, 0x6f,
+	0x6d, 0xa2, 0x02, 0x03, 0x50, 0x50, 0x58, 0xaa, 0x02, 0x11, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x6f, 0x6d, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0xca, 0x02, 0x11, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0xe2,
+	0x02, 0x1d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x5c, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x6f, 0x6d, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea,
+	0x02, 0x12, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x6f, 0x6d, 0x3a, 0x3a, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x6f, 0x6d, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2467,8 +4376,8 @@ func file_sbom_proto_rawDescGZIP() []byte {
 	return file_sbom_proto_rawDescData
 }
 
-var file_sbom_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_sbom_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_sbom_proto_enumTypes = make([]protoimpl.EnumInfo, 13)
+var file_sbom_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_sbom_proto_goTypes = []any{
 	(HashAlgorithm)(0),                           // 0: protobom.protobom.HashAlgorithm
 	(Purpose)(0),                                 // 1: protobom.protobom.Purpose
@@ -2477,55 +4386,103 @@ var file_sbom_proto_goTypes = []any{
 	(Edge_Type)(0),                               // 4: protobom.protobom.Edge.Type
 	(ExternalReference_ExternalReferenceType)(0), // 5: protobom.protobom.ExternalReference.ExternalReferenceType
 	(Node_NodeType)(0),                           // 6: protobom.protobom.Node.NodeType
-	(*Document)(nil),                             // 7: protobom.protobom.Document
-	(*DocumentType)(nil),                         // 8: protobom.protobom.DocumentType
-	(*Edge)(nil),                                 // 9: protobom.protobom.Edge
-	(*ExternalReference)(nil),                    // 10: protobom.protobom.ExternalReference
-	(*Metadata)(nil),                             // 11: protobom.protobom.Metadata
-	(*Node)(nil),                                 // 12: protobom.protobom.Node
-	(*NodeList)(nil),                             // 13: protobom.protobom.NodeList
-	(*Person)(nil),                               // 14: protobom.protobom.Person
-	(*Property)(nil),                             // 15: protobom.protobom.Property
-	(*SourceData)(nil),                           // 16: protobom.protobom.SourceData
-	(*Tool)(nil),                                 // 17: protobom.protobom.Tool
-	nil,                                          // 18: protobom.protobom.ExternalReference.HashesEntry
-	nil,                                          // 19: protobom.protobom.Node.IdentifiersEntry
-	nil,                                          // 20: protobom.protobom.Node.HashesEntry
-	nil,                                          // 21: protobom.protobom.SourceData.HashesEntry
-	(*timestamppb.Timestamp)(nil),                // 22: google.protobuf.Timestamp
+	(Signature_Format)(0),                        // 7: protobom.protobom.Signature.Format
+	(Signature_Verification)(0),                  // 8: protobom.protobom.Signature.Verification
+	(Vulnerability_Severity)(0),                  // 9: protobom.protobom.Vulnerability.Severity
+	(Vulnerability_Analysis_State)(0),            // 10: protobom.protobom.Vulnerability.Analysis.State
+	(Vulnerability_Analysis_Justification)(0),    // 11: protobom.protobom.Vulnerability.Analysis.Justification
+	(Vulnerability_Analysis_Response)(0),         // 12: protobom.protobom.Vulnerability.Analysis.Response
+	(*AIModel)(nil),                              // 13: protobom.protobom.AIModel
+	(*Dataset)(nil),                              // 14: protobom.protobom.Dataset
+	(*Document)(nil),                             // 15: protobom.protobom.Document
+	(*DocumentType)(nil),                         // 16: protobom.protobom.DocumentType
+	(*Edge)(nil),                                 // 17: protobom.protobom.Edge
+	(*ExternalReference)(nil),                    // 18: protobom.protobom.ExternalReference
+	(*License)(nil),                              // 19: protobom.protobom.License
+	(*Metadata)(nil),                             // 20: protobom.protobom.Metadata
+	(*Node)(nil),                                 // 21: protobom.protobom.Node
+	(*NodeList)(nil),                             // 22: protobom.protobom.NodeList
+	(*NodeSelector)(nil),                         // 23: protobom.protobom.NodeSelector
+	(*Overlay)(nil),                              // 24: protobom.protobom.Overlay
+	(*Person)(nil),                               // 25: protobom.protobom.Person
+	(*Property)(nil),                             // 26: protobom.protobom.Property
+	(*Revision)(nil),                             // 27: protobom.protobom.Revision
+	(*Signature)(nil),                            // 28: protobom.protobom.Signature
+	(*SourceData)(nil),                           // 29: protobom.protobom.SourceData
+	(*Tool)(nil),                                 // 30: protobom.protobom.Tool
+	(*Vulnerability)(nil),                        // 31: protobom.protobom.Vulnerability
+	nil,                                          // 32: protobom.protobom.AIModel.MetricsEntry
+	nil,                                          // 33: protobom.protobom.AIModel.HyperparametersEntry
+	nil,                                          // 34: protobom.protobom.ExternalReference.HashesEntry
+	nil,                                          // 35: protobom.protobom.Node.IdentifiersEntry
+	nil,                                          // 36: protobom.protobom.Node.HashesEntry
+	(*Overlay_Correction)(nil),                   // 37: protobom.protobom.Overlay.Correction
+	nil,                                          // 38: protobom.protobom.SourceData.HashesEntry
+	(*Vulnerability_Analysis)(nil),               // 39: protobom.protobom.Vulnerability.Analysis
+	(*Vulnerability_Rating)(nil),                 // 40: protobom.protobom.Vulnerability.Rating
+	nil,                                          // 41: protobom.protobom.Vulnerability.NodeAnalysesEntry
+	(*timestamppb.Timestamp)(nil),                // 42: google.protobuf.Timestamp
 }
 var file_sbom_proto_depIdxs = []int32{
-	11, // 0: protobom.protobom.Document.metadata:type_name -> protobom.protobom.Metadata
-	13, // 1: protobom.protobom.Document.node_list:type_name -> protobom.protobom.NodeList
-	3,  // 2: protobom.protobom.DocumentType.type:type_name -> protobom.protobom.DocumentType.SBOMType
-	4,  // 3: protobom.protobom.Edge.type:type_name -> protobom.protobom.Edge.Type
-	18, // 4: protobom.protobom.ExternalReference.hashes:type_name -> protobom.protobom.ExternalReference.HashesEntry
-	5,  // 5: protobom.protobom.ExternalReference.type:type_name -> protobom.protobom.ExternalReference.ExternalReferenceType
-	22, // 6: protobom.protobom.Metadata.date:type_name -> google.protobuf.Timestamp
-	17, // 7: protobom.protobom.Metadata.tools:type_name -> protobom.protobom.Tool
-	14, // 8: protobom.protobom.Metadata.authors:type_name -> protobom.protobom.Person
-	8,  // 9: protobom.protobom.Metadata.documentTypes:type_name -> protobom.protobom.DocumentType
-	16, // 10: protobom.protobom.Metadata.source_data:type_name -> protobom.protobom.SourceData
-	6,  // 11: protobom.protobom.Node.type:type_name -> protobom.protobom.Node.NodeType
-	14, // 12: protobom.protobom.Node.suppliers:type_name -> protobom.protobom.Person
-	14, // 13: protobom.protobom.Node.originators:type_name -> protobom.protobom.Person
-	22, // 14: protobom.protobom.Node.release_date:type_name -> google.protobuf.Timestamp
-	22, // 15: protobom.protobom.Node.build_date:type_name -> google.protobuf.Timestamp
-	22, // 16: protobom.protobom.Node.valid_until_date:type_name -> google.protobuf.Timestamp
-	10, // 17: protobom.protobom.Node.external_references:type_name -> protobom.protobom.ExternalReference
-	19, // 18: protobom.protobom.Node.identifiers:type_name -> protobom.protobom.Node.IdentifiersEntry
-	20, // 19: protobom.protobom.Node.hashes:type_name -> protobom.protobom.Node.HashesEntry
-	1,  // 20: protobom.protobom.Node.primary_purpose:type_name -> protobom.protobom.Purpose
-	15, // 21: protobom.protobom.Node.properties:type_name -> protobom.protobom.Property
-	12, // 22: protobom.protobom.NodeList.nodes:type_name -> protobom.protobom.Node
-	9,  // 23: protobom.protobom.NodeList.edges:type_name -> protobom.protobom.Edge
-	14, // 24: protobom.protobom.Person.contacts:type_name -> protobom.protobom.Person
-	21, // 25: protobom.protobom.SourceData.hashes:type_name -> protobom.protobom.SourceData.HashesEntry
-	26, // [26:26] is the sub-list for method output_type
-	26, // [26:26] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	32, // 0: protobom.protobom.AIModel.metrics:type_name -> protobom.protobom.AIModel.MetricsEntry
+	33, // 1: protobom.protobom.AIModel.hyperparameters:type_name -> protobom.protobom.AIModel.HyperparametersEntry
+	20, // 2: protobom.protobom.Document.metadata:type_name -> protobom.protobom.Metadata
+	22, // 3: protobom.protobom.Document.node_list:type_name -> protobom.protobom.NodeList
+	31, // 4: protobom.protobom.Document.vulnerabilities:type_name -> protobom.protobom.Vulnerability
+	3,  // 5: protobom.protobom.DocumentType.type:type_name -> protobom.protobom.DocumentType.SBOMType
+	4,  // 6: protobom.protobom.Edge.type:type_name -> protobom.protobom.Edge.Type
+	34, // 7: protobom.protobom.ExternalReference.hashes:type_name -> protobom.protobom.ExternalReference.HashesEntry
+	5,  // 8: protobom.protobom.ExternalReference.type:type_name -> protobom.protobom.ExternalReference.ExternalReferenceType
+	42, // 9: protobom.protobom.Metadata.date:type_name -> google.protobuf.Timestamp
+	30, // 10: protobom.protobom.Metadata.tools:type_name -> protobom.protobom.Tool
+	25, // 11: protobom.protobom.Metadata.authors:type_name -> protobom.protobom.Person
+	16, // 12: protobom.protobom.Metadata.documentTypes:type_name -> protobom.protobom.DocumentType
+	29, // 13: protobom.protobom.Metadata.source_data:type_name -> protobom.protobom.SourceData
+	19, // 14: protobom.protobom.Metadata.custom_licenses:type_name -> protobom.protobom.License
+	27, // 15: protobom.protobom.Metadata.revisions:type_name -> protobom.protobom.Revision
+	26, // 16: protobom.protobom.Metadata.properties:type_name -> protobom.protobom.Property
+	28, // 17: protobom.protobom.Metadata.signatures:type_name -> protobom.protobom.Signature
+	6,  // 18: protobom.protobom.Node.type:type_name -> protobom.protobom.Node.NodeType
+	25, // 19: protobom.protobom.Node.suppliers:type_name -> protobom.protobom.Person
+	25, // 20: protobom.protobom.Node.originators:type_name -> protobom.protobom.Person
+	42, // 21: protobom.protobom.Node.release_date:type_name -> google.protobuf.Timestamp
+	42, // 22: protobom.protobom.Node.build_date:type_name -> google.protobuf.Timestamp
+	42, // 23: protobom.protobom.Node.valid_until_date:type_name -> google.protobuf.Timestamp
+	18, // 24: protobom.protobom.Node.external_references:type_name -> protobom.protobom.ExternalReference
+	35, // 25: protobom.protobom.Node.identifiers:type_name -> protobom.protobom.Node.IdentifiersEntry
+	36, // 26: protobom.protobom.Node.hashes:type_name -> protobom.protobom.Node.HashesEntry
+	1,  // 27: protobom.protobom.Node.primary_purpose:type_name -> protobom.protobom.Purpose
+	26, // 28: protobom.protobom.Node.properties:type_name -> protobom.protobom.Property
+	13, // 29: protobom.protobom.Node.ai_model:type_name -> protobom.protobom.AIModel
+	14, // 30: protobom.protobom.Node.dataset:type_name -> protobom.protobom.Dataset
+	21, // 31: protobom.protobom.NodeList.nodes:type_name -> protobom.protobom.Node
+	17, // 32: protobom.protobom.NodeList.edges:type_name -> protobom.protobom.Edge
+	37, // 33: protobom.protobom.Overlay.corrections:type_name -> protobom.protobom.Overlay.Correction
+	23, // 34: protobom.protobom.Overlay.removals:type_name -> protobom.protobom.NodeSelector
+	25, // 35: protobom.protobom.Person.contacts:type_name -> protobom.protobom.Person
+	42, // 36: protobom.protobom.Revision.date:type_name -> google.protobuf.Timestamp
+	7,  // 37: protobom.protobom.Signature.format:type_name -> protobom.protobom.Signature.Format
+	8,  // 38: protobom.protobom.Signature.verification:type_name -> protobom.protobom.Signature.Verification
+	38, // 39: protobom.protobom.SourceData.hashes:type_name -> protobom.protobom.SourceData.HashesEntry
+	40, // 40: protobom.protobom.Vulnerability.ratings:type_name -> protobom.protobom.Vulnerability.Rating
+	42, // 41: protobom.protobom.Vulnerability.published:type_name -> google.protobuf.Timestamp
+	42, // 42: protobom.protobom.Vulnerability.updated:type_name -> google.protobuf.Timestamp
+	39, // 43: protobom.protobom.Vulnerability.analysis:type_name -> protobom.protobom.Vulnerability.Analysis
+	41, // 44: protobom.protobom.Vulnerability.node_analyses:type_name -> protobom.protobom.Vulnerability.NodeAnalysesEntry
+	9,  // 45: protobom.protobom.Vulnerability.severity:type_name -> protobom.protobom.Vulnerability.Severity
+	23, // 46: protobom.protobom.Overlay.Correction.selector:type_name -> protobom.protobom.NodeSelector
+	21, // 47: protobom.protobom.Overlay.Correction.patch:type_name -> protobom.protobom.Node
+	10, // 48: protobom.protobom.Vulnerability.Analysis.state:type_name -> protobom.protobom.Vulnerability.Analysis.State
+	11, // 49: protobom.protobom.Vulnerability.Analysis.justification:type_name -> protobom.protobom.Vulnerability.Analysis.Justification
+	12, // 50: protobom.protobom.Vulnerability.Analysis.responses:type_name -> protobom.protobom.Vulnerability.Analysis.Response
+	42, // 51: protobom.protobom.Vulnerability.Analysis.first_issued:type_name -> google.protobuf.Timestamp
+	42, // 52: protobom.protobom.Vulnerability.Analysis.last_updated:type_name -> google.protobuf.Timestamp
+	39, // 53: protobom.protobom.Vulnerability.NodeAnalysesEntry.value:type_name -> protobom.protobom.Vulnerability.Analysis
+	54, // [54:54] is the sub-list for method output_type
+	54, // [54:54] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_sbom_proto_init() }
@@ -2535,7 +4492,7 @@ func file_sbom_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_sbom_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*Document); i {
+			switch v := v.(*AIModel); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2547,7 +4504,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*DocumentType); i {
+			switch v := v.(*Dataset); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2559,7 +4516,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*Edge); i {
+			switch v := v.(*Document); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2571,7 +4528,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*ExternalReference); i {
+			switch v := v.(*DocumentType); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2583,7 +4540,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*Metadata); i {
+			switch v := v.(*Edge); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2595,7 +4552,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*Node); i {
+			switch v := v.(*ExternalReference); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2607,7 +4564,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*NodeList); i {
+			switch v := v.(*License); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2619,7 +4576,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*Person); i {
+			switch v := v.(*Metadata); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2631,7 +4588,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*Property); i {
+			switch v := v.(*Node); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2643,7 +4600,7 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*SourceData); i {
+			switch v := v.(*NodeList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2655,6 +4612,90 @@ func file_sbom_proto_init() {
 			}
 		}
 		file_sbom_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*NodeSelector); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*Overlay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*Person); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*Property); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*Revision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*SourceData); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[17].Exporter = func(v any, i int) any {
 			switch v := v.(*Tool); i {
 			case 0:
 				return &v.state
@@ -2666,16 +4707,65 @@ func file_sbom_proto_init() {
 				return nil
 			}
 		}
+		file_sbom_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*Vulnerability); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*Overlay_Correction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*Vulnerability_Analysis); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_sbom_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*Vulnerability_Rating); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
-	file_sbom_proto_msgTypes[1].OneofWrappers = []any{}
-	file_sbom_proto_msgTypes[9].OneofWrappers = []any{}
+	file_sbom_proto_msgTypes[3].OneofWrappers = []any{}
+	file_sbom_proto_msgTypes[16].OneofWrappers = []any{}
+	file_sbom_proto_msgTypes[27].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_sbom_proto_rawDesc,
-			NumEnums:      7,
-			NumMessages:   15,
+			NumEnums:      13,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,277 @@
+package sbom
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Weights of the node fields in the search results
+const (
+	searchWeightName        = 3
+	searchWeightFileName    = 2
+	searchWeightDescription = 1
+)
+
+// Scores of the kinds of term matches
+const (
+	searchScoreExact  = 4
+	searchScorePrefix = 2
+	searchScoreFuzzy  = 1
+)
+
+// SearchOption configures the node searches
+type SearchOption func(*searchOptions)
+
+type searchOptions struct {
+	fuzziness int
+	limit     int
+}
+
+// WithFuzziness enables fuzzy matching in the search, matching the query
+// terms with the indexed words within n edits (insertions, deletions or
+// substitutions). Fuzzy matching is disabled by default.
+func WithFuzziness(n int) SearchOption {
+	return func(o *searchOptions) {
+		o.fuzziness = n
+	}
+}
+
+// WithSearchLimit caps the number of nodes returned by the search. Zero,
+// the default, returns all the matches.
+func WithSearchLimit(n int) SearchOption {
+	return func(o *searchOptions) {
+		o.limit = n
+	}
+}
+
+// searchPosting records a node where an indexed word is found
+type searchPosting struct {
+	node   int
+	weight int
+}
+
+// searchWord is an indexed word of a node
+type searchWord struct {
+	word   string
+	weight int
+}
+
+// SearchIndex is an in-memory full-text index over the names, file names
+// and descriptions of the nodes of a NodeList. The index captures the nodes
+// when it is built, changes to the NodeList are not reflected until the
+// index is built again.
+type SearchIndex struct {
+	nodes []*Node
+
+	// postings maps the indexed words to the nodes where they are found
+	// and nodeWords has the words found in each node.
+	postings  map[string][]searchPosting
+	nodeWords [][]searchWord
+
+	// words has the indexed words, sorted for prefix lookups. The fuzzy
+	// lookups use the words grouped by their first letter.
+	words        []string
+	wordsByFirst map[rune][]string
+}
+
+// NewSearchIndex builds a full-text search index of the NodeList nodes
+func NewSearchIndex(nl *NodeList) *SearchIndex {
+	idx := &SearchIndex{
+		nodes:        slices.Clone(nl.GetNodes()),
+		postings:     map[string][]searchPosting{},
+		wordsByFirst: map[rune][]string{},
+	}
+	idx.nodeWords = make([][]searchWord, len(idx.nodes))
+	for i, n := range idx.nodes {
+		idx.add(i, n.Name, searchWeightName)
+		idx.add(i, n.FileName, searchWeightFileName)
+		idx.add(i, n.Description, searchWeightDescription)
+	}
+	idx.words = make([]string, 0, len(idx.postings))
+	for w := range idx.postings {
+		idx.words = append(idx.words, w)
+	}
+	sort.Strings(idx.words)
+	for _, w := range idx.words {
+		first := []rune(w)[0]
+		idx.wordsByFirst[first] = append(idx.wordsByFirst[first], w)
+	}
+	return idx
+}
+
+// add indexes the words of text for the node at position i
+func (idx *SearchIndex) add(i int, text string, weight int) {
+	for _, w := range searchTerms(text) {
+		postings := idx.postings[w]
+		// Words repeated in the same field are indexed once
+		if l := len(postings); l > 0 && postings[l-1].node == i && postings[l-1].weight == weight {
+			continue
+		}
+		idx.postings[w] = append(postings, searchPosting{node: i, weight: weight})
+		idx.nodeWords[i] = append(idx.nodeWords[i], searchWord{word: w, weight: weight})
+	}
+}
+
+// Search returns the nodes matching all the terms of the query, best
+// matches first. Query terms match the indexed words exactly or as a
+// prefix and, when fuzziness is set, within the allowed edit distance.
+// Fuzzy matches are only looked up among the words starting with the same
+// letter as the term. Matches in the node name rank higher than in the file
+// name and those higher than in the description.
+func (idx *SearchIndex) Search(query string, opts ...SearchOption) []*Node {
+	o := &searchOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ret := []*Node{}
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return ret
+	}
+
+	// Find the words matched by each term, noting the most selective
+	// term to draw the candidate nodes from.
+	matches := make([]map[string]int, len(terms))
+	best, bestCost := 0, -1
+	for i, term := range terms {
+		matches[i] = idx.matchWords(term, o.fuzziness)
+		cost := 0
+		for w := range matches[i] {
+			cost += len(idx.postings[w])
+		}
+		if bestCost == -1 || cost < bestCost {
+			best, bestCost = i, cost
+		}
+	}
+
+	scores := map[int]int{}
+	for w, score := range matches[best] {
+		for _, p := range idx.postings[w] {
+			scores[p.node] = max(scores[p.node], score*p.weight)
+		}
+	}
+
+	// Only the candidates matching all the other terms are kept
+	for i := range terms {
+		if i == best {
+			continue
+		}
+		for node, score := range scores {
+			termScore := 0
+			for _, nw := range idx.nodeWords[node] {
+				if s, ok := matches[i][nw.word]; ok {
+					termScore = max(termScore, s*nw.weight)
+				}
+			}
+			if termScore == 0 {
+				delete(scores, node)
+				continue
+			}
+			scores[node] = score + termScore
+		}
+	}
+
+	positions := make([]int, 0, len(scores))
+	for node := range scores {
+		positions = append(positions, node)
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		if scores[positions[i]] != scores[positions[j]] {
+			return scores[positions[i]] > scores[positions[j]]
+		}
+		return positions[i] < positions[j]
+	})
+	if o.limit > 0 && len(positions) > o.limit {
+		positions = positions[:o.limit]
+	}
+	for _, p := range positions {
+		ret = append(ret, idx.nodes[p])
+	}
+	return ret
+}
+
+// matchWords returns the indexed words matched by a query term with the
+// score of the match.
+func (idx *SearchIndex) matchWords(term string, fuzziness int) map[string]int {
+	ret := map[string]int{}
+	for i := sort.SearchStrings(idx.words, term); i < len(idx.words) && strings.HasPrefix(idx.words[i], term); i++ {
+		if idx.words[i] == term {
+			ret[idx.words[i]] = searchScoreExact
+			continue
+		}
+		ret[idx.words[i]] = searchScorePrefix
+	}
+
+	if fuzziness <= 0 {
+		return ret
+	}
+	rterm := []rune(term)
+	rword := []rune{}
+	rows := make([]int, 2*(len(rterm)+fuzziness+1))
+	for _, w := range idx.wordsByFirst[rterm[0]] {
+		if _, ok := ret[w]; ok {
+			continue
+		}
+		rword = rword[:0]
+		for _, r := range w {
+			rword = append(rword, r)
+		}
+		if d := len(rword) - len(rterm); d > fuzziness || -d > fuzziness {
+			continue
+		}
+		if boundedEditDistance(rterm, rword, fuzziness, rows) <= fuzziness {
+			ret[w] = searchScoreFuzzy
+		}
+	}
+	return ret
+}
+
+// Search returns the nodes whose name, file name or description match the
+// query. It builds a throwaway index on each call, use NewSearchIndex to run
+// multiple searches over the same NodeList.
+func (nl *NodeList) Search(query string, opts ...SearchOption) []*Node {
+	return NewSearchIndex(nl).Search(query, opts...)
+}
+
+// searchTerms splits text in lowercase words made of letters and digits
+func searchTerms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// editDistance returns the Levenshtein distance between a and b. The
+// computation stops early once the distance exceeds maxDistance, returning
+// maxDistance + 1.
+func editDistance(ra, rb []rune, maxDistance int) int {
+	return boundedEditDistance(ra, rb, maxDistance, make([]int, 2*(len(rb)+1)))
+}
+
+// boundedEditDistance is editDistance using rows as the scratch space of the
+// computation. rows must have room for at least 2*(len(rb)+1) elements.
+func boundedEditDistance(ra, rb []rune, maxDistance int, rows []int) int {
+	prev, curr := rows[:len(rb)+1], rows[len(rb)+1:2*(len(rb)+1)]
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin > maxDistance {
+			return maxDistance + 1
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,104 @@
+package sbom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSearchNodeList() *NodeList {
+	return &NodeList{
+		Nodes: []*Node{
+			{Id: "lev", Name: "fast-levenshtein", Description: "Efficient implementation of Levenshtein algorithm"},
+			{Id: "express", Name: "express", Description: "Fast, unopinionated, minimalist web framework"},
+			{Id: "file", Type: Node_FILE, FileName: "src/lib/express/router.js"},
+			{Id: "lodash", Name: "lodash", Description: "Lodash modular utilities."},
+		},
+	}
+}
+
+func ids(nodes []*Node) []string {
+	ret := []string{}
+	for _, n := range nodes {
+		ret = append(ret, n.Id)
+	}
+	return ret
+}
+
+func TestSearch(t *testing.T) {
+	nl := testSearchNodeList()
+	idx := NewSearchIndex(nl)
+	for _, tc := range []struct {
+		name     string
+		query    string
+		opts     []SearchOption
+		expected []string
+	}{
+		{"exact", "lodash", nil, []string{"lodash"}},
+		{"case-insensitive", "LoDash", nil, []string{"lodash"}},
+		{"name-ranks-first", "express", nil, []string{"express", "file"}},
+		{"description", "framework", nil, []string{"express"}},
+		{"prefix", "leven", nil, []string{"lev"}},
+		{"all-terms", "fast web", nil, []string{"express"}},
+		{"multiple-fields", "fast", nil, []string{"lev", "express"}},
+		{"no-fuzzy-by-default", "lodahs", nil, []string{}},
+		{"fuzzy", "lodahs", []SearchOption{WithFuzziness(2)}, []string{"lodash"}},
+		{"fuzzy-distance", "lodahs", []SearchOption{WithFuzziness(1)}, []string{}},
+		{"limit", "fast", []SearchOption{WithSearchLimit(1)}, []string{"lev"}},
+		{"empty", " - ", nil, []string{}},
+		{"no-match", "react", nil, []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ids(idx.Search(tc.query, tc.opts...)))
+		})
+	}
+	require.Equal(t, []string{"file"}, ids(nl.Search("router.js")))
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		a, b     string
+		max      int
+		expected int
+	}{
+		{name: "equal", a: "lodash", b: "lodash", max: 2, expected: 0},
+		{name: "deletion", a: "lodash", b: "lodas", max: 2, expected: 1},
+		{name: "transposition", a: "lodash", b: "lodahs", max: 2, expected: 2},
+		{name: "within max", a: "kitten", b: "sitting", max: 3, expected: 3},
+		{name: "over max", a: "kitten", b: "sitting", max: 1, expected: 2},
+		{name: "empty string", a: "", b: "abc", max: 5, expected: 3},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, editDistance([]rune(tc.a), []rune(tc.b), tc.max))
+		})
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	nl := &NodeList{}
+	for i := range 100000 {
+		nl.Nodes = append(nl.Nodes, &Node{
+			Id:          fmt.Sprintf("node-%d", i),
+			Name:        fmt.Sprintf("package-%d", i),
+			Description: fmt.Sprintf("Library number %d of the component collection", i%1000),
+		})
+	}
+	idx := NewSearchIndex(nl)
+	b.Run("exact", func(b *testing.B) {
+		for range b.N {
+			idx.Search("package 4242")
+		}
+	})
+	b.Run("prefix", func(b *testing.B) {
+		for range b.N {
+			idx.Search("package 4242", WithSearchLimit(20))
+		}
+	})
+	b.Run("fuzzy", func(b *testing.B) {
+		for range b.N {
+			idx.Search("packge 4242", WithFuzziness(1), WithSearchLimit(20))
+		}
+	})
+}
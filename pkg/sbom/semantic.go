@@ -0,0 +1,99 @@
+package sbom
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// EphemeralNodeFields masks the node fields that change every time an SBOM
+// is generated without the component changing: the identifiers generated
+// by the tools and the timestamps.
+var EphemeralNodeFields = &fieldmaskpb.FieldMask{
+	Paths: slices.Concat([]string{"id"}, timestampFields),
+}
+
+// nodeFieldMaskTree parses the paths of a mask relative to the Node
+// message. Paths that don't match the Node fields have no effect.
+func nodeFieldMaskTree(mask *fieldmaskpb.FieldMask) fieldMaskTree {
+	md := (&Node{}).ProtoReflect().Descriptor()
+	tree := fieldMaskTree{}
+	for _, p := range mask.GetPaths() {
+		t, err := newFieldMaskTree(md, []string{p})
+		if err != nil {
+			continue
+		}
+		mergeFieldMaskTrees(tree, t)
+	}
+	return tree
+}
+
+// mergeFieldMaskTrees adds the paths of src to dst
+func mergeFieldMaskTrees(dst, src fieldMaskTree) {
+	for name, subtree := range src {
+		if _, ok := dst[name]; !ok {
+			dst[name] = fieldMaskTree{}
+		}
+		mergeFieldMaskTrees(dst[name], subtree)
+	}
+}
+
+// semanticString returns the flat string of the node with the fields in
+// the mask tree cleared
+func (n *Node) semanticString(ignore fieldMaskTree) string {
+	if len(ignore) == 0 {
+		return n.flatString()
+	}
+	masked, ok := proto.Clone(n).(*Node)
+	if !ok {
+		return n.flatString()
+	}
+	clearFields(masked.ProtoReflect(), ignore)
+	return masked.flatString()
+}
+
+// SemanticallyEquals compares the node to other leaving out the fields in
+// the ignore mask, so nodes that only differ in data regenerated by the
+// tools, like their IDs or timestamps, are equal. Paths in the mask are
+// relative to the Node and may traverse repeated fields, for example
+// "suppliers.email". Use EphemeralNodeFields to ignore the generated data.
+// A nil mask compares the nodes like Equal.
+func (n *Node) SemanticallyEquals(other *Node, ignore *fieldmaskpb.FieldMask) bool {
+	if n == nil || other == nil {
+		return n == other
+	}
+	tree := nodeFieldMaskTree(ignore)
+	return n.semanticString(tree) == other.semanticString(tree)
+}
+
+// WithIgnoredFields leaves the fields in the mask out of the node
+// comparisons of Diff and Dedupe. See Node.SemanticallyEquals.
+func WithIgnoredFields(mask *fieldmaskpb.FieldMask) NodeListOption {
+	return func(o *nodeListOptions) {
+		o.ignoredFields = nodeFieldMaskTree(mask)
+	}
+}
+
+// renameNodes replaces the IDs of the nodes in the list, the edges and the
+// root elements as mapped in renames
+func (nl *NodeList) renameNodes(renames map[string]string) {
+	rename := func(id string) string {
+		if newID, ok := renames[id]; ok {
+			return newID
+		}
+		return id
+	}
+	for _, n := range nl.Nodes {
+		n.Id = rename(n.Id)
+	}
+	for _, e := range nl.Edges {
+		e.From = rename(e.From)
+		for i := range e.To {
+			e.To[i] = rename(e.To[i])
+		}
+	}
+	for i := range nl.RootElements {
+		nl.RootElements[i] = rename(nl.RootElements[i])
+	}
+}
@@ -0,0 +1,186 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestSemanticallyEquals(t *testing.T) {
+	curl := func(id string, built int64, email, version string) *Node {
+		return &Node{
+			Id:        id,
+			Name:      "curl",
+			Version:   version,
+			BuildDate: timestamppb.New(time.Unix(built, 0)),
+			Suppliers: []*Person{{Name: "curl", Email: email}},
+		}
+	}
+	nestedMask := &fieldmaskpb.FieldMask{Paths: []string{"id", "build_date", "suppliers.email", "no_such_field"}}
+	for _, tc := range []struct {
+		name     string
+		sut      *Node
+		other    *Node
+		mask     *fieldmaskpb.FieldMask
+		expected bool
+	}{
+		{
+			name:  "no mask",
+			sut:   curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			other: curl("protobom-auto--000002", 1710000000, "daniel@example.com", "8.5.0"),
+		},
+		{
+			name:     "ephemeral fields",
+			sut:      curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			other:    curl("protobom-auto--000002", 1710000000, "daniel@example.com", "8.5.0"),
+			mask:     EphemeralNodeFields,
+			expected: true,
+		},
+		{
+			name:  "partial mask",
+			sut:   curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			other: curl("protobom-auto--000002", 1710000000, "daniel@example.com", "8.5.0"),
+			mask:  &fieldmaskpb.FieldMask{Paths: []string{"id"}},
+		},
+		{
+			name:  "nested field not in mask",
+			sut:   curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			other: curl("protobom-auto--000002", 1710000000, "other@example.com", "8.5.0"),
+			mask:  EphemeralNodeFields,
+		},
+		{
+			name:     "nested and unknown paths",
+			sut:      curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			other:    curl("protobom-auto--000002", 1710000000, "other@example.com", "8.5.0"),
+			mask:     nestedMask,
+			expected: true,
+		},
+		{
+			name:  "different version",
+			sut:   curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			other: curl("protobom-auto--000002", 1710000000, "other@example.com", "8.6.0"),
+			mask:  nestedMask,
+		},
+		{
+			name: "nil other",
+			sut:  curl("protobom-auto--000001", 1700000000, "daniel@example.com", "8.5.0"),
+			mask: nestedMask,
+		},
+		{
+			name:     "both nil",
+			mask:     nestedMask,
+			expected: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sut := proto.Clone(tc.sut)
+			other := proto.Clone(tc.other)
+			require.Equal(t, tc.expected, tc.sut.SemanticallyEquals(tc.other, tc.mask))
+
+			// The nodes are not modified
+			require.True(t, proto.Equal(sut, tc.sut))
+			require.True(t, proto.Equal(other, tc.other))
+		})
+	}
+}
+
+func TestDiffIgnoredFields(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    []NodeListOption
+		added   int
+		removed int
+		// changed maps the IDs of the changed nodes to their changed fields
+		changed map[string][]string
+	}{
+		{
+			name:    "no ignored fields",
+			added:   1,
+			removed: 1,
+			changed: map[string][]string{"a": {"build_date"}, "c": {"version", "build_date"}},
+		},
+		{
+			name:    "ephemeral fields",
+			opts:    []NodeListOption{WithIgnoredFields(EphemeralNodeFields)},
+			changed: map[string][]string{"c": {"version"}},
+		},
+		{
+			// Renamed nodes are only matched when the IDs are ignored
+			name:    "ids not ignored",
+			opts:    []NodeListOption{WithIgnoredFields(&fieldmaskpb.FieldMask{Paths: []string{"build_date"}})},
+			added:   1,
+			removed: 1,
+			changed: map[string][]string{"c": {"version"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl1 := &NodeList{Nodes: []*Node{
+				{Id: "a", Name: "a", Version: "1", BuildDate: timestamppb.New(time.Unix(1, 0))},
+				{Id: "gen-1", Name: "b", Version: "1"},
+				{Id: "c", Name: "c", Version: "1"},
+			}}
+			nl2 := &NodeList{Nodes: []*Node{
+				{Id: "a", Name: "a", Version: "1", BuildDate: timestamppb.New(time.Unix(2, 0))},
+				{Id: "gen-2", Name: "b", Version: "1"},
+				{Id: "c", Name: "c", Version: "2", BuildDate: timestamppb.New(time.Unix(2, 0))},
+			}}
+
+			d := nl1.Diff(nl2, tc.opts...)
+			require.Len(t, d.Added, tc.added)
+			require.Len(t, d.Removed, tc.removed)
+			require.Len(t, d.Changed, len(tc.changed))
+			for _, c := range d.Changed {
+				fields := []string{}
+				for _, f := range c.Fields {
+					fields = append(fields, f.Field)
+				}
+				require.ElementsMatch(t, tc.changed[c.After.Id], fields)
+			}
+		})
+	}
+}
+
+func TestDedupeIgnoredFields(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		opts  []NodeListOption
+		nodes []string
+		to    []string
+	}{
+		{
+			name:  "no ignored fields",
+			nodes: []string{"root", "gen-1", "gen-2", "gen-3"},
+			to:    []string{"gen-1", "gen-2", "gen-3"},
+		},
+		{
+			name:  "ephemeral fields",
+			opts:  []NodeListOption{WithIgnoredFields(EphemeralNodeFields)},
+			nodes: []string{"root", "gen-1", "gen-3"},
+			to:    []string{"gen-1", "gen-3"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{
+				Nodes: []*Node{
+					{Id: "root", Name: "root"},
+					{Id: "gen-1", Name: "lib", Version: "1", BuildDate: timestamppb.New(time.Unix(1, 0))},
+					{Id: "gen-2", Name: "lib", Version: "1", BuildDate: timestamppb.New(time.Unix(2, 0))},
+					{Id: "gen-3", Name: "lib", Version: "2"},
+				},
+				Edges: []*Edge{
+					{Type: Edge_dependsOn, From: "root", To: []string{"gen-1", "gen-2", "gen-3"}},
+				},
+				RootElements: []string{"root"},
+			}
+
+			nl.Dedupe(tc.opts...)
+			require.Equal(t, tc.nodes, ids(nl.Nodes))
+			require.Len(t, nl.Edges, 1)
+			require.ElementsMatch(t, tc.to, nl.Edges[0].To)
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package sbom
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scoring methods of the vulnerability ratings, as named by CycloneDX
+const (
+	ScoringMethodCVSSv2  = "CVSSv2"
+	ScoringMethodCVSSv3  = "CVSSv3"
+	ScoringMethodCVSSv31 = "CVSSv31"
+	ScoringMethodCVSSv4  = "CVSSv4"
+)
+
+// methodPrecedence ranks the scoring methods when choosing the rating that
+// determines the canonical severity. Newer CVSS versions are preferred, all
+// other methods rank the same, below CVSS.
+var methodPrecedence = map[string]int{
+	ScoringMethodCVSSv4:  4,
+	ScoringMethodCVSSv31: 3,
+	ScoringMethodCVSSv3:  2,
+	ScoringMethodCVSSv2:  1,
+}
+
+// severityNames maps the textual severities used by vulnerability sources
+// to the canonical severity. Vendor specific names are mapped to the closest
+// CVSS qualitative rating.
+var severityNames = map[string]Vulnerability_Severity{
+	"none":          Vulnerability_NONE,
+	"info":          Vulnerability_INFO,
+	"informational": Vulnerability_INFO,
+	"negligible":    Vulnerability_INFO,
+	"unimportant":   Vulnerability_INFO,
+	"low":           Vulnerability_LOW,
+	"minor":         Vulnerability_LOW,
+	"medium":        Vulnerability_MEDIUM,
+	"moderate":      Vulnerability_MEDIUM,
+	"high":          Vulnerability_HIGH,
+	"important":     Vulnerability_HIGH,
+	"major":         Vulnerability_HIGH,
+	"critical":      Vulnerability_CRITICAL,
+}
+
+// SeverityFromString returns the canonical severity of a textual severity,
+// such as "High", "moderate" or "important". It returns UNKNOWN_SEVERITY if
+// the severity is not recognized.
+func SeverityFromString(severity string) Vulnerability_Severity {
+	return severityNames[strings.ToLower(strings.TrimSpace(severity))]
+}
+
+// SeverityFromScore returns the canonical severity of a CVSS base score
+// computed with method. CVSS v2 has no critical or none ratings, its high
+// rating starts at 7.0. Other methods use the CVSS v3 and v4 ranges.
+func SeverityFromScore(method string, score float64) Vulnerability_Severity {
+	if score < 0 || score > 10 || math.IsNaN(score) {
+		return Vulnerability_UNKNOWN_SEVERITY
+	}
+	if method == ScoringMethodCVSSv2 {
+		switch {
+		case score >= 7:
+			return Vulnerability_HIGH
+		case score >= 4:
+			return Vulnerability_MEDIUM
+		default:
+			return Vulnerability_LOW
+		}
+	}
+	switch {
+	case score >= 9:
+		return Vulnerability_CRITICAL
+	case score >= 7:
+		return Vulnerability_HIGH
+	case score >= 4:
+		return Vulnerability_MEDIUM
+	case score > 0:
+		return Vulnerability_LOW
+	default:
+		return Vulnerability_NONE
+	}
+}
+
+// ScoringMethodFromVector infers the CVSS version of a vector string. CVSS v3
+// and v4 vectors carry their version prefix, v2 vectors are recognized by
+// their authentication metric. It returns an empty string if the version
+// can't be inferred.
+func ScoringMethodFromVector(vector string) string {
+	switch {
+	case strings.HasPrefix(vector, "CVSS:4.0/"):
+		return ScoringMethodCVSSv4
+	case strings.HasPrefix(vector, "CVSS:3.1/"):
+		return ScoringMethodCVSSv31
+	case strings.HasPrefix(vector, "CVSS:3.0/"):
+		return ScoringMethodCVSSv3
+	case strings.Contains(vector, "Au:"):
+		return ScoringMethodCVSSv2
+	default:
+		return ""
+	}
+}
+
+// ScoringMethod returns the scoring method of the rating, inferred from the
+// vector when the method is not set.
+func (r *Vulnerability_Rating) ScoringMethod() string {
+	if r.GetMethod() != "" {
+		return r.GetMethod()
+	}
+	return ScoringMethodFromVector(r.GetVector())
+}
+
+// NormalizedSeverity returns the canonical severity of the rating. The score
+// takes precedence over the textual severity of the source as it is
+// unambiguous.
+func (r *Vulnerability_Rating) NormalizedSeverity() Vulnerability_Severity {
+	if r.Score != nil {
+		if s := SeverityFromScore(r.ScoringMethod(), r.GetScore()); s != Vulnerability_UNKNOWN_SEVERITY {
+			return s
+		}
+	}
+	return SeverityFromString(r.GetSeverity())
+}
+
+// NormalizeSeverity sets the canonical severity of the vulnerability from
+// its ratings and returns it. The rating of the most recent CVSS version is
+// preferred and, among ratings of the same method, the highest severity. If
+// no rating has a known severity, the severity is left unchanged.
+//
+// The ratings are not modified so the severities of the sources are kept.
+func (v *Vulnerability) NormalizeSeverity() Vulnerability_Severity {
+	best, bestRank := Vulnerability_UNKNOWN_SEVERITY, -1
+	for _, r := range v.GetRatings() {
+		s := r.NormalizedSeverity()
+		if s == Vulnerability_UNKNOWN_SEVERITY {
+			continue
+		}
+		rank := methodPrecedence[r.ScoringMethod()]
+		if rank > bestRank || (rank == bestRank && s > best) {
+			best, bestRank = s, rank
+		}
+	}
+	if best != Vulnerability_UNKNOWN_SEVERITY {
+		v.Severity = best
+	}
+	return v.Severity
+}
+
+// NormalizeSeverities normalizes the severity of all the vulnerabilities in
+// the document.
+func (d *Document) NormalizeSeverities() {
+	for _, v := range d.GetVulnerabilities() {
+		v.NormalizeSeverity()
+	}
+}
+
+// ParseCWE parses a CWE identifier, such as "CWE-79" or "79", returning its
+// number.
+func ParseCWE(id string) (int32, error) {
+	s := strings.TrimSpace(id)
+	if len(s) > 4 && strings.EqualFold(s[:4], "CWE-") {
+		s = s[4:]
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid CWE identifier %q", id)
+	}
+	return int32(n), nil
+}
+
+// CWEIDs returns the weaknesses of the vulnerability as CWE identifiers,
+// eg "CWE-79".
+func (v *Vulnerability) CWEIDs() []string {
+	ret := []string{}
+	for _, c := range v.GetCwes() {
+		ret = append(ret, fmt.Sprintf("CWE-%d", c))
+	}
+	return ret
+}
+
+// AddCWEs parses the CWE identifiers and adds the missing ones to the
+// weaknesses of the vulnerability.
+func (v *Vulnerability) AddCWEs(ids ...string) error {
+	for _, id := range ids {
+		n, err := ParseCWE(id)
+		if err != nil {
+			return err
+		}
+		v.Cwes = appendMissing(v.Cwes, n)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityFromScore(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		method   string
+		score    float64
+		severity Vulnerability_Severity
+	}{
+		{"cvss v3.1 none", ScoringMethodCVSSv31, 0, Vulnerability_NONE},
+		{"cvss v3.1 low", ScoringMethodCVSSv31, 3.9, Vulnerability_LOW},
+		{"cvss v3.1 medium", ScoringMethodCVSSv31, 4.0, Vulnerability_MEDIUM},
+		{"cvss v3.1 high", ScoringMethodCVSSv31, 8.9, Vulnerability_HIGH},
+		{"cvss v4 critical", ScoringMethodCVSSv4, 9.0, Vulnerability_CRITICAL},
+		{"cvss v2 low", ScoringMethodCVSSv2, 0, Vulnerability_LOW},
+		{"cvss v2 high", ScoringMethodCVSSv2, 10, Vulnerability_HIGH},
+		{"out of range", "", 11, Vulnerability_UNKNOWN_SEVERITY},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.severity, SeverityFromScore(tc.method, tc.score))
+		})
+	}
+}
+
+func TestSeverityFromString(t *testing.T) {
+	for s, severity := range map[string]Vulnerability_Severity{
+		"CRITICAL":   Vulnerability_CRITICAL,
+		"Important":  Vulnerability_HIGH,
+		"moderate":   Vulnerability_MEDIUM,
+		" low ":      Vulnerability_LOW,
+		"negligible": Vulnerability_INFO,
+		"none":       Vulnerability_NONE,
+		"unknown":    Vulnerability_UNKNOWN_SEVERITY,
+	} {
+		t.Run(s, func(t *testing.T) {
+			require.Equal(t, severity, SeverityFromString(s))
+		})
+	}
+}
+
+func TestScoringMethodFromVector(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		vector string
+		method string
+	}{
+		{"cvss v4", "CVSS:4.0/AV:N/AC:L/AT:N/PR:N/UI:N/VC:H/VI:H/VA:H/SC:N/SI:N/SA:N", ScoringMethodCVSSv4},
+		{"cvss v3.1", "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", ScoringMethodCVSSv31},
+		{"cvss v3.0", "CVSS:3.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", ScoringMethodCVSSv3},
+		{"cvss v2", "AV:N/AC:M/Au:N/C:P/I:P/A:P", ScoringMethodCVSSv2},
+		{"empty", "", ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.method, ScoringMethodFromVector(tc.vector))
+		})
+	}
+}
+
+func TestNormalizeSeverity(t *testing.T) {
+	score := func(f float64) *float64 { return &f }
+	for _, tc := range []struct {
+		name     string
+		sut      *Vulnerability
+		severity Vulnerability_Severity
+	}{
+		{
+			name: "cvss v3.1 takes precedence",
+			sut: &Vulnerability{Ratings: []*Vulnerability_Rating{
+				{Source: "Red Hat", Severity: "important"},
+				{Source: "NVD", Score: score(9.3), Vector: "AV:N/AC:M/Au:N/C:C/I:C/A:C"},
+				{Source: "NVD", Score: score(6.5), Method: ScoringMethodCVSSv31},
+			}},
+			severity: Vulnerability_MEDIUM,
+		},
+		{
+			name: "cvss v2 over vendor severities",
+			sut: &Vulnerability{Ratings: []*Vulnerability_Rating{
+				{Source: "Red Hat", Severity: "low"},
+				{Source: "NVD", Score: score(9.3), Vector: "AV:N/AC:M/Au:N/C:C/I:C/A:C"},
+			}},
+			severity: Vulnerability_HIGH,
+		},
+		{
+			name: "highest vendor severity",
+			sut: &Vulnerability{Ratings: []*Vulnerability_Rating{
+				{Source: "Debian", Severity: "low"},
+				{Source: "Red Hat", Severity: "moderate"},
+			}},
+			severity: Vulnerability_MEDIUM,
+		},
+		{
+			name:     "no known severities",
+			sut:      &Vulnerability{Severity: Vulnerability_HIGH, Ratings: []*Vulnerability_Rating{{Severity: "unknown"}}},
+			severity: Vulnerability_HIGH,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			severities := []string{}
+			for _, r := range tc.sut.Ratings {
+				severities = append(severities, r.Severity)
+			}
+			require.Equal(t, tc.severity, tc.sut.NormalizeSeverity())
+			require.Equal(t, tc.severity, tc.sut.Severity)
+
+			// The ratings are not modified
+			for i, r := range tc.sut.Ratings {
+				require.Equal(t, severities[i], r.Severity)
+			}
+		})
+	}
+}
+
+func TestParseCWE(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		input   string
+		cwe     int32
+		mustErr bool
+	}{
+		{name: "prefixed", input: "CWE-79", cwe: 79},
+		{name: "lowercase prefix", input: "cwe-502", cwe: 502},
+		{name: "nvd placeholder", input: "NVD-CWE-Other", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			n, err := ParseCWE(tc.input)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.cwe, n)
+		})
+	}
+}
+
+func TestAddCWEs(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		cwes    []string
+		mustErr bool
+		ids     []int32
+		names   []string
+	}{
+		{
+			name:  "deduplicated",
+			cwes:  []string{"CWE-79", "89", "CWE-79"},
+			ids:   []int32{79, 89},
+			names: []string{"CWE-79", "CWE-89"},
+		},
+		{
+			name:    "invalid",
+			cwes:    []string{"bogus"},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := &Vulnerability{}
+			err := v.AddCWEs(tc.cwes...)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.ids, v.Cwes)
+			require.Equal(t, tc.names, v.CWEIDs())
+		})
+	}
+}
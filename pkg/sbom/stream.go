@@ -0,0 +1,169 @@
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// StreamEncoding is the encoding of a stream carrying multiple documents
+type StreamEncoding string
+
+const (
+	// StreamEncodingProtobuf encodes each document as a protobuf message
+	// prefixed by its length as a varint.
+	StreamEncodingProtobuf StreamEncoding = "protobuf"
+
+	// StreamEncodingJSONL encodes each document as protobuf JSON on its
+	// own line (JSON Lines).
+	StreamEncodingJSONL StreamEncoding = "jsonl"
+)
+
+// DocumentEncoder writes documents to a multi-document stream
+type DocumentEncoder struct {
+	w        io.Writer
+	encoding StreamEncoding
+}
+
+// NewDocumentEncoder returns an encoder writing documents to w
+func NewDocumentEncoder(w io.Writer, encoding StreamEncoding) (*DocumentEncoder, error) {
+	switch encoding {
+	case StreamEncodingProtobuf, StreamEncodingJSONL:
+	default:
+		return nil, fmt.Errorf("unsupported stream encoding %q", encoding)
+	}
+	return &DocumentEncoder{w: w, encoding: encoding}, nil
+}
+
+// Encode writes a document to the stream
+func (e *DocumentEncoder) Encode(doc *Document) error {
+	if doc == nil {
+		return errors.New("unable to encode nil document")
+	}
+
+	if e.encoding == StreamEncodingProtobuf {
+		if _, err := protodelim.MarshalTo(e.w, doc); err != nil {
+			return fmt.Errorf("writing document: %w", err)
+		}
+		return nil
+	}
+
+	data, err := protojson.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshaling document: %w", err)
+	}
+	// protojson randomizes its whitespace, compact the output to get
+	// stable lines starting with the object brace.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, data); err != nil {
+		return fmt.Errorf("compacting document: %w", err)
+	}
+	buf.WriteByte('\n')
+	if _, err := e.w.Write(buf.Bytes()); err != nil {
+		return fmt.Errorf("writing document: %w", err)
+	}
+	return nil
+}
+
+// DocumentDecoder reads the documents in a multi-document stream
+type DocumentDecoder struct {
+	r        *bufio.Reader
+	encoding StreamEncoding
+}
+
+// NewDocumentDecoder returns a decoder reading documents from r. If the
+// encoding is empty, it is detected from the start of the stream.
+func NewDocumentDecoder(r io.Reader, encoding StreamEncoding) (*DocumentDecoder, error) {
+	br := bufio.NewReader(r)
+	if encoding == "" {
+		var err error
+		encoding, err = detectStreamEncoding(br)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch encoding {
+	case StreamEncodingProtobuf, StreamEncodingJSONL:
+	default:
+		return nil, fmt.Errorf("unsupported stream encoding %q", encoding)
+	}
+	return &DocumentDecoder{r: br, encoding: encoding}, nil
+}
+
+// Encoding returns the encoding of the stream
+func (d *DocumentDecoder) Encoding() StreamEncoding {
+	return d.encoding
+}
+
+// Decode reads the next document from the stream. It returns io.EOF when
+// there are no more documents.
+func (d *DocumentDecoder) Decode() (*Document, error) {
+	doc := &Document{}
+	if d.encoding == StreamEncodingProtobuf {
+		// SBOMs can be large, lift the default size limit
+		err := protodelim.UnmarshalOptions{MaxSize: -1}.UnmarshalFrom(d.r, doc)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("reading document: %w", err)
+		}
+		return doc, nil
+	}
+
+	for {
+		line, err := d.r.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading document: %w", err)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			// Skip blank lines
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			continue
+		}
+		if err := protojson.Unmarshal(line, doc); err != nil {
+			return nil, fmt.Errorf("unmarshaling document: %w", err)
+		}
+		return doc, nil
+	}
+}
+
+// DecodeAll reads all the remaining documents in the stream
+func (d *DocumentDecoder) DecodeAll() ([]*Document, error) {
+	ret := []*Document{}
+	for {
+		doc, err := d.Decode()
+		if errors.Is(err, io.EOF) {
+			return ret, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("decoding document #%d: %w", len(ret)+1, err)
+		}
+		ret = append(ret, doc)
+	}
+}
+
+// detectStreamEncoding peeks at the start of the stream to determine its
+// encoding. JSON lines start with an object while the first byte of a
+// protobuf stream is a varint length. A length of 123 reads as a brace, but
+// it is followed by a tag for a field not defined in Document.
+func detectStreamEncoding(br *bufio.Reader) (StreamEncoding, error) {
+	data, err := br.Peek(2)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("detecting stream encoding: %w", err)
+	}
+	if bytes.Equal(data, []byte(`{"`)) || bytes.Equal(data, []byte("{}")) {
+		return StreamEncodingJSONL, nil
+	}
+	return StreamEncodingProtobuf, nil
+}
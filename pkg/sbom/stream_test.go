@@ -0,0 +1,102 @@
+package sbom
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDocumentStream(t *testing.T) {
+	docs := []*Document{}
+	for i := range 3 {
+		doc := NewDocument()
+		doc.Metadata.Id = fmt.Sprintf("doc-%d", i)
+		doc.NodeList.AddRootNode(&Node{Id: fmt.Sprintf("node-%d", i), Name: "test"})
+		docs = append(docs, doc)
+	}
+
+	for _, encoding := range []StreamEncoding{StreamEncodingProtobuf, StreamEncodingJSONL} {
+		t.Run(string(encoding), func(t *testing.T) {
+			var buf bytes.Buffer
+			enc, err := NewDocumentEncoder(&buf, encoding)
+			require.NoError(t, err)
+			for _, doc := range docs {
+				require.NoError(t, enc.Encode(doc))
+			}
+			require.Error(t, enc.Encode(nil))
+
+			if encoding == StreamEncodingJSONL {
+				require.Equal(t, 3, strings.Count(buf.String(), "\n"))
+			}
+
+			// Decode with autodetection
+			dec, err := NewDocumentDecoder(bytes.NewReader(buf.Bytes()), "")
+			require.NoError(t, err)
+			require.Equal(t, encoding, dec.Encoding())
+			res, err := dec.DecodeAll()
+			require.NoError(t, err)
+			require.Len(t, res, len(docs))
+			for i := range docs {
+				require.True(t, proto.Equal(docs[i], res[i]))
+			}
+
+			_, err = dec.Decode()
+			require.True(t, errors.Is(err, io.EOF))
+		})
+	}
+}
+
+func TestDocumentStreamErrors(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewDocumentEncoder(&buf, StreamEncodingProtobuf)
+	require.NoError(t, err)
+	require.NoError(t, enc.Encode(NewDocument()))
+	truncated := buf.Bytes()[:buf.Len()-1]
+
+	for _, tc := range []struct {
+		name     string
+		data     []byte
+		encoding StreamEncoding
+		docs     int
+		mustErr  bool
+	}{
+		// Empty streams have no documents
+		{name: "empty stream", data: []byte{}},
+		// Blank lines are skipped
+		{name: "blank lines", data: []byte("{}\n\n{}\n"), docs: 2},
+		{name: "invalid line", data: []byte("{}\n{\"bad\n"), encoding: StreamEncodingJSONL, mustErr: true},
+		{name: "truncated protobuf", data: truncated, encoding: StreamEncodingProtobuf, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dec, err := NewDocumentDecoder(bytes.NewReader(tc.data), tc.encoding)
+			require.NoError(t, err)
+			res, err := dec.DecodeAll()
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res, tc.docs)
+		})
+	}
+}
+
+func TestDocumentStreamUnknownEncoding(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		new  func() error
+	}{
+		{name: "encoder", new: func() error { _, err := NewDocumentEncoder(io.Discard, "yaml"); return err }},
+		{name: "decoder", new: func() error { _, err := NewDocumentDecoder(strings.NewReader(""), "yaml"); return err }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Error(t, tc.new())
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// DefaultOrganizationSuffixes are the legal entity designators ignored when
+// comparing organization names, eg "Acme, Inc." is the same as "ACME".
+var DefaultOrganizationSuffixes = []string{
+	"inc", "incorporated", "llc", "llp", "ltd", "limited", "corp", "corporation",
+	"co", "company", "gmbh", "ag", "sa", "sas", "srl", "bv", "nv", "plc", "oy",
+	"ab", "as", "kk", "pty", "lp",
+}
+
+// Vendor is the canonical entry of an organization in a VendorRegistry
+type Vendor struct {
+	// Name is the canonical name of the organization
+	Name string `json:"name"`
+
+	// Aliases are other names the organization is known by
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Domains are the internet domains of the organization, suppliers with
+	// emails or URLs in them are matched to the vendor
+	Domains []string `json:"domains,omitempty"`
+
+	// URL and Email are set in the suppliers that have none
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// VendorRegistry maps the free-form supplier names found in SBOMs to
+// canonical organizations. Suppliers are matched by name, ignoring case,
+// punctuation and legal entity suffixes, by the domain of their email or
+// URL and, failing that, by names within MaxDistance edits of a vendor name.
+type VendorRegistry struct {
+	// MaxDistance is the number of edits tolerated between a supplier name
+	// and a vendor name. Zero disables the fuzzy comparison.
+	MaxDistance int
+
+	// Suffixes are the legal entity designators ignored in the names
+	Suffixes []string
+
+	vendors []*Vendor
+	names   map[string]*Vendor
+	domains map[string]*Vendor
+}
+
+// NewVendorRegistry returns a registry with the vendors, tolerating two
+// edits in the names and ignoring the default organization suffixes.
+func NewVendorRegistry(vendors ...*Vendor) *VendorRegistry {
+	r := &VendorRegistry{
+		MaxDistance: 2,
+		Suffixes:    DefaultOrganizationSuffixes,
+	}
+	for _, v := range vendors {
+		r.Add(v)
+	}
+	return r
+}
+
+// LoadVendorRegistry reads a registry in JSON, a list of vendors under the
+// "vendors" key.
+func LoadVendorRegistry(rd io.Reader) (*VendorRegistry, error) {
+	f := struct {
+		Vendors []*Vendor `json:"vendors"`
+	}{}
+	if err := json.NewDecoder(rd).Decode(&f); err != nil {
+		return nil, fmt.Errorf("decoding vendor registry: %w", err)
+	}
+	for i, v := range f.Vendors {
+		if strings.TrimSpace(v.Name) == "" {
+			return nil, fmt.Errorf("vendor #%d has no name", i+1)
+		}
+	}
+	return NewVendorRegistry(f.Vendors...), nil
+}
+
+// Add registers a vendor. Names and domains already registered are
+// reassigned to the new vendor.
+func (r *VendorRegistry) Add(v *Vendor) {
+	if v == nil {
+		return
+	}
+	if r.names == nil {
+		r.names = map[string]*Vendor{}
+		r.domains = map[string]*Vendor{}
+	}
+	r.vendors = append(r.vendors, v)
+	for _, name := range append([]string{v.Name}, v.Aliases...) {
+		if key := r.nameKey(name); key != "" {
+			r.names[key] = v
+		}
+	}
+	for _, d := range v.Domains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			r.domains[strings.TrimPrefix(d, "www.")] = v
+		}
+	}
+}
+
+// Vendors returns the vendors in the registry
+func (r *VendorRegistry) Vendors() []*Vendor {
+	return r.vendors
+}
+
+// nameKey normalizes an organization name for comparison
+func (r *VendorRegistry) nameKey(name string) string {
+	words := searchTerms(name)
+	words = slices.DeleteFunc(words, func(w string) bool { return w == "the" })
+	for len(words) > 1 && slices.Contains(r.Suffixes, words[len(words)-1]) {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, "")
+}
+
+// Match returns the vendor of a supplier or nil if it is not registered or
+// its name is equally close to more than one vendor.
+func (r *VendorRegistry) Match(p *Person) *Vendor {
+	if p == nil {
+		return nil
+	}
+	key := r.nameKey(p.GetName())
+	if v, ok := r.names[key]; ok {
+		return v
+	}
+	if v := r.matchDomain(p); v != nil {
+		return v
+	}
+	if r.MaxDistance <= 0 || len(key) < minFuzzyNameLength {
+		return nil
+	}
+
+	var match *Vendor
+	best := r.MaxDistance + 1
+	for _, name := range slices.Sorted(maps.Keys(r.names)) {
+		if len(name) < minFuzzyNameLength {
+			continue
+		}
+		d := editDistance([]rune(key), []rune(name), r.MaxDistance)
+		switch {
+		case d < best:
+			best, match = d, r.names[name]
+		case d == best && r.names[name] != match:
+			match = nil
+		}
+	}
+	return match
+}
+
+// matchDomain returns the vendor owning the domain of the supplier email or
+// URL, or one of its parent domains
+func (r *VendorRegistry) matchDomain(p *Person) *Vendor {
+	hosts := []string{}
+	if _, domain, ok := strings.Cut(p.GetEmail(), "@"); ok {
+		hosts = append(hosts, domain)
+	}
+	if u, err := url.Parse(p.GetUrl()); err == nil && u.Hostname() != "" {
+		hosts = append(hosts, u.Hostname())
+	}
+	for _, host := range hosts {
+		host = strings.TrimPrefix(strings.ToLower(host), "www.")
+		for host != "" {
+			if v, ok := r.domains[host]; ok {
+				return v
+			}
+			_, host, _ = strings.Cut(host, ".")
+		}
+	}
+	return nil
+}
+
+// SupplierReport records the changes of NormalizeSuppliers
+type SupplierReport struct {
+	// Normalized maps the supplier names found to their canonical name
+	Normalized map[string]string
+
+	// Unmatched lists the supplier names not found in the registry
+	Unmatched []string
+}
+
+// NormalizeSuppliers replaces the suppliers of the nodes found in the
+// registry with the canonical vendor, so all the nodes of an organization
+// refer to it with the same name. Contact data of the suppliers is kept,
+// the vendor URL and email are only set when missing. Suppliers that become
+// duplicates of others in the same node are removed.
+func (nl *NodeList) NormalizeSuppliers(r *VendorRegistry) *SupplierReport {
+	report := &SupplierReport{Normalized: map[string]string{}, Unmatched: []string{}}
+	for _, n := range nl.GetNodes() {
+		seen := map[*Vendor]struct{}{}
+		n.Suppliers = slices.DeleteFunc(n.Suppliers, func(p *Person) bool {
+			v := r.Match(p)
+			if v == nil {
+				if p.GetName() != "" && !slices.Contains(report.Unmatched, p.GetName()) {
+					report.Unmatched = append(report.Unmatched, p.GetName())
+				}
+				return false
+			}
+			report.Normalized[p.GetName()] = v.Name
+			if _, ok := seen[v]; ok {
+				return true
+			}
+			seen[v] = struct{}{}
+			p.Name, p.IsOrg = v.Name, true
+			if p.Url == "" {
+				p.Url = v.URL
+			}
+			if p.Email == "" {
+				p.Email = v.Email
+			}
+			return false
+		})
+	}
+	slices.Sort(report.Unmatched)
+	return report
+}
+
+// ExtractForSupplier returns a standalone copy of the document containing
+// only the nodes supplied by the vendor, for example to send it inquiries
+// about its components. Suppliers are matched to the vendor by name, alias
+// or domain as in VendorRegistry.Match, without tolerating edits.
+//
+// The nodes of other suppliers are collapsed out of the graph as in
+// NodeList.Prune, so the vendor components keep the dependency structure
+// connecting them and the nearest ones replace the root elements of the
+// original document. Vulnerabilities are kept only for the extracted
+// nodes. The document is not modified.
+func (d *Document) ExtractForSupplier(supplier *Vendor) (*Document, error) {
+	if supplier == nil || strings.TrimSpace(supplier.Name) == "" {
+		return nil, errors.New("supplier has no name")
+	}
+	if d.GetNodeList() == nil {
+		return nil, errors.New("document has no nodes")
+	}
+
+	r := NewVendorRegistry(supplier)
+	r.MaxDistance = 0
+	supplied := func(n *Node) bool {
+		return slices.ContainsFunc(n.GetSuppliers(), func(p *Person) bool {
+			return r.Match(p) != nil
+		})
+	}
+	if !slices.ContainsFunc(d.NodeList.GetNodes(), supplied) {
+		return nil, fmt.Errorf("no nodes supplied by %q", supplier.Name)
+	}
+
+	doc := d.Clone()
+	if doc.Metadata == nil {
+		doc.Metadata = &Metadata{}
+	}
+	doc.NodeList.Prune(func(n *Node) bool { return !supplied(n) }, true)
+
+	nodes := doc.NodeList.indexNodes()
+	vulns := []*Vulnerability{}
+	for _, v := range doc.Vulnerabilities {
+		v.Affects = slices.DeleteFunc(v.Affects, func(id string) bool {
+			_, ok := nodes[id]
+			return !ok
+		})
+		if len(v.Affects) == 0 {
+			continue
+		}
+		maps.DeleteFunc(v.NodeAnalyses, func(id string, _ *Vulnerability_Analysis) bool {
+			_, ok := nodes[id]
+			return !ok
+		})
+		vulns = append(vulns, v)
+	}
+	doc.Vulnerabilities = vulns
+	doc.GC()
+
+	if doc.Metadata.Id != "" {
+		doc.Metadata.Id = fmt.Sprintf("%s-%s", doc.Metadata.Id, r.nameKey(supplier.Name))
+	}
+	if doc.Metadata.Name != "" {
+		doc.Metadata.Name = fmt.Sprintf("%s (%s)", doc.Metadata.Name, supplier.Name)
+	}
+	return doc, nil
+}
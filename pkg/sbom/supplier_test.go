@@ -0,0 +1,296 @@
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendorRegistryMatch(t *testing.T) {
+	r := NewVendorRegistry(
+		&Vendor{Name: "Acme Corporation", Aliases: []string{"ACME Labs"}, Domains: []string{"acme.com"}, URL: "https://acme.com"},
+		&Vendor{Name: "Globex", Domains: []string{"globex.io"}},
+		&Vendor{Name: "Initech", Email: "oss@initech.example"},
+		&Vendor{Name: "Initrode"},
+	)
+	for name, tc := range map[string]struct {
+		person   *Person
+		expected string
+	}{
+		"exact":            {&Person{Name: "Globex"}, "Globex"},
+		"legal suffix":     {&Person{Name: "ACME, Inc."}, "Acme Corporation"},
+		"the and case":     {&Person{Name: "The Acme Corp"}, "Acme Corporation"},
+		"alias":            {&Person{Name: "acme labs"}, "Acme Corporation"},
+		"email domain":     {&Person{Name: "Jane Doe", Email: "jane@dev.acme.com"}, "Acme Corporation"},
+		"url domain":       {&Person{Name: "GX", Url: "https://www.globex.io/oss"}, "Globex"},
+		"typo":             {&Person{Name: "Globexx LLC"}, "Globex"},
+		"ambiguous":        {&Person{Name: "Initroch"}, ""},
+		"closest wins":     {&Person{Name: "Initeck"}, "Initech"},
+		"short not fuzzed": {&Person{Name: "Glob"}, ""},
+		"unknown":          {&Person{Name: "Umbrella"}, ""},
+		"nil":              {nil, ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			v := r.Match(tc.person)
+			if tc.expected == "" {
+				require.Nil(t, v)
+				return
+			}
+			require.NotNil(t, v)
+			require.Equal(t, tc.expected, v.Name)
+		})
+	}
+}
+
+func TestLoadVendorRegistry(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		data    string
+		mustErr bool
+		vendors int
+		// person is matched to the vendor named match
+		person *Person
+		match  string
+	}{
+		{
+			name:    "vendor with aliases",
+			data:    `{"vendors": [{"name": "Acme", "aliases": ["Acme Labs"], "domains": ["acme.com"]}]}`,
+			vendors: 1,
+			person:  &Person{Name: "acme labs inc"},
+			match:   "Acme",
+		},
+		{
+			name:    "vendor domains",
+			data:    `{"vendors": [{"name": "Acme", "domains": ["acme.com"]}, {"name": "Globex"}]}`,
+			vendors: 2,
+			person:  &Person{Name: "Jane Doe", Email: "jane@acme.com"},
+			match:   "Acme",
+		},
+		{
+			name:    "vendor without name",
+			data:    `{"vendors": [{"aliases": ["Acme"]}]}`,
+			mustErr: true,
+		},
+		{
+			name:    "invalid json",
+			data:    `vendors`,
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := LoadVendorRegistry(strings.NewReader(tc.data))
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, r.Vendors(), tc.vendors)
+			v := r.Match(tc.person)
+			require.NotNil(t, v)
+			require.Equal(t, tc.match, v.Name)
+		})
+	}
+}
+
+func TestNormalizeSuppliers(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		suppliers  []*Person
+		expected   []*Person
+		normalized map[string]string
+		unmatched  []string
+	}{
+		{
+			name:      "merged into one vendor",
+			suppliers: []*Person{{Name: "ACME Inc", Email: "sales@acme.com"}, {Name: "Acme Labs"}},
+			expected: []*Person{
+				{Name: "Acme Corporation", IsOrg: true, Email: "sales@acme.com", Url: "https://acme.com"},
+			},
+			normalized: map[string]string{"ACME Inc": "Acme Corporation", "Acme Labs": "Acme Corporation"},
+			unmatched:  []string{},
+		},
+		{
+			name:      "unmatched supplier is kept",
+			suppliers: []*Person{{Name: "acme corp."}, {Name: "Umbrella"}},
+			expected: []*Person{
+				{Name: "Acme Corporation", IsOrg: true, Url: "https://acme.com"},
+				{Name: "Umbrella"},
+			},
+			normalized: map[string]string{"acme corp.": "Acme Corporation"},
+			unmatched:  []string{"Umbrella"},
+		},
+		{
+			name:       "vendor email",
+			suppliers:  []*Person{{Name: "Initech"}},
+			expected:   []*Person{{Name: "Initech", IsOrg: true, Email: "oss@initech.example"}},
+			normalized: map[string]string{"Initech": "Initech"},
+			unmatched:  []string{},
+		},
+		{
+			name:       "no suppliers",
+			normalized: map[string]string{},
+			unmatched:  []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewVendorRegistry(
+				&Vendor{Name: "Acme Corporation", Aliases: []string{"ACME Labs"}, Domains: []string{"acme.com"}, URL: "https://acme.com"},
+				&Vendor{Name: "Initech", Email: "oss@initech.example"},
+			)
+			nl := &NodeList{Nodes: []*Node{{Id: "a", Suppliers: tc.suppliers}}}
+			report := nl.NormalizeSuppliers(r)
+			require.Equal(t, tc.normalized, report.Normalized)
+			require.Equal(t, tc.unmatched, report.Unmatched)
+			require.Equal(t, tc.expected, nl.Nodes[0].Suppliers)
+		})
+	}
+}
+
+func TestNormalizeVendors(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     *NormalizeOptions
+		supplier string
+		expected string
+	}{
+		{
+			name:     "known vendor",
+			opts:     &NormalizeOptions{Vendors: NewVendorRegistry(&Vendor{Name: "Globex", Domains: []string{"globex.io"}})},
+			supplier: "Globex, Inc.",
+			expected: "Globex",
+		},
+		{
+			name:     "unknown vendor",
+			opts:     &NormalizeOptions{Vendors: NewVendorRegistry(&Vendor{Name: "Globex", Domains: []string{"globex.io"}})},
+			supplier: "Umbrella",
+			expected: "Umbrella",
+		},
+		{
+			name:     "no vendor registry",
+			opts:     &NormalizeOptions{},
+			supplier: "Globex, Inc.",
+			expected: "Globex, Inc.",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.NodeList.AddNode(&Node{Id: "a", Suppliers: []*Person{{Name: tc.supplier}}})
+			doc.Normalize(tc.opts)
+			require.Equal(t, tc.expected, doc.NodeList.Nodes[0].Suppliers[0].Name)
+		})
+	}
+}
+
+func TestExtractForSupplier(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		vendor  *Vendor
+		mustErr bool
+		id      string
+		docName string
+		nodes   []string
+		roots   []string
+		// edges maps the edge sources to their targets
+		edges map[string][]string
+		// licenses are the IDs of the custom licenses kept
+		licenses []string
+		// vulnerabilities maps the vulnerability IDs to the nodes they affect
+		vulnerabilities map[string][]string
+	}{
+		{
+			name:            "supplier subtrees",
+			vendor:          &Vendor{Name: "Acme Corporation", Aliases: []string{"ACME Labs"}, Domains: []string{"acme.com"}},
+			id:              "urn:example:app-acme",
+			docName:         "app (Acme Corporation)",
+			nodes:           []string{"acme-lib", "acme-util", "acme-cli"},
+			roots:           []string{"acme-cli", "acme-lib"},
+			edges:           map[string][]string{"acme-lib": {"acme-util"}},
+			licenses:        []string{"LicenseRef-acme"},
+			vulnerabilities: map[string][]string{"CVE-2024-0001": {"acme-lib"}},
+		},
+		{
+			name:            "single node",
+			vendor:          &Vendor{Name: "Globex", Domains: []string{"globex.io"}},
+			id:              "urn:example:app-globex",
+			docName:         "app (Globex)",
+			nodes:           []string{"framework"},
+			roots:           []string{"framework"},
+			edges:           map[string][]string{},
+			licenses:        []string{},
+			vulnerabilities: map[string][]string{"CVE-2024-0001": {"framework"}, "CVE-2024-0002": {"framework"}},
+		},
+		{
+			name:    "no supplied nodes",
+			vendor:  &Vendor{Name: "Initech"},
+			mustErr: true,
+		},
+		{
+			name:    "empty vendor",
+			vendor:  &Vendor{},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// app -> framework (globex) -> acme-lib (acme) -> acme-util (acme)
+			//     -> acme-cli (acme)
+			doc := NewDocument()
+			doc.Metadata.Id = "urn:example:app"
+			doc.Metadata.Name = "app"
+			doc.NodeList.AddRootNode(&Node{Id: "app", Name: "app"})
+			doc.NodeList.AddNode(&Node{Id: "framework", Name: "framework", Suppliers: []*Person{{Name: "Globex"}}})
+			doc.NodeList.AddNode(&Node{Id: "acme-lib", Name: "lib", Suppliers: []*Person{{Name: "ACME, Inc."}}, Licenses: []string{"LicenseRef-acme"}})
+			doc.NodeList.AddNode(&Node{Id: "acme-util", Name: "util", Suppliers: []*Person{{Name: "Unknown", Email: "dev@acme.com"}}})
+			doc.NodeList.AddNode(&Node{Id: "acme-cli", Name: "cli", Suppliers: []*Person{{Name: "ACME Labs"}}})
+			doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"framework", "acme-cli"}})
+			doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "framework", To: []string{"acme-lib"}})
+			doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "acme-lib", To: []string{"acme-util"}})
+			doc.Metadata.CustomLicenses = []*License{{Id: "LicenseRef-acme"}, {Id: "LicenseRef-globex"}}
+			doc.Vulnerabilities = []*Vulnerability{
+				{Id: "CVE-2024-0001", Affects: []string{"framework", "acme-lib"}, NodeAnalyses: map[string]*Vulnerability_Analysis{
+					"framework": {Detail: "globex"}, "acme-lib": {Detail: "acme"},
+				}},
+				{Id: "CVE-2024-0002", Affects: []string{"framework"}},
+			}
+
+			extracted, err := doc.ExtractForSupplier(tc.vendor)
+
+			// The original document is not modified
+			require.Len(t, doc.NodeList.Nodes, 5)
+			require.Len(t, doc.Vulnerabilities[0].Affects, 2)
+			require.Equal(t, "urn:example:app", doc.Metadata.Id)
+
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.id, extracted.Metadata.Id)
+			require.Equal(t, tc.docName, extracted.Metadata.Name)
+
+			nl := extracted.NodeList
+			require.ElementsMatch(t, tc.nodes, ids(nl.Nodes))
+			require.ElementsMatch(t, tc.roots, nl.RootElements)
+			edges := map[string][]string{}
+			for _, e := range nl.Edges {
+				edges[e.From] = e.To
+			}
+			require.Equal(t, tc.edges, edges)
+
+			licenses := []string{}
+			for _, l := range extracted.Metadata.CustomLicenses {
+				licenses = append(licenses, l.Id)
+			}
+			require.Equal(t, tc.licenses, licenses)
+
+			vulnerabilities := map[string][]string{}
+			for _, v := range extracted.Vulnerabilities {
+				vulnerabilities[v.Id] = v.Affects
+				for id := range v.NodeAnalyses {
+					require.Contains(t, v.Affects, id)
+				}
+			}
+			require.Equal(t, tc.vulnerabilities, vulnerabilities)
+		})
+	}
+}
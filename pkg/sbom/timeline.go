@@ -0,0 +1,219 @@
+package sbom
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Timeline tracks the components of an artifact across a series of its
+// documents, such as the SBOMs of consecutive releases. It answers
+// questions like when a component appeared or which release first shipped
+// a vulnerable version.
+type Timeline struct {
+	// Points describes the documents in the series, in order
+	Points []*TimelinePoint
+
+	// Components has the history of each component, sorted by key
+	Components []*ComponentHistory
+
+	index map[string]*ComponentHistory
+}
+
+// TimelinePoint is a document in the timeline series
+type TimelinePoint struct {
+	Index      int
+	DocumentID string
+	Version    string
+	Date       time.Time
+}
+
+// TimelineSpan is a range of consecutive points where a component is
+// present. First and Last are indexes of the points, inclusive.
+type TimelineSpan struct {
+	First int
+	Last  int
+}
+
+// VersionChange records a change in the versions of a component. From is
+// empty when the component appears and To when it disappears.
+type VersionChange struct {
+	Index int
+	From  []string
+	To    []string
+}
+
+// ComponentHistory is the evolution of a component through the timeline
+type ComponentHistory struct {
+	// Key identifies the component: its package URL without version or,
+	// for components without one, its name.
+	Key  string
+	Name string
+
+	// Spans lists the ranges of documents where the component is present
+	Spans []*TimelineSpan
+
+	// Changes lists the points where the versions of the component changed,
+	// including its appearances and disappearances.
+	Changes []*VersionChange
+
+	versions  [][]string
+	firstSeen map[string]int
+}
+
+// NewTimeline builds the timeline of a series of documents of the same
+// artifact, which must be ordered from oldest to newest. Components are
+// matched across documents by their package URL, ignoring the version, or
+// by name when they have no package URL.
+func NewTimeline(docs ...*Document) *Timeline {
+	t := &Timeline{
+		Points:     make([]*TimelinePoint, 0, len(docs)),
+		Components: []*ComponentHistory{},
+		index:      map[string]*ComponentHistory{},
+	}
+	for i, doc := range docs {
+		point := &TimelinePoint{
+			Index:      i,
+			DocumentID: doc.GetMetadata().GetId(),
+			Version:    doc.GetMetadata().GetVersion(),
+		}
+		if doc.GetMetadata().GetDate() != nil {
+			point.Date = doc.GetMetadata().GetDate().AsTime()
+		}
+		t.Points = append(t.Points, point)
+
+		for _, n := range doc.GetNodeList().GetNodes() {
+			key := timelineKey(n)
+			if key == "" {
+				continue
+			}
+			h, ok := t.index[key]
+			if !ok {
+				h = &ComponentHistory{
+					Key:       key,
+					Name:      n.Name,
+					versions:  make([][]string, len(docs)),
+					firstSeen: map[string]int{},
+				}
+				t.index[key] = h
+				t.Components = append(t.Components, h)
+			}
+			if h.versions[i] == nil {
+				h.versions[i] = []string{}
+			}
+			if !slices.Contains(h.versions[i], n.Version) {
+				h.versions[i] = append(h.versions[i], n.Version)
+			}
+			if _, ok := h.firstSeen[n.Version]; !ok {
+				h.firstSeen[n.Version] = i
+			}
+		}
+	}
+
+	for _, h := range t.Components {
+		h.computeChanges()
+	}
+	slices.SortFunc(t.Components, func(a, b *ComponentHistory) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return t
+}
+
+// timelineKey returns the key identifying a node across the documents
+func timelineKey(n *Node) string {
+	if purl := n.Purl(); purl != "" {
+		return versionlessPurl(string(purl))
+	}
+	if n.Type == Node_PACKAGE {
+		return n.Name
+	}
+	return ""
+}
+
+// computeChanges derives the spans and version changes of the component
+func (h *ComponentHistory) computeChanges() {
+	var prev []string
+	for i, versions := range h.versions {
+		slices.Sort(versions)
+		switch {
+		case versions != nil && prev == nil:
+			h.Spans = append(h.Spans, &TimelineSpan{First: i, Last: i})
+		case versions != nil:
+			h.Spans[len(h.Spans)-1].Last = i
+		}
+		if !slices.Equal(prev, versions) {
+			h.Changes = append(h.Changes, &VersionChange{Index: i, From: prev, To: versions})
+		}
+		prev = versions
+	}
+}
+
+// Versions returns the versions of the component in the document at index
+// i, nil if the component is not present.
+func (h *ComponentHistory) Versions(i int) []string {
+	if i < 0 || i >= len(h.versions) {
+		return nil
+	}
+	return h.versions[i]
+}
+
+// AllVersions returns the versions of the component seen in the timeline,
+// in the order they first appeared.
+func (h *ComponentHistory) AllVersions() []string {
+	ret := slices.Collect(maps.Keys(h.firstSeen))
+	slices.SortStableFunc(ret, func(a, b string) int {
+		if h.firstSeen[a] != h.firstSeen[b] {
+			return h.firstSeen[a] - h.firstSeen[b]
+		}
+		return strings.Compare(a, b)
+	})
+	return ret
+}
+
+// FirstSeen returns the index of the first document containing version of
+// the component, or -1 if it never shipped. An empty version returns the
+// first appearance of the component.
+func (h *ComponentHistory) FirstSeen(version string) int {
+	if version == "" {
+		if len(h.Spans) == 0 {
+			return -1
+		}
+		return h.Spans[0].First
+	}
+	if i, ok := h.firstSeen[version]; ok {
+		return i
+	}
+	return -1
+}
+
+// Component returns the history of a component by its package URL, with
+// or without version, or its name. It returns nil if not found.
+func (t *Timeline) Component(id string) *ComponentHistory {
+	if strings.HasPrefix(id, "pkg:") {
+		id = versionlessPurl(id)
+	}
+	return t.index[id]
+}
+
+// FirstShipped returns the first document that included the component
+// version identified by a package URL. If the package URL has no version,
+// it returns the first document including the component. It returns nil if
+// the component version never shipped.
+func (t *Timeline) FirstShipped(purl string) *TimelinePoint {
+	h := t.Component(purl)
+	if h == nil {
+		return nil
+	}
+	version := ""
+	base, _, _ := strings.Cut(purl, "?")
+	base, _, _ = strings.Cut(base, "#")
+	if i := strings.LastIndex(base, "@"); i > strings.LastIndex(base, "/") {
+		version = base[i+1:]
+	}
+	i := h.FirstSeen(version)
+	if i < 0 {
+		return nil
+	}
+	return t.Points[i]
+}
@@ -0,0 +1,183 @@
+package sbom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeline(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// releases has the component versions of each document
+		releases     []map[string]string
+		component    string
+		spans        []*TimelineSpan
+		changes      []*VersionChange
+		allVersions  []string
+		firstShipped string
+	}{
+		{
+			name: "upgraded component",
+			releases: []map[string]string{
+				{"a": "1.0.0", "b": "2.0.0"},
+				{"a": "1.1.0", "b": "2.0.0"},
+				{"a": "1.1.0"},
+				{"a": "1.2.0", "b": "2.1.0"},
+			},
+			component: "pkg:golang/example.com/a@1.1.0",
+			spans:     []*TimelineSpan{{First: 0, Last: 3}},
+			changes: []*VersionChange{
+				{Index: 0, To: []string{"1.0.0"}},
+				{Index: 1, From: []string{"1.0.0"}, To: []string{"1.1.0"}},
+				{Index: 3, From: []string{"1.1.0"}, To: []string{"1.2.0"}},
+			},
+			allVersions:  []string{"1.0.0", "1.1.0", "1.2.0"},
+			firstShipped: "2",
+		},
+		{
+			name: "removed and added back",
+			releases: []map[string]string{
+				{"a": "1.0.0", "b": "2.0.0"},
+				{"a": "1.1.0", "b": "2.0.0"},
+				{"a": "1.1.0"},
+				{"a": "1.2.0", "b": "2.1.0"},
+			},
+			component: "pkg:golang/example.com/b",
+			spans:     []*TimelineSpan{{First: 0, Last: 1}, {First: 3, Last: 3}},
+			changes: []*VersionChange{
+				{Index: 0, To: []string{"2.0.0"}},
+				{Index: 2, From: []string{"2.0.0"}},
+				{Index: 3, To: []string{"2.1.0"}},
+			},
+			allVersions:  []string{"2.0.0", "2.1.0"},
+			firstShipped: "1",
+		},
+		{
+			name:      "unknown component",
+			releases:  []map[string]string{{"a": "1.0.0"}},
+			component: "pkg:golang/example.com/c@1.0.0",
+		},
+		{
+			name:      "unknown version",
+			releases:  []map[string]string{{"a": "1.0.0"}},
+			component: "pkg:golang/example.com/a@9.9.9",
+			spans:     []*TimelineSpan{{First: 0, Last: 0}},
+			changes: []*VersionChange{
+				{Index: 0, To: []string{"1.0.0"}},
+			},
+			allVersions: []string{"1.0.0"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			docs := []*Document{}
+			for i, components := range tc.releases {
+				doc := NewDocument()
+				doc.Metadata.Id = fmt.Sprintf("doc-%d", i+1)
+				doc.Metadata.Version = fmt.Sprintf("%d", i+1)
+				for name, v := range components {
+					doc.NodeList.AddNode(&Node{
+						Id: name + "@" + v, Type: Node_PACKAGE, Name: name, Version: v,
+						Identifiers: map[int32]string{
+							int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/" + name + "@" + v,
+						},
+					})
+				}
+				docs = append(docs, doc)
+			}
+
+			tl := NewTimeline(docs...)
+			require.Len(t, tl.Points, len(docs))
+			for i, p := range tl.Points {
+				require.Equal(t, fmt.Sprintf("doc-%d", i+1), p.DocumentID)
+			}
+
+			c := tl.Component(tc.component)
+			if tc.spans == nil {
+				require.Nil(t, c)
+				require.Nil(t, tl.FirstShipped(tc.component))
+				return
+			}
+			require.NotNil(t, c)
+			require.Equal(t, tc.spans, c.Spans)
+			require.Equal(t, tc.changes, c.Changes)
+			require.Equal(t, tc.allVersions, c.AllVersions())
+
+			first := tl.FirstShipped(tc.component)
+			if tc.firstShipped == "" {
+				require.Nil(t, first)
+				return
+			}
+			require.NotNil(t, first)
+			require.Equal(t, tc.firstShipped, first.Version)
+		})
+	}
+}
+
+func TestTimelineVersions(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		sut       []*Document
+		component string
+		index     int
+		versions  []string
+		firstSeen map[string]int
+	}{
+		{
+			name: "multiple versions in a document",
+			sut: func() []*Document {
+				doc1 := NewDocument()
+				doc1.NodeList.AddNode(&Node{Id: "a", Type: Node_PACKAGE, Name: "a", Version: "1.0.0", Identifiers: map[int32]string{
+					int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/a@1.0.0",
+				}})
+				doc2 := NewDocument()
+				doc2.NodeList.AddNode(&Node{Id: "a", Type: Node_PACKAGE, Name: "a", Version: "1.0.0", Identifiers: map[int32]string{
+					int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/a@1.0.0",
+				}})
+				doc2.NodeList.AddNode(&Node{Id: "a-vendored", Type: Node_PACKAGE, Name: "a", Version: "0.9.0", Identifiers: map[int32]string{
+					int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/a@0.9.0",
+				}})
+				return []*Document{doc1, doc2}
+			}(),
+			component: "pkg:golang/example.com/a",
+			index:     1,
+			versions:  []string{"0.9.0", "1.0.0"},
+			firstSeen: map[string]int{"0.9.0": 1, "1.0.0": 0},
+		},
+		{
+			name: "component without purl",
+			sut: func() []*Document {
+				doc := NewDocument()
+				doc.NodeList.AddNode(&Node{Id: "nopurl", Type: Node_PACKAGE, Name: "nopurl", Version: "1"})
+				return []*Document{NewDocument(), doc}
+			}(),
+			component: "nopurl",
+			index:     1,
+			versions:  []string{"1"},
+			firstSeen: map[string]int{"": 1},
+		},
+		{
+			name: "absent from the document",
+			sut: func() []*Document {
+				doc := NewDocument()
+				doc.NodeList.AddNode(&Node{Id: "b", Type: Node_PACKAGE, Name: "b", Version: "2.0.0", Identifiers: map[int32]string{
+					int32(SoftwareIdentifierType_PURL): "pkg:golang/example.com/b@2.0.0",
+				}})
+				return []*Document{doc, NewDocument()}
+			}(),
+			component: "pkg:golang/example.com/b",
+			index:     1,
+			firstSeen: map[string]int{"2.0.0": 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewTimeline(tc.sut...).Component(tc.component)
+			require.NotNil(t, c)
+			require.Equal(t, tc.versions, c.Versions(tc.index))
+			for v, i := range tc.firstSeen {
+				require.Equal(t, i, c.FirstSeen(v))
+			}
+		})
+	}
+}
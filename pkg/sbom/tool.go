@@ -0,0 +1,213 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PropertyToolVerification stores the verification material of a tool in
+// the document metadata, one property per tool with its ToolVerification
+// encoded as JSON. Serializers map it to the native fields of the formats
+// that support them, eg the hashes of CycloneDX tool components.
+const PropertyToolVerification = "protobom:tool:verification"
+
+// ToolVerification is the material to verify the binary of a tool that
+// generated the document, as published by the tool maintainers. Consumers
+// compare it with the digest and signature of the binaries they trust to
+// check which generator produced the data.
+type ToolVerification struct {
+	// Name and Version identify the tool in the metadata
+	Name    string
+	Version string
+
+	// Hashes are the digests of the tool binary, indexed by HashAlgorithm
+	Hashes map[int32]string
+
+	// Signature is the signature of the tool binary, if it is signed
+	Signature *ToolSignature
+}
+
+// ToolSignature is a signature over a tool binary
+type ToolSignature struct {
+	// Algorithm is the signature algorithm, eg "ES256" or "Ed25519"
+	Algorithm string
+
+	// KeyID identifies the signing key, eg its fingerprint or the identity
+	// of the certificate issued for keyless signing
+	KeyID string
+
+	// Value is the encoded signature
+	Value string
+}
+
+// toolVerificationJSON is the encoding of the verification properties. The
+// hash algorithms are stored by name so the property can be read by humans.
+type toolVerificationJSON struct {
+	Name      string             `json:"name"`
+	Version   string             `json:"version,omitempty"`
+	Hashes    map[string]string  `json:"hashes,omitempty"`
+	Signature *toolSignatureJSON `json:"signature,omitempty"`
+}
+
+// toolSignatureJSON is the encoding of the tool signatures
+type toolSignatureJSON struct {
+	Algorithm string `json:"algorithm,omitempty"`
+	KeyID     string `json:"keyId,omitempty"`
+	Value     string `json:"value"`
+}
+
+// Validate checks the tool verification has a name, valid hashes and a
+// signature value
+func (tv *ToolVerification) Validate() error {
+	if tv.Name == "" {
+		return errors.New("tool verification has no tool name")
+	}
+	if len(tv.Hashes) == 0 && tv.Signature == nil {
+		return errors.New("tool verification has no hashes or signature")
+	}
+	for algo, v := range tv.Hashes {
+		if err := HashAlgorithm(algo).ValidateValue(v); err != nil {
+			return fmt.Errorf("invalid %s hash: %w", HashAlgorithm(algo), err)
+		}
+	}
+	if tv.Signature != nil && tv.Signature.Value == "" {
+		return errors.New("tool signature has no value")
+	}
+	return nil
+}
+
+// HasDigest returns true if the tool binary has the digest value computed
+// with algorithm
+func (tv *ToolVerification) HasDigest(algorithm HashAlgorithm, value string) bool {
+	h, ok := tv.Hashes[int32(algorithm)]
+	return ok && value != "" && strings.EqualFold(h, value)
+}
+
+// matches returns true if the verification is for the tool
+func (tv *ToolVerification) matches(name, version string) bool {
+	return tv.Name == name && tv.Version == version
+}
+
+// MarshalJSON encodes the tool verification as stored in the properties
+func (tv *ToolVerification) MarshalJSON() ([]byte, error) {
+	out := toolVerificationJSON{Name: tv.Name, Version: tv.Version}
+	if len(tv.Hashes) > 0 {
+		out.Hashes = map[string]string{}
+		for algo, v := range tv.Hashes {
+			out.Hashes[HashAlgorithm(algo).String()] = v
+		}
+	}
+	if s := tv.Signature; s != nil {
+		out.Signature = &toolSignatureJSON{Algorithm: s.Algorithm, KeyID: s.KeyID, Value: s.Value}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a tool verification property
+func (tv *ToolVerification) UnmarshalJSON(data []byte) error {
+	in := toolVerificationJSON{}
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	*tv = ToolVerification{Name: in.Name, Version: in.Version}
+	if len(in.Hashes) > 0 {
+		tv.Hashes = map[int32]string{}
+		for name, v := range in.Hashes {
+			algo, ok := HashAlgorithm_value[name]
+			if !ok {
+				return fmt.Errorf("unknown hash algorithm %q", name)
+			}
+			tv.Hashes[algo] = v
+		}
+	}
+	if s := in.Signature; s != nil {
+		tv.Signature = &ToolSignature{Algorithm: s.Algorithm, KeyID: s.KeyID, Value: s.Value}
+	}
+	return nil
+}
+
+// ToolVerifications returns the verification material of the tools in the
+// metadata. Properties that can't be decoded are skipped.
+func (m *Metadata) ToolVerifications() []*ToolVerification {
+	ret := []*ToolVerification{}
+	for _, p := range m.GetProperties() {
+		if p.GetName() != PropertyToolVerification {
+			continue
+		}
+		tv := &ToolVerification{}
+		if err := json.Unmarshal([]byte(p.GetData()), tv); err != nil {
+			continue
+		}
+		ret = append(ret, tv)
+	}
+	return ret
+}
+
+// GetToolVerification returns the verification material of the tool with
+// the name and version or nil if the metadata has none
+func (m *Metadata) GetToolVerification(name, version string) *ToolVerification {
+	for _, tv := range m.ToolVerifications() {
+		if tv.matches(name, version) {
+			return tv
+		}
+	}
+	return nil
+}
+
+// SetToolVerification records the verification material of a tool,
+// replacing any previous one. The tool is added to the metadata tools if
+// it is not listed.
+func (m *Metadata) SetToolVerification(tv *ToolVerification) error {
+	if tv == nil {
+		return errors.New("tool verification is nil")
+	}
+	if err := tv.Validate(); err != nil {
+		return fmt.Errorf("invalid tool verification: %w", err)
+	}
+	data, err := json.Marshal(tv)
+	if err != nil {
+		return fmt.Errorf("encoding tool verification: %w", err)
+	}
+
+	m.RemoveToolVerification(tv.Name, tv.Version)
+	m.Properties = append(m.Properties, &Property{Name: PropertyToolVerification, Data: string(data)})
+
+	if !slices.ContainsFunc(m.Tools, func(t *Tool) bool {
+		return t.GetName() == tv.Name && t.GetVersion() == tv.Version
+	}) {
+		m.Tools = append(m.Tools, &Tool{Name: tv.Name, Version: tv.Version})
+	}
+	return nil
+}
+
+// RemoveToolVerification removes the verification material of the tool
+// with the name and version. The tool is kept in the metadata tools.
+func (m *Metadata) RemoveToolVerification(name, version string) {
+	m.Properties = slices.DeleteFunc(m.Properties, func(p *Property) bool {
+		if p.GetName() != PropertyToolVerification {
+			return false
+		}
+		tv := &ToolVerification{}
+		return json.Unmarshal([]byte(p.GetData()), tv) == nil && tv.matches(name, version)
+	})
+}
+
+// ToolsWithDigest returns the tools whose binary has the digest value
+// computed with algorithm
+func (m *Metadata) ToolsWithDigest(algorithm HashAlgorithm, value string) []*Tool {
+	ret := []*Tool{}
+	for _, tv := range m.ToolVerifications() {
+		if !tv.HasDigest(algorithm, value) {
+			continue
+		}
+		for _, t := range m.GetTools() {
+			if t.GetName() == tv.Name && t.GetVersion() == tv.Version {
+				ret = append(ret, t)
+			}
+		}
+	}
+	return ret
+}
@@ -0,0 +1,185 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const toolTestDigest = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func toolNames(tools []*Tool) []string {
+	ret := []string{}
+	for _, t := range tools {
+		ret = append(ret, t.Name)
+	}
+	return ret
+}
+
+func TestSetToolVerification(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// prepare are the verifications set before the one under test
+		prepare       []*ToolVerification
+		sut           *ToolVerification
+		mustErr       bool
+		tools         []string
+		verifications int
+	}{
+		{
+			name: "new verification",
+			sut: &ToolVerification{
+				Name: "scanner", Version: "1.0",
+				Hashes:    map[int32]string{int32(HashAlgorithm_SHA256): toolTestDigest},
+				Signature: &ToolSignature{Algorithm: "ES256", KeyID: "release-key", Value: "c2lnbmF0dXJl"},
+			},
+			tools:         []string{"scanner"},
+			verifications: 1,
+		},
+		{
+			// Setting the verification again replaces it
+			name: "replaced",
+			prepare: []*ToolVerification{{
+				Name: "scanner", Version: "1.0",
+				Hashes: map[int32]string{int32(HashAlgorithm_SHA256): toolTestDigest},
+			}},
+			sut:           &ToolVerification{Name: "scanner", Version: "1.0", Signature: &ToolSignature{Value: "b3RoZXI="}},
+			tools:         []string{"scanner"},
+			verifications: 1,
+		},
+		{
+			// New tools are listed
+			name: "new tool",
+			prepare: []*ToolVerification{{
+				Name: "scanner", Version: "1.0",
+				Hashes: map[int32]string{int32(HashAlgorithm_SHA256): toolTestDigest},
+			}},
+			sut: &ToolVerification{
+				Name: "linter", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"},
+			},
+			tools:         []string{"scanner", "linter"},
+			verifications: 2,
+		},
+		{
+			name:    "nil",
+			mustErr: true,
+			tools:   []string{"scanner"},
+		},
+		{
+			name:    "no name",
+			sut:     &ToolVerification{Hashes: map[int32]string{int32(HashAlgorithm_SHA1): "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"}},
+			mustErr: true,
+			tools:   []string{"scanner"},
+		},
+		{
+			name:    "no material",
+			sut:     &ToolVerification{Name: "scanner"},
+			mustErr: true,
+			tools:   []string{"scanner"},
+		},
+		{
+			name:    "invalid hash",
+			sut:     &ToolVerification{Name: "scanner", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): "abc"}},
+			mustErr: true,
+			tools:   []string{"scanner"},
+		},
+		{
+			name:    "no signature",
+			sut:     &ToolVerification{Name: "scanner", Signature: &ToolSignature{Algorithm: "ES256"}},
+			mustErr: true,
+			tools:   []string{"scanner"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			md := &Metadata{Tools: []*Tool{{Name: "scanner", Version: "1.0", Vendor: "ACME"}}}
+			for _, tv := range tc.prepare {
+				require.NoError(t, md.SetToolVerification(tv))
+			}
+
+			err := md.SetToolVerification(tc.sut)
+			require.Equal(t, tc.tools, toolNames(md.Tools))
+			require.Len(t, md.ToolVerifications(), tc.verifications)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.sut, md.GetToolVerification(tc.sut.Name, tc.sut.Version))
+			require.Equal(t, PropertyToolVerification, md.Properties[0].Name)
+			for _, digest := range tc.sut.Hashes {
+				require.Contains(t, md.Properties[len(md.Properties)-1].Data, `"`+digest+`"`)
+			}
+		})
+	}
+}
+
+func TestGetToolVerification(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		tool    string
+		version string
+		// remove removes the verification before the lookup
+		remove bool
+		found  bool
+	}{
+		{name: "same version", tool: "scanner", version: "1.0", found: true},
+		{name: "other version", tool: "scanner", version: "2.0"},
+		{name: "unversioned", tool: "linter", found: true},
+		{name: "unknown tool", tool: "compiler"},
+		{name: "removed", tool: "scanner", version: "1.0", remove: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			md := &Metadata{}
+			require.NoError(t, md.SetToolVerification(&ToolVerification{
+				Name: "scanner", Version: "1.0", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): toolTestDigest},
+			}))
+			require.NoError(t, md.SetToolVerification(&ToolVerification{
+				Name: "linter", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"},
+			}))
+			if tc.remove {
+				md.RemoveToolVerification(tc.tool, tc.version)
+				require.Len(t, md.ToolVerifications(), 1)
+				// The tool is still listed
+				require.Equal(t, []string{"scanner", "linter"}, toolNames(md.Tools))
+			}
+
+			tv := md.GetToolVerification(tc.tool, tc.version)
+			if !tc.found {
+				require.Nil(t, tv)
+				return
+			}
+			require.NotNil(t, tv)
+			require.Equal(t, tc.tool, tv.Name)
+			require.Equal(t, tc.version, tv.Version)
+		})
+	}
+}
+
+func TestToolVerificationDigest(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		algorithm HashAlgorithm
+		value     string
+		expected  bool
+	}{
+		{name: "same digest", algorithm: HashAlgorithm_SHA256, value: toolTestDigest, expected: true},
+		{name: "uppercase digest", algorithm: HashAlgorithm_SHA256, value: "9F86D081884C7D659A2FEAA0C55AD015A3BF4F1B2B0B822CD15D6C15B0F00A08", expected: true},
+		{name: "other algorithm", algorithm: HashAlgorithm_SHA512, value: toolTestDigest},
+		{name: "other digest", algorithm: HashAlgorithm_SHA256, value: "00"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			md := &Metadata{Tools: []*Tool{{Name: "scanner", Version: "1.0"}, {Name: "linter"}}}
+			tv := &ToolVerification{
+				Name: "scanner", Version: "1.0", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): toolTestDigest},
+			}
+			require.NoError(t, md.SetToolVerification(tv))
+
+			require.Equal(t, tc.expected, tv.HasDigest(tc.algorithm, tc.value))
+			tools := []string{}
+			if tc.expected {
+				tools = []string{"scanner"}
+			}
+			require.Equal(t, tools, toolNames(md.ToolsWithDigest(tc.algorithm, tc.value)))
+		})
+	}
+}
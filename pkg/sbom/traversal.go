@@ -0,0 +1,311 @@
+package sbom
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrCycle is returned by TopologicalSort when the graph has a cycle
+var ErrCycle = errors.New("graph has a cycle")
+
+// ErrSkipChildren is returned by a NodeVisitor to skip the nodes reachable
+// from the visited one. They are still visited if reached by another path.
+var ErrSkipChildren = errors.New("skip children")
+
+// ErrStopWalk is returned by a NodeVisitor to stop the walk without an error
+var ErrStopWalk = errors.New("stop walk")
+
+// NodeVisitor is called for every node visited by WalkDepthFirst and
+// WalkBreadthFirst with the number of edges followed to reach it. Returning
+// ErrSkipChildren or ErrStopWalk controls the walk, any other error aborts it.
+type NodeVisitor func(n *Node, depth int) error
+
+// successors returns the IDs of the nodes pointed by the edges of each node,
+// following the edges of the types or all edges if none are set. Edges to
+// nodes not in the NodeList are ignored. Successors are in edge order
+// without duplicates.
+func (nl *NodeList) successors(types []Edge_Type) map[string][]string {
+	nodes := nl.indexNodes()
+	seen := map[string]map[string]struct{}{}
+	ret := map[string][]string{}
+	for _, e := range nl.GetEdges() {
+		if len(types) > 0 && !slices.Contains(types, e.GetType()) {
+			continue
+		}
+		if _, ok := nodes[e.GetFrom()]; !ok {
+			continue
+		}
+		if seen[e.GetFrom()] == nil {
+			seen[e.GetFrom()] = map[string]struct{}{}
+		}
+		for _, to := range e.GetTo() {
+			if _, ok := nodes[to]; !ok {
+				continue
+			}
+			if _, ok := seen[e.GetFrom()][to]; ok {
+				continue
+			}
+			seen[e.GetFrom()][to] = struct{}{}
+			ret[e.GetFrom()] = append(ret[e.GetFrom()], to)
+		}
+	}
+	return ret
+}
+
+// TopologicalSort returns the nodes of the NodeList ordered so every node
+// comes after the nodes its edges point to. When following dependency
+// edges (eg Edge_dependsOn) this is a build order: dependencies are listed
+// before their dependents. Only the edges of the types are followed, all
+// of them if none are set. Nodes without an order between them keep their
+// order in the NodeList. It returns an error wrapping ErrCycle when the
+// graph has a cycle.
+func (nl *NodeList) TopologicalSort(types ...Edge_Type) ([]*Node, error) {
+	succ := nl.successors(types)
+
+	// Kahn's algorithm on the reversed graph, taking the ready nodes in
+	// NodeList order
+	pending := map[string]int{}
+	dependents := map[string][]string{}
+	for from, tos := range succ {
+		pending[from] = len(tos)
+		for _, to := range tos {
+			dependents[to] = append(dependents[to], from)
+		}
+	}
+
+	nodes := nl.GetNodes()
+	position := map[string]int{}
+	for i, n := range nodes {
+		if _, ok := position[n.Id]; !ok {
+			position[n.Id] = i
+		}
+	}
+
+	ready := []int{}
+	for id, i := range position {
+		if pending[id] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	slices.Sort(ready)
+
+	ret := make([]*Node, 0, len(position))
+	for len(ready) > 0 {
+		n := nodes[ready[0]]
+		ready = ready[1:]
+		ret = append(ret, n)
+		for _, id := range dependents[n.Id] {
+			pending[id]--
+			if pending[id] == 0 {
+				i, _ := slices.BinarySearch(ready, position[id])
+				ready = slices.Insert(ready, i, position[id])
+			}
+		}
+	}
+
+	if len(ret) < len(position) {
+		cycles := nl.Cycles(types...)
+		if len(cycles) > 0 {
+			return nil, fmt.Errorf("%w: %s", ErrCycle, strings.Join(cycles[0], " -> "))
+		}
+		return nil, ErrCycle
+	}
+	return ret, nil
+}
+
+// Cycles returns a cycle for each group of nodes in the graph that reach
+// each other, following the edges of the types or all edges if none are
+// set. Each cycle lists the IDs of the nodes in edge order, ending with
+// the first one, eg [a b c a]. Nodes with edges to themselves are reported
+// as [a a]. The result is empty when the graph is acyclic.
+func (nl *NodeList) Cycles(types ...Edge_Type) [][]string {
+	succ := nl.successors(types)
+
+	// Tarjan's strongly connected components algorithm
+	index := map[string]int{}
+	low := map[string]int{}
+	onStack := map[string]bool{}
+	stack := []string{}
+	components := [][]string{}
+
+	var strongConnect func(id string)
+	strongConnect = func(id string) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, to := range succ[id] {
+			if _, ok := index[to]; !ok {
+				strongConnect(to)
+				low[id] = min(low[id], low[to])
+			} else if onStack[to] {
+				low[id] = min(low[id], index[to])
+			}
+		}
+
+		if low[id] != index[id] {
+			return
+		}
+		component := []string{}
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || slices.Contains(succ[id], id) {
+			components = append(components, component)
+		}
+	}
+
+	for _, n := range nl.GetNodes() {
+		if _, ok := index[n.Id]; !ok {
+			strongConnect(n.Id)
+		}
+	}
+
+	// Report the components in NodeList order, as a path starting from
+	// their first node
+	position := map[string]int{}
+	for i, n := range nl.GetNodes() {
+		if _, ok := position[n.Id]; !ok {
+			position[n.Id] = i
+		}
+	}
+	ret := make([][]string, 0, len(components))
+	for _, c := range components {
+		start := slices.MinFunc(c, func(a, b string) int { return position[a] - position[b] })
+		ret = append(ret, cyclePath(start, c, succ))
+	}
+	slices.SortFunc(ret, func(a, b []string) int { return position[a[0]] - position[b[0]] })
+	return ret
+}
+
+// cyclePath returns a path from start back to itself through the nodes of
+// its strongly connected component
+func cyclePath(start string, component []string, succ map[string][]string) []string {
+	members := map[string]struct{}{}
+	for _, id := range component {
+		members[id] = struct{}{}
+	}
+
+	// Breadth first search for the shortest way back to start
+	parent := map[string]string{}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, to := range succ[id] {
+			if _, ok := members[to]; !ok {
+				continue
+			}
+			if to == start {
+				path := []string{start}
+				for n := id; n != start; n = parent[n] {
+					path = append(path, n)
+				}
+				slices.Reverse(path[1:])
+				return append(path, start)
+			}
+			if _, ok := parent[to]; ok {
+				continue
+			}
+			parent[to] = id
+			queue = append(queue, to)
+		}
+	}
+	return []string{start, start}
+}
+
+// HasCycles returns true if the graph has a cycle following the edges of
+// the types, or all edges if none are set
+func (nl *NodeList) HasCycles(types ...Edge_Type) bool {
+	return len(nl.Cycles(types...)) > 0
+}
+
+// WalkDepthFirst calls visit for the node id and the nodes reachable from
+// it, depth first in edge order. Only the edges of the types are followed,
+// all of them if none are set. Every node is visited once, at the depth it
+// is first reached. It returns the error returned by visit, other than
+// ErrSkipChildren and ErrStopWalk, or an error if the node is not found.
+func (nl *NodeList) WalkDepthFirst(id string, visit NodeVisitor, types ...Edge_Type) error {
+	nodes := nl.indexNodes()
+	if _, ok := nodes[id]; !ok {
+		return fmt.Errorf("node %q not found", id)
+	}
+	succ := nl.successors(types)
+	seen := map[string]struct{}{}
+
+	var walk func(id string, depth int) error
+	walk = func(id string, depth int) error {
+		seen[id] = struct{}{}
+		if err := visit(nodes[id], depth); err != nil {
+			if errors.Is(err, ErrSkipChildren) {
+				return nil
+			}
+			return err
+		}
+		for _, to := range succ[id] {
+			if _, ok := seen[to]; ok {
+				continue
+			}
+			if err := walk(to, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(id, 0); err != nil && !errors.Is(err, ErrStopWalk) {
+		return err
+	}
+	return nil
+}
+
+// WalkBreadthFirst calls visit for the node id and the nodes reachable from
+// it, breadth first in edge order. Only the edges of the types are
+// followed, all of them if none are set. Every node is visited once. It
+// returns the error returned by visit, other than ErrSkipChildren and
+// ErrStopWalk, or an error if the node is not found.
+func (nl *NodeList) WalkBreadthFirst(id string, visit NodeVisitor, types ...Edge_Type) error {
+	nodes := nl.indexNodes()
+	if _, ok := nodes[id]; !ok {
+		return fmt.Errorf("node %q not found", id)
+	}
+	succ := nl.successors(types)
+
+	type item struct {
+		id    string
+		depth int
+	}
+	seen := map[string]struct{}{id: {}}
+	queue := []item{{id, 0}}
+	for len(queue) > 0 {
+		it := queue[0]
+		queue = queue[1:]
+		if err := visit(nodes[it.id], it.depth); err != nil {
+			switch {
+			case errors.Is(err, ErrSkipChildren):
+				continue
+			case errors.Is(err, ErrStopWalk):
+				return nil
+			default:
+				return err
+			}
+		}
+		for _, to := range succ[it.id] {
+			if _, ok := seen[to]; ok {
+				continue
+			}
+			seen[to] = struct{}{}
+			queue = append(queue, item{to, it.depth + 1})
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package sbom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// traversalTestNodeList returns a graph where app depends on lib and util,
+// lib depends on util and base, and app has a test dependency on mock
+func traversalTestNodeList() *NodeList {
+	return &NodeList{
+		Nodes: []*Node{
+			{Id: "app"}, {Id: "lib"}, {Id: "util"}, {Id: "base"}, {Id: "mock"},
+		},
+		Edges: []*Edge{
+			{From: "app", Type: Edge_dependsOn, To: []string{"lib", "util"}},
+			{From: "lib", Type: Edge_dependsOn, To: []string{"util", "base"}},
+			{From: "app", Type: Edge_testDependency, To: []string{"mock"}},
+		},
+		RootElements: []string{"app"},
+	}
+}
+
+func nodeIDs(nodes []*Node) []string {
+	ret := []string{}
+	for _, n := range nodes {
+		ret = append(ret, n.Id)
+	}
+	return ret
+}
+
+func TestTopologicalSort(t *testing.T) {
+	for name, tc := range map[string]struct {
+		// edges are added to the test graph
+		edges    []*Edge
+		types    []Edge_Type
+		expected []string
+		cycle    string
+	}{
+		"all edges": {
+			expected: []string{"util", "base", "lib", "mock", "app"},
+		},
+		"edge types": {
+			types:    []Edge_Type{Edge_dependsOn},
+			expected: []string{"util", "base", "lib", "app", "mock"},
+		},
+		"cycle": {
+			edges: []*Edge{{From: "base", Type: Edge_dependsOn, To: []string{"lib"}}},
+			cycle: "lib -> base -> lib",
+		},
+		// The cycle is not followed when filtering other edge types
+		"cycle in other edge types": {
+			edges:    []*Edge{{From: "base", Type: Edge_dependsOn, To: []string{"lib"}}},
+			types:    []Edge_Type{Edge_testDependency},
+			expected: []string{"lib", "util", "base", "mock", "app"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			nl := traversalTestNodeList()
+			nl.Edges = append(nl.Edges, tc.edges...)
+			nodes, err := nl.TopologicalSort(tc.types...)
+			if tc.cycle != "" {
+				require.ErrorIs(t, err, ErrCycle)
+				require.ErrorContains(t, err, tc.cycle)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, nodeIDs(nodes))
+		})
+	}
+}
+
+func TestCycles(t *testing.T) {
+	cyclic := []*Edge{
+		{From: "util", Type: Edge_dependsOn, To: []string{"app"}},
+		{From: "mock", Type: Edge_contains, To: []string{"mock"}},
+		{From: "self", Type: Edge_dependsOn, To: []string{"self", "missing"}},
+	}
+	for name, tc := range map[string]struct {
+		// edges are added to the test graph
+		edges    []*Edge
+		types    []Edge_Type
+		expected [][]string
+	}{
+		"acyclic": {
+			expected: [][]string{},
+		},
+		"all edges": {
+			edges:    cyclic,
+			expected: [][]string{{"app", "util", "app"}, {"mock", "mock"}, {"self", "self"}},
+		},
+		"edge types": {
+			edges:    cyclic,
+			types:    []Edge_Type{Edge_dependsOn},
+			expected: [][]string{{"app", "util", "app"}, {"self", "self"}},
+		},
+		"self edge": {
+			edges:    cyclic,
+			types:    []Edge_Type{Edge_contains},
+			expected: [][]string{{"mock", "mock"}},
+		},
+		"no cycles in edge types": {
+			edges:    cyclic,
+			types:    []Edge_Type{Edge_testDependency},
+			expected: [][]string{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			nl := traversalTestNodeList()
+			nl.Nodes = append(nl.Nodes, &Node{Id: "self"})
+			nl.Edges = append(nl.Edges, tc.edges...)
+			require.Equal(t, tc.expected, nl.Cycles(tc.types...))
+			require.Equal(t, len(tc.expected) > 0, nl.HasCycles(tc.types...))
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	nl := traversalTestNodeList()
+	type visit struct {
+		id    string
+		depth int
+	}
+	record := func(visits *[]visit, control map[string]error) NodeVisitor {
+		return func(n *Node, depth int) error {
+			*visits = append(*visits, visit{n.Id, depth})
+			return control[n.Id]
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		walk    func(string, NodeVisitor, ...Edge_Type) error
+		types   []Edge_Type
+		control map[string]error
+		expect  []visit
+	}{
+		"depth first": {
+			walk:   nl.WalkDepthFirst,
+			expect: []visit{{"app", 0}, {"lib", 1}, {"util", 2}, {"base", 2}, {"mock", 1}},
+		},
+		"breadth first": {
+			walk:   nl.WalkBreadthFirst,
+			expect: []visit{{"app", 0}, {"lib", 1}, {"util", 1}, {"mock", 1}, {"base", 2}},
+		},
+		"depth first edge types": {
+			walk:   nl.WalkDepthFirst,
+			types:  []Edge_Type{Edge_testDependency},
+			expect: []visit{{"app", 0}, {"mock", 1}},
+		},
+		"depth first skip": {
+			walk:    nl.WalkDepthFirst,
+			control: map[string]error{"lib": ErrSkipChildren},
+			expect:  []visit{{"app", 0}, {"lib", 1}, {"util", 1}, {"mock", 1}},
+		},
+		"breadth first skip": {
+			walk:    nl.WalkBreadthFirst,
+			control: map[string]error{"lib": ErrSkipChildren},
+			expect:  []visit{{"app", 0}, {"lib", 1}, {"util", 1}, {"mock", 1}},
+		},
+		"depth first stop": {
+			walk:    nl.WalkDepthFirst,
+			control: map[string]error{"util": ErrStopWalk},
+			expect:  []visit{{"app", 0}, {"lib", 1}, {"util", 2}},
+		},
+		"breadth first stop": {
+			walk:    nl.WalkBreadthFirst,
+			control: map[string]error{"util": ErrStopWalk},
+			expect:  []visit{{"app", 0}, {"lib", 1}, {"util", 1}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			visits := []visit{}
+			require.NoError(t, tc.walk("app", record(&visits, tc.control), tc.types...))
+			require.Equal(t, tc.expect, visits)
+		})
+	}
+
+	errTest := errors.New("test")
+	for _, walk := range []func(string, NodeVisitor, ...Edge_Type) error{nl.WalkDepthFirst, nl.WalkBreadthFirst} {
+		visits := []visit{}
+		err := walk("app", record(&visits, map[string]error{"lib": errTest}))
+		require.ErrorIs(t, err, errTest)
+		require.Len(t, visits, 2)
+
+		require.Error(t, walk("missing", record(&visits, nil)))
+	}
+}
@@ -0,0 +1,333 @@
+package sbom
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Bytes is a size in bytes
+type Bytes int64
+
+// Common size units
+const (
+	KiB Bytes = 1024
+	MiB Bytes = 1024 * KiB
+)
+
+// PropertyTruncated records the data removed from a document by Truncate.
+// Nodes get one property for each kind of data removed from them or from
+// their subtree, the metadata one for each kind removed from the document.
+const PropertyTruncated = "protobom:truncation:removed"
+
+// Values of the PropertyTruncated properties
+const (
+	TruncatedFiles           = "files"
+	TruncatedDescription     = "description"
+	TruncatedDevDependencies = "dev_dependencies"
+)
+
+// ErrOverBudget is returned by Truncate when the document does not fit
+// the budget after applying all the steps of the strategy
+var ErrOverBudget = errors.New("document does not fit the size budget")
+
+// TruncationStep is a kind of data Truncate can remove
+type TruncationStep int
+
+const (
+	// TruncateFiles removes the file nodes, except the root elements.
+	// The packages owning them are marked as missing files.
+	TruncateFiles TruncationStep = iota + 1
+
+	// TruncateDescriptions clears the descriptions of the nodes
+	TruncateDescriptions
+
+	// TruncateDevSubtrees removes the nodes only reachable from the roots
+	// through development and test dependencies or tools. The nodes
+	// depending on them are marked as missing dev dependencies.
+	TruncateDevSubtrees
+)
+
+// devEdgeTypes are the edges relating the nodes to their dev-scope
+// subtrees. Like the SPDX *_OF relationships, they point from the
+// dependency to its dependent.
+var devEdgeTypes = []Edge_Type{Edge_devDependency, Edge_devTool, Edge_testDependency, Edge_testTool}
+
+// TruncateStrategy selects what Truncate removes and how it measures the
+// document
+type TruncateStrategy struct {
+	// Steps are the kinds of data removed, least important first. Each step
+	// is only applied as much as needed to fit the budget.
+	Steps []TruncationStep
+
+	// Size measures the document as published, eg serialized in the
+	// format of the registry. Defaults to the size of its protobuf
+	// encoding.
+	Size func(*Document) (Bytes, error)
+}
+
+// DefaultTruncateStrategy removes files first, then descriptions and then
+// dev-scope subtrees
+var DefaultTruncateStrategy = &TruncateStrategy{
+	Steps: []TruncationStep{TruncateFiles, TruncateDescriptions, TruncateDevSubtrees},
+}
+
+// TruncationReport lists the data removed by Truncate
+type TruncationReport struct {
+	// Size is the size of the document after truncating it
+	Size Bytes
+
+	// RemovedFiles are the IDs of the file nodes removed
+	RemovedFiles []string
+
+	// TrimmedDescriptions are the IDs of the nodes whose description was
+	// cleared
+	TrimmedDescriptions []string
+
+	// RemovedDevNodes are the IDs of the nodes removed from dev-scope
+	// subtrees
+	RemovedDevNodes []string
+}
+
+// truncationCut is a piece of data that can be removed from a document
+type truncationCut struct {
+	// node is the ID of the node cleared or marked as incomplete
+	node string
+
+	// remove are the IDs of the nodes removed
+	remove []string
+
+	// size is the protobuf size of the data removed
+	size int
+}
+
+// Truncate trims the least important data of the document to fit it in a
+// size budget, eg to attach it to a registry capping the artifact sizes.
+// The steps of the strategy are applied in order, each one removing its
+// largest pieces of data first and stopping as soon as the document fits.
+// What was removed is recorded in PropertyTruncated properties so the
+// document is not mistaken for a complete one.
+//
+// If the document does not fit after applying all the steps, Truncate
+// returns the report of the data removed and an error wrapping
+// ErrOverBudget.
+func (d *Document) Truncate(budget Bytes, strategy *TruncateStrategy) (*TruncationReport, error) {
+	if strategy == nil {
+		strategy = DefaultTruncateStrategy
+	}
+	size := strategy.Size
+	if size == nil {
+		size = func(doc *Document) (Bytes, error) { return Bytes(proto.Size(doc)), nil }
+	}
+	report := &TruncationReport{RemovedFiles: []string{}, TrimmedDescriptions: []string{}, RemovedDevNodes: []string{}}
+
+	current, err := size(d)
+	if err != nil {
+		return nil, fmt.Errorf("measuring document: %w", err)
+	}
+	for _, step := range strategy.Steps {
+		if current <= budget {
+			break
+		}
+		cuts, err := d.truncationCuts(step)
+		if err != nil {
+			return nil, err
+		}
+		for len(cuts) > 0 && current > budget {
+			// Estimate the data to cut from the protobuf sizes and cut half
+			// of it before measuring again, so estimation errors converge
+			// instead of removing more than needed.
+			ratio := float64(current) / float64(max(proto.Size(d), 1))
+			excess, saved, n := float64(current-budget), 0.0, 0
+			for n < len(cuts) && saved < excess {
+				saved += float64(cuts[n].size) * ratio
+				n++
+			}
+			n = max(n/2, 1)
+			d.applyTruncationCuts(step, cuts[:n], report)
+			cuts = cuts[n:]
+			if current, err = size(d); err != nil {
+				return nil, fmt.Errorf("measuring document: %w", err)
+			}
+		}
+	}
+
+	report.Size = current
+	if current > budget {
+		return report, fmt.Errorf("%w: %d bytes, budget is %d", ErrOverBudget, current, budget)
+	}
+	return report, nil
+}
+
+// truncationCuts returns the data removed by a step, largest first
+func (d *Document) truncationCuts(step TruncationStep) ([]*truncationCut, error) {
+	nl := d.GetNodeList()
+	cuts := []*truncationCut{}
+	switch step {
+	case TruncateFiles:
+		roots := nl.indexRootElements()
+		nodes := nl.indexNodes()
+		_, containers := nl.containmentIndex()
+		for _, n := range nl.GetNodes() {
+			if _, ok := roots[n.Id]; ok || n.Type != Node_FILE {
+				continue
+			}
+			cuts = append(cuts, &truncationCut{
+				node: ownerOf(n.Id, nodes, containers).GetId(), remove: []string{n.Id}, size: proto.Size(n),
+			})
+		}
+	case TruncateDescriptions:
+		for _, n := range nl.GetNodes() {
+			if n.Description != "" {
+				cuts = append(cuts, &truncationCut{node: n.Id, size: len(n.Description)})
+			}
+		}
+	case TruncateDevSubtrees:
+		cuts = nl.devSubtreeCuts()
+	default:
+		return nil, fmt.Errorf("unknown truncation step %d", step)
+	}
+	slices.SortStableFunc(cuts, func(a, b *truncationCut) int {
+		return cmp.Or(cmp.Compare(b.size, a.size), cmp.Compare(a.node, b.node))
+	})
+	return cuts, nil
+}
+
+// devSubtreeCuts returns the subtrees of the dev and test dependencies not
+// reachable from the roots through other edges. Subtrees shared by more
+// than one dependency are cut with the first one.
+func (nl *NodeList) devSubtreeCuts() []*truncationCut {
+	nodes := nl.indexNodes()
+	children := map[string][]string{}
+	devChildren := map[string][]string{}
+	for _, e := range nl.GetEdges() {
+		index := children
+		if slices.Contains(devEdgeTypes, e.Type) {
+			index = devChildren
+		}
+		for _, to := range e.To {
+			parent, child := e.From, to
+			if e.Type.IsReverse() {
+				parent, child = to, e.From
+			}
+			index[parent] = append(index[parent], child)
+		}
+	}
+
+	var visit func(id string, seen map[string]struct{}, follow ...map[string][]string)
+	visit = func(id string, seen map[string]struct{}, follow ...map[string][]string) {
+		if _, ok := seen[id]; ok {
+			return
+		}
+		if _, ok := nodes[id]; !ok {
+			return
+		}
+		seen[id] = struct{}{}
+		for _, index := range follow {
+			for _, child := range index[id] {
+				visit(child, seen, follow...)
+			}
+		}
+	}
+
+	kept := map[string]struct{}{}
+	for _, id := range nl.GetRootElements() {
+		visit(id, kept, children)
+	}
+	cuts := []*truncationCut{}
+	if len(kept) == 0 {
+		return cuts
+	}
+
+	froms := []string{}
+	for id := range devChildren {
+		if _, ok := kept[id]; ok {
+			froms = append(froms, id)
+		}
+	}
+	slices.Sort(froms)
+
+	cut := map[string]struct{}{}
+	for _, from := range froms {
+		for _, to := range devChildren[from] {
+			subtree := map[string]struct{}{}
+			visit(to, subtree, children, devChildren)
+			c := &truncationCut{node: from, remove: []string{}}
+			for _, n := range nl.GetNodes() {
+				_, inSubtree := subtree[n.Id]
+				_, isKept := kept[n.Id]
+				_, isCut := cut[n.Id]
+				if !inSubtree || isKept || isCut {
+					continue
+				}
+				cut[n.Id] = struct{}{}
+				c.remove = append(c.remove, n.Id)
+				c.size += proto.Size(n)
+			}
+			if len(c.remove) > 0 {
+				cuts = append(cuts, c)
+			}
+		}
+	}
+	return cuts
+}
+
+// applyTruncationCuts removes the data of the cuts from the document and
+// records it in the report and the truncation properties
+func (d *Document) applyTruncationCuts(step TruncationStep, cuts []*truncationCut, report *TruncationReport) {
+	if len(cuts) == 0 {
+		return
+	}
+	var kind string
+	remove := []string{}
+	for _, c := range cuts {
+		switch step {
+		case TruncateFiles:
+			kind = TruncatedFiles
+			report.RemovedFiles = append(report.RemovedFiles, c.remove...)
+		case TruncateDescriptions:
+			kind = TruncatedDescription
+			d.NodeList.GetNodeByID(c.node).Description = ""
+			report.TrimmedDescriptions = append(report.TrimmedDescriptions, c.node)
+		case TruncateDevSubtrees:
+			kind = TruncatedDevDependencies
+			report.RemovedDevNodes = append(report.RemovedDevNodes, c.remove...)
+		}
+		remove = append(remove, c.remove...)
+		if n := d.NodeList.GetNodeByID(c.node); n != nil {
+			n.Properties = addTruncationProperty(n.Properties, kind)
+		}
+	}
+
+	if len(remove) > 0 {
+		d.NodeList.RemoveNodes(remove)
+		removed := map[string]struct{}{}
+		for _, id := range remove {
+			removed[id] = struct{}{}
+		}
+		for _, v := range d.Vulnerabilities {
+			v.Affects = slices.DeleteFunc(v.Affects, func(id string) bool {
+				_, ok := removed[id]
+				return ok
+			})
+		}
+	}
+	if d.Metadata == nil {
+		d.Metadata = &Metadata{}
+	}
+	d.Metadata.Properties = addTruncationProperty(d.Metadata.Properties, kind)
+}
+
+// addTruncationProperty adds a PropertyTruncated property if it is not
+// already in the list
+func addTruncationProperty(props []*Property, kind string) []*Property {
+	for _, p := range props {
+		if p.GetName() == PropertyTruncated && p.GetData() == kind {
+			return props
+		}
+	}
+	return append(props, &Property{Name: PropertyTruncated, Data: kind})
+}
@@ -0,0 +1,147 @@
+package sbom
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func truncateTestDocument() *Document {
+	doc := NewDocument()
+	doc.NodeList.AddRootNode(&Node{Id: "app", Name: "app", Description: strings.Repeat("a", 200)})
+	doc.NodeList.AddNode(&Node{Id: "lib", Name: "lib", Description: strings.Repeat("l", 100)})
+	doc.NodeList.AddNode(&Node{Id: "linter", Name: "linter", Summary: strings.Repeat("s", 200)})
+	doc.NodeList.AddNode(&Node{Id: "linter-dep", Name: "linter-dep"})
+	doc.NodeList.AddNode(&Node{Id: "shared", Name: "shared"})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "app", To: []string{"lib"}})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "lib", To: []string{"shared"}})
+	doc.NodeList.AddEdge(&Edge{Type: EdgeTypeFromSPDX2("DEV_DEPENDENCY_OF"), From: "linter", To: []string{"app"}})
+	doc.NodeList.AddEdge(&Edge{Type: Edge_dependsOn, From: "linter", To: []string{"linter-dep", "shared"}})
+	files := []string{}
+	for i := range 20 {
+		id := fmt.Sprintf("file-%02d", i)
+		doc.NodeList.AddNode(&Node{Id: id, Type: Node_FILE, Name: fmt.Sprintf("/usr/lib/%s-%s", id, strings.Repeat("x", i))})
+		files = append(files, id)
+	}
+	doc.NodeList.AddEdge(&Edge{Type: Edge_contains, From: "lib", To: files})
+	doc.Vulnerabilities = []*Vulnerability{{Id: "CVE-2024-0001", Affects: []string{"lib", "file-19"}}}
+	return doc
+}
+
+func hasTruncationProperty(props []*Property, kind string) bool {
+	for _, p := range props {
+		if p.GetName() == PropertyTruncated && p.GetData() == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func TestTruncate(t *testing.T) {
+	t.Run("fits", func(t *testing.T) {
+		doc := truncateTestDocument()
+		report, err := doc.Truncate(MiB, nil)
+		require.NoError(t, err)
+		require.Empty(t, report.RemovedFiles)
+		require.Empty(t, doc.Metadata.Properties)
+	})
+
+	t.Run("removes the largest files first", func(t *testing.T) {
+		doc := truncateTestDocument()
+		full := Bytes(proto.Size(doc))
+		report, err := doc.Truncate(full-100, nil)
+		require.NoError(t, err)
+		require.LessOrEqual(t, report.Size, full-100)
+		require.NotEmpty(t, report.RemovedFiles)
+		require.Less(t, len(report.RemovedFiles), 20)
+		require.Equal(t, "file-19", report.RemovedFiles[0])
+		require.Empty(t, report.TrimmedDescriptions)
+		require.Nil(t, doc.NodeList.GetNodeByID("file-19"))
+		require.Equal(t, []string{"lib"}, doc.Vulnerabilities[0].Affects)
+		require.True(t, hasTruncationProperty(doc.NodeList.GetNodeByID("lib").Properties, TruncatedFiles))
+		require.Len(t, doc.Metadata.Properties, 1)
+		require.True(t, hasTruncationProperty(doc.Metadata.Properties, TruncatedFiles))
+	})
+
+	t.Run("dev subtrees last", func(t *testing.T) {
+		doc := truncateTestDocument()
+		files := []string{}
+		for _, f := range doc.NodeList.FilesOf("lib") {
+			files = append(files, f.Id)
+		}
+		doc.NodeList.RemoveNodes(files)
+		doc.Vulnerabilities = nil
+
+		// Budget one byte under the size without descriptions
+		trimmed := proto.Clone(doc).(*Document) //nolint:errcheck,forcetypeassert
+		_, err := trimmed.Truncate(0, &TruncateStrategy{Steps: []TruncationStep{TruncateDescriptions}})
+		require.ErrorIs(t, err, ErrOverBudget)
+
+		report, err := doc.Truncate(Bytes(proto.Size(trimmed))-1, nil)
+		require.NoError(t, err)
+		require.Equal(t, []string{"app", "lib"}, report.TrimmedDescriptions)
+		require.Equal(t, []string{"linter", "linter-dep"}, report.RemovedDevNodes)
+		require.NotNil(t, doc.NodeList.GetNodeByID("shared"))
+		require.True(t, hasTruncationProperty(doc.NodeList.GetNodeByID("app").Properties, TruncatedDevDependencies))
+		require.Len(t, doc.Metadata.Properties, 2)
+	})
+
+	t.Run("over budget", func(t *testing.T) {
+		doc := truncateTestDocument()
+		report, err := doc.Truncate(10, &TruncateStrategy{Steps: []TruncationStep{TruncateDescriptions}})
+		require.ErrorIs(t, err, ErrOverBudget)
+		require.Len(t, report.TrimmedDescriptions, 2)
+		require.Empty(t, report.RemovedFiles)
+	})
+
+	t.Run("custom size", func(t *testing.T) {
+		doc := truncateTestDocument()
+		report, err := doc.Truncate(100, &TruncateStrategy{
+			Steps: DefaultTruncateStrategy.Steps,
+			Size: func(d *Document) (Bytes, error) {
+				return Bytes(len(d.NodeList.Nodes) * 10), nil
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, report.RemovedFiles, 15)
+		require.Equal(t, Bytes(100), report.Size)
+	})
+}
+
+func TestTruncateDevSubtreeDirection(t *testing.T) {
+	for name, tc := range map[string]struct {
+		relationship string
+		removed      []string
+	}{
+		"dev dependency of":  {"DEV_DEPENDENCY_OF", []string{"linter", "linter-dep"}},
+		"test dependency of": {"TEST_DEPENDENCY_OF", []string{"linter", "linter-dep"}},
+		"dev tool of":        {"DEV_TOOL_OF", []string{"linter", "linter-dep"}},
+		"test tool of":       {"TEST_TOOL_OF", []string{"linter", "linter-dep"}},
+		"runtime dependency": {"RUNTIME_DEPENDENCY_OF", []string{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// app and the linter dependency are related with SPDX
+			// relationships, linter RELATIONSHIP app
+			doc := NewDocument()
+			doc.NodeList.AddRootNode(&Node{Id: "app", Name: "app"})
+			doc.NodeList.AddNode(&Node{Id: "lib", Name: "lib"})
+			doc.NodeList.AddNode(&Node{Id: "linter", Name: "linter", Summary: strings.Repeat("s", 200)})
+			doc.NodeList.AddNode(&Node{Id: "linter-dep", Name: "linter-dep"})
+			doc.NodeList.AddEdge(&Edge{Type: EdgeTypeFromSPDX2("DEPENDS_ON"), From: "app", To: []string{"lib"}})
+			doc.NodeList.AddEdge(&Edge{Type: EdgeTypeFromSPDX2(tc.relationship), From: "linter", To: []string{"app"}})
+			doc.NodeList.AddEdge(&Edge{Type: EdgeTypeFromSPDX2("DEPENDENCY_OF"), From: "linter-dep", To: []string{"linter"}})
+
+			report, err := doc.Truncate(0, &TruncateStrategy{Steps: []TruncationStep{TruncateDevSubtrees}})
+			require.ErrorIs(t, err, ErrOverBudget)
+			require.Equal(t, tc.removed, report.RemovedDevNodes)
+
+			// The dependents of the dev dependency are never removed
+			require.NotNil(t, doc.NodeList.GetNodeByID("app"))
+			require.NotNil(t, doc.NodeList.GetNodeByID("lib"))
+			require.Equal(t, len(tc.removed) > 0, hasTruncationProperty(doc.NodeList.GetNodeByID("app").Properties, TruncatedDevDependencies))
+		})
+	}
+}
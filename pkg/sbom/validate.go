@@ -0,0 +1,181 @@
+package sbom
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ValidationIssue describes a problem found when validating a document
+type ValidationIssue struct {
+	// NodeID is the ID of the node with the issue. Empty for document-level issues.
+	NodeID string
+
+	// Field is the name of the field with the issue
+	Field string
+
+	// Message describes the issue
+	Message string
+}
+
+// String returns a human readable representation of the issue
+func (vi *ValidationIssue) String() string {
+	if vi.NodeID != "" {
+		return fmt.Sprintf("node %s: %s: %s", vi.NodeID, vi.Field, vi.Message)
+	}
+	return fmt.Sprintf("%s: %s", vi.Field, vi.Message)
+}
+
+// ValidateOptions controls the document validation
+type ValidateOptions struct {
+	// Clock returns the current time used to detect timestamps in the
+	// future. Defaults to time.Now.
+	Clock func() time.Time
+
+	// ClockSkew is the tolerance allowed when comparing timestamps with
+	// the current time.
+	ClockSkew time.Duration
+}
+
+// DefaultValidateOptions is the set of options used when none are specified
+var DefaultValidateOptions = &ValidateOptions{
+	Clock:     time.Now,
+	ClockSkew: 5 * time.Minute,
+}
+
+// now returns the current time according to the options clock
+func (o *ValidateOptions) now() time.Time {
+	if o == nil || o.Clock == nil {
+		return time.Now()
+	}
+	return o.Clock()
+}
+
+// Validate checks the document and returns the issues found. An empty list
+// means the document is valid.
+func (d *Document) Validate(opts *ValidateOptions) []*ValidationIssue {
+	if opts == nil {
+		opts = DefaultValidateOptions
+	}
+	issues := d.validateTimestamps(opts)
+	if d.GetNodeList() != nil {
+		issues = append(issues, d.NodeList.ValidateHashes()...)
+		issues = append(issues, d.NodeList.ValidateIdentifiers()...)
+		issues = append(issues, d.NodeList.ValidateEdges()...)
+	}
+	return issues
+}
+
+// ValidateHashes checks that the hash values of the nodes and their
+// external references match the length and encoding of their algorithms.
+func (nl *NodeList) ValidateHashes() []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	for _, n := range nl.GetNodes() {
+		issues = append(issues, hashIssues(n.Id, "hashes", n.Hashes)...)
+		for _, er := range n.ExternalReferences {
+			issues = append(issues, hashIssues(n.Id, "external_references.hashes", er.Hashes)...)
+		}
+	}
+	return issues
+}
+
+// ValidateIdentifiers checks the syntax of the node identifiers with a well
+// defined format, such as gitoids and SWHIDs.
+func (nl *NodeList) ValidateIdentifiers() []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	for _, n := range nl.GetNodes() {
+		for _, t := range slices.Sorted(maps.Keys(n.Identifiers)) {
+			if err := SoftwareIdentifierType(t).ValidateValue(n.Identifiers[t]); err != nil {
+				issues = append(issues, &ValidationIssue{NodeID: n.Id, Field: "identifiers", Message: err.Error()})
+			}
+		}
+	}
+	return issues
+}
+
+// DropInvalidHashes removes the hashes of the nodes and their external
+// references that fail validation. It returns the number of hashes removed.
+func (nl *NodeList) DropInvalidHashes() int {
+	dropped := 0
+	for _, n := range nl.GetNodes() {
+		dropped += dropInvalidHashes(n.Hashes)
+		for _, er := range n.ExternalReferences {
+			dropped += dropInvalidHashes(er.Hashes)
+		}
+	}
+	return dropped
+}
+
+// hashIssues returns the validation issues of a hash map, sorted by algorithm
+func hashIssues(nodeID, field string, hashes map[int32]string) []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	for _, algo := range slices.Sorted(maps.Keys(hashes)) {
+		if err := HashAlgorithm(algo).ValidateValue(hashes[algo]); err != nil {
+			issues = append(issues, &ValidationIssue{NodeID: nodeID, Field: field, Message: err.Error()})
+		}
+	}
+	return issues
+}
+
+// dropInvalidHashes deletes the invalid hashes from the map
+func dropInvalidHashes(hashes map[int32]string) int {
+	dropped := 0
+	for algo, value := range hashes {
+		if HashAlgorithm(algo).ValidateValue(value) != nil {
+			delete(hashes, algo)
+			dropped++
+		}
+	}
+	return dropped
+}
+
+// timestampProblem returns a description of the problem with a timestamp or
+// an empty string if it is valid. Missing timestamps are reported only when
+// required is true.
+func timestampProblem(ts *timestamppb.Timestamp, required, allowFuture bool, now time.Time, skew time.Duration) string {
+	switch {
+	case ts == nil:
+		if required {
+			return "timestamp is missing"
+		}
+		return ""
+	case !ts.IsValid():
+		return "timestamp is invalid"
+	case ts.GetSeconds() <= 0:
+		return fmt.Sprintf("timestamp is zero or before the epoch (%s)", ts.AsTime().Format(time.RFC3339))
+	case !allowFuture && ts.AsTime().After(now.Add(skew)):
+		return fmt.Sprintf("timestamp is in the future (%s)", ts.AsTime().Format(time.RFC3339))
+	}
+	return ""
+}
+
+// validateTimestamps flags missing, zero and future creation timestamps in
+// the document metadata and invalid dates in the nodes.
+func (d *Document) validateTimestamps(opts *ValidateOptions) []*ValidationIssue {
+	issues := []*ValidationIssue{}
+	now := opts.now()
+
+	if p := timestampProblem(d.GetMetadata().GetDate(), true, false, now, opts.ClockSkew); p != "" {
+		issues = append(issues, &ValidationIssue{Field: "metadata.date", Message: p})
+	}
+
+	for _, n := range d.GetNodeList().GetNodes() {
+		for _, f := range []struct {
+			name        string
+			ts          *timestamppb.Timestamp
+			allowFuture bool
+		}{
+			{"release_date", n.ReleaseDate, false},
+			{"build_date", n.BuildDate, false},
+			{"valid_until_date", n.ValidUntilDate, true},
+		} {
+			if p := timestampProblem(f.ts, false, f.allowFuture, now, opts.ClockSkew); p != "" {
+				issues = append(issues, &ValidationIssue{NodeID: n.Id, Field: f.name, Message: p})
+			}
+		}
+	}
+	return issues
+}
@@ -0,0 +1,234 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testTimestampDocument(now time.Time) *Document {
+	return &Document{
+		Metadata: &Metadata{Id: "doc", Date: timestamppb.New(time.Unix(0, 0))},
+		NodeList: &NodeList{
+			Nodes: []*Node{
+				{
+					Id:             "good",
+					ReleaseDate:    timestamppb.New(now.Add(-24 * time.Hour)),
+					ValidUntilDate: timestamppb.New(now.Add(24 * time.Hour)),
+				},
+				{
+					Id:          "bad",
+					ReleaseDate: timestamppb.New(now.Add(24 * time.Hour)),
+					BuildDate:   timestamppb.New(time.Unix(0, 0)),
+				},
+			},
+		},
+	}
+}
+
+func TestValidateTimestamps(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("document", func(t *testing.T) {
+		issues := testTimestampDocument(now).Validate(&ValidateOptions{Clock: func() time.Time { return now }})
+		fields := []string{}
+		for _, i := range issues {
+			fields = append(fields, i.NodeID+":"+i.Field)
+		}
+		require.Equal(t, []string{":metadata.date", "bad:release_date", "bad:build_date"}, fields)
+		require.Contains(t, issues[1].Message, "future")
+	})
+
+	for name, tc := range map[string]struct {
+		date     *timestamppb.Timestamp
+		expected int
+	}{
+		"missing":       {nil, 1},
+		"epoch":         {timestamppb.New(time.Unix(0, 0)), 1},
+		"future":        {timestamppb.New(now.Add(time.Hour)), 1},
+		"within skew":   {timestamppb.New(now.Add(time.Minute)), 0},
+		"valid":         {timestamppb.New(now.Add(-time.Hour)), 0},
+		"invalid nanos": {&timestamppb.Timestamp{Seconds: 1, Nanos: -1}, 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := &Document{Metadata: &Metadata{Date: tc.date}}
+			issues := doc.Validate(&ValidateOptions{
+				Clock: func() time.Time { return now }, ClockSkew: 5 * time.Minute,
+			})
+			require.Len(t, issues, tc.expected)
+		})
+	}
+}
+
+func TestNormalizeTimestamps(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name   string
+		opts   *NormalizeOptions
+		issues int
+		date   time.Time
+		// removed has the node dates cleared by the repair
+		removed bool
+	}{
+		{
+			// Without repairing, the document is not modified
+			name:   "no repair",
+			opts:   &NormalizeOptions{},
+			issues: 3,
+			date:   time.Unix(0, 0).UTC(),
+		},
+		{
+			name:    "repair",
+			opts:    &NormalizeOptions{RepairTimestamps: true, Clock: func() time.Time { return now }},
+			date:    now,
+			removed: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := testTimestampDocument(now)
+			doc.Normalize(tc.opts)
+			require.Len(t, doc.Validate(&ValidateOptions{Clock: func() time.Time { return now }}), tc.issues)
+			require.Equal(t, tc.date, doc.Metadata.Date.AsTime())
+
+			bad := doc.NodeList.GetNodeByID("bad")
+			require.Equal(t, tc.removed, bad.ReleaseDate == nil)
+			require.Equal(t, tc.removed, bad.BuildDate == nil)
+			require.NotNil(t, doc.NodeList.GetNodeByID("good").ReleaseDate)
+			require.NotNil(t, doc.NodeList.GetNodeByID("good").ValidUntilDate)
+		})
+	}
+}
+
+func TestNormalizeStrings(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		opts     *NormalizeOptions
+		input    string
+		expected string
+	}{
+		{name: "sanitize disabled", opts: &NormalizeOptions{}, input: "\uFEFFbad\x00name", expected: "\uFEFFbad\x00name"},
+		{name: "byte order mark", opts: &NormalizeOptions{SanitizeStrings: true}, input: "\uFEFFmy-sbom", expected: "my-sbom"},
+		{name: "control characters", opts: &NormalizeOptions{SanitizeStrings: true}, input: "bad\x00name\x1b\x07", expected: "badname"},
+		{name: "invalid utf-8", opts: &NormalizeOptions{SanitizeStrings: true}, input: "caf\xe9", expected: "caf�"},
+		{name: "whitespace is kept", opts: &NormalizeOptions{SanitizeStrings: true}, input: "line one\nline two\ttabbed", expected: "line one\nline two\ttabbed"},
+		{name: "non ascii is kept", opts: &NormalizeOptions{SanitizeStrings: true}, input: "日本\x00語 Überprüfung", expected: "日本語 Überprüfung"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := &Document{
+				Metadata: &Metadata{Name: tc.input, Comment: tc.input},
+				NodeList: &NodeList{
+					Nodes: []*Node{
+						{
+							Id:          "node",
+							Name:        tc.input,
+							Description: tc.input,
+							Attribution: []string{tc.input, "ACME Corp"},
+							Hashes:      map[int32]string{int32(HashAlgorithm_SHA256): tc.input},
+							Suppliers:   []*Person{{Name: tc.input}},
+						},
+					},
+				},
+			}
+
+			doc.Normalize(tc.opts)
+			require.Equal(t, tc.expected, doc.Metadata.Name)
+			require.Equal(t, tc.expected, doc.Metadata.Comment)
+			node := doc.NodeList.Nodes[0]
+			require.Equal(t, tc.expected, node.Name)
+			require.Equal(t, tc.expected, node.Description)
+			require.Equal(t, []string{tc.expected, "ACME Corp"}, node.Attribution)
+			require.Equal(t, tc.expected, node.Hashes[int32(HashAlgorithm_SHA256)])
+			require.Equal(t, tc.expected, node.Suppliers[0].Name)
+		})
+	}
+}
+
+func TestValidateHashes(t *testing.T) {
+	sha1 := "da39a3ee5e6b4b0d3255bfef95601890afd80709"
+	md5 := "d41d8cd98f00b204e9800998ecf8427e"
+	for _, tc := range []struct {
+		name      string
+		sut       *Node
+		issues    []*ValidationIssue
+		remaining map[int32]string
+	}{
+		{
+			name: "valid hashes",
+			sut: &Node{Id: "node", Hashes: map[int32]string{
+				int32(HashAlgorithm_SHA1): sha1,
+				// Algorithms without a known length are not checked
+				int32(HashAlgorithm_MD6): "0123",
+			}},
+			issues: []*ValidationIssue{},
+			remaining: map[int32]string{
+				int32(HashAlgorithm_SHA1): sha1,
+				int32(HashAlgorithm_MD6):  "0123",
+			},
+		},
+		{
+			name: "not hexadecimal",
+			sut: &Node{Id: "node", Hashes: map[int32]string{
+				int32(HashAlgorithm_SHA1): sha1,
+				int32(HashAlgorithm_MD5):  "not-a-hash-not-a-hash-not-a-hash",
+			}},
+			issues: []*ValidationIssue{
+				{NodeID: "node", Field: "hashes", Message: "MD5 value is not a hexadecimal string"},
+			},
+			remaining: map[int32]string{int32(HashAlgorithm_SHA1): sha1},
+		},
+		{
+			name: "wrong length",
+			sut:  &Node{Id: "node", Hashes: map[int32]string{int32(HashAlgorithm_SHA256): md5}},
+			issues: []*ValidationIssue{
+				{NodeID: "node", Field: "hashes", Message: "SHA256 value has 32 hex digits, expected 64 (length matches MD5, MD2, MD4)"},
+			},
+			remaining: map[int32]string{},
+		},
+		{
+			name: "external reference",
+			sut: &Node{Id: "node", ExternalReferences: []*ExternalReference{
+				{Url: "https://example.com/", Hashes: map[int32]string{int32(HashAlgorithm_SHA1): md5}},
+			}},
+			issues: []*ValidationIssue{
+				{NodeID: "node", Field: "external_references.hashes", Message: "SHA1 value has 32 hex digits, expected 40 (length matches MD5, MD2, MD4)"},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			nl := &NodeList{Nodes: []*Node{tc.sut}}
+			require.Equal(t, tc.issues, nl.ValidateHashes())
+
+			require.Equal(t, len(tc.issues), nl.DropInvalidHashes())
+			require.Empty(t, nl.ValidateHashes())
+			if tc.remaining != nil {
+				require.Equal(t, tc.remaining, tc.sut.Hashes)
+			}
+			for _, er := range tc.sut.ExternalReferences {
+				require.Empty(t, er.Hashes)
+			}
+		})
+	}
+}
+
+func TestValidateSSDEEP(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		value   string
+		mustErr bool
+	}{
+		{name: "valid", value: "3:AXGBicFlgVNhBGcL6wCrFQEv:AXGHsNhxLsr2C"},
+		{name: "hex digest", value: "e3b0c44298fc1c149afbf4c8996fb924", mustErr: true},
+		{name: "non numeric block size", value: "x:AXGB:AXGH", mustErr: true},
+		{name: "empty hash", value: "3::AXGH", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := HashAlgorithm_SSDEEP.ValidateValue(tc.value)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
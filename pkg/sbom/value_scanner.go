@@ -14,6 +14,22 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+func (x *AIModel) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *AIModel) Scan(src any) error {
+	return scan(src, x)
+}
+
+func (x *Dataset) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *Dataset) Scan(src any) error {
+	return scan(src, x)
+}
+
 func (x *Document) Value() (driver.Value, error) {
 	return value(x)
 }
@@ -46,6 +62,14 @@ func (x *ExternalReference) Scan(src any) error {
 	return scan(src, x)
 }
 
+func (x *License) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *License) Scan(src any) error {
+	return scan(src, x)
+}
+
 func (x *Metadata) Value() (driver.Value, error) {
 	return value(x)
 }
@@ -70,6 +94,22 @@ func (x *NodeList) Scan(src any) error {
 	return scan(src, x)
 }
 
+func (x *NodeSelector) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *NodeSelector) Scan(src any) error {
+	return scan(src, x)
+}
+
+func (x *Overlay) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *Overlay) Scan(src any) error {
+	return scan(src, x)
+}
+
 func (x *Person) Value() (driver.Value, error) {
 	return value(x)
 }
@@ -86,6 +126,22 @@ func (x *Property) Scan(src any) error {
 	return scan(src, x)
 }
 
+func (x *Revision) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *Revision) Scan(src any) error {
+	return scan(src, x)
+}
+
+func (x *Signature) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *Signature) Scan(src any) error {
+	return scan(src, x)
+}
+
 func (x *SourceData) Value() (driver.Value, error) {
 	return value(x)
 }
@@ -102,6 +158,14 @@ func (x *Tool) Scan(src any) error {
 	return scan(src, x)
 }
 
+func (x *Vulnerability) Value() (driver.Value, error) {
+	return value(x)
+}
+
+func (x *Vulnerability) Scan(src any) error {
+	return scan(src, x)
+}
+
 func value(msg proto.Message) (driver.Value, error) {
 	return proto.MarshalOptions{Deterministic: true}.Marshal(msg)
 }
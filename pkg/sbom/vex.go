@@ -0,0 +1,178 @@
+package sbom
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// VEXStatus is the status of a product with respect to a vulnerability as
+// defined by the VEX (Vulnerability Exploitability eXchange) specification.
+type VEXStatus string
+
+const (
+	VEXStatusNotAffected        VEXStatus = "not_affected"
+	VEXStatusAffected           VEXStatus = "affected"
+	VEXStatusFixed              VEXStatus = "fixed"
+	VEXStatusUnderInvestigation VEXStatus = "under_investigation"
+)
+
+// vexStatusStates maps the VEX statuses to the analysis state recorded
+var vexStatusStates = map[VEXStatus]Vulnerability_Analysis_State{
+	VEXStatusNotAffected:        Vulnerability_Analysis_NOT_AFFECTED,
+	VEXStatusAffected:           Vulnerability_Analysis_EXPLOITABLE,
+	VEXStatusFixed:              Vulnerability_Analysis_RESOLVED,
+	VEXStatusUnderInvestigation: Vulnerability_Analysis_IN_TRIAGE,
+}
+
+// justificationsCDX maps the justifications to the CycloneDX impact
+// analysis justifications
+var justificationsCDX = map[Vulnerability_Analysis_Justification]cdx.ImpactAnalysisJustification{
+	Vulnerability_Analysis_CODE_NOT_PRESENT:                cdx.IAJCodeNotPresent,
+	Vulnerability_Analysis_CODE_NOT_REACHABLE:              cdx.IAJCodeNotReachable,
+	Vulnerability_Analysis_REQUIRES_CONFIGURATION:          cdx.IAJRequiresConfiguration,
+	Vulnerability_Analysis_REQUIRES_DEPENDENCY:             cdx.IAJRequiresDependency,
+	Vulnerability_Analysis_REQUIRES_ENVIRONMENT:            cdx.IAJRequiresEnvironment,
+	Vulnerability_Analysis_PROTECTED_BY_COMPILER:           cdx.IAJProtectedByCompiler,
+	Vulnerability_Analysis_PROTECTED_AT_RUNTIME:            cdx.IAJProtectedAtRuntime,
+	Vulnerability_Analysis_PROTECTED_AT_PERIMETER:          cdx.IAJProtectedAtPerimeter,
+	Vulnerability_Analysis_PROTECTED_BY_MITIGATING_CONTROL: cdx.IAJProtectedByMitigatingControl,
+}
+
+// responsesCDX maps the responses to the CycloneDX impact analysis responses
+var responsesCDX = map[Vulnerability_Analysis_Response]cdx.ImpactAnalysisResponse{
+	Vulnerability_Analysis_CAN_NOT_FIX:          cdx.IARCanNotFix,
+	Vulnerability_Analysis_WILL_NOT_FIX:         cdx.IARWillNotFix,
+	Vulnerability_Analysis_UPDATE:               cdx.IARUpdate,
+	Vulnerability_Analysis_ROLLBACK:             cdx.IARRollback,
+	Vulnerability_Analysis_WORKAROUND_AVAILABLE: cdx.IARWorkaroundAvailable,
+}
+
+// AnalysisState returns the analysis state recorded for a VEX status
+func (s VEXStatus) AnalysisState() Vulnerability_Analysis_State {
+	return vexStatusStates[s]
+}
+
+// VEXStatus returns the VEX status of an analysis state. RESOLVED states
+// are fixed, EXPLOITABLE is affected, IN_TRIAGE is under investigation and
+// both NOT_AFFECTED and FALSE_POSITIVE are not affected. It returns an empty
+// status for UNKNOWN_STATE.
+func (s Vulnerability_Analysis_State) VEXStatus() VEXStatus {
+	switch s {
+	case Vulnerability_Analysis_NOT_AFFECTED, Vulnerability_Analysis_FALSE_POSITIVE:
+		return VEXStatusNotAffected
+	case Vulnerability_Analysis_EXPLOITABLE:
+		return VEXStatusAffected
+	case Vulnerability_Analysis_RESOLVED, Vulnerability_Analysis_RESOLVED_WITH_PEDIGREE:
+		return VEXStatusFixed
+	case Vulnerability_Analysis_IN_TRIAGE:
+		return VEXStatusUnderInvestigation
+	default:
+		return ""
+	}
+}
+
+// JustificationFromCDX converts a CycloneDX impact analysis justification
+func JustificationFromCDX(j cdx.ImpactAnalysisJustification) Vulnerability_Analysis_Justification {
+	for pj, cj := range justificationsCDX {
+		if cj == j {
+			return pj
+		}
+	}
+	return Vulnerability_Analysis_UNKNOWN_JUSTIFICATION
+}
+
+// ToCycloneDX returns the CycloneDX impact analysis justification
+func (j Vulnerability_Analysis_Justification) ToCycloneDX() cdx.ImpactAnalysisJustification {
+	return justificationsCDX[j]
+}
+
+// ResponseFromCDX converts a CycloneDX impact analysis response
+func ResponseFromCDX(r cdx.ImpactAnalysisResponse) Vulnerability_Analysis_Response {
+	for pr, cr := range responsesCDX {
+		if cr == r {
+			return pr
+		}
+	}
+	return Vulnerability_Analysis_UNKNOWN_RESPONSE
+}
+
+// ToCycloneDX returns the CycloneDX impact analysis response
+func (r Vulnerability_Analysis_Response) ToCycloneDX() cdx.ImpactAnalysisResponse {
+	return responsesCDX[r]
+}
+
+// NewVEXAnalysis returns an analysis with the state of a VEX status
+func NewVEXAnalysis(status VEXStatus, justification Vulnerability_Analysis_Justification, detail string) *Vulnerability_Analysis {
+	return &Vulnerability_Analysis{
+		State:         status.AnalysisState(),
+		Justification: justification,
+		Detail:        detail,
+	}
+}
+
+// AnalysisFor returns the analysis of the vulnerability that applies to a
+// node: the node analysis if there is one, the vulnerability analysis
+// otherwise. It returns nil if the vulnerability has not been analyzed.
+func (v *Vulnerability) AnalysisFor(nodeID string) *Vulnerability_Analysis {
+	if a, ok := v.GetNodeAnalyses()[nodeID]; ok {
+		return a
+	}
+	return v.GetAnalysis()
+}
+
+// SetAnalysis records the analysis of a vulnerability in the document. With
+// an empty nodeID, the analysis applies to all the nodes affected by the
+// vulnerability, otherwise it applies only to the node, which is added to
+// the affected nodes if missing.
+//
+// The analysis update time is set to now and, if a previous analysis is
+// replaced, its first issued date is kept.
+func (d *Document) SetAnalysis(vulnID, nodeID string, a *Vulnerability_Analysis) error {
+	return d.SetAnalysisAt(vulnID, nodeID, a, time.Now())
+}
+
+// SetAnalysisAt is SetAnalysis recording now as the update time
+func (d *Document) SetAnalysisAt(vulnID, nodeID string, a *Vulnerability_Analysis, now time.Time) error {
+	v := d.GetVulnerability(vulnID)
+	if v == nil {
+		return fmt.Errorf("vulnerability %q not found in document", vulnID)
+	}
+	if a == nil {
+		return fmt.Errorf("no analysis to set for %q", vulnID)
+	}
+	if nodeID != "" && d.GetNodeList().GetNodeByID(nodeID) == nil {
+		return fmt.Errorf("node %q not found in document", nodeID)
+	}
+
+	previous := v.Analysis
+	if nodeID != "" {
+		previous = v.GetNodeAnalyses()[nodeID]
+	}
+
+	a = a.Copy()
+	a.LastUpdated = timestamppb.New(now)
+	switch {
+	case a.FirstIssued != nil:
+	case previous.GetFirstIssued() != nil:
+		a.FirstIssued = previous.GetFirstIssued()
+	default:
+		a.FirstIssued = timestamppb.New(now)
+	}
+
+	if nodeID == "" {
+		v.Analysis = a
+		return nil
+	}
+	if v.NodeAnalyses == nil {
+		v.NodeAnalyses = map[string]*Vulnerability_Analysis{}
+	}
+	v.NodeAnalyses[nodeID] = a
+	if !slices.Contains(v.Affects, nodeID) {
+		v.Affects = append(v.Affects, nodeID)
+	}
+	return nil
+}
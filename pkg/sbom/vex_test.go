@@ -0,0 +1,150 @@
+package sbom
+
+import (
+	"testing"
+	"time"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVEXStatus(t *testing.T) {
+	for state, status := range map[Vulnerability_Analysis_State]VEXStatus{
+		Vulnerability_Analysis_UNKNOWN_STATE:          "",
+		Vulnerability_Analysis_NOT_AFFECTED:           VEXStatusNotAffected,
+		Vulnerability_Analysis_FALSE_POSITIVE:         VEXStatusNotAffected,
+		Vulnerability_Analysis_EXPLOITABLE:            VEXStatusAffected,
+		Vulnerability_Analysis_RESOLVED:               VEXStatusFixed,
+		Vulnerability_Analysis_RESOLVED_WITH_PEDIGREE: VEXStatusFixed,
+		Vulnerability_Analysis_IN_TRIAGE:              VEXStatusUnderInvestigation,
+	} {
+		t.Run(state.String(), func(t *testing.T) {
+			require.Equal(t, status, state.VEXStatus())
+			if status != "" {
+				require.Equal(t, status, status.AnalysisState().VEXStatus())
+			}
+		})
+	}
+}
+
+func TestJustificationsCDX(t *testing.T) {
+	for j := range justificationsCDX {
+		t.Run(j.String(), func(t *testing.T) {
+			require.Equal(t, j, JustificationFromCDX(j.ToCycloneDX()))
+		})
+	}
+	for r := range responsesCDX {
+		t.Run(r.String(), func(t *testing.T) {
+			require.Equal(t, r, ResponseFromCDX(r.ToCycloneDX()))
+		})
+	}
+	t.Run("unknown justification", func(t *testing.T) {
+		require.Equal(t, Vulnerability_Analysis_UNKNOWN_JUSTIFICATION, JustificationFromCDX(""))
+	})
+	t.Run("unknown response", func(t *testing.T) {
+		require.Equal(t, Vulnerability_Analysis_UNKNOWN_RESPONSE, ResponseFromCDX(cdx.ImpactAnalysisResponse("bogus")))
+	})
+}
+
+func TestSetAnalysis(t *testing.T) {
+	t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name string
+		// prepare records the analyses set before the one under test
+		prepare  func(*testing.T, *Document)
+		id       string
+		node     string
+		analysis *Vulnerability_Analysis
+		mustErr  bool
+		affects  []string
+		statuses map[string]VEXStatus
+		detail   string
+		issued   time.Time
+	}{
+		{
+			name:     "unknown vulnerability",
+			id:       "CVE-2024-9999",
+			analysis: NewVEXAnalysis(VEXStatusAffected, 0, ""),
+			mustErr:  true,
+		},
+		{
+			name:     "unknown node",
+			id:       "CVE-2024-0001",
+			node:     "c",
+			analysis: NewVEXAnalysis(VEXStatusAffected, 0, ""),
+			mustErr:  true,
+		},
+		{
+			name:    "nil analysis",
+			id:      "CVE-2024-0001",
+			mustErr: true,
+		},
+		{
+			name:     "document analysis",
+			id:       "CVE-2024-0001",
+			analysis: NewVEXAnalysis(VEXStatusUnderInvestigation, 0, ""),
+			affects:  []string{"a"},
+			statuses: map[string]VEXStatus{"a": VEXStatusUnderInvestigation},
+			issued:   t2,
+		},
+		{
+			name: "node analysis",
+			prepare: func(t *testing.T, doc *Document) {
+				t.Helper()
+				require.NoError(t, doc.SetAnalysisAt("CVE-2024-0001", "", NewVEXAnalysis(VEXStatusUnderInvestigation, 0, ""), t1))
+			},
+			id:       "CVE-2024-0001",
+			node:     "b",
+			analysis: NewVEXAnalysis(VEXStatusNotAffected, Vulnerability_Analysis_CODE_NOT_PRESENT, "removed"),
+			affects:  []string{"a", "b"},
+			statuses: map[string]VEXStatus{"a": VEXStatusUnderInvestigation, "b": VEXStatusNotAffected},
+			detail:   "removed",
+			issued:   t2,
+		},
+		{
+			name: "update keeps the first issued date",
+			prepare: func(t *testing.T, doc *Document) {
+				t.Helper()
+				require.NoError(t, doc.SetAnalysisAt("CVE-2024-0001", "", NewVEXAnalysis(VEXStatusUnderInvestigation, 0, ""), t1))
+				require.NoError(t, doc.SetAnalysisAt(
+					"CVE-2024-0001", "b", NewVEXAnalysis(VEXStatusNotAffected, Vulnerability_Analysis_CODE_NOT_PRESENT, "removed"), t1,
+				))
+			},
+			id:       "CVE-2024-0001",
+			analysis: NewVEXAnalysis(VEXStatusAffected, 0, ""),
+			affects:  []string{"a", "b"},
+			statuses: map[string]VEXStatus{"a": VEXStatusAffected, "b": VEXStatusNotAffected},
+			detail:   "removed",
+			issued:   t1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.NodeList.AddNode(&Node{Id: "a"})
+			doc.NodeList.AddNode(&Node{Id: "b"})
+			doc.AddVulnerability(&Vulnerability{Id: "CVE-2024-0001", Affects: []string{"a"}})
+			if tc.prepare != nil {
+				tc.prepare(t, doc)
+			}
+
+			err := doc.SetAnalysisAt(tc.id, tc.node, tc.analysis, t2)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			v := doc.GetVulnerability(tc.id)
+			require.Equal(t, tc.affects, v.Affects)
+			for node, status := range tc.statuses {
+				require.Equal(t, status, v.AnalysisFor(node).GetState().VEXStatus())
+			}
+			require.Equal(t, tc.detail, v.AnalysisFor("b").GetDetail())
+
+			updated := v.AnalysisFor(tc.node)
+			require.Equal(t, tc.issued, updated.FirstIssued.AsTime())
+			require.Equal(t, t2, updated.LastUpdated.AsTime())
+		})
+	}
+}
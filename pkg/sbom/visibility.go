@@ -0,0 +1,149 @@
+package sbom
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// Visibility is an access tier of a document view. Each tier sees the data
+// of the tiers below it, from the public to the internal tier.
+type Visibility int
+
+const (
+	VisibilityPublic Visibility = iota
+	VisibilityPartner
+	VisibilityInternal
+)
+
+var visibilityNames = map[Visibility]string{
+	VisibilityPublic:   "public",
+	VisibilityPartner:  "partner",
+	VisibilityInternal: "internal",
+}
+
+// String returns the name of the tier
+func (v Visibility) String() string {
+	if s, ok := visibilityNames[v]; ok {
+		return s
+	}
+	return fmt.Sprintf("Visibility(%d)", int(v))
+}
+
+// ParseVisibility returns the tier with the specified name
+func ParseVisibility(name string) (Visibility, error) {
+	for v, s := range visibilityNames {
+		if strings.EqualFold(s, strings.TrimSpace(name)) {
+			return v, nil
+		}
+	}
+	return VisibilityPublic, fmt.Errorf("unknown visibility tier %q", name)
+}
+
+// VisibilityPolicy assigns the tier required to see the fields and node
+// properties of a document. Data not listed in the policy is public.
+type VisibilityPolicy struct {
+	// Fields maps field mask paths to the minimum tier that can see them.
+	// Paths are relative to the Document and may traverse repeated fields,
+	// as in ApplyFieldMask, eg "node_list.nodes.suppliers.email".
+	Fields map[string]Visibility
+
+	// Properties maps node property names to the minimum tier that can see
+	// them. Names ending in "*" match all the properties with that prefix,
+	// the longest match wins.
+	Properties map[string]Visibility
+}
+
+// DefaultVisibilityPolicy returns a policy that keeps contact details and
+// vulnerability analyses internal, and shares the vulnerabilities and the
+// component provenance details only with partners.
+func DefaultVisibilityPolicy() *VisibilityPolicy {
+	return &VisibilityPolicy{
+		Fields: map[string]Visibility{
+			"metadata.authors.email":              VisibilityInternal,
+			"metadata.authors.phone":              VisibilityInternal,
+			"node_list.nodes.suppliers.email":     VisibilityInternal,
+			"node_list.nodes.suppliers.phone":     VisibilityInternal,
+			"node_list.nodes.originators.email":   VisibilityInternal,
+			"node_list.nodes.originators.phone":   VisibilityInternal,
+			"vulnerabilities.analysis":            VisibilityInternal,
+			"vulnerabilities.node_analyses":       VisibilityInternal,
+			"vulnerabilities":                     VisibilityPartner,
+			"node_list.nodes.source_info":         VisibilityPartner,
+			"node_list.nodes.comment":             VisibilityPartner,
+			"node_list.nodes.license_comments":    VisibilityPartner,
+			"node_list.nodes.external_references": VisibilityPartner,
+		},
+	}
+}
+
+// propertyVisibility returns the tier required to see a property
+func (p *VisibilityPolicy) propertyVisibility(name string) Visibility {
+	if v, ok := p.Properties[name]; ok {
+		return v
+	}
+	best, bestLen := VisibilityPublic, -1
+	for pattern, v := range p.Properties {
+		prefix, ok := strings.CutSuffix(pattern, "*")
+		if ok && strings.HasPrefix(name, prefix) && len(prefix) > bestLen {
+			best, bestLen = v, len(prefix)
+		}
+	}
+	return best
+}
+
+// View returns a copy of the document with the data the tier can't see
+// removed. The document is not modified, so progressively redacted views
+// can be rendered from the same document.
+func (d *Document) View(policy *VisibilityPolicy, tier Visibility) (*Document, error) {
+	if policy == nil {
+		policy = DefaultVisibilityPolicy()
+	}
+
+	hidden := []string{}
+	for path, v := range policy.Fields {
+		if v > tier {
+			hidden = append(hidden, path)
+		}
+	}
+	// Drop the paths under a hidden field, the mask would otherwise clear
+	// only the nested fields instead of the whole field.
+	slices.Sort(hidden)
+	all := slices.Clone(hidden)
+	hidden = slices.DeleteFunc(hidden, func(path string) bool {
+		return slices.ContainsFunc(all, func(parent string) bool {
+			return strings.HasPrefix(path, parent+".")
+		})
+	})
+
+	doc, err := d.ApplyFieldMask(nil, &fieldmaskpb.FieldMask{Paths: hidden})
+	if err != nil {
+		return nil, fmt.Errorf("applying visibility policy: %w", err)
+	}
+
+	if len(policy.Properties) == 0 {
+		return doc, nil
+	}
+	for _, n := range doc.GetNodeList().GetNodes() {
+		n.Properties = slices.DeleteFunc(n.Properties, func(p *Property) bool {
+			return policy.propertyVisibility(p.GetName()) > tier
+		})
+	}
+	return doc, nil
+}
+
+// Views renders the document at every tier, keyed by tier
+func (d *Document) Views(policy *VisibilityPolicy) (map[Visibility]*Document, error) {
+	ret := map[Visibility]*Document{}
+	for _, tier := range slices.Sorted(maps.Keys(visibilityNames)) {
+		doc, err := d.View(policy, tier)
+		if err != nil {
+			return nil, err
+		}
+		ret[tier] = doc
+	}
+	return ret, nil
+}
@@ -0,0 +1,134 @@
+package sbom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestView(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		tier         Visibility
+		email        string
+		sourceInfo   string
+		properties   []string
+		vulns        int
+		withAnalysis bool
+	}{
+		{
+			name:       "public",
+			tier:       VisibilityPublic,
+			properties: []string{"team"},
+		},
+		{
+			name:       "partner",
+			tier:       VisibilityPartner,
+			sourceInfo: "built from the release branch",
+			properties: []string{"build:id", "team"},
+			vulns:      1,
+		},
+		{
+			name:         "internal",
+			tier:         VisibilityInternal,
+			email:        "john@example.com",
+			sourceInfo:   "built from the release branch",
+			properties:   []string{"build:host", "build:id", "team"},
+			vulns:        1,
+			withAnalysis: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := testFieldMaskDocument()
+			doc.NodeList.Nodes[0].SourceInfo = "built from the release branch"
+			doc.NodeList.Nodes[0].Properties = []*Property{
+				{Name: "build:host", Data: "ci-runner-42"},
+				{Name: "build:id", Data: "1234"},
+				{Name: "team", Data: "platform"},
+			}
+			doc.AddVulnerability(&Vulnerability{
+				Id:       "CVE-2024-0001",
+				Affects:  []string{"root"},
+				Analysis: &Vulnerability_Analysis{State: Vulnerability_Analysis_IN_TRIAGE},
+			})
+			policy := DefaultVisibilityPolicy()
+			policy.Properties = map[string]Visibility{
+				"build:*":    VisibilityPartner,
+				"build:host": VisibilityInternal,
+			}
+
+			views, err := doc.Views(policy)
+			require.NoError(t, err)
+			require.Len(t, views, 3)
+
+			view := views[tc.tier]
+			require.Equal(t, "John", view.Metadata.Authors[0].Name)
+			require.Equal(t, tc.email, view.Metadata.Authors[0].Email)
+			require.Equal(t, tc.sourceInfo, view.NodeList.Nodes[0].SourceInfo)
+			names := []string{}
+			for _, p := range view.NodeList.Nodes[0].Properties {
+				names = append(names, p.Name)
+			}
+			require.Equal(t, tc.properties, names)
+			require.Len(t, view.NodeList.Edges, 1)
+			require.Len(t, view.Vulnerabilities, tc.vulns)
+			if tc.vulns > 0 {
+				require.Equal(t, []string{"root"}, view.Vulnerabilities[0].Affects)
+				require.Equal(t, tc.withAnalysis, view.Vulnerabilities[0].Analysis != nil)
+			}
+
+			// The source document is not modified
+			require.Len(t, doc.NodeList.Nodes[0].Properties, 3)
+			require.NotNil(t, doc.Vulnerabilities[0].Analysis)
+		})
+	}
+}
+
+func TestViewInvalidPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy *VisibilityPolicy
+	}{
+		{
+			name:   "unknown field",
+			policy: &VisibilityPolicy{Fields: map[string]Visibility{"node_list.nodes.bogus": VisibilityInternal}},
+		},
+		{
+			name:   "unknown message",
+			policy: &VisibilityPolicy{Fields: map[string]Visibility{"bogus.name": VisibilityInternal}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			doc.NodeList.AddRootNode(&Node{Id: "root"})
+			_, err := doc.View(tc.policy, VisibilityPublic)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestParseVisibility(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		input   string
+		expect  Visibility
+		str     string
+		mustErr bool
+	}{
+		{name: "public", input: "public", expect: VisibilityPublic, str: "public"},
+		{name: "mixed case", input: "Partner", expect: VisibilityPartner, str: "partner"},
+		{name: "internal", input: "internal", expect: VisibilityInternal, str: "internal"},
+		{name: "unknown", input: "secret", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			v, err := ParseVisibility(tc.input)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, v)
+			require.Equal(t, tc.str, v.String())
+		})
+	}
+}
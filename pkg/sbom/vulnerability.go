@@ -0,0 +1,200 @@
+package sbom
+
+import (
+	"regexp"
+	"slices"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// vulnerabilityIDRe matches the identifiers of the most common
+// vulnerability databases
+var vulnerabilityIDRe = regexp.MustCompile(
+	`(CVE-\d{4}-\d{4,}|GHSA(-[23456789cfghjmpqrvwx]{4}){3}|GO-\d{4}-\d{4,}|PYSEC-\d{4}-\d+|RUSTSEC-\d{4}-\d{4,})`,
+)
+
+// analysisStatesCDX maps the analysis states to the CycloneDX impact
+// analysis states
+var analysisStatesCDX = map[Vulnerability_Analysis_State]cdx.ImpactAnalysisState{
+	Vulnerability_Analysis_RESOLVED:               cdx.IASResolved,
+	Vulnerability_Analysis_RESOLVED_WITH_PEDIGREE: cdx.IASResolvedWithPedigree,
+	Vulnerability_Analysis_EXPLOITABLE:            cdx.IASExploitable,
+	Vulnerability_Analysis_IN_TRIAGE:              cdx.IASInTriage,
+	Vulnerability_Analysis_FALSE_POSITIVE:         cdx.IASFalsePositive,
+	Vulnerability_Analysis_NOT_AFFECTED:           cdx.IASNotAffected,
+}
+
+// AnalysisStateFromCDX converts a CycloneDX impact analysis state to its
+// corresponding analysis state
+func AnalysisStateFromCDX(state cdx.ImpactAnalysisState) Vulnerability_Analysis_State {
+	for s, cdxState := range analysisStatesCDX {
+		if cdxState == state {
+			return s
+		}
+	}
+	return Vulnerability_Analysis_UNKNOWN_STATE
+}
+
+// ToCycloneDX returns the CycloneDX impact analysis state of the state. It
+// returns an empty string for UNKNOWN_STATE.
+func (s Vulnerability_Analysis_State) ToCycloneDX() cdx.ImpactAnalysisState {
+	return analysisStatesCDX[s]
+}
+
+// Copy returns a deep copy of the vulnerability
+func (v *Vulnerability) Copy() *Vulnerability {
+	if v == nil {
+		return nil
+	}
+	ret := &Vulnerability{
+		Id:             v.Id,
+		SourceName:     v.SourceName,
+		SourceUrl:      v.SourceUrl,
+		Description:    v.Description,
+		Detail:         v.Detail,
+		Recommendation: v.Recommendation,
+		Advisories:     slices.Clone(v.Advisories),
+		Affects:        slices.Clone(v.Affects),
+		Aliases:        slices.Clone(v.Aliases),
+		Analysis:       v.Analysis.Copy(),
+		Severity:       v.Severity,
+		Cwes:           slices.Clone(v.Cwes),
+	}
+	if v.NodeAnalyses != nil {
+		ret.NodeAnalyses = make(map[string]*Vulnerability_Analysis, len(v.NodeAnalyses))
+		for id, a := range v.NodeAnalyses {
+			ret.NodeAnalyses[id] = a.Copy()
+		}
+	}
+	for _, r := range v.Ratings {
+		ret.Ratings = append(ret.Ratings, r.Copy())
+	}
+	if v.Published != nil {
+		ret.Published = timestamppb.New(v.Published.AsTime())
+	}
+	if v.Updated != nil {
+		ret.Updated = timestamppb.New(v.Updated.AsTime())
+	}
+	return ret
+}
+
+// Copy returns a copy of the rating
+func (r *Vulnerability_Rating) Copy() *Vulnerability_Rating {
+	ret := &Vulnerability_Rating{
+		Source:   r.Source,
+		Severity: r.Severity,
+		Method:   r.Method,
+		Vector:   r.Vector,
+	}
+	if r.Score != nil {
+		score := *r.Score
+		ret.Score = &score
+	}
+	return ret
+}
+
+// Copy returns a copy of the analysis
+func (a *Vulnerability_Analysis) Copy() *Vulnerability_Analysis {
+	if a == nil {
+		return nil
+	}
+	ret := &Vulnerability_Analysis{
+		State:         a.State,
+		Detail:        a.Detail,
+		Justification: a.Justification,
+		Responses:     slices.Clone(a.Responses),
+	}
+	if a.FirstIssued != nil {
+		ret.FirstIssued = timestamppb.New(a.FirstIssued.AsTime())
+	}
+	if a.LastUpdated != nil {
+		ret.LastUpdated = timestamppb.New(a.LastUpdated.AsTime())
+	}
+	return ret
+}
+
+// VulnerabilityIDFromURL extracts the vulnerability identifier from the
+// URL of an advisory, such as https://nvd.nist.gov/vuln/detail/CVE-2021-44228.
+// If the URL has no known identifier, the URL itself is returned.
+func VulnerabilityIDFromURL(url string) string {
+	if id := vulnerabilityIDRe.FindString(url); id != "" {
+		return id
+	}
+	return url
+}
+
+// AdvisoryURLs returns the URLs pointing to information about the
+// vulnerability: its advisories or, if there are none, its URL in the
+// source database.
+func (v *Vulnerability) AdvisoryURLs() []string {
+	if len(v.GetAdvisories()) > 0 {
+		return v.GetAdvisories()
+	}
+	if v.GetSourceUrl() != "" {
+		return []string{v.GetSourceUrl()}
+	}
+	return nil
+}
+
+// GetVulnerability returns the vulnerability with the specified identifier
+// or nil if the document does not record it. Aliases are not considered.
+func (d *Document) GetVulnerability(id string) *Vulnerability {
+	for _, v := range d.GetVulnerabilities() {
+		if v.Id == id {
+			return v
+		}
+	}
+	return nil
+}
+
+// AddVulnerability records a vulnerability in the document. If the document
+// already has a vulnerability with the same identifier, the nodes affected,
+// advisories, aliases, weaknesses and ratings of v are merged into it and
+// its severity is normalized again.
+func (d *Document) AddVulnerability(v *Vulnerability) {
+	if v == nil {
+		return
+	}
+	existing := d.GetVulnerability(v.Id)
+	if existing == nil {
+		d.Vulnerabilities = append(d.Vulnerabilities, v)
+		return
+	}
+	existing.Affects = appendMissing(existing.Affects, v.Affects...)
+	existing.Advisories = appendMissing(existing.Advisories, v.Advisories...)
+	existing.Aliases = appendMissing(existing.Aliases, v.Aliases...)
+	existing.Cwes = appendMissing(existing.Cwes, v.Cwes...)
+	for _, r := range v.Ratings {
+		if !slices.ContainsFunc(existing.Ratings, func(er *Vulnerability_Rating) bool {
+			return er.Source == r.Source && er.Method == r.Method && er.Vector == r.Vector
+		}) {
+			existing.Ratings = append(existing.Ratings, r)
+		}
+	}
+	if existing.Severity == Vulnerability_UNKNOWN_SEVERITY {
+		existing.Severity = v.Severity
+	}
+	existing.NormalizeSeverity()
+}
+
+// GetNodeVulnerabilities returns the vulnerabilities affecting a node
+func (d *Document) GetNodeVulnerabilities(nodeID string) []*Vulnerability {
+	ret := []*Vulnerability{}
+	for _, v := range d.GetVulnerabilities() {
+		if slices.Contains(v.Affects, nodeID) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// appendMissing appends to s the values it does not contain yet
+func appendMissing[T comparable](s []T, values ...T) []T {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
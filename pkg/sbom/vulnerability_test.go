@@ -0,0 +1,122 @@
+package sbom
+
+import (
+	"testing"
+
+	cdx "github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVulnerabilityIDFromURL(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		url  string
+		id   string
+	}{
+		{"nvd", "https://nvd.nist.gov/vuln/detail/CVE-2021-44228", "CVE-2021-44228"},
+		{"github", "https://github.com/advisories/GHSA-jfh8-c2jp-5v3q", "GHSA-jfh8-c2jp-5v3q"},
+		{"go", "https://pkg.go.dev/vuln/GO-2022-0969", "GO-2022-0969"},
+		{"rustsec", "https://rustsec.org/advisories/RUSTSEC-2021-0078", "RUSTSEC-2021-0078"},
+		{"unknown", "https://example.com/advisory/42", "https://example.com/advisory/42"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.id, VulnerabilityIDFromURL(tc.url))
+		})
+	}
+}
+
+func TestAddVulnerability(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		add       []*Vulnerability
+		id        string
+		expected  *Vulnerability
+		total     int
+		nodeVulns map[string]int
+	}{
+		{
+			name: "merges the same id",
+			add: []*Vulnerability{
+				{
+					Id:         "CVE-2021-44228",
+					Affects:    []string{"a"},
+					Advisories: []string{"https://example.com/1"},
+					Ratings:    []*Vulnerability_Rating{{Source: "NVD", Method: "CVSSv31"}},
+				},
+				{
+					Id:         "CVE-2021-44228",
+					Affects:    []string{"a", "b"},
+					Advisories: []string{"https://example.com/2"},
+					Aliases:    []string{"GHSA-jfh8-c2jp-5v3q"},
+					Ratings: []*Vulnerability_Rating{
+						{Source: "NVD", Method: "CVSSv31"},
+						{Source: "GitHub", Method: "CVSSv31"},
+					},
+				},
+			},
+			id: "CVE-2021-44228",
+			expected: &Vulnerability{
+				Id:         "CVE-2021-44228",
+				Affects:    []string{"a", "b"},
+				Advisories: []string{"https://example.com/1", "https://example.com/2"},
+				Aliases:    []string{"GHSA-jfh8-c2jp-5v3q"},
+				Ratings: []*Vulnerability_Rating{
+					{Source: "NVD", Method: "CVSSv31"},
+					{Source: "GitHub", Method: "CVSSv31"},
+				},
+			},
+			total:     1,
+			nodeVulns: map[string]int{"a": 1, "b": 1, "c": 0},
+		},
+		{
+			name: "different ids",
+			add: []*Vulnerability{
+				{Id: "CVE-2021-44228", Affects: []string{"a", "b"}},
+				{Id: "CVE-2021-45046", Affects: []string{"b"}},
+			},
+			id:        "CVE-2021-45046",
+			expected:  &Vulnerability{Id: "CVE-2021-45046", Affects: []string{"b"}},
+			total:     2,
+			nodeVulns: map[string]int{"a": 1, "b": 2, "c": 0},
+		},
+		{
+			name:      "nil vulnerability",
+			add:       []*Vulnerability{nil},
+			id:        "CVE-0000-0000",
+			nodeVulns: map[string]int{"a": 0},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := NewDocument()
+			for _, v := range tc.add {
+				doc.AddVulnerability(v)
+			}
+			require.Len(t, doc.Vulnerabilities, tc.total)
+			v := doc.GetVulnerability(tc.id)
+			if tc.expected == nil {
+				require.Nil(t, v)
+			} else {
+				require.NotNil(t, v)
+				require.Equal(t, tc.expected.Affects, v.Affects)
+				require.Equal(t, tc.expected.Advisories, v.Advisories)
+				require.Equal(t, tc.expected.Aliases, v.Aliases)
+				require.Len(t, v.Ratings, len(tc.expected.Ratings))
+			}
+			for id, n := range tc.nodeVulns {
+				require.Len(t, doc.GetNodeVulnerabilities(id), n)
+			}
+		})
+	}
+}
+
+func TestAnalysisStateCDX(t *testing.T) {
+	for s, name := range Vulnerability_Analysis_State_name {
+		t.Run(name, func(t *testing.T) {
+			state := Vulnerability_Analysis_State(s)
+			require.Equal(t, state, AnalysisStateFromCDX(state.ToCycloneDX()))
+		})
+	}
+	t.Run("unknown cyclonedx state", func(t *testing.T) {
+		require.Equal(t, Vulnerability_Analysis_UNKNOWN_STATE, AnalysisStateFromCDX(cdx.ImpactAnalysisState("bogus")))
+	})
+}
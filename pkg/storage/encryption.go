@@ -0,0 +1,204 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encrypted documents are sealed with envelope encryption: each document is
+// encrypted with AES-256-GCM using a fresh data key, and the data key is
+// stored next to it wrapped by a KeyProvider. The layout is:
+//
+//	magic[8] keyIDLen(u16) keyID wrappedLen(u16) wrappedKey nonce[12] ciphertext
+//
+// Everything before the ciphertext is authenticated as additional data.
+const (
+	encryptedMagic = "PBOMENC1"
+	dataKeySize    = 32
+)
+
+// ErrNotEncrypted is returned when decrypting data that was not encrypted
+var ErrNotEncrypted = errors.New("data is not encrypted")
+
+// KeyProvider wraps and unwraps the data keys encrypting the documents. It
+// is the extension point for key management systems: an implementation
+// backed by a cloud KMS wraps the data keys with a key that never leaves
+// the service.
+type KeyProvider interface {
+	// KeyID identifies the key wrapping the data keys. It is recorded in
+	// the encrypted data to find the key when decrypting.
+	KeyID() string
+
+	// WrapKey encrypts a data key
+	WrapKey(ctx context.Context, key []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key wrapped with the key keyID
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+var _ KeyProvider = (*StaticKeyProvider)(nil)
+
+// StaticKeyProvider wraps the data keys locally with AES-256-GCM using a
+// fixed key-encryption key. It is meant for tests and deployments where the
+// key is managed outside of protobom, eg mounted as a secret.
+type StaticKeyProvider struct {
+	ID   string
+	aead cipher.AEAD
+}
+
+// NewStaticKeyProvider returns a key provider wrapping the data keys with
+// key, which must be 32 bytes long.
+func NewStaticKeyProvider(id string, key []byte) (*StaticKeyProvider, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating key-encryption cipher: %w", err)
+	}
+	return &StaticKeyProvider{ID: id, aead: aead}, nil
+}
+
+// KeyID returns the identifier of the key-encryption key
+func (p *StaticKeyProvider) KeyID() string {
+	return p.ID
+}
+
+// WrapKey encrypts a data key with the key-encryption key
+func (p *StaticKeyProvider) WrapKey(_ context.Context, key []byte) ([]byte, error) {
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	return p.aead.Seal(nonce, nonce, key, []byte(p.ID)), nil
+}
+
+// UnwrapKey decrypts a data key wrapped with the key-encryption key
+func (p *StaticKeyProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if keyID != p.ID {
+		return nil, fmt.Errorf("data key was wrapped with unknown key %q", keyID)
+	}
+	if len(wrapped) < p.aead.NonceSize() {
+		return nil, errors.New("wrapped data key is too short")
+	}
+	nonce, ciphertext := wrapped[:p.aead.NonceSize()], wrapped[p.aead.NonceSize():]
+	key, err := p.aead.Open(nil, nonce, ciphertext, []byte(p.ID))
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+	return key, nil
+}
+
+// newAEAD returns an AES-GCM cipher for a 256 bit key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != dataKeySize {
+		return nil, fmt.Errorf("key must be %d bytes long, got %d", dataKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// IsEncrypted returns true if data was produced by Encrypt
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedMagic))
+}
+
+// Encrypt seals data with a new data key wrapped by keys
+func Encrypt(ctx context.Context, keys KeyProvider, data []byte) ([]byte, error) {
+	key := make([]byte, dataKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("generating data key: %w", err)
+	}
+	wrapped, err := keys.WrapKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping data key: %w", err)
+	}
+	keyID := keys.KeyID()
+	if len(keyID) > 0xffff || len(wrapped) > 0xffff {
+		return nil, errors.New("key identifier or wrapped key too long")
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating data cipher: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(encryptedMagic)
+	binary.Write(&buf, binary.BigEndian, uint16(len(keyID))) //nolint:errcheck,gosec
+	buf.WriteString(keyID)
+	binary.Write(&buf, binary.BigEndian, uint16(len(wrapped))) //nolint:errcheck,gosec
+	buf.Write(wrapped)
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("generating nonce: %w", err)
+	}
+	// Seal into a new slice so the output never aliases the additional data
+	header := buf.Bytes()
+	out := make([]byte, 0, len(header)+len(nonce)+len(data)+aead.Overhead())
+	out = append(out, header...)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, data, header), nil
+}
+
+// Decrypt opens data sealed by Encrypt, unwrapping its data key with keys
+func Decrypt(ctx context.Context, keys KeyProvider, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, ErrNotEncrypted
+	}
+	r := bytes.NewReader(data[len(encryptedMagic):])
+	keyID, err := readField(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading key identifier: %w", err)
+	}
+	wrapped, err := readField(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading wrapped data key: %w", err)
+	}
+	headerLen := len(data) - r.Len()
+
+	key, err := keys.UnwrapKey(ctx, string(keyID), wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key: %w", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("creating data cipher: %w", err)
+	}
+	if len(data)-headerLen < aead.NonceSize() {
+		return nil, errors.New("encrypted data is truncated")
+	}
+	nonce := data[headerLen : headerLen+aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, data[headerLen+aead.NonceSize():], data[:headerLen])
+	if err != nil {
+		return nil, fmt.Errorf("decrypting data: %w", err)
+	}
+	return plaintext, nil
+}
+
+// readField reads a field prefixed by its 16 bit length
+func readField(r io.Reader) ([]byte, error) {
+	var l uint16
+	if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+		return nil, err
+	}
+	field := make([]byte, l)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestNewStaticKeyProvider(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		key     []byte
+		mustErr bool
+	}{
+		{name: "256 bit key", key: bytes.Repeat([]byte("k"), 32)},
+		{name: "short key", key: []byte("too short"), mustErr: true},
+		{name: "long key", key: bytes.Repeat([]byte("k"), 64), mustErr: true},
+		{name: "no key", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			keys, err := NewStaticKeyProvider("key-1", tc.key)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "key-1", keys.KeyID())
+		})
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	data := []byte("unreleased product SBOM")
+
+	for _, tc := range []struct {
+		name string
+		// decryptKey opens the data with a different key when set
+		decryptKey []byte
+		tamper     bool
+		plaintext  bool
+		mustErr    bool
+		errIs      error
+	}{
+		{name: "same key"},
+		{name: "wrong key", decryptKey: bytes.Repeat([]byte("o"), 32), mustErr: true},
+		{name: "tampered data", tamper: true, mustErr: true},
+		{name: "not encrypted", plaintext: true, mustErr: true, errIs: ErrNotEncrypted},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			keys, err := NewStaticKeyProvider("key-1", bytes.Repeat([]byte("k"), 32))
+			require.NoError(t, err)
+
+			sealed, err := Encrypt(ctx, keys, data)
+			require.NoError(t, err)
+			require.True(t, IsEncrypted(sealed))
+			require.NotContains(t, string(sealed), string(data))
+
+			if tc.decryptKey != nil {
+				keys, err = NewStaticKeyProvider("other", tc.decryptKey)
+				require.NoError(t, err)
+			}
+			if tc.tamper {
+				sealed[len(sealed)-1] ^= 0xff
+			}
+			if tc.plaintext {
+				sealed = data
+			}
+
+			opened, err := Decrypt(ctx, keys, sealed)
+			if tc.mustErr {
+				require.Error(t, err)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, data, opened)
+		})
+	}
+}
+
+func TestFileSystemEncryption(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		// storeKeys and retrieveKeys set the encryption keys of the
+		// backends storing and retrieving the document
+		storeKeys    bool
+		retrieveKeys bool
+		mustErr      bool
+		errIs        error
+	}{
+		{name: "encrypted", storeKeys: true, retrieveKeys: true},
+		{name: "plaintext"},
+		// Without the keys, the document can't be read
+		{name: "encrypted without keys", storeKeys: true, mustErr: true},
+		// With the keys, plaintext documents are rejected
+		{name: "plaintext with keys", retrieveKeys: true, mustErr: true, errIs: ErrNotEncrypted},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			keys, err := NewStaticKeyProvider("key-1", bytes.Repeat([]byte("k"), 32))
+			require.NoError(t, err)
+			dir := t.TempDir()
+			store := NewFileSystem()
+			store.Options.Path = dir
+			retrieve := NewFileSystem()
+			retrieve.Options.Path = dir
+			if tc.storeKeys {
+				store.Options.Encryption = keys
+			}
+			if tc.retrieveKeys {
+				retrieve.Options.Encryption = keys
+			}
+
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "secret-document"
+			doc.NodeList.AddNode(&sbom.Node{Id: "pkg", Name: "unreleased-product"})
+			require.NoError(t, store.Store(doc, nil))
+
+			filename, err := generateDocFileName(doc.Metadata.Id)
+			require.NoError(t, err)
+			data, err := os.ReadFile(filepath.Join(dir, filename))
+			require.NoError(t, err)
+			require.Equal(t, tc.storeKeys, IsEncrypted(data))
+			if tc.storeKeys {
+				require.NotContains(t, string(data), "unreleased-product")
+			}
+
+			res, err := retrieve.Retrieve(doc.Metadata.Id, nil)
+			if tc.mustErr {
+				require.Error(t, err)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "unreleased-product", res.NodeList.Nodes[0].Name)
+		})
+	}
+}
@@ -1,14 +1,13 @@
 package storage
 
 import (
+	"context"
 	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 
-	"github.com/sirupsen/logrus"
-	"google.golang.org/protobuf/proto"
 	"sigs.k8s.io/release-utils/util"
 
 	"github.com/protobom/protobom/pkg/sbom"
@@ -20,6 +19,10 @@ type FileSystemOptions struct {
 	// Path is the path top the directory where the storage
 	// backend will store the document data.
 	Path string
+
+	// Encryption is the key provider used to encrypt the documents at rest.
+	// When nil, documents are stored unencrypted.
+	Encryption KeyProvider
 }
 
 // FileSystem is the default persistence drive of protobom. It is a simple
@@ -68,12 +71,19 @@ func (fs *FileSystem) Store(bom *sbom.Document, opts *StoreOptions) error {
 		return fmt.Errorf("unable to persist document: no document id set")
 	}
 
-	// Marshal the proto to binary form
-	out, err := proto.Marshal(bom)
+	// Marshal the proto to binary form, tagged with the model version
+	out, err := sbom.MarshalVersioned(bom)
 	if err != nil {
 		return fmt.Errorf("marshalling protobom to binary form: %w", err)
 	}
 
+	if fs.Options.Encryption != nil {
+		out, err = Encrypt(context.Background(), fs.Options.Encryption, out)
+		if err != nil {
+			return fmt.Errorf("encrypting protobom data: %w", err)
+		}
+	}
+
 	filename, err := generateDocFileName(bom.Metadata.Id)
 	if err != nil {
 		return err
@@ -107,11 +117,21 @@ func (fs *FileSystem) Retrieve(id string, _ *RetrieveOptions) (*sbom.Document, e
 
 	data, err := os.ReadFile(filepath.Join(fs.Options.Path, filename))
 	if err != nil {
-		logrus.Fatal(fmt.Errorf("reading protobom data from disk: %w", err))
+		return nil, fmt.Errorf("reading protobom data from disk: %w", err)
+	}
+	switch {
+	case fs.Options.Encryption != nil:
+		// Plaintext documents are rejected to prevent downgrading the storage
+		data, err = Decrypt(context.Background(), fs.Options.Encryption, data)
+		if err != nil {
+			return nil, fmt.Errorf("decrypting protobom data: %w", err)
+		}
+	case IsEncrypted(data):
+		return nil, fmt.Errorf("document %q is encrypted and no key provider is set", id)
 	}
 	bom := &sbom.Document{}
-	if err := proto.Unmarshal(data, bom); err != nil {
-		logrus.Fatal(fmt.Errorf("unmarshaling protobom data: %w", err))
+	if err := sbom.UnmarshalVersioned(data, bom); err != nil {
+		return nil, fmt.Errorf("unmarshaling protobom data: %w", err)
 	}
 
 	return bom, nil
@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/protobom/protobom/pkg/sbom"
 )
@@ -48,3 +51,70 @@ func TestFileSystem(t *testing.T) {
 		})
 	}
 }
+
+func TestFileSystemRetrieveErrors(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name string
+		// stored is saved through the backend before retrieving
+		stored *sbom.Document
+		// raw is written as the document file before retrieving
+		raw     []byte
+		id      string
+		mustErr bool
+		errIs   error
+	}{
+		{
+			// Stored documents are valid protobuf messages
+			name:   "plain proto",
+			stored: &sbom.Document{Metadata: &sbom.Metadata{Id: "plain-proto"}},
+			id:     "plain-proto",
+		},
+		{
+			name:    "missing document",
+			id:      "missing",
+			mustErr: true,
+		},
+		{
+			name: "newer model version",
+			raw: protowire.AppendVarint(
+				protowire.AppendTag(nil, 1000, protowire.VarintType), uint64(sbom.ModelVersion+1),
+			),
+			id:      "newer",
+			mustErr: true,
+			errIs:   sbom.ErrUnsupportedModelVersion,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			fs := NewFileSystem()
+			fs.Options.Path = t.TempDir()
+			filename, err := generateDocFileName(tc.id)
+			require.NoError(t, err)
+			path := filepath.Join(fs.Options.Path, filename)
+
+			if tc.stored != nil {
+				require.NoError(t, fs.Store(tc.stored, nil))
+			}
+			if tc.raw != nil {
+				require.NoError(t, os.WriteFile(path, tc.raw, os.FileMode(0o644)))
+			}
+
+			_, err = fs.Retrieve(tc.id, nil)
+			if tc.mustErr {
+				require.Error(t, err)
+				if tc.errIs != nil {
+					require.ErrorIs(t, err, tc.errIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			plain := &sbom.Document{}
+			require.NoError(t, proto.Unmarshal(data, plain))
+			require.Equal(t, tc.id, plain.Metadata.Id)
+		})
+	}
+}
@@ -0,0 +1,511 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/release-utils/util"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var (
+	_ StoreRetriever = (*Mapped)(nil)
+	_ sbom.Hydrator  = (*MappedDocument)(nil)
+)
+
+// The mapped file layout is a fixed header followed by the document metadata,
+// the root elements, the node and edge records and two sorted indexes. All
+// integers are little endian:
+//
+//	header:  magic[8] metadataOff metadataLen rootsOff rootsLen
+//	         nodeIndexOff nodeCount edgeIndexOff edgeCount (uint64 each)
+//	record:  key bytes followed by the marshaled proto message
+//	index:   entries of keyOff(u64) recOff(u64) keyLen(u32) recLen(u32)
+//	         sorted by key
+//
+// Nodes are keyed by their ID and edges by the ID of their source node, so
+// both can be looked up with a binary search directly on the mapped data.
+const (
+	mappedMagic      = "PBOMMAP1"
+	mappedHeaderSize = len(mappedMagic) + 8*8
+	mappedEntrySize  = 24
+)
+
+// ErrNodeNotFound is returned when looking up a node not in a mapped document
+var ErrNodeNotFound = errors.New("node not found")
+
+type MappedOptions struct {
+	// Path is the directory where the storage backend writes the
+	// mapped document files.
+	Path string
+}
+
+// Mapped is a storage backend that persists documents in a flat, indexed
+// layout that can be memory mapped. Documents stored with it can be opened
+// with Open to query their nodes without loading the whole graph in memory.
+type Mapped struct {
+	Options MappedOptions
+}
+
+func NewMapped() *Mapped {
+	return &Mapped{
+		Options: MappedOptions{},
+	}
+}
+
+// mappedFileName returns the name of the file storing the document
+func mappedFileName(documentId string) (string, error) {
+	if documentId == "" {
+		return "", fmt.Errorf("unable to generate filename, document ID not set")
+	}
+	return fmt.Sprintf("%x.pbmap", sha256.Sum256([]byte(documentId))), nil
+}
+
+// indexEntry locates a record in the mapped file
+type indexEntry struct {
+	key    string
+	keyOff uint64
+	recOff uint64
+	recLen uint32
+}
+
+// countingWriter tracks the offset of the data written to the file
+type countingWriter struct {
+	w   *bufio.Writer
+	off uint64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.off += uint64(n) //nolint:gosec // n is never negative
+	return n, err
+}
+
+// writeRecord writes a keyed record and returns its index entry
+func (cw *countingWriter) writeRecord(key string, msg proto.Message) (indexEntry, error) {
+	data, err := proto.Marshal(msg)
+	if err != nil {
+		return indexEntry{}, fmt.Errorf("marshaling record %q: %w", key, err)
+	}
+	entry := indexEntry{key: key, keyOff: cw.off}
+	if _, err := io.WriteString(cw, key); err != nil {
+		return indexEntry{}, err
+	}
+	entry.recOff = cw.off
+	entry.recLen = uint32(len(data)) //nolint:gosec // records are smaller than 4GiB
+	if _, err := cw.Write(data); err != nil {
+		return indexEntry{}, err
+	}
+	return entry, nil
+}
+
+// writeIndex sorts the entries by key and writes them
+func (cw *countingWriter) writeIndex(entries []indexEntry) error {
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	buf := make([]byte, mappedEntrySize)
+	for _, e := range entries {
+		binary.LittleEndian.PutUint64(buf[0:], e.keyOff)
+		binary.LittleEndian.PutUint64(buf[8:], e.recOff)
+		binary.LittleEndian.PutUint32(buf[16:], uint32(len(e.key))) //nolint:gosec // IDs are short
+		binary.LittleEndian.PutUint32(buf[20:], e.recLen)
+		if _, err := cw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Store writes the document to a mapped file in the backend directory
+func (m *Mapped) Store(bom *sbom.Document, opts *StoreOptions) error {
+	if opts == nil {
+		opts = &StoreOptions{}
+	}
+
+	if m.Options.Path == "" {
+		return fmt.Errorf("unable to store SBOM data: mapped backend data dir not set")
+	}
+
+	if bom.GetMetadata().GetId() == "" {
+		return fmt.Errorf("unable to persist document: no document id set")
+	}
+
+	if err := os.MkdirAll(m.Options.Path, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("creating mapped backend storage directory: %w", err)
+	}
+
+	filename, err := mappedFileName(bom.Metadata.Id)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(m.Options.Path, filename)
+
+	if opts.NoClobber && util.Exists(path) {
+		return fmt.Errorf("there is already an entry for the specified document (and NoClobber = true)")
+	}
+
+	// Write to a temporary file first to never leave a partial document
+	f, err := os.CreateTemp(m.Options.Path, filename+".tmp*")
+	if err != nil {
+		return fmt.Errorf("creating mapped file: %w", err)
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	if err := writeMapped(f, bom); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("writing mapped document: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("closing mapped file: %w", err)
+	}
+
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("moving mapped file into place: %w", err)
+	}
+	return nil
+}
+
+// writeMapped writes the mapped layout of bom to f
+func writeMapped(f *os.File, bom *sbom.Document) error {
+	cw := &countingWriter{w: bufio.NewWriter(f)}
+	header := make([]uint64, 8)
+
+	// Reserve the space of the header, it is written at the end
+	if _, err := cw.Write(make([]byte, mappedHeaderSize)); err != nil {
+		return err
+	}
+
+	data, err := proto.Marshal(bom.GetMetadata())
+	if err != nil {
+		return fmt.Errorf("marshaling metadata: %w", err)
+	}
+	header[0], header[1] = cw.off, uint64(len(data))
+	if _, err := cw.Write(data); err != nil {
+		return err
+	}
+
+	data, err = proto.Marshal(&sbom.NodeList{RootElements: bom.GetNodeList().GetRootElements()})
+	if err != nil {
+		return fmt.Errorf("marshaling root elements: %w", err)
+	}
+	header[2], header[3] = cw.off, uint64(len(data))
+	if _, err := cw.Write(data); err != nil {
+		return err
+	}
+
+	nodes := make([]indexEntry, 0, len(bom.GetNodeList().GetNodes()))
+	for _, n := range bom.GetNodeList().GetNodes() {
+		e, err := cw.writeRecord(n.GetId(), n)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, e)
+	}
+
+	edges := make([]indexEntry, 0, len(bom.GetNodeList().GetEdges()))
+	for _, edge := range bom.GetNodeList().GetEdges() {
+		e, err := cw.writeRecord(edge.GetFrom(), edge)
+		if err != nil {
+			return err
+		}
+		edges = append(edges, e)
+	}
+
+	header[4], header[5] = cw.off, uint64(len(nodes))
+	if err := cw.writeIndex(nodes); err != nil {
+		return err
+	}
+	header[6], header[7] = cw.off, uint64(len(edges))
+	if err := cw.writeIndex(edges); err != nil {
+		return err
+	}
+
+	if err := cw.w.Flush(); err != nil {
+		return err
+	}
+
+	buf := make([]byte, mappedHeaderSize)
+	copy(buf, mappedMagic)
+	for i, v := range header {
+		binary.LittleEndian.PutUint64(buf[len(mappedMagic)+i*8:], v)
+	}
+	if _, err := f.WriteAt(buf, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Retrieve reads a full document from the mapped backend
+func (m *Mapped) Retrieve(id string, _ *RetrieveOptions) (*sbom.Document, error) {
+	md, err := m.Open(id)
+	if err != nil {
+		return nil, err
+	}
+	defer md.Close() //nolint:errcheck
+
+	return md.Document()
+}
+
+// Open maps a stored document into memory. Nodes and edges are only
+// unmarshaled when requested. The returned document must be closed.
+func (m *Mapped) Open(id string) (*MappedDocument, error) {
+	if m.Options.Path == "" {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: mapped backend data dir not set")
+	}
+	if id == "" {
+		return nil, fmt.Errorf("unable to retrieve SBOM data: no identifier defined")
+	}
+
+	filename, err := mappedFileName(id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, unmap, err := mapFile(filepath.Join(m.Options.Path, filename))
+	if err != nil {
+		return nil, fmt.Errorf("mapping document file: %w", err)
+	}
+
+	md, err := newMappedDocument(data, unmap)
+	if err != nil {
+		unmap() //nolint:errcheck,gosec
+		return nil, err
+	}
+	return md, nil
+}
+
+// MappedDocument is a read-only view of a document stored in the mapped
+// layout. It is not safe to use after calling Close.
+type MappedDocument struct {
+	data   []byte
+	unmap  func() error
+	header [8]uint64
+}
+
+func newMappedDocument(data []byte, unmap func() error) (*MappedDocument, error) {
+	if len(data) < mappedHeaderSize || string(data[:len(mappedMagic)]) != mappedMagic {
+		return nil, errors.New("invalid mapped document: bad header")
+	}
+
+	md := &MappedDocument{data: data, unmap: unmap}
+	for i := range md.header {
+		md.header[i] = binary.LittleEndian.Uint64(data[len(mappedMagic)+i*8:])
+	}
+
+	size := uint64(len(data))
+	within := func(off, length uint64) bool {
+		return off <= size && length <= size-off
+	}
+	if !within(md.header[0], md.header[1]) || !within(md.header[2], md.header[3]) ||
+		!within(md.header[4], 0) || md.header[5] > (size-md.header[4])/mappedEntrySize ||
+		!within(md.header[6], 0) || md.header[7] > (size-md.header[6])/mappedEntrySize {
+		return nil, errors.New("invalid mapped document: truncated data")
+	}
+	return md, nil
+}
+
+// Close releases the mapped memory
+func (md *MappedDocument) Close() error {
+	if md.unmap == nil {
+		return nil
+	}
+	err := md.unmap()
+	md.unmap = nil
+	md.data = nil
+	return err
+}
+
+// Metadata returns the document metadata
+func (md *MappedDocument) Metadata() (*sbom.Metadata, error) {
+	metadata := &sbom.Metadata{}
+	if err := proto.Unmarshal(md.data[md.header[0]:md.header[0]+md.header[1]], metadata); err != nil {
+		return nil, fmt.Errorf("unmarshaling metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// RootElements returns the IDs of the document root nodes
+func (md *MappedDocument) RootElements() ([]string, error) {
+	nl := &sbom.NodeList{}
+	if err := proto.Unmarshal(md.data[md.header[2]:md.header[2]+md.header[3]], nl); err != nil {
+		return nil, fmt.Errorf("unmarshaling root elements: %w", err)
+	}
+	return nl.RootElements, nil
+}
+
+// Len returns the number of nodes in the document
+func (md *MappedDocument) Len() int {
+	return int(md.header[5]) //nolint:gosec // bounded by the file size
+}
+
+// entry returns the key and record of entry i of the index at indexOff. It
+// returns an error if the entry points outside of the mapped data.
+func (md *MappedDocument) entry(indexOff uint64, i int) (key, record []byte, err error) {
+	size := uint64(len(md.data))
+	off := indexOff + uint64(i)*mappedEntrySize //nolint:gosec // i is never negative
+	if off > size || mappedEntrySize > size-off {
+		return nil, nil, fmt.Errorf("invalid mapped document: index entry #%d out of bounds", i)
+	}
+	buf := md.data[off:]
+	keyOff := binary.LittleEndian.Uint64(buf[0:])
+	recOff := binary.LittleEndian.Uint64(buf[8:])
+	keyLen := uint64(binary.LittleEndian.Uint32(buf[16:]))
+	recLen := uint64(binary.LittleEndian.Uint32(buf[20:]))
+	if keyOff > size || keyLen > size-keyOff || recOff > size || recLen > size-recOff {
+		return nil, nil, fmt.Errorf("invalid mapped document: index entry #%d points out of bounds", i)
+	}
+	return md.data[keyOff : keyOff+keyLen], md.data[recOff : recOff+recLen], nil
+}
+
+// search returns the position of the first entry in the index with key id
+func (md *MappedDocument) search(indexOff uint64, count int, id string) (int, error) {
+	var err error
+	i := sort.Search(count, func(i int) bool {
+		key, _, e := md.entry(indexOff, i)
+		if e != nil {
+			if err == nil {
+				err = e
+			}
+			return true
+		}
+		return bytes.Compare(key, []byte(id)) >= 0
+	})
+	return i, err
+}
+
+// NodeAt returns the node at position i in the document index. Nodes are
+// sorted by their ID.
+func (md *MappedDocument) NodeAt(i int) (*sbom.Node, error) {
+	if i < 0 || i >= md.Len() {
+		return nil, fmt.Errorf("node index %d out of range", i)
+	}
+	_, record, err := md.entry(md.header[4], i)
+	if err != nil {
+		return nil, err
+	}
+	node := &sbom.Node{}
+	if err := proto.Unmarshal(record, node); err != nil {
+		return nil, fmt.Errorf("unmarshaling node #%d: %w", i, err)
+	}
+	return node, nil
+}
+
+// GetNodeByID loads a node from the mapped data. It returns ErrNodeNotFound
+// if the document has no node with the specified ID.
+func (md *MappedDocument) GetNodeByID(id string) (*sbom.Node, error) {
+	i, err := md.search(md.header[4], md.Len(), id)
+	if err != nil {
+		return nil, err
+	}
+	if i == md.Len() {
+		return nil, fmt.Errorf("%w: %q", ErrNodeNotFound, id)
+	}
+	key, _, err := md.entry(md.header[4], i)
+	if err != nil {
+		return nil, err
+	}
+	if string(key) != id {
+		return nil, fmt.Errorf("%w: %q", ErrNodeNotFound, id)
+	}
+	return md.NodeAt(i)
+}
+
+// GetEdgesFrom returns the edges whose source is the node with the
+// specified ID.
+func (md *MappedDocument) GetEdgesFrom(id string) ([]*sbom.Edge, error) {
+	count := int(md.header[7]) //nolint:gosec // bounded by the file size
+	edges := []*sbom.Edge{}
+	start, err := md.search(md.header[6], count, id)
+	if err != nil {
+		return nil, err
+	}
+	for i := start; i < count; i++ {
+		key, record, err := md.entry(md.header[6], i)
+		if err != nil {
+			return nil, err
+		}
+		if string(key) != id {
+			break
+		}
+		edge := &sbom.Edge{}
+		if err := proto.Unmarshal(record, edge); err != nil {
+			return nil, fmt.Errorf("unmarshaling edge from %q: %w", id, err)
+		}
+		edges = append(edges, edge)
+	}
+	return edges, nil
+}
+
+// Document loads the complete document into memory
+func (md *MappedDocument) Document() (*sbom.Document, error) {
+	return md.document(false)
+}
+
+// DehydratedDocument loads the document graph without the heavyweight node
+// fields. The mapped document can be used to hydrate the nodes on demand
+// while it remains open.
+func (md *MappedDocument) DehydratedDocument() (*sbom.Document, error) {
+	return md.document(true)
+}
+
+// document loads the document into memory, optionally dehydrating the nodes
+func (md *MappedDocument) document(dehydrate bool) (*sbom.Document, error) {
+	metadata, err := md.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	roots, err := md.RootElements()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &sbom.Document{
+		Metadata: metadata,
+		NodeList: &sbom.NodeList{
+			Nodes:        make([]*sbom.Node, 0, md.Len()),
+			RootElements: roots,
+		},
+	}
+
+	for i := range md.Len() {
+		node, err := md.NodeAt(i)
+		if err != nil {
+			return nil, err
+		}
+		if dehydrate {
+			node.Dehydrate()
+		}
+		doc.NodeList.Nodes = append(doc.NodeList.Nodes, node)
+	}
+
+	count := int(md.header[7]) //nolint:gosec // bounded by the file size
+	for i := range count {
+		_, record, err := md.entry(md.header[6], i)
+		if err != nil {
+			return nil, err
+		}
+		edge := &sbom.Edge{}
+		if err := proto.Unmarshal(record, edge); err != nil {
+			return nil, fmt.Errorf("unmarshaling edge #%d: %w", i, err)
+		}
+		doc.NodeList.Edges = append(doc.NodeList.Edges, edge)
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func testMappedDocument(nodes int) *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = "https://example.com/firmware"
+	doc.Metadata.Name = "firmware"
+	doc.NodeList.AddNode(&sbom.Node{Id: "root", Name: "firmware", Type: sbom.Node_PACKAGE})
+	doc.NodeList.RootElements = []string{"root"}
+	contains := &sbom.Edge{Type: sbom.Edge_contains, From: "root"}
+	for i := range nodes {
+		id := fmt.Sprintf("file-%05d", i)
+		doc.NodeList.AddNode(&sbom.Node{Id: id, Name: fmt.Sprintf("/usr/lib/%d.so", i), Type: sbom.Node_FILE})
+		contains.To = append(contains.To, id)
+	}
+	doc.NodeList.AddEdge(contains)
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "root", To: []string{"file-00001"}})
+	return doc
+}
+
+func TestMapped(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name  string
+		files int
+	}{
+		{name: "small document", files: 2},
+		{name: "large document", files: 500},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := NewMapped()
+			m.Options.Path = t.TempDir()
+			doc := testMappedDocument(tc.files)
+
+			require.NoError(t, m.Store(doc, nil))
+			filename, err := mappedFileName(doc.Metadata.Id)
+			require.NoError(t, err)
+			require.FileExists(t, filepath.Join(m.Options.Path, filename))
+			require.Error(t, m.Store(doc, &StoreOptions{NoClobber: true}))
+
+			md, err := m.Open(doc.Metadata.Id)
+			require.NoError(t, err)
+			require.Equal(t, tc.files+1, md.Len())
+			metadata, err := md.Metadata()
+			require.NoError(t, err)
+			require.Equal(t, "firmware", metadata.Name)
+			roots, err := md.RootElements()
+			require.NoError(t, err)
+			require.Equal(t, []string{"root"}, roots)
+
+			// The full document round trips
+			retrieved, err := m.Retrieve(doc.Metadata.Id, nil)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(doc.Metadata, retrieved.Metadata))
+			require.Equal(t, doc.NodeList.RootElements, retrieved.NodeList.RootElements)
+			require.Len(t, retrieved.NodeList.Nodes, tc.files+1)
+			require.Len(t, retrieved.NodeList.Edges, 2)
+			require.Equal(t, "firmware", retrieved.NodeList.GetNodeByID("root").Name)
+
+			require.NoError(t, md.Close())
+			require.NoError(t, md.Close())
+		})
+	}
+}
+
+func TestMappedLookups(t *testing.T) {
+	t.Parallel()
+	m := NewMapped()
+	m.Options.Path = t.TempDir()
+	doc := testMappedDocument(500)
+	require.NoError(t, m.Store(doc, nil))
+	md, err := m.Open(doc.Metadata.Id)
+	require.NoError(t, err)
+	defer md.Close() //nolint:errcheck
+
+	for _, tc := range []struct {
+		name string
+		id   string
+		// nodeName is the expected name of the node, empty when the
+		// node is not in the document
+		nodeName string
+		edges    int
+	}{
+		{name: "root", id: "root", nodeName: "firmware", edges: 2},
+		{name: "first file", id: "file-00000", nodeName: "/usr/lib/0.so"},
+		{name: "leaf with incoming edges", id: "file-00001", nodeName: "/usr/lib/1.so"},
+		{name: "middle file", id: "file-00042", nodeName: "/usr/lib/42.so"},
+		{name: "last file", id: "file-00499", nodeName: "/usr/lib/499.so"},
+		{name: "missing node", id: "file-99999"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			node, err := md.GetNodeByID(tc.id)
+			if tc.nodeName == "" {
+				require.True(t, errors.Is(err, ErrNodeNotFound))
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tc.nodeName, node.Name)
+			}
+
+			edges, err := md.GetEdgesFrom(tc.id)
+			require.NoError(t, err)
+			require.Len(t, edges, tc.edges)
+		})
+	}
+}
+
+func TestMappedErrors(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		noPath bool
+		// prepare writes files to the storage directory
+		prepare func(*testing.T, string)
+		store   *sbom.Document
+		open    string
+	}{
+		{name: "store without path", noPath: true, store: testMappedDocument(1)},
+		{name: "open without path", noPath: true, open: "test"},
+		{name: "store without id", store: sbom.NewDocument()},
+		{name: "open without id", open: ""},
+		{name: "open missing document", open: "missing"},
+		{
+			name: "corrupt file",
+			prepare: func(t *testing.T, dir string) {
+				t.Helper()
+				filename, err := mappedFileName("corrupt")
+				require.NoError(t, err)
+				require.NoError(t, os.WriteFile(
+					filepath.Join(dir, filename), []byte("not a protobom file at all, just some text here to fill the header"), 0o600,
+				))
+			},
+			open: "corrupt",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := NewMapped()
+			if !tc.noPath {
+				m.Options.Path = t.TempDir()
+			}
+			if tc.prepare != nil {
+				tc.prepare(t, m.Options.Path)
+			}
+			if tc.store != nil {
+				require.Error(t, m.Store(tc.store, nil))
+				return
+			}
+			_, err := m.Open(tc.open)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMappedCorruptIndex(t *testing.T) {
+	t.Parallel()
+	m := NewMapped()
+	m.Options.Path = t.TempDir()
+	doc := testMappedDocument(10)
+	require.NoError(t, m.Store(doc, nil))
+
+	// Point the record of the first node and the key of the first
+	// edge past the end of the file
+	filename, err := mappedFileName(doc.Metadata.Id)
+	require.NoError(t, err)
+	path := filepath.Join(m.Options.Path, filename)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	nodeIndex := binary.LittleEndian.Uint64(data[len(mappedMagic)+4*8:])
+	edgeIndex := binary.LittleEndian.Uint64(data[len(mappedMagic)+6*8:])
+	binary.LittleEndian.PutUint32(data[nodeIndex+20:], math.MaxUint32)
+	binary.LittleEndian.PutUint64(data[edgeIndex:], uint64(len(data)))
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	md, err := m.Open(doc.Metadata.Id)
+	require.NoError(t, err)
+	defer md.Close() //nolint:errcheck
+
+	for _, tc := range []struct {
+		name    string
+		read    func() error
+		mustErr bool
+	}{
+		{
+			name:    "node out of bounds",
+			read:    func() error { _, err := md.NodeAt(0); return err },
+			mustErr: true,
+		},
+		{
+			name:    "lookup out of bounds",
+			read:    func() error { _, err := md.GetNodeByID("file-00000"); return err },
+			mustErr: true,
+		},
+		{
+			name:    "edge out of bounds",
+			read:    func() error { _, err := md.GetEdgesFrom("root"); return err },
+			mustErr: true,
+		},
+		{
+			name:    "full document",
+			read:    func() error { _, err := md.Document(); return err },
+			mustErr: true,
+		},
+		{
+			// Entries within bounds can still be read
+			name: "node within bounds",
+			read: func() error {
+				node, err := md.NodeAt(1)
+				if err == nil && node.Id != "file-00001" {
+					return fmt.Errorf("unexpected node %q", node.Id)
+				}
+				return err
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.read()
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMappedHydration(t *testing.T) {
+	t.Parallel()
+	m := NewMapped()
+	m.Options.Path = t.TempDir()
+	doc := testMappedDocument(10)
+	doc.NodeList.GetNodeByID("file-00003").Description = "a shared library"
+	doc.NodeList.GetNodeByID("file-00003").Copyright = "Copyright ACME"
+	require.NoError(t, m.Store(doc, nil))
+
+	md, err := m.Open(doc.Metadata.Id)
+	require.NoError(t, err)
+	defer md.Close() //nolint:errcheck
+
+	for _, tc := range []struct {
+		name        string
+		id          string
+		nodeName    string
+		description string
+		copyright   string
+	}{
+		{name: "node with long fields", id: "file-00003", nodeName: "/usr/lib/3.so", description: "a shared library", copyright: "Copyright ACME"},
+		{name: "node without long fields", id: "file-00004", nodeName: "/usr/lib/4.so"},
+		{name: "root node", id: "root", nodeName: "firmware"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			light, err := md.DehydratedDocument()
+			require.NoError(t, err)
+			node := light.NodeList.GetNodeByID(tc.id)
+			require.NotNil(t, node)
+			require.True(t, node.IsDehydrated())
+			require.Equal(t, tc.nodeName, node.Name)
+
+			require.NoError(t, node.Hydrate(md))
+			require.Equal(t, tc.description, node.Description)
+			require.Equal(t, tc.copyright, node.Copyright)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+//go:build unix
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file at path into memory read only. The returned function
+// unmaps it.
+func mapFile(path string) ([]byte, func() error, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading file info: %w", err)
+	}
+	if info.Size() == 0 {
+		return nil, nil, errors.New("file is empty")
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(info.Size()), syscall.PROT_READ, syscall.MAP_SHARED) //nolint:gosec // fds fit in int
+	if err != nil {
+		return nil, nil, fmt.Errorf("mapping file: %w", err)
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,337 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"sigs.k8s.io/release-utils/util"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var _ StoreRetriever = (*ContentAddressed)(nil)
+
+// NodeFingerprint returns the hex encoded SHA-256 digest of the
+// deterministic protobuf encoding of a node. Nodes with the same fingerprint
+// are identical, including their IDs.
+func NodeFingerprint(n *sbom.Node) (string, error) {
+	_, fp, err := marshalNode(n)
+	return fp, err
+}
+
+// marshalNode returns the deterministic encoding of a node and its
+// fingerprint
+func marshalNode(n *sbom.Node) (data []byte, fingerprint string, err error) {
+	data, err = proto.MarshalOptions{Deterministic: true}.Marshal(n)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshaling node: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
+
+// NodeStore keeps a single copy in memory of the identical nodes of many
+// documents, keyed by their fingerprint. It is safe for concurrent use.
+//
+// The nodes in the store are shared by all the documents interned in it,
+// they must be treated as read-only. Copy a node before modifying it.
+type NodeStore struct {
+	mu    sync.RWMutex
+	nodes map[string]*sbom.Node
+}
+
+// NewNodeStore returns an empty node store
+func NewNodeStore() *NodeStore {
+	return &NodeStore{nodes: map[string]*sbom.Node{}}
+}
+
+// Put adds a copy of the node to the store and returns its fingerprint and
+// the shared copy held by the store. The node passed is never shared, it
+// can be modified after calling Put.
+func (s *NodeStore) Put(n *sbom.Node) (string, *sbom.Node, error) {
+	fp, shared, _, err := s.put(n)
+	return fp, shared, err
+}
+
+// put implements Put, it also returns true if the store already had the node
+func (s *NodeStore) put(n *sbom.Node) (fp string, shared *sbom.Node, existed bool, err error) {
+	fp, err = NodeFingerprint(n)
+	if err != nil {
+		return "", nil, false, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if shared, ok := s.nodes[fp]; ok {
+		return fp, shared, true, nil
+	}
+	shared = n.Copy()
+	s.nodes[fp] = shared
+	return fp, shared, false, nil
+}
+
+// Get returns the node with the specified fingerprint or nil if it is not
+// in the store
+func (s *NodeStore) Get(fingerprint string) *sbom.Node {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.nodes[fingerprint]
+}
+
+// Len returns the number of distinct nodes in the store
+func (s *NodeStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.nodes)
+}
+
+// Intern replaces the nodes of the document with their shared copies,
+// adding the ones not in the store yet. It returns the number of nodes
+// replaced by a copy already held for another document. The nodes of
+// interned documents are shared, they must be treated as read-only.
+func (s *NodeStore) Intern(doc *sbom.Document) (int, error) {
+	shared := 0
+	for i, n := range doc.GetNodeList().GetNodes() {
+		_, canonical, existed, err := s.put(n)
+		if err != nil {
+			return shared, fmt.Errorf("interning node %q: %w", n.GetId(), err)
+		}
+		doc.NodeList.Nodes[i] = canonical
+		if existed {
+			shared++
+		}
+	}
+	return shared, nil
+}
+
+// ContentAddressedOptions configures the content addressed backend
+type ContentAddressedOptions struct {
+	// Path is the directory where the documents and nodes are stored
+	Path string
+}
+
+// ContentAddressedRetrieveOptions are the options of the content addressed
+// backend passed in RetrieveOptions.BackendOptions
+type ContentAddressedRetrieveOptions struct {
+	// ShareNodes returns the shared copies of the nodes held in the backend
+	// NodeStore instead of copies of them. The nodes of the documents
+	// retrieved this way must be treated as read-only.
+	ShareNodes bool
+}
+
+// ContentAddressed is a storage backend that writes each distinct node only
+// once, keyed by its fingerprint, no matter how many documents include it.
+// Documents are stored without their nodes, along with the list of the
+// fingerprints of the nodes. The nodes read are kept in memory in the
+// backend NodeStore, retrieved documents get copies of them unless
+// ContentAddressedRetrieveOptions.ShareNodes is set.
+//
+// The directory layout is:
+//
+//	<sha256 of document ID>.protobom    The document without nodes
+//	<sha256 of document ID>.nodes       The node fingerprints, one per line
+//	nodes/<fp[:2]>/<fp>.pb              The nodes
+type ContentAddressed struct {
+	Options ContentAddressedOptions
+
+	// Nodes holds the nodes of the retrieved documents
+	Nodes *NodeStore
+}
+
+// NewContentAddressed returns a content addressed backend storing data in
+// the directory path
+func NewContentAddressed(path string) *ContentAddressed {
+	return &ContentAddressed{
+		Options: ContentAddressedOptions{Path: path},
+		Nodes:   NewNodeStore(),
+	}
+}
+
+// nodePath returns the path of the file storing a node
+func (ca *ContentAddressed) nodePath(fingerprint string) string {
+	return filepath.Join(ca.Options.Path, "nodes", fingerprint[:2], fingerprint+".pb")
+}
+
+// Store writes the document and the nodes not already in the store
+func (ca *ContentAddressed) Store(bom *sbom.Document, opts *StoreOptions) error {
+	if opts == nil {
+		opts = &StoreOptions{}
+	}
+	if ca.Options.Path == "" {
+		return errors.New("unable to store document: content addressed backend path not set")
+	}
+	if bom.GetMetadata().GetId() == "" {
+		return errors.New("unable to persist document: no document id set")
+	}
+	filename, err := generateDocFileName(bom.Metadata.Id)
+	if err != nil {
+		return err
+	}
+	docPath := filepath.Join(ca.Options.Path, filename)
+	if opts.NoClobber && util.Exists(docPath) {
+		return errors.New("there is already an entry for the specified document (and NoClobber = true)")
+	}
+
+	if err := os.MkdirAll(ca.Options.Path, os.FileMode(0o755)); err != nil {
+		return fmt.Errorf("creating content addressed storage directory: %w", err)
+	}
+
+	var manifest bytes.Buffer
+	for _, n := range bom.GetNodeList().GetNodes() {
+		fp, err := ca.storeNode(n)
+		if err != nil {
+			return fmt.Errorf("storing node %q: %w", n.GetId(), err)
+		}
+		manifest.WriteString(fp + "\n")
+	}
+
+	skeleton := &sbom.Document{
+		Metadata:        bom.GetMetadata(),
+		Vulnerabilities: bom.GetVulnerabilities(),
+		NodeList: &sbom.NodeList{
+			Edges:        bom.GetNodeList().GetEdges(),
+			RootElements: bom.GetNodeList().GetRootElements(),
+		},
+	}
+	data, err := proto.Marshal(skeleton)
+	if err != nil {
+		return fmt.Errorf("marshalling protobom to binary form: %w", err)
+	}
+	if err := writeFileAtomic(strings.TrimSuffix(docPath, ".protobom")+".nodes", manifest.Bytes()); err != nil {
+		return err
+	}
+	return writeFileAtomic(docPath, data)
+}
+
+// storeNode writes a node if the store does not have it and returns its
+// fingerprint
+func (ca *ContentAddressed) storeNode(n *sbom.Node) (string, error) {
+	data, fp, err := marshalNode(n)
+	if err != nil {
+		return "", err
+	}
+	path := ca.nodePath(fp)
+	if util.Exists(path) {
+		return fp, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.FileMode(0o755)); err != nil {
+		return "", fmt.Errorf("creating node directory: %w", err)
+	}
+	return fp, writeFileAtomic(path, data)
+}
+
+// Retrieve reads a document. Its nodes are copies of the ones held in the
+// backend NodeStore, pass ContentAddressedRetrieveOptions in the backend
+// options to share them with the other documents retrieved instead.
+func (ca *ContentAddressed) Retrieve(id string, opts *RetrieveOptions) (*sbom.Document, error) {
+	if ca.Options.Path == "" {
+		return nil, errors.New("unable to retrieve SBOM data: content addressed backend path not set")
+	}
+	filename, err := generateDocFileName(id)
+	if err != nil {
+		return nil, err
+	}
+	docPath := filepath.Join(ca.Options.Path, filename)
+	data, err := os.ReadFile(docPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading document: %w", err)
+	}
+	bom := &sbom.Document{}
+	if err := proto.Unmarshal(data, bom); err != nil {
+		return nil, fmt.Errorf("unmarshaling protobom data: %w", err)
+	}
+	if bom.NodeList == nil {
+		bom.NodeList = &sbom.NodeList{}
+	}
+
+	f, err := os.Open(strings.TrimSuffix(docPath, ".protobom") + ".nodes")
+	if err != nil {
+		return nil, fmt.Errorf("opening node list: %w", err)
+	}
+	defer f.Close() //nolint:errcheck
+
+	share := false
+	if opts != nil {
+		if ro, ok := opts.BackendOptions.(*ContentAddressedRetrieveOptions); ok && ro != nil {
+			share = ro.ShareNodes
+		}
+	}
+
+	if ca.Nodes == nil {
+		ca.Nodes = NewNodeStore()
+	}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		n, err := ca.retrieveNode(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		if !share {
+			n = n.Copy()
+		}
+		bom.NodeList.Nodes = append(bom.NodeList.Nodes, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading node list: %w", err)
+	}
+	return bom, nil
+}
+
+// retrieveNode returns the shared copy of a node, reading it from disk if
+// it is not in memory
+func (ca *ContentAddressed) retrieveNode(fingerprint string) (*sbom.Node, error) {
+	if n := ca.Nodes.Get(fingerprint); n != nil {
+		return n, nil
+	}
+	if len(fingerprint) != sha256.Size*2 {
+		return nil, fmt.Errorf("invalid node fingerprint %q", fingerprint)
+	}
+	data, err := os.ReadFile(ca.nodePath(fingerprint))
+	if err != nil {
+		return nil, fmt.Errorf("reading node: %w", err)
+	}
+	if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != fingerprint {
+		return nil, fmt.Errorf("node %s is corrupt, its data does not match its fingerprint", fingerprint)
+	}
+	n := &sbom.Node{}
+	if err := proto.Unmarshal(data, n); err != nil {
+		return nil, fmt.Errorf("unmarshaling node %s: %w", fingerprint, err)
+	}
+	_, shared, err := ca.Nodes.Put(n)
+	return shared, err
+}
+
+// writeFileAtomic writes data to a temporary file and moves it to path, so
+// readers never see partially written files
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+	if _, err := f.Write(data); err != nil {
+		f.Close() //nolint:errcheck,gosec
+		return fmt.Errorf("writing data to disk: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing data to disk: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("writing data to disk: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func nodeStoreTestDocument(id string, extra ...*sbom.Node) *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = id
+	doc.NodeList.AddRootNode(&sbom.Node{Id: id + "-root", Name: id})
+	doc.NodeList.AddNode(&sbom.Node{Id: "glibc", Name: "glibc", Version: "2.36"})
+	doc.NodeList.AddNode(&sbom.Node{Id: "openssl", Name: "openssl", Version: "3.0.11"})
+	for _, n := range extra {
+		doc.NodeList.AddNode(n)
+	}
+	doc.NodeList.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: id + "-root", To: []string{"glibc", "openssl"}})
+	return doc
+}
+
+func TestNodeStore(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		docs []*sbom.Document
+		// shared is the number of shared nodes found interning each document
+		shared []int
+		len    int
+	}{
+		{
+			name:   "single document",
+			docs:   []*sbom.Document{nodeStoreTestDocument("doc1")},
+			shared: []int{0},
+			len:    3,
+		},
+		{
+			name: "shared nodes",
+			docs: []*sbom.Document{
+				nodeStoreTestDocument("doc1"),
+				nodeStoreTestDocument("doc2", &sbom.Node{Id: "zlib", Name: "zlib"}),
+			},
+			shared: []int{0, 2},
+			len:    5,
+		},
+		{
+			name: "same document",
+			docs: []*sbom.Document{
+				nodeStoreTestDocument("doc1"),
+				nodeStoreTestDocument("doc1"),
+			},
+			shared: []int{0, 3},
+			len:    3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewNodeStore()
+			for i, doc := range tc.docs {
+				shared, err := store.Intern(doc)
+				require.NoError(t, err)
+				require.Equal(t, tc.shared[i], shared)
+			}
+			require.Equal(t, tc.len, store.Len())
+
+			// Interned nodes are the copies held by the store
+			for _, doc := range tc.docs {
+				for _, n := range doc.NodeList.Nodes {
+					fp, err := NodeFingerprint(n)
+					require.NoError(t, err)
+					require.Same(t, n, store.Get(fp))
+				}
+				require.Same(t, tc.docs[0].NodeList.GetNodeByID("glibc"), doc.NodeList.GetNodeByID("glibc"))
+			}
+			require.Nil(t, store.Get("nope"))
+		})
+	}
+}
+
+func TestNodeStorePut(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		node *sbom.Node
+		// existing expects the copy interned from the document
+		existing bool
+		len      int
+	}{
+		{
+			name: "new node",
+			node: &sbom.Node{Id: "musl", Name: "musl"},
+			len:  4,
+		},
+		{
+			name:     "existing node",
+			node:     &sbom.Node{Id: "glibc", Name: "glibc", Version: "2.36"},
+			existing: true,
+			len:      3,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewNodeStore()
+			doc := nodeStoreTestDocument("doc1")
+			_, err := store.Intern(doc)
+			require.NoError(t, err)
+
+			fp, stored, err := store.Put(tc.node)
+			require.NoError(t, err)
+			require.Equal(t, tc.len, store.Len())
+			require.Same(t, stored, store.Get(fp))
+			if tc.existing {
+				require.Same(t, doc.NodeList.GetNodeByID(tc.node.Id), stored)
+			}
+
+			// Put stores a copy, the node passed can be modified
+			require.NotSame(t, tc.node, stored)
+			name := tc.node.Name
+			tc.node.Name = "modified"
+			require.Equal(t, name, store.Get(fp).Name)
+		})
+	}
+}
+
+func TestContentAddressed(t *testing.T) {
+	dir := t.TempDir()
+	ca := NewContentAddressed(dir)
+	doc1 := nodeStoreTestDocument("doc1")
+	doc2 := nodeStoreTestDocument("doc2", &sbom.Node{Id: "zlib", Name: "zlib"})
+	require.NoError(t, ca.Store(doc1, nil))
+	require.NoError(t, ca.Store(doc2, nil))
+	require.Error(t, ca.Store(doc1, &StoreOptions{NoClobber: true}))
+
+	nodeFiles, err := filepath.Glob(filepath.Join(dir, "nodes", "*", "*.pb"))
+	require.NoError(t, err)
+	require.Len(t, nodeFiles, 5)
+
+	for _, tc := range []struct {
+		name   string
+		opts   *RetrieveOptions
+		shared bool
+	}{
+		{"copies by default", nil, false},
+		{"copies without backend options", &RetrieveOptions{}, false},
+		{"shared nodes", &RetrieveOptions{BackendOptions: &ContentAddressedRetrieveOptions{ShareNodes: true}}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Retrieve with a fresh backend to read the nodes from disk
+			ca := NewContentAddressed(dir)
+			got1, err := ca.Retrieve("doc1", tc.opts)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(doc1, got1))
+			got2, err := ca.Retrieve("doc2", tc.opts)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(doc2, got2))
+			require.Equal(t, 5, ca.Nodes.Len())
+			if tc.shared {
+				require.Same(t, got1.NodeList.GetNodeByID("openssl"), got2.NodeList.GetNodeByID("openssl"))
+				return
+			}
+
+			// Modifying a retrieved node does not change the other documents
+			got1.NodeList.GetNodeByID("openssl").Version = "modified"
+			require.Equal(t, "3.0.11", got2.NodeList.GetNodeByID("openssl").Version)
+			got3, err := ca.Retrieve("doc1", tc.opts)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(doc1, got3))
+		})
+	}
+
+	_, err = ca.Retrieve("missing", nil)
+	require.Error(t, err)
+
+	// Corrupt nodes are detected
+	require.NoError(t, os.WriteFile(nodeFiles[0], []byte("garbage"), 0o600))
+	_, err = NewContentAddressed(dir).Retrieve("doc2", nil)
+	require.ErrorContains(t, err, "corrupt")
+}
@@ -0,0 +1,290 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package tea
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// Scheme is the URI scheme of the TEA references
+const Scheme = "tea"
+
+// Kinds of objects a reference can point to
+const (
+	KindArtifact         = "artifact"
+	KindComponentRelease = "componentRelease"
+)
+
+// Reference points to an SBOM in a TEA server, either an artifact or the
+// BOM in the latest collection of a component release:
+//
+//	tea://tea.example.com/tea/v1/artifact/<uuid>
+//	tea://tea.example.com/tea/v1/componentRelease/<uuid>
+type Reference struct {
+	// Host and Path locate the API root in the server
+	Host string
+	Path string
+
+	Kind string
+	UUID string
+}
+
+// IsReference returns true if s is a tea:// reference
+func IsReference(s string) bool {
+	return strings.HasPrefix(s, Scheme+"://")
+}
+
+// ParseReference parses a tea:// reference
+func ParseReference(s string) (*Reference, error) {
+	rest, ok := strings.CutPrefix(s, Scheme+"://")
+	if !ok {
+		return nil, fmt.Errorf("%q is not a %s:// reference", s, Scheme)
+	}
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	if len(parts) < 3 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid TEA reference %q", s)
+	}
+	ref := &Reference{
+		Host: parts[0],
+		Path: strings.Join(parts[1:len(parts)-2], "/"),
+		Kind: parts[len(parts)-2],
+		UUID: parts[len(parts)-1],
+	}
+	if ref.Kind != KindArtifact && ref.Kind != KindComponentRelease {
+		return nil, fmt.Errorf("TEA reference %q must point to an %s or a %s", s, KindArtifact, KindComponentRelease)
+	}
+	return ref, nil
+}
+
+// String returns the reference as a tea:// URI
+func (r *Reference) String() string {
+	return fmt.Sprintf("%s://%s", Scheme, strings.Join(nonEmpty(r.Host, r.Path, r.Kind, r.UUID), "/"))
+}
+
+// BaseURL returns the URL of the API root of the referenced server
+func (r *Reference) BaseURL(plainHTTP bool) string {
+	scheme := "https"
+	if plainHTTP {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s", scheme, strings.Join(nonEmpty(r.Host, r.Path), "/"))
+}
+
+// nonEmpty returns the non empty strings
+func nonEmpty(s ...string) []string {
+	ret := []string{}
+	for _, v := range s {
+		if v != "" {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+var (
+	_ storage.Backend  = (*Backend)(nil)
+	_ reader.Fetcher   = (*Backend)(nil)
+	_ writer.Publisher = (*Backend)(nil)
+)
+
+// StoreOptions are the options of the Backend, passed to Store in the
+// storage.StoreOptions BackendOptions
+type StoreOptions struct {
+	// Release is the UUID of the component release to publish to
+	Release string
+
+	// Name of the artifact published
+	Name string
+}
+
+// Backend is a storage backend reading and publishing documents in TEA
+// servers. Retrieve accepts tea:// references, resolved with a client
+// created for their server, and artifact UUIDs in the server of Client.
+// Register it to resolve tea:// references in the reader and writer.
+type Backend struct {
+	// Client talks to the default server. It is also used for the
+	// references to its host.
+	Client *Client
+
+	// ClientOptions configure the clients created for tea:// references
+	ClientOptions []ClientOption
+
+	// PlainHTTP resolves tea:// references over http instead of https
+	PlainHTTP bool
+
+	// Format is the format of the documents published. Defaults to
+	// CycloneDX 1.6 JSON.
+	Format formats.Format
+}
+
+// NewBackend returns a backend talking to the server of client. client
+// may be nil to use only tea:// references.
+func NewBackend(client *Client) *Backend {
+	return &Backend{Client: client, Format: formats.CDX16JSON}
+}
+
+// clientFor returns the client to resolve a reference
+func (b *Backend) clientFor(ref *Reference) (*Client, error) {
+	if b.Client != nil && b.Client.BaseURL.Host == ref.Host &&
+		strings.Trim(b.Client.BaseURL.Path, "/") == ref.Path {
+		return b.Client, nil
+	}
+	return NewClient(ref.BaseURL(b.PlainHTTP), b.ClientOptions...)
+}
+
+// RetrieveOptions are the options of the Backend, passed to Retrieve in the
+// storage.RetrieveOptions BackendOptions
+type RetrieveOptions struct {
+	// ReaderOptions are the options used to parse the documents. The reader
+	// defaults are used when not set.
+	ReaderOptions *reader.Options
+}
+
+// Register registers the backend as the fetcher and publisher of the tea://
+// references in the reader and writer, so ParseFile, Retrieve and WriteFile
+// resolve them with the options of the reader or writer called.
+func Register(b *Backend) {
+	reader.RegisterFetcher(Scheme, b)
+	writer.RegisterPublisher(Scheme, b)
+}
+
+// Unregister removes the tea:// references handling from the reader and
+// writer
+func Unregister() {
+	reader.UnregisterFetcher(Scheme)
+	writer.UnregisterPublisher(Scheme)
+}
+
+// Retrieve downloads and parses the SBOM of a TEA artifact. The document
+// is parsed with the reader options in the RetrieveOptions.
+func (b *Backend) Retrieve(id string, opts *storage.RetrieveOptions) (*sbom.Document, error) {
+	data, err := b.Fetch(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+	r := reader.New()
+	ro := r.Options
+	if opts != nil {
+		if o, ok := opts.BackendOptions.(*RetrieveOptions); ok && o != nil && o.ReaderOptions != nil {
+			ro = o.ReaderOptions
+		}
+	}
+	doc, err := r.ParseStreamWithOptions(bytes.NewReader(data), ro)
+	if err != nil {
+		return nil, fmt.Errorf("parsing artifact %s: %w", id, err)
+	}
+	return doc, nil
+}
+
+// Fetch downloads the SBOM of a TEA artifact, referenced by a tea:// URI or
+// by its UUID in the server of Client. It implements reader.Fetcher.
+func (b *Backend) Fetch(ctx context.Context, id string) ([]byte, error) {
+	client := b.Client
+	ref := &Reference{Kind: KindArtifact, UUID: id}
+	if IsReference(id) {
+		var err error
+		if ref, err = ParseReference(id); err != nil {
+			return nil, err
+		}
+		if client, err = b.clientFor(ref); err != nil {
+			return nil, err
+		}
+	}
+	if client == nil {
+		return nil, errors.New("no TEA client configured to retrieve artifact")
+	}
+
+	artifact, err := b.resolveArtifact(ctx, client, ref)
+	if err != nil {
+		return nil, err
+	}
+	f := artifact.SBOMFormat()
+	if f == nil {
+		return nil, fmt.Errorf("artifact %s has no SBOM in a supported format", artifact.UUID)
+	}
+	return client.Download(ctx, f)
+}
+
+// Publish uploads a document as a new artifact of the component release
+// referenced by a tea:// URI. It implements writer.Publisher.
+func (b *Backend) Publish(ctx context.Context, uri string, format formats.Format, data []byte) error {
+	ref, err := ParseReference(uri)
+	if err != nil {
+		return err
+	}
+	if ref.Kind != KindComponentRelease {
+		return fmt.Errorf("documents can only be published to a %s, not to %q", KindComponentRelease, uri)
+	}
+	client, err := b.clientFor(ref)
+	if err != nil {
+		return err
+	}
+	_, err = client.Publish(ctx, ref.UUID, "", mediaTypeOf(format), data)
+	return err
+}
+
+// mediaTypeOf returns the media type of the artifacts of a format
+func mediaTypeOf(format formats.Format) string {
+	if format.Type() == formats.SPDXFORMAT {
+		return MediaTypeSPDXJSON
+	}
+	return MediaTypeCycloneDXJSON
+}
+
+// resolveArtifact returns the artifact pointed to by the reference
+func (b *Backend) resolveArtifact(ctx context.Context, client *Client, ref *Reference) (*Artifact, error) {
+	if ref.Kind == KindArtifact {
+		return client.Artifact(ctx, ref.UUID)
+	}
+	col, err := client.LatestCollection(ctx, ref.UUID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range col.Artifacts {
+		if col.Artifacts[i].Type == ArtifactTypeBOM && col.Artifacts[i].SBOMFormat() != nil {
+			return &col.Artifacts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("release %s has no SBOM artifact: %w", ref.UUID, ErrNotFound)
+}
+
+// Store publishes a document to the release in the StoreOptions
+func (b *Backend) Store(doc *sbom.Document, opts *storage.StoreOptions) error {
+	if b.Client == nil {
+		return errors.New("no TEA client configured to publish")
+	}
+	var so *StoreOptions
+	if opts != nil {
+		so, _ = opts.BackendOptions.(*StoreOptions)
+	}
+	if so == nil || so.Release == "" {
+		return errors.New("publishing to TEA requires the release UUID in the backend options")
+	}
+
+	format := b.Format
+	if format == "" {
+		format = formats.CDX16JSON
+	}
+	var buf bytes.Buffer
+	if err := writer.New().WriteStreamWithOptions(doc, &buf, &writer.Options{Format: format}); err != nil {
+		return fmt.Errorf("serializing document: %w", err)
+	}
+	if _, err := b.Client.Publish(context.Background(), so.Release, so.Name, mediaTypeOf(format), buf.Bytes()); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package tea implements a client of the Transparency Exchange API (TEA),
+// the CycloneDX standard to publish and discover the SBOMs and other
+// transparency artifacts of software releases (ECMA TC54, Project Koala).
+//
+// The Backend type plugs the client into the protobom reader and writer as
+// a storage backend, so tea:// references can be retrieved and documents
+// published like any other stored document. Once registered with Register,
+// the reader ParseFile and Retrieve and the writer WriteFile resolve tea://
+// references too.
+package tea
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Artifact types defined by TEA
+const (
+	ArtifactTypeBOM = "BOM"
+	ArtifactTypeVEX = "VULNERABILITIES"
+)
+
+// Media types of the SBOM formats protobom reads
+const (
+	MediaTypeCycloneDXJSON = "application/vnd.cyclonedx+json"
+	MediaTypeSPDXJSON      = "application/spdx+json"
+)
+
+// ErrNotFound is returned when the TEA server has no object with the
+// requested identifier
+var ErrNotFound = errors.New("not found in TEA server")
+
+// Checksum is the digest of an artifact format
+type Checksum struct {
+	Algorithm string `json:"algType"`
+	Value     string `json:"algValue"`
+}
+
+// ArtifactFormat is a representation of an artifact, eg an SBOM in
+// CycloneDX and another one in SPDX
+type ArtifactFormat struct {
+	MediaType    string     `json:"mediaType"`
+	Description  string     `json:"description,omitempty"`
+	URL          string     `json:"url"`
+	SignatureURL string     `json:"signatureUrl,omitempty"`
+	Checksums    []Checksum `json:"checksums,omitempty"`
+}
+
+// Artifact is a transparency artifact of a release, such as its SBOM
+type Artifact struct {
+	UUID    string           `json:"uuid"`
+	Name    string           `json:"name,omitempty"`
+	Type    string           `json:"type,omitempty"`
+	Formats []ArtifactFormat `json:"formats"`
+}
+
+// Collection is a version of the set of artifacts of a release
+type Collection struct {
+	UUID      string     `json:"uuid"`
+	Version   int        `json:"version"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// Client talks to a TEA server
+type Client struct {
+	// BaseURL is the root of the API, eg https://tea.example.com/tea/v1
+	BaseURL *url.URL
+
+	// HTTPClient performs the requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	// Token is sent as a bearer token when set
+	Token string
+}
+
+// ClientOption is a functional option to configure the client
+type ClientOption func(*Client)
+
+// WithHTTPClient sets the HTTP client used to talk to the server
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient = hc
+	}
+}
+
+// WithToken sets the bearer token sent to the server
+func WithToken(token string) ClientOption {
+	return func(c *Client) {
+		c.Token = token
+	}
+}
+
+// NewClient returns a client of the TEA server at baseURL
+func NewClient(baseURL string, opts ...ClientOption) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parsing TEA server URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("TEA server URL must be http or https, got %q", baseURL)
+	}
+	c := &Client{BaseURL: u, HTTPClient: http.DefaultClient}
+	for _, o := range opts {
+		o(c)
+	}
+	return c, nil
+}
+
+// do sends a request to u and decodes the JSON response into out
+func (c *Client) do(ctx context.Context, method string, u *url.URL, body io.Reader, contentType string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return fmt.Errorf("building TEA request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding TEA response: %w", err)
+	}
+	return nil
+}
+
+// send performs a request, returning an error if it did not succeed
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("calling TEA server: %w", err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close() //nolint:errcheck,gosec
+		return nil, fmt.Errorf("%s: %w", req.URL.Path, ErrNotFound)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		resp.Body.Close() //nolint:errcheck,gosec
+		return nil, fmt.Errorf("calling TEA server: HTTP %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// Artifact returns the artifact with the specified UUID
+func (c *Client) Artifact(ctx context.Context, uuid string) (*Artifact, error) {
+	a := &Artifact{}
+	if err := c.do(ctx, http.MethodGet, c.BaseURL.JoinPath("artifact", uuid), nil, "", a); err != nil {
+		return nil, fmt.Errorf("getting artifact %s: %w", uuid, err)
+	}
+	return a, nil
+}
+
+// LatestCollection returns the latest collection of artifacts of a
+// component release
+func (c *Client) LatestCollection(ctx context.Context, releaseUUID string) (*Collection, error) {
+	col := &Collection{}
+	u := c.BaseURL.JoinPath("componentRelease", releaseUUID, "collection", "latest")
+	if err := c.do(ctx, http.MethodGet, u, nil, "", col); err != nil {
+		return nil, fmt.Errorf("getting latest collection of %s: %w", releaseUUID, err)
+	}
+	return col, nil
+}
+
+// Download fetches the data of an artifact format, checking it against the
+// SHA-256 and SHA-512 checksums published for it.
+func (c *Client) Download(ctx context.Context, f *ArtifactFormat) ([]byte, error) {
+	if f.URL == "" {
+		return nil, errors.New("artifact format has no URL")
+	}
+	u, err := c.BaseURL.Parse(f.URL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing artifact URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("building download request: %w", err)
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return nil, fmt.Errorf("downloading artifact: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading artifact: %w", err)
+	}
+	if err := verifyChecksums(data, f.Checksums); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Publish uploads a document as a new artifact of a component release
+// using the TEA publisher API and returns the artifact created. The
+// publisher API is still a draft, servers may not implement it.
+func (c *Client) Publish(ctx context.Context, releaseUUID, name, mediaType string, data []byte) (*Artifact, error) {
+	u := c.BaseURL.JoinPath("componentRelease", releaseUUID, "artifacts")
+	if name != "" {
+		u.RawQuery = url.Values{"name": {name}}.Encode()
+	}
+	a := &Artifact{}
+	if err := c.do(ctx, http.MethodPost, u, bytes.NewReader(data), mediaType, a); err != nil {
+		return nil, fmt.Errorf("publishing artifact: %w", err)
+	}
+	return a, nil
+}
+
+// verifyChecksums checks data against the checksums of the algorithms
+// supported. Checksums of other algorithms are ignored.
+func verifyChecksums(data []byte, checksums []Checksum) error {
+	for _, cs := range checksums {
+		var h hash.Hash
+		switch strings.ToUpper(strings.ReplaceAll(cs.Algorithm, "_", "-")) {
+		case "SHA-256":
+			h = sha256.New()
+		case "SHA-512":
+			h = sha512.New()
+		default:
+			continue
+		}
+		h.Write(data)
+		if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, cs.Value) {
+			return fmt.Errorf("artifact %s checksum mismatch: expected %s, got %s", cs.Algorithm, cs.Value, got)
+		}
+	}
+	return nil
+}
+
+// SBOMFormat returns the format of the artifact protobom can read,
+// preferring CycloneDX. It returns nil if there is none.
+func (a *Artifact) SBOMFormat() *ArtifactFormat {
+	for _, mt := range []string{MediaTypeCycloneDXJSON, MediaTypeSPDXJSON} {
+		for i := range a.Formats {
+			if strings.HasPrefix(a.Formats[i].MediaType, mt) {
+				return &a.Formats[i]
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,395 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package tea
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/native"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+const testSBOM = `{
+  "bomFormat": "CycloneDX",
+  "specVersion": "1.6",
+  "serialNumber": "urn:uuid:3e671687-395b-41f5-a30f-a58921a69b79",
+  "version": 1,
+  "metadata": {"component": {"bom-ref": "app", "type": "application", "name": "app", "version": "1.0.0"}},
+  "components": [{"bom-ref": "lib", "type": "library", "name": "lib", "version": "2.0.0"}]
+}`
+
+// teaServer is a fake TEA server with one release holding one artifact
+type teaServer struct {
+	*httptest.Server
+	checksum  string
+	published []byte
+	mediaType string
+	name      string
+}
+
+func newTEAServer(t *testing.T) *teaServer {
+	sum := sha256.Sum256([]byte(testSBOM))
+	ts := &teaServer{checksum: hex.EncodeToString(sum[:])}
+	artifact := func() Artifact {
+		return Artifact{
+			UUID: "art-1", Type: ArtifactTypeBOM,
+			Formats: []ArtifactFormat{
+				{MediaType: "application/pdf", URL: "/files/sbom.pdf"},
+				{
+					MediaType: MediaTypeCycloneDXJSON, URL: "/files/sbom.cdx.json",
+					Checksums: []Checksum{{Algorithm: "SHA-256", Value: ts.checksum}},
+				},
+			},
+		}
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /tea/v1/artifact/art-1", func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(artifact()) //nolint:errcheck,errchkjson
+	})
+	mux.HandleFunc("GET /tea/v1/componentRelease/rel-1/collection/latest", func(w http.ResponseWriter, _ *http.Request) {
+		col := Collection{UUID: "rel-1", Version: 3, Artifacts: []Artifact{
+			{UUID: "vex-1", Type: ArtifactTypeVEX}, artifact(),
+		}}
+		json.NewEncoder(w).Encode(col) //nolint:errcheck,errchkjson
+	})
+	mux.HandleFunc("GET /files/sbom.cdx.json", func(w http.ResponseWriter, _ *http.Request) {
+		io.WriteString(w, testSBOM) //nolint:errcheck
+	})
+	mux.HandleFunc("POST /tea/v1/componentRelease/rel-1/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		ts.published, _ = io.ReadAll(r.Body) //nolint:errcheck
+		ts.mediaType = r.Header.Get("Content-Type")
+		ts.name = r.URL.Query().Get("name")
+		json.NewEncoder(w).Encode(Artifact{UUID: "art-2", Type: ArtifactTypeBOM}) //nolint:errcheck,errchkjson
+	})
+	ts.Server = httptest.NewServer(mux)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestParseReference(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		ref       string
+		expected  *Reference
+		plainHTTP bool
+		baseURL   string
+		mustErr   bool
+	}{
+		{
+			name:     "artifact",
+			ref:      "tea://tea.example.com/tea/v1/artifact/1234",
+			expected: &Reference{Host: "tea.example.com", Path: "tea/v1", Kind: KindArtifact, UUID: "1234"},
+			baseURL:  "https://tea.example.com/tea/v1",
+		},
+		{
+			name:      "component release without path",
+			ref:       "tea://localhost:8080/componentRelease/abcd",
+			expected:  &Reference{Host: "localhost:8080", Kind: KindComponentRelease, UUID: "abcd"},
+			plainHTTP: true,
+			baseURL:   "http://localhost:8080",
+		},
+		{name: "other scheme", ref: "oci://example.com/artifact/1", mustErr: true},
+		{name: "no kind", ref: "tea://example.com/1234", mustErr: true},
+		{name: "unknown kind", ref: "tea://example.com/product/1234", mustErr: true},
+		{name: "no host", ref: "tea:///artifact/1234", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := ParseReference(tc.ref)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, ref)
+			require.Equal(t, tc.ref, ref.String())
+			require.Equal(t, tc.baseURL, ref.BaseURL(tc.plainHTTP))
+		})
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		url     string
+		mustErr bool
+	}{
+		{name: "https", url: "https://tea.example.com/tea/v1/"},
+		{name: "http", url: "http://localhost:8080"},
+		{name: "other scheme", url: "ftp://example.com", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := NewClient(tc.url)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, c)
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	ts := newTEAServer(t)
+	c, err := NewClient(ts.URL + "/tea/v1/")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name string
+		uuid string
+		// checksum replaces the checksum of the SBOM format when set
+		checksum    string
+		errIs       error
+		errContains string
+	}{
+		{
+			name: "artifact",
+			uuid: "art-1",
+		},
+		{
+			name:        "tampered checksum",
+			uuid:        "art-1",
+			checksum:    strings.Repeat("0", 64),
+			errContains: "checksum mismatch",
+		},
+		{
+			name:  "missing artifact",
+			uuid:  "missing",
+			errIs: ErrNotFound,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a, err := c.Artifact(context.Background(), tc.uuid)
+			if tc.errIs != nil {
+				require.ErrorIs(t, err, tc.errIs)
+				return
+			}
+			require.NoError(t, err)
+			format := *a.SBOMFormat()
+			require.Equal(t, MediaTypeCycloneDXJSON, format.MediaType)
+			if tc.checksum != "" {
+				format.Checksums = []Checksum{{Algorithm: "SHA-256", Value: tc.checksum}}
+			}
+
+			data, err := c.Download(context.Background(), &format)
+			if tc.errContains != "" {
+				require.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, testSBOM, string(data))
+		})
+	}
+}
+
+func TestBackendRetrieve(t *testing.T) {
+	ts := newTEAServer(t)
+	host := strings.TrimPrefix(ts.URL, "http://")
+
+	for _, tc := range []struct {
+		name string
+		ref  string
+		// defaultClient sets the client used to resolve bare UUIDs
+		defaultClient bool
+		mustErr       bool
+	}{
+		{name: "artifact", ref: "tea://" + host + "/tea/v1/artifact/art-1"},
+		{name: "component release", ref: "tea://" + host + "/tea/v1/componentRelease/rel-1"},
+		{name: "uuid", ref: "art-1", defaultClient: true},
+		// Bare UUIDs need a default client
+		{name: "uuid without client", ref: "art-1", mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			b := NewBackend(nil)
+			b.PlainHTTP = true
+			if tc.defaultClient {
+				c, err := NewClient(ts.URL + "/tea/v1")
+				require.NoError(t, err)
+				b.Client = c
+			}
+
+			doc, err := reader.New(reader.WithStoreRetriever(b)).Retrieve(tc.ref)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, doc.GetNodeList().GetNodes(), 2)
+			require.NotNil(t, doc.GetNodeList().GetNodeByID("lib"))
+		})
+	}
+}
+
+func TestBackendStore(t *testing.T) {
+	ts := newTEAServer(t)
+
+	for _, tc := range []struct {
+		name    string
+		token   string
+		opts    *storage.StoreOptions
+		mustErr bool
+	}{
+		{
+			name:  "publish",
+			token: "s3cr3t",
+			opts: &storage.StoreOptions{
+				BackendOptions: &StoreOptions{Release: "rel-1", Name: "app-sbom"},
+			},
+		},
+		{
+			name:    "no release",
+			token:   "s3cr3t",
+			opts:    &storage.StoreOptions{},
+			mustErr: true,
+		},
+		{
+			name:  "unauthorized",
+			token: "wrong",
+			opts: &storage.StoreOptions{
+				BackendOptions: &StoreOptions{Release: "rel-1", Name: "app-sbom"},
+			},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ts.published, ts.mediaType, ts.name = nil, "", ""
+			client, err := NewClient(ts.URL+"/tea/v1", WithToken(tc.token))
+			require.NoError(t, err)
+
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "urn:uuid:8d2f1a7e-8f6a-4c39-9a0c-0d4c5a1c2b3e"
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0.0"})
+
+			err = writer.New(writer.WithStoreRetriever(NewBackend(client))).StoreWithOptions(
+				doc, &writer.Options{StoreOptions: tc.opts},
+			)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Nil(t, ts.published)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, MediaTypeCycloneDXJSON, ts.mediaType)
+			require.Equal(t, "app-sbom", ts.name)
+			require.Contains(t, string(ts.published), `"bomFormat": "CycloneDX"`)
+		})
+	}
+}
+
+func TestBackendRetrieveOptions(t *testing.T) {
+	ts := newTEAServer(t)
+	client, err := NewClient(ts.URL + "/tea/v1")
+	require.NoError(t, err)
+	b := NewBackend(client)
+
+	for _, tc := range []struct {
+		name    string
+		opts    *storage.RetrieveOptions
+		tracked bool
+	}{
+		{"reader defaults", nil, true},
+		{"reader options", &storage.RetrieveOptions{BackendOptions: &RetrieveOptions{
+			ReaderOptions: &reader.Options{UnserializeOptions: &native.UnserializeOptions{}},
+		}}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc, err := b.Retrieve("art-1", tc.opts)
+			require.NoError(t, err)
+			require.NotNil(t, doc.GetNodeList().GetNodeByID("lib"))
+			require.Equal(t, tc.tracked, doc.GetMetadata().GetSourceData() != nil)
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	ts := newTEAServer(t)
+	host := strings.TrimPrefix(ts.URL, "http://")
+	b := NewBackend(nil)
+	b.PlainHTTP = true
+	b.ClientOptions = []ClientOption{WithToken("s3cr3t")}
+	Register(b)
+	t.Cleanup(Unregister)
+
+	t.Run("parse", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			ref     string
+			tracked bool
+		}{
+			{"artifact", "tea://" + host + "/tea/v1/artifact/art-1", true},
+			{"release", "tea://" + host + "/tea/v1/componentRelease/rel-1", true},
+			{"untracked", "tea://" + host + "/tea/v1/artifact/art-1", false},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				// The reference is parsed with the options passed to the reader
+				doc, err := reader.New().ParseFileWithOptions(tc.ref, &reader.Options{
+					UnserializeOptions: &native.UnserializeOptions{TrackSource: tc.tracked},
+				})
+				require.NoError(t, err)
+				require.NotNil(t, doc.GetNodeList().GetNodeByID("lib"))
+				if tc.tracked {
+					require.Equal(t, tc.ref, doc.GetMetadata().GetSourceData().GetUri())
+				} else {
+					require.Nil(t, doc.GetMetadata().GetSourceData())
+				}
+
+				doc, err = reader.New().RetrieveWithOptions(tc.ref, &reader.Options{
+					UnserializeOptions: &native.UnserializeOptions{TrackSource: tc.tracked},
+				})
+				require.NoError(t, err)
+				require.Equal(t, tc.tracked, doc.GetMetadata().GetSourceData() != nil)
+			})
+		}
+	})
+
+	t.Run("publish", func(t *testing.T) {
+		doc := sbom.NewDocument()
+		doc.Metadata.Id = "urn:uuid:8d2f1a7e-8f6a-4c39-9a0c-0d4c5a1c2b3e"
+		doc.NodeList.AddRootNode(&sbom.Node{Id: "app", Name: "app", Version: "1.0.0"})
+
+		for _, tc := range []struct {
+			name      string
+			ref       string
+			format    formats.Format
+			mediaType string
+			mustErr   bool
+		}{
+			{"cyclonedx", "tea://" + host + "/tea/v1/componentRelease/rel-1", formats.CDX16JSON, MediaTypeCycloneDXJSON, false},
+			{"spdx", "tea://" + host + "/tea/v1/componentRelease/rel-1", formats.SPDX23JSON, MediaTypeSPDXJSON, false},
+			{"artifact", "tea://" + host + "/tea/v1/artifact/art-1", formats.CDX16JSON, "", true},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				ts.mediaType = ""
+				err := writer.New().WriteFileWithOptions(doc, tc.ref, &writer.Options{Format: tc.format})
+				if tc.mustErr {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, tc.mediaType, ts.mediaType)
+			})
+		}
+	})
+}
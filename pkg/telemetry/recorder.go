@@ -0,0 +1,132 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Recorder is an in-memory Tracer and Meter. It is useful in tests and to
+// inspect the instrumentation without an OpenTelemetry backend.
+type Recorder struct {
+	mu        sync.Mutex
+	spans     []*RecordedSpan
+	counters  map[string]int64
+	durations map[string][]time.Duration
+}
+
+// RecordedSpan is a span captured by the Recorder
+type RecordedSpan struct {
+	Name       string
+	Parent     *RecordedSpan
+	Attributes map[string]any
+	Errors     []error
+	Ended      bool
+
+	recorder *Recorder
+}
+
+type recordedSpanKey struct{}
+
+// NewRecorder returns an empty Recorder
+func NewRecorder() *Recorder {
+	return &Recorder{
+		counters:  map[string]int64{},
+		durations: map[string][]time.Duration{},
+	}
+}
+
+// Telemetry returns a Telemetry recording to r
+func (r *Recorder) Telemetry() *Telemetry {
+	return &Telemetry{Tracer: r, Meter: r}
+}
+
+// Start implements Tracer
+func (r *Recorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &RecordedSpan{
+		Name:       name,
+		Attributes: map[string]any{},
+		recorder:   r,
+	}
+	if parent, ok := ctx.Value(recordedSpanKey{}).(*RecordedSpan); ok {
+		span.Parent = parent
+	}
+	r.mu.Lock()
+	r.spans = append(r.spans, span)
+	r.mu.Unlock()
+	return context.WithValue(ctx, recordedSpanKey{}, span), span
+}
+
+// AddInt64 implements Meter
+func (r *Recorder) AddInt64(_ context.Context, name string, value int64, _ ...Attribute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[name] += value
+}
+
+// RecordDuration implements Meter
+func (r *Recorder) RecordDuration(_ context.Context, name string, d time.Duration, _ ...Attribute) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.durations[name] = append(r.durations[name], d)
+}
+
+// Spans returns the spans recorded, in the order they were started
+func (r *Recorder) Spans() []*RecordedSpan {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*RecordedSpan{}, r.spans...)
+}
+
+// Span returns the first recorded span with the specified name or nil
+func (r *Recorder) Span(name string) *RecordedSpan {
+	for _, s := range r.Spans() {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+// Counter returns the value of a counter metric
+func (r *Recorder) Counter(name string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.counters[name]
+}
+
+// Durations returns the durations recorded in a histogram metric
+func (r *Recorder) Durations(name string) []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]time.Duration{}, r.durations[name]...)
+}
+
+// SetAttributes implements Span
+func (s *RecordedSpan) SetAttributes(attrs ...Attribute) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	for _, a := range attrs {
+		s.Attributes[a.Key] = a.Value
+	}
+}
+
+// RecordError implements Span
+func (s *RecordedSpan) RecordError(err error) {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Errors = append(s.Errors, err)
+}
+
+// End implements Span
+func (s *RecordedSpan) End() {
+	s.recorder.mu.Lock()
+	defer s.recorder.mu.Unlock()
+	s.Ended = true
+}
@@ -0,0 +1,189 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package telemetry defines the tracing and metrics hooks used to instrument
+// the protobom reader, writer and the heavy NodeList operations.
+//
+// Instrumentation is disabled unless a Telemetry is configured. The Tracer
+// and Meter interfaces follow the OpenTelemetry API closely so they can be
+// backed by an OpenTelemetry SDK with a thin adapter, for example:
+//
+//	type otelTracer struct{ t trace.Tracer }
+//
+//	func (o otelTracer) Start(ctx context.Context, name string) (context.Context, telemetry.Span) {
+//		ctx, span := o.t.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+// Telemetry can be set in the reader and writer options or carried in a
+// context with ContextWithTelemetry, which scopes it to a single request.
+package telemetry
+
+import (
+	"context"
+	"time"
+)
+
+// Names of the spans and metrics recorded by protobom
+const (
+	SpanReaderParse = "protobom.reader.parse"
+	SpanWriterWrite = "protobom.writer.write"
+	SpanUnion       = "protobom.nodelist.union"
+	SpanIntersect   = "protobom.nodelist.intersect"
+	SpanDedupe      = "protobom.nodelist.dedupe"
+
+	MetricBytesRead    = "protobom.reader.bytes"
+	MetricNodesParsed  = "protobom.reader.nodes"
+	MetricBytesWritten = "protobom.writer.bytes"
+	MetricNodesWritten = "protobom.writer.nodes"
+
+	// DurationSuffix is appended to the span names to name the metric
+	// recording the duration of the operations.
+	DurationSuffix = ".duration"
+)
+
+// Attribute is a key-value pair annotating spans and metrics
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// String returns a string attribute
+func String(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int) Attribute {
+	return Attribute{Key: key, Value: int64(value)}
+}
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Tracer starts spans. The span is a child of the span in ctx, if any, and
+// the returned context carries the new span.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is an operation being traced
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Meter records the protobom metrics
+type Meter interface {
+	// AddInt64 increments the counter name by value
+	AddInt64(ctx context.Context, name string, value int64, attrs ...Attribute)
+
+	// RecordDuration records a duration in the histogram name
+	RecordDuration(ctx context.Context, name string, d time.Duration, attrs ...Attribute)
+}
+
+// Telemetry bundles the tracer and meter used to instrument protobom. Either
+// one can be nil to only record spans or metrics.
+type Telemetry struct {
+	Tracer Tracer
+	Meter  Meter
+}
+
+type contextKey struct{}
+
+// ContextWithTelemetry returns a copy of ctx carrying t
+func ContextWithTelemetry(ctx context.Context, t *Telemetry) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the Telemetry carried by ctx or nil if there is none
+func FromContext(ctx context.Context) *Telemetry {
+	if ctx == nil {
+		return nil
+	}
+	t, ok := ctx.Value(contextKey{}).(*Telemetry)
+	if !ok {
+		return nil
+	}
+	return t
+}
+
+// Operation is an instrumented operation. It traces the operation in a span
+// and records its duration when it ends. The methods of a nil Operation do
+// nothing, so code can be instrumented unconditionally.
+type Operation struct {
+	telemetry *Telemetry
+	ctx       context.Context
+	name      string
+	span      Span
+	start     time.Time
+	attrs     []Attribute
+}
+
+// Start begins an instrumented operation. The attributes annotate both the
+// span and the metrics recorded by the operation. If t is nil, instrumentation
+// is disabled and Start returns ctx and a nil Operation.
+func (t *Telemetry) Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Operation) {
+	if t == nil {
+		return ctx, nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	op := &Operation{
+		telemetry: t,
+		name:      name,
+		start:     time.Now(),
+	}
+	if t.Tracer != nil {
+		ctx, op.span = t.Tracer.Start(ctx, name)
+	}
+	op.ctx = ctx
+	op.SetAttributes(attrs...)
+	return ctx, op
+}
+
+// SetAttributes annotates the operation span and its metrics
+func (op *Operation) SetAttributes(attrs ...Attribute) {
+	if op == nil || len(attrs) == 0 {
+		return
+	}
+	op.attrs = append(op.attrs, attrs...)
+	if op.span != nil {
+		op.span.SetAttributes(attrs...)
+	}
+}
+
+// Count adds value to the counter metric, annotated with the operation
+// attributes.
+func (op *Operation) Count(metric string, value int64) {
+	if op == nil || op.telemetry.Meter == nil {
+		return
+	}
+	op.telemetry.Meter.AddInt64(op.ctx, metric, value, op.attrs...)
+}
+
+// End finishes the operation, recording err in the span if not nil and
+// the duration of the operation.
+func (op *Operation) End(err error) {
+	if op == nil {
+		return
+	}
+	if op.telemetry.Meter != nil {
+		attrs := append([]Attribute{Bool("error", err != nil)}, op.attrs...)
+		op.telemetry.Meter.RecordDuration(op.ctx, op.name+DurationSuffix, time.Since(op.start), attrs...)
+	}
+	if op.span == nil {
+		return
+	}
+	if err != nil {
+		op.span.RecordError(err)
+	}
+	op.span.End()
+}
@@ -0,0 +1,67 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperation(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		var tel *Telemetry
+		ctx := context.Background()
+		ctx2, op := tel.Start(ctx, "op")
+		require.Nil(t, op)
+		require.Equal(t, ctx, ctx2)
+		// Methods of a nil operation are no-ops
+		op.SetAttributes(String("k", "v"))
+		op.Count("metric", 1)
+		op.End(errors.New("fail"))
+		require.Nil(t, FromContext(ctx))
+	})
+
+	t.Run("recorded", func(t *testing.T) {
+		rec := NewRecorder()
+		tel := rec.Telemetry()
+		ctx := ContextWithTelemetry(context.Background(), tel)
+		require.Equal(t, tel, FromContext(ctx))
+
+		ctx, parent := FromContext(ctx).Start(ctx, "parent", String("doc", "a"))
+		_, child := tel.Start(ctx, "child")
+		child.SetAttributes(Int("nodes", 3))
+		child.Count("items", 2)
+		child.Count("items", 3)
+		child.End(errors.New("fail"))
+		parent.End(nil)
+
+		spans := rec.Spans()
+		require.Len(t, spans, 2)
+		require.Equal(t, "parent", spans[0].Name)
+		require.Equal(t, "a", spans[0].Attributes["doc"])
+		require.True(t, spans[0].Ended)
+		require.Empty(t, spans[0].Errors)
+		require.Equal(t, spans[0], spans[1].Parent)
+		require.Equal(t, int64(3), spans[1].Attributes["nodes"])
+		require.Len(t, spans[1].Errors, 1)
+		require.Equal(t, int64(5), rec.Counter("items"))
+		require.Len(t, rec.Durations("child"+DurationSuffix), 1)
+		require.Len(t, rec.Durations("parent"+DurationSuffix), 1)
+	})
+
+	t.Run("metrics-only", func(t *testing.T) {
+		rec := NewRecorder()
+		_, op := (&Telemetry{Meter: rec}).Start(context.Background(), "op")
+		op.Count("items", 1)
+		op.End(nil)
+		require.Empty(t, rec.Spans())
+		require.Equal(t, int64(1), rec.Counter("items"))
+	})
+}
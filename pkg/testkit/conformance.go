@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testkit
+
+import (
+	"slices"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// ConversionStatus classifies the result of converting a document to a format
+type ConversionStatus string
+
+const (
+	// StatusLossless means the document read back is equal to the original
+	StatusLossless ConversionStatus = "lossless"
+	// StatusLossy means the conversion worked but data was lost or altered
+	StatusLossy ConversionStatus = "lossy"
+	// StatusFailed means the document could not be written or read back
+	StatusFailed ConversionStatus = "failed"
+)
+
+// ConversionResult captures the outcome of round-tripping a document
+// through a format.
+type ConversionResult struct {
+	Format      formats.Format   `json:"format"`
+	Status      ConversionStatus `json:"status"`
+	Differences []string         `json:"differences,omitempty"`
+	Error       string           `json:"error,omitempty"`
+}
+
+// ConformanceReport is the conversion matrix of a document: the result of
+// converting it to each format that can be both written and read.
+type ConformanceReport struct {
+	Results []*ConversionResult `json:"results"`
+}
+
+// ConformanceOptions controls how CheckConformance runs
+type ConformanceOptions struct {
+	// Formats limits the check to these formats. When empty, all formats
+	// with a registered serializer and unserializer are checked.
+	Formats []formats.Format
+}
+
+// RoundTripFormats returns the formats that have both a registered
+// serializer and unserializer.
+func RoundTripFormats() []formats.Format {
+	readable := reader.ListUnserializers()
+	ret := []formats.Format{}
+	for _, f := range writer.ListSerializers() {
+		if slices.Contains(readable, f) {
+			ret = append(ret, f)
+		}
+	}
+	return ret
+}
+
+// CheckConformance converts the document to every round-trippable format and
+// reads it back, reporting which conversions are lossless, lossy or failing.
+func CheckConformance(doc *sbom.Document, opts *ConformanceOptions) *ConformanceReport {
+	if opts == nil {
+		opts = &ConformanceOptions{}
+	}
+	targets := opts.Formats
+	if len(targets) == 0 {
+		targets = RoundTripFormats()
+	}
+
+	report := &ConformanceReport{Results: []*ConversionResult{}}
+	for _, f := range targets {
+		// Compare against a copy, NodeList.Equal sorts some fields in place
+		original := sbom.NewNodeList()
+		if doc.GetNodeList() != nil {
+			original = doc.NodeList.Copy()
+		}
+
+		result := &ConversionResult{Format: f}
+		report.Results = append(report.Results, result)
+
+		converted, err := RoundTripDocument(doc, f)
+		if err != nil {
+			result.Status = StatusFailed
+			result.Error = err.Error()
+			continue
+		}
+
+		result.Differences = CompareNodeLists(original, converted.GetNodeList())
+		if len(result.Differences) == 0 {
+			result.Status = StatusLossless
+		} else {
+			result.Status = StatusLossy
+		}
+	}
+	return report
+}
+
+// Count returns the number of results with the specified status
+func (r *ConformanceReport) Count(status ConversionStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
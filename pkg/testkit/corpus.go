@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testkit
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/protobom/protobom/pkg/formats"
+)
+
+// GoldenSuffix is appended to the name of a corpus document to get the
+// path of its golden protobom file.
+const GoldenSuffix = ".proto"
+
+//go:embed corpus
+var corpus embed.FS
+
+// Corpus returns the sample SBOM corpus. Documents are stored in
+// <type>/<version>/<encoding> directories next to their golden protobom
+// files, the same layout used by the protobom conformance tests.
+func Corpus() fs.FS {
+	sub, err := fs.Sub(corpus, "corpus")
+	if err != nil {
+		// The embedded directory always exists
+		panic(err)
+	}
+	return sub
+}
+
+// CorpusFiles returns the paths of the documents in fsys stored in the
+// directory of the specified format. Golden files are not included.
+func CorpusFiles(fsys fs.FS, f formats.Format) ([]string, error) {
+	ret := []string{}
+	dir := path.Join(f.Type(), f.Version(), f.Encoding())
+	entries, err := fs.ReadDir(fsys, dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ret, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading corpus directory: %w", err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || strings.HasSuffix(e.Name(), GoldenSuffix) {
+			continue
+		}
+		ret = append(ret, path.Join(dir, e.Name()))
+	}
+	return ret, nil
+}
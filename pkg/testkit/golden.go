@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testkit
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a non
+// empty value, makes RequireGolden write the golden files instead of
+// comparing against them.
+const UpdateGoldenEnv = "PROTOBOM_UPDATE_GOLDEN"
+
+// ReadGolden reads a golden protobom file from fsys
+func ReadGolden(fsys fs.FS, path string) (*sbom.Document, error) {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return nil, fmt.Errorf("reading golden file: %w", err)
+	}
+	return unmarshalGolden(data)
+}
+
+// unmarshalGolden parses the data of a golden protobom file
+func unmarshalGolden(data []byte) (*sbom.Document, error) {
+	doc := &sbom.Document{}
+	if err := proto.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("unmarshaling golden file: %w", err)
+	}
+	return doc, nil
+}
+
+// WriteGolden writes a document as a golden protobom file
+func WriteGolden(path string, doc *sbom.Document) error {
+	data, err := proto.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("marshaling golden document: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing golden file: %w", err)
+	}
+	return nil
+}
+
+// RequireGolden compares a document to the golden protobom file at path.
+// When the UpdateGoldenEnv variable is set, the golden file is rewritten
+// from the document instead.
+func RequireGolden(t require.TestingT, doc *sbom.Document, path string) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		require.NoError(t, WriteGolden(path, doc))
+		return
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	golden, err := unmarshalGolden(data)
+	require.NoError(t, err)
+	RequireEqualDocuments(t, golden, doc)
+}
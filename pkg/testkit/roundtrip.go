@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package testkit
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/reader"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+// RoundTrip parses data, serializes the resulting document to format and
+// parses the output again. It returns the originally parsed document and the
+// one read back. The registered drivers of the reader and writer are used.
+func RoundTrip(data []byte, format formats.Format) (original, result *sbom.Document, err error) {
+	original, err = reader.New().ParseStream(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing document: %w", err)
+	}
+
+	result, err = RoundTripDocument(original, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	return original, result, nil
+}
+
+// RoundTripDocument serializes a document to format and parses it back
+func RoundTripDocument(doc *sbom.Document, format formats.Format) (*sbom.Document, error) {
+	var buf bytes.Buffer
+	if err := writer.New(writer.WithFormat(format)).WriteStream(doc, &buf); err != nil {
+		return nil, fmt.Errorf("serializing to %s: %w", format, err)
+	}
+
+	r := reader.New()
+	result, err := r.ParseStreamWithOptions(bytes.NewReader(buf.Bytes()), &reader.Options{
+		Format:             format,
+		UnserializeOptions: r.Options.UnserializeOptions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s output: %w", format, err)
+	}
+	return result, nil
+}
+
+// RequireRoundTrip asserts that parsing data, serializing it to format and
+// parsing it again yields an equal node list. Document metadata is not
+// compared as serializers regenerate fields such as the creation date.
+func RequireRoundTrip(t require.TestingT, data []byte, format formats.Format) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	original, result, err := RoundTrip(data, format)
+	require.NoError(t, err)
+	RequireEqualNodeLists(t, original.NodeList, result.NodeList)
+}
+
+// RequireCorpusRoundTrip runs RequireRoundTrip in a subtest for each
+// document of the format in fsys, serializing them back to the same format.
+func RequireCorpusRoundTrip(t *testing.T, fsys fs.FS, format formats.Format) {
+	t.Helper()
+	files, err := CorpusFiles(fsys, format)
+	require.NoError(t, err)
+	for _, path := range files {
+		t.Run(path, func(t *testing.T) {
+			data, err := fs.ReadFile(fsys, path)
+			require.NoError(t, err)
+			RequireRoundTrip(t, data, format)
+		})
+	}
+}
+
+// RequireCorpusGolden parses each document of the format in fsys and
+// compares it with its golden protobom file.
+func RequireCorpusGolden(t *testing.T, fsys fs.FS, format formats.Format) {
+	t.Helper()
+	files, err := CorpusFiles(fsys, format)
+	require.NoError(t, err)
+	for _, path := range files {
+		t.Run(path, func(t *testing.T) {
+			data, err := fs.ReadFile(fsys, path)
+			require.NoError(t, err)
+			doc, err := reader.New().ParseStream(bytes.NewReader(data))
+			require.NoError(t, err)
+			golden, err := ReadGolden(fsys, path+GoldenSuffix)
+			require.NoError(t, err)
+			RequireEqualDocuments(t, golden, doc)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2024 The Protobom Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testkit provides the helpers protobom uses to verify its format
+// drivers: a sample SBOM corpus, golden file assertions and round-trip
+// checks. Driver authors and integrators can use it to verify that parsing,
+// serializing and parsing again yields equal documents in their formats.
+package testkit
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// CompareNodeLists returns a description of the differences between two
+// node lists. An empty list means the node lists are equal.
+func CompareNodeLists(expected, actual *sbom.NodeList) []string {
+	diffs := []string{}
+	if expected == nil || actual == nil {
+		if expected != actual {
+			diffs = append(diffs, fmt.Sprintf("node list: expected %v, got %v", expected, actual))
+		}
+		return diffs
+	}
+	if expected.Equal(actual) {
+		return diffs
+	}
+
+	if len(expected.GetNodes()) != len(actual.GetNodes()) {
+		diffs = append(diffs, fmt.Sprintf("number of nodes: expected %d, got %d", len(expected.GetNodes()), len(actual.GetNodes())))
+	}
+	if len(expected.GetEdges()) != len(actual.GetEdges()) {
+		diffs = append(diffs, fmt.Sprintf("number of edges: expected %d, got %d", len(expected.GetEdges()), len(actual.GetEdges())))
+	}
+
+	roots, actualRoots := sortedCopy(expected.GetRootElements()), sortedCopy(actual.GetRootElements())
+	if fmt.Sprint(roots) != fmt.Sprint(actualRoots) {
+		diffs = append(diffs, fmt.Sprintf("root elements: expected %v, got %v", roots, actualRoots))
+	}
+
+	for _, n := range expected.GetNodes() {
+		n2 := actual.GetNodeByID(n.Id)
+		if n2 == nil {
+			diffs = append(diffs, fmt.Sprintf("node %q: missing", n.Id))
+			continue
+		}
+		if n.Checksum() != n2.Checksum() {
+			nd := n.Diff(n2)
+			diffs = append(diffs, fmt.Sprintf("node %q: added {%v} removed {%v}", n.Id, nd.Added, nd.Removed))
+		}
+	}
+	for _, n := range actual.GetNodes() {
+		if expected.GetNodeByID(n.Id) == nil {
+			diffs = append(diffs, fmt.Sprintf("node %q: unexpected", n.Id))
+		}
+	}
+
+	for _, e := range expected.GetEdges() {
+		e2 := actual.GetEdgeByType(e.From, e.Type)
+		if e2 == nil {
+			diffs = append(diffs, fmt.Sprintf("edge %s %s: missing", e.From, e.Type))
+			continue
+		}
+		if fmt.Sprint(sortedCopy(e.To)) != fmt.Sprint(sortedCopy(e2.To)) {
+			diffs = append(diffs, fmt.Sprintf("edge %s %s: expected %v, got %v", e.From, e.Type, sortedCopy(e.To), sortedCopy(e2.To)))
+		}
+	}
+
+	// Equal found a difference not described above, report it generically
+	if len(diffs) == 0 {
+		diffs = append(diffs, "node lists are not equal")
+	}
+	return diffs
+}
+
+// CompareMetadata returns the differences between the fields of the
+// document metadata that are checked by the golden file tests.
+func CompareMetadata(expected, actual *sbom.Metadata) []string {
+	diffs := []string{}
+	check := func(field string, e, a any) {
+		if fmt.Sprint(e) != fmt.Sprint(a) {
+			diffs = append(diffs, fmt.Sprintf("metadata %s: expected %v, got %v", field, e, a))
+		}
+	}
+	check("id", expected.GetId(), actual.GetId())
+	check("version", expected.GetVersion(), actual.GetVersion())
+	check("comment", expected.GetComment(), actual.GetComment())
+	check("date", expected.GetDate().AsTime(), actual.GetDate().AsTime())
+	check("document types", expected.GetDocumentTypes(), actual.GetDocumentTypes())
+	return diffs
+}
+
+// RequireEqualNodeLists fails the test if the node lists are not equal
+func RequireEqualNodeLists(t require.TestingT, expected, actual *sbom.NodeList) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	diffs := CompareNodeLists(expected, actual)
+	require.Empty(t, diffs, "node lists differ")
+}
+
+// RequireEqualDocuments fails the test if the node lists or the compared
+// metadata fields of the documents differ.
+func RequireEqualDocuments(t require.TestingT, expected, actual *sbom.Document) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	diffs := append(
+		CompareNodeLists(expected.GetNodeList(), actual.GetNodeList()),
+		CompareMetadata(expected.GetMetadata(), actual.GetMetadata())...,
+	)
+	require.Empty(t, diffs, "documents differ")
+}
+
+// sortedCopy returns a sorted copy of a string slice
+func sortedCopy(s []string) []string {
+	ret := append([]string{}, s...)
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,187 @@
+package testkit
+
+import (
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+var corpusFormats = []formats.Format{formats.CDX14JSON, formats.CDX15JSON, formats.SPDX23JSON}
+
+func TestCorpus(t *testing.T) {
+	for _, tc := range []struct {
+		format formats.Format
+		empty  bool
+	}{
+		{format: formats.CDX14JSON},
+		{format: formats.CDX15JSON},
+		{format: formats.SPDX23JSON},
+		{format: formats.CDX10JSON, empty: true},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			files, err := CorpusFiles(Corpus(), tc.format)
+			require.NoError(t, err)
+			if tc.empty {
+				require.Empty(t, files)
+				return
+			}
+			require.NotEmpty(t, files)
+		})
+	}
+}
+
+func TestCorpusGolden(t *testing.T) {
+	for _, f := range corpusFormats {
+		t.Run(string(f), func(t *testing.T) {
+			RequireCorpusGolden(t, Corpus(), f)
+		})
+	}
+}
+
+func TestCorpusRoundTrip(t *testing.T) {
+	for _, f := range corpusFormats {
+		t.Run(string(f), func(t *testing.T) {
+			RequireCorpusRoundTrip(t, Corpus(), f)
+		})
+	}
+}
+
+func TestCompareNodeLists(t *testing.T) {
+	nl := sbom.NewNodeList()
+	nl.AddRootNode(&sbom.Node{Id: "root", Name: "app"})
+	nl.AddNode(&sbom.Node{Id: "lib", Name: "lib", Version: "1.0"})
+	nl.AddEdge(&sbom.Edge{Type: sbom.Edge_dependsOn, From: "root", To: []string{"lib"}})
+
+	for _, tc := range []struct {
+		name     string
+		expected *sbom.NodeList
+		actual   func() *sbom.NodeList
+		diffs    []string
+	}{
+		{
+			name:     "equal",
+			expected: nl,
+			actual:   nl.Copy,
+			diffs:    []string{},
+		},
+		{
+			name:     "changed and extra nodes",
+			expected: nl,
+			actual: func() *sbom.NodeList {
+				changed := nl.Copy()
+				changed.GetNodeByID("lib").Version = "2.0"
+				changed.AddNode(&sbom.Node{Id: "extra"})
+				return changed
+			},
+			diffs: []string{"number of nodes", `node "lib"`, `node "extra": unexpected`},
+		},
+		{
+			name:     "missing edge target",
+			expected: nl,
+			actual: func() *sbom.NodeList {
+				changed := nl.Copy()
+				changed.Edges[0].To = []string{}
+				return changed
+			},
+			diffs: []string{"edge root dependsOn: expected [lib], got []"},
+		},
+		{
+			name:     "nil node list",
+			expected: nl,
+			actual:   func() *sbom.NodeList { return nil },
+			diffs:    []string{"node list: expected"},
+		},
+		{
+			name:   "both nil",
+			actual: func() *sbom.NodeList { return nil },
+			diffs:  []string{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			diffs := CompareNodeLists(tc.expected, tc.actual())
+			require.Len(t, diffs, len(tc.diffs), diffs)
+			for i, d := range tc.diffs {
+				require.Contains(t, diffs[i], d)
+			}
+		})
+	}
+}
+
+func TestRequireGolden(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		// update is the value of the golden file update variable
+		update string
+		// exists writes the golden file before the test
+		exists bool
+	}{
+		{name: "create", update: "1"},
+		{name: "update", update: "1", exists: true},
+		{name: "compare", update: "", exists: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "test"
+			doc.NodeList.AddRootNode(&sbom.Node{Id: "root", Name: "app"})
+
+			path := filepath.Join(t.TempDir(), "doc.proto")
+			if tc.exists {
+				require.NoError(t, WriteGolden(path, doc))
+			}
+			t.Setenv(UpdateGoldenEnv, tc.update)
+			RequireGolden(t, doc, path)
+			require.FileExists(t, path)
+		})
+	}
+}
+
+func TestRoundTripFormats(t *testing.T) {
+	for _, tc := range []struct {
+		format   formats.Format
+		expected bool
+	}{
+		{format: formats.SPDX23JSON, expected: true},
+		{format: formats.CDX15JSON, expected: true},
+		{format: formats.HTMLReport, expected: false},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			require.Equal(t, tc.expected, slices.Contains(RoundTripFormats(), tc.format))
+		})
+	}
+}
+
+func TestCheckConformance(t *testing.T) {
+	data, err := fs.ReadFile(Corpus(), "cyclonedx/1.5/json/bom-1.5.json")
+	require.NoError(t, err)
+	doc, _, err := RoundTrip(data, formats.CDX15JSON)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name        string
+		format      formats.Format
+		status      ConversionStatus
+		differences bool
+		mustErr     bool
+	}{
+		{name: "lossless", format: formats.CDX15JSON, status: StatusLossless},
+		{name: "lossy", format: formats.SPDX23JSON, status: StatusLossy, differences: true},
+		{name: "unknown format", format: formats.Format("text/unknown"), status: StatusFailed, mustErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			report := CheckConformance(doc, &ConformanceOptions{Formats: []formats.Format{tc.format}})
+			require.Len(t, report.Results, 1)
+			res := report.Results[0]
+			require.Equal(t, tc.format, res.Format)
+			require.Equal(t, tc.status, res.Status, res.Differences)
+			require.Equal(t, tc.differences, len(res.Differences) > 0)
+			require.Equal(t, tc.mustErr, res.Error != "")
+			require.Equal(t, 1, report.Count(tc.status))
+		})
+	}
+}
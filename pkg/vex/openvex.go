@@ -0,0 +1,276 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+// Package vex exports the vulnerability analyses recorded in protobom
+// documents as OpenVEX documents.
+package vex
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Context is the JSON-LD context of the OpenVEX documents produced
+const Context = "https://openvex.dev/ns/v0.2.0"
+
+// DefaultAuthor is the author of the documents when none is set
+const DefaultAuthor = "Unknown Author"
+
+// Document is an OpenVEX document
+type Document struct {
+	Context    string       `json:"@context"`
+	ID         string       `json:"@id"`
+	Author     string       `json:"author"`
+	Timestamp  *time.Time   `json:"timestamp,omitempty"`
+	Version    int          `json:"version"`
+	Tooling    string       `json:"tooling,omitempty"`
+	Statements []*Statement `json:"statements"`
+}
+
+// Statement records the status of products with respect to a vulnerability
+type Statement struct {
+	Vulnerability   Vulnerability  `json:"vulnerability"`
+	Timestamp       *time.Time     `json:"timestamp,omitempty"`
+	LastUpdated     *time.Time     `json:"last_updated,omitempty"`
+	Products        []*Product     `json:"products"`
+	Status          sbom.VEXStatus `json:"status"`
+	StatusNotes     string         `json:"status_notes,omitempty"`
+	Justification   Justification  `json:"justification,omitempty"`
+	ImpactStatement string         `json:"impact_statement,omitempty"`
+	ActionStatement string         `json:"action_statement,omitempty"`
+}
+
+// Vulnerability identifies the vulnerability of a statement
+type Vulnerability struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+}
+
+// Product is a software component a statement applies to
+type Product struct {
+	ID          string            `json:"@id"`
+	Identifiers map[string]string `json:"identifiers,omitempty"`
+	Hashes      map[string]string `json:"hashes,omitempty"`
+}
+
+// Justification is the reason a product is not affected by a vulnerability
+type Justification string
+
+const (
+	ComponentNotPresent                         Justification = "component_not_present"
+	VulnerableCodeNotPresent                    Justification = "vulnerable_code_not_present"
+	VulnerableCodeNotInExecutePath              Justification = "vulnerable_code_not_in_execute_path"
+	VulnerableCodeCannotBeControlledByAdversary Justification = "vulnerable_code_cannot_be_controlled_by_adversary"
+	InlineMitigationsAlreadyExist               Justification = "inline_mitigations_already_exist"
+)
+
+// justifications maps the analysis justifications to the closest OpenVEX
+// justification. OpenVEX has fewer, so some of them share one.
+var justifications = map[sbom.Vulnerability_Analysis_Justification]Justification{
+	sbom.Vulnerability_Analysis_CODE_NOT_PRESENT:                VulnerableCodeNotPresent,
+	sbom.Vulnerability_Analysis_CODE_NOT_REACHABLE:              VulnerableCodeNotInExecutePath,
+	sbom.Vulnerability_Analysis_REQUIRES_CONFIGURATION:          VulnerableCodeCannotBeControlledByAdversary,
+	sbom.Vulnerability_Analysis_REQUIRES_ENVIRONMENT:            VulnerableCodeCannotBeControlledByAdversary,
+	sbom.Vulnerability_Analysis_REQUIRES_DEPENDENCY:             ComponentNotPresent,
+	sbom.Vulnerability_Analysis_PROTECTED_BY_COMPILER:           InlineMitigationsAlreadyExist,
+	sbom.Vulnerability_Analysis_PROTECTED_AT_RUNTIME:            InlineMitigationsAlreadyExist,
+	sbom.Vulnerability_Analysis_PROTECTED_AT_PERIMETER:          InlineMitigationsAlreadyExist,
+	sbom.Vulnerability_Analysis_PROTECTED_BY_MITIGATING_CONTROL: InlineMitigationsAlreadyExist,
+}
+
+// JustificationFor returns the OpenVEX justification of an analysis
+// justification, empty if it has none.
+func JustificationFor(j sbom.Vulnerability_Analysis_Justification) Justification {
+	return justifications[j]
+}
+
+// Options configures the OpenVEX documents produced
+type Options struct {
+	ID        string
+	Author    string
+	Tooling   string
+	Timestamp time.Time
+}
+
+// Option sets an option of the export
+type Option func(*Options)
+
+// WithID sets the IRI identifying the OpenVEX document
+func WithID(id string) Option {
+	return func(o *Options) { o.ID = id }
+}
+
+// WithAuthor sets the author of the OpenVEX document
+func WithAuthor(author string) Option {
+	return func(o *Options) { o.Author = author }
+}
+
+// WithTooling sets the tool recorded as producing the document
+func WithTooling(tooling string) Option {
+	return func(o *Options) { o.Tooling = tooling }
+}
+
+// WithTimestamp sets the issue date of the document, now by default
+func WithTimestamp(t time.Time) Option {
+	return func(o *Options) { o.Timestamp = t }
+}
+
+// FromDocument builds the OpenVEX document of the vulnerability analyses in
+// a protobom document. Each vulnerability produces a statement for each
+// status of the nodes it affects. Vulnerabilities without an analysis for a
+// node are left out, as their status is unknown.
+func FromDocument(doc *sbom.Document, opts ...Option) *Document {
+	o := &Options{
+		Author:    DefaultAuthor,
+		Timestamp: time.Now().UTC(),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.ID == "" && doc.GetMetadata().GetId() != "" {
+		o.ID = doc.GetMetadata().GetId() + "#vex"
+	}
+
+	ts := o.Timestamp
+	vex := &Document{
+		Context:    Context,
+		ID:         o.ID,
+		Author:     o.Author,
+		Timestamp:  &ts,
+		Version:    1,
+		Tooling:    o.Tooling,
+		Statements: []*Statement{},
+	}
+
+	for _, v := range doc.GetVulnerabilities() {
+		// Group the nodes sharing the same analysis in one statement
+		var groups []*Statement
+		analyses := []*sbom.Vulnerability_Analysis{}
+		for _, id := range v.GetAffects() {
+			a := v.AnalysisFor(id)
+			if a.GetState().VEXStatus() == "" {
+				continue
+			}
+			i := slices.IndexFunc(analyses, func(b *sbom.Vulnerability_Analysis) bool { return a == b })
+			if i < 0 {
+				analyses = append(analyses, a)
+				groups = append(groups, newStatement(v, a))
+				i = len(groups) - 1
+			}
+			groups[i].Products = append(groups[i].Products, newProduct(doc.GetNodeList().GetNodeByID(id), id))
+		}
+		vex.Statements = append(vex.Statements, groups...)
+	}
+	return vex
+}
+
+// newStatement returns a statement without products of a vulnerability
+func newStatement(v *sbom.Vulnerability, a *sbom.Vulnerability_Analysis) *Statement {
+	s := &Statement{
+		Vulnerability: Vulnerability{
+			Name:        v.GetId(),
+			Description: v.GetDescription(),
+			Aliases:     v.GetAliases(),
+		},
+		Products: []*Product{},
+		Status:   a.GetState().VEXStatus(),
+	}
+	if a.GetFirstIssued() != nil {
+		t := a.GetFirstIssued().AsTime()
+		s.Timestamp = &t
+	}
+	if a.GetLastUpdated() != nil {
+		t := a.GetLastUpdated().AsTime()
+		s.LastUpdated = &t
+	}
+	switch s.Status {
+	case sbom.VEXStatusNotAffected:
+		s.Justification = JustificationFor(a.GetJustification())
+		s.ImpactStatement = a.GetDetail()
+		// OpenVEX requires a justification or an impact statement
+		if s.Justification == "" && s.ImpactStatement == "" && a.GetState() == sbom.Vulnerability_Analysis_FALSE_POSITIVE {
+			s.ImpactStatement = "false positive"
+		}
+	case sbom.VEXStatusAffected:
+		s.ActionStatement = v.GetRecommendation()
+		s.StatusNotes = a.GetDetail()
+	default:
+		s.StatusNotes = a.GetDetail()
+	}
+	return s
+}
+
+// newProduct returns the product of a node, identified by its package URL
+// when it has one.
+func newProduct(n *sbom.Node, id string) *Product {
+	p := &Product{ID: id}
+	if n == nil {
+		return p
+	}
+	if purl := n.Purl(); purl != "" {
+		p.ID = string(purl)
+		p.Identifiers = map[string]string{"purl": string(purl)}
+	}
+	for t, cpe := range map[sbom.SoftwareIdentifierType]string{
+		sbom.SoftwareIdentifierType_CPE22: "cpe22",
+		sbom.SoftwareIdentifierType_CPE23: "cpe23",
+	} {
+		if v := n.GetIdentifiers()[int32(t)]; v != "" {
+			if p.Identifiers == nil {
+				p.Identifiers = map[string]string{}
+			}
+			p.Identifiers[cpe] = v
+		}
+	}
+	for algo, h := range n.GetHashes() {
+		if p.Hashes == nil {
+			p.Hashes = map[string]string{}
+		}
+		p.Hashes[openVEXAlgorithm(sbom.HashAlgorithm(algo))] = h
+	}
+	return p
+}
+
+// openVEXAlgorithm returns the OpenVEX name of a hash algorithm
+func openVEXAlgorithm(algo sbom.HashAlgorithm) string {
+	switch algo {
+	case sbom.HashAlgorithm_SHA1:
+		return "sha1"
+	case sbom.HashAlgorithm_SHA256:
+		return "sha-256"
+	case sbom.HashAlgorithm_SHA384:
+		return "sha-384"
+	case sbom.HashAlgorithm_SHA512:
+		return "sha-512"
+	case sbom.HashAlgorithm_SHA3_256:
+		return "sha3-256"
+	case sbom.HashAlgorithm_SHA3_384:
+		return "sha3-384"
+	case sbom.HashAlgorithm_SHA3_512:
+		return "sha3-512"
+	case sbom.HashAlgorithm_MD5:
+		return "md5"
+	default:
+		return strings.ToLower(algo.String())
+	}
+}
+
+// Write encodes the OpenVEX document of doc as JSON in w
+func Write(w io.Writer, doc *sbom.Document, opts ...Option) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(FromDocument(doc, opts...)); err != nil {
+		return fmt.Errorf("encoding OpenVEX document: %w", err)
+	}
+	return nil
+}
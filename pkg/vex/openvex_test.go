@@ -0,0 +1,194 @@
+// --------------------------------------------------------------
+// SPDX-FileCopyrightText: Copyright © 2024 The Protobom Authors
+// SPDX-FileType: SOURCE
+// SPDX-License-Identifier: Apache-2.0
+// --------------------------------------------------------------
+
+package vex
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+func TestFromDocument(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name       string
+		vuln       *sbom.Vulnerability
+		analyses   map[string]*sbom.Vulnerability_Analysis
+		statements []*Statement
+	}{
+		{
+			name: "affected",
+			vuln: &sbom.Vulnerability{
+				Id:             "CVE-2024-0001",
+				Aliases:        []string{"GHSA-aaaa-bbbb-cccc"},
+				Recommendation: "Upgrade to 1.2.3",
+				Affects:        []string{"app", "tool"},
+			},
+			analyses: map[string]*sbom.Vulnerability_Analysis{
+				"": sbom.NewVEXAnalysis(sbom.VEXStatusAffected, 0, ""),
+			},
+			statements: []*Statement{{
+				Vulnerability:   Vulnerability{Name: "CVE-2024-0001", Aliases: []string{"GHSA-aaaa-bbbb-cccc"}},
+				Timestamp:       &now,
+				LastUpdated:     &now,
+				Status:          sbom.VEXStatusAffected,
+				ActionStatement: "Upgrade to 1.2.3",
+				Products: []*Product{
+					{
+						ID:          "pkg:golang/example.com/app@v1.0.0",
+						Identifiers: map[string]string{"purl": "pkg:golang/example.com/app@v1.0.0"},
+						Hashes:      map[string]string{"sha-256": "abc"},
+					},
+					{ID: "tool"},
+				},
+			}},
+		},
+		{
+			name: "not affected node",
+			vuln: &sbom.Vulnerability{Id: "CVE-2024-0001", Affects: []string{"tool"}},
+			analyses: map[string]*sbom.Vulnerability_Analysis{
+				"": sbom.NewVEXAnalysis(sbom.VEXStatusAffected, 0, ""),
+				"lib": sbom.NewVEXAnalysis(
+					sbom.VEXStatusNotAffected, sbom.Vulnerability_Analysis_CODE_NOT_REACHABLE, "never called",
+				),
+			},
+			statements: []*Statement{
+				{
+					Vulnerability: Vulnerability{Name: "CVE-2024-0001"},
+					Timestamp:     &now,
+					LastUpdated:   &now,
+					Status:        sbom.VEXStatusAffected,
+					Products:      []*Product{{ID: "tool"}},
+				},
+				{
+					Vulnerability:   Vulnerability{Name: "CVE-2024-0001"},
+					Timestamp:       &now,
+					LastUpdated:     &now,
+					Status:          sbom.VEXStatusNotAffected,
+					Justification:   VulnerableCodeNotInExecutePath,
+					ImpactStatement: "never called",
+					Products:        []*Product{{ID: "lib"}},
+				},
+			},
+		},
+		{
+			name:       "no analysis",
+			vuln:       &sbom.Vulnerability{Id: "CVE-2024-0002", Affects: []string{"app"}},
+			statements: []*Statement{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.NodeList.AddNode(&sbom.Node{
+				Id: "app", Name: "app",
+				Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:golang/example.com/app@v1.0.0"},
+				Hashes:      map[int32]string{int32(sbom.HashAlgorithm_SHA256): "abc"},
+			})
+			doc.NodeList.AddNode(&sbom.Node{Id: "lib", Name: "lib"})
+			doc.NodeList.AddNode(&sbom.Node{Id: "tool", Name: "tool"})
+			doc.AddVulnerability(tc.vuln)
+			// Set the document analysis first, the node ones override it
+			for _, node := range []string{"", "app", "lib", "tool"} {
+				if a, ok := tc.analyses[node]; ok {
+					require.NoError(t, doc.SetAnalysisAt(tc.vuln.Id, node, a, now))
+				}
+			}
+
+			vex := FromDocument(doc, WithTimestamp(now))
+			require.Equal(t, tc.statements, vex.Statements)
+		})
+	}
+}
+
+func TestFromDocumentOptions(t *testing.T) {
+	ts := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		name    string
+		docID   string
+		opts    []Option
+		id      string
+		author  string
+		tooling string
+	}{
+		{
+			name:   "defaults",
+			docID:  "urn:uuid:1234",
+			id:     "urn:uuid:1234#vex",
+			author: DefaultAuthor,
+		},
+		{
+			name:   "document without id",
+			author: DefaultAuthor,
+		},
+		{
+			name:    "all options",
+			docID:   "urn:uuid:1234",
+			opts:    []Option{WithID("https://example.com/vex-1"), WithAuthor("Jane Doe"), WithTooling("protobom")},
+			id:      "https://example.com/vex-1",
+			author:  "Jane Doe",
+			tooling: "protobom",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = tc.docID
+			vex := FromDocument(doc, append(tc.opts, WithTimestamp(ts))...)
+			require.Equal(t, Context, vex.Context)
+			require.Equal(t, tc.id, vex.ID)
+			require.Equal(t, tc.author, vex.Author)
+			require.Equal(t, tc.tooling, vex.Tooling)
+			require.Equal(t, ts, *vex.Timestamp)
+			require.Equal(t, 1, vex.Version)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		opts       []Option
+		id         string
+		statements int
+	}{
+		{
+			name:       "document id",
+			id:         "urn:uuid:1234#vex",
+			statements: 1,
+		},
+		{
+			name:       "custom id",
+			opts:       []Option{WithID("https://example.com/vex-1")},
+			id:         "https://example.com/vex-1",
+			statements: 1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			doc := sbom.NewDocument()
+			doc.Metadata.Id = "urn:uuid:1234"
+			doc.NodeList.AddNode(&sbom.Node{Id: "app", Name: "app"})
+			doc.AddVulnerability(&sbom.Vulnerability{Id: "CVE-2024-0001", Affects: []string{"app"}})
+			require.NoError(t, doc.SetAnalysisAt(
+				"CVE-2024-0001", "", sbom.NewVEXAnalysis(sbom.VEXStatusFixed, 0, ""), time.Now(),
+			))
+
+			var buf bytes.Buffer
+			require.NoError(t, Write(&buf, doc, tc.opts...))
+
+			res := map[string]any{}
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+			require.Equal(t, Context, res["@context"])
+			require.Equal(t, tc.id, res["@id"])
+			require.Equal(t, DefaultAuthor, res["author"])
+			require.Len(t, res["statements"], tc.statements)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package writer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// unsafePathChars matches the characters replaced in the values expanded
+// in the path templates
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._@+-]+`)
+
+// PathValues are the variables available to the WriteAll path templates
+type PathValues struct {
+	// Name of the document subject: the name of its first root node or the
+	// document name if there is none
+	Name string
+
+	// Version of the document subject, same as Name
+	Version string
+
+	// Fingerprint is the hex encoded sha256 digest of the document
+	Fingerprint string
+
+	// ID is the document identifier
+	ID string
+
+	// Index is the position of the document in the batch, starting at 1
+	Index int
+}
+
+// pathValues returns the template values of a document, sanitized to be
+// used as path elements
+func pathValues(doc *sbom.Document, index int) (*PathValues, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("computing document fingerprint: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	name, version := doc.GetMetadata().GetName(), doc.GetMetadata().GetVersion()
+	if roots := doc.GetRootNodes(); len(roots) > 0 {
+		if roots[0].GetName() != "" {
+			name = roots[0].GetName()
+		}
+		if roots[0].GetVersion() != "" {
+			version = roots[0].GetVersion()
+		}
+	}
+
+	return &PathValues{
+		Name:        sanitizePathElement(name),
+		Version:     sanitizePathElement(version),
+		Fingerprint: hex.EncodeToString(sum[:]),
+		ID:          sanitizePathElement(doc.GetMetadata().GetId()),
+		Index:       index,
+	}, nil
+}
+
+// sanitizePathElement replaces the characters of s that are not safe in a
+// file name so that values can't traverse directories
+func sanitizePathElement(s string) string {
+	s = unsafePathChars.ReplaceAllString(strings.TrimSpace(s), "_")
+	if strings.Trim(s, ".") == "" && s != "" {
+		return strings.Repeat("_", len(s))
+	}
+	return s
+}
+
+// WriteAll writes each document to its own file in format. The file paths
+// are expanded from pathTemplate, a text/template with the fields of
+// PathValues, eg "out/{{.Name}}-{{.Version}}.cdx.json". Missing directories
+// are created.
+//
+// All paths are expanded before writing and WriteAll fails without writing
+// any file if two documents expand to the same path. It returns the paths
+// written, in the order of the documents.
+func (w *Writer) WriteAll(docs []*sbom.Document, pathTemplate string, format formats.Format) ([]string, error) {
+	tmpl, err := template.New("path").Option("missingkey=error").Parse(pathTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("parsing path template: %w", err)
+	}
+
+	paths := make([]string, len(docs))
+	seen := map[string]int{}
+	for i, doc := range docs {
+		if doc == nil {
+			return nil, fmt.Errorf("document #%d is nil", i+1)
+		}
+		values, err := pathValues(doc, i+1)
+		if err != nil {
+			return nil, fmt.Errorf("document #%d: %w", i+1, err)
+		}
+		var sb strings.Builder
+		if err := tmpl.Execute(&sb, values); err != nil {
+			return nil, fmt.Errorf("expanding path of document #%d: %w", i+1, err)
+		}
+		p := filepath.Clean(sb.String())
+		if j, ok := seen[p]; ok {
+			return nil, fmt.Errorf("documents #%d and #%d both expand to %s", j, i+1, p)
+		}
+		seen[p] = i + 1
+		paths[i] = p
+	}
+
+	o := *w.Options
+	o.Format = format
+	for i, doc := range docs {
+		if err := os.MkdirAll(filepath.Dir(paths[i]), 0o755); err != nil {
+			return paths[:i], fmt.Errorf("creating directory for document #%d: %w", i+1, err)
+		}
+		if err := w.WriteFileWithOptions(doc, paths[i], &o); err != nil {
+			return paths[:i], fmt.Errorf("writing document #%d to %s: %w", i+1, paths[i], err)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,140 @@
+package writer_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/protobom/protobom/pkg/formats"
+	drivers "github.com/protobom/protobom/pkg/native/serializers"
+	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/writer"
+)
+
+func batchDocument(id, name, version string) *sbom.Document {
+	doc := sbom.NewDocument()
+	doc.Metadata.Id = id
+	doc.NodeList.AddRootNode(&sbom.Node{Id: id + "-root", Name: name, Version: version})
+	return doc
+}
+
+func TestWriteAll(t *testing.T) {
+	writer.RegisterSerializer(formats.CDX16JSON, drivers.NewCDX("1.6", formats.JSON))
+	docs := []*sbom.Document{
+		batchDocument("doc-1", "frontend", "1.0.0"),
+		batchDocument("doc-2", "@acme/api", "2.1.0"),
+	}
+
+	for _, tc := range []struct {
+		name     string
+		template string
+		docs     []*sbom.Document
+		expected []string
+		mustErr  bool
+	}{
+		{
+			name:     "name and version",
+			template: "{{.Name}}/{{.Version}}.cdx.json",
+			docs:     docs,
+			expected: []string{"frontend/1.0.0.cdx.json", "@acme_api/2.1.0.cdx.json"},
+		},
+		{
+			name:     "index and id",
+			template: "{{.Index}}-{{.ID}}.json",
+			docs:     docs,
+			expected: []string{"1-doc-1.json", "2-doc-2.json"},
+		},
+		{
+			name:     "traversal is sanitized",
+			template: "{{.Name}}.json",
+			docs:     []*sbom.Document{batchDocument("doc", "..", "")},
+			expected: []string{"__.json"},
+		},
+		{
+			name:     "colliding paths",
+			template: "sbom.json",
+			docs:     docs,
+			mustErr:  true,
+		},
+		{
+			name:     "unknown variable",
+			template: "{{.Supplier}}.json",
+			docs:     docs,
+			mustErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			paths, err := writer.New().WriteAll(tc.docs, filepath.Join(dir, tc.template), formats.CDX16JSON)
+			if tc.mustErr {
+				require.Error(t, err)
+				entries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				require.Empty(t, entries)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, paths, len(tc.expected))
+			for i, p := range tc.expected {
+				require.Equal(t, filepath.Join(dir, p), paths[i])
+				data, err := os.ReadFile(paths[i])
+				require.NoError(t, err)
+				require.Contains(t, string(data), `"bomFormat": "CycloneDX"`)
+			}
+		})
+	}
+}
+
+func TestWriteAllFingerprint(t *testing.T) {
+	writer.RegisterSerializer(formats.CDX16JSON, drivers.NewCDX("1.6", formats.JSON))
+	for _, tc := range []struct {
+		name    string
+		docs    []*sbom.Document
+		mustErr bool
+	}{
+		{
+			name: "different ids",
+			docs: []*sbom.Document{
+				batchDocument("doc-1", "app", "1.0.0"),
+				batchDocument("doc-2", "app", "1.0.0"),
+			},
+		},
+		{
+			name: "different versions",
+			docs: []*sbom.Document{
+				batchDocument("doc", "app", "1.0.0"),
+				batchDocument("doc", "app", "1.0.1"),
+			},
+		},
+		{
+			name: "same document",
+			docs: []*sbom.Document{
+				batchDocument("doc", "app", "1.0.0"),
+				batchDocument("doc", "app", "1.0.0"),
+			},
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			paths, err := writer.New().WriteAll(tc.docs, filepath.Join(dir, "{{.Fingerprint}}.json"), formats.CDX16JSON)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, paths, len(tc.docs))
+			require.NotEqual(t, paths[0], paths[1])
+			for _, p := range paths {
+				require.Len(t, filepath.Base(p), 64+len(".json"))
+			}
+
+			// The fingerprint is stable for the same document
+			again, err := writer.New().WriteAll(tc.docs[:1], filepath.Join(dir, "{{.Fingerprint}}.json"), formats.CDX16JSON)
+			require.NoError(t, err)
+			require.Equal(t, paths[:1], again)
+		})
+	}
+}
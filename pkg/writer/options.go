@@ -8,6 +8,7 @@ import (
 	"github.com/protobom/protobom/pkg/mod"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/telemetry"
 )
 
 type WriterOption func(*Writer)
@@ -84,7 +85,16 @@ type Options struct {
 	RenderOptions    *native.RenderOptions
 	SerializeOptions *native.SerializeOptions
 	StoreOptions     *storage.StoreOptions
-	formatOptions    map[string]interface{}
+
+	// Profile checks the documents have the fields it requires before
+	// writing them. It is set by WithProfile.
+	Profile *Profile
+
+	// Telemetry instruments the writing of documents with spans and
+	// metrics. Instrumentation is disabled when nil.
+	Telemetry *telemetry.Telemetry
+
+	formatOptions map[string]interface{}
 }
 
 // argToOptsKeyVal returns a key value to access the options dictionary by using
@@ -117,3 +127,10 @@ func (o *Options) SetFormatOptions(key, opts interface{}) {
 	}
 	o.formatOptions[keyVal] = opts
 }
+
+// WithTelemetry instruments the writer with the tracer and meter in t
+func WithTelemetry(t *telemetry.Telemetry) WriterOption {
+	return func(w *Writer) {
+		w.Options.Telemetry = t
+	}
+}
@@ -0,0 +1,218 @@
+package writer
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/mod"
+	"github.com/protobom/protobom/pkg/native"
+	drivers "github.com/protobom/protobom/pkg/native/serializers"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Names of the built-in profiles
+const (
+	ProfileMinimal      = "minimal"
+	ProfileFull         = "full"
+	ProfileFDAPremarket = "fda-premarket"
+	ProfileNISTSSDF     = "nist-ssdf"
+)
+
+// Profile bundles the writer options expected by a regulator or a use case:
+// the output format, the fields emitted, the fields every document must
+// have and the format options.
+type Profile struct {
+	// Name identifies the profile, eg "fda-premarket"
+	Name string
+
+	// Description explains what the profile is for
+	Description string
+
+	// Format is the format written by the profile
+	Format formats.Format
+
+	// Indent is the number of spaces used to indent the output, zero
+	// minifies it.
+	Indent int
+
+	// Mods are enabled in the serializer
+	Mods []mod.Mod
+
+	// FieldMask and ExcludeFieldMask are the document field paths emitted
+	// and left out, see native.SerializeOptions.
+	FieldMask        []string
+	ExcludeFieldMask []string
+
+	// RequiredMetadataFields are the metadata fields every document must
+	// have, by their protobuf names, eg "authors".
+	RequiredMetadataFields []string
+
+	// RequiredNodeFields are the fields every node must have, by their
+	// protobuf names, eg "suppliers".
+	RequiredNodeFields []string
+
+	// FormatOptions are the serializer options, keyed by driver as in
+	// WithFormatOptions.
+	FormatOptions map[string]any
+}
+
+var profiles = sync.Map{}
+
+func init() {
+	for _, p := range []*Profile{
+		{
+			Name:        ProfileMinimal,
+			Description: "Compact CycloneDX inventory listing the components names, versions and identifiers",
+			Format:      formats.CDX16JSON,
+			FieldMask: []string{
+				"metadata.id", "metadata.version", "metadata.date", "metadata.tools",
+				"node_list.nodes.name", "node_list.nodes.version", "node_list.nodes.identifiers",
+				"node_list.nodes.primary_purpose",
+			},
+			RequiredNodeFields: []string{"name"},
+		},
+		{
+			Name:        ProfileFull,
+			Description: "CycloneDX document with all the data protobom can express",
+			Format:      formats.CDX16JSON,
+			Indent:      2,
+		},
+		{
+			Name: ProfileFDAPremarket,
+			Description: "CycloneDX document with the NTIA minimum elements and the end of support " +
+				"dates required in FDA premarket cybersecurity submissions",
+			Format:                 formats.CDX16JSON,
+			Indent:                 2,
+			RequiredMetadataFields: []string{"authors", "date"},
+			RequiredNodeFields:     []string{"name", "version", "suppliers", "identifiers", "valid_until_date"},
+			FormatOptions: map[string]any{
+				argToOptsKeyVal(&drivers.CDX{}): drivers.CDXOptions{GenerateSerialNumber: true},
+			},
+		},
+		{
+			Name: ProfileNISTSSDF,
+			Description: "SPDX document with the provenance and integrity data expected by the " +
+				"NIST Secure Software Development Framework",
+			Format:                 formats.SPDX23JSON,
+			Indent:                 2,
+			Mods:                   []mod.Mod{mod.SPDX_RENDER_PROPERTIES_IN_ANNOTATIONS},
+			RequiredMetadataFields: []string{"authors", "tools", "date"},
+			RequiredNodeFields:     []string{"name", "version", "suppliers", "identifiers", "hashes"},
+			FormatOptions: map[string]any{
+				argToOptsKeyVal(&drivers.SPDX23{}): drivers.SPDX23Options{
+					GenerateDocumentID:        true,
+					LicenseExpressionOperator: "AND",
+				},
+			},
+		},
+	} {
+		RegisterProfile(p)
+	}
+}
+
+// RegisterProfile adds a profile, replacing any profile with the same name
+func RegisterProfile(p *Profile) {
+	profiles.Store(p.Name, p)
+}
+
+// GetProfile returns the profile registered with the name
+func GetProfile(name string) (*Profile, error) {
+	p, ok := profiles.Load(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown writer profile %q", name)
+	}
+	return p.(*Profile), nil //nolint:forcetypeassert // Only profiles are stored
+}
+
+// ListProfiles returns the names of the registered profiles
+func ListProfiles() []string {
+	ret := []string{}
+	profiles.Range(func(key, _ any) bool {
+		ret = append(ret, key.(string)) //nolint:forcetypeassert // Keys are names
+		return true
+	})
+	slices.Sort(ret)
+	return ret
+}
+
+// WithProfile configures the writer with the options of a profile. The
+// documents are checked for the profile required fields before writing.
+func WithProfile(p *Profile) WriterOption {
+	return func(w *Writer) {
+		if p == nil {
+			return
+		}
+		// Copy the options to avoid modifying the shared defaults
+		o := *w.Options
+		o.formatOptions = maps.Clone(w.Options.formatOptions)
+		w.Options = &o
+
+		o.Profile = p
+		o.Format = p.Format
+		o.RenderOptions = &native.RenderOptions{Indent: p.Indent, Minify: p.Indent == 0}
+		so := &native.SerializeOptions{Mods: map[mod.Mod]struct{}{}}
+		if w.Options.SerializeOptions != nil {
+			*so = *w.Options.SerializeOptions
+			so.Mods = maps.Clone(w.Options.SerializeOptions.Mods)
+			if so.Mods == nil {
+				so.Mods = map[mod.Mod]struct{}{}
+			}
+		}
+		for _, m := range p.Mods {
+			so.Mods[m] = struct{}{}
+		}
+		so.FieldMask = nil
+		so.ExcludeFieldMask = nil
+		if len(p.FieldMask) > 0 {
+			so.FieldMask = &fieldmaskpb.FieldMask{Paths: slices.Clone(p.FieldMask)}
+		}
+		if len(p.ExcludeFieldMask) > 0 {
+			so.ExcludeFieldMask = &fieldmaskpb.FieldMask{Paths: slices.Clone(p.ExcludeFieldMask)}
+		}
+		o.SerializeOptions = so
+		for k, v := range p.FormatOptions {
+			o.SetFormatOptions(k, v)
+		}
+	}
+}
+
+// Check verifies that the document has the fields required by the profile.
+// The error lists all the missing fields.
+func (p *Profile) Check(doc *sbom.Document) error {
+	missing := missingFields(doc.GetMetadata().ProtoReflect(), p.RequiredMetadataFields)
+	if len(missing) > 0 {
+		missing = []string{"metadata: " + strings.Join(missing, ", ")}
+	}
+	for _, n := range doc.GetNodeList().GetNodes() {
+		if fields := missingFields(n.ProtoReflect(), p.RequiredNodeFields); len(fields) > 0 {
+			missing = append(missing, fmt.Sprintf("node %q: %s", n.GetId(), strings.Join(fields, ", ")))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"document does not meet the requirements of profile %q, missing %w",
+		p.Name, errors.New(strings.Join(missing, "; ")),
+	)
+}
+
+// missingFields returns the fields that are not set in the message
+func missingFields(m protoreflect.Message, fields []string) []string {
+	ret := []string{}
+	for _, name := range fields {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if fd == nil || !m.IsValid() || !m.Has(fd) {
+			ret = append(ret, name)
+		}
+	}
+	return ret
+}
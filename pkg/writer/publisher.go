@@ -0,0 +1,64 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/protobom/protobom/pkg/formats"
+	"github.com/protobom/protobom/pkg/sbom"
+)
+
+// Publisher uploads the documents written to the URIs of a scheme, such as
+// the tea:// references of the TEA client. WriteFile renders the documents
+// written to the URIs of the schemes with a registered publisher with the
+// writer options and hands the data to it.
+type Publisher interface {
+	Publish(ctx context.Context, uri string, format formats.Format, data []byte) error
+}
+
+var publishers sync.Map
+
+// RegisterPublisher registers the publisher of the URIs of a scheme,
+// replacing any previously registered one.
+func RegisterPublisher(scheme string, p Publisher) {
+	publishers.Store(scheme, p)
+}
+
+// UnregisterPublisher removes the publisher of a scheme
+func UnregisterPublisher(scheme string) {
+	publishers.Delete(scheme)
+}
+
+// publisherFor returns the publisher registered for the scheme of uri, nil
+// if uri has no scheme or its scheme has no publisher.
+func publisherFor(uri string) Publisher {
+	scheme, _, ok := strings.Cut(uri, "://")
+	if !ok {
+		return nil
+	}
+	p, ok := publishers.Load(scheme)
+	if !ok {
+		return nil
+	}
+	return p.(Publisher) //nolint:forcetypeassert
+}
+
+// publishWithOptions renders the document with the options and publishes
+// it to uri.
+func (w *Writer) publishWithOptions(p Publisher, bom *sbom.Document, uri string, o *Options) error {
+	var buf bytes.Buffer
+	if err := w.WriteStreamWithOptions(bom, &buf, o); err != nil {
+		return err
+	}
+	format := o.Format
+	if format == "" {
+		format = w.Options.Format
+	}
+	if err := p.Publish(context.Background(), uri, format, buf.Bytes()); err != nil {
+		return fmt.Errorf("publishing to %s: %w", uri, err)
+	}
+	return nil
+}
@@ -1,10 +1,13 @@
 package writer
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"os"
+	"slices"
 	"sync"
 
 	"github.com/protobom/protobom/pkg/formats"
@@ -12,6 +15,7 @@ import (
 	drivers "github.com/protobom/protobom/pkg/native/serializers"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/telemetry"
 )
 
 type Writer struct {
@@ -55,7 +59,15 @@ func ensureSerializersInitialized() {
 		serializers.Store(formats.CDX14JSON, drivers.NewCDX("1.4", formats.JSON))
 		serializers.Store(formats.CDX15JSON, drivers.NewCDX("1.5", formats.JSON))
 		serializers.Store(formats.CDX16JSON, drivers.NewCDX("1.6", formats.JSON))
+		serializers.Store(formats.CDX14XML, drivers.NewCDX("1.4", formats.XML))
+		serializers.Store(formats.CDX15XML, drivers.NewCDX("1.5", formats.XML))
+		serializers.Store(formats.CDX16XML, drivers.NewCDX("1.6", formats.XML))
 		serializers.Store(formats.SPDX23JSON, drivers.NewSPDX23())
+		serializers.Store(formats.SPDX23TV, drivers.NewSPDX23TV())
+		serializers.Store(formats.SPDX30JSON, drivers.NewSPDX3())
+		serializers.Store(formats.HTMLReport, drivers.NewHTML())
+		serializers.Store(formats.MarkdownReport, drivers.NewMarkdown())
+		serializers.Store(formats.GitHubSnapshotJSON, drivers.NewGitHubSnapshot())
 	})
 }
 
@@ -71,6 +83,20 @@ func UnregisterSerializer(format formats.Format) {
 	serializers.Delete(format)
 }
 
+// ListSerializers returns the formats with a registered serializer
+func ListSerializers() []formats.Format {
+	ensureSerializersInitialized()
+	ret := []formats.Format{}
+	serializers.Range(func(key, value any) bool {
+		if f, ok := key.(formats.Format); ok && value != nil {
+			ret = append(ret, f)
+		}
+		return true
+	})
+	slices.Sort(ret)
+	return ret
+}
+
 // GetFormatSerializer retrieves a serializer for the specified format.
 // It ensures that serializers are initialized before attempting to load the serializer for the given format.
 func GetFormatSerializer(format formats.Format) (native.Serializer, error) {
@@ -93,6 +119,26 @@ func GetFormatSerializer(format formats.Format) (native.Serializer, error) {
 
 // WriteStreamWithOptions writes an SBOM in a native format to the stream w using the options set o.
 func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.Writer, o *Options) error {
+	return w.writeStream(context.Background(), bom, wr, o)
+}
+
+// WriteStreamContext writes an SBOM in a native format to the stream w.
+// When telemetry is not set in the writer options, it is taken from ctx.
+// The write span is a child of the span in ctx.
+func (w *Writer) WriteStreamContext(ctx context.Context, bom *sbom.Document, wr io.Writer) error {
+	return w.writeStream(ctx, bom, wr, w.Options)
+}
+
+// writeStream writes bom to wr, instrumenting the operation with the
+// telemetry in the options or in ctx.
+func (w *Writer) writeStream(ctx context.Context, bom *sbom.Document, wr io.Writer, o *Options) (err error) {
+	t := o.Telemetry
+	if t == nil {
+		t = telemetry.FromContext(ctx)
+	}
+	_, op := t.Start(ctx, telemetry.SpanWriterWrite)
+	defer func() { op.End(err) }()
+
 	if bom == nil {
 		return fmt.Errorf("unable to write sbom to stream, SBOM is nil")
 	}
@@ -101,6 +147,7 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.Writer, o *Opt
 	if o.Format == "" {
 		format = w.Options.Format
 	}
+	op.SetAttributes(telemetry.String("format", string(format)))
 
 	serializer, err := GetFormatSerializer(format)
 	if err != nil {
@@ -112,6 +159,24 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.Writer, o *Opt
 		so = defaultOptions.SerializeOptions
 	}
 
+	// Apply the field masks to a copy of the document
+	if len(so.FieldMask.GetPaths()) > 0 || len(so.ExcludeFieldMask.GetPaths()) > 0 {
+		bom, err = bom.ApplyFieldMask(so.FieldMask, so.ExcludeFieldMask)
+		if err != nil {
+			return fmt.Errorf("applying field mask: %w", err)
+		}
+		// Serializers expect the document metadata, even if it was masked out
+		if bom.Metadata == nil {
+			bom.Metadata = &sbom.Metadata{}
+		}
+	}
+
+	if o.Profile != nil {
+		if err := o.Profile.Check(bom); err != nil {
+			return err
+		}
+	}
+
 	nativeDoc, err := serializer.Serialize(bom, so, o.GetFormatOptions(serializer))
 	if err != nil {
 		return fmt.Errorf("serializing SBOM to native format: %w", err)
@@ -128,21 +193,47 @@ func (w *Writer) WriteStreamWithOptions(bom *sbom.Document, wr io.Writer, o *Opt
 		sinks = append(sinks, l)
 	}
 	stream := io.MultiWriter(sinks...)
+	if ro.LineEnding != "" && ro.LineEnding != native.LineEndingLF {
+		if ro.LineEnding != native.LineEndingCRLF {
+			return fmt.Errorf("unsupported line ending %q", ro.LineEnding)
+		}
+		stream = &crlfWriter{w: stream}
+	}
 
-	if err := serializer.Render(nativeDoc, stream, ro, o.GetFormatOptions(serializer)); err != nil {
+	written := &countingWriter{w: stream}
+	if err := serializer.Render(nativeDoc, written, ro, o.GetFormatOptions(serializer)); err != nil {
 		return fmt.Errorf("writing rendered document to string: %w", err)
 	}
+	op.Count(telemetry.MetricBytesWritten, written.n)
+	op.Count(telemetry.MetricNodesWritten, int64(len(bom.GetNodeList().GetNodes())))
 
 	return nil
 }
 
+// countingWriter counts the bytes written through it
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
 func (w *Writer) WriteStream(bom *sbom.Document, wr io.Writer) error {
 	return w.WriteStreamWithOptions(bom, wr, w.Options)
 }
 
 // WriteFile takes an sbom.Document and writes it to the file at the specified
-// path. If the file exists it will be truncated.
+// path. If the file exists it will be truncated. Paths with a scheme that has
+// a registered Publisher, such as tea:// references, are published with it.
 func (w *Writer) WriteFileWithOptions(bom *sbom.Document, path string, o *Options) error {
+	if p := publisherFor(path); p != nil {
+		return w.publishWithOptions(p, bom, path, o)
+	}
+
 	f, err := os.Create(path)
 	if err != nil {
 		return err
@@ -179,3 +270,42 @@ func (w *Writer) StoreWithOptions(bom *sbom.Document, o *Options) error {
 
 	return nil
 }
+
+// crlfWriter converts the bare line feeds written to it to CRLF line endings,
+// leaving the existing CRLF sequences untouched.
+type crlfWriter struct {
+	w io.Writer
+
+	// cr records if the last byte written was a carriage return
+	cr bool
+}
+
+func (cw *crlfWriter) Write(p []byte) (int, error) {
+	out := make([]byte, 0, len(p)+bytes.Count(p, []byte("\n")))
+	for _, b := range p {
+		if b == '\n' && !cw.cr {
+			out = append(out, '\r')
+		}
+		out = append(out, b)
+		cw.cr = b == '\r'
+	}
+	if _, err := cw.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteDocumentStream writes a batch of protobom documents to a single
+// stream, using length-prefixed protobuf or JSON Lines encoding.
+func (w *Writer) WriteDocumentStream(docs []*sbom.Document, wr io.Writer, encoding sbom.StreamEncoding) error {
+	enc, err := sbom.NewDocumentEncoder(wr, encoding)
+	if err != nil {
+		return fmt.Errorf("creating document encoder: %w", err)
+	}
+	for i, doc := range docs {
+		if err := enc.Encode(doc); err != nil {
+			return fmt.Errorf("encoding document #%d: %w", i+1, err)
+		}
+	}
+	return nil
+}
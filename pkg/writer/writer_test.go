@@ -2,17 +2,24 @@ package writer_test
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"path"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/native"
 	"github.com/protobom/protobom/pkg/native/nativefakes"
+	drivers "github.com/protobom/protobom/pkg/native/serializers"
 	"github.com/protobom/protobom/pkg/sbom"
 	"github.com/protobom/protobom/pkg/storage"
+	"github.com/protobom/protobom/pkg/telemetry"
 	"github.com/protobom/protobom/pkg/writer"
 )
 
@@ -485,3 +492,262 @@ func TestStore(t *testing.T) {
 		})
 	}
 }
+
+func TestWriteStreamLineEndings(t *testing.T) {
+	bom := sbom.NewDocument()
+	bom.NodeList.AddRootNode(&sbom.Node{Id: "pkg", Name: "pkg"})
+
+	for _, tc := range []struct {
+		name     string
+		ending   string
+		rendered []string
+		expected string
+		mustErr  bool
+	}{
+		{"default", "", []string{"{\n  \"a\": 1\n}\n"}, "{\n  \"a\": 1\n}\n", false},
+		{"lf", native.LineEndingLF, []string{"{\n}\n"}, "{\n}\n", false},
+		{"crlf", native.LineEndingCRLF, []string{"{\n  \"a\": 1\n}\n"}, "{\r\n  \"a\": 1\r\n}\r\n", false},
+		{"existing crlf", native.LineEndingCRLF, []string{"<text>a\r\nb\nc</text>\n"}, "<text>a\r\nb\r\nc</text>\r\n", false},
+		{"crlf across writes", native.LineEndingCRLF, []string{"a\r", "\nb\n", "\n"}, "a\r\nb\r\n\r\n", false},
+		{"invalid", "\r", nil, "", true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &nativefakes.FakeSerializer{}
+			s.RenderStub = func(_ interface{}, w io.Writer, _ *native.RenderOptions, _ interface{}) error {
+				for _, chunk := range tc.rendered {
+					if _, err := w.Write([]byte(chunk)); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+			writer.RegisterSerializer(formats.CDX16JSON, s)
+
+			var b strings.Builder
+			err := writer.New().WriteStreamWithOptions(bom, &b, &writer.Options{
+				Format:           formats.CDX16JSON,
+				RenderOptions:    &native.RenderOptions{Indent: 2, LineEnding: tc.ending},
+				SerializeOptions: &native.SerializeOptions{},
+			})
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, b.String())
+		})
+	}
+}
+
+func TestWriteStreamFieldMask(t *testing.T) {
+	writer.RegisterSerializer(formats.CDX15JSON, drivers.NewCDX("1.5", "json"))
+	bom := sbom.NewDocument()
+	bom.Metadata.Id = "urn:uuid:6a3ee4e5-0e0c-4a3c-9b59-5b1e2b0f6f4d"
+	bom.Metadata.Name = "masked"
+	bom.NodeList.AddRootNode(&sbom.Node{Id: "pkg", Name: "pkg", Version: "1.0"})
+
+	for name, so := range map[string]*native.SerializeOptions{
+		"include":          {FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"node_list.nodes.name"}}},
+		"exclude metadata": {ExcludeFieldMask: &fieldmaskpb.FieldMask{Paths: []string{"metadata"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var b strings.Builder
+			require.NoError(t, writer.New().WriteStreamWithOptions(bom, &b, &writer.Options{
+				Format:           formats.CDX15JSON,
+				SerializeOptions: so,
+			}))
+			require.Contains(t, b.String(), `"name": "pkg"`)
+			require.NotContains(t, b.String(), "masked")
+			if so.FieldMask != nil {
+				require.Contains(t, b.String(), "6a3ee4e5-0e0c-4a3c-9b59-5b1e2b0f6f4d")
+				require.NotContains(t, b.String(), `"version": "1.0"`)
+			}
+		})
+	}
+}
+
+func TestWriteDocumentStream(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		docs     []*sbom.Document
+		encoding sbom.StreamEncoding
+		lines    []string
+		mustErr  bool
+	}{
+		{
+			name: "jsonl",
+			docs: []*sbom.Document{
+				{Metadata: &sbom.Metadata{}, NodeList: &sbom.NodeList{Nodes: []*sbom.Node{{Id: "a", Name: "a"}}, RootElements: []string{"a"}}},
+				{Metadata: &sbom.Metadata{}, NodeList: &sbom.NodeList{Nodes: []*sbom.Node{{Id: "b", Name: "b"}}, RootElements: []string{"b"}}},
+			},
+			encoding: sbom.StreamEncodingJSONL,
+			lines:    []string{`"id":"a"`, `"id":"b"`},
+		},
+		{
+			name:     "unknown encoding",
+			docs:     []*sbom.Document{sbom.NewDocument()},
+			encoding: "yaml",
+			mustErr:  true,
+		},
+		{
+			name:     "nil document",
+			docs:     []*sbom.Document{nil},
+			encoding: sbom.StreamEncodingProtobuf,
+			mustErr:  true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b strings.Builder
+			err := writer.New().WriteDocumentStream(tc.docs, &b, tc.encoding)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+			require.Len(t, lines, len(tc.lines))
+			for i, l := range tc.lines {
+				require.Contains(t, lines[i], l)
+			}
+		})
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	writer.RegisterSerializer(formats.CDX16JSON, drivers.NewCDX("1.6", "json"))
+	writer.RegisterSerializer(formats.SPDX23JSON, drivers.NewSPDX23())
+
+	require.Equal(t, []string{
+		writer.ProfileFDAPremarket, writer.ProfileFull, writer.ProfileMinimal, writer.ProfileNISTSSDF,
+	}, writer.ListProfiles())
+	_, err := writer.GetProfile("unknown")
+	require.Error(t, err)
+
+	newDocument := func() *sbom.Document {
+		bom := sbom.NewDocument()
+		bom.Metadata.Authors = []*sbom.Person{{Name: "ACME"}}
+		bom.Metadata.Tools = []*sbom.Tool{{Name: "builder"}}
+		bom.Metadata.Date = timestamppb.Now()
+		bom.NodeList.AddRootNode(&sbom.Node{
+			Id:          "pkg",
+			Name:        "pkg",
+			Version:     "1.0",
+			Suppliers:   []*sbom.Person{{Name: "ACME", IsOrg: true}},
+			Identifiers: map[int32]string{int32(sbom.SoftwareIdentifierType_PURL): "pkg:generic/pkg@1.0"},
+			Hashes:      map[int32]string{int32(sbom.HashAlgorithm_SHA256): strings.Repeat("a", 64)},
+			Description: "A package",
+		})
+		return bom
+	}
+
+	for _, tc := range []struct {
+		profile  string
+		prepare  func(*sbom.Document)
+		mustErr  bool
+		contains string
+		excludes string
+	}{
+		{profile: writer.ProfileMinimal, contains: `"name":"pkg"`, excludes: "A package"},
+		{profile: writer.ProfileFull, contains: "A package"},
+		{profile: writer.ProfileFDAPremarket, mustErr: true},
+		{
+			profile: writer.ProfileFDAPremarket,
+			prepare: func(bom *sbom.Document) {
+				bom.NodeList.Nodes[0].ValidUntilDate = timestamppb.Now()
+			},
+			contains: `"specVersion": "1.6"`,
+		},
+		{profile: writer.ProfileNISTSSDF, contains: `"spdxVersion": "SPDX-2.3"`},
+		{
+			profile: writer.ProfileNISTSSDF,
+			prepare: func(bom *sbom.Document) { bom.NodeList.Nodes[0].Hashes = nil },
+			mustErr: true,
+		},
+	} {
+		t.Run(tc.profile, func(t *testing.T) {
+			p, err := writer.GetProfile(tc.profile)
+			require.NoError(t, err)
+			bom := newDocument()
+			if tc.prepare != nil {
+				tc.prepare(bom)
+			}
+
+			w := writer.New(writer.WithProfile(p))
+			var b strings.Builder
+			err = w.WriteStream(bom, &b)
+			if tc.mustErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Contains(t, b.String(), tc.contains)
+			if tc.excludes != "" {
+				require.NotContains(t, b.String(), tc.excludes)
+			}
+		})
+	}
+
+	// The profiles don't change the default options
+	require.Nil(t, writer.New().Options.Profile)
+}
+
+func TestWriterTelemetry(t *testing.T) {
+	writer.RegisterSerializer(formats.CDX16JSON, drivers.NewCDX("1.6", "json"))
+
+	for _, tc := range []struct {
+		name string
+		sut  *sbom.Document
+		// inContext passes the telemetry in the context instead of the
+		// writer options
+		inContext bool
+		mustErr   bool
+		nodes     int64
+	}{
+		{
+			name: "options telemetry",
+			sut: func() *sbom.Document {
+				bom := sbom.NewDocument()
+				bom.NodeList.AddRootNode(&sbom.Node{Id: "pkg", Name: "pkg"})
+				bom.NodeList.AddNode(&sbom.Node{Id: "dep", Name: "dep"})
+				return bom
+			}(),
+			nodes: 2,
+		},
+		{
+			name:      "nil document",
+			inContext: true,
+			mustErr:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := telemetry.NewRecorder()
+			var b strings.Builder
+			var err error
+			if tc.inContext {
+				ctx := telemetry.ContextWithTelemetry(context.Background(), rec.Telemetry())
+				err = writer.New().WriteStreamContext(ctx, tc.sut, &b)
+			} else {
+				err = writer.New().WriteStreamWithOptions(tc.sut, &b, &writer.Options{
+					Format:           formats.CDX16JSON,
+					RenderOptions:    &native.RenderOptions{},
+					SerializeOptions: &native.SerializeOptions{},
+					Telemetry:        rec.Telemetry(),
+				})
+			}
+
+			span := rec.Span(telemetry.SpanWriterWrite)
+			require.NotNil(t, span)
+			require.True(t, span.Ended)
+			if tc.mustErr {
+				require.Error(t, err)
+				require.Len(t, span.Errors, 1)
+				return
+			}
+			require.NoError(t, err)
+			require.Empty(t, span.Errors)
+			require.Equal(t, string(formats.CDX16JSON), span.Attributes["format"])
+			require.Equal(t, int64(b.Len()), rec.Counter(telemetry.MetricBytesWritten))
+			require.Equal(t, tc.nodes, rec.Counter(telemetry.MetricNodesWritten))
+		})
+	}
+}
@@ -1,32 +1,32 @@
 package conformance
 
 import (
-	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"strings"
 	"testing"
 
-	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
-	"google.golang.org/protobuf/proto"
 
 	"github.com/protobom/protobom/pkg/formats"
 	"github.com/protobom/protobom/pkg/reader"
 	"github.com/protobom/protobom/pkg/sbom"
+	"github.com/protobom/protobom/pkg/testkit"
 )
 
 func TestUnserializeFormats(t *testing.T) {
+	testdata := os.DirFS("testdata")
 	for _, format := range formats.List {
-		files := findFiles(t, format)
+		files := findFiles(t, testdata, format)
 		r := reader.New()
 		for _, fname := range files {
 			t.Run(fname, func(t *testing.T) {
-				sut, err := r.ParseFile(fname)
+				sut, err := r.ParseFile(filepath.Join("testdata", fname))
 				require.NoError(t, err)
-				golden := readProtobom(t, fname+".proto")
-				t.Logf("sut: %s golden: %s", fname, fname+".proto")
+				golden, err := testkit.ReadGolden(testdata, fname+testkit.GoldenSuffix)
+				require.NoError(t, err)
+				t.Logf("sut: %s golden: %s", fname, fname+testkit.GoldenSuffix)
 				t.Run(
 					fmt.Sprintf("testNodes-%s-%s-%s", format.Type(), format.Version(), format.Encoding()),
 					func(t *testing.T) {
@@ -41,21 +41,10 @@ func TestUnserializeFormats(t *testing.T) {
 	}
 }
 
-func findFiles(t *testing.T, f formats.Format) []string {
-	ret := []string{}
-	dirName := filepath.Join("testdata", f.Type(), f.Version(), f.Encoding())
-	files, err := os.ReadDir(dirName)
-	if errors.Is(err, os.ErrNotExist) {
-		return ret
-	}
+func findFiles(t *testing.T, testdata fs.FS, f formats.Format) []string {
+	files, err := testkit.CorpusFiles(testdata, f)
 	require.NoError(t, err)
-	for _, fsentry := range files {
-		if strings.HasSuffix(fsentry.Name(), ".proto") {
-			continue
-		}
-		ret = append(ret, filepath.Join(dirName, fsentry.Name()))
-	}
-	return ret
+	return files
 }
 
 func testNodes(t *testing.T, golden, sut *sbom.Document) {
@@ -63,28 +52,13 @@ func testNodes(t *testing.T, golden, sut *sbom.Document) {
 }
 
 func testEqualNodeList(t *testing.T, golden, sut *sbom.Document) {
-	require.True(t, golden.NodeList.Equal(sut.NodeList), "equal nodelist", golden, sut)
-}
-
-func readProtobom(t *testing.T, path string) *sbom.Document {
-	data, err := os.ReadFile(path)
-	require.NoError(t, err)
-	bom := &sbom.Document{}
-	if err := proto.Unmarshal(data, bom); err != nil {
-		logrus.Fatal(fmt.Errorf("unmarshaling protobuf: %w", err))
-	}
-	return bom
+	testkit.RequireEqualNodeLists(t, golden.NodeList, sut.NodeList)
 }
 
 func testEdges(t *testing.T, golden, sut *sbom.Document) {
 	require.Len(t, golden.NodeList.Edges, len(sut.NodeList.Edges), "number of nodes")
 }
 
-// TODO(puerco): Implement comparison methods in the document metadata
 func testDocument(t *testing.T, golden, sut *sbom.Document) {
-	require.Equal(t, golden.Metadata.Comment, sut.Metadata.Comment)
-	require.Equal(t, golden.Metadata.Date, sut.Metadata.Date)
-	require.Equal(t, golden.Metadata.Id, sut.Metadata.Id)
-	require.Equal(t, golden.Metadata.DocumentTypes, sut.Metadata.DocumentTypes)
-	require.Equal(t, golden.Metadata.Version, sut.Metadata.Version)
+	require.Empty(t, testkit.CompareMetadata(golden.Metadata, sut.Metadata))
 }